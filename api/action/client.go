@@ -5,9 +5,12 @@ package action
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
 )
 
 // Client provides access to the action facade.
@@ -118,3 +121,28 @@ func (c *Client) ApplicationCharmActions(arg params.Entity) (map[string]params.A
 	}
 	return result.Actions, nil
 }
+
+// WatchActionProgress returns a watcher that reports the progress
+// messages logged by the action with the given id. Each string is a
+// JSON encoded params.ActionMessage.
+func (c *Client) WatchActionProgress(actionId string) (watcher.StringsWatcher, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("WatchActionProgress")
+	}
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewActionTag(actionId).String()}},
+	}
+	err := c.facade.FacadeCall("WatchActionsProgress", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result), nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"encoding/json"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type progressSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&progressSuite{})
+
+func (s *progressSuite) TestWatchActionProgress(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: s.Factory.MakeApplication(c, &factory.ApplicationParams{
+			Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"}),
+		}),
+		SetCharmURL: true,
+	})
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("first")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := s.client.WatchActionProgress(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		w.Kill()
+		c.Assert(w.Wait(), jc.ErrorIsNil)
+	}()
+
+	assertChange := func(expect string) {
+		s.State.StartSync()
+		select {
+		case changes, ok := <-w.Changes():
+			c.Assert(ok, jc.IsTrue)
+			c.Assert(changes, gc.HasLen, 1)
+			var message params.ActionMessage
+			err := json.Unmarshal([]byte(changes[0]), &message)
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(message.Message, gc.Equals, expect)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("watcher did not send change")
+		}
+	}
+	assertChange("first")
+
+	err = a.Log("second")
+	c.Assert(err, jc.ErrorIsNil)
+	assertChange("second")
+}
@@ -0,0 +1,63 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AddActionSchedules adds schedules on which actions are enqueued on
+// units repeatedly, returning the new schedule or an error for each.
+func (c *Client) AddActionSchedules(arg params.AddActionScheduleArgs) (params.ActionScheduleResults, error) {
+	results := params.ActionScheduleResults{}
+	if c.BestAPIVersion() < 4 {
+		return results, errors.NotSupportedf("scheduling actions")
+	}
+	err := c.facade.FacadeCall("AddActionSchedules", arg, &results)
+	return results, err
+}
+
+// ListActionSchedules returns all the action schedules in the model,
+// without their history.
+func (c *Client) ListActionSchedules() ([]params.ActionSchedule, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("scheduling actions")
+	}
+	results := params.ActionScheduleResults{}
+	if err := c.facade.FacadeCall("ListActionSchedules", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	schedules := make([]params.ActionSchedule, 0, len(results.Results))
+	for _, result := range results.Results {
+		if result.Error != nil {
+			return nil, errors.Trace(result.Error)
+		}
+		schedules = append(schedules, *result.Result)
+	}
+	return schedules, nil
+}
+
+// ActionSchedules returns the action schedules with the given ids,
+// including the history of their runs.
+func (c *Client) ActionSchedules(arg params.ActionScheduleIds) (params.ActionScheduleResults, error) {
+	results := params.ActionScheduleResults{}
+	if c.BestAPIVersion() < 4 {
+		return results, errors.NotSupportedf("scheduling actions")
+	}
+	err := c.facade.FacadeCall("ActionSchedules", arg, &results)
+	return results, err
+}
+
+// RemoveActionSchedules removes the action schedules with the given
+// ids, returning an error result for each.
+func (c *Client) RemoveActionSchedules(arg params.ActionScheduleIds) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if c.BestAPIVersion() < 4 {
+		return results, errors.NotSupportedf("scheduling actions")
+	}
+	err := c.facade.FacadeCall("RemoveActionSchedules", arg, &results)
+	return results, err
+}
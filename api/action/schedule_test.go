@@ -0,0 +1,59 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing/factory"
+)
+
+type scheduleSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func (s *scheduleSuite) TestActionSchedules(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: s.Factory.MakeApplication(c, &factory.ApplicationParams{
+			Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"}),
+		}),
+		SetCharmURL: true,
+	})
+
+	added, err := s.client.AddActionSchedules(params.AddActionScheduleArgs{
+		Schedules: []params.AddActionScheduleArg{{
+			Receiver: unit.Tag().String(),
+			Name:     "snapshot",
+			Cron:     "@daily",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added.Results, gc.HasLen, 1)
+	c.Assert(added.Results[0].Error, gc.IsNil)
+	id := added.Results[0].Result.Id
+
+	schedules, err := s.client.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 1)
+	c.Check(schedules[0].Id, gc.Equals, id)
+	c.Check(schedules[0].Receiver, gc.Equals, unit.Tag().String())
+	c.Check(schedules[0].Cron, gc.Equals, "@daily")
+
+	shown, err := s.client.ActionSchedules(params.ActionScheduleIds{Ids: []string{id}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(shown.Results, gc.HasLen, 1)
+	c.Check(shown.Results[0].Result.Name, gc.Equals, "snapshot")
+
+	removed, err := s.client.RemoveActionSchedules(params.ActionScheduleIds{Ids: []string{id}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed.OneError(), jc.ErrorIsNil)
+
+	schedules, err = s.client.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 0)
+}
@@ -0,0 +1,59 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler provides the client-side API used by the
+// action scheduler worker.
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+const actionSchedulerFacade = "ActionScheduler"
+
+// API provides access to the ActionScheduler API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side ActionScheduler facade.
+func NewAPI(caller base.APICaller) *API {
+	return &API{facade: base.NewFacadeCaller(caller, actionSchedulerFacade)}
+}
+
+// WatchActionSchedules returns a NotifyWatcher that notifies of changes
+// to the model's action schedules.
+func (api *API) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := api.facade.FacadeCall("WatchActionSchedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(api.facade.RawAPICaller(), result), nil
+}
+
+// RunDueActionSchedules enqueues the actions of all schedules that are
+// due, and returns when the next schedule will be due. The zero time is
+// returned if there are no schedules.
+func (api *API) RunDueActionSchedules() (time.Time, error) {
+	var result params.NextActionScheduleResult
+	if err := api.facade.FacadeCall("RunDueActionSchedules", nil, &result); err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return time.Time{}, result.Error
+	}
+	if result.NextRun == nil {
+		return time.Time{}, nil
+	}
+	return *result.NextRun, nil
+}
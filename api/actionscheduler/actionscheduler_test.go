@@ -0,0 +1,74 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionSchedulerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ActionSchedulerSuite{})
+
+func (s *ActionSchedulerSuite) TestRunDueActionSchedules(c *gc.C) {
+	next := time.Date(2019, 4, 1, 11, 0, 0, 0, time.UTC)
+	var called bool
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "ActionScheduler")
+		c.Check(request, gc.Equals, "RunDueActionSchedules")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.NextActionScheduleResult{})
+		*(result.(*params.NextActionScheduleResult)) = params.NextActionScheduleResult{NextRun: &next}
+		return nil
+	})
+	api := actionscheduler.NewAPI(apiCaller)
+	got, err := api.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(got.Equal(next), jc.IsTrue)
+}
+
+func (s *ActionSchedulerSuite) TestRunDueActionSchedulesNoSchedules(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.NextActionScheduleResult)) = params.NextActionScheduleResult{}
+		return nil
+	})
+	got, err := actionscheduler.NewAPI(apiCaller).RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.IsZero(), jc.IsTrue)
+}
+
+func (s *ActionSchedulerSuite) TestRunDueActionSchedulesError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.NextActionScheduleResult)) = params.NextActionScheduleResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	_, err := actionscheduler.NewAPI(apiCaller).RunDueActionSchedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ActionSchedulerSuite) TestWatchActionSchedulesError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "WatchActionSchedules")
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	_, err := actionscheduler.NewAPI(apiCaller).WatchActionSchedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -315,10 +315,28 @@ type SetCharmConfig struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]storage.Constraints `json:"storage-constraints,omitempty"`
+
+	// BatchSize, if positive, rolls the charm out to the application's
+	// units this many at a time. This field is only understood by
+	// Application facade version 12 and greater.
+	BatchSize int
+
+	// PauseOnError pauses a batched rollout when a unit fails, rather
+	// than stopping it.
+	PauseOnError bool
+
+	// LeaderLast upgrades the application's leader in the final batch
+	// of a batched rollout.
+	LeaderLast bool
 }
 
 // SetCharm sets the charm for a given application.
 func (c *Client) SetCharm(branchName string, cfg SetCharmConfig) error {
+	if cfg.BatchSize > 0 {
+		if apiVersion := c.BestAPIVersion(); apiVersion < 12 {
+			return errors.NotSupportedf("batched upgrades for Application facade v%v", apiVersion)
+		}
+	}
 	var storageConstraints map[string]params.StorageConstraints
 	if len(cfg.StorageConstraints) > 0 {
 		storageConstraints = make(map[string]params.StorageConstraints)
@@ -350,6 +368,9 @@ func (c *Client) SetCharm(branchName string, cfg SetCharmConfig) error {
 		ResourceIDs:        cfg.ResourceIDs,
 		StorageConstraints: storageConstraints,
 		Generation:         branchName,
+		BatchSize:          cfg.BatchSize,
+		PauseOnError:       cfg.PauseOnError,
+		LeaderLast:         cfg.LeaderLast,
 	}
 	return c.facade.FacadeCall("SetCharm", args, nil)
 }
@@ -730,10 +751,34 @@ func (c *Client) SetConstraints(application string, constraints constraints.Valu
 	return c.facade.FacadeCall("SetConstraints", args, nil)
 }
 
+// SetBranchConstraints specifies the constraints for the given application
+// under the branch with the input name. Only units tracking the branch
+// use these constraints until the branch is committed.
+func (c *Client) SetBranchConstraints(branchName, application string, constraints constraints.Value) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 14 {
+		return errors.NotSupportedf("setting constraints under a branch for Application facade v%v", apiVersion)
+	}
+	args := params.SetConstraints{
+		ApplicationName: application,
+		Constraints:     constraints,
+		Generation:      branchName,
+	}
+	return c.facade.FacadeCall("SetConstraints", args, nil)
+}
+
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
-func (c *Client) Expose(application string) error {
-	args := params.ApplicationExpose{ApplicationName: application}
+// were also explicitly marked by units as open. If exposedEndpoints is
+// not empty, access to the opened ports is restricted to the given
+// spaces and CIDRs, keyed by endpoint name; the empty endpoint name
+// applies to all endpoints.
+func (c *Client) Expose(application string, exposedEndpoints map[string]params.ExposedEndpoint) error {
+	if len(exposedEndpoints) > 0 && c.BestAPIVersion() < 10 {
+		return errors.New("this controller does not support exposing applications to specific spaces or CIDRs")
+	}
+	args := params.ApplicationExpose{
+		ApplicationName:  application,
+		ExposedEndpoints: exposedEndpoints,
+	}
 	return c.facade.FacadeCall("Expose", args, nil)
 }
 
@@ -965,3 +1010,73 @@ func (c *Client) ApplicationsInfo(applications []names.ApplicationTag) ([]params
 	}
 	return out.Results, nil
 }
+
+// UnitsState retrieves the key/value state stored by the charms of the
+// specified units.
+func (c *Client) UnitsState(units []names.UnitTag) ([]params.UnitStateResult, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 11 {
+		return nil, errors.NotSupportedf("UnitsState for Application facade v%v", apiVersion)
+	}
+	all := make([]params.Entity, len(units))
+	for i, one := range units {
+		all[i] = params.Entity{Tag: one.String()}
+	}
+	in := params.Entities{Entities: all}
+	var out params.UnitStateResults
+	err := c.facade.FacadeCall("UnitsState", in, &out)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resultsLen := len(out.Results); resultsLen != len(units) {
+		return nil, errors.Errorf("expected %d results, got %d", len(units), resultsLen)
+	}
+	return out.Results, nil
+}
+
+// CharmRollout returns the progress of the batched charm upgrade of
+// the specified application.
+func (c *Client) CharmRollout(application string) (*params.CharmRollout, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 12 {
+		return nil, errors.NotSupportedf("CharmRollouts for Application facade v%v", apiVersion)
+	}
+	if !names.IsValidApplication(application) {
+		return nil, errors.NotValidf("application name %q", application)
+	}
+	in := params.Entities{Entities: []params.Entity{
+		{Tag: names.NewApplicationTag(application).String()},
+	}}
+	var out params.CharmRolloutResults
+	if err := c.facade.FacadeCall("CharmRollouts", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resultsLen := len(out.Results); resultsLen != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", resultsLen)
+	}
+	if err := out.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return out.Results[0].Result, nil
+}
+
+// SetCharmRefreshPolicy sets the policy by which the charm of the
+// specified application is automatically upgraded to newer revisions
+// in the channel it tracks. A nil policy removes any existing policy.
+func (c *Client) SetCharmRefreshPolicy(application string, policy *params.CharmRefreshPolicy) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 13 {
+		return errors.NotSupportedf("SetCharmRefreshPolicy for Application facade v%v", apiVersion)
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.SetCharmRefreshPolicyArgs{
+		Args: []params.SetCharmRefreshPolicyArg{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Policy:         policy,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetCharmRefreshPolicy", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
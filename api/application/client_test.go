@@ -977,6 +977,47 @@ func (s *applicationSuite) TestGetConstraintsError(c *gc.C) {
 	c.Assert(results, gc.IsNil)
 }
 
+func (s *applicationSuite) TestSetBranchConstraints(c *gc.C) {
+	fooConstraints := constraints.MustParse("mem=4G")
+
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Assert(request, gc.Equals, "SetConstraints")
+				c.Assert(a, jc.DeepEquals, params.SetConstraints{
+					ApplicationName: "foo",
+					Constraints:     fooConstraints,
+					Generation:      newBranchName,
+				})
+				return nil
+			},
+		),
+		BestVersion: 14,
+	})
+
+	err := client.SetBranchConstraints(newBranchName, "foo", fooConstraints)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetBranchConstraintsNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 13,
+	})
+
+	err := client.SetBranchConstraints(newBranchName, "foo", constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "setting constraints under a branch for Application facade v13 not supported")
+}
+
 func (s *applicationSuite) TestGetConstraintsAPIv4(c *gc.C) {
 	fooConstraints := constraints.MustParse("mem=4G")
 	barConstraints := constraints.MustParse("mem=128G", "cores=64")
@@ -1469,3 +1510,219 @@ func (s *applicationSuite) TestApplicationsInfoResultMismatch(c *gc.C) {
 	c.Check(called, jc.IsTrue)
 	c.Assert(err, gc.ErrorMatches, "expected 2 results, got 3")
 }
+
+func (s *applicationSuite) TestExpose(c *gc.C) {
+	called := false
+	exposedEndpoints := map[string]params.ExposedEndpoint{
+		"website": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Application")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Expose")
+			c.Check(a, jc.DeepEquals, params.ApplicationExpose{
+				ApplicationName:  "foo",
+				ExposedEndpoints: exposedEndpoints,
+			})
+			return nil
+		},
+		BestVersion: 10,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.Expose("foo", exposedEndpoints)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestExposeSettingsNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call %q", request)
+		return nil
+	})
+	err := client.Expose("foo", map[string]params.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "this controller does not support exposing applications to specific spaces or CIDRs")
+}
+
+func (s *applicationSuite) TestUnitsStatePriorV11(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	_, err := client.UnitsState(nil)
+	c.Assert(err, gc.ErrorMatches, "UnitsState for Application facade v10 not supported")
+}
+
+func (s *applicationSuite) TestUnitsState(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "UnitsState")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "unit-foo-0"}, {Tag: "unit-bar-1"}},
+			})
+			result, ok := response.(*params.UnitStateResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.UnitStateResult{
+				{State: map[string]string{"key": "value"}},
+				{Error: &params.Error{Message: "boom"}},
+			}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	results, err := client.UnitsState([]names.UnitTag{
+		names.NewUnitTag("foo/0"),
+		names.NewUnitTag("bar/1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.UnitStateResult{
+		{State: map[string]string{"key": "value"}},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *applicationSuite) TestSetCharmBatchedPriorV12(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	err := client.SetCharm(model.GenerationMaster, application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+		BatchSize: 2,
+	})
+	c.Assert(err, gc.ErrorMatches, "batched upgrades for Application facade v8 not supported")
+}
+
+func (s *applicationSuite) TestSetCharmBatched(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "SetCharm")
+			args, ok := a.(params.ApplicationSetCharm)
+			c.Assert(ok, jc.IsTrue)
+			c.Assert(args.BatchSize, gc.Equals, 2)
+			c.Assert(args.PauseOnError, jc.IsTrue)
+			c.Assert(args.LeaderLast, jc.IsTrue)
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	err := client.SetCharm(model.GenerationMaster, application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+		BatchSize:    2,
+		PauseOnError: true,
+		LeaderLast:   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestCharmRolloutPriorV12(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	_, err := client.CharmRollout("foo")
+	c.Assert(err, gc.ErrorMatches, "CharmRollouts for Application facade v8 not supported")
+}
+
+func (s *applicationSuite) TestCharmRollout(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "CharmRollouts")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-foo"}},
+			})
+			result, ok := response.(*params.CharmRolloutResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.CharmRolloutResult{{
+				Result: &params.CharmRollout{Application: "foo", Status: "running"},
+			}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	rollout, err := client.CharmRollout("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(rollout, jc.DeepEquals, &params.CharmRollout{Application: "foo", Status: "running"})
+}
+
+func (s *applicationSuite) TestCharmRolloutError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			result := response.(*params.CharmRolloutResults)
+			result.Results = []params.CharmRolloutResult{{
+				Error: &params.Error{Message: "not found", Code: params.CodeNotFound},
+			}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	_, err := client.CharmRollout("foo")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *applicationSuite) TestSetCharmRefreshPolicyPriorV13(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	err := client.SetCharmRefreshPolicy("foo", nil)
+	c.Assert(err, gc.ErrorMatches, "SetCharmRefreshPolicy for Application facade v8 not supported")
+}
+
+func (s *applicationSuite) TestSetCharmRefreshPolicy(c *gc.C) {
+	policy := &params.CharmRefreshPolicy{
+		Window:    "0 2 * * SAT",
+		Duration:  2 * time.Hour,
+		PatchOnly: true,
+	}
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "SetCharmRefreshPolicy")
+			c.Assert(a, jc.DeepEquals, params.SetCharmRefreshPolicyArgs{
+				Args: []params.SetCharmRefreshPolicyArg{{
+					ApplicationTag: "application-foo",
+					Policy:         policy,
+				}},
+			})
+			result, ok := response.(*params.ErrorResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.ErrorResult{{
+				Error: &params.Error{Message: "boom"},
+			}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	err := client.SetCharmRefreshPolicy("foo", policy)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
@@ -30,8 +30,36 @@ func NewClient(st base.APICallCloser) *Client {
 	return &Client{ClientFacade: frontend, facade: backend}
 }
 
+// OfferOptions holds the optional settings for a new offer.
+type OfferOptions struct {
+	// RequireApproval is true if requests to consume the offer must be
+	// approved by an offer admin.
+	RequireApproval bool
+
+	// MaxConsumers is the maximum number of models which can consume
+	// the offer, or 0 for no limit.
+	MaxConsumers int
+
+	// MaxRelations is the maximum number of relations to the offer,
+	// or 0 for no limit.
+	MaxRelations int
+}
+
 // Offer prepares application's endpoints for consumption.
 func (c *Client) Offer(modelUUID, application string, endpoints []string, offerName string, desc string) ([]params.ErrorResult, error) {
+	return c.OfferWithOptions(modelUUID, application, endpoints, offerName, desc, OfferOptions{})
+}
+
+// OfferWithOptions prepares application's endpoints for consumption,
+// with the specified approval and connection limit options.
+func (c *Client) OfferWithOptions(
+	modelUUID, application string, endpoints []string, offerName string, desc string, opts OfferOptions,
+) ([]params.ErrorResult, error) {
+	if opts != (OfferOptions{}) {
+		if bestVer := c.BestAPIVersion(); bestVer < 3 {
+			return nil, errors.NotImplementedf("Offer() with approval or limits (need v3+, have v%d)", bestVer)
+		}
+	}
 	// TODO(wallyworld) - support endpoint aliases
 	ep := make(map[string]string)
 	for _, name := range endpoints {
@@ -44,6 +72,9 @@ func (c *Client) Offer(modelUUID, application string, endpoints []string, offerN
 			ApplicationDescription: desc,
 			Endpoints:              ep,
 			OfferName:              offerName,
+			RequireApproval:        opts.RequireApproval,
+			MaxConsumers:           opts.MaxConsumers,
+			MaxRelations:           opts.MaxRelations,
 		},
 	}
 	out := params.ErrorResults{}
@@ -286,6 +317,54 @@ func (c *Client) GetConsumeDetails(urlStr string) (params.ConsumeOfferDetails, e
 	}, nil
 }
 
+// OfferConnectionRequests returns the requests to consume the specified offer.
+func (c *Client) OfferConnectionRequests(offerURL string) ([]params.OfferConnectionRequest, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return nil, errors.NotImplementedf("OfferConnectionRequests() (need v3+, have v%d)", bestVer)
+	}
+	if _, err := crossmodel.ParseOfferURL(offerURL); err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := params.OfferURLs{OfferURLs: []string{offerURL}}
+	var results params.OfferConnectionRequestsResults
+	if err := c.facade.FacadeCall("OfferConnectionRequests", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Requests, nil
+}
+
+// ChangeOfferConnectionRequest approves or rejects the specified user's
+// request to consume an offer.
+func (c *Client) ChangeOfferConnectionRequest(offerURL, user string, approve bool) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return errors.NotImplementedf("ChangeOfferConnectionRequest() (need v3+, have v%d)", bestVer)
+	}
+	if _, err := crossmodel.ParseOfferURL(offerURL); err != nil {
+		return errors.Trace(err)
+	}
+	if !names.IsValidUser(user) {
+		return errors.NotValidf("user name %q", user)
+	}
+	args := params.ChangeOfferConnectionRequests{
+		Changes: []params.ChangeOfferConnectionRequest{{
+			OfferURL: offerURL,
+			Username: user,
+			Approve:  approve,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ChangeOfferConnectionRequests", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // DestroyOffers removes the specified application offers.
 func (c *Client) DestroyOffers(force bool, offerURLs ...string) error {
 	if len(offerURLs) == 0 {
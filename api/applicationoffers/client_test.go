@@ -701,3 +701,151 @@ func (s *crossmodelMockSuite) TestDestroyOffersForce(c *gc.C) {
 
 	c.Assert(err, gc.ErrorMatches, "DestroyOffers\\(\\).* not implemented")
 }
+
+func (s *crossmodelMockSuite) TestOfferWithOptions(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Check(request, gc.Equals, "Offer")
+				args, ok := a.(params.AddApplicationOffers)
+				c.Assert(ok, jc.IsTrue)
+				c.Assert(args.Offers, gc.HasLen, 1)
+				c.Check(args.Offers[0].RequireApproval, jc.IsTrue)
+				c.Check(args.Offers[0].MaxConsumers, gc.Equals, 2)
+				c.Check(args.Offers[0].MaxRelations, gc.Equals, 3)
+				if results, ok := result.(*params.ErrorResults); ok {
+					results.Results = []params.ErrorResult{{}}
+				}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	results, err := client.OfferWithOptions("uuid", "shared", []string{"db"}, "offer", "desc", applicationoffers.OfferOptions{
+		RequireApproval: true,
+		MaxConsumers:    2,
+		MaxRelations:    3,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{{}})
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *crossmodelMockSuite) TestOfferWithOptionsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fail()
+				return nil
+			},
+		),
+		BestVersion: 2,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	_, err := client.OfferWithOptions("uuid", "shared", []string{"db"}, "offer", "desc", applicationoffers.OfferOptions{
+		MaxRelations: 1,
+	})
+	c.Assert(err, gc.ErrorMatches, `Offer\(\) with approval or limits .* not implemented`)
+}
+
+func (s *crossmodelMockSuite) TestOfferConnectionRequests(c *gc.C) {
+	requested := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ApplicationOffers")
+				c.Check(request, gc.Equals, "OfferConnectionRequests")
+				c.Check(a, jc.DeepEquals, params.OfferURLs{OfferURLs: []string{"fred/prod.db2"}})
+				if results, ok := result.(*params.OfferConnectionRequestsResults); ok {
+					results.Results = []params.OfferConnectionRequestsResult{{
+						Requests: []params.OfferConnectionRequest{{
+							Username:  "mary",
+							Status:    "pending",
+							Requested: requested,
+							Updated:   requested,
+						}},
+					}}
+				}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	requests, err := client.OfferConnectionRequests("fred/prod.db2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requests, jc.DeepEquals, []params.OfferConnectionRequest{{
+		Username:  "mary",
+		Status:    "pending",
+		Requested: requested,
+		Updated:   requested,
+	}})
+}
+
+func (s *crossmodelMockSuite) TestChangeOfferConnectionRequest(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Check(request, gc.Equals, "ChangeOfferConnectionRequests")
+				c.Check(a, jc.DeepEquals, params.ChangeOfferConnectionRequests{
+					Changes: []params.ChangeOfferConnectionRequest{{
+						OfferURL: "fred/prod.db2",
+						Username: "mary",
+						Approve:  true,
+					}},
+				})
+				if results, ok := result.(*params.ErrorResults); ok {
+					results.Results = []params.ErrorResult{{
+						Error: &params.Error{Message: "fail"},
+					}}
+				}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.ChangeOfferConnectionRequest("fred/prod.db2", "mary", true)
+	c.Assert(err, gc.ErrorMatches, "fail")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *crossmodelMockSuite) TestChangeOfferConnectionRequestNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fail()
+				return nil
+			},
+		),
+		BestVersion: 2,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.ChangeOfferConnectionRequest("fred/prod.db2", "mary", false)
+	c.Assert(err, gc.ErrorMatches, `ChangeOfferConnectionRequest\(\) .* not implemented`)
+}
@@ -0,0 +1,35 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog provides a client for the AuditLog facade, used
+// to query the audit log stored in the controller database.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the AuditLog API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the AuditLog API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "AuditLog")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Query returns the audit log conversations matching the
+// arguments, most recent first.
+func (c *Client) Query(args params.AuditLogQueryArgs) ([]params.AuditConversation, error) {
+	var result params.AuditLogQueryResult
+	if err := c.facade.FacadeCall("Query", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Conversations, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "AuditLog")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Query")
+			c.Check(a, jc.DeepEquals, params.AuditLogQueryArgs{
+				Who:   "alice",
+				Limit: 10,
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.AuditLogQueryResult{})
+			*(result.(*params.AuditLogQueryResult)) = params.AuditLogQueryResult{
+				Conversations: []params.AuditConversation{{
+					ConversationID: "0123456789abcdef",
+					Who:            "alice",
+					What:           "juju deploy",
+				}},
+			}
+			return nil
+		})
+	client := auditlog.NewClient(apiCaller)
+	conversations, err := client.Query(params.AuditLogQueryArgs{
+		Who:   "alice",
+		Limit: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(conversations, jc.DeepEquals, []params.AuditConversation{{
+		ConversationID: "0123456789abcdef",
+		Who:            "alice",
+		What:           "juju deploy",
+	}})
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		})
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.Query(params.AuditLogQueryArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
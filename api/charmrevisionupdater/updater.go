@@ -31,3 +31,23 @@ func (st *State) UpdateLatestRevisions() error {
 	}
 	return nil
 }
+
+// ApplyCharmRefreshPolicies upgrades the charms of applications whose
+// charm refresh policy's maintenance window is open to the latest
+// revisions recorded by UpdateLatestRevisions.
+func (st *State) ApplyCharmRefreshPolicies() error {
+	if st.facade.BestAPIVersion() < 3 {
+		// Controllers without the API have no refresh policies
+		// to apply.
+		return nil
+	}
+	result := new(params.ErrorResult)
+	err := st.facade.FacadeCall("ApplyCharmRefreshPolicies", nil, result)
+	if err != nil {
+		return err
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
@@ -68,3 +68,17 @@ func (s *versionUpdaterSuite) TestUpdateRevisions(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(pending.String(), gc.Equals, "cs:quantal/mysql-23")
 }
+
+func (s *versionUpdaterSuite) TestApplyCharmRefreshPolicies(c *gc.C) {
+	s.SetupScenario(c)
+	err := s.updater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.updater.ApplyCharmRefreshPolicies()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// mysql has no refresh policy, so it is not upgraded.
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-22")
+}
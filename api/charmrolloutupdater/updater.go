@@ -0,0 +1,38 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmrolloutupdater provides the client-side API used by the
+// charm rollout worker.
+package charmrolloutupdater
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const charmRolloutUpdaterFacade = "CharmRolloutUpdater"
+
+// API provides access to the CharmRolloutUpdater API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side CharmRolloutUpdater facade.
+func NewAPI(caller base.APICaller) *API {
+	return &API{facade: base.NewFacadeCaller(caller, charmRolloutUpdaterFacade)}
+}
+
+// UpdateCharmRollouts advances the charm rollouts in progress in the
+// model.
+func (api *API) UpdateCharmRollouts() error {
+	var result params.ErrorResult
+	if err := api.facade.FacadeCall("UpdateCharmRollouts", nil, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
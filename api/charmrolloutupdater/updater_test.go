@@ -0,0 +1,46 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrolloutupdater_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/charmrolloutupdater"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type CharmRolloutUpdaterSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&CharmRolloutUpdaterSuite{})
+
+func (s *CharmRolloutUpdaterSuite) TestUpdateCharmRollouts(c *gc.C) {
+	var called bool
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "CharmRolloutUpdater")
+		c.Check(request, gc.Equals, "UpdateCharmRollouts")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResult{})
+		return nil
+	})
+	err := charmrolloutupdater.NewAPI(apiCaller).UpdateCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *CharmRolloutUpdaterSuite) TestUpdateCharmRolloutsError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ErrorResult)) = params.ErrorResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	err := charmrolloutupdater.NewAPI(apiCaller).UpdateCharmRollouts()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -53,6 +53,13 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, limits the response to records with a log time
+	// before EndTime. Once EndTime has passed the server stops waiting
+	// for new logs.
+	EndTime time.Time
+	// MessagePattern, if set, is a regular expression which log messages
+	// must match to be returned. The filtering is done by the server.
+	MessagePattern string
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -80,6 +87,12 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
+	if args.MessagePattern != "" {
+		attrs.Set("messagePattern", args.MessagePattern)
+	}
 	return attrs
 }
 
@@ -13,7 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs/config"
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd"
 )
 
 // ModelWatcher provides common client-side API functions
@@ -61,16 +61,23 @@ func (e *ModelWatcher) WatchForLogForwardConfigChanges() (watcher.NotifyWatcher,
 	return e.WatchForModelConfigChanges()
 }
 
-// LogForwardConfig returns the current log forward syslog configuration.
-func (e *ModelWatcher) LogForwardConfig() (*syslog.RawConfig, bool, error) {
+// LogForwardConfig returns the current configuration of each log
+// forwarding target that has been configured.
+func (e *ModelWatcher) LogForwardConfig() ([]logfwd.SinkConfig, error) {
 	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
 	// For now, we'll piggyback off the ModelConfig API.
 	modelConfig, err := e.ModelConfig()
 	if err != nil {
-		return nil, false, err
+		return nil, err
+	}
+	var cfgs []logfwd.SinkConfig
+	if cfg, ok := modelConfig.LogFwdSyslog(); ok {
+		cfgs = append(cfgs, cfg)
+	}
+	if cfg, ok := modelConfig.LogFwdHTTP(); ok {
+		cfgs = append(cfgs, cfg)
 	}
-	cfg, ok := modelConfig.LogFwdSyslog()
-	return cfg, ok, nil
+	return cfgs, nil
 }
 
 // UpdateStatusHookInterval returns the current update status hook interval.
@@ -298,16 +298,81 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
+	args, err := migrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// MigrationDryRunReport holds the outcome of checking whether a model
+// could be migrated to another controller.
+type MigrationDryRunReport struct {
+	// Issues lists the problems which would prevent the migration
+	// from succeeding.
+	Issues []string
+
+	// ModelDescriptionSize, CharmsSize, ToolsSize and ResourcesSize
+	// hold the estimated number of bytes which would be transferred
+	// to the target controller.
+	ModelDescriptionSize int64
+	CharmsSize           int64
+	ToolsSize            int64
+	ResourcesSize        int64
+}
+
+// DryRunMigration checks whether the specified model could be migrated,
+// without starting the migration. Neither controller is changed.
+func (c *Client) DryRunMigration(spec MigrationSpec) (MigrationDryRunReport, error) {
+	if c.BestAPIVersion() < 8 {
+		return MigrationDryRunReport{}, errors.NotSupportedf("migration dry run on this controller version")
+	}
+	args, err := migrationArgs(spec)
+	if err != nil {
+		return MigrationDryRunReport{}, errors.Trace(err)
+	}
+	response := params.MigrationDryRunResults{}
+	if err := c.facade.FacadeCall("DryRunMigration", args, &response); err != nil {
+		return MigrationDryRunReport{}, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return MigrationDryRunReport{}, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return MigrationDryRunReport{}, errors.Trace(result.Error)
+	}
+	return MigrationDryRunReport{
+		Issues:               result.Issues,
+		ModelDescriptionSize: result.ModelDescriptionSize,
+		CharmsSize:           result.CharmsSize,
+		ToolsSize:            result.ToolsSize,
+		ResourcesSize:        result.ResourcesSize,
+	}, nil
+}
+
+func migrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
 	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -320,19 +385,7 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Macaroons:       macsJSON,
 			},
 		}},
-	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
-	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
-	}
-	result := response.Results[0]
-	if result.Error != nil {
-		return "", errors.Trace(result.Error)
-	}
-	return result.MigrationId, nil
+	}, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
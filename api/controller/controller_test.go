@@ -171,6 +171,58 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestDryRunMigration(c *gc.C) {
+	spec := makeSpec()
+	client, stub := makeDryRunMigrationClient(8, params.MigrationDryRunResults{
+		Results: []params.MigrationDryRunResult{{
+			ModelTag:             names.NewModelTag(spec.ModelUUID).String(),
+			Issues:               []string{"boom"},
+			ModelDescriptionSize: 1,
+			CharmsSize:           2,
+			ToolsSize:            3,
+			ResourcesSize:        4,
+		}},
+	})
+	report, err := client.DryRunMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report, jc.DeepEquals, controller.MigrationDryRunReport{
+		Issues:               []string{"boom"},
+		ModelDescriptionSize: 1,
+		CharmsSize:           2,
+		ToolsSize:            3,
+		ResourcesSize:        4,
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.DryRunMigration", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestDryRunMigrationError(c *gc.C) {
+	client, _ := makeDryRunMigrationClient(8, params.MigrationDryRunResults{
+		Results: []params.MigrationDryRunResult{{
+			Error: common.ServerError(errors.New("boom")),
+		}},
+	})
+	_, err := client.DryRunMigration(makeSpec())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestDryRunMigrationValidationError(c *gc.C) {
+	client, stub := makeDryRunMigrationClient(8, params.MigrationDryRunResults{})
+	spec := makeSpec()
+	spec.ModelUUID = "not-a-uuid"
+	_, err := client.DryRunMigration(spec)
+	c.Check(err, gc.ErrorMatches, "client-side validation failed: model UUID not valid")
+	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
+}
+
+func (s *Suite) TestDryRunMigrationNotSupported(c *gc.C) {
+	client, stub := makeDryRunMigrationClient(7, params.MigrationDryRunResults{})
+	_, err := client.DryRunMigration(makeSpec())
+	c.Check(err, gc.ErrorMatches, "migration dry run on this controller version not supported")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
@@ -255,6 +307,23 @@ func makeInitiateMigrationClient(results params.InitiateMigrationResults) (
 	return client, &stub
 }
 
+func makeDryRunMigrationClient(version int, results params.MigrationDryRunResults) (
+	*controller.Client, *jujutesting.Stub,
+) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: version,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.MigrationDryRunResults)
+			*out = results
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	return client, &stub
+}
+
 func makeSpec() controller.MigrationSpec {
 	mac, err := macaroon.New([]byte("secret"), []byte("id"), "location")
 	if err != nil {
@@ -11,16 +11,18 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       3,
+	"Action":                       6,
 	"ActionPruner":                 1,
+	"ActionScheduler":              1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  9,
-	"ApplicationOffers":            2,
+	"Application":                  14,
+	"ApplicationOffers":            3,
 	"ApplicationScaler":            1,
+	"AuditLog":                     1,
 	"Backups":                      2,
 	"Block":                        2,
 	"Bundle":                       2,
@@ -30,12 +32,13 @@ var facadeVersions = map[string]int{
 	"CAASOperatorProvisioner":      1,
 	"CAASOperatorUpgrader":         1,
 	"CAASUnitProvisioner":          1,
-	"CharmRevisionUpdater":         2,
+	"CharmRevisionUpdater":         3,
+	"CharmRolloutUpdater":          1,
 	"Charms":                       2,
 	"Cleaner":                      2,
 	"Client":                       2,
 	"Cloud":                        5,
-	"Controller":                   7,
+	"Controller":                   8,
 	"CredentialManager":            1,
 	"CredentialValidator":          2,
 	"CrossController":              1,
@@ -46,7 +49,7 @@ var facadeVersions = map[string]int{
 	"ExternalControllerUpdater":    1,
 	"FanConfigurer":                1,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   5,
+	"Firewaller":                   6,
 	"FirewallRules":                1,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
@@ -55,6 +58,7 @@ var facadeVersions = map[string]int{
 	"ImageMetadataManager":         1,
 	"InstanceMutater":              1,
 	"InstancePoller":               3,
+	"Introspection":                1,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
 	"LeadershipService":            2,
@@ -88,6 +92,7 @@ var facadeVersions = map[string]int{
 	"Reboot":                       2,
 	"RelationStatusWatcher":        1,
 	"RelationUnitsWatcher":         1,
+	"RemoteIntrospection":          1,
 	"RemoteRelations":              1,
 	"Resources":                    1,
 	"ResourcesHookContext":         1,
@@ -97,13 +102,13 @@ var facadeVersions = map[string]int{
 	"Spaces":                       3,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      6,
+	"Storage":                      7,
 	"StorageProvisioner":           4,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       12,
+	"Uniter":                       14,
 	"Upgrader":                     1,
 	"UpgradeSeries":                1,
 	"UserManager":                  2,
@@ -63,3 +63,35 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// ExposeInfo returns whether this application is exposed and, if so,
+// where each of its endpoints may be accessed from, keyed by endpoint
+// name. Spaces have already been resolved to their subnet CIDRs. A nil
+// map for an exposed application means it may be accessed from
+// anywhere.
+func (s *Application) ExposeInfo() (bool, map[string]params.ExposedEndpoint, error) {
+	if s.st.BestAPIVersion() < 6 {
+		// Older controllers only know about the exposed flag.
+		exposed, err := s.IsExposed()
+		return exposed, nil, err
+	}
+	var results params.ExposeInfoResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("ExposeInfo", args, &results)
+	if err != nil {
+		return false, nil, err
+	}
+	if len(results.Results) != 1 {
+		return false, nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		if params.IsCodeNotFound(result.Error) {
+			return false, nil, errors.NewNotFound(result.Error, "")
+		}
+		return false, nil, result.Error
+	}
+	return result.Exposed, result.ExposedEndpoints, nil
+}
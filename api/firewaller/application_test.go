@@ -9,7 +9,9 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/firewaller"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/state"
 )
 
 type applicationSuite struct {
@@ -78,3 +80,22 @@ func (s *applicationSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *applicationSuite) TestExposeInfo(c *gc.C) {
+	exposed, exposedEndpoints, err := s.apiApplication.ExposeInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exposed, jc.IsFalse)
+	c.Assert(exposedEndpoints, gc.HasLen, 0)
+
+	err = s.application.SetExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	exposed, exposedEndpoints, err = s.apiApplication.ExposeInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exposed, jc.IsTrue)
+	c.Assert(exposedEndpoints, jc.DeepEquals, map[string]params.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+}
@@ -100,6 +100,35 @@ func (c *Client) WatchModelMachines() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchSubnets returns a StringsWatcher that notifies of changes to
+// the subnets of the current model, which may change the CIDRs of the
+// spaces that applications are exposed to.
+func (c *Client) WatchSubnets() (watcher.StringsWatcher, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("WatchSubnets on API version %d", c.BestAPIVersion())
+	}
+	modelTag, ok := c.ModelTag()
+	if !ok {
+		return nil, errors.New("API connection is controller-only (should never happen)")
+	}
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: modelTag.String()}},
+	}
+	if err := c.facade.FacadeCall("WatchSubnets", args, &results); err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // WatchOpenedPorts returns a StringsWatcher that notifies of
 // changes to the opened ports for the current model.
 func (c *Client) WatchOpenedPorts() (watcher.StringsWatcher, error) {
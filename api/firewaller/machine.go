@@ -108,9 +108,18 @@ func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
 	return tags, nil
 }
 
-// OpenedPorts returns a map of network.PortRange to unit tag for all opened
-// port ranges on the machine for the subnet matching given subnetTag.
-func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]names.UnitTag, error) {
+// OpenedPortRange holds the unit that opened a port range, and the
+// names of the unit's endpoints the range was opened for. Endpoints
+// is empty if the range was opened for all of them.
+type OpenedPortRange struct {
+	Unit      names.UnitTag
+	Endpoints []string
+}
+
+// OpenedPorts returns a map of network.PortRange to the unit and endpoints
+// for all opened port ranges on the machine for the subnet matching given
+// subnetTag.
+func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]OpenedPortRange, error) {
 	var results params.MachinePortsResults
 	var subnetTagAsString string
 	if subnetTag.Id() != "" {
@@ -133,13 +142,16 @@ func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]
 		return nil, result.Error
 	}
 	// Convert string tags to names.UnitTag before returning.
-	endResult := make(map[network.PortRange]names.UnitTag)
+	endResult := make(map[network.PortRange]OpenedPortRange)
 	for _, ports := range result.Ports {
 		unitTag, err := names.ParseUnitTag(ports.UnitTag)
 		if err != nil {
 			return nil, err
 		}
-		endResult[ports.PortRange.NetworkPortRange()] = unitTag
+		endResult[ports.PortRange.NetworkPortRange()] = OpenedPortRange{
+			Unit:      unitTag,
+			Endpoints: ports.Endpoints,
+		}
 	}
 	return endResult, nil
 }
@@ -130,8 +130,20 @@ func (s *machineSuite) TestOpenedPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	ports, err = s.apiMachine.OpenedPorts(names.SubnetTag{})
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, jc.DeepEquals, map[network.PortRange]names.UnitTag{
-		{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: unitTag,
+	c.Assert(ports, jc.DeepEquals, map[network.PortRange]firewaller.OpenedPortRange{
+		{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: {Unit: unitTag},
+	})
+
+	// Open a port for specific endpoints.
+	err = s.units[0].OpenPortsForEndpoint("url", "tcp", 8080, 8080)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenPortsForEndpoint("db", "tcp", 8080, 8080)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err = s.apiMachine.OpenedPorts(names.SubnetTag{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, map[network.PortRange]firewaller.OpenedPortRange{
+		{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: {Unit: unitTag},
+		{FromPort: 8080, ToPort: 8080, Protocol: "tcp"}: {Unit: unitTag, Endpoints: []string{"db", "url"}},
 	})
 }
 
@@ -58,6 +58,22 @@ func (s *stateSuite) TestWatchModelMachines(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *stateSuite) TestWatchSubnets(c *gc.C) {
+	w, err := s.firewaller.WatchSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewStringsWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("10.0.0.0/24")
+	wc.AssertNoChange()
+}
+
 func (s *stateSuite) TestWatchOpenedPorts(c *gc.C) {
 	// Open some ports.
 	err := s.units[0].OpenPorts("tcp", 1234, 1400)
@@ -0,0 +1,46 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package introspection provides a client for the Introspection
+// facade, used to query the introspection endpoints of machine and
+// unit agents through the controller.
+package introspection
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the Introspection API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the Introspection API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Introspection")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Introspect asks the specified agent to query the given path on its
+// introspection endpoint, and returns the response.
+func (c *Client) Introspect(agent names.Tag, path string) (string, error) {
+	args := params.IntrospectArgs{
+		Args: []params.IntrospectArg{{Tag: agent.String(), Path: path}},
+	}
+	var results params.IntrospectResults
+	if err := c.facade.FacadeCall("Introspect", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", errors.Trace(err)
+	}
+	return results.Results[0].Response, nil
+}
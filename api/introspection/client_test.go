@@ -0,0 +1,60 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/introspection"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type introspectionSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&introspectionSuite{})
+
+func (s *introspectionSuite) TestIntrospect(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Introspection")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Introspect")
+			c.Check(a, jc.DeepEquals, params.IntrospectArgs{
+				Args: []params.IntrospectArg{{Tag: "unit-mysql-0", Path: "/depengine"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.IntrospectResults{})
+			*(result.(*params.IntrospectResults)) = params.IntrospectResults{
+				Results: []params.IntrospectResult{{Response: "Dependency Engine Report"}},
+			}
+			return nil
+		})
+	client := introspection.NewClient(apiCaller)
+	response, err := client.Introspect(names.NewUnitTag("mysql/0"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(response, gc.Equals, "Dependency Engine Report")
+}
+
+func (s *introspectionSuite) TestIntrospectError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.IntrospectResults)) = params.IntrospectResults{
+				Results: []params.IntrospectResult{{
+					Error: &params.Error{Message: "waiting for machine 0 to respond timeout"},
+				}},
+			}
+			return nil
+		})
+	client := introspection.NewClient(apiCaller)
+	_, err := client.Introspect(names.NewMachineTag("0"), "/depengine")
+	c.Assert(err, gc.ErrorMatches, "waiting for machine 0 to respond timeout")
+}
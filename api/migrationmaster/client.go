@@ -187,6 +187,7 @@ func (c *Client) Export() (migration.SerializedModel, error) {
 		Charms:    serialized.Charms,
 		Tools:     tools,
 		Resources: resources,
+		Extras:    serialized.Extras,
 	}, nil
 }
 
@@ -230,6 +230,7 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 		out := result.(*params.SerializedModel)
 		*out = params.SerializedModel{
 			Bytes:  []byte("foo"),
+			Extras: []byte("bar"),
 			Charms: []string{"cs:foo-1"},
 			Tools: []params.SerializedModelTools{{
 				Version: "2.0.0-trusty-amd64",
@@ -285,6 +286,7 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 	})
 	c.Assert(out, gc.DeepEquals, migration.SerializedModel{
 		Bytes:  []byte("foo"),
+		Extras: []byte("bar"),
 		Charms: []string{"cs:foo-1"},
 		Tools: map[version.Binary]string{
 			version.MustParseBinary("2.0.0-trusty-amd64"): "/tools/0",
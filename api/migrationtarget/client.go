@@ -53,10 +53,10 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 	return c.caller.FacadeCall("Prechecks", args, nil)
 }
 
-// Import takes a serialized model and imports it into the target
-// controller.
-func (c *Client) Import(bytes []byte) error {
-	serialized := params.SerializedModel{Bytes: bytes}
+// Import takes a serialized model, along with any serialized model
+// extras, and imports it into the target controller.
+func (c *Client) Import(bytes, extras []byte) error {
+	serialized := params.SerializedModel{Bytes: bytes, Extras: extras}
 	return c.caller.FacadeCall("Import", serialized, nil)
 }
 
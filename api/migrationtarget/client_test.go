@@ -80,9 +80,9 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
-	err := client.Import([]byte("foo"))
+	err := client.Import([]byte("foo"), []byte("bar"))
 
-	expectedArg := params.SerializedModel{Bytes: []byte("foo")}
+	expectedArg := params.SerializedModel{Bytes: []byte("foo"), Extras: []byte("bar")}
 	stub.CheckCalls(c, []jujutesting.StubCall{
 		{"MigrationTarget.Import", []interface{}{"", expectedArg}},
 	})
@@ -56,6 +56,20 @@ func (c *Client) CommitBranch(branchName string) (int, error) {
 	return result.Result, nil
 }
 
+// AbortBranch aborts the branch with the input name, discarding all changes
+// made under it. Units tracking the branch revert to the master generation.
+func (c *Client) AbortBranch(branchName string) error {
+	var result params.ErrorResult
+	err := c.facade.FacadeCall("AbortBranch", argForBranch(branchName), &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
+
 // TrackBranch sets the input units and/or applications
 // to track changes made under the input branch name.
 func (c *Client) TrackBranch(branchName string, entities []string) error {
@@ -143,6 +157,10 @@ func generationInfoFromResult(
 				ApplicationName: a.ApplicationName,
 				UnitProgress:    a.UnitProgress,
 				ConfigChanges:   a.ConfigChanges,
+				CharmURL:        a.CharmURL,
+			}
+			if a.Constraints != nil {
+				bApp.Constraints = a.Constraints.String()
 			}
 			if detailed {
 				bApp.UnitDetail = &model.GenerationUnits{
@@ -99,6 +99,30 @@ func (s *modelGenerationSuite) TestCommitBranch(c *gc.C) {
 	c.Check(newGenID, gc.Equals, 2)
 }
 
+func (s *modelGenerationSuite) TestAbortBranch(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultSource := params.ErrorResult{}
+	arg := params.BranchArg{BranchName: s.branchName}
+	s.fCaller.EXPECT().FacadeCall("AbortBranch", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.AbortBranch(s.branchName)
+	c.Assert(err, gc.IsNil)
+}
+
+func (s *modelGenerationSuite) TestAbortBranchError(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultSource := params.ErrorResult{Error: &params.Error{Message: "branch was already committed"}}
+	arg := params.BranchArg{BranchName: s.branchName}
+	s.fCaller.EXPECT().FacadeCall("AbortBranch", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.AbortBranch(s.branchName)
+	c.Assert(err, gc.ErrorMatches, "branch was already committed")
+}
+
 func (s *modelGenerationSuite) TestHasActiveBranch(c *gc.C) {
 	defer s.setUpMocks(c).Finish()
 
@@ -0,0 +1,83 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoteintrospection provides the agent side client for the
+// RemoteIntrospection facade, used to answer introspection requests
+// relayed through the controller.
+package remoteintrospection
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Client provides access to the RemoteIntrospection API end point.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the RemoteIntrospection
+// API.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{base.NewFacadeCaller(caller, "RemoteIntrospection")}
+}
+
+// WatchRequests returns a StringsWatcher that notifies of the ids of
+// introspection requests made of the given agent.
+func (c *Client) WatchRequests(agent names.Tag) (watcher.StringsWatcher, error) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: agent.String()}},
+	}
+	var results params.StringsWatchResults
+	if err := c.facade.FacadeCall("WatchRequests", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// Request returns the details of the introspection request with the
+// given id.
+func (c *Client) Request(id string) (params.IntrospectionRequest, error) {
+	args := params.IntrospectionRequestIds{Ids: []string{id}}
+	var results params.IntrospectionRequestResults
+	if err := c.facade.FacadeCall("Requests", args, &results); err != nil {
+		return params.IntrospectionRequest{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.IntrospectionRequest{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.IntrospectionRequest{}, errors.Trace(result.Error)
+	}
+	return *result.Result, nil
+}
+
+// Complete records the response to the introspection request with the
+// given id. If the request could not be answered, errMsg describes why.
+func (c *Client) Complete(id, response, errMsg string) error {
+	args := params.IntrospectionResponses{
+		Responses: []params.IntrospectionResponse{{
+			Id:       id,
+			Response: response,
+			Error:    errMsg,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Complete", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
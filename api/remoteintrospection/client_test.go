@@ -0,0 +1,86 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoteintrospection_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/remoteintrospection"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type remoteIntrospectionSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&remoteIntrospectionSuite{})
+
+func (s *remoteIntrospectionSuite) TestWatchRequestsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "RemoteIntrospection")
+			c.Check(request, gc.Equals, "WatchRequests")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-0"}},
+			})
+			*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+				Results: []params.StringsWatchResult{{
+					Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+				}},
+			}
+			return nil
+		})
+	client := remoteintrospection.NewClient(apiCaller)
+	_, err := client.WatchRequests(names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *remoteIntrospectionSuite) TestRequest(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "RemoteIntrospection")
+			c.Check(request, gc.Equals, "Requests")
+			c.Check(a, jc.DeepEquals, params.IntrospectionRequestIds{
+				Ids: []string{"0#introspect#1"},
+			})
+			*(result.(*params.IntrospectionRequestResults)) = params.IntrospectionRequestResults{
+				Results: []params.IntrospectionRequestResult{{
+					Result: &params.IntrospectionRequest{Id: "0#introspect#1", Path: "/depengine"},
+				}},
+			}
+			return nil
+		})
+	client := remoteintrospection.NewClient(apiCaller)
+	req, err := client.Request("0#introspect#1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(req, jc.DeepEquals, params.IntrospectionRequest{Id: "0#introspect#1", Path: "/depengine"})
+}
+
+func (s *remoteIntrospectionSuite) TestComplete(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "RemoteIntrospection")
+			c.Check(request, gc.Equals, "Complete")
+			c.Check(a, jc.DeepEquals, params.IntrospectionResponses{
+				Responses: []params.IntrospectionResponse{{
+					Id:    "0#introspect#1",
+					Error: "boom",
+				}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		})
+	client := remoteintrospection.NewClient(apiCaller)
+	err := client.Complete("0#introspect#1", "", "boom")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
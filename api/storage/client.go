@@ -198,6 +198,13 @@ func (c *Client) ListFilesystems(machines []string) ([]params.FilesystemDetailsL
 // NOTE(axw) for old controllers, the results will only
 // contain errors.
 func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.AddStorageResult, error) {
+	if c.BestAPIVersion() < 7 {
+		for _, s := range storages {
+			if s.SnapshotId != "" {
+				return nil, errors.New("this juju controller does not support adding storage from a snapshot")
+			}
+		}
+	}
 	out := params.AddStorageResults{}
 	in := params.StoragesAddParams{Storages: storages}
 	err := c.facade.FacadeCall("AddToUnit", in, &out)
@@ -355,3 +362,111 @@ func (c *Client) Import(
 	}
 	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
 }
+
+// CreateSnapshots takes point-in-time snapshots of the specified volumes.
+func (c *Client) CreateSnapshots(volumeIds []string) ([]params.VolumeSnapshotResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.New("this juju controller does not support volume snapshots")
+	}
+	args, err := volumeEntities(volumeIds)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.VolumeSnapshotResults
+	if err := c.facade.FacadeCall("CreateSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(volumeIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(volumeIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ListSnapshots lists the snapshots of the specified volumes.
+func (c *Client) ListSnapshots(volumeIds []string) ([]params.VolumeSnapshotsResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.New("this juju controller does not support volume snapshots")
+	}
+	args, err := volumeEntities(volumeIds)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.VolumeSnapshotsResults
+	if err := c.facade.FacadeCall("ListSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(volumeIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(volumeIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// DeleteSnapshots deletes the specified snapshots of a volume.
+func (c *Client) DeleteSnapshots(volumeId string, snapshotIds []string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.New("this juju controller does not support volume snapshots")
+	}
+	if !names.IsValidVolume(volumeId) {
+		return nil, errors.NotValidf("volume ID %q", volumeId)
+	}
+	volumeTag := names.NewVolumeTag(volumeId).String()
+	args := params.DeleteVolumeSnapshotArgs{
+		Snapshots: make([]params.DeleteVolumeSnapshotArg, len(snapshotIds)),
+	}
+	for i, snapshotId := range snapshotIds {
+		args.Snapshots[i] = params.DeleteVolumeSnapshotArg{
+			VolumeTag:  volumeTag,
+			SnapshotId: snapshotId,
+		}
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("DeleteSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(snapshotIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(snapshotIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ResizeStorage grows the storage instance with the specified ID to
+// the given size, in MiB.
+func (c *Client) ResizeStorage(storageId string, size uint64) error {
+	if c.BestAPIVersion() < 7 {
+		return errors.New("this juju controller does not support resizing storage")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.ResizeStorageArgs{
+		Storage: []params.ResizeStorageArg{{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			Size:       size,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResizeStorage", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+func volumeEntities(volumeIds []string) (params.Entities, error) {
+	entities := make([]params.Entity, len(volumeIds))
+	for i, id := range volumeIds {
+		if !names.IsValidVolume(id) {
+			return params.Entities{}, errors.NotValidf("volume ID %q", id)
+		}
+		entities[i] = params.Entity{Tag: names.NewVolumeTag(id).String()}
+	}
+	return params.Entities{Entities: entities}, nil
+}
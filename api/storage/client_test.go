@@ -1077,3 +1077,179 @@ func (s *storageMockSuite) TestUpdatePoolFacadeCallError(c *gc.C) {
 	err := storageClient.UpdatePool("", "", nil)
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 }
+
+func (s *storageMockSuite) TestAddToUnitFromSnapshotUnsupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{BestVersion: 6}
+	client := storage.NewClient(apiCaller)
+	_, err := client.AddToUnit([]params.StorageAddParams{{
+		UnitTag:     "unit-a-0",
+		StorageName: "data",
+		SnapshotId:  "snap-0",
+	}})
+	c.Check(err, gc.ErrorMatches, "this juju controller does not support adding storage from a snapshot")
+}
+
+func (s *storageMockSuite) TestCreateSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "CreateSnapshots")
+				c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+					{Tag: "volume-0"}, {Tag: "volume-1"},
+				}})
+				results := result.(*params.VolumeSnapshotResults)
+				results.Results = []params.VolumeSnapshotResult{
+					{Result: &params.VolumeSnapshot{SnapshotId: "snap-0", VolumeTag: "volume-0"}},
+					{Error: &params.Error{Message: "bad"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.CreateSnapshots([]string{"0", "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotResult{
+		{Result: &params.VolumeSnapshot{SnapshotId: "snap-0", VolumeTag: "volume-0"}},
+		{Error: &params.Error{Message: "bad"}},
+	})
+}
+
+func (s *storageMockSuite) TestCreateSnapshotsInvalidVolumeId(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{BestVersion: 7}
+	client := storage.NewClient(apiCaller)
+	_, err := client.CreateSnapshots([]string{"foo/bar"})
+	c.Check(err, gc.ErrorMatches, `volume ID "foo/bar" not valid`)
+}
+
+func (s *storageMockSuite) TestCreateSnapshotsUnsupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{BestVersion: 6}
+	client := storage.NewClient(apiCaller)
+	_, err := client.CreateSnapshots([]string{"0"})
+	c.Check(err, gc.ErrorMatches, "this juju controller does not support volume snapshots")
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(request, gc.Equals, "ListSnapshots")
+				c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+					{Tag: "volume-0"},
+				}})
+				results := result.(*params.VolumeSnapshotsResults)
+				results.Results = []params.VolumeSnapshotsResult{{
+					Result: []params.VolumeSnapshot{{SnapshotId: "snap-0", VolumeTag: "volume-0"}},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.ListSnapshots([]string{"0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotsResult{{
+		Result: []params.VolumeSnapshot{{SnapshotId: "snap-0", VolumeTag: "volume-0"}},
+	}})
+}
+
+func (s *storageMockSuite) TestListSnapshotsArityMismatch(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				results := result.(*params.VolumeSnapshotsResults)
+				results.Results = []params.VolumeSnapshotsResult{{}, {}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.ListSnapshots([]string{"0"})
+	c.Check(err, gc.ErrorMatches, `expected 1 result\(s\), got 2`)
+}
+
+func (s *storageMockSuite) TestDeleteSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(request, gc.Equals, "DeleteSnapshots")
+				c.Check(a, jc.DeepEquals, params.DeleteVolumeSnapshotArgs{
+					Snapshots: []params.DeleteVolumeSnapshotArg{
+						{VolumeTag: "volume-0", SnapshotId: "snap-0"},
+						{VolumeTag: "volume-0", SnapshotId: "snap-1"},
+					},
+				})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{}, {}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.DeleteSnapshots("0", []string{"snap-0", "snap-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+}
+
+func (s *storageMockSuite) TestResizeStorage(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(request, gc.Equals, "ResizeStorage")
+				c.Check(a, jc.DeepEquals, params.ResizeStorageArgs{
+					Storage: []params.ResizeStorageArg{
+						{StorageTag: "storage-data-0", Size: 2048},
+					},
+				})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: "cannot grow"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.ResizeStorage("data/0", 2048)
+	c.Assert(err, gc.ErrorMatches, "cannot grow")
+}
+
+func (s *storageMockSuite) TestResizeStorageInvalidId(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.ResizeStorage("foo", 2048)
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *storageMockSuite) TestResizeStorageUnsupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.ResizeStorage("data/0", 2048)
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support resizing storage")
+}
@@ -74,19 +74,9 @@ func (s *Settings) Delete(key string) {
 // to make sure we update the address (and other settings) correctly,
 // without overwritting.
 func (s *Settings) Write() error {
-	// First make a copy of the map, including deleted keys.
-	settingsCopy := make(params.Settings)
-	for k, v := range s.settings {
-		settingsCopy[k] = v
-	}
-
 	var result params.ErrorResults
 	args := params.RelationUnitsSettings{
-		RelationUnits: []params.RelationUnitSettings{{
-			Relation: s.relationTag,
-			Unit:     s.unitTag,
-			Settings: settingsCopy,
-		}},
+		RelationUnits: []params.RelationUnitSettings{s.RelationUnitSettings()},
 	}
 	err := s.st.facade.FacadeCall("UpdateSettings", args, &result)
 	if err != nil {
@@ -94,3 +84,18 @@ func (s *Settings) Write() error {
 	}
 	return result.OneError()
 }
+
+// RelationUnitSettings returns the settings as they would be sent to
+// the server by Write, with deleted keys set to empty values.
+func (s *Settings) RelationUnitSettings() params.RelationUnitSettings {
+	// Make a copy of the map, including deleted keys.
+	settingsCopy := make(params.Settings)
+	for k, v := range s.settings {
+		settingsCopy[k] = v
+	}
+	return params.RelationUnitSettings{
+		Relation: s.relationTag,
+		Unit:     s.unitTag,
+		Settings: settingsCopy,
+	}
+}
@@ -64,10 +64,22 @@ func (s *stateSuite) TestAllMachinePorts(c *gc.C) {
 
 	portsMap, err := s.uniter.AllMachinePorts(s.wordpressMachine.Tag().(names.MachineTag))
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(portsMap, jc.DeepEquals, map[network.PortRange]params.RelationUnit{
-		{100, 200, "tcp"}: {Unit: s.wordpressUnit.Tag().String()},
-		{10, 20, "udp"}:   {Unit: s.wordpressUnit.Tag().String()},
-		{201, 250, "tcp"}: {Unit: wordpressUnit1.Tag().String()},
-		{1, 8, "udp"}:     {Unit: wordpressUnit1.Tag().String()},
+	c.Assert(portsMap, jc.DeepEquals, map[network.PortRange]params.MachinePortRange{
+		{100, 200, "tcp"}: {
+			UnitTag:   s.wordpressUnit.Tag().String(),
+			PortRange: params.PortRange{FromPort: 100, ToPort: 200, Protocol: "tcp"},
+		},
+		{10, 20, "udp"}: {
+			UnitTag:   s.wordpressUnit.Tag().String(),
+			PortRange: params.PortRange{FromPort: 10, ToPort: 20, Protocol: "udp"},
+		},
+		{201, 250, "tcp"}: {
+			UnitTag:   wordpressUnit1.Tag().String(),
+			PortRange: params.PortRange{FromPort: 201, ToPort: 250, Protocol: "tcp"},
+		},
+		{1, 8, "udp"}: {
+			UnitTag:   wordpressUnit1.Tag().String(),
+			PortRange: params.PortRange{FromPort: 1, ToPort: 8, Protocol: "udp"},
+		},
 	})
 }
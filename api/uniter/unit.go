@@ -449,8 +449,9 @@ func (u *Unit) AvailabilityZone() (string, error) {
 }
 
 // OpenPorts sets the policy of the port range with protocol to be
-// opened.
-func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
+// opened for the given endpoint, or for all of the unit's endpoints
+// if endpoint is empty.
+func (u *Unit) OpenPorts(endpoint, protocol string, fromPort, toPort int) error {
 	var result params.ErrorResults
 	args := params.EntitiesPortRanges{
 		Entities: []params.EntityPortRange{{
@@ -458,6 +459,7 @@ func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
 			Protocol: protocol,
 			FromPort: fromPort,
 			ToPort:   toPort,
+			Endpoint: endpoint,
 		}},
 	}
 	err := u.st.facade.FacadeCall("OpenPorts", args, &result)
@@ -468,8 +470,9 @@ func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
 }
 
 // ClosePorts sets the policy of the port range with protocol to be
-// closed.
-func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
+// closed for the given endpoint, or for all of the unit's endpoints
+// if endpoint is empty.
+func (u *Unit) ClosePorts(endpoint, protocol string, fromPort, toPort int) error {
 	var result params.ErrorResults
 	args := params.EntitiesPortRanges{
 		Entities: []params.EntityPortRange{{
@@ -477,6 +480,7 @@ func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
 			Protocol: protocol,
 			FromPort: fromPort,
 			ToPort:   toPort,
+			Endpoint: endpoint,
 		}},
 	}
 	err := u.st.facade.FacadeCall("ClosePorts", args, &result)
@@ -782,6 +786,66 @@ func (u *Unit) AddStorage(constraints map[string][]params.StorageConstraints) er
 	return results.Combine()
 }
 
+// State returns the private key/value state stored by the unit's charm.
+func (u *Unit) State() (map[string]string, error) {
+	if u.st.facade.BestAPIVersion() < 13 {
+		return nil, errors.NotImplementedf("State() (need V13+)")
+	}
+	var results params.UnitStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("State", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.State, nil
+}
+
+// SetState replaces the private key/value state stored by the unit's charm.
+func (u *Unit) SetState(state map[string]string) error {
+	if u.st.facade.BestAPIVersion() < 13 {
+		return errors.NotImplementedf("SetState() (need V13+)")
+	}
+	var results params.ErrorResults
+	args := params.SetUnitStateArgs{
+		Args: []params.SetUnitStateArg{{
+			Tag:   u.tag.String(),
+			State: state,
+		}},
+	}
+	err := u.st.facade.FacadeCall("SetState", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CommitHookChanges commits the changes made by one of the unit's
+// charm hooks, so that either all or none of them are made.
+func (u *Unit) CommitHookChanges(changes params.CommitHookChangesArg) error {
+	if u.st.facade.BestAPIVersion() < 13 {
+		return errors.NotImplementedf("CommitHookChanges() (need V13+)")
+	}
+	changes.Tag = u.tag.String()
+	var results params.ErrorResults
+	args := params.CommitHookChangesArgs{
+		Args: []params.CommitHookChangesArg{changes},
+	}
+	err := u.st.facade.FacadeCall("CommitHookChanges", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // NetworkInfo returns network interfaces/addresses for specified bindings.
 func (u *Unit) NetworkInfo(bindings []string, relationId *int) (map[string]params.NetworkInfoResult, error) {
 	var results params.NetworkInfoResults
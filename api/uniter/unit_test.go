@@ -411,9 +411,9 @@ func (s *unitSuite) TestOpenClosePortRanges(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ports, gc.HasLen, 0)
 
-	err = s.apiUnit.OpenPorts("tcp", 1234, 1400)
+	err = s.apiUnit.OpenPorts("", "tcp", 1234, 1400)
 	c.Assert(err, jc.ErrorIsNil)
-	err = s.apiUnit.OpenPorts("udp", 4321, 5000)
+	err = s.apiUnit.OpenPorts("", "udp", 4321, 5000)
 	c.Assert(err, jc.ErrorIsNil)
 
 	ports, err = s.wordpressUnit.OpenedPorts()
@@ -424,7 +424,7 @@ func (s *unitSuite) TestOpenClosePortRanges(c *gc.C) {
 		{Protocol: "udp", FromPort: 4321, ToPort: 5000},
 	})
 
-	err = s.apiUnit.ClosePorts("udp", 4321, 5000)
+	err = s.apiUnit.ClosePorts("", "udp", 4321, 5000)
 	c.Assert(err, jc.ErrorIsNil)
 
 	ports, err = s.wordpressUnit.OpenedPorts()
@@ -434,7 +434,7 @@ func (s *unitSuite) TestOpenClosePortRanges(c *gc.C) {
 		{Protocol: "tcp", FromPort: 1234, ToPort: 1400},
 	})
 
-	err = s.apiUnit.ClosePorts("tcp", 1234, 1400)
+	err = s.apiUnit.ClosePorts("", "tcp", 1234, 1400)
 	c.Assert(err, jc.ErrorIsNil)
 
 	ports, err = s.wordpressUnit.OpenedPorts()
@@ -934,6 +934,45 @@ func (s *unitSuite) TestMeterStatusResultError(c *gc.C) {
 	c.Assert(statusInfo, gc.Equals, "")
 }
 
+func (s *unitSuite) TestState(c *gc.C) {
+	err := s.wordpressUnit.SetState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitState, err := s.apiUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
+func (s *unitSuite) TestSetState(c *gc.C) {
+	err := s.apiUnit.SetState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitState, err := s.wordpressUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
+func (s *unitSuite) TestCommitHookChanges(c *gc.C) {
+	unitState := map[string]string{"foo": "bar"}
+	err := s.apiUnit.CommitHookChanges(params.CommitHookChangesArg{
+		OpenPorts: []params.EntityPortRange{{
+			Tag:      s.apiUnit.Tag().String(),
+			Protocol: "tcp",
+			FromPort: 80,
+			ToPort:   80,
+		}},
+		State: &unitState,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ports, err := s.wordpressUnit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, []corenetwork.PortRange{{Protocol: "tcp", FromPort: 80, ToPort: 80}})
+	stored, err := s.wordpressUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, unitState)
+}
+
 func (s *unitSuite) TestUpgradeSeriesStatusMultipleReturnsError(c *gc.C) {
 	facadeCaller := testing.StubFacadeCaller{Stub: &coretesting.Stub{}}
 	facadeCaller.FacadeCallFn = func(name string, args, response interface{}) error {
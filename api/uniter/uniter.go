@@ -281,6 +281,29 @@ func (st *State) ActionFinish(tag names.ActionTag, status string, results map[st
 	return nil
 }
 
+// LogActionMessage records a progress message for the running action.
+func (st *State) LogActionMessage(tag names.ActionTag, message string) error {
+	if st.BestAPIVersion() < 14 {
+		return errors.NotImplementedf("LogActionMessage() (need V14+)")
+	}
+	var outcome params.ErrorResults
+	args := params.ActionMessageParams{
+		Messages: []params.EntityString{{Tag: tag.String(), Value: message}},
+	}
+	err := st.facade.FacadeCall("LogActionsMessages", args, &outcome)
+	if err != nil {
+		return err
+	}
+	if len(outcome.Results) != 1 {
+		return fmt.Errorf("expected 1 result, got %d", len(outcome.Results))
+	}
+	result := outcome.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
 // RelationById returns the existing relation with the given id.
 func (st *State) RelationById(id int) (*Relation, error) {
 	var results params.RelationResults
@@ -332,8 +355,8 @@ func (st *State) Model() (*model.Model, error) {
 
 // AllMachinePorts returns all port ranges currently open on the given
 // machine, mapped to the tags of the unit that opened them and the
-// relation that applies.
-func (st *State) AllMachinePorts(machineTag names.MachineTag) (map[corenetwork.PortRange]params.RelationUnit, error) {
+// relation that applies, and the endpoints they were opened for.
+func (st *State) AllMachinePorts(machineTag names.MachineTag) (map[corenetwork.PortRange]params.MachinePortRange, error) {
 	if st.BestAPIVersion() < 1 {
 		// AllMachinePorts() was introduced in UniterAPIV1.
 		return nil, errors.NotImplementedf("AllMachinePorts() (need V1+)")
@@ -353,13 +376,10 @@ func (st *State) AllMachinePorts(machineTag names.MachineTag) (map[corenetwork.P
 	if result.Error != nil {
 		return nil, result.Error
 	}
-	portsMap := make(map[corenetwork.PortRange]params.RelationUnit)
+	portsMap := make(map[corenetwork.PortRange]params.MachinePortRange)
 	for _, ports := range result.Ports {
 		portRange := ports.PortRange.NetworkPortRange()
-		portsMap[portRange] = params.RelationUnit{
-			Unit:     ports.UnitTag,
-			Relation: ports.RelationTag,
-		}
+		portsMap[portRange] = ports
 	}
 	return portsMap, nil
 }
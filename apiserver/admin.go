@@ -177,6 +177,7 @@ func (a *admin) getAuditRecorder(req params.LoginRequest, authResult *authResult
 			Who:          a.root.entity.Tag().Id(),
 			What:         req.CLIArgs,
 			ModelName:    a.root.model.Name(),
+			ModelOwner:   a.root.model.Owner().Id(),
 			ModelUUID:    a.root.model.UUID(),
 			ConnectionID: a.root.connectionID,
 		},
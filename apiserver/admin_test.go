@@ -1147,6 +1147,7 @@ func (s *loginSuite) TestLoginAddsAuditConversationEventually(c *gc.C) {
 		What:           "hey you guys",
 		When:           cfg.Clock.Now().Format(time.RFC3339),
 		ModelName:      s.Model.Name(),
+		ModelOwner:     s.Model.Owner().Id(),
 		ModelUUID:      s.Model.UUID(),
 		ConnectionID:   "something",
 		ConversationID: "0123456789abcdef",
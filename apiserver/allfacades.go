@@ -34,6 +34,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/provisioner"
 	"github.com/juju/juju/apiserver/facades/agent/proxyupdater"
 	"github.com/juju/juju/apiserver/facades/agent/reboot"
+	"github.com/juju/juju/apiserver/facades/agent/remoteintrospection"
 	"github.com/juju/juju/apiserver/facades/agent/resourceshookcontext"
 	"github.com/juju/juju/apiserver/facades/agent/retrystrategy"
 	"github.com/juju/juju/apiserver/facades/agent/storageprovisioner"
@@ -45,8 +46,9 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/annotations" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/application" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
-	"github.com/juju/juju/apiserver/facades/client/backups" // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/block"   // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/auditlog" // Superuser Read
+	"github.com/juju/juju/apiserver/facades/client/backups"  // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/block"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/bundle"
 	"github.com/juju/juju/apiserver/facades/client/charms"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/client"     // ModelUser Write
@@ -57,6 +59,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/highavailability" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemanager"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemetadatamanager"
+	"github.com/juju/juju/apiserver/facades/client/introspection"  // ModelUser Admin
 	"github.com/juju/juju/apiserver/facades/client/keymanager"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/machinemanager" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/metricsdebug"   // ModelUser Write
@@ -71,6 +74,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/subnets"
 	"github.com/juju/juju/apiserver/facades/client/usermanager"
 	"github.com/juju/juju/apiserver/facades/controller/actionpruner"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
 	"github.com/juju/juju/apiserver/facades/controller/caasfirewaller"
@@ -78,6 +82,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/caasoperatorupgrader"
 	"github.com/juju/juju/apiserver/facades/controller/caasunitprovisioner"
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater"
+	"github.com/juju/juju/apiserver/facades/controller/charmrolloutupdater"
 	"github.com/juju/juju/apiserver/facades/controller/cleaner"
 	"github.com/juju/juju/apiserver/facades/controller/crosscontroller"
 	"github.com/juju/juju/apiserver/facades/controller/crossmodelrelations"
@@ -131,10 +136,15 @@ func AllFacades() *facade.Registry {
 
 	reg("Action", 2, action.NewActionAPIV2)
 	reg("Action", 3, action.NewActionAPIV3)
+	reg("Action", 4, action.NewActionAPIV4)
+	reg("Action", 5, action.NewActionAPIV5) // Adds WatchActionsProgress.
+	reg("Action", 6, action.NewActionAPIV6) // Adds streaming of juju-run output.
 	reg("ActionPruner", 1, actionpruner.NewAPI)
+	reg("ActionScheduler", 1, actionscheduler.NewStateAPI)
 	reg("Agent", 2, agent.NewAgentAPIV2)
 	reg("AgentTools", 1, agenttools.NewFacade)
 	reg("Annotations", 2, annotations.NewAPI)
+	reg("AuditLog", 1, auditlog.NewFacade)
 
 	// Application facade versions 1-4 share NewFacadeV4 as
 	// the newer methodology for versioning wasn't started with
@@ -147,17 +157,25 @@ func AllFacades() *facade.Registry {
 	reg("Application", 6, application.NewFacadeV6)
 	reg("Application", 7, application.NewFacadeV7)
 	reg("Application", 8, application.NewFacadeV8)
-	reg("Application", 9, application.NewFacadeV9) // ApplicationInfo; generational config; Force on App, Relation and Unit Removal.
+	reg("Application", 9, application.NewFacadeV9)   // ApplicationInfo; generational config; Force on App, Relation and Unit Removal.
+	reg("Application", 10, application.NewFacadeV10) // Expose to spaces and CIDRs.
+	reg("Application", 11, application.NewFacadeV11) // Adds UnitsState.
+	reg("Application", 12, application.NewFacadeV12) // Adds batched SetCharm and CharmRollouts.
+	reg("Application", 13, application.NewFacadeV13) // Adds charm refresh policies.
+	reg("Application", 14, application.NewFacadeV14) // Adds SetConstraints under a branch.
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
+	reg("ApplicationOffers", 3, applicationoffers.NewOffersAPIV3) // Offer approval and connection limits.
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
 	reg("Backups", 1, backups.NewFacade)
 	reg("Backups", 2, backups.NewFacadeV2)
 	reg("Block", 2, block.NewAPI)
 	reg("Bundle", 1, bundle.NewFacadeV1)
 	reg("Bundle", 2, bundle.NewFacadeV2)
-	reg("CharmRevisionUpdater", 2, charmrevisionupdater.NewCharmRevisionUpdaterAPI)
+	reg("CharmRevisionUpdater", 2, charmrevisionupdater.NewCharmRevisionUpdaterAPIV2)
+	reg("CharmRevisionUpdater", 3, charmrevisionupdater.NewCharmRevisionUpdaterAPI) // Adds ApplyCharmRefreshPolicies.
+	reg("CharmRolloutUpdater", 1, charmrolloutupdater.NewStateAPI)
 	reg("Charms", 2, charms.NewFacade)
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
 	reg("Client", 1, client.NewFacadeV1)
@@ -182,6 +200,7 @@ func AllFacades() *facade.Registry {
 	reg("Controller", 5, controller.NewControllerAPIv5)
 	reg("Controller", 6, controller.NewControllerAPIv6)
 	reg("Controller", 7, controller.NewControllerAPIv7)
+	reg("Controller", 8, controller.NewControllerAPIv8) // Adds DryRunMigration.
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPI)
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
 	reg("CredentialManager", 1, credentialmanager.NewCredentialManagerAPI)
@@ -195,6 +214,7 @@ func AllFacades() *facade.Registry {
 	reg("Firewaller", 3, firewaller.NewStateFirewallerAPIV3)
 	reg("Firewaller", 4, firewaller.NewStateFirewallerAPIV4)
 	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5)
+	reg("Firewaller", 6, firewaller.NewStateFirewallerAPIV6) // ExposeInfo.
 	reg("FirewallRules", 1, firewallrules.NewFacade)
 	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
@@ -208,6 +228,7 @@ func AllFacades() *facade.Registry {
 	reg("InstanceMutater", 1, instancemutater.NewFacadeV1)
 
 	reg("InstancePoller", 3, instancepoller.NewFacade)
+	reg("Introspection", 1, introspection.NewFacade)
 	reg("KeyManager", 1, keymanager.NewKeyManagerAPI)
 	reg("KeyUpdater", 1, keyupdater.NewKeyUpdaterAPI)
 
@@ -267,6 +288,7 @@ func AllFacades() *facade.Registry {
 	reg("ProxyUpdater", 1, proxyupdater.NewFacadeV1)
 	reg("ProxyUpdater", 2, proxyupdater.NewFacadeV2)
 	reg("Reboot", 2, reboot.NewRebootAPI)
+	reg("RemoteIntrospection", 1, remoteintrospection.NewFacade)
 	reg("RemoteRelations", 1, remoterelations.NewStateRemoteRelationsAPI)
 
 	reg("Resources", 1, resources.NewPublicFacade)
@@ -287,7 +309,8 @@ func AllFacades() *facade.Registry {
 	reg("Storage", 3, storage.NewStorageAPIV3)
 	reg("Storage", 4, storage.NewStorageAPIV4) // changes Destroy() method signature.
 	reg("Storage", 5, storage.NewStorageAPIV5) // Update and Delete storage pools and CreatePool bulk calls.
-	reg("Storage", 6, storage.NewStorageAPI)   // modify Remove to support force and maxWait; adde DetachStorage to support force and maxWait.
+	reg("Storage", 6, storage.NewStorageAPIV6) // modify Remove to support force and maxWait; adde DetachStorage to support force and maxWait.
+	reg("Storage", 7, storage.NewStorageAPI)   // add CreateSnapshots, ListSnapshots and DeleteSnapshots; AddToUnit accepts a snapshot ID; add ResizeStorage.
 
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacadeV3)
 	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4)
@@ -303,7 +326,9 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 9, uniter.NewUniterAPIV9)
 	reg("Uniter", 10, uniter.NewUniterAPIV10)
 	reg("Uniter", 11, uniter.NewUniterAPIV11)
-	reg("Uniter", 12, uniter.NewUniterAPI)
+	reg("Uniter", 12, uniter.NewUniterAPIV12)
+	reg("Uniter", 13, uniter.NewUniterAPIV13) // Adds State, SetState and CommitHookChanges.
+	reg("Uniter", 14, uniter.NewUniterAPI)    // Adds LogActionsMessages.
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 	reg("UpgradeSeries", 1, upgradeseries.NewAPI)
@@ -131,6 +131,28 @@ func FinishActions(args params.ActionExecutionResults, actionFn func(string) (st
 	return results
 }
 
+// LogActionsMessages records the progress messages logged by actions.
+// It's a helper function currently used by the uniter.
+// It needs an actionFn that can fetch an action from state using it's id that's usually created by AuthAndActionFromTagFn
+func LogActionsMessages(args params.ActionMessageParams, actionFn func(string) (state.Action, error)) params.ErrorResults {
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Messages))}
+
+	for i, arg := range args.Messages {
+		action, err := actionFn(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+		err = action.Log(arg.Value)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+	}
+
+	return results
+}
+
 // Actions returns the Actions by Tags passed in and ensures that the receiver asking for
 // them is the same one that has the action.
 // It's a helper function currently used by the uniter and by machineactions.
@@ -243,8 +265,25 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.A
 		Status:    string(action.Status()),
 		Message:   message,
 		Output:    output,
+		Log:       MakeActionMessages(action.Messages()),
 		Enqueued:  action.Enqueued(),
 		Started:   action.Started(),
 		Completed: action.Completed(),
 	}
 }
+
+// MakeActionMessages converts the progress messages logged by an
+// action to their API representation.
+func MakeActionMessages(messages []state.ActionMessage) []params.ActionMessage {
+	if len(messages) == 0 {
+		return nil
+	}
+	result := make([]params.ActionMessage, len(messages))
+	for i, m := range messages {
+		result[i] = params.ActionMessage{
+			Timestamp: m.Timestamp,
+			Message:   m.Message,
+		}
+	}
+	return result
+}
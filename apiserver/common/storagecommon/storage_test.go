@@ -99,6 +99,7 @@ func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentPlanInfoDeviceNa
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: "/dev/sdb",
+		Size:     1024,
 	})
 }
 
@@ -110,6 +111,7 @@ func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentInfoPersistentDe
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: "/dev/sda",
+		Size:     1024,
 	})
 }
 
@@ -134,6 +136,7 @@ func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentInfoPersistentDe
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: "/dev/sda",
+		Size:     1024,
 	})
 }
 
@@ -145,6 +148,7 @@ func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentInfoPersistentDe
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: "/dev/disk/by-id/verbatim",
+		Size:     1024,
 	})
 }
 
@@ -156,6 +160,7 @@ func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentInfoPersistentHa
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: "/dev/disk/by-id/whatever",
+		Size:     1024,
 	})
 }
 
@@ -167,6 +172,7 @@ func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentInfoPersistentWW
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: "/dev/disk/by-id/wwn-drbr",
+		Size:     1024,
 	})
 }
 
@@ -187,6 +193,7 @@ func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentInfoMatchingBloc
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: "/dev/sdb",
+		Size:     1024,
 	})
 }
 
@@ -266,6 +273,7 @@ func (s *FilesystemStorageAttachmentInfoSuite) TestStorageAttachmentInfo(c *gc.C
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindFilesystem,
 		Location: "/path/to/here",
+		Size:     1024,
 	})
 }
 
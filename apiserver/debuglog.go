@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -82,6 +83,10 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - RFC3339 time, only send lines logged at or after this time
+//   endTime -> string - RFC3339 time, only send lines logged before this time
+//      - once the end time has passed, no new lines are waited for
+//   messagePattern -> string - regular expression that log messages must match
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	handler := func(conn *websocket.Conn) {
 		socket := &debugLogSocketImpl{conn}
@@ -174,16 +179,18 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
-	startTime     time.Time
-	maxLines      uint
-	fromTheStart  bool
-	noTail        bool
-	backlog       uint
-	filterLevel   loggo.Level
-	includeEntity []string
-	excludeEntity []string
-	includeModule []string
-	excludeModule []string
+	startTime      time.Time
+	endTime        time.Time
+	maxLines       uint
+	fromTheStart   bool
+	noTail         bool
+	backlog        uint
+	filterLevel    loggo.Level
+	includeEntity  []string
+	excludeEntity  []string
+	includeModule  []string
+	excludeModule  []string
+	messagePattern string
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -239,6 +246,25 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return params, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		if !params.startTime.IsZero() && !endTime.After(params.startTime) {
+			return params, errors.Errorf("end time %q is not after start time %q",
+				value, params.startTime.Format(time.RFC3339Nano))
+		}
+		params.endTime = endTime
+	}
+
+	if value := queryMap.Get("messagePattern"); value != "" {
+		if _, err := regexp.Compile(value); err != nil {
+			return params, errors.Errorf("message pattern %q is not a valid regular expression", value)
+		}
+		params.messagePattern = value
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
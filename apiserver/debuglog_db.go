@@ -61,14 +61,16 @@ func handleDebugLogDBRequest(
 
 func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 	params := state.LogTailerParams{
-		MinLevel:      reqParams.filterLevel,
-		NoTail:        reqParams.noTail,
-		StartTime:     reqParams.startTime,
-		InitialLines:  int(reqParams.backlog),
-		IncludeEntity: reqParams.includeEntity,
-		ExcludeEntity: reqParams.excludeEntity,
-		IncludeModule: reqParams.includeModule,
-		ExcludeModule: reqParams.excludeModule,
+		MinLevel:       reqParams.filterLevel,
+		NoTail:         reqParams.noTail,
+		StartTime:      reqParams.startTime,
+		EndTime:        reqParams.endTime,
+		InitialLines:   int(reqParams.backlog),
+		IncludeEntity:  reqParams.includeEntity,
+		ExcludeEntity:  reqParams.excludeEntity,
+		IncludeModule:  reqParams.includeModule,
+		ExcludeModule:  reqParams.excludeModule,
+		MessagePattern: reqParams.messagePattern,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
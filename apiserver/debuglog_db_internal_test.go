@@ -31,25 +31,27 @@ func (s *debugLogDBIntSuite) SetUpTest(c *gc.C) {
 
 func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	t1 := time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC)
+	t2 := time.Date(2016, 11, 30, 11, 51, 0, 0, time.UTC)
 	reqParams := debugLogParams{
-		fromTheStart:  false,
-		noTail:        true,
-		backlog:       11,
-		startTime:     t1,
-		filterLevel:   loggo.INFO,
-		includeEntity: []string{"foo"},
-		includeModule: []string{"bar"},
-		excludeEntity: []string{"baz"},
-		excludeModule: []string{"qux"},
+		fromTheStart:   false,
+		noTail:         true,
+		backlog:        11,
+		startTime:      t1,
+		endTime:        t2,
+		filterLevel:    loggo.INFO,
+		includeEntity:  []string{"foo"},
+		includeModule:  []string{"bar"},
+		excludeEntity:  []string{"baz"},
+		excludeModule:  []string{"qux"},
+		messagePattern: "hook failed",
 	}
 
 	called := false
 	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
 		called = true
 
-		// Start time will be used once the client is extended to send
-		// time range arguments.
 		c.Assert(params.StartTime, gc.Equals, t1)
+		c.Assert(params.EndTime, gc.Equals, t2)
 		c.Assert(params.NoTail, jc.IsTrue)
 		c.Assert(params.MinLevel, gc.Equals, loggo.INFO)
 		c.Assert(params.InitialLines, gc.Equals, 11)
@@ -57,6 +59,7 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.MessagePattern, gc.Equals, "hook failed")
 
 		return newFakeLogTailer(), nil
 	})
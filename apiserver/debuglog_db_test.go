@@ -35,6 +35,25 @@ func (s *debugLogDBSuite) TestBadParams(c *gc.C) {
 	websockettest.AssertWebsocketClosed(c, conn)
 }
 
+func (s *debugLogDBSuite) TestBadMessagePattern(c *gc.C) {
+	conn := s.dialWebsocket(c, url.Values{"messagePattern": {"hook (failed"}})
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, `message pattern "hook \(failed" is not a valid regular expression`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *debugLogDBSuite) TestEndTimeBeforeStartTime(c *gc.C) {
+	conn := s.dialWebsocket(c, url.Values{
+		"startTime": {"2016-11-30T11:00:00Z"},
+		"endTime":   {"2016-11-30T10:00:00Z"},
+	})
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, `end time "2016-11-30T10:00:00Z" is not after start time "2016-11-30T11:00:00Z"`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
 func (s *debugLogDBSuite) TestWithHTTP(c *gc.C) {
 	uri := s.logURL("http", nil).String()
 	apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
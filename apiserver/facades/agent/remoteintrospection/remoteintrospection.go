@@ -0,0 +1,139 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoteintrospection implements the RemoteIntrospection
+// facade, which machine and unit agents use to answer introspection
+// requests relayed to them through the controller.
+package remoteintrospection
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend defines the state methods used by the RemoteIntrospection
+// facade.
+type Backend interface {
+	WatchIntrospectionRequests(agent names.Tag) state.StringsWatcher
+	IntrospectionRequest(id string) (Request, error)
+}
+
+// Request defines the methods of a state introspection request used
+// by the RemoteIntrospection facade.
+type Request interface {
+	Agent() (names.Tag, error)
+	Path() string
+	Completed() bool
+	Complete(response, errMsg string) error
+}
+
+// Facade implements the RemoteIntrospection facade.
+type Facade struct {
+	backend    Backend
+	resources  facade.Resources
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*Facade, error) {
+	return NewAPI(backendShim{ctx.State()}, ctx.Resources(), ctx.Auth())
+}
+
+// NewAPI returns a new RemoteIntrospection facade. Only machine and
+// unit agents may use it.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() && !authorizer.AuthUnitAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:    backend,
+		resources:  resources,
+		authorizer: authorizer,
+	}, nil
+}
+
+// WatchRequests returns a StringsWatcher for the ids of introspection
+// requests made of each of the given agents.
+func (f *Facade) WatchRequests(args params.Entities) params.StringsWatchResults {
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !f.authorizer.AuthOwner(tag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		w := f.backend.WatchIntrospectionRequests(tag)
+		if changes, ok := <-w.Changes(); ok {
+			results.Results[i].StringsWatcherId = f.resources.Register(w)
+			results.Results[i].Changes = changes
+		} else {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
+		}
+	}
+	return results
+}
+
+// Requests returns the details of the introspection requests with the
+// given ids.
+func (f *Facade) Requests(args params.IntrospectionRequestIds) params.IntrospectionRequestResults {
+	results := params.IntrospectionRequestResults{
+		Results: make([]params.IntrospectionRequestResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		req, err := f.request(id)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = &params.IntrospectionRequest{
+			Id:        id,
+			Path:      req.Path(),
+			Completed: req.Completed(),
+		}
+	}
+	return results
+}
+
+// Complete records the agents' responses to introspection requests.
+func (f *Facade) Complete(args params.IntrospectionResponses) params.ErrorResults {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Responses)),
+	}
+	for i, response := range args.Responses {
+		req, err := f.request(response.Id)
+		if err == nil {
+			err = req.Complete(response.Response, response.Error)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results
+}
+
+// request returns the introspection request with the given id, if it
+// was made of the authenticated agent.
+func (f *Facade) request(id string) (Request, error) {
+	req, err := f.backend.IntrospectionRequest(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	agent, err := req.Agent()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !f.authorizer.AuthOwner(agent) {
+		return nil, common.ErrPerm
+	}
+	return req, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoteintrospection_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/remoteintrospection"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type remoteIntrospectionSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&remoteIntrospectionSuite{})
+
+func (s *remoteIntrospectionSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		requests: map[string]*mockRequest{
+			"0#introspect#1": {agent: names.NewMachineTag("0"), path: "/depengine"},
+			"1#introspect#2": {agent: names.NewMachineTag("1"), path: "/depengine"},
+		},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	}
+}
+
+func (s *remoteIntrospectionSuite) newFacade(c *gc.C) *remoteintrospection.Facade {
+	facade, err := remoteintrospection.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return facade
+}
+
+func (s *remoteIntrospectionSuite) TestClientDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	_, err := remoteintrospection.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *remoteIntrospectionSuite) TestWatchRequests(c *gc.C) {
+	changes := make(chan []string, 1)
+	changes <- []string{"0#introspect#1"}
+	s.backend.watcher = statetesting.NewMockStringsWatcher(changes)
+
+	facade := s.newFacade(c)
+	results := facade.WatchRequests(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1"}},
+	})
+	c.Assert(results, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{{
+			StringsWatcherId: "1",
+			Changes:          []string{"0#introspect#1"},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+		}},
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"WatchIntrospectionRequests", []interface{}{names.NewMachineTag("0")}},
+	})
+	c.Assert(s.resources.Get("1"), gc.Equals, s.backend.watcher)
+}
+
+func (s *remoteIntrospectionSuite) TestRequests(c *gc.C) {
+	facade := s.newFacade(c)
+	results := facade.Requests(params.IntrospectionRequestIds{
+		Ids: []string{"0#introspect#1", "1#introspect#2", "0#introspect#3"},
+	})
+	c.Assert(results, jc.DeepEquals, params.IntrospectionRequestResults{
+		Results: []params.IntrospectionRequestResult{{
+			Result: &params.IntrospectionRequest{Id: "0#introspect#1", Path: "/depengine"},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+		}, {
+			Error: &params.Error{Message: `introspection request "0#introspect#3" not found`, Code: params.CodeNotFound},
+		}},
+	})
+}
+
+func (s *remoteIntrospectionSuite) TestComplete(c *gc.C) {
+	facade := s.newFacade(c)
+	results := facade.Complete(params.IntrospectionResponses{
+		Responses: []params.IntrospectionResponse{
+			{Id: "0#introspect#1", Response: "Dependency Engine Report"},
+			{Id: "1#introspect#2", Response: "sneaky"},
+		},
+	})
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+		},
+	})
+	s.backend.requests["0#introspect#1"].CheckCall(c, 0, "Complete", "Dependency Engine Report", "")
+	s.backend.requests["1#introspect#2"].CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	testing.Stub
+	watcher  state.StringsWatcher
+	requests map[string]*mockRequest
+}
+
+func (b *mockBackend) WatchIntrospectionRequests(agent names.Tag) state.StringsWatcher {
+	b.MethodCall(b, "WatchIntrospectionRequests", agent)
+	return b.watcher
+}
+
+func (b *mockBackend) IntrospectionRequest(id string) (remoteintrospection.Request, error) {
+	b.MethodCall(b, "IntrospectionRequest", id)
+	req, ok := b.requests[id]
+	if !ok {
+		return nil, errors.NotFoundf("introspection request %q", id)
+	}
+	return req, nil
+}
+
+type mockRequest struct {
+	testing.Stub
+	agent     names.Tag
+	path      string
+	completed bool
+}
+
+func (r *mockRequest) Agent() (names.Tag, error) {
+	return r.agent, nil
+}
+
+func (r *mockRequest) Path() string {
+	return r.path
+}
+
+func (r *mockRequest) Completed() bool {
+	return r.completed
+}
+
+func (r *mockRequest) Complete(response, errMsg string) error {
+	r.MethodCall(r, "Complete", response, errMsg)
+	return r.NextErr()
+}
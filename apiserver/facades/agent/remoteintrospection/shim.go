@@ -0,0 +1,17 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoteintrospection
+
+import (
+	"github.com/juju/juju/state"
+)
+
+type backendShim struct {
+	*state.State
+}
+
+// IntrospectionRequest is part of the Backend interface.
+func (b backendShim) IntrospectionRequest(id string) (Request, error) {
+	return b.State.IntrospectionRequest(id)
+}
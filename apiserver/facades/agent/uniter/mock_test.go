@@ -21,6 +21,7 @@ type fakeStorage struct {
 	volumeAttachment       func(names.Tag, names.VolumeTag) (state.VolumeAttachment, error)
 	volumeAttachmentPlan   func(names.Tag, names.VolumeTag) (state.VolumeAttachmentPlan, error)
 	blockDevices           func(names.MachineTag) ([]state.BlockDeviceInfo, error)
+	watchVolume            func(names.VolumeTag) state.NotifyWatcher
 	watchVolumeAttachment  func(names.Tag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices      func(names.MachineTag) state.NotifyWatcher
 	watchStorageAttachment func(names.StorageTag, names.UnitTag) state.NotifyWatcher
@@ -51,6 +52,11 @@ func (s *fakeStorage) BlockDevices(m names.MachineTag) ([]state.BlockDeviceInfo,
 	return s.blockDevices(m)
 }
 
+func (s *fakeStorage) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	s.MethodCall(s, "WatchVolume", v)
+	return s.watchVolume(v)
+}
+
 func (s *fakeStorage) WatchVolumeAttachment(host names.Tag, v names.VolumeTag) state.NotifyWatcher {
 	s.MethodCall(s, "WatchVolumeAttachment", host, v)
 	return s.watchVolumeAttachment(host, v)
@@ -205,6 +205,7 @@ func (s *StorageAPI) fromStateStorageAttachment(stateStorageAttachment state.Sto
 		params.StorageKind(stateStorageInstance.Kind()),
 		info.Location,
 		params.Life(stateStorageAttachment.Life().String()),
+		info.Size,
 	}, nil
 }
 
@@ -457,10 +458,12 @@ func watchStorageAttachment(
 		if err != nil {
 			return nil, errors.Annotate(err, "getting storage volume")
 		}
-		// We need to watch both the volume attachment, and the
-		// machine's block devices. A volume attachment's block
-		// device could change (most likely, become present).
+		// We need to watch the volume, the volume attachment, and
+		// the machine's block devices. A volume attachment's block
+		// device could change (most likely, become present), and
+		// the volume's size changes when the storage is resized.
 		watchers = []state.NotifyWatcher{
+			stVolume.WatchVolume(volume.VolumeTag()),
 			stVolume.WatchVolumeAttachment(hostTag, volume.VolumeTag()),
 		}
 
@@ -483,7 +486,10 @@ func watchStorageAttachment(
 		if err != nil {
 			return nil, errors.Annotate(err, "getting storage filesystem")
 		}
+		// The filesystem is watched so that the unit is notified
+		// when the storage is resized.
 		watchers = []state.NotifyWatcher{
+			stFile.WatchFilesystem(filesystem.FilesystemTag()),
 			stFile.WatchFilesystemAttachment(hostTag, filesystem.FilesystemTag()),
 		}
 	default:
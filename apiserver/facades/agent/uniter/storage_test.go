@@ -81,6 +81,10 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 		changes: make(chan struct{}, 1),
 	}
 	volumeWatcher.changes <- struct{}{}
+	volumeAttachmentWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	volumeAttachmentWatcher.changes <- struct{}{}
 	blockDevicesWatcher := &mockNotifyWatcher{
 		changes: make(chan struct{}, 1),
 	}
@@ -104,11 +108,16 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 			c.Assert(u, gc.DeepEquals, unitTag)
 			return storageWatcher
 		},
+		watchVolume: func(v names.VolumeTag) state.NotifyWatcher {
+			calls = append(calls, "WatchVolume")
+			c.Assert(v, gc.DeepEquals, volumeTag)
+			return volumeWatcher
+		},
 		watchVolumeAttachment: func(host names.Tag, v names.VolumeTag) state.NotifyWatcher {
 			calls = append(calls, "WatchVolumeAttachment")
 			c.Assert(host, gc.DeepEquals, machineTag)
 			c.Assert(v, gc.DeepEquals, volumeTag)
-			return volumeWatcher
+			return volumeAttachmentWatcher
 		},
 		watchBlockDevices: func(m names.MachineTag) state.NotifyWatcher {
 			calls = append(calls, "WatchBlockDevices")
@@ -134,6 +143,7 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 	c.Assert(calls, gc.DeepEquals, []string{
 		"StorageInstance",
 		"StorageInstanceVolume",
+		"WatchVolume",
 		"WatchVolumeAttachment",
 		"WatchBlockDevices",
 		"WatchStorageAttachment",
@@ -173,6 +183,10 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 		changes: make(chan struct{}, 1),
 	}
 	filesystemWatcher.changes <- struct{}{}
+	filesystemAttachmentWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	filesystemAttachmentWatcher.changes <- struct{}{}
 	var calls []string
 	st := &mockStorageState{
 		assignedMachine: assignedMachine,
@@ -192,11 +206,16 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 			c.Assert(u, gc.DeepEquals, unitTag)
 			return storageWatcher
 		},
+		watchFilesystem: func(f names.FilesystemTag) state.NotifyWatcher {
+			calls = append(calls, "WatchFilesystem")
+			c.Assert(f, gc.DeepEquals, filesystemTag)
+			return filesystemWatcher
+		},
 		watchFilesystemAttachment: func(host names.Tag, f names.FilesystemTag) state.NotifyWatcher {
 			calls = append(calls, "WatchFilesystemAttachment")
 			c.Assert(host, gc.DeepEquals, hostTag)
 			c.Assert(f, gc.DeepEquals, filesystemTag)
-			return filesystemWatcher
+			return filesystemAttachmentWatcher
 		},
 	}
 
@@ -217,6 +236,7 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 	c.Assert(calls, gc.DeepEquals, []string{
 		"StorageInstance",
 		"StorageInstanceFilesystem",
+		"WatchFilesystem",
 		"WatchFilesystemAttachment",
 		"WatchStorageAttachment",
 	})
@@ -488,7 +508,9 @@ type mockStorageState struct {
 	storageInstanceVolume         func(names.StorageTag) (state.Volume, error)
 	watchStorageAttachments       func(names.UnitTag) state.StringsWatcher
 	watchStorageAttachment        func(names.StorageTag, names.UnitTag) state.NotifyWatcher
+	watchFilesystem               func(names.FilesystemTag) state.NotifyWatcher
 	watchFilesystemAttachment     func(names.Tag, names.FilesystemTag) state.NotifyWatcher
+	watchVolume                   func(names.VolumeTag) state.NotifyWatcher
 	watchVolumeAttachment         func(names.Tag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices             func(names.MachineTag) state.NotifyWatcher
 	addUnitStorage                func(u names.UnitTag, name string, cons state.StorageConstraints) error
@@ -536,6 +558,14 @@ func (m *mockStorageState) WatchStorageAttachment(s names.StorageTag, u names.Un
 	return m.watchStorageAttachment(s, u)
 }
 
+func (m *mockStorageState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return m.watchFilesystem(f)
+}
+
+func (m *mockStorageState) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	return m.watchVolume(v)
+}
+
 func (m *mockStorageState) WatchFilesystemAttachment(hostTag names.Tag, f names.FilesystemTag) state.NotifyWatcher {
 	return m.watchFilesystemAttachment(hostTag, f)
 }
@@ -621,6 +651,7 @@ type watchStorageAttachmentSuite struct {
 	st                       *fakeStorage
 	storageInstance          *fakeStorageInstance
 	volume                   *fakeVolume
+	volumeWatcher            *apiservertesting.FakeNotifyWatcher
 	volumeAttachmentWatcher  *apiservertesting.FakeNotifyWatcher
 	blockDevicesWatcher      *apiservertesting.FakeNotifyWatcher
 	storageAttachmentWatcher *apiservertesting.FakeNotifyWatcher
@@ -638,6 +669,7 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 		kind:  state.StorageKindBlock,
 	}
 	s.volume = &fakeVolume{tag: names.NewVolumeTag("0")}
+	s.volumeWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.volumeAttachmentWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.blockDevicesWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.storageAttachmentWatcher = apiservertesting.NewFakeNotifyWatcher()
@@ -648,6 +680,9 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 		storageInstanceVolume: func(tag names.StorageTag) (state.Volume, error) {
 			return s.volume, nil
 		},
+		watchVolume: func(names.VolumeTag) state.NotifyWatcher {
+			return s.volumeWatcher
+		},
 		watchVolumeAttachment: func(names.Tag, names.VolumeTag) state.NotifyWatcher {
 			return s.volumeAttachmentWatcher
 		},
@@ -660,6 +695,12 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 	}
 }
 
+func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentVolumeChanges(c *gc.C) {
+	s.testWatchBlockStorageAttachment(c, func() {
+		s.volumeWatcher.C <- struct{}{}
+	})
+}
+
 func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentVolumeAttachmentChanges(c *gc.C) {
 	s.testWatchBlockStorageAttachment(c, func() {
 		s.volumeAttachmentWatcher.C <- struct{}{}
@@ -683,6 +724,7 @@ func (s *watchStorageAttachmentSuite) testWatchBlockStorageAttachment(c *gc.C, c
 	s.st.CheckCallNames(c,
 		"StorageInstance",
 		"StorageInstanceVolume",
+		"WatchVolume",
 		"WatchVolumeAttachment",
 		"WatchBlockDevices",
 		"WatchStorageAttachment",
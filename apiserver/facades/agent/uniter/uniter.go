@@ -69,11 +69,22 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
-// UniterAPIV11 implements the latest version (v11) of the Uniter API,
-// which adds CloudAPIVersion.
+// UniterAPIV13 implements version (v13) of the Uniter API, which
+// adds State and SetState. It doesn't have LogActionsMessages.
+type UniterAPIV13 struct {
+	UniterAPI
+}
+
+// UniterAPIV12 implements version (v12) of the Uniter API, which
+// removes LXDProfileAPI. It doesn't have State or SetState.
+type UniterAPIV12 struct {
+	UniterAPIV13
+}
+
+// UniterAPIV11 adds CloudAPIVersion.
 type UniterAPIV11 struct {
 	*LXDProfileAPI
-	UniterAPI
+	UniterAPIV12
 }
 
 // UniterAPIV10 adds WatchUnitLXDProfileUpgradeNotifications and
@@ -301,6 +312,28 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV13 creates an instance of the V13 uniter API.
+func NewUniterAPIV13(context facade.Context) (*UniterAPIV13, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV13{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV12 creates an instance of the V12 uniter API.
+func NewUniterAPIV12(context facade.Context) (*UniterAPIV12, error) {
+	uniterAPI, err := NewUniterAPIV13(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV12{
+		UniterAPIV13: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV11 creates an instance of the V11 uniter API.
 func NewUniterAPIV11(context facade.Context) (*UniterAPIV11, error) {
 	uniterAPI, err := NewUniterAPI(context)
@@ -313,7 +346,7 @@ func NewUniterAPIV11(context facade.Context) (*UniterAPIV11, error) {
 	accessUnit := unitAccessor(authorizer, st)
 	return &UniterAPIV11{
 		LXDProfileAPI: NewExternalLXDProfileAPI(st, resources, authorizer, accessUnit, logger),
-		UniterAPI:     *uniterAPI,
+		UniterAPIV12:  UniterAPIV12{UniterAPIV13{*uniterAPI}},
 	}, nil
 }
 
@@ -474,6 +507,7 @@ func (u *UniterAPI) getOneMachinePorts(canAccess common.AuthFunc, machineTag str
 		// AllPortRanges gives a map, but apis require a stable order
 		// for results, so sort the port ranges.
 		portRangesToUnits := ports.AllPortRanges()
+		portRangesToEndpoints := ports.PortRangeEndpoints()
 		portRanges := make([]corenetwork.PortRange, 0, len(portRangesToUnits))
 		for portRange := range portRangesToUnits {
 			portRanges = append(portRanges, portRange)
@@ -484,6 +518,7 @@ func (u *UniterAPI) getOneMachinePorts(canAccess common.AuthFunc, machineTag str
 			resultPorts = append(resultPorts, params.MachinePortRange{
 				UnitTag:   names.NewUnitTag(unitName).String(),
 				PortRange: params.FromNetworkPortRange(portRange),
+				Endpoints: portRangesToEndpoints[portRange],
 			})
 		}
 	}
@@ -821,6 +856,18 @@ func (u *UniterAPI) charmModifiedVersion(tagStr string, canAccess func(names.Tag
 	var application *state.Application
 	switch entity := unitOrApplication.(type) {
 	case *state.Application:
+		// When a unit agent asks for its own application's version,
+		// the result takes into account any charm rollout in progress.
+		if unitTag, ok := u.auth.GetAuthTag().(names.UnitTag); ok {
+			appName, err := names.UnitApplication(unitTag.Id())
+			if err == nil && appName == entity.Name() {
+				unit, err := u.getUnit(unitTag)
+				if err != nil {
+					return -1, errors.Trace(err)
+				}
+				return unit.ApplicationCharmModifiedVersion()
+			}
+		}
 		application = entity
 	case *state.Unit:
 		application, err = entity.Application()
@@ -854,10 +901,9 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 			var unitOrApplication state.Entity
 			unitOrApplication, err = u.st.FindEntity(tag)
 			if err == nil {
-				charmURLer := unitOrApplication.(interface {
-					CharmURL() (*charm.URL, bool)
-				})
-				curl, ok := charmURLer.CharmURL()
+				var curl *charm.URL
+				var ok bool
+				curl, ok, err = u.entityCharmURL(unitOrApplication)
 				if curl != nil {
 					result.Results[i].Result = curl.String()
 					result.Results[i].Ok = ok
@@ -869,6 +915,30 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 	return result, nil
 }
 
+// entityCharmURL returns the charm URL for the input unit or application.
+// When a unit agent asks for its own application's charm URL, the result
+// takes into account any charm upgrade staged under the branch that the
+// unit is tracking.
+func (u *UniterAPI) entityCharmURL(entity state.Entity) (*charm.URL, bool, error) {
+	if app, ok := entity.(*state.Application); ok {
+		if unitTag, ok := u.auth.GetAuthTag().(names.UnitTag); ok {
+			appName, err := names.UnitApplication(unitTag.Id())
+			if err == nil && appName == app.Name() {
+				unit, err := u.getUnit(unitTag)
+				if err != nil {
+					return nil, false, errors.Trace(err)
+				}
+				return unit.ApplicationCharmURL()
+			}
+		}
+	}
+	charmURLer := entity.(interface {
+		CharmURL() (*charm.URL, bool)
+	})
+	curl, ok := charmURLer.CharmURL()
+	return curl, ok, nil
+}
+
 // SetCharmURL sets the charm URL for each given unit. An error will
 // be returned if a unit is dead, or the charm URL is not know.
 func (u *UniterAPI) SetCharmURL(args params.EntitiesCharmURL) (params.ErrorResults, error) {
@@ -972,7 +1042,8 @@ func (u *UniterAPI) SetWorkloadVersion(args params.EntityWorkloadVersions) (para
 }
 
 // OpenPorts sets the policy of the port range with protocol to be
-// opened, for all given units.
+// opened, for all given units. Ranges are opened for the given
+// endpoint, or for all of the unit's endpoints if none is given.
 func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -992,7 +1063,7 @@ func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResul
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
 			if err == nil {
-				err = unit.OpenPorts(entity.Protocol, entity.FromPort, entity.ToPort)
+				err = unit.OpenPortsForEndpoint(entity.Endpoint, entity.Protocol, entity.FromPort, entity.ToPort)
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -1021,7 +1092,7 @@ func (u *UniterAPI) ClosePorts(args params.EntitiesPortRanges) (params.ErrorResu
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
 			if err == nil {
-				err = unit.ClosePorts(entity.Protocol, entity.FromPort, entity.ToPort)
+				err = unit.ClosePortsForEndpoint(entity.Endpoint, entity.Protocol, entity.FromPort, entity.ToPort)
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -1222,6 +1293,26 @@ func (u *UniterAPI) FinishActions(args params.ActionExecutionResults) (params.Er
 	return common.FinishActions(args, actionFn), nil
 }
 
+// LogActionsMessages isn't on the v13 API.
+func (u *UniterAPIV13) LogActionsMessages(_, _ struct{}) {}
+
+// LogActionsMessages records the progress messages logged by the
+// given running actions.
+func (u *UniterAPI) LogActionsMessages(args params.ActionMessageParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	m, err := u.st.Model()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, m.ActionByTag)
+	return common.LogActionsMessages(args, actionFn), nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -2931,3 +3022,162 @@ func (u *UniterAPI) CloudAPIVersion() (params.StringResult, error) {
 	result.Result = apiVersion
 	return result, err
 }
+
+// State isn't on the v12 API.
+func (u *UniterAPIV12) State(_, _ struct{}) {}
+
+// SetState isn't on the v12 API.
+func (u *UniterAPIV12) SetState(_, _ struct{}) {}
+
+// CommitHookChanges isn't on the v12 API.
+func (u *UniterAPIV12) CommitHookChanges(_, _ struct{}) {}
+
+// State returns the private key/value state stored by each given
+// unit's charm.
+func (u *UniterAPI) State(args params.Entities) (params.UnitStateResults, error) {
+	result := params.UnitStateResults{
+		Results: make([]params.UnitStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.UnitStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		unitState, err := unit.State()
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].State = unitState
+	}
+	return result, nil
+}
+
+// SetState replaces the private key/value state stored by each
+// given unit's charm.
+func (u *UniterAPI) SetState(args params.SetUnitStateArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := unit.SetState(arg.State); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// CommitHookChanges commits the changes made by a charm hook of each
+// given unit: the unit's relation settings, opened and closed ports,
+// added storage and charm state. The changes for each unit are made
+// in a single transaction, so either all or none of them are made.
+func (u *UniterAPI) CommitHookChanges(args params.CommitHookChangesArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = u.commitHookChanges(canAccess, tag, arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) commitHookChanges(canAccess common.AuthFunc, tag names.UnitTag, arg params.CommitHookChangesArg) error {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return err
+	}
+	changes := state.HookChanges{State: arg.State}
+	for _, rs := range arg.RelationSettings {
+		if rs.Unit != arg.Tag {
+			return common.ErrPerm
+		}
+		relUnit, err := u.getRelationUnit(canAccess, rs.Relation, tag)
+		if err != nil {
+			return err
+		}
+		changes.RelationSettings = append(changes.RelationSettings, state.HookRelationSettings{
+			RelationUnit: relUnit,
+			Settings:     rs.Settings,
+		})
+	}
+	for _, p := range arg.OpenPorts {
+		ports, err := unit.EndpointPortRange(p.Endpoint, p.Protocol, p.FromPort, p.ToPort)
+		if err != nil {
+			return err
+		}
+		changes.OpenPorts = append(changes.OpenPorts, ports)
+	}
+	for _, p := range arg.ClosePorts {
+		ports, err := unit.EndpointPortRange(p.Endpoint, p.Protocol, p.FromPort, p.ToPort)
+		if err != nil {
+			return err
+		}
+		changes.ClosePorts = append(changes.ClosePorts, ports)
+	}
+	if len(arg.AddStorage) > 0 {
+		cons, err := unitStorageConstraints(u.StorageAPI.backend, tag)
+		if err != nil {
+			return err
+		}
+		for _, one := range arg.AddStorage {
+			if one.UnitTag != arg.Tag {
+				return common.ErrPerm
+			}
+			oneCons, err := validConstraints(one, cons)
+			if err != nil {
+				return errors.Annotatef(err, "adding storage %v for %v", one.StorageName, one.UnitTag)
+			}
+			changes.AddStorage = append(changes.AddStorage, state.HookStorage{
+				StorageName: one.StorageName,
+				Constraints: oneCons,
+			})
+		}
+	}
+	return unit.CommitHookChanges(changes)
+}
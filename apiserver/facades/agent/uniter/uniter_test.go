@@ -953,6 +953,82 @@ func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
 	c.Assert(newVersion, gc.Equals, "shiro")
 }
 
+func (s *uniterSuite) TestSetState(c *gc.C) {
+	args := params.SetUnitStateArgs{Args: []params.SetUnitStateArg{
+		{Tag: "unit-mysql-0", State: map[string]string{"foo": "bar"}},
+		{Tag: "unit-wordpress-0", State: map[string]string{"foo": "bar"}},
+		{Tag: "unit-foo-42", State: map[string]string{"foo": "bar"}},
+	}}
+	result, err := s.uniter.SetState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	unitState, err := s.wordpressUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
+func (s *uniterSuite) TestState(c *gc.C) {
+	err := s.wordpressUnit.SetState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.State(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UnitStateResults{
+		Results: []params.UnitStateResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{State: map[string]string{"foo": "bar"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestCommitHookChanges(c *gc.C) {
+	unitState := map[string]string{"foo": "bar"}
+	args := params.CommitHookChangesArgs{Args: []params.CommitHookChangesArg{{
+		Tag:   "unit-mysql-0",
+		State: &unitState,
+	}, {
+		Tag:       "unit-wordpress-0",
+		OpenPorts: []params.EntityPortRange{{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 80, ToPort: 80}},
+		State:     &unitState,
+	}, {
+		Tag:       "unit-wordpress-0",
+		OpenPorts: []params.EntityPortRange{{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 81, ToPort: 81, Endpoint: "missing"}},
+		State:     &map[string]string{"foo": "baz"},
+	}, {
+		Tag: "unit-foo-42",
+	}}}
+	result, err := s.uniter.CommitHookChanges(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Assert(result.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `application "wordpress" has no "missing" relation`)
+	c.Assert(result.Results[3].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	// The failed changes were not made.
+	openedPorts, err := s.wordpressUnit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(openedPorts, gc.DeepEquals, []corenetwork.PortRange{
+		{Protocol: "tcp", FromPort: 80, ToPort: 80},
+	})
+	stored, err := s.wordpressUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
 func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "application-mysql"},
@@ -1000,6 +1076,26 @@ func (s *uniterSuite) TestOpenPorts(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestOpenPortsForEndpoint(c *gc.C) {
+	args := params.EntitiesPortRanges{Entities: []params.EntityPortRange{
+		{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 80, ToPort: 80, Endpoint: "url"},
+		{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 81, ToPort: 81, Endpoint: "missing"},
+	}}
+	result, err := s.uniter.OpenPorts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `application "wordpress" has no "missing" relation`)
+
+	machine, err := s.State.Machine(s.machine0.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.PortRangeEndpoints(), jc.DeepEquals, map[corenetwork.PortRange][]string{
+		{Protocol: "tcp", FromPort: 80, ToPort: 80}: {"url"},
+	})
+}
+
 func (s *uniterSuite) TestClosePorts(c *gc.C) {
 	// Open port udp:4321 in advance on wordpressUnit.
 	err := s.wordpressUnit.OpenPorts("udp", 4321, 5000)
@@ -1694,6 +1790,36 @@ func (s *uniterSuite) TestFinishActionsSuccess(c *gc.C) {
 	c.Assert(results[0].Name(), gc.Equals, testName)
 }
 
+func (s *uniterSuite) TestLogActionsMessages(c *gc.C) {
+	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	mysqlAction, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, err := s.uniter.LogActionsMessages(params.ActionMessageParams{
+		Messages: []params.EntityString{
+			{Tag: action.ActionTag().String(), Value: "hello"},
+			{Tag: mysqlAction.ActionTag().String(), Value: "hello"},
+			{Tag: "invalid", Value: "hello"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[1].Error, jc.Satisfies, params.IsCodeUnauthorized)
+	c.Assert(res.Results[2].Error, gc.ErrorMatches, `"invalid" is not a valid action tag`)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = model.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "hello")
+}
+
 func (s *uniterSuite) TestFinishActionsFailure(c *gc.C) {
 	testName := "fakeaction"
 	testError := "fakeaction was a dismal failure"
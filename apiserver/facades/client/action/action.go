@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 )
 
 // ActionAPI implements the client API for interacting with Actions
@@ -32,6 +33,21 @@ type APIv2 struct {
 
 // APIv3 provides the Action API facade for version 3.
 type APIv3 struct {
+	*APIv4
+}
+
+// APIv4 provides the Action API facade for version 4.
+type APIv4 struct {
+	*APIv5
+}
+
+// APIv5 provides the Action API facade for version 5.
+type APIv5 struct {
+	*APIv6
+}
+
+// APIv6 provides the Action API facade for version 6.
+type APIv6 struct {
 	*ActionAPI
 }
 
@@ -46,13 +62,40 @@ func NewActionAPIV2(ctx facade.Context) (*APIv2, error) {
 
 // NewActionAPIV3 returns an initialized ActionAPI for version 3.
 func NewActionAPIV3(ctx facade.Context) (*APIv3, error) {
-	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	api, err := NewActionAPIV4(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv3{api}, nil
 }
 
+// NewActionAPIV4 returns an initialized ActionAPI for version 4.
+func NewActionAPIV4(ctx facade.Context) (*APIv4, error) {
+	api, err := NewActionAPIV5(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv4{api}, nil
+}
+
+// NewActionAPIV5 returns an initialized ActionAPI for version 5.
+func NewActionAPIV5(ctx facade.Context) (*APIv5, error) {
+	api, err := NewActionAPIV6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{api}, nil
+}
+
+// NewActionAPIV6 returns an initialized ActionAPI for version 6.
+func NewActionAPIV6(ctx facade.Context) (*APIv6, error) {
+	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv6{api}, nil
+}
+
 func newActionAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
@@ -450,3 +493,35 @@ func runningActions(ar state.ActionReceiver) ([]params.ActionResult, error) {
 func completedActions(ar state.ActionReceiver) ([]params.ActionResult, error) {
 	return common.ConvertActions(ar, ar.CompletedActions)
 }
+
+// WatchActionsProgress isn't on the v4 API.
+func (a *APIv4) WatchActionsProgress(_, _ struct{}) {}
+
+// WatchActionsProgress creates a watcher for each of the given actions
+// that reports the progress messages logged by the action.
+func (a *ActionAPI) WatchActionsProgress(actions params.Entities) (params.StringsWatchResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StringsWatchResults{}, errors.Trace(err)
+	}
+
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(actions.Entities)),
+	}
+	for i, arg := range actions.Entities {
+		actionTag, err := names.ParseActionTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		w := a.model.WatchActionLogs(actionTag.Id())
+		// Consume the initial event.
+		changes, ok := <-w.Changes()
+		if !ok {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
+			continue
+		}
+		results.Results[i].StringsWatcherId = a.resources.Register(w)
+		results.Results[i].Changes = changes
+	}
+	return results, nil
+}
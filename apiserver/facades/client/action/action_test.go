@@ -4,6 +4,7 @@
 package action_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -766,3 +767,38 @@ func stringify(r params.ActionResult) string {
 	}
 	return fmt.Sprintf("%s-%s-%#v-%s-%s-%#v", a.Tag, a.Name, a.Parameters, r.Status, r.Message, r.Output)
 }
+
+func (s *actionSuite) TestWatchActionsProgress(c *gc.C) {
+	api, err := action.NewActionAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("hello")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.WatchActionsProgress(params.Entities{
+		Entities: []params.Entity{{Tag: a.Tag().String()}, {Tag: "unit-wordpress-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].StringsWatcherId, gc.Equals, "1")
+	c.Assert(results.Results[0].Changes, gc.HasLen, 1)
+	var message params.ActionMessage
+	err = json.Unmarshal([]byte(results.Results[0].Changes[0]), &message)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(message.Message, gc.Equals, "hello")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"unit-wordpress-0" is not a valid action tag`)
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+}
+
+func (s *actionSuite) TestWatchActionsProgressPermissionDenied(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("fred")}
+	api, err := action.NewActionAPI(s.State, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.WatchActionsProgress(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -99,7 +99,7 @@ func (a *ActionAPI) Run(run params.RunParams) (results params.ActionResults, err
 		machines[i] = names.NewMachineTag(machineId)
 	}
 
-	actionParams := a.createActionsParams(append(units, machines...), run.Commands, run.Timeout)
+	actionParams := a.createActionsParams(append(units, machines...), run.Commands, run.Timeout, run.StreamOutput)
 
 	return queueActions(a, actionParams)
 }
@@ -123,18 +123,21 @@ func (a *ActionAPI) RunOnAllMachines(run params.RunParams) (results params.Actio
 		machineTags[i] = machine.Tag()
 	}
 
-	actionParams := a.createActionsParams(machineTags, run.Commands, run.Timeout)
+	actionParams := a.createActionsParams(machineTags, run.Commands, run.Timeout, run.StreamOutput)
 
 	return queueActions(a, actionParams)
 }
 
-func (a *ActionAPI) createActionsParams(actionReceiverTags []names.Tag, quotedCommands string, timeout time.Duration) params.Actions {
+func (a *ActionAPI) createActionsParams(actionReceiverTags []names.Tag, quotedCommands string, timeout time.Duration, streamOutput bool) params.Actions {
 
 	apiActionParams := params.Actions{Actions: []params.Action{}}
 
 	actionParams := map[string]interface{}{}
 	actionParams["command"] = quotedCommands
 	actionParams["timeout"] = timeout.Nanoseconds()
+	if streamOutput {
+		actionParams["stream-output"] = true
+	}
 
 	for _, tag := range actionReceiverTags {
 		apiActionParams.Actions = append(apiActionParams.Actions, params.Action{
@@ -226,6 +226,38 @@ func (s *runSuite) TestRunMachineAndApplication(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *runSuite) TestRunStreamOutput(c *gc.C) {
+	expectedPayload := map[string]interface{}{
+		"command":       "hostname",
+		"timeout":       int64(0),
+		"stream-output": true,
+	}
+	expectedArgs := params.Actions{
+		Actions: []params.Action{
+			{Receiver: "unit-magic-0", Name: "juju-run", Parameters: expectedPayload},
+		},
+	}
+	called := false
+	s.PatchValue(action.QueueActions, func(client *action.ActionAPI, args params.Actions) (params.ActionResults, error) {
+		called = true
+		c.Assert(args, jc.DeepEquals, expectedArgs)
+		return params.ActionResults{}, nil
+	})
+
+	charm := s.AddTestingCharm(c, "dummy")
+	magic, err := s.State.AddApplication(state.AddApplicationArgs{Name: "magic", Charm: charm})
+	c.Assert(err, jc.ErrorIsNil)
+	s.addUnit(c, magic)
+
+	s.client.Run(
+		params.RunParams{
+			Commands:     "hostname",
+			Units:        []string{"magic/0"},
+			StreamOutput: true,
+		})
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *runSuite) TestRunOnAllMachines(c *gc.C) {
 	// We only test that we create the actions correctly
 	// There is no need to test anything else at this level.
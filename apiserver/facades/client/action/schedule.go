@@ -0,0 +1,134 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddActionSchedules isn't on the v3 API.
+func (*APIv3) AddActionSchedules(_, _ struct{}) {}
+
+// ListActionSchedules isn't on the v3 API.
+func (*APIv3) ListActionSchedules(_, _ struct{}) {}
+
+// ActionSchedules isn't on the v3 API.
+func (*APIv3) ActionSchedules(_, _ struct{}) {}
+
+// RemoveActionSchedules isn't on the v3 API.
+func (*APIv3) RemoveActionSchedules(_, _ struct{}) {}
+
+// AddActionSchedules adds schedules on which actions are to be
+// enqueued on units repeatedly.
+func (a *ActionAPI) AddActionSchedules(args params.AddActionScheduleArgs) (params.ActionScheduleResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(args.Schedules)),
+	}
+	for i, arg := range args.Schedules {
+		tag, err := names.ParseUnitTag(arg.Receiver)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		schedule, err := a.model.AddActionSchedule(tag, arg.Name, arg.Parameters, arg.Cron)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = makeActionSchedule(schedule)
+	}
+	return results, nil
+}
+
+// ListActionSchedules returns all the action schedules in the model,
+// without their history.
+func (a *ActionAPI) ListActionSchedules() (params.ActionScheduleResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	schedules, err := a.model.AllActionSchedules()
+	if err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		result := makeActionSchedule(schedule)
+		result.History = nil
+		results.Results[i].Result = result
+	}
+	return results, nil
+}
+
+// ActionSchedules returns the action schedules with the given ids,
+// including the history of their runs.
+func (a *ActionAPI) ActionSchedules(args params.ActionScheduleIds) (params.ActionScheduleResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		schedule, err := a.model.ActionSchedule(id)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = makeActionSchedule(schedule)
+	}
+	return results, nil
+}
+
+// RemoveActionSchedules removes the action schedules with the given
+// ids. Actions already enqueued by the schedules are unaffected.
+func (a *ActionAPI) RemoveActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		err := a.model.RemoveActionSchedule(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func makeActionSchedule(schedule *state.ActionSchedule) *params.ActionSchedule {
+	result := &params.ActionSchedule{
+		Id:         schedule.Id(),
+		Receiver:   names.NewUnitTag(schedule.Receiver()).String(),
+		Name:       schedule.Name(),
+		Parameters: schedule.Parameters(),
+		Cron:       schedule.Cron(),
+		Created:    schedule.Created(),
+		NextRun:    schedule.NextRun(),
+	}
+	for _, run := range schedule.History() {
+		entry := params.ActionScheduleRun{
+			Time:  run.Time,
+			Error: run.Error,
+		}
+		if run.ActionId != "" {
+			entry.ActionTag = names.NewActionTag(run.ActionId).String()
+		}
+		result.History = append(result.History, entry)
+	}
+	return result
+}
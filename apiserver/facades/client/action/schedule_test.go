@@ -0,0 +1,86 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *actionSuite) addDummyUnit(c *gc.C) *state.Unit {
+	return s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: s.dummy,
+		SetCharmURL: true,
+	})
+}
+
+func (s *actionSuite) TestAddActionSchedules(c *gc.C) {
+	unit := s.addDummyUnit(c)
+	args := params.AddActionScheduleArgs{
+		Schedules: []params.AddActionScheduleArg{{
+			Receiver:   unit.Tag().String(),
+			Name:       "snapshot",
+			Parameters: map[string]interface{}{"outfile": "db.bz2"},
+			Cron:       "0 3 * * *",
+		}, {
+			Receiver: s.dummy.Tag().String(),
+			Name:     "snapshot",
+			Cron:     "@daily",
+		}, {
+			Receiver: unit.Tag().String(),
+			Name:     "snapshot",
+			Cron:     "every tuesday",
+		}},
+	}
+	results, err := s.action.AddActionSchedules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	result := results.Results[0].Result
+	c.Assert(result, gc.NotNil)
+	c.Check(result.Receiver, gc.Equals, unit.Tag().String())
+	c.Check(result.Name, gc.Equals, "snapshot")
+	c.Check(result.Parameters, jc.DeepEquals, map[string]interface{}{"outfile": "db.bz2"})
+	c.Check(result.Cron, gc.Equals, "0 3 * * *")
+	c.Check(result.NextRun.IsZero(), jc.IsFalse)
+
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `"application-dummy" is not a valid unit tag`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `cannot schedule action "snapshot" on unit dummy/0: schedule "every tuesday" .* not valid`)
+
+	schedule, err := s.Model.ActionSchedule(result.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Cron(), gc.Equals, "0 3 * * *")
+}
+
+func (s *actionSuite) TestListAndRemoveActionSchedules(c *gc.C) {
+	unit := s.addDummyUnit(c)
+	schedule, err := s.Model.AddActionSchedule(unit.UnitTag(), "snapshot", nil, "@hourly")
+	c.Assert(err, jc.ErrorIsNil)
+
+	list, err := s.action.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Results, gc.HasLen, 1)
+	c.Check(list.Results[0].Result.Id, gc.Equals, schedule.Id())
+
+	shown, err := s.action.ActionSchedules(params.ActionScheduleIds{Ids: []string{schedule.Id(), "42"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(shown.Results, gc.HasLen, 2)
+	c.Check(shown.Results[0].Result.Cron, gc.Equals, "@hourly")
+	c.Check(shown.Results[1].Error, gc.ErrorMatches, `action schedule "42" not found`)
+
+	removed, err := s.action.RemoveActionSchedules(params.ActionScheduleIds{Ids: []string{schedule.Id(), "42"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed.Results, gc.HasLen, 2)
+	c.Check(removed.Results[0].Error, gc.IsNil)
+	c.Check(removed.Results[1].Error, gc.ErrorMatches, `action schedule "42" not found`)
+
+	list, err = s.action.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Results, gc.HasLen, 0)
+}
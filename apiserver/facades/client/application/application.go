@@ -75,6 +75,31 @@ type APIv8 struct {
 
 // APIv9 provides the Application API facade for version 9.
 type APIv9 struct {
+	*APIv10
+}
+
+// APIv10 provides the Application API facade for version 10.
+type APIv10 struct {
+	*APIv11
+}
+
+// APIv11 provides the Application API facade for version 11.
+type APIv11 struct {
+	*APIv12
+}
+
+// APIv12 provides the Application API facade for version 12.
+type APIv12 struct {
+	*APIv13
+}
+
+// APIv13 provides the Application API facade for version 13.
+type APIv13 struct {
+	*APIv14
+}
+
+// APIv14 provides the Application API facade for version 14.
+type APIv14 struct {
 	*APIBase
 }
 
@@ -157,13 +182,53 @@ func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
 }
 
 func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV10(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv9{api}, nil
 }
 
+func NewFacadeV10(ctx facade.Context) (*APIv10, error) {
+	api, err := NewFacadeV11(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv10{api}, nil
+}
+
+func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
+	api, err := NewFacadeV12(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv11{api}, nil
+}
+
+func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
+	api, err := NewFacadeV13(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv12{api}, nil
+}
+
+func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
+	api, err := NewFacadeV14(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv13{api}, nil
+}
+
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv14{api}, nil
+}
+
 func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	facadeModel, err := ctx.State().Model()
 	if err != nil {
@@ -730,12 +795,14 @@ func parseSettingsCompatible(charmConfig *charm.Config, settings map[string]stri
 type setCharmParams struct {
 	AppName               string
 	Application           Application
+	BranchName            string
 	Channel               csparams.Channel
 	ConfigSettingsStrings map[string]string
 	ConfigSettingsYAML    string
 	ResourceIDs           map[string]string
 	StorageConstraints    map[string]params.StorageConstraints
 	Force                 forceParams
+	Rollout               *state.CharmRolloutParams
 }
 
 type forceParams struct {
@@ -893,11 +960,20 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	var rollout *state.CharmRolloutParams
+	if args.BatchSize > 0 {
+		rollout = &state.CharmRolloutParams{
+			BatchSize:    args.BatchSize,
+			PauseOnError: args.PauseOnError,
+			LeaderLast:   args.LeaderLast,
+		}
+	}
 	channel := csparams.Channel(args.Channel)
 	return api.setCharmWithAgentValidation(
 		setCharmParams{
 			AppName:               args.ApplicationName,
 			Application:           oneApplication,
+			BranchName:            args.Generation,
 			Channel:               channel,
 			ConfigSettingsStrings: args.ConfigSettings,
 			ConfigSettingsYAML:    args.ConfigSettingsYAML,
@@ -908,6 +984,7 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 				ForceUnits:  args.ForceUnits,
 				Force:       args.Force,
 			},
+			Rollout: rollout,
 		},
 		args.CharmURL,
 	)
@@ -971,6 +1048,10 @@ func (api *APIBase) applicationSetCharm(
 	params setCharmParams,
 	stateCharm Charm,
 ) error {
+	if params.BranchName != "" && params.BranchName != model.GenerationMaster {
+		return errors.Trace(api.applicationSetBranchCharm(params, stateCharm))
+	}
+
 	var err error
 	var settings charm.Settings
 	if params.ConfigSettingsYAML != "" {
@@ -1005,10 +1086,30 @@ func (api *APIBase) applicationSetCharm(
 		Force:              force.Force,
 		ResourceIDs:        params.ResourceIDs,
 		StorageConstraints: stateStorageConstraints,
+		Rollout:            params.Rollout,
 	}
 	return params.Application.SetCharm(cfg)
 }
 
+// applicationSetBranchCharm stages an upgrade of the application to the
+// input charm under the branch indicated by the input parameters.
+// Only units tracking the branch are upgraded until it is committed.
+func (api *APIBase) applicationSetBranchCharm(params setCharmParams, stateCharm Charm) error {
+	if params.ConfigSettingsYAML != "" || len(params.ConfigSettingsStrings) > 0 {
+		return errors.NotSupportedf("setting config when upgrading a charm under a branch")
+	}
+	if len(params.StorageConstraints) > 0 {
+		return errors.NotSupportedf("setting storage constraints when upgrading a charm under a branch")
+	}
+	if len(params.ResourceIDs) > 0 {
+		return errors.NotSupportedf("upgrading resources under a branch")
+	}
+	if params.Rollout != nil {
+		return errors.NotSupportedf("batched upgrades under a branch")
+	}
+	return params.Application.SetBranchCharm(params.BranchName, api.stateCharm(stateCharm))
+}
+
 // charmConfigFromGetYaml will parse a yaml produced by juju get and generate
 // charm.Settings from it that can then be sent to the application.
 func charmConfigFromGetYaml(yamlContents map[string]interface{}) (charm.Settings, error) {
@@ -1078,6 +1179,13 @@ func (api *APIBase) GetCharmURL(args params.ApplicationGet) (params.StringResult
 	if err != nil {
 		return params.StringResult{}, errors.Trace(err)
 	}
+	if args.BranchName != "" && args.BranchName != model.GenerationMaster {
+		charmURL, err := oneApplication.BranchCharmURL(args.BranchName)
+		if err != nil {
+			return params.StringResult{}, errors.Trace(err)
+		}
+		return params.StringResult{Result: charmURL.String()}, nil
+	}
 	charmURL, _ := oneApplication.CharmURL()
 	return params.StringResult{Result: charmURL.String()}, nil
 }
@@ -1179,8 +1287,21 @@ func (api *APIBase) Expose(args params.ApplicationExpose) error {
 				"cannot expose a CAAS application without a %q value set, run\n"+
 					"juju config %s %s=<value>", caas.JujuExternalHostNameKey, args.ApplicationName, caas.JujuExternalHostNameKey)
 		}
+		if len(args.ExposedEndpoints) > 0 {
+			return errors.NotSupportedf("exposing a CAAS application to specific spaces or CIDRs")
+		}
+	}
+	if len(args.ExposedEndpoints) == 0 {
+		return app.SetExposed()
 	}
-	return app.SetExposed()
+	exposedEndpoints := make(map[string]state.ExposedEndpoint, len(args.ExposedEndpoints))
+	for name, exposed := range args.ExposedEndpoints {
+		exposedEndpoints[name] = state.ExposedEndpoint{
+			ExposeToSpaces: exposed.ExposeToSpaces,
+			ExposeToCIDRs:  exposed.ExposeToCIDRs,
+		}
+	}
+	return app.SetExposeSettings(exposedEndpoints)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
@@ -1599,6 +1720,9 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 		} else if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if err := api.checkNotAutoscaled(appTag, app); err != nil {
+			return nil, errors.Trace(err)
+		}
 		var info params.ScaleApplicationInfo
 		if arg.ScaleChange != 0 {
 			newScale, err := app.ChangeScale(arg.ScaleChange)
@@ -1626,6 +1750,32 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 	return params.ScaleApplicationResults{results}, nil
 }
 
+// checkNotAutoscaled returns an error if the application is scaled by
+// a horizontal pod autoscaler, which owns the application's scale while
+// it is enabled.
+func (api *APIBase) checkNotAutoscaled(appTag names.ApplicationTag, app Application) error {
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	podSpec, err := api.backend.PodSpec(appTag)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	autoscaled, err := k8s.AutoscalingEnabled(podSpec, config)
+	if err != nil {
+		return errors.Annotatef(err, "checking autoscaling of application %q", appTag.Id())
+	}
+	if autoscaled {
+		return errors.Errorf(
+			"application %q is autoscaled; set its kubernetes-autoscaling-min-replicas "+
+				"and kubernetes-autoscaling-max-replicas config to change its scale",
+			appTag.Id(),
+		)
+	}
+	return nil
+}
+
 // GetConstraints returns the constraints for a given application.
 func (api *APIBase) GetConstraints(args params.Entities) (params.ApplicationGetConstraintsResults, error) {
 	if err := api.checkCanRead(); err != nil {
@@ -1671,6 +1821,9 @@ func (api *APIBase) SetConstraints(args params.SetConstraints) error {
 	if err != nil {
 		return err
 	}
+	if args.Generation != "" && args.Generation != model.GenerationMaster {
+		return app.SetBranchConstraints(args.Generation, args.Constraints)
+	}
 	return app.SetConstraints(args.Constraints)
 }
 
@@ -2308,6 +2461,12 @@ func (api *APIBase) ApplicationsInfo(in params.Entities) (params.ApplicationInfo
 			continue
 		}
 
+		refreshPolicy, err := charmRefreshPolicyParams(app)
+		if err != nil {
+			out[i].Error = common.ServerError(err)
+			continue
+		}
+
 		out[i].Result = &params.ApplicationInfo{
 			Tag:              tag.String(),
 			Charm:            details.Charm,
@@ -2318,11 +2477,36 @@ func (api *APIBase) ApplicationsInfo(in params.Entities) (params.ApplicationInfo
 			Exposed:          app.IsExposed(),
 			Remote:           app.IsRemote(),
 			EndpointBindings: bindings,
+			RefreshPolicy:    refreshPolicy,
 		}
 	}
 	return params.ApplicationInfoResults{out}, nil
 }
 
+// charmRefreshPolicyParams returns the charm refresh policy of the
+// application, or nil if it has none.
+func charmRefreshPolicyParams(app Application) (*params.CharmRefreshPolicy, error) {
+	policy, err := app.CharmRefreshPolicy()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	p := policy.Policy()
+	result := &params.CharmRefreshPolicy{
+		Window:         p.Window,
+		Duration:       p.Duration,
+		PatchOnly:      p.PatchOnly,
+		CanaryBranch:   p.CanaryBranch,
+		CanaryCharmURL: policy.CanaryCharmURL(),
+		LastMessage:    policy.LastMessage(),
+	}
+	if lastRefreshed := policy.LastRefreshed(); !lastRefreshed.IsZero() {
+		result.LastRefreshed = &lastRefreshed
+	}
+	return result, nil
+}
+
 // lxdCharmProfiler massages a *state.Charm into a LXDProfiler
 // inside of the core package.
 type lxdCharmProfiler struct {
@@ -2412,3 +2596,129 @@ func validateAgentVersions(application Application, versioner AgentVersioner) er
 	}
 	return nil
 }
+
+// UnitsState isn't on the v10 API.
+func (u *APIv10) UnitsState(_, _ struct{}) {}
+
+// UnitsState returns the key/value state stored by the charms of the
+// specified units. Only model administrators may view unit state.
+func (api *APIBase) UnitsState(in params.Entities) (params.UnitStateResults, error) {
+	if err := api.checkPermission(api.model.ModelTag(), permission.AdminAccess); err != nil {
+		return params.UnitStateResults{}, errors.Trace(err)
+	}
+	out := make([]params.UnitStateResult, len(in.Entities))
+	for i, one := range in.Entities {
+		tag, err := names.ParseUnitTag(one.Tag)
+		if err != nil {
+			out[i].Error = common.ServerError(err)
+			continue
+		}
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			out[i].Error = common.ServerError(err)
+			continue
+		}
+		unitState, err := unit.State()
+		if err != nil {
+			out[i].Error = common.ServerError(err)
+			continue
+		}
+		out[i].State = unitState
+	}
+	return params.UnitStateResults{Results: out}, nil
+}
+
+// CharmRollouts isn't on the v11 API.
+func (u *APIv11) CharmRollouts(_, _ struct{}) {}
+
+// CharmRollouts returns the progress of the batched charm upgrades of
+// the specified applications.
+func (api *APIBase) CharmRollouts(in params.Entities) (params.CharmRolloutResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.CharmRolloutResults{}, errors.Trace(err)
+	}
+	out := make([]params.CharmRolloutResult, len(in.Entities))
+	for i, one := range in.Entities {
+		tag, err := names.ParseApplicationTag(one.Tag)
+		if err != nil {
+			out[i].Error = common.ServerError(err)
+			continue
+		}
+		app, err := api.backend.Application(tag.Id())
+		if err != nil {
+			out[i].Error = common.ServerError(err)
+			continue
+		}
+		rollout, err := app.CharmRollout()
+		if err != nil {
+			out[i].Error = common.ServerError(err)
+			continue
+		}
+		out[i].Result = &params.CharmRollout{
+			Application:   rollout.ApplicationName(),
+			FromCharmURL:  rollout.FromCharmURL(),
+			ToCharmURL:    rollout.ToCharmURL(),
+			BatchSize:     rollout.BatchSize(),
+			PauseOnError:  rollout.PauseOnError(),
+			LeaderLast:    rollout.LeaderLast(),
+			ReleasedUnits: rollout.ReleasedUnits(),
+			PendingUnits:  rollout.PendingUnits(),
+			Status:        string(rollout.Status()),
+			Message:       rollout.Message(),
+			Started:       rollout.Started(),
+			Updated:       rollout.Updated(),
+		}
+	}
+	return params.CharmRolloutResults{Results: out}, nil
+}
+
+// SetCharmRefreshPolicy isn't on the v12 API.
+func (u *APIv12) SetCharmRefreshPolicy(_, _ struct{}) {}
+
+// SetCharmRefreshPolicy sets, or removes, the policies by which the
+// charms of the specified applications are automatically upgraded to
+// newer revisions in the channels they track.
+func (api *APIBase) SetCharmRefreshPolicy(args params.SetCharmRefreshPolicyArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		if err := api.setCharmRefreshPolicy(arg); err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *APIBase) setCharmRefreshPolicy(arg params.SetCharmRefreshPolicyArg) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var policy *application.RefreshPolicy
+	if arg.Policy != nil {
+		policy = &application.RefreshPolicy{
+			Window:       arg.Policy.Window,
+			Duration:     arg.Policy.Duration,
+			PatchOnly:    arg.Policy.PatchOnly,
+			CanaryBranch: arg.Policy.CanaryBranch,
+		}
+		if err := policy.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if policy.CanaryBranch != "" {
+			if _, err := api.backend.Branch(policy.CanaryBranch); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return errors.Trace(app.SetCharmRefreshPolicy(policy))
+}
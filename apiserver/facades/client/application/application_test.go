@@ -50,7 +50,7 @@ type applicationSuite struct {
 	apiservertesting.CharmStoreSuite
 	commontesting.BlockHelper
 
-	applicationAPI *application.APIv9
+	applicationAPI *application.APIv14
 	application    *state.Application
 	authorizer     *apiservertesting.FakeAuthorizer
 }
@@ -87,7 +87,7 @@ func (s *applicationSuite) TearDownTest(c *gc.C) {
 	s.JujuConnSuite.TearDownTest(c)
 }
 
-func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv9 {
+func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv14 {
 	resources := common.NewResources()
 	c.Assert(resources.RegisterNamed("dataDir", common.StringResource(c.MkDir())), jc.ErrorIsNil)
 	storageAccess, err := application.GetStorageState(s.State)
@@ -111,7 +111,7 @@ func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv9 {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	return &application.APIv9{api}
+	return &application.APIv14{api}
 }
 
 func (s *applicationSuite) TestCharmConfig(c *gc.C) {
@@ -132,7 +132,7 @@ func (s *applicationSuite) TestCharmConfig(c *gc.C) {
 
 func (s *applicationSuite) TestCharmConfigV8(c *gc.C) {
 	s.setUpConfigTest(c)
-	api := &application.APIv8{APIv9: s.applicationAPI}
+	api := &application.APIv8{APIv9: &application.APIv9{s.applicationAPI}}
 	results, err := api.CharmConfig(params.Entities{
 		Entities: []params.Entity{
 			{"wat"}, {"machine-0"}, {"user-foo"},
@@ -2421,7 +2421,7 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	c.Assert(apps[1].IsExposed(), jc.IsTrue)
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -2433,6 +2433,32 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	}
 }
 
+func (s *applicationSuite) TestApplicationExposeSettings(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingApplication(c, "dummy-application", charm)
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-application",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"": {ExposeToCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+	})
+
+	err = s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-application",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"no-such-endpoint": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set expose settings for application "dummy-application": endpoint "no-such-endpoint" not found`)
+}
+
 func (s *applicationSuite) setupApplicationExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	applicationNames := []string{"dummy-application", "exposed-application"}
@@ -2473,7 +2499,7 @@ var applicationExposeTests = []struct {
 func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -2488,7 +2514,7 @@ func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 func (s *applicationSuite) assertApplicationExposeBlocked(c *gc.C, msg string) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -54,7 +54,7 @@ type ApplicationSuite struct {
 	env              environs.Environ
 	blockChecker     mockBlockChecker
 	authorizer       apiservertesting.FakeAuthorizer
-	api              *application.APIv9
+	api              *application.APIv14
 	deployParams     map[string]application.DeployApplicationParams
 }
 
@@ -84,7 +84,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.storageValidator,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv9{api}
+	s.api = &application.APIv14{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -347,6 +347,63 @@ postgresql:
 	})
 }
 
+func (s *ApplicationSuite) TestSetCharmBranch(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		Generation:      "new-branch",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "Application", "Charm")
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "Charm", "AgentTools", "SetBranchCharm")
+	app.CheckCall(c, 2, "SetBranchCharm", "new-branch", &state.Charm{})
+}
+
+func (s *ApplicationSuite) TestSetCharmBranchConfigSettingsNotSupported(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		Generation:      "new-branch",
+		ConfigSettings:  map[string]string{"stringOption": "value"},
+	})
+	c.Assert(err, gc.ErrorMatches, "setting config when upgrading a charm under a branch not supported")
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "Charm", "AgentTools")
+}
+
+func (s *ApplicationSuite) TestSetCharmBatched(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		BatchSize:       2,
+		PauseOnError:    true,
+		LeaderLast:      true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 2, "SetCharm", state.SetCharmConfig{
+		Charm: &state.Charm{},
+		Rollout: &state.CharmRolloutParams{
+			BatchSize:    2,
+			PauseOnError: true,
+			LeaderLast:   true,
+		},
+	})
+}
+
+func (s *ApplicationSuite) TestSetCharmBranchBatchedNotSupported(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		Generation:      "new-branch",
+		BatchSize:       2,
+	})
+	c.Assert(err, gc.ErrorMatches, "batched upgrades under a branch not supported")
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "Charm", "AgentTools")
+}
+
 func (s *ApplicationSuite) TestLXDProfileSetCharmWithNewerAgentVersion(c *gc.C) {
 	err := s.api.SetCharm(params.ApplicationSetCharm{
 		ApplicationName: "postgresql",
@@ -860,7 +917,7 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModel(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 0, "Scale", 5)
+	app.CheckCall(c, 1, "Scale", 5)
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleChange(c *gc.C) {
@@ -879,7 +936,51 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleChange(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 0, "ChangeScale", 5)
+	app.CheckCall(c, 1, "ChangeScale", 5)
+}
+
+func (s *ApplicationSuite) TestScaleApplicationsCAASModelAutoscaledByConfig(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.applications["postgresql"].config = coreapplication.ConfigAttributes{
+		"kubernetes-autoscaling-max-replicas":           5,
+		"kubernetes-autoscaling-target-cpu-utilization": 70,
+	}
+	results, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			Scale:          5,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`application "postgresql" is autoscaled; set its kubernetes-autoscaling-min-replicas `+
+			`and kubernetes-autoscaling-max-replicas config to change its scale`)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "ApplicationConfig")
+}
+
+func (s *ApplicationSuite) TestScaleApplicationsCAASModelAutoscaledByPodSpec(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.podSpecs = map[string]string{
+		"postgresql": `
+containers:
+  - name: postgresql
+    image: postgresql/latest
+autoscaling:
+  maxReplicas: 5
+  targetCPUUtilizationPercentage: 70
+`[1:],
+	}
+	results, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			ScaleChange:    1,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `application "postgresql" is autoscaled; .*`)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "ApplicationConfig")
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleArgCheck(c *gc.C) {
@@ -1626,6 +1727,35 @@ func (s *ApplicationSuite) TestCAASExposeWithHostname(c *gc.C) {
 	app.CheckCallNames(c, "ApplicationConfig", "SetExposed")
 }
 
+func (s *ApplicationSuite) TestCAASExposeSettingsNotSupported(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{"juju-external-hostname": "exthost"}
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "exposing a CAAS application to specific spaces or CIDRs not supported")
+	app.CheckCallNames(c, "ApplicationConfig")
+}
+
+func (s *ApplicationSuite) TestExposeSettings(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"db": {ExposeToSpaces: []string{"dmz"}, ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app.CheckCallNames(c, "SetExposeSettings")
+	app.CheckCall(c, 0, "SetExposeSettings", map[string]state.ExposedEndpoint{
+		"db": {ExposeToSpaces: []string{"dmz"}, ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+}
+
 func (s *ApplicationSuite) TestApplicationsInfoOne(c *gc.C) {
 	entities := []params.Entity{{Tag: "application-postgresql"}}
 	result, err := s.api.ApplicationsInfo(params.Entities{entities})
@@ -1643,7 +1773,31 @@ func (s *ApplicationSuite) TestApplicationsInfoOne(c *gc.C) {
 		},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "Series", "Channel", "EndpointBindings", "IsPrincipal", "IsExposed", "IsRemote")
+	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "Series", "Channel", "EndpointBindings", "CharmRefreshPolicy", "IsPrincipal", "IsExposed", "IsRemote")
+}
+
+func (s *ApplicationSuite) TestApplicationsInfoRefreshPolicy(c *gc.C) {
+	lastRefreshed := time.Date(2019, 4, 6, 2, 30, 0, 0, time.UTC)
+	s.backend.applications["postgresql"].refresh = &mockCharmRefreshPolicy{
+		policy: coreapplication.RefreshPolicy{
+			Window:    "0 2 * * SAT",
+			Duration:  2 * time.Hour,
+			PatchOnly: true,
+		},
+		lastRefreshed: lastRefreshed,
+		lastMessage:   "upgraded to cs:postgresql-43",
+	}
+	entities := []params.Entity{{Tag: "application-postgresql"}}
+	result, err := s.api.ApplicationsInfo(params.Entities{entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Result.RefreshPolicy, jc.DeepEquals, &params.CharmRefreshPolicy{
+		Window:        "0 2 * * SAT",
+		Duration:      2 * time.Hour,
+		PatchOnly:     true,
+		LastRefreshed: &lastRefreshed,
+		LastMessage:   "upgraded to cs:postgresql-43",
+	})
 }
 
 func (s *ApplicationSuite) TestApplicationsInfoDetailsErr(c *gc.C) {
@@ -1694,5 +1848,111 @@ func (s *ApplicationSuite) TestApplicationsInfoMany(c *gc.C) {
 	c.Assert(result.Results[1].Error, gc.ErrorMatches, `application "wordpress" not found`)
 	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "Series", "Channel", "EndpointBindings", "IsPrincipal", "IsExposed", "IsRemote")
+	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "Series", "Channel", "EndpointBindings", "CharmRefreshPolicy", "IsPrincipal", "IsExposed", "IsRemote")
+}
+
+func (s *ApplicationSuite) TestUnitsState(c *gc.C) {
+	unit := s.backend.applications["postgresql"].units[0]
+	unit.state = map[string]string{"foo": "bar"}
+	entities := []params.Entity{{Tag: "unit-postgresql-0"}, {Tag: "unit-postgresql-9"}, {Tag: "application-postgresql"}}
+	result, err := s.api.UnitsState(params.Entities{entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(entities))
+	c.Assert(result.Results[0], jc.DeepEquals, params.UnitStateResult{State: map[string]string{"foo": "bar"}})
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `unit "postgresql/9" not found`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+	unit.CheckCallNames(c, "State")
+}
+
+func (s *ApplicationSuite) TestCharmRollouts(c *gc.C) {
+	started := time.Date(2019, 4, 1, 10, 0, 0, 0, time.UTC)
+	s.backend.applications["postgresql"].rollout = &mockCharmRollout{
+		appName:  "postgresql",
+		from:     "cs:postgresql-1",
+		to:       "cs:postgresql-2",
+		released: []string{"postgresql/0"},
+		pending:  []string{"postgresql/1"},
+		status:   state.CharmRolloutPaused,
+		message:  "unit failed: postgresql/0: hook failed",
+		started:  started,
+	}
+	entities := []params.Entity{{Tag: "application-postgresql"}, {Tag: "application-redis"}, {Tag: "unit-postgresql-0"}}
+	result, err := s.api.CharmRollouts(params.Entities{entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(entities))
+	c.Assert(result.Results[0], jc.DeepEquals, params.CharmRolloutResult{
+		Result: &params.CharmRollout{
+			Application:   "postgresql",
+			FromCharmURL:  "cs:postgresql-1",
+			ToCharmURL:    "cs:postgresql-2",
+			BatchSize:     1,
+			PauseOnError:  true,
+			ReleasedUnits: []string{"postgresql/0"},
+			PendingUnits:  []string{"postgresql/1"},
+			Status:        "paused",
+			Message:       "unit failed: postgresql/0: hook failed",
+			Started:       started,
+			Updated:       started,
+		},
+	})
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `charm rollout for application "redis" not found`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+}
+
+func (s *ApplicationSuite) TestUnitsStatePermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.UnitsState(params.Entities{[]params.Entity{{Tag: "unit-postgresql-0"}}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.applications["postgresql"].units[0].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetCharmRefreshPolicy(c *gc.C) {
+	result, err := s.api.SetCharmRefreshPolicy(params.SetCharmRefreshPolicyArgs{
+		Args: []params.SetCharmRefreshPolicyArg{{
+			ApplicationTag: "application-postgresql",
+			Policy: &params.CharmRefreshPolicy{
+				Window:       "0 2 * * SAT",
+				Duration:     2 * time.Hour,
+				CanaryBranch: "new-branch",
+			},
+		}, {
+			ApplicationTag: "application-redis",
+		}, {
+			ApplicationTag: "application-postgresql",
+			Policy: &params.CharmRefreshPolicy{
+				Window:       "0 2 * * SAT",
+				Duration:     2 * time.Hour,
+				CanaryBranch: "no-such-branch",
+			},
+		}, {
+			ApplicationTag: "application-postgresql",
+			Policy:         &params.CharmRefreshPolicy{Window: "0 2 * * SAT"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `branch "no-such-branch" not found`)
+	c.Assert(result.Results[3].Error, gc.ErrorMatches, `maintenance window duration 0s not valid`)
+
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.applications["postgresql"].CheckCall(c, 0, "SetCharmRefreshPolicy", &coreapplication.RefreshPolicy{
+		Window:       "0 2 * * SAT",
+		Duration:     2 * time.Hour,
+		CanaryBranch: "new-branch",
+	})
+	s.backend.applications["redis"].CheckCall(c, 0, "SetCharmRefreshPolicy", (*coreapplication.RefreshPolicy)(nil))
+}
+
+func (s *ApplicationSuite) TestSetCharmRefreshPolicyBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetCharmRefreshPolicy(params.SetCharmRefreshPolicyArgs{
+		Args: []params.SetCharmRefreshPolicyArg{{
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
 }
@@ -49,6 +49,7 @@ type Backend interface {
 	OfferConnectionForRelation(string) (OfferConnection, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
 	Branch(string) (Generation, error)
+	PodSpec(names.ApplicationTag) (string, error)
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -69,6 +70,9 @@ type Application interface {
 	ApplicationConfig() (application.ConfigAttributes, error)
 	Charm() (Charm, bool, error)
 	CharmURL() (*charm.URL, bool)
+	CharmRollout() (CharmRollout, error)
+	CharmRefreshPolicy() (CharmRefreshPolicy, error)
+	SetCharmRefreshPolicy(*application.RefreshPolicy) error
 	Channel() csparams.Channel
 	ClearExposed() error
 	CharmConfig(string) (charm.Settings, error)
@@ -82,8 +86,12 @@ type Application interface {
 	IsRemote() bool
 	Series() string
 	SetCharm(state.SetCharmConfig) error
+	SetBranchCharm(string, *state.Charm) error
+	BranchCharmURL(string) (*charm.URL, error)
 	SetConstraints(constraints.Value) error
+	SetBranchConstraints(string, constraints.Value) error
 	SetExposed() error
+	SetExposeSettings(map[string]state.ExposedEndpoint) error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
 	UpdateApplicationSeries(string, bool) error
@@ -94,6 +102,36 @@ type Application interface {
 	AgentTools() (*tools.Tools, error)
 }
 
+// CharmRollout defines a subset of the functionality provided by the
+// state.CharmRollout type, as required by the application facade. For
+// details on the methods, see the methods on state.CharmRollout with
+// the same names.
+type CharmRollout interface {
+	ApplicationName() string
+	FromCharmURL() string
+	ToCharmURL() string
+	BatchSize() int
+	PauseOnError() bool
+	LeaderLast() bool
+	ReleasedUnits() []string
+	PendingUnits() []string
+	Status() state.CharmRolloutStatus
+	Message() string
+	Started() time.Time
+	Updated() time.Time
+}
+
+// CharmRefreshPolicy defines a subset of the functionality provided by
+// the state.CharmRefreshPolicy type, as required by the application
+// facade. For details on the methods, see the methods on
+// state.CharmRefreshPolicy with the same names.
+type CharmRefreshPolicy interface {
+	Policy() application.RefreshPolicy
+	CanaryCharmURL() string
+	LastRefreshed() time.Time
+	LastMessage() string
+}
+
 // Charm defines a subset of the functionality provided by the
 // state.Charm type, as required by the application facade. For
 // details on the methods, see the methods on state.Charm with
@@ -140,6 +178,7 @@ type Unit interface {
 	Life() state.Life
 	Resolve(retryHooks bool) error
 	AgentTools() (*tools.Tools, error)
+	State() (map[string]string, error)
 
 	AssignedMachineId() (string, error)
 	AssignWithPolicy(state.AssignmentPolicy) error
@@ -359,6 +398,19 @@ func (s stateShim) Branch(name string) (Generation, error) {
 	return Generation(gen), nil
 }
 
+// PodSpec returns the pod spec set for the application by its charm.
+func (s stateShim) PodSpec(tag names.ApplicationTag) (string, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	caasModel, err := model.CAASModel()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return caasModel.PodSpec(tag)
+}
+
 type stateApplicationShim struct {
 	*state.Application
 	st *state.State
@@ -380,6 +432,22 @@ func (a stateApplicationShim) Charm() (Charm, bool, error) {
 	return ch, force, nil
 }
 
+func (a stateApplicationShim) CharmRollout() (CharmRollout, error) {
+	r, err := a.Application.CharmRollout()
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (a stateApplicationShim) CharmRefreshPolicy() (CharmRefreshPolicy, error) {
+	p, err := a.Application.CharmRefreshPolicy()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
 func (a stateApplicationShim) AllUnits() ([]Unit, error) {
 	units, err := a.Application.AllUnits()
 	if err != nil {
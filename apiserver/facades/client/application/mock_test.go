@@ -102,6 +102,8 @@ type mockApplication struct {
 	exposed     bool
 	remote      bool
 	agentTools  *tools.Tools
+	rollout     *mockCharmRollout
+	refresh     *mockCharmRefreshPolicy
 }
 
 func (m *mockApplication) Name() string {
@@ -124,6 +126,33 @@ func (m *mockApplication) CharmURL() (curl *charm.URL, force bool) {
 	return m.curl, true
 }
 
+func (m *mockApplication) CharmRollout() (application.CharmRollout, error) {
+	m.MethodCall(m, "CharmRollout")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	if m.rollout == nil {
+		return nil, errors.NotFoundf("charm rollout for application %q", m.name)
+	}
+	return m.rollout, nil
+}
+
+func (m *mockApplication) CharmRefreshPolicy() (application.CharmRefreshPolicy, error) {
+	m.MethodCall(m, "CharmRefreshPolicy")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	if m.refresh == nil {
+		return nil, errors.NotFoundf("charm refresh policy for application %q", m.name)
+	}
+	return m.refresh, nil
+}
+
+func (m *mockApplication) SetCharmRefreshPolicy(policy *coreapplication.RefreshPolicy) error {
+	m.MethodCall(m, "SetCharmRefreshPolicy", policy)
+	return m.NextErr()
+}
+
 func (m *mockApplication) CharmConfig(branchName string) (charm.Settings, error) {
 	m.MethodCall(m, "CharmConfig", branchName)
 	return m.charm.config.DefaultSettings(), m.NextErr()
@@ -161,6 +190,16 @@ func (a *mockApplication) SetCharm(cfg state.SetCharmConfig) error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) SetBranchCharm(branchName string, ch *state.Charm) error {
+	a.MethodCall(a, "SetBranchCharm", branchName, ch)
+	return a.NextErr()
+}
+
+func (a *mockApplication) SetBranchConstraints(branchName string, cons constraints.Value) error {
+	a.MethodCall(a, "SetBranchConstraints", branchName, cons)
+	return a.NextErr()
+}
+
 func (a *mockApplication) DestroyOperation() *state.DestroyApplicationOperation {
 	a.MethodCall(a, "DestroyOperation")
 	return &state.DestroyApplicationOperation{}
@@ -237,6 +276,11 @@ func (a *mockApplication) SetExposed() error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) SetExposeSettings(exposedEndpoints map[string]state.ExposedEndpoint) error {
+	a.MethodCall(a, "SetExposeSettings", exposedEndpoints)
+	return a.NextErr()
+}
+
 func (a *mockApplication) IsExposed() bool {
 	a.MethodCall(a, "IsExposed")
 	return a.exposed
@@ -252,6 +296,45 @@ func (a *mockApplication) AgentTools() (*tools.Tools, error) {
 	return a.agentTools, a.NextErr()
 }
 
+type mockCharmRollout struct {
+	application.CharmRollout
+
+	appName  string
+	from     string
+	to       string
+	released []string
+	pending  []string
+	status   state.CharmRolloutStatus
+	message  string
+	started  time.Time
+}
+
+func (r *mockCharmRollout) ApplicationName() string          { return r.appName }
+func (r *mockCharmRollout) FromCharmURL() string             { return r.from }
+func (r *mockCharmRollout) ToCharmURL() string               { return r.to }
+func (r *mockCharmRollout) BatchSize() int                   { return 1 }
+func (r *mockCharmRollout) PauseOnError() bool               { return true }
+func (r *mockCharmRollout) LeaderLast() bool                 { return false }
+func (r *mockCharmRollout) ReleasedUnits() []string          { return r.released }
+func (r *mockCharmRollout) PendingUnits() []string           { return r.pending }
+func (r *mockCharmRollout) Status() state.CharmRolloutStatus { return r.status }
+func (r *mockCharmRollout) Message() string                  { return r.message }
+func (r *mockCharmRollout) Started() time.Time               { return r.started }
+func (r *mockCharmRollout) Updated() time.Time               { return r.started }
+
+type mockCharmRefreshPolicy struct {
+	application.CharmRefreshPolicy
+
+	policy        coreapplication.RefreshPolicy
+	lastRefreshed time.Time
+	lastMessage   string
+}
+
+func (p *mockCharmRefreshPolicy) Policy() coreapplication.RefreshPolicy { return p.policy }
+func (p *mockCharmRefreshPolicy) CanaryCharmURL() string                { return "" }
+func (p *mockCharmRefreshPolicy) LastRefreshed() time.Time              { return p.lastRefreshed }
+func (p *mockCharmRefreshPolicy) LastMessage() string                   { return p.lastMessage }
+
 type mockNotifyWatcher struct {
 	state.NotifyWatcher
 	jtesting.Stub
@@ -336,6 +419,7 @@ type mockBackend struct {
 	controllers                map[string]crossmodel.ControllerInfo
 	machines                   map[string]*mockMachine
 	generation                 *mockGeneration
+	podSpecs                   map[string]string
 }
 
 type mockFilesystemAccess struct {
@@ -650,6 +734,18 @@ func (m *mockBackend) Branch(branchName string) (application.Generation, error)
 	return m.generation, nil
 }
 
+func (m *mockBackend) PodSpec(tag names.ApplicationTag) (string, error) {
+	m.MethodCall(m, "PodSpec", tag)
+	if err := m.NextErr(); err != nil {
+		return "", err
+	}
+	spec, ok := m.podSpecs[tag.Id()]
+	if !ok {
+		return "", errors.NotFoundf("pod spec for %s", names.ReadableString(tag))
+	}
+	return spec, nil
+}
+
 type mockExternalController struct {
 	uuid string
 	info crossmodel.ControllerInfo
@@ -733,6 +829,7 @@ type mockUnit struct {
 	machineId  string
 	name       string
 	agentTools *tools.Tools
+	state      map[string]string
 }
 
 func (u *mockUnit) Tag() names.Tag {
@@ -784,6 +881,11 @@ func (u *mockUnit) AgentTools() (*tools.Tools, error) {
 	return u.agentTools, u.NextErr()
 }
 
+func (u *mockUnit) State() (map[string]string, error) {
+	u.MethodCall(u, "State")
+	return u.state, u.NextErr()
+}
+
 type mockStorageAttachment struct {
 	state.StorageAttachment
 	jtesting.Stub
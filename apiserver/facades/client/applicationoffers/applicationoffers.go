@@ -40,6 +40,11 @@ type OffersAPIV2 struct {
 	*OffersAPI
 }
 
+// OffersAPIV3 implements the cross model interface V3.
+type OffersAPIV3 struct {
+	*OffersAPIV2
+}
+
 // createAPI returns a new application offers OffersAPI facade.
 func createOffersAPI(
 	getApplicationOffers func(interface{}) jujucrossmodel.ApplicationOffers,
@@ -122,6 +127,15 @@ func NewOffersAPIV2(ctx facade.Context) (*OffersAPIV2, error) {
 	return &OffersAPIV2{OffersAPI: apiV1}, nil
 }
 
+// NewOffersAPIV3 returns a new application offers OffersAPIV3 facade.
+func NewOffersAPIV3(ctx facade.Context) (*OffersAPIV3, error) {
+	apiV2, err := NewOffersAPIV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &OffersAPIV3{OffersAPIV2: apiV2}, nil
+}
+
 // Offer makes application endpoints available for consumption at a specified URL.
 func (api *OffersAPI) Offer(all params.AddApplicationOffers) (params.ErrorResults, error) {
 	result := make([]params.ErrorResult, len(all.Offers))
@@ -163,6 +177,9 @@ func (api *OffersAPI) makeAddOfferArgsFromParams(backend Backend, addOfferParams
 		Endpoints:              addOfferParams.Endpoints,
 		Owner:                  api.Authorizer.GetAuthTag().Id(),
 		HasRead:                []string{common.EveryoneTagName},
+		RequireApproval:        addOfferParams.RequireApproval,
+		MaxConsumers:           addOfferParams.MaxConsumers,
+		MaxRelations:           addOfferParams.MaxRelations,
 	}
 	if result.OfferName == "" {
 		result.OfferName = result.ApplicationName
@@ -471,6 +488,10 @@ func (api *OffersAPI) GetConsumeDetails(args params.OfferURLs) (params.ConsumeOf
 		}
 		offer := result.Result
 		offerDetails := &offer.ApplicationOfferDetails
+		if err := api.checkConsumeApproved(offerDetails); err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
 		results[i].Offer = offerDetails
 		results[i].ControllerInfo = controllerInfo
 		offerMacaroon, err := api.authContext.CreateConsumeOfferMacaroon(offerDetails, api.Authorizer.GetAuthTag().Id())
@@ -484,6 +505,161 @@ func (api *OffersAPI) GetConsumeDetails(args params.OfferURLs) (params.ConsumeOf
 	return consumeResults, nil
 }
 
+// checkConsumeApproved ensures that the authenticated user may consume
+// the offer. If the offer requires approval, a request to consume it is
+// recorded for an offer admin to approve or reject.
+func (api *OffersAPI) checkConsumeApproved(offer *params.ApplicationOfferDetails) error {
+	modelTag, err := names.ParseModelTag(offer.SourceModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	backend, releaser, err := api.StatePool.Get(modelTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer releaser()
+
+	appOffer, err := backend.ApplicationOffer(offer.OfferName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !appOffer.RequireApproval {
+		return nil
+	}
+	// Offer admins don't need to ask for approval.
+	if err := api.checkOfferAdmin(backend, appOffer.OfferUUID); err == nil {
+		return nil
+	} else if err != common.ErrPerm {
+		return errors.Trace(err)
+	}
+
+	req, err := backend.RequestOfferConnection(appOffer.OfferUUID, api.Authorizer.GetAuthTag().Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch req.Status() {
+	case state.OfferConnectionRequestApproved:
+		return nil
+	case state.OfferConnectionRequestRejected:
+		return errors.Unauthorizedf("request to consume offer %q was rejected", offer.OfferURL)
+	}
+	return errors.Unauthorizedf("request to consume offer %q is awaiting approval by an offer admin", offer.OfferURL)
+}
+
+// checkOfferAdmin ensures that the logged in user is a model admin or
+// has admin access to the specified offer.
+func (api *OffersAPI) checkOfferAdmin(backend Backend, offerUUID string) error {
+	err := api.checkAdmin(backend)
+	if err != common.ErrPerm {
+		return errors.Trace(err)
+	}
+	access, err := api.checkOfferAccess(backend, offerUUID, permission.AdminAccess)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if access != permission.AdminAccess {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// offerForURL returns the backend hosting the offer with the specified URL,
+// and the offer itself. The caller must call the returned releaser.
+func (api *OffersAPI) offerForURL(urlStr string) (Backend, func(), *jujucrossmodel.ApplicationOffer, error) {
+	url, err := jujucrossmodel.ParseOfferURL(urlStr)
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	if url.HasEndpoint() {
+		return nil, nil, nil, errors.Errorf("remote application %q shouldn't include endpoint", url)
+	}
+	if url.Source != "" {
+		return nil, nil, nil, errors.NotSupportedf("query for non-local application offers")
+	}
+	model, absModelPath, ok, err := api.modelForName(url.ModelName, url.User)
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	if !ok {
+		return nil, nil, nil, errors.NotFoundf("model %q", absModelPath)
+	}
+	backend, releaser, err := api.StatePool.Get(model.UUID())
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	offer, err := backend.ApplicationOffer(url.ApplicationName)
+	if err != nil {
+		releaser()
+		return nil, nil, nil, errors.Trace(err)
+	}
+	return backend, releaser, offer, nil
+}
+
+// OfferConnectionRequests returns the requests to consume the offers
+// with the specified URLs. Only offer admins can see the requests.
+func (api *OffersAPIV3) OfferConnectionRequests(args params.OfferURLs) (params.OfferConnectionRequestsResults, error) {
+	results := make([]params.OfferConnectionRequestsResult, len(args.OfferURLs))
+	for i, urlStr := range args.OfferURLs {
+		requests, err := api.oneOfferConnectionRequests(urlStr)
+		results[i].Requests = requests
+		results[i].Error = common.ServerError(err)
+	}
+	return params.OfferConnectionRequestsResults{Results: results}, nil
+}
+
+func (api *OffersAPIV3) oneOfferConnectionRequests(urlStr string) ([]params.OfferConnectionRequest, error) {
+	backend, releaser, offer, err := api.offerForURL(urlStr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer releaser()
+
+	if err := api.checkOfferAdmin(backend, offer.OfferUUID); err != nil {
+		return nil, errors.Trace(err)
+	}
+	requests, err := backend.OfferConnectionRequests(offer.OfferUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.OfferConnectionRequest, len(requests))
+	for i, req := range requests {
+		result[i] = params.OfferConnectionRequest{
+			Username:  req.UserName(),
+			Status:    string(req.Status()),
+			Requested: req.Requested(),
+			Updated:   req.Updated(),
+		}
+	}
+	return result, nil
+}
+
+// ChangeOfferConnectionRequests approves or rejects requests to consume
+// offers. Only offer admins can change the requests.
+func (api *OffersAPIV3) ChangeOfferConnectionRequests(args params.ChangeOfferConnectionRequests) (params.ErrorResults, error) {
+	results := make([]params.ErrorResult, len(args.Changes))
+	for i, arg := range args.Changes {
+		err := api.changeOneOfferConnectionRequest(arg)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *OffersAPIV3) changeOneOfferConnectionRequest(arg params.ChangeOfferConnectionRequest) error {
+	backend, releaser, offer, err := api.offerForURL(arg.OfferURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer releaser()
+
+	if err := api.checkOfferAdmin(backend, offer.OfferUUID); err != nil {
+		return errors.Trace(err)
+	}
+	if arg.Approve {
+		return backend.ApproveOfferConnectionRequest(offer.OfferUUID, arg.Username)
+	}
+	return backend.RejectOfferConnectionRequest(offer.OfferUUID, arg.Username)
+}
+
 // RemoteApplicationInfo returns information about the requested remote application.
 func (api *OffersAPI) RemoteApplicationInfo(args params.OfferURLs) (params.RemoteApplicationInfoResults, error) {
 	results := make([]params.RemoteApplicationInfoResult, len(args.OfferURLs))
@@ -6,6 +6,7 @@ package applicationoffers_test
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -117,6 +118,34 @@ func (s *applicationOffersSuite) TestOffer(c *gc.C) {
 	s.assertOffer(c, nil)
 }
 
+func (s *applicationOffersSuite) TestOfferWithLimits(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	one := params.AddApplicationOffer{
+		ModelTag:               testing.ModelTag.String(),
+		OfferName:              "offer-test",
+		ApplicationName:        "test",
+		ApplicationDescription: "a blog",
+		Endpoints:              map[string]string{"db": "db"},
+		RequireApproval:        true,
+		MaxConsumers:           2,
+		MaxRelations:           3,
+	}
+	s.applicationOffers.addOffer = func(offer jujucrossmodel.AddApplicationOfferArgs) (*jujucrossmodel.ApplicationOffer, error) {
+		c.Check(offer.RequireApproval, jc.IsTrue)
+		c.Check(offer.MaxConsumers, gc.Equals, 2)
+		c.Check(offer.MaxRelations, gc.Equals, 3)
+		return &jujucrossmodel.ApplicationOffer{}, nil
+	}
+	s.mockState.applications = map[string]crossmodel.Application{
+		"test": &mockApplication{charm: &mockCharm{}, bindings: map[string]string{"db": "myspace"}},
+	}
+
+	errs, err := s.api.Offer(params.AddApplicationOffers{Offers: []params.AddApplicationOffer{one}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs.OneError(), jc.ErrorIsNil)
+	s.applicationOffers.CheckCallNames(c, addOffersBackendCall)
+}
+
 func (s *applicationOffersSuite) TestOfferPermission(c *gc.C) {
 	s.authorizer.Tag = names.NewUserTag("mary")
 	s.assertOffer(c, common.ErrPerm)
@@ -1395,3 +1424,131 @@ func (s *consumeSuite) TestDestroyOffersPermission(c *gc.C) {
 	c.Assert(results.Results, gc.HasLen, 1)
 	c.Assert(results.Results[0].Error, gc.ErrorMatches, common.ErrPerm.Error())
 }
+
+func (s *consumeSuite) setupApprovalOffer(c *gc.C, username string) *mockState {
+	s.setupOffer()
+	st := s.mockStatePool.st[testing.ModelTag.Id()].(*mockState)
+	offer := st.applicationOffers["hosted-mysql"]
+	offer.RequireApproval = true
+	st.applicationOffers["hosted-mysql"] = offer
+	st.users[username] = &mockUser{username}
+	err := st.CreateOfferAccess(
+		names.NewApplicationOfferTag("hosted-mysql"), names.NewUserTag(username), permission.ConsumeAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	return st
+}
+
+func (s *consumeSuite) consumeDetails(c *gc.C, username string) params.ConsumeOfferDetailsResult {
+	s.authorizer.Tag = names.NewUserTag(username)
+	results, err := s.api.GetConsumeDetails(params.OfferURLs{
+		OfferURLs: []string{"fred/prod.hosted-mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results.Results[0]
+}
+
+func (s *consumeSuite) changeRequest(c *gc.C, username string, approve bool) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	api := &applicationoffers.OffersAPIV3{OffersAPIV2: s.api}
+	results, err := api.ChangeOfferConnectionRequests(params.ChangeOfferConnectionRequests{
+		Changes: []params.ChangeOfferConnectionRequest{{
+			OfferURL: "fred/prod.hosted-mysql",
+			Username: username,
+			Approve:  approve,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+}
+
+func (s *consumeSuite) TestConsumeDetailsAwaitingApproval(c *gc.C) {
+	st := s.setupApprovalOffer(c, "someone")
+
+	result := s.consumeDetails(c, "someone")
+	c.Assert(result.Error, gc.ErrorMatches, `request to consume offer "fred/prod.hosted-mysql" is awaiting approval by an offer admin`)
+	c.Assert(result.Error.Code, gc.Equals, params.CodeUnauthorized)
+	c.Assert(result.Offer, gc.IsNil)
+	c.Assert(result.Macaroon, gc.IsNil)
+
+	req := st.requests[offerAccess{user: names.NewUserTag("someone"), offerUUID: "hosted-mysql-uuid"}]
+	c.Assert(req, gc.NotNil)
+	c.Assert(req.status, gc.Equals, state.OfferConnectionRequestPending)
+}
+
+func (s *consumeSuite) TestConsumeDetailsApproved(c *gc.C) {
+	s.setupApprovalOffer(c, "someone")
+	s.consumeDetails(c, "someone")
+	s.changeRequest(c, "someone", true)
+
+	result := s.consumeDetails(c, "someone")
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Offer.OfferUUID, gc.Equals, "hosted-mysql-uuid")
+	c.Assert(result.Macaroon, gc.NotNil)
+}
+
+func (s *consumeSuite) TestConsumeDetailsRejected(c *gc.C) {
+	s.setupApprovalOffer(c, "someone")
+	s.consumeDetails(c, "someone")
+	s.changeRequest(c, "someone", false)
+
+	result := s.consumeDetails(c, "someone")
+	c.Assert(result.Error, gc.ErrorMatches, `request to consume offer "fred/prod.hosted-mysql" was rejected`)
+}
+
+func (s *consumeSuite) TestConsumeDetailsApprovalNotNeededByAdmin(c *gc.C) {
+	st := s.setupApprovalOffer(c, "someone")
+	err := st.UpdateOfferAccess(
+		names.NewApplicationOfferTag("hosted-mysql"), names.NewUserTag("someone"), permission.AdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := s.consumeDetails(c, "someone")
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(st.requests, gc.HasLen, 0)
+}
+
+func (s *consumeSuite) TestOfferConnectionRequests(c *gc.C) {
+	s.setupApprovalOffer(c, "someone")
+	s.consumeDetails(c, "someone")
+
+	s.authorizer.Tag = names.NewUserTag("admin")
+	api := &applicationoffers.OffersAPIV3{OffersAPIV2: s.api}
+	results, err := api.OfferConnectionRequests(params.OfferURLs{
+		OfferURLs: []string{"fred/prod.hosted-mysql", "fred/prod.unknown"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	requested := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.Assert(results.Results, jc.DeepEquals, []params.OfferConnectionRequestsResult{{
+		Requests: []params.OfferConnectionRequest{{
+			Username:  "someone",
+			Status:    "pending",
+			Requested: requested,
+			Updated:   requested,
+		}},
+	}, {
+		Error: &params.Error{Message: `application offer "unknown" not found`, Code: "not found"},
+	}})
+}
+
+func (s *consumeSuite) TestOfferConnectionRequestsPermission(c *gc.C) {
+	s.setupApprovalOffer(c, "someone")
+
+	s.authorizer.Tag = names.NewUserTag("someone")
+	api := &applicationoffers.OffersAPIV3{OffersAPIV2: s.api}
+	results, err := api.OfferConnectionRequests(params.OfferURLs{
+		OfferURLs: []string{"fred/prod.hosted-mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, common.ErrPerm.Error())
+
+	changes, err := api.ChangeOfferConnectionRequests(params.ChangeOfferConnectionRequests{
+		Changes: []params.ChangeOfferConnectionRequest{{
+			OfferURL: "fred/prod.hosted-mysql",
+			Username: "someone",
+			Approve:  true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.OneError(), gc.ErrorMatches, common.ErrPerm.Error())
+}
@@ -169,6 +169,9 @@ func (api *BaseAPI) applicationOffersFromModel(
 		}
 		// Only admins can see some sensitive details of the offer.
 		if isAdmin {
+			offer.RequireApproval = appOffer.RequireApproval
+			offer.MaxConsumers = appOffer.MaxConsumers
+			offer.MaxRelations = appOffer.MaxRelations
 			if err := api.getOfferAdminDetails(backend, app, &offer); err != nil {
 				logger.Warningf("cannot get offer admin details: %v", err)
 			}
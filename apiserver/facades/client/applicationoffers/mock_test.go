@@ -294,6 +294,7 @@ type mockState struct {
 	connections       []applicationoffers.OfferConnection
 	accessPerms       map[offerAccess]permission.Access
 	relationNetworks  state.RelationNetworks
+	requests          map[offerAccess]*mockOfferConnectionRequest
 }
 
 func (m *mockState) GetAddressAndCertGetter() common.AddressAndCertGetter {
@@ -448,6 +449,74 @@ func (m *mockState) GetOfferUsers(offerUUID string) (map[string]permission.Acces
 	return result, nil
 }
 
+func (m *mockState) RequestOfferConnection(offerUUID, username string) (applicationoffers.OfferConnectionRequest, error) {
+	key := offerAccess{user: names.NewUserTag(username), offerUUID: offerUUID}
+	if req, ok := m.requests[key]; ok {
+		return req, nil
+	}
+	if m.requests == nil {
+		m.requests = make(map[offerAccess]*mockOfferConnectionRequest)
+	}
+	req := &mockOfferConnectionRequest{
+		username:  username,
+		status:    state.OfferConnectionRequestPending,
+		requested: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	req.updated = req.requested
+	m.requests[key] = req
+	return req, nil
+}
+
+func (m *mockState) OfferConnectionRequests(offerUUID string) ([]applicationoffers.OfferConnectionRequest, error) {
+	var result []applicationoffers.OfferConnectionRequest
+	for key, req := range m.requests {
+		if key.offerUUID == offerUUID {
+			result = append(result, req)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockState) ApproveOfferConnectionRequest(offerUUID, username string) error {
+	return m.setRequestStatus(offerUUID, username, state.OfferConnectionRequestApproved)
+}
+
+func (m *mockState) RejectOfferConnectionRequest(offerUUID, username string) error {
+	return m.setRequestStatus(offerUUID, username, state.OfferConnectionRequestRejected)
+}
+
+func (m *mockState) setRequestStatus(offerUUID, username string, status state.OfferConnectionRequestStatus) error {
+	req, ok := m.requests[offerAccess{user: names.NewUserTag(username), offerUUID: offerUUID}]
+	if !ok {
+		return errors.NotFoundf("request by %q to consume offer %q", username, offerUUID)
+	}
+	req.status = status
+	return nil
+}
+
+type mockOfferConnectionRequest struct {
+	username  string
+	status    state.OfferConnectionRequestStatus
+	requested time.Time
+	updated   time.Time
+}
+
+func (m *mockOfferConnectionRequest) UserName() string {
+	return m.username
+}
+
+func (m *mockOfferConnectionRequest) Status() state.OfferConnectionRequestStatus {
+	return m.status
+}
+
+func (m *mockOfferConnectionRequest) Requested() time.Time {
+	return m.requested
+}
+
+func (m *mockOfferConnectionRequest) Updated() time.Time {
+	return m.updated
+}
+
 type mockStatePool struct {
 	st map[string]applicationoffers.Backend
 }
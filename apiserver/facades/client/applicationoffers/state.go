@@ -4,6 +4,8 @@
 package applicationoffers
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -66,6 +68,11 @@ type Backend interface {
 	UpdateOfferAccess(offer names.ApplicationOfferTag, user names.UserTag, access permission.Access) error
 	RemoveOfferAccess(offer names.ApplicationOfferTag, user names.UserTag) error
 	GetOfferUsers(offerUUID string) (map[string]permission.Access, error)
+
+	RequestOfferConnection(offerUUID, username string) (OfferConnectionRequest, error)
+	OfferConnectionRequests(offerUUID string) ([]OfferConnectionRequest, error)
+	ApproveOfferConnectionRequest(offerUUID, username string) error
+	RejectOfferConnectionRequest(offerUUID, username string) error
 }
 
 var GetStateAccess = func(st *state.State) Backend {
@@ -96,6 +103,34 @@ func (s stateShim) GetOfferUsers(offerUUID string) (map[string]permission.Access
 	return s.st.GetOfferUsers(offerUUID)
 }
 
+func (s stateShim) RequestOfferConnection(offerUUID, username string) (OfferConnectionRequest, error) {
+	req, err := s.st.RequestOfferConnection(offerUUID, username)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (s stateShim) OfferConnectionRequests(offerUUID string) ([]OfferConnectionRequest, error) {
+	reqs, err := s.st.OfferConnectionRequests(offerUUID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]OfferConnectionRequest, len(reqs))
+	for i, req := range reqs {
+		result[i] = req
+	}
+	return result, nil
+}
+
+func (s stateShim) ApproveOfferConnectionRequest(offerUUID, username string) error {
+	return s.st.ApproveOfferConnectionRequest(offerUUID, username)
+}
+
+func (s stateShim) RejectOfferConnectionRequest(offerUUID, username string) error {
+	return s.st.RejectOfferConnectionRequest(offerUUID, username)
+}
+
 func (s *stateShim) Space(name string) (Space, error) {
 	sp, err := s.st.Space(name)
 	return &spaceShim{sp}, err
@@ -206,6 +241,13 @@ type offerConnectionShim struct {
 	*state.OfferConnection
 }
 
+type OfferConnectionRequest interface {
+	UserName() string
+	Status() state.OfferConnectionRequestStatus
+	Requested() time.Time
+	Updated() time.Time
+}
+
 func (s *stateShim) User(tag names.UserTag) (User, error) {
 	return s.st.User(tag)
 }
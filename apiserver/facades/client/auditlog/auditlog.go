@@ -0,0 +1,114 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog implements the AuditLog facade, which lets
+// controller superusers query the audit log recorded in the
+// controller database.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	coreauditlog "github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/permission"
+)
+
+// API implements the AuditLog facade.
+type API struct {
+	querier coreauditlog.Querier
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.StatePool().SystemState()
+	return NewAPI(st.AuditLogStore(), ctx.Auth(), st.ControllerTag())
+}
+
+// NewAPI returns a new AuditLog API facade. Only controller
+// superusers may query the audit log.
+func NewAPI(querier coreauditlog.Querier, authorizer facade.Authorizer, controllerTag names.ControllerTag) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isSuperuser, err := authorizer.HasPermission(permission.SuperuserAccess, controllerTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isSuperuser {
+		return nil, common.ErrPerm
+	}
+	return &API{querier: querier}, nil
+}
+
+// Query returns the audit log conversations matching the arguments,
+// most recent first.
+func (api *API) Query(args params.AuditLogQueryArgs) (params.AuditLogQueryResult, error) {
+	filter := coreauditlog.Filter{
+		Who:    args.Who,
+		Model:  args.Model,
+		Facade: args.Facade,
+		Method: args.Method,
+		Limit:  args.Limit,
+	}
+	if args.After != nil {
+		filter.After = *args.After
+	}
+	if args.Before != nil {
+		filter.Before = *args.Before
+	}
+	if err := filter.Validate(); err != nil {
+		return params.AuditLogQueryResult{}, errors.Trace(err)
+	}
+	details, err := api.querier.Query(filter)
+	if err != nil {
+		return params.AuditLogQueryResult{}, errors.Trace(err)
+	}
+	result := params.AuditLogQueryResult{
+		Conversations: make([]params.AuditConversation, len(details)),
+	}
+	for i, d := range details {
+		result.Conversations[i] = toParams(d)
+	}
+	return result, nil
+}
+
+func toParams(d coreauditlog.ConversationDetails) params.AuditConversation {
+	errorsByRequest := make(map[uint64][]params.AuditError)
+	for _, resp := range d.Errors {
+		for _, e := range resp.Errors {
+			if e == nil {
+				continue
+			}
+			errorsByRequest[resp.RequestID] = append(errorsByRequest[resp.RequestID], params.AuditError{
+				Message: e.Message,
+				Code:    e.Code,
+			})
+		}
+	}
+	conv := params.AuditConversation{
+		ConversationID: d.Conversation.ConversationID,
+		ConnectionID:   d.Conversation.ConnectionID,
+		Who:            d.Conversation.Who,
+		What:           d.Conversation.What,
+		When:           d.Conversation.When,
+		ModelName:      d.Conversation.ModelName,
+		ModelOwner:     d.Conversation.ModelOwner,
+		ModelUUID:      d.Conversation.ModelUUID,
+	}
+	for _, req := range d.Requests {
+		conv.Requests = append(conv.Requests, params.AuditRequest{
+			RequestID: req.RequestID,
+			When:      req.When,
+			Facade:    req.Facade,
+			Method:    req.Method,
+			Version:   req.Version,
+			Args:      req.Args,
+			Errors:    errorsByRequest[req.RequestID],
+		})
+	}
+	return conv
+}
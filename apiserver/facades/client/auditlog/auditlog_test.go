@@ -0,0 +1,145 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreauditlog "github.com/juju/juju/core/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	testing.IsolationSuite
+
+	querier    *stubQuerier
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.querier = &stubQuerier{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("superuser-alice"),
+	}
+}
+
+func (s *auditLogSuite) newAPI(c *gc.C) *auditlog.API {
+	api, err := auditlog.NewAPI(s.querier, s.authorizer, coretesting.ControllerTag)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *auditLogSuite) TestNonSuperuserDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin-bob")
+	_, err := auditlog.NewAPI(s.querier, s.authorizer, coretesting.ControllerTag)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestAgentDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := auditlog.NewAPI(s.querier, s.authorizer, coretesting.ControllerTag)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	s.querier.results = []coreauditlog.ConversationDetails{{
+		Conversation: coreauditlog.Conversation{
+			Who:            "fred",
+			What:           "juju deploy mysql",
+			When:           "2018-11-01T10:00:00Z",
+			ModelName:      "default",
+			ModelOwner:     "fred",
+			ModelUUID:      "deadbeef",
+			ConversationID: "0001",
+			ConnectionID:   "AC1",
+		},
+		Requests: []coreauditlog.Request{{
+			RequestID: 1,
+			When:      "2018-11-01T10:00:00Z",
+			Facade:    "Application",
+			Method:    "Deploy",
+			Version:   7,
+		}, {
+			RequestID: 2,
+			When:      "2018-11-01T10:00:01Z",
+			Facade:    "Application",
+			Method:    "Expose",
+			Version:   7,
+		}},
+		Errors: []coreauditlog.ResponseErrors{{
+			RequestID: 2,
+			Errors:    []*coreauditlog.Error{{Message: "boom", Code: "bad"}},
+		}},
+	}}
+	after := time.Date(2018, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	result, err := s.newAPI(c).Query(params.AuditLogQueryArgs{
+		Who:    "fred",
+		Method: "Deploy",
+		After:  &after,
+		Limit:  10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.querier.CheckCall(c, 0, "Query", coreauditlog.Filter{
+		Who:    "fred",
+		Method: "Deploy",
+		After:  after,
+		Limit:  10,
+	})
+	c.Assert(result, jc.DeepEquals, params.AuditLogQueryResult{
+		Conversations: []params.AuditConversation{{
+			ConversationID: "0001",
+			ConnectionID:   "AC1",
+			Who:            "fred",
+			What:           "juju deploy mysql",
+			When:           "2018-11-01T10:00:00Z",
+			ModelName:      "default",
+			ModelOwner:     "fred",
+			ModelUUID:      "deadbeef",
+			Requests: []params.AuditRequest{{
+				RequestID: 1,
+				When:      "2018-11-01T10:00:00Z",
+				Facade:    "Application",
+				Method:    "Deploy",
+				Version:   7,
+			}, {
+				RequestID: 2,
+				When:      "2018-11-01T10:00:01Z",
+				Facade:    "Application",
+				Method:    "Expose",
+				Version:   7,
+				Errors:    []params.AuditError{{Message: "boom", Code: "bad"}},
+			}},
+		}},
+	})
+}
+
+func (s *auditLogSuite) TestQueryInvalidFilter(c *gc.C) {
+	_, err := s.newAPI(c).Query(params.AuditLogQueryArgs{Limit: -1})
+	c.Assert(err, gc.ErrorMatches, "negative limit -1 not valid")
+	s.querier.CheckNoCalls(c)
+}
+
+type stubQuerier struct {
+	testing.Stub
+	results []coreauditlog.ConversationDetails
+}
+
+func (q *stubQuerier) Query(filter coreauditlog.Filter) ([]coreauditlog.ConversationDetails, error) {
+	q.AddCall("Query", filter)
+	return q.results, q.NextErr()
+}
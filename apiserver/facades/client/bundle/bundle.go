@@ -267,12 +267,28 @@ func (b *BundleAPI) ExportBundle() (params.StringResult, error) {
 // but in a more user oriented output order, with the description first,
 // then the distro series, then the apps, machines and releations.
 type bundleOutput struct {
-	Type         string                            `yaml:"bundle,omitempty"`
-	Description  string                            `yaml:"description,omitempty"`
-	Series       string                            `yaml:"series,omitempty"`
-	Applications map[string]*charm.ApplicationSpec `yaml:"applications,omitempty"`
-	Machines     map[string]*charm.MachineSpec     `yaml:"machines,omitempty"`
-	Relations    [][]string                        `yaml:"relations,omitempty"`
+	Type         string                        `yaml:"bundle,omitempty"`
+	Description  string                        `yaml:"description,omitempty"`
+	Series       string                        `yaml:"series,omitempty"`
+	Applications map[string]*bundleApplication `yaml:"applications,omitempty"`
+	Machines     map[string]*charm.MachineSpec `yaml:"machines,omitempty"`
+	Relations    [][]string                    `yaml:"relations,omitempty"`
+}
+
+// bundleApplication extends charm.ApplicationSpec with the spaces and
+// CIDRs the endpoints of an exposed application are exposed to, which
+// the charm package does not know about.
+type bundleApplication struct {
+	charm.ApplicationSpec `yaml:",inline"`
+
+	ExposedEndpoints map[string]bundleExposedEndpoint `yaml:"exposed-endpoints,omitempty"`
+}
+
+// bundleExposedEndpoint describes where an endpoint of an exposed
+// application may be accessed from.
+type bundleExposedEndpoint struct {
+	ExposeToSpaces []string `yaml:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs  []string `yaml:"expose-to-cidrs,omitempty"`
 }
 
 // Mask the new method from V1 API.
@@ -288,7 +304,7 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*bundleOutput, erro
 	defaultSeries := fmt.Sprintf("%v", value)
 
 	data := &bundleOutput{
-		Applications: make(map[string]*charm.ApplicationSpec),
+		Applications: make(map[string]*bundleApplication),
 		Machines:     make(map[string]*charm.MachineSpec),
 		Relations:    [][]string{},
 	}
@@ -371,7 +387,23 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*bundleOutput, erro
 			}
 		}
 
-		data.Applications[application.Name()] = newApplication
+		bundleApp := &bundleApplication{ApplicationSpec: *newApplication}
+		if application.Exposed() {
+			exposedEndpoints, err := b.backend.ExposedEndpoints(application.Name())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for endpoint, exposed := range exposedEndpoints {
+				if bundleApp.ExposedEndpoints == nil {
+					bundleApp.ExposedEndpoints = make(map[string]bundleExposedEndpoint)
+				}
+				bundleApp.ExposedEndpoints[endpoint] = bundleExposedEndpoint{
+					ExposeToSpaces: exposed.ExposeToSpaces,
+					ExposeToCIDRs:  exposed.ExposeToCIDRs,
+				}
+			}
+		}
+		data.Applications[application.Name()] = bundleApp
 	}
 
 	for _, machine := range model.Machines() {
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/bundle"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -773,6 +774,54 @@ applications:
 	s.st.CheckCall(c, 0, "ExportPartial", s.st.GetExportConfig())
 }
 
+func (s *bundleSuite) TestExportBundleExposedEndpoints(c *gc.C) {
+	s.st.model = description.NewModel(description.ModelArgs{Owner: names.NewUserTag("magic"),
+		Config: map[string]interface{}{
+			"name": "awesome",
+			"uuid": "some-uuid",
+		},
+		CloudRegion: "some-region"})
+
+	application := s.st.model.AddApplication(description.ApplicationArgs{
+		Tag:         names.NewApplicationTag("magic"),
+		Series:      "zesty",
+		Subordinate: true,
+		CharmURL:    "cs:zesty/magic",
+		Channel:     "stable",
+		Exposed:     true,
+	})
+	application.SetStatus(minimalStatusArgs())
+	s.st.exposedEndpoints = map[string]map[string]state.ExposedEndpoint{
+		"magic": {
+			"website": {
+				ExposeToSpaces: []string{"dmz"},
+				ExposeToCIDRs:  []string{"10.0.0.0/8"},
+			},
+		},
+	}
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+
+	expectedResult := params.StringResult{nil, `
+series: zesty
+applications:
+  magic:
+    charm: cs:zesty/magic
+    expose: true
+    exposed-endpoints:
+      website:
+        expose-to-spaces:
+        - dmz
+        expose-to-cidrs:
+        - 10.0.0.0/8
+`[1:]}
+
+	c.Assert(result, gc.Equals, expectedResult)
+	s.st.CheckCall(c, 0, "ExportPartial", s.st.GetExportConfig())
+	s.st.CheckCall(c, 1, "ExposedEndpoints", "magic")
+}
+
 func (s *bundleSuite) addMinimalMachineWithConstraints(model description.Model, id string) {
 	m := model.AddMachine(description.MachineArgs{
 		Id:           names.NewMachineTag(id),
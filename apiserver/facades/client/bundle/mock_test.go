@@ -14,7 +14,8 @@ import (
 type mockState struct {
 	testing.Stub
 	bundle.Backend
-	model description.Model
+	model            description.Model
+	exposedEndpoints map[string]map[string]state.ExposedEndpoint
 }
 
 func (m *mockState) ExportPartial(config state.ExportConfig) (description.Model, error) {
@@ -38,6 +39,14 @@ func (m *mockState) GetExportConfig() state.ExportConfig {
 	}
 }
 
+func (m *mockState) ExposedEndpoints(appName string) (map[string]state.ExposedEndpoint, error) {
+	m.MethodCall(m, "ExposedEndpoints", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.exposedEndpoints[appName], nil
+}
+
 func newMockState() *mockState {
 	st := &mockState{
 		Stub: testing.Stub{},
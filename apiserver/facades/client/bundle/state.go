@@ -5,6 +5,7 @@ package bundle
 
 import (
 	"github.com/juju/description"
+	"github.com/juju/errors"
 
 	"github.com/juju/juju/state"
 )
@@ -12,6 +13,7 @@ import (
 type Backend interface {
 	ExportPartial(cfg state.ExportConfig) (description.Model, error)
 	GetExportConfig() state.ExportConfig
+	ExposedEndpoints(appName string) (map[string]state.ExposedEndpoint, error)
 }
 
 type stateShim struct {
@@ -36,6 +38,15 @@ func (m *stateShim) GetExportConfig() state.ExportConfig {
 	return cfg
 }
 
+// ExposedEndpoints implements Backend.ExposedEndpoints.
+func (m *stateShim) ExposedEndpoints(appName string) (map[string]state.ExposedEndpoint, error) {
+	app, err := m.State.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.ExposedEndpoints(), nil
+}
+
 // NewStateShim creates new state shim to be used by bundle Facade.
 func NewStateShim(st *state.State) Backend {
 	return &stateShim{st}
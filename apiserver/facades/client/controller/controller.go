@@ -7,17 +7,21 @@ package controller
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 	"strings"
 
+	"github.com/juju/description"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/txn"
 	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v2-unstable"
 
 	"github.com/juju/juju/api"
+	cloudclient "github.com/juju/juju/api/cloud"
 	controllerclient "github.com/juju/juju/api/controller"
 	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/api/usermanager"
@@ -31,6 +35,7 @@ import (
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.controller")
@@ -50,10 +55,16 @@ type ControllerAPI struct {
 	hub        facade.Hub
 }
 
+// ControllerAPIv7 provides the v7 Controller API. The only difference
+// between this and v8 is that v7 doesn't have the DryRunMigration method.
+type ControllerAPIv7 struct {
+	*ControllerAPI
+}
+
 // ControllerAPIv6 provides the v6 Controller API. The only difference
 // between this and v7 is that v6 doesn't have the IdentityProviderURL method.
 type ControllerAPIv6 struct {
-	*ControllerAPI
+	*ControllerAPIv7
 }
 
 // ControllerAPIv5 provides the v5 Controller API. The only difference
@@ -74,8 +85,8 @@ type ControllerAPIv3 struct {
 	*ControllerAPIv4
 }
 
-// NewControllerAPIv7 creates a new ControllerAPIv7.
-func NewControllerAPIv7(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv8 creates a new ControllerAPIv8.
+func NewControllerAPIv8(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -93,6 +104,15 @@ func NewControllerAPIv7(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv7 creates a new ControllerAPIv7.
+func NewControllerAPIv7(ctx facade.Context) (*ControllerAPIv7, error) {
+	v8, err := NewControllerAPIv8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv7{v8}, nil
+}
+
 // NewControllerAPIv6 creates a new ControllerAPIv6.
 func NewControllerAPIv6(ctx facade.Context) (*ControllerAPIv6, error) {
 	v7, err := NewControllerAPIv7(ctx)
@@ -523,23 +543,46 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 	}
 	defer hostedState.Release()
 
-	// Construct target info.
-	specTarget := spec.TargetInfo
+	targetInfo, err := makeTargetInfo(spec.TargetInfo)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Check if the migration is likely to succeed.
+	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return mig.Id(), nil
+}
+
+// makeTargetInfo constructs the core migration target info from the
+// target info in a migration spec.
+func makeTargetInfo(specTarget params.MigrationTargetInfo) (coremigration.TargetInfo, error) {
+	var empty coremigration.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return empty, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return empty, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return empty, errors.Annotate(err, "invalid macaroons")
 		}
 	}
-	targetInfo := coremigration.TargetInfo{
+	return coremigration.TargetInfo{
 		ControllerTag:   controllerTag,
 		ControllerAlias: specTarget.ControllerAlias,
 		Addrs:           specTarget.Addrs,
@@ -547,22 +590,71 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		AuthTag:         authTag,
 		Password:        specTarget.Password,
 		Macaroons:       macs,
+	}, nil
+}
+
+// DryRunMigration isn't on the v7 API.
+func (c *ControllerAPIv7) DryRunMigration() {}
+
+// DryRunMigration checks whether one or more models could be migrated
+// to other controllers, without starting the migrations. The source and
+// target prechecks are run and each model is exported, but nothing is
+// changed on either controller. Any issues which would prevent a
+// migration from succeeding are reported, along with an estimate of
+// the data which would be transferred to the target controller.
+func (c *ControllerAPI) DryRunMigration(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationDryRunResults, error,
+) {
+	out := params.MigrationDryRunResults{
+		Results: make([]params.MigrationDryRunResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
 	}
 
-	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
-		return "", errors.Trace(err)
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		issues, sizes, err := c.dryRunOneMigration(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+			continue
+		}
+		result.Issues = issues
+		result.ModelDescriptionSize = sizes.ModelDescription
+		result.CharmsSize = sizes.Charms
+		result.ToolsSize = sizes.Tools
+		result.ResourcesSize = sizes.Resources
 	}
+	return out, nil
+}
 
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
+func (c *ControllerAPI) dryRunOneMigration(spec params.MigrationSpec) ([]string, migration.TransferSizes, error) {
+	var sizes migration.TransferSizes
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
 	if err != nil {
-		return "", errors.Trace(err)
+		return nil, sizes, errors.Annotate(err, "model tag")
 	}
-	return mig.Id(), nil
+
+	// Ensure the model exists.
+	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
+		return nil, sizes, errors.Annotate(err, "reading model")
+	} else if !modelExists {
+		return nil, sizes, errors.NotFoundf("model")
+	}
+
+	hostedState, err := c.statePool.Get(modelTag.Id())
+	if err != nil {
+		return nil, sizes, errors.Trace(err)
+	}
+	defer hostedState.Release()
+
+	targetInfo, err := makeTargetInfo(spec.TargetInfo)
+	if err != nil {
+		return nil, sizes, errors.Trace(err)
+	}
+	issues, sizes, err := runMigrationDryRun(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence)
+	return issues, sizes, errors.Trace(err)
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -687,6 +779,155 @@ var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigrat
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// runMigrationDryRun runs the same checks as runMigrationPrechecks, along
+// with some additional checks on the model's cloud, credential and
+// offers, and estimates the size of the data to be transferred by
+// exporting the model. Unlike runMigrationPrechecks, a failed check
+// doesn't stop the remaining checks from being run; all problems found
+// are returned as issues. An error is only returned if the checks
+// themselves could not be run.
+var runMigrationDryRun = func(
+	st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence,
+) ([]string, migration.TransferSizes, error) {
+	var issues []string
+	var sizes migration.TransferSizes
+	addIssue := func(err error) {
+		issues = append(issues, err.Error())
+	}
+
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st, ctlrSt)
+	if err != nil {
+		return nil, sizes, errors.Annotate(err, "creating backend")
+	}
+	modelPresence := presence.ModelPresence(st.ModelUUID())
+	controllerPresence := presence.ModelPresence(ctlrSt.ModelUUID())
+	if err := migration.SourcePrecheck(backend, modelPresence, controllerPresence); err != nil {
+		addIssue(errors.Annotate(err, "source prechecks failed"))
+	}
+	model, err := st.Model()
+	if err != nil {
+		return nil, sizes, errors.Trace(err)
+	}
+	if credTag, ok := model.CloudCredential(); ok {
+		cred, err := st.CloudCredential(credTag)
+		if err != nil {
+			return nil, sizes, errors.Trace(err)
+		}
+		if !cred.IsValid() {
+			addIssue(errors.Errorf("model credential %q is not valid", credTag.Id()))
+		}
+	}
+	offerIssues, err := checkOffersForMigration(st)
+	if err != nil {
+		return nil, sizes, errors.Trace(err)
+	}
+	issues = append(issues, offerIssues...)
+
+	// Export the model to estimate the transfer sizes. The exported
+	// model is discarded rather than imported into the target.
+	sizes, err = estimateTransferSizes(st)
+	if err != nil {
+		addIssue(errors.Annotate(err, "exporting model"))
+	}
+
+	// Check target controller.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		addIssue(errors.Annotate(err, "connect to target controller"))
+		return issues, sizes, nil
+	}
+	defer conn.Close()
+	modelInfo, srcUserList, err := makeModelInfo(st, ctlrSt)
+	if err != nil {
+		return nil, sizes, errors.Trace(err)
+	}
+	dstUserList, err := getTargetControllerUsers(conn)
+	if err != nil {
+		return nil, sizes, errors.Trace(err)
+	}
+	if err = srcUserList.checkCompatibilityWith(dstUserList); err != nil {
+		addIssue(err)
+	}
+	_, err = cloudclient.NewClient(conn).Cloud(names.NewCloudTag(model.Cloud()))
+	if errors.IsNotFound(err) || params.IsCodeNotFound(err) {
+		addIssue(errors.Errorf("cloud %q not found on target controller", model.Cloud()))
+	} else if err != nil {
+		return nil, sizes, errors.Annotate(err, "checking target controller cloud")
+	}
+	client := migrationtarget.NewClient(conn)
+	if targetInfo.CACert == "" {
+		targetInfo.CACert, err = client.CACert()
+		if err != nil {
+			if !params.IsCodeNotImplemented(err) {
+				return nil, sizes, errors.Annotatef(err, "cannot retrieve CA certificate")
+			}
+			// If the call's not implemented, it indicates an earlier version
+			// of the controller, which we can't migrate to.
+			addIssue(errors.New("controller API version is too old"))
+			return issues, sizes, nil
+		}
+	}
+	if err := client.Prechecks(modelInfo); err != nil {
+		addIssue(errors.Annotate(err, "target prechecks failed"))
+	}
+	return issues, sizes, nil
+}
+
+// checkOffersForMigration returns an issue for each application offer
+// in the model which is in use by consuming models. Those relations
+// would be broken by migrating the model.
+func checkOffersForMigration(st *state.State) ([]string, error) {
+	offers, err := state.NewApplicationOffers(st).AllApplicationOffers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var issues []string
+	for _, offer := range offers {
+		conns, err := st.OfferConnections(offer.OfferUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(conns) > 0 {
+			issues = append(issues, fmt.Sprintf(
+				"offer %q has %d active connection(s)", offer.OfferName, len(conns)))
+		}
+	}
+	return issues, nil
+}
+
+// estimateTransferSizes exports the model and estimates the number of
+// bytes which would be transferred to the target controller.
+func estimateTransferSizes(st *state.State) (migration.TransferSizes, error) {
+	model, err := st.Export()
+	if err != nil {
+		return migration.TransferSizes{}, errors.Trace(err)
+	}
+	serialized, err := description.Serialize(model)
+	if err != nil {
+		return migration.TransferSizes{}, errors.Trace(err)
+	}
+	store := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	charmSize := func(curlStr string) (int64, error) {
+		curl, err := charm.ParseURL(curlStr)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		ch, err := st.Charm(curl)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		r, length, err := store.Get(ch.StoragePath())
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		r.Close()
+		return length, nil
+	}
+	sizes, err := migration.EstimateTransferSizes(model, serialized, charmSize)
+	return sizes, errors.Trace(err)
+}
+
 // userList encapsulates information about the users who have been granted
 // access to a model or the users known to a particular controller.
 type userList struct {
@@ -27,6 +27,7 @@ import (
 	corecontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/migration"
 	"github.com/juju/juju/permission"
 	pscontroller "github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/state"
@@ -64,7 +65,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 	}
 	s.hub = pubsub.NewStructuredHub(nil)
 
-	controller, err := controller.NewControllerAPIv7(
+	controller, err := controller.NewControllerAPIv8(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.StatePool,
@@ -574,6 +575,102 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestDryRunMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetDryRunResult(s, []string{"users missing"}, migration.TransferSizes{
+		ModelDescription: 1,
+		Charms:           2,
+		Tools:            3,
+		Resources:        4,
+	}, nil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{
+			{
+				ModelTag: m.ModelTag().String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: randomControllerTag(),
+					Addrs:         []string{"1.1.1.1:1111"},
+					CACert:        "cert",
+					AuthTag:       names.NewUserTag("admin").String(),
+					Password:      "secret",
+				},
+			}, {
+				ModelTag: randomModelTag(), // Doesn't exist.
+			},
+		},
+	}
+	out, err := s.controller.DryRunMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.DeepEquals, []params.MigrationDryRunResult{{
+		ModelTag:             m.ModelTag().String(),
+		Issues:               []string{"users missing"},
+		ModelDescriptionSize: 1,
+		CharmsSize:           2,
+		ToolsSize:            3,
+		ResourcesSize:        4,
+	}, {
+		ModelTag: args.Specs[1].ModelTag,
+		Error: &params.Error{
+			Message: "model not found",
+			Code:    params.CodeNotFound,
+		},
+	}})
+
+	// No migration is started.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestDryRunMigrationError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetDryRunResult(s, nil, migration.TransferSizes{}, errors.New("boom"))
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+		}},
+	}
+	out, err := s.controller.DryRunMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *controllerSuite) TestDryRunMigrationRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPIv8(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endpoint.DryRunMigration(params.InitiateMigrationArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -5,7 +5,8 @@ package controller
 
 import (
 	"github.com/juju/juju/apiserver/facade"
-	"github.com/juju/juju/core/migration"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
 
@@ -14,7 +15,13 @@ type patcher interface {
 }
 
 func SetPrecheckResult(p patcher, err error) {
-	p.PatchValue(&runMigrationPrechecks, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence) error {
+	p.PatchValue(&runMigrationPrechecks, func(*state.State, *state.State, *coremigration.TargetInfo, facade.Presence) error {
 		return err
 	})
 }
+
+func SetDryRunResult(p patcher, issues []string, sizes migration.TransferSizes, err error) {
+	p.PatchValue(&runMigrationDryRun, func(*state.State, *state.State, *coremigration.TargetInfo, facade.Presence) ([]string, migration.TransferSizes, error) {
+		return issues, sizes, err
+	})
+}
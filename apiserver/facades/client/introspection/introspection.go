@@ -0,0 +1,165 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package introspection implements the Introspection facade, which
+// relays queries of the introspection endpoints of machine and unit
+// agents in a model, so they can be debugged without shell access to
+// the hosts they run on.
+package introspection
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// responseTimeout is how long to wait for an agent to answer an
+// introspection request.
+const responseTimeout = 30 * time.Second
+
+// staleRequestAge is the age after which an introspection request is
+// considered to have been abandoned, having outlived any API server
+// waiting for it to be answered.
+const staleRequestAge = 2 * responseTimeout
+
+// Backend defines the state methods used by the Introspection facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	ModelTag() names.ModelTag
+	FindEntity(names.Tag) (state.Entity, error)
+	AddIntrospectionRequest(agent names.Tag, path string) (Request, error)
+	RemoveStaleIntrospectionRequests(maxAge time.Duration) error
+}
+
+// Request defines the methods of a state introspection request used
+// by the Introspection facade.
+type Request interface {
+	Refresh() error
+	Completed() bool
+	Response() string
+	Error() string
+	Remove() error
+	Watch() state.NotifyWatcher
+}
+
+// API implements the Introspection facade.
+type API struct {
+	backend Backend
+	clock   clock.Clock
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(backendShim{ctx.State()}, ctx.Auth(), clock.WallClock)
+}
+
+// NewAPI returns a new Introspection API facade. Only model admins and
+// controller superusers may introspect agents.
+func NewAPI(backend Backend, authorizer facade.Authorizer, clock clock.Clock) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isSuperuser, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isSuperuser {
+		isAdmin, err := authorizer.HasPermission(permission.AdminAccess, backend.ModelTag())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !isAdmin {
+			return nil, common.ErrPerm
+		}
+	}
+	return &API{backend: backend, clock: clock}, nil
+}
+
+// Introspect asks each of the specified agents to query the given path
+// on its introspection endpoint, and returns the responses.
+func (api *API) Introspect(args params.IntrospectArgs) params.IntrospectResults {
+	results := params.IntrospectResults{
+		Results: make([]params.IntrospectResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		response, err := api.introspect(arg)
+		results.Results[i].Response = response
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results
+}
+
+func (api *API) introspect(arg params.IntrospectArg) (string, error) {
+	tag, err := names.ParseTag(arg.Tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	switch tag.(type) {
+	case names.MachineTag, names.UnitTag:
+	default:
+		return "", errors.NotValidf("introspecting %q", arg.Tag)
+	}
+	if arg.Path == "" {
+		return "", errors.NotValidf("empty path")
+	}
+	if _, err := api.backend.FindEntity(tag); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Requests are left behind if an API server stops while waiting
+	// for them to be answered, so remove any that have been abandoned.
+	if err := api.backend.RemoveStaleIntrospectionRequests(staleRequestAge); err != nil {
+		logger.Warningf("cannot remove stale introspection requests: %v", err)
+	}
+	req, err := api.backend.AddIntrospectionRequest(tag, arg.Path)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer func() {
+		if err := req.Remove(); err != nil {
+			logger.Warningf("cannot remove introspection request: %v", err)
+		}
+	}()
+
+	if err := api.waitForResponse(tag, req); err != nil {
+		return "", errors.Trace(err)
+	}
+	if msg := req.Error(); msg != "" {
+		return "", errors.New(msg)
+	}
+	return req.Response(), nil
+}
+
+// waitForResponse waits until the agent has answered the request, or
+// gives up after responseTimeout.
+func (api *API) waitForResponse(tag names.Tag, req Request) error {
+	w := req.Watch()
+	defer w.Stop()
+
+	timeout := api.clock.After(responseTimeout)
+	for {
+		select {
+		case _, ok := <-w.Changes():
+			if !ok {
+				return watcher.EnsureErr(w)
+			}
+			if err := req.Refresh(); err != nil {
+				return errors.Trace(err)
+			}
+			if req.Completed() {
+				return nil
+			}
+		case <-timeout:
+			return errors.Timeoutf("waiting for %s to respond", names.ReadableString(tag))
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/introspection"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type introspectionSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	clock      *testclock.Clock
+}
+
+var _ = gc.Suite(&introspectionSuite{})
+
+func (s *introspectionSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		request: &mockRequest{
+			changes: make(chan struct{}, 1),
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.clock = testclock.NewClock(time.Now())
+}
+
+func (s *introspectionSuite) newAPI(c *gc.C) *introspection.API {
+	api, err := introspection.NewAPI(s.backend, s.authorizer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *introspectionSuite) TestNonAdminDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read-bob")
+	_, err := introspection.NewAPI(s.backend, s.authorizer, s.clock)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *introspectionSuite) TestAgentDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := introspection.NewAPI(s.backend, s.authorizer, s.clock)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *introspectionSuite) TestIntrospect(c *gc.C) {
+	s.backend.request.changes <- struct{}{}
+	s.backend.request.completed = true
+	s.backend.request.response = "Dependency Engine Report"
+
+	api := s.newAPI(c)
+	results := api.Introspect(params.IntrospectArgs{
+		Args: []params.IntrospectArg{{Tag: "unit-mysql-0", Path: "/depengine"}},
+	})
+	c.Assert(results, jc.DeepEquals, params.IntrospectResults{
+		Results: []params.IntrospectResult{{Response: "Dependency Engine Report"}},
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"FindEntity", []interface{}{names.NewUnitTag("mysql/0")}},
+		{"RemoveStaleIntrospectionRequests", []interface{}{time.Minute}},
+		{"AddIntrospectionRequest", []interface{}{names.NewUnitTag("mysql/0"), "/depengine"}},
+	})
+	s.backend.request.CheckCallNames(c, "Watch", "Refresh", "Completed", "Error", "Response", "Remove")
+}
+
+func (s *introspectionSuite) TestIntrospectAgentError(c *gc.C) {
+	s.backend.request.changes <- struct{}{}
+	s.backend.request.completed = true
+	s.backend.request.errMsg = "response returned 404 (Not Found)"
+
+	api := s.newAPI(c)
+	results := api.Introspect(params.IntrospectArgs{
+		Args: []params.IntrospectArg{{Tag: "machine-0", Path: "/bogus"}},
+	})
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `response returned 404 \(Not Found\)`)
+}
+
+func (s *introspectionSuite) TestIntrospectInvalidArgs(c *gc.C) {
+	api := s.newAPI(c)
+	results := api.Introspect(params.IntrospectArgs{
+		Args: []params.IntrospectArg{
+			{Tag: "application-mysql", Path: "/depengine"},
+			{Tag: "machine-0"},
+			{Tag: "bogus", Path: "/depengine"},
+		},
+	})
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `introspecting "application-mysql" not valid`)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `empty path not valid`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `"bogus" is not a valid tag`)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *introspectionSuite) TestIntrospectUnknownAgent(c *gc.C) {
+	s.backend.SetErrors(errors.NotFoundf("machine 42"))
+	api := s.newAPI(c)
+	results := api.Introspect(params.IntrospectArgs{
+		Args: []params.IntrospectArg{{Tag: "machine-42", Path: "/depengine"}},
+	})
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+	s.backend.CheckCallNames(c, "FindEntity")
+}
+
+func (s *introspectionSuite) TestIntrospectTimeout(c *gc.C) {
+	api := s.newAPI(c)
+	done := make(chan params.IntrospectResults)
+	go func() {
+		done <- api.Introspect(params.IntrospectArgs{
+			Args: []params.IntrospectArg{{Tag: "machine-0", Path: "/depengine"}},
+		})
+	}()
+	err := s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case results := <-done:
+		c.Assert(results.Results, gc.HasLen, 1)
+		c.Assert(results.Results[0].Error, gc.ErrorMatches, `waiting for machine 0 to respond timeout`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for result")
+	}
+	// The request is always removed.
+	s.backend.request.CheckCallNames(c, "Watch", "Remove")
+}
+
+type mockBackend struct {
+	testing.Stub
+	request *mockRequest
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) FindEntity(tag names.Tag) (state.Entity, error) {
+	b.MethodCall(b, "FindEntity", tag)
+	return nil, b.NextErr()
+}
+
+func (b *mockBackend) AddIntrospectionRequest(agent names.Tag, path string) (introspection.Request, error) {
+	b.MethodCall(b, "AddIntrospectionRequest", agent, path)
+	return b.request, b.NextErr()
+}
+
+func (b *mockBackend) RemoveStaleIntrospectionRequests(maxAge time.Duration) error {
+	b.MethodCall(b, "RemoveStaleIntrospectionRequests", maxAge)
+	return b.NextErr()
+}
+
+type mockRequest struct {
+	testing.Stub
+	changes   chan struct{}
+	completed bool
+	response  string
+	errMsg    string
+}
+
+func (r *mockRequest) Refresh() error {
+	r.MethodCall(r, "Refresh")
+	return r.NextErr()
+}
+
+func (r *mockRequest) Completed() bool {
+	r.MethodCall(r, "Completed")
+	return r.completed
+}
+
+func (r *mockRequest) Response() string {
+	r.MethodCall(r, "Response")
+	return r.response
+}
+
+func (r *mockRequest) Error() string {
+	r.MethodCall(r, "Error")
+	return r.errMsg
+}
+
+func (r *mockRequest) Remove() error {
+	r.MethodCall(r, "Remove")
+	return r.NextErr()
+}
+
+func (r *mockRequest) Watch() state.NotifyWatcher {
+	r.MethodCall(r, "Watch")
+	return statetesting.NewMockNotifyWatcher(r.changes)
+}
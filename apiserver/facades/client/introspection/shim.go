@@ -0,0 +1,22 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.introspection")
+
+type backendShim struct {
+	*state.State
+}
+
+// AddIntrospectionRequest is part of the Backend interface.
+func (b backendShim) AddIntrospectionRequest(agent names.Tag, path string) (Request, error) {
+	return b.State.AddIntrospectionRequest(agent, path)
+}
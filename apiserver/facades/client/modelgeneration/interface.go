@@ -7,6 +7,7 @@ import (
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/settings"
 )
 
@@ -36,7 +37,10 @@ type Generation interface {
 	AssignUnit(string) error
 	AssignedUnits() map[string][]string
 	Commit(string) (int, error)
+	Abort(string) error
 	Config() map[string]settings.ItemChanges
+	CharmURLs() map[string]string
+	Constraints() map[string]constraints.Value
 }
 
 // Application describes application state used by the model generation API.
@@ -7,6 +7,7 @@ package mocks
 import (
 	gomock "github.com/golang/mock/gomock"
 	modelgeneration "github.com/juju/juju/apiserver/facades/client/modelgeneration"
+	constraints "github.com/juju/juju/core/constraints"
 	settings "github.com/juju/juju/core/settings"
 	charm_v6 "gopkg.in/juju/charm.v6"
 	names_v2 "gopkg.in/juju/names.v2"
@@ -170,6 +171,18 @@ func (m *MockGeneration) EXPECT() *MockGenerationMockRecorder {
 	return m.recorder
 }
 
+// Abort mocks base method
+func (m *MockGeneration) Abort(arg0 string) error {
+	ret := m.ctrl.Call(m, "Abort", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Abort indicates an expected call of Abort
+func (mr *MockGenerationMockRecorder) Abort(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Abort", reflect.TypeOf((*MockGeneration)(nil).Abort), arg0)
+}
+
 // AssignAllUnits mocks base method
 func (m *MockGeneration) AssignAllUnits(arg0 string) error {
 	ret := m.ctrl.Call(m, "AssignAllUnits", arg0)
@@ -218,6 +231,18 @@ func (mr *MockGenerationMockRecorder) BranchName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchName", reflect.TypeOf((*MockGeneration)(nil).BranchName))
 }
 
+// CharmURLs mocks base method
+func (m *MockGeneration) CharmURLs() map[string]string {
+	ret := m.ctrl.Call(m, "CharmURLs")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// CharmURLs indicates an expected call of CharmURLs
+func (mr *MockGenerationMockRecorder) CharmURLs() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CharmURLs", reflect.TypeOf((*MockGeneration)(nil).CharmURLs))
+}
+
 // Commit mocks base method
 func (m *MockGeneration) Commit(arg0 string) (int, error) {
 	ret := m.ctrl.Call(m, "Commit", arg0)
@@ -243,6 +268,18 @@ func (mr *MockGenerationMockRecorder) Config() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Config", reflect.TypeOf((*MockGeneration)(nil).Config))
 }
 
+// Constraints mocks base method
+func (m *MockGeneration) Constraints() map[string]constraints.Value {
+	ret := m.ctrl.Call(m, "Constraints")
+	ret0, _ := ret[0].(map[string]constraints.Value)
+	return ret0
+}
+
+// Constraints indicates an expected call of Constraints
+func (mr *MockGenerationMockRecorder) Constraints() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Constraints", reflect.TypeOf((*MockGeneration)(nil).Constraints))
+}
+
 // Created mocks base method
 func (m *MockGeneration) Created() int64 {
 	ret := m.ctrl.Call(m, "Created")
@@ -161,6 +161,27 @@ func (api *API) CommitBranch(arg params.BranchArg) (params.IntResult, error) {
 	return result, nil
 }
 
+// AbortBranch aborts the input branch, discarding all changes made under it
+// and returning the units that were tracking it to the master generation.
+func (api *API) AbortBranch(arg params.BranchArg) (params.ErrorResult, error) {
+	result := params.ErrorResult{}
+
+	isModelAdmin, err := api.hasAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isModelAdmin && !api.isControllerAdmin {
+		return result, common.ErrPerm
+	}
+
+	branch, err := api.model.Branch(arg.BranchName)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Error = common.ServerError(branch.Abort(api.apiUser.Name()))
+	return result, nil
+}
+
 // BranchInfo will return details of branch identified by the input argument,
 // including units on the branch and the configuration disjoint with the
 // master generation.
@@ -205,6 +226,8 @@ func (api *API) BranchInfo(args params.BranchInfoArgs) (params.GenerationResults
 
 func (api *API) oneBranchInfo(branch Generation, detailed bool) (params.Generation, error) {
 	delta := branch.Config()
+	charmURLs := branch.CharmURLs()
+	cons := branch.Constraints()
 
 	var apps []params.GenerationApplication
 	for appName, tracking := range branch.AssignedUnits() {
@@ -229,7 +252,10 @@ func (api *API) oneBranchInfo(branch Generation, detailed bool) (params.Generati
 		}
 		branchApp.ConfigChanges = delta[appName].CurrentSettings(defaults)
 
-		// TODO (manadart 2019-04-12): Charm URL.
+		branchApp.CharmURL = charmURLs[appName]
+		if appCons, ok := cons[appName]; ok {
+			branchApp.Constraints = &appCons
+		}
 
 		// TODO (manadart 2019-04-12): Resources.
 
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelgeneration"
 	"github.com/juju/juju/apiserver/facades/client/modelgeneration/mocks"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 )
@@ -127,6 +128,30 @@ func (s *modelGenerationSuite) TestCommitGeneration(c *gc.C) {
 	c.Assert(result, gc.DeepEquals, params.IntResult{Result: 3, Error: nil})
 }
 
+func (s *modelGenerationSuite) TestAbortBranch(c *gc.C) {
+	defer s.setupModelGenerationAPI(c, func(ctrl *gomock.Controller, _ *mocks.MockState, mod *mocks.MockModel) {
+		gen := mocks.NewMockGeneration(ctrl)
+		gen.EXPECT().Abort(s.apiUser).Return(nil)
+		mod.EXPECT().Branch(s.newBranchName).Return(gen, nil)
+	}).Finish()
+
+	result, err := s.api.AbortBranch(s.newBranchArg())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResult{Error: nil})
+}
+
+func (s *modelGenerationSuite) TestAbortBranchError(c *gc.C) {
+	defer s.setupModelGenerationAPI(c, func(ctrl *gomock.Controller, _ *mocks.MockState, mod *mocks.MockModel) {
+		gen := mocks.NewMockGeneration(ctrl)
+		gen.EXPECT().Abort(s.apiUser).Return(errors.New("branch was already committed"))
+		mod.EXPECT().Branch(s.newBranchName).Return(gen, nil)
+	}).Finish()
+
+	result, err := s.api.AbortBranch(s.newBranchArg())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "branch was already committed")
+}
+
 func (s *modelGenerationSuite) TestHasActiveBranchTrue(c *gc.C) {
 	defer s.setupModelGenerationAPI(c, func(_ *gomock.Controller, _ *mocks.MockState, mockModel *mocks.MockModel) {
 		mockModel.EXPECT().Branch(s.newBranchName).Return(nil, nil)
@@ -168,6 +193,8 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 			settings.MakeDeletion("databases", 100),
 			settings.MakeModification("ignored-key", "unchanged", "unchanged"),
 		}})
+		gExp.CharmURLs().Return(map[string]string{"redis": "cs:redis-2"})
+		gExp.Constraints().Return(map[string]constraints.Value{"redis": constraints.MustParse("mem=4G")})
 		gExp.BranchName().Return(s.newBranchName)
 		gExp.AssignedUnits().Return(map[string][]string{"redis": units[:2]})
 		gExp.Created().Return(int64(666))
@@ -205,6 +232,8 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 	c.Assert(gen.CreatedBy, gc.Equals, s.apiUser)
 	c.Assert(gen.Applications, gc.HasLen, 1)
 
+	mem := uint64(4096)
+
 	app := gen.Applications[0]
 	c.Check(app.ApplicationName, gc.Equals, "redis")
 	c.Check(app.UnitProgress, gc.Equals, "2/3")
@@ -212,6 +241,8 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 		"password":  "added-pass",
 		"databases": 16,
 	})
+	c.Check(app.CharmURL, gc.Equals, "cs:redis-2")
+	c.Check(app.Constraints, gc.DeepEquals, &constraints.Value{Mem: &mem})
 
 	// Unit lists are only populated when detailed is true.
 	if detailed {
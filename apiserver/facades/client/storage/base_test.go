@@ -75,7 +75,9 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	s.apiv3 = &storage.StorageAPIv3{
 		StorageAPIv4: storage.StorageAPIv4{
 			StorageAPIv5: storage.StorageAPIv5{
-				StorageAPI: *newAPI,
+				StorageAPIv6: storage.StorageAPIv6{
+					StorageAPI: *newAPI,
+				},
 			},
 		},
 	}
@@ -112,6 +114,8 @@ const (
 	destroyStorageInstanceCall              = "destroyStorageInstance"
 	releaseStorageInstanceCall              = "releaseStorageInstance"
 	addExistingFilesystemCall               = "addExistingFilesystem"
+	setVolumeInfoCall                       = "setVolumeInfo"
+	setFilesystemInfoCall                   = "setFilesystemInfo"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -320,6 +324,14 @@ func (s *baseStorageSuite) constructStorageAccessor() *mockStorageAccessor {
 			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName)
 			return s.storageTag, s.stub.NextErr()
 		},
+		setVolumeInfo: func(tag names.VolumeTag, info state.VolumeInfo) error {
+			s.stub.AddCall(setVolumeInfoCall, tag, info)
+			return s.stub.NextErr()
+		},
+		setFilesystemInfo: func(tag names.FilesystemTag, info state.FilesystemInfo) error {
+			s.stub.AddCall(setFilesystemInfoCall, tag, info)
+			return s.stub.NextErr()
+		},
 	}
 }
 
@@ -71,6 +71,8 @@ type mockStorageAccessor struct {
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag, bool) error
 	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	setVolumeInfo                       func(names.VolumeTag, state.VolumeInfo) error
+	setFilesystemInfo                   func(names.FilesystemTag, state.FilesystemInfo) error
 }
 
 func (st *mockStorageAccessor) VolumeAccess() storage.StorageVolume {
@@ -138,6 +140,14 @@ func (st *mockStorageAccessor) Volume(tag names.VolumeTag) (state.Volume, error)
 	return st.volume(tag)
 }
 
+func (st *mockStorageAccessor) SetVolumeInfo(tag names.VolumeTag, info state.VolumeInfo) error {
+	return st.setVolumeInfo(tag, info)
+}
+
+func (st *mockStorageAccessor) SetFilesystemInfo(tag names.FilesystemTag, info state.FilesystemInfo) error {
+	return st.setFilesystemInfo(tag, info)
+}
+
 func (st *mockStorageAccessor) AllFilesystems() ([]state.Filesystem, error) {
 	return st.allFilesystems()
 }
@@ -80,6 +80,9 @@ type storageVolume interface {
 	// Volume is required for volume functionality.
 	Volume(tag names.VolumeTag) (state.Volume, error)
 
+	// SetVolumeInfo is required for storage resize functionality.
+	SetVolumeInfo(names.VolumeTag, state.VolumeInfo) error
+
 	// AddExistingFilesystem imports an existing filesystem into the model.
 	AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error)
 }
@@ -99,6 +102,9 @@ type storageFile interface {
 	// Filesystem is required for filesystem functionality.
 	Filesystem(tag names.FilesystemTag) (state.Filesystem, error)
 
+	// SetFilesystemInfo is required for storage resize functionality.
+	SetFilesystemInfo(names.FilesystemTag, state.FilesystemInfo) error
+
 	// AddExistingFilesystem imports an existing filesystem into the model.
 	AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error)
 }
@@ -6,6 +6,7 @@
 package storage
 
 import (
+	"sort"
 	"time"
 
 	"github.com/juju/collections/set"
@@ -29,7 +30,7 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 )
 
-// StorageAPI implements the latest version (v6) of the Storage API.
+// StorageAPI implements the latest version (v7) of the Storage API.
 type StorageAPI struct {
 	backend       backend
 	storageAccess storageAccess
@@ -40,9 +41,14 @@ type StorageAPI struct {
 	modelType     state.ModelType
 }
 
+// StorageAPIv6 implements the storage v6 API.
+type StorageAPIv6 struct {
+	StorageAPI
+}
+
 // APIv5 implements the storage v5 API.
 type StorageAPIv5 struct {
-	StorageAPI
+	StorageAPIv6
 }
 
 // APIv4 implements the storage v4 API adding AddToUnit, Import and Remove (replacing Destroy)
@@ -103,14 +109,25 @@ func newStorageAPI(
 	}
 }
 
+// NewStorageAPIV6 returns a new storage v6 API facade.
+func NewStorageAPIV6(context facade.Context) (*StorageAPIv6, error) {
+	storageAPI, err := NewStorageAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageAPIv6{
+		StorageAPI: *storageAPI,
+	}, nil
+}
+
 // NewStorageAPIV5 returns a new storage v5 API facade.
 func NewStorageAPIV5(context facade.Context) (*StorageAPIv5, error) {
-	storageAPI, err := NewStorageAPI(context)
+	storageAPI, err := NewStorageAPIV6(context)
 	if err != nil {
 		return nil, err
 	}
 	return &StorageAPIv5{
-		StorageAPI: *storageAPI,
+		StorageAPIv6: *storageAPI,
 	}, nil
 }
 
@@ -887,9 +904,15 @@ func (a *StorageAPI) addToUnit(args params.StoragesAddParams) (params.AddStorage
 			continue
 		}
 
-		storageTags, err := a.storageAccess.AddStorageForUnit(
-			u, one.StorageName, paramsToState(one.Constraints),
-		)
+		if one.SnapshotId != "" {
+			if err := a.checkModelSnapshot(one.SnapshotId); err != nil {
+				result[i].Error = common.ServerError(err)
+				continue
+			}
+		}
+		cons := paramsToState(one.Constraints)
+		cons.SnapshotId = one.SnapshotId
+		storageTags, err := a.storageAccess.AddStorageForUnit(u, one.StorageName, cons)
 		if err != nil {
 			result[i].Error = common.ServerError(err)
 		}
@@ -1174,6 +1197,458 @@ func (a *StorageAPI) importFilesystem(
 	}, nil
 }
 
+// CreateSnapshots takes snapshots of the specified volumes.
+func (a *StorageAPI) CreateSnapshots(args params.Entities) (params.VolumeSnapshotResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.VolumeSnapshotResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.VolumeSnapshotResults{}, errors.Trace(err)
+	}
+	resourceTags := map[string]string{
+		tags.JujuModel:      a.backend.ModelTag().Id(),
+		tags.JujuController: a.backend.ControllerTag().Id(),
+	}
+	results := make([]params.VolumeSnapshotResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		snapshot, err := a.createSnapshot(arg.Tag, resourceTags)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = snapshot
+	}
+	return params.VolumeSnapshotResults{Results: results}, nil
+}
+
+func (a *StorageAPI) createSnapshot(tagString string, resourceTags map[string]string) (*params.VolumeSnapshot, error) {
+	volumeTag, err := names.ParseVolumeTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotter, volumeId, err := a.volumeSnapshotter(volumeTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results, err := snapshotter.CreateVolumeSnapshots(a.callContext, []storage.VolumeSnapshotParams{{
+		Volume:       volumeTag,
+		VolumeId:     volumeId,
+		ResourceTags: resourceTags,
+	}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return nil, errors.Trace(results[0].Error)
+	}
+	return volumeSnapshotFromStorage(volumeTag, *results[0].Snapshot), nil
+}
+
+// ListSnapshots returns the snapshots of the specified volumes.
+func (a *StorageAPI) ListSnapshots(args params.Entities) (params.VolumeSnapshotsResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.VolumeSnapshotsResults{}, errors.Trace(err)
+	}
+	results := make([]params.VolumeSnapshotsResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		snapshots, err := a.listSnapshots(arg.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = snapshots
+	}
+	return params.VolumeSnapshotsResults{Results: results}, nil
+}
+
+func (a *StorageAPI) listSnapshots(tagString string) ([]params.VolumeSnapshot, error) {
+	volumeTag, err := names.ParseVolumeTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotter, volumeId, err := a.volumeSnapshotter(volumeTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results, err := snapshotter.ListVolumeSnapshots(a.callContext, []string{volumeId})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return nil, errors.Trace(results[0].Error)
+	}
+	snapshots := make([]params.VolumeSnapshot, len(results[0].Snapshots))
+	for i, snapshot := range results[0].Snapshots {
+		snapshots[i] = *volumeSnapshotFromStorage(volumeTag, snapshot)
+	}
+	return snapshots, nil
+}
+
+// DeleteSnapshots deletes the specified volume snapshots.
+func (a *StorageAPI) DeleteSnapshots(args params.DeleteVolumeSnapshotArgs) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Snapshots))
+	for i, arg := range args.Snapshots {
+		if err := a.deleteSnapshot(arg); err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *StorageAPI) deleteSnapshot(arg params.DeleteVolumeSnapshotArg) error {
+	volumeTag, err := names.ParseVolumeTag(arg.VolumeTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	snapshotter, volumeId, err := a.volumeSnapshotter(volumeTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Only snapshots of the named volume may be deleted, so that
+	// snapshots belonging to other models, or to no model at all,
+	// are left alone.
+	found, err := hasVolumeSnapshot(a.callContext, snapshotter, []string{volumeId}, arg.SnapshotId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !found {
+		return errors.NotFoundf("snapshot %q of %s", arg.SnapshotId, names.ReadableString(volumeTag))
+	}
+	results, err := snapshotter.DeleteVolumeSnapshots(a.callContext, []string{arg.SnapshotId})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	return errors.Trace(results[0])
+}
+
+// checkModelSnapshot returns a not-found error if the snapshot with the
+// specified provider ID was not taken of one of the model's volumes.
+func (a *StorageAPI) checkModelSnapshot(snapshotId string) error {
+	volumes, err := a.storageAccess.VolumeAccess().AllVolumes()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volumeIds := make(map[string][]string)
+	for _, volume := range volumes {
+		info, err := volume.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		volumeIds[info.Pool] = append(volumeIds[info.Pool], info.VolumeId)
+	}
+	pools := make([]string, 0, len(volumeIds))
+	for pool := range volumeIds {
+		pools = append(pools, pool)
+	}
+	sort.Strings(pools)
+	for _, pool := range pools {
+		snapshotter, err := a.volumeSnapshotterForPool(pool)
+		if errors.IsNotSupported(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		found, err := hasVolumeSnapshot(a.callContext, snapshotter, volumeIds[pool], snapshotId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if found {
+			return nil
+		}
+	}
+	return errors.NotFoundf("snapshot %q of a volume in the model", snapshotId)
+}
+
+// hasVolumeSnapshot reports whether the snapshot with the specified
+// provider ID was taken of one of the volumes with the specified
+// provider IDs.
+func hasVolumeSnapshot(
+	ctx context.ProviderCallContext, snapshotter storage.VolumeSnapshotter, volumeIds []string, snapshotId string,
+) (bool, error) {
+	results, err := snapshotter.ListVolumeSnapshots(ctx, volumeIds)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, result := range results {
+		// A volume that can't be listed, e.g. because it has been
+		// removed from the cloud, has no snapshots to match.
+		if result.Error != nil {
+			continue
+		}
+		for _, snapshot := range result.Snapshots {
+			if snapshot.SnapshotId == snapshotId {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// volumeSnapshotter returns the storage.VolumeSnapshotter for the
+// pool of the specified volume, along with the volume's provider ID.
+func (a *StorageAPI) volumeSnapshotter(tag names.VolumeTag) (storage.VolumeSnapshotter, string, error) {
+	volume, err := a.storageAccess.VolumeAccess().Volume(tag)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	snapshotter, err := a.volumeSnapshotterForPool(info.Pool)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return snapshotter, info.VolumeId, nil
+}
+
+func (a *StorageAPI) volumeSnapshotterForPool(pool string) (storage.VolumeSnapshotter, error) {
+	volumeSource, err := a.environVolumeSourceForPool(pool, "snapshots")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotter, ok := volumeSource.(storage.VolumeSnapshotter)
+	if !ok {
+		return nil, errors.NotSupportedf("snapshots with storage provider %q", volumeSource.providerType)
+	}
+	return snapshotter, nil
+}
+
+// providerVolumeSource is a storage.VolumeSource along with the type
+// of the storage provider that it was obtained from.
+type providerVolumeSource struct {
+	storage.VolumeSource
+	providerType storage.ProviderType
+}
+
+// environVolumeSourceForPool returns the volume source for the named
+// pool, which must be backed by an environ-scoped storage provider.
+// The feature is used to describe the operation in NotSupported errors.
+func (a *StorageAPI) environVolumeSourceForPool(pool, feature string) (*providerVolumeSource, error) {
+	provider, cfg, err := a.environProviderForPool(pool, feature)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeSource, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &providerVolumeSource{volumeSource, cfg.Provider()}, nil
+}
+
+// environProviderForPool returns the storage provider and config for
+// the named pool, or a NotSupported error if the provider is not
+// environ-scoped. The feature is used to describe the operation in
+// the error.
+func (a *StorageAPI) environProviderForPool(pool, feature string) (storage.Provider, *storage.Config, error) {
+	providerType, cfg, err := storagecommon.StoragePoolConfig(pool, a.poolManager, a.registry)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	// Machine-scoped storage can only be managed from the machine
+	// itself, so the controller cannot operate on it.
+	if provider.Scope() != storage.ScopeEnviron {
+		return nil, nil, errors.NotSupportedf("%s with storage provider %q", feature, providerType)
+	}
+	return provider, cfg, nil
+}
+
+func volumeSnapshotFromStorage(tag names.VolumeTag, snapshot storage.VolumeSnapshot) *params.VolumeSnapshot {
+	return &params.VolumeSnapshot{
+		SnapshotId: snapshot.SnapshotId,
+		VolumeTag:  tag.String(),
+		Size:       snapshot.Size,
+		Created:    snapshot.Created,
+		Status:     snapshot.Status,
+	}
+}
+
+// ResizeStorage grows the specified storage instances. The units
+// that the storage is attached to are notified once the storage has
+// been resized, so that their charms can grow their filesystems.
+func (a *StorageAPI) ResizeStorage(args params.ResizeStorageArgs) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		if err := a.resizeStorage(arg); err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *StorageAPI) resizeStorage(arg params.ResizeStorageArg) error {
+	storageTag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	storageInstance, err := a.storageAccess.StorageInstance(storageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch storageInstance.Kind() {
+	case state.StorageKindBlock:
+		volume, err := a.storageAccess.VolumeAccess().StorageInstanceVolume(storageTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		_, err = a.resizeVolume(volume, arg.Size)
+		return errors.Trace(err)
+	case state.StorageKindFilesystem:
+		filesystem, err := a.storageAccess.FilesystemAccess().StorageInstanceFilesystem(storageTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(a.resizeFilesystem(filesystem, arg.Size))
+	}
+	return errors.NotSupportedf("resizing %s storage", storageInstance.Kind())
+}
+
+// resizeVolume grows the volume to at least the specified size, and
+// records the volume's new size, which is returned.
+func (a *StorageAPI) resizeVolume(volume state.Volume, size uint64) (uint64, error) {
+	info, err := volume.Info()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if err := checkResize(info.Size, size); err != nil {
+		return 0, errors.Trace(err)
+	}
+	volumeSource, err := a.environVolumeSourceForPool(info.Pool, "resizing")
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	resizer, ok := volumeSource.VolumeSource.(storage.VolumeResizer)
+	if !ok {
+		return 0, errors.NotSupportedf("resizing with storage provider %q", volumeSource.providerType)
+	}
+	results, err := resizer.ResizeVolumes(a.callContext, []storage.VolumeResizeParams{{
+		Tag:      volume.VolumeTag(),
+		VolumeId: info.VolumeId,
+		Size:     size,
+	}})
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(results) != 1 {
+		return 0, errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return 0, errors.Trace(results[0].Error)
+	}
+	info.Size = results[0].Size
+	if info.Size < size {
+		info.Size = size
+	}
+	if err := a.storageAccess.VolumeAccess().SetVolumeInfo(volume.VolumeTag(), info); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return info.Size, nil
+}
+
+// resizeFilesystem grows the filesystem to at least the specified size,
+// and records the filesystem's new size. Volume-backed filesystems are
+// resized by growing the backing volume; it is then up to the charm to
+// grow the filesystem on the volume.
+func (a *StorageAPI) resizeFilesystem(filesystem state.Filesystem, size uint64) error {
+	info, err := filesystem.Info()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkResize(info.Size, size); err != nil {
+		return errors.Trace(err)
+	}
+	if volumeTag, err := filesystem.Volume(); err == nil {
+		volume, err := a.storageAccess.VolumeAccess().Volume(volumeTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if info.Size, err = a.resizeVolume(volume, size); err != nil {
+			return errors.Trace(err)
+		}
+	} else if errors.Cause(err) != state.ErrNoBackingVolume {
+		return errors.Trace(err)
+	} else {
+		newSize, err := a.resizeNonVolumeFilesystem(filesystem.FilesystemTag(), info, size)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		info.Size = newSize
+	}
+	return errors.Trace(a.storageAccess.FilesystemAccess().SetFilesystemInfo(filesystem.FilesystemTag(), info))
+}
+
+func (a *StorageAPI) resizeNonVolumeFilesystem(tag names.FilesystemTag, info state.FilesystemInfo, size uint64) (uint64, error) {
+	provider, cfg, err := a.environProviderForPool(info.Pool, "resizing")
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	filesystemSource, err := provider.FilesystemSource(cfg)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	resizer, ok := filesystemSource.(storage.FilesystemResizer)
+	if !ok {
+		return 0, errors.NotSupportedf("resizing with storage provider %q", cfg.Provider())
+	}
+	results, err := resizer.ResizeFilesystems(a.callContext, []storage.FilesystemResizeParams{{
+		Tag:          tag,
+		FilesystemId: info.FilesystemId,
+		Size:         size,
+	}})
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(results) != 1 {
+		return 0, errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return 0, errors.Trace(results[0].Error)
+	}
+	if results[0].Size < size {
+		return size, nil
+	}
+	return results[0].Size, nil
+}
+
+// checkResize returns an error if the new size is not larger than
+// the current size; storage may only be grown.
+func checkResize(current, size uint64) error {
+	if size <= current {
+		return errors.Errorf("new size %dMiB must be larger than current size %dMiB", size, current)
+	}
+	return nil
+}
+
 // RemovePool deletes the named pool
 func (a *StorageAPI) RemovePool(p params.StoragePoolDeleteArgs) (params.ErrorResults, error) {
 	results := params.ErrorResults{
@@ -1212,6 +1687,12 @@ func (a *StorageAPI) UpdatePool(p params.StoragePoolArgs) (params.ErrorResults,
 // code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
 // so this removes the method as far as the RPC machinery is concerned.
 
+// Added in v7 api version
+func (*StorageAPIv6) CreateSnapshots(_, _ struct{}) {}
+func (*StorageAPIv6) ListSnapshots(_, _ struct{})   {}
+func (*StorageAPIv6) DeleteSnapshots(_, _ struct{}) {}
+func (*StorageAPIv6) ResizeStorage(_, _ struct{})   {}
+
 // Added in v6 api version
 func (*StorageAPIv5) DetachStorage(_, _ struct{}) {}
 
@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -877,6 +878,328 @@ func (s *storageSuite) TestListStorageAsNonAdminOnNotOwnedModel(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
 }
 
+func (s *baseStorageSuite) setupVolumeSnapshotter(scope storage.Scope) *dummy.VolumeSource {
+	s.volume.info = &state.VolumeInfo{Pool: "radiance", VolumeId: "vol-22"}
+	volumeSource := &dummy.VolumeSource{}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: scope,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return volumeSource, nil
+		},
+	}
+	return volumeSource
+}
+
+func (s *storageSuite) TestCreateSnapshots(c *gc.C) {
+	s.state.modelTag = coretesting.ModelTag
+	created := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	volumeSource := s.setupVolumeSnapshotter(storage.ScopeEnviron)
+	volumeSource.CreateVolumeSnapshotsFunc = func(
+		_ context.ProviderCallContext, args []storage.VolumeSnapshotParams,
+	) ([]storage.CreateVolumeSnapshotsResult, error) {
+		return []storage.CreateVolumeSnapshotsResult{{
+			Snapshot: &storage.VolumeSnapshot{
+				SnapshotId: "snap-0",
+				VolumeId:   args[0].VolumeId,
+				Size:       1024,
+				Created:    created,
+				Status:     "pending",
+			},
+		}}, nil
+	}
+
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.volumeTag.String()},
+		{Tag: "volume-42"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotResult{{
+		Result: &params.VolumeSnapshot{
+			SnapshotId: "snap-0",
+			VolumeTag:  s.volumeTag.String(),
+			Size:       1024,
+			Created:    created,
+			Status:     "pending",
+		},
+	}, {
+		Error: &params.Error{Code: params.CodeNotFound, Message: "volume 42 not found"},
+	}})
+	volumeSource.CheckCalls(c, []testing.StubCall{
+		{"CreateVolumeSnapshots", []interface{}{
+			s.callContext,
+			[]storage.VolumeSnapshotParams{{
+				Volume:   s.volumeTag,
+				VolumeId: "vol-22",
+				ResourceTags: map[string]string{
+					"juju-model-uuid":      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+					"juju-controller-uuid": "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+				},
+			}},
+		}},
+	})
+}
+
+func (s *storageSuite) TestCreateSnapshotsMachineScoped(c *gc.C) {
+	volumeSource := s.setupVolumeSnapshotter(storage.ScopeMachine)
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.volumeTag.String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotResult{{
+		Error: &params.Error{
+			Code:    params.CodeNotSupported,
+			Message: `snapshots with storage provider "radiance" not supported`,
+		},
+	}})
+	volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageSuite) TestCreateSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateSnapshotsBlocked")
+	_, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.volumeTag.String()},
+	}})
+	s.assertBlocked(c, err, "TestCreateSnapshotsBlocked")
+}
+
+func (s *storageSuite) TestListSnapshots(c *gc.C) {
+	volumeSource := s.setupVolumeSnapshotter(storage.ScopeEnviron)
+	volumeSource.ListVolumeSnapshotsFunc = func(
+		_ context.ProviderCallContext, volumeIds []string,
+	) ([]storage.ListVolumeSnapshotsResult, error) {
+		c.Assert(volumeIds, jc.DeepEquals, []string{"vol-22"})
+		return []storage.ListVolumeSnapshotsResult{{
+			Snapshots: []storage.VolumeSnapshot{
+				{SnapshotId: "snap-0", VolumeId: "vol-22", Size: 1024},
+				{SnapshotId: "snap-1", VolumeId: "vol-22", Size: 1024},
+			},
+		}}, nil
+	}
+
+	results, err := s.api.ListSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.volumeTag.String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotsResult{{
+		Result: []params.VolumeSnapshot{
+			{SnapshotId: "snap-0", VolumeTag: s.volumeTag.String(), Size: 1024},
+			{SnapshotId: "snap-1", VolumeTag: s.volumeTag.String(), Size: 1024},
+		},
+	}})
+}
+
+func (s *storageSuite) TestListSnapshotsNotProvisioned(c *gc.C) {
+	s.setupVolumeSnapshotter(storage.ScopeEnviron)
+	s.volume.info = nil
+	results, err := s.api.ListSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.volumeTag.String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotProvisioned)
+}
+
+func (s *storageSuite) TestDeleteSnapshots(c *gc.C) {
+	volumeSource := s.setupVolumeSnapshotter(storage.ScopeEnviron)
+	volumeSource.ListVolumeSnapshotsFunc = func(
+		_ context.ProviderCallContext, volumeIds []string,
+	) ([]storage.ListVolumeSnapshotsResult, error) {
+		c.Assert(volumeIds, jc.DeepEquals, []string{"vol-22"})
+		return []storage.ListVolumeSnapshotsResult{{
+			Snapshots: []storage.VolumeSnapshot{
+				{SnapshotId: "snap-0", VolumeId: "vol-22", Size: 1024},
+				{SnapshotId: "snap-1", VolumeId: "vol-22", Size: 1024},
+			},
+		}}, nil
+	}
+	volumeSource.DeleteVolumeSnapshotsFunc = func(
+		_ context.ProviderCallContext, snapshotIds []string,
+	) ([]error, error) {
+		if snapshotIds[0] == "snap-1" {
+			return []error{errors.New("snapshot in use")}, nil
+		}
+		return []error{nil}, nil
+	}
+
+	results, err := s.api.DeleteSnapshots(params.DeleteVolumeSnapshotArgs{[]params.DeleteVolumeSnapshotArg{
+		{VolumeTag: s.volumeTag.String(), SnapshotId: "snap-0"},
+		{VolumeTag: s.volumeTag.String(), SnapshotId: "snap-1"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "snapshot in use"}},
+	})
+	volumeSource.CheckCallNames(c,
+		"ListVolumeSnapshots", "DeleteVolumeSnapshots",
+		"ListVolumeSnapshots", "DeleteVolumeSnapshots",
+	)
+}
+
+func (s *storageSuite) TestDeleteSnapshotsOfOtherVolume(c *gc.C) {
+	volumeSource := s.setupVolumeSnapshotter(storage.ScopeEnviron)
+	volumeSource.ListVolumeSnapshotsFunc = func(
+		_ context.ProviderCallContext, volumeIds []string,
+	) ([]storage.ListVolumeSnapshotsResult, error) {
+		return []storage.ListVolumeSnapshotsResult{{
+			Snapshots: []storage.VolumeSnapshot{
+				{SnapshotId: "snap-0", VolumeId: "vol-22", Size: 1024},
+			},
+		}}, nil
+	}
+
+	results, err := s.api.DeleteSnapshots(params.DeleteVolumeSnapshotArgs{[]params.DeleteVolumeSnapshotArg{
+		{VolumeTag: s.volumeTag.String(), SnapshotId: "snap-other"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{
+		Error: &params.Error{Code: params.CodeNotFound, Message: `snapshot "snap-other" of volume 22 not found`},
+	}})
+	volumeSource.CheckCallNames(c, "ListVolumeSnapshots")
+}
+
+func (s *storageSuite) TestResizeStorageBlock(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	volumeSource := s.setupVolumeSnapshotter(storage.ScopeEnviron)
+	s.volume.info.Size = 1024
+	volumeSource.ResizeVolumesFunc = func(
+		_ context.ProviderCallContext, args []storage.VolumeResizeParams,
+	) ([]storage.ResizeVolumesResult, error) {
+		return []storage.ResizeVolumesResult{{Size: 2048}}, nil
+	}
+
+	results, err := s.api.ResizeStorage(params.ResizeStorageArgs{[]params.ResizeStorageArg{
+		{StorageTag: s.storageTag.String(), Size: 2000},
+		{StorageTag: s.storageTag.String(), Size: 512},
+		{StorageTag: "storage-foo-42", Size: 2048},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "new size 512MiB must be larger than current size 1024MiB"}},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: "storage foo/42 not found"}},
+	})
+	volumeSource.CheckCalls(c, []testing.StubCall{
+		{"ResizeVolumes", []interface{}{
+			s.callContext,
+			[]storage.VolumeResizeParams{{
+				Tag:      s.volumeTag,
+				VolumeId: "vol-22",
+				Size:     2000,
+			}},
+		}},
+	})
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall,
+		storageInstanceCall,
+		storageInstanceVolumeCall,
+		setVolumeInfoCall,
+		storageInstanceCall,
+		storageInstanceVolumeCall,
+		storageInstanceCall,
+	)
+	s.stub.CheckCall(c, 3, setVolumeInfoCall, s.volumeTag, state.VolumeInfo{
+		Pool:     "radiance",
+		VolumeId: "vol-22",
+		Size:     2048,
+	})
+}
+
+func (s *storageSuite) TestResizeStorageVolumeBackedFilesystem(c *gc.C) {
+	volumeSource := s.setupVolumeSnapshotter(storage.ScopeEnviron)
+	s.volume.info.Size = 1024
+	s.filesystem.volume = &s.volumeTag
+	s.filesystem.info = &state.FilesystemInfo{Pool: "radiance", FilesystemId: "fs-104", Size: 1000}
+	volumeSource.ResizeVolumesFunc = func(
+		_ context.ProviderCallContext, args []storage.VolumeResizeParams,
+	) ([]storage.ResizeVolumesResult, error) {
+		return []storage.ResizeVolumesResult{{Size: args[0].Size}}, nil
+	}
+
+	results, err := s.api.ResizeStorage(params.ResizeStorageArgs{[]params.ResizeStorageArg{
+		{StorageTag: s.storageTag.String(), Size: 4096},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	volumeSource.CheckCallNames(c, "ResizeVolumes")
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall,
+		storageInstanceCall,
+		storageInstanceFilesystemCall,
+		volumeCall,
+		setVolumeInfoCall,
+		setFilesystemInfoCall,
+	)
+	s.stub.CheckCall(c, 5, setFilesystemInfoCall, s.filesystemTag, state.FilesystemInfo{
+		Pool:         "radiance",
+		FilesystemId: "fs-104",
+		Size:         4096,
+	})
+}
+
+func (s *storageSuite) TestResizeStorageFilesystem(c *gc.C) {
+	s.filesystem.info = &state.FilesystemInfo{Pool: "radiance", FilesystemId: "fs-104", Size: 1024}
+	filesystemSource := &dummy.FilesystemSource{}
+	filesystemSource.ResizeFilesystemsFunc = func(
+		_ context.ProviderCallContext, args []storage.FilesystemResizeParams,
+	) ([]storage.ResizeFilesystemsResult, error) {
+		return []storage.ResizeFilesystemsResult{{Size: 3072}}, nil
+	}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		FilesystemSourceFunc: func(*storage.Config) (storage.FilesystemSource, error) {
+			return filesystemSource, nil
+		},
+	}
+
+	results, err := s.api.ResizeStorage(params.ResizeStorageArgs{[]params.ResizeStorageArg{
+		{StorageTag: s.storageTag.String(), Size: 3000},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	filesystemSource.CheckCalls(c, []testing.StubCall{
+		{"ResizeFilesystems", []interface{}{
+			s.callContext,
+			[]storage.FilesystemResizeParams{{
+				Tag:          s.filesystemTag,
+				FilesystemId: "fs-104",
+				Size:         3000,
+			}},
+		}},
+	})
+	s.stub.CheckCall(c, 3, setFilesystemInfoCall, s.filesystemTag, state.FilesystemInfo{
+		Pool:         "radiance",
+		FilesystemId: "fs-104",
+		Size:         3072,
+	})
+}
+
+func (s *storageSuite) TestResizeStorageMachineScoped(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	volumeSource := s.setupVolumeSnapshotter(storage.ScopeMachine)
+	results, err := s.api.ResizeStorage(params.ResizeStorageArgs{[]params.ResizeStorageArg{
+		{StorageTag: s.storageTag.String(), Size: 2048},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{
+		Error: &params.Error{
+			Code:    params.CodeNotSupported,
+			Message: `resizing with storage provider "radiance" not supported`,
+		},
+	}})
+	volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageSuite) TestResizeStorageBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeStorageBlocked")
+	_, err := s.api.ResizeStorage(params.ResizeStorageArgs{[]params.ResizeStorageArg{
+		{StorageTag: s.storageTag.String(), Size: 2048},
+	}})
+	s.assertBlocked(c, err, "TestResizeStorageBlocked")
+}
+
 type filesystemImporter struct {
 	*dummy.FilesystemSource
 }
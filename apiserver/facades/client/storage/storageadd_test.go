@@ -12,7 +12,9 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
 )
 
 type storageAddSuite struct {
@@ -50,6 +52,55 @@ func (s *storageAddSuite) TestStorageAddUnit(c *gc.C) {
 	s.assertCalls(c, []string{getBlockForTypeCall, addStorageForUnitCall})
 }
 
+func (s *storageAddSuite) setupSnapshot() {
+	volumeSource := s.setupVolumeSnapshotter(storage.ScopeEnviron)
+	volumeSource.ListVolumeSnapshotsFunc = func(
+		_ context.ProviderCallContext, volumeIds []string,
+	) ([]storage.ListVolumeSnapshotsResult, error) {
+		return []storage.ListVolumeSnapshotsResult{{
+			Snapshots: []storage.VolumeSnapshot{{SnapshotId: "snap-0", VolumeId: "vol-22"}},
+		}}, nil
+	}
+}
+
+func (s *storageAddSuite) TestStorageAddUnitFromSnapshot(c *gc.C) {
+	s.setupSnapshot()
+	var addedCons state.StorageConstraints
+	s.storageAccessor.addStorageForUnit = func(u names.UnitTag, name string, cons state.StorageConstraints) ([]names.StorageTag, error) {
+		addedCons = cons
+		return nil, nil
+	}
+	size := uint64(2048)
+	args := params.StorageAddParams{
+		UnitTag:     s.unitTag.String(),
+		StorageName: "data",
+		Constraints: params.StorageConstraints{Pool: "ebs", Size: &size},
+		SnapshotId:  "snap-0",
+	}
+	s.assertStorageAddedNoErrors(c, args)
+	c.Assert(addedCons, jc.DeepEquals, state.StorageConstraints{
+		Pool:       "ebs",
+		Size:       2048,
+		SnapshotId: "snap-0",
+	})
+}
+
+func (s *storageAddSuite) TestStorageAddUnitFromSnapshotNotInModel(c *gc.C) {
+	s.setupSnapshot()
+	s.storageAccessor.addStorageForUnit = func(u names.UnitTag, name string, cons state.StorageConstraints) ([]names.StorageTag, error) {
+		c.Fatalf("unexpected call to AddStorageForUnit")
+		return nil, nil
+	}
+	failures, err := s.api.AddToUnit(params.StoragesAddParams{[]params.StorageAddParams{{
+		UnitTag:     s.unitTag.String(),
+		StorageName: "data",
+		SnapshotId:  "snap-other",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures.Results, gc.HasLen, 1)
+	c.Assert(failures.Results[0].Error, gc.ErrorMatches, `snapshot "snap-other" of a volume in the model not found`)
+}
+
 func (s *storageAddSuite) TestStorageAddUnitBlocked(c *gc.C) {
 	s.blockAllChanges(c, "TestStorageAddUnitBlocked")
 
@@ -0,0 +1,78 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler implements the API used by the action
+// scheduler worker to enqueue scheduled actions.
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend provides the state methods used by the facade.
+type Backend interface {
+	RunDueActionSchedules() (time.Time, error)
+	WatchActionSchedules() state.NotifyWatcher
+}
+
+// API implements the API used by the action scheduler worker.
+type API struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewStateAPI provides the signature required for facade registration.
+func NewStateAPI(ctx facade.Context) (*API, error) {
+	model, err := ctx.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(model, ctx.Resources(), ctx.Auth())
+}
+
+// NewAPI returns a new action scheduler API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		resources: resources,
+	}, nil
+}
+
+// WatchActionSchedules returns a NotifyWatcher that notifies of changes
+// to the model's action schedules.
+func (api *API) WatchActionSchedules() (params.NotifyWatchResult, error) {
+	watch := api.backend.WatchActionSchedules()
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(watch)),
+	}, nil
+}
+
+// RunDueActionSchedules enqueues the actions of all schedules that are
+// due, and returns when the next schedule will be due.
+func (api *API) RunDueActionSchedules() (params.NextActionScheduleResult, error) {
+	next, err := api.backend.RunDueActionSchedules()
+	if err != nil {
+		return params.NextActionScheduleResult{Error: common.ServerError(err)}, nil
+	}
+	var result params.NextActionScheduleResult
+	if !next.IsZero() {
+		result.NextRun = &next
+	}
+	return result, nil
+}
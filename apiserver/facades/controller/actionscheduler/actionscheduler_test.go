@@ -0,0 +1,133 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionSchedulerSuite struct {
+	coretesting.BaseSuite
+
+	backend    *mockBackend
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	api        *actionscheduler.API
+}
+
+var _ = gc.Suite(&ActionSchedulerSuite{})
+
+func (s *ActionSchedulerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{Stub: &testing.Stub{}}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{Controller: true}
+
+	var err error
+	s.api, err = actionscheduler.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionSchedulerSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	api, err := actionscheduler.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(common.ServerError(err), jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *ActionSchedulerSuite) TestWatchActionSchedules(c *gc.C) {
+	result, err := s.api.WatchActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	s.backend.CheckCallNames(c, "WatchActionSchedules")
+}
+
+func (s *ActionSchedulerSuite) TestWatchActionSchedulesFailure(c *gc.C) {
+	s.backend.watchFails = true
+	s.backend.SetErrors(errors.New("boom"))
+
+	result, err := s.api.WatchActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
+
+func (s *ActionSchedulerSuite) TestRunDueActionSchedules(c *gc.C) {
+	s.backend.next = time.Date(2019, 4, 1, 11, 0, 0, 0, time.UTC)
+
+	result, err := s.api.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NextRun, gc.NotNil)
+	c.Assert(result.NextRun.Equal(s.backend.next), jc.IsTrue)
+	s.backend.CheckCallNames(c, "RunDueActionSchedules")
+}
+
+func (s *ActionSchedulerSuite) TestRunDueActionSchedulesNoSchedules(c *gc.C) {
+	result, err := s.api.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NextRun, gc.IsNil)
+}
+
+func (s *ActionSchedulerSuite) TestRunDueActionSchedulesError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+
+	result, err := s.api.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+	c.Assert(result.NextRun, gc.IsNil)
+}
+
+type mockBackend struct {
+	*testing.Stub
+	next       time.Time
+	watchFails bool
+}
+
+func (b *mockBackend) RunDueActionSchedules() (time.Time, error) {
+	b.MethodCall(b, "RunDueActionSchedules")
+	return b.next, b.NextErr()
+}
+
+func (b *mockBackend) WatchActionSchedules() state.NotifyWatcher {
+	b.MethodCall(b, "WatchActionSchedules")
+	w := &mockWatcher{
+		changes: make(chan struct{}, 1),
+		backend: b,
+	}
+	if b.watchFails {
+		close(w.changes)
+	} else {
+		w.changes <- struct{}{}
+	}
+	return w
+}
+
+type mockWatcher struct {
+	changes chan struct{}
+	backend *mockBackend
+}
+
+func (w *mockWatcher) Changes() <-chan struct{} { return w.changes }
+func (w *mockWatcher) Stop() error              { return nil }
+func (w *mockWatcher) Kill()                    {}
+func (w *mockWatcher) Wait() error              { return nil }
+func (w *mockWatcher) Err() error               { return w.backend.NextErr() }
@@ -0,0 +1,16 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrevisionupdater
+
+import (
+	"github.com/juju/clock"
+)
+
+var AddStoreCharm = &addStoreCharm
+
+// SetClock sets the clock used by the API to determine whether
+// maintenance windows are open.
+func SetClock(api *CharmRevisionUpdaterAPI, clock clock.Clock) {
+	api.clock = clock
+}
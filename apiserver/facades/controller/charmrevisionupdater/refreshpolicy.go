@@ -0,0 +1,146 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrevisionupdater
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/apiserver/params"
+	coreapplication "github.com/juju/juju/core/application"
+	"github.com/juju/juju/state"
+)
+
+// addStoreCharm downloads the charm with the input URL from the charm
+// store into the model. It is a variable so it can be replaced in tests.
+var addStoreCharm = func(st *state.State, curl *charm.URL, channel csparams.Channel) error {
+	return application.AddCharmWithAuthorization(application.NewStateShim(st), params.AddCharmWithAuthorization{
+		URL:     curl.String(),
+		Channel: string(channel),
+	})
+}
+
+// recordLatestCharm records the latest revision of the application's
+// charm in the channel it tracks, if it has a charm refresh policy.
+func recordLatestCharm(app *state.Application, latest *charm.URL) error {
+	if _, err := app.CharmRefreshPolicy(); errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	err := app.SetCharmRefreshLatest(latest, app.Channel())
+	if errors.IsNotFound(err) {
+		// The policy has since been removed.
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// ApplyCharmRefreshPolicies upgrades the charms of the applications
+// whose charm refresh policy's maintenance window is open, to the
+// latest revisions in their channels recorded by UpdateLatestRevisions.
+func (api *CharmRevisionUpdaterAPI) ApplyCharmRefreshPolicies() (params.ErrorResult, error) {
+	if err := api.applyCharmRefreshPolicies(); err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}, nil
+	}
+	return params.ErrorResult{}, nil
+}
+
+func (api *CharmRevisionUpdaterAPI) applyCharmRefreshPolicies() error {
+	applications, err := api.state.AllApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := api.clock.Now()
+	for _, app := range applications {
+		policy, err := app.CharmRefreshPolicy()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		open, err := policy.Policy().InWindow(now)
+		if err != nil {
+			logger.Errorf("cannot apply charm refresh policy for application %q: %v", app.Name(), err)
+			continue
+		}
+		if !open {
+			continue
+		}
+		// A failure to refresh one application must not
+		// prevent the others from being refreshed.
+		if err := api.applyCharmRefreshPolicy(app, policy); err != nil {
+			logger.Errorf("cannot apply charm refresh policy for application %q: %v", app.Name(), err)
+		}
+	}
+	return nil
+}
+
+// applyCharmRefreshPolicy upgrades the application to the charm staged
+// on the policy's canary branch once the canary is healthy. If there
+// is no canary, the latest revision of the application's charm is
+// staged on the canary branch or, without one, upgraded to directly.
+// The application's resources are not refreshed, so upgrades to charms
+// declaring different resources are skipped by the application.
+func (api *CharmRevisionUpdaterAPI) applyCharmRefreshPolicy(app *state.Application, policy *state.CharmRefreshPolicy) error {
+	if canaryURL := policy.CanaryCharmURL(); canaryURL != "" {
+		healthy, err := app.CharmRefreshCanaryHealthy()
+		if err != nil || !healthy {
+			return errors.Trace(err)
+		}
+		curl, err := charm.ParseURL(canaryURL)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ch, err := api.state.Charm(curl)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(app.RefreshCharm(ch))
+	}
+
+	curl, _ := app.CharmURL()
+	if curl.Schema != "cs" {
+		return nil
+	}
+	// Only the latest revision in the channel the application tracks
+	// is used; a revision recorded for another channel, or for a
+	// charm the application no longer uses, is ignored.
+	latest := policy.LatestCharmURL(app.Channel())
+	if latest == nil || latest.WithRevision(-1).String() != curl.WithRevision(-1).String() {
+		return nil
+	}
+	if latest.Revision <= curl.Revision {
+		return nil
+	}
+	if err := addStoreCharm(api.state, latest, app.Channel()); err != nil {
+		return errors.Annotatef(err, "cannot download charm %q", latest)
+	}
+	ch, err := api.state.Charm(latest)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	p := policy.Policy()
+	if p.PatchOnly {
+		current, _, err := app.Charm()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !coreapplication.IsPatchUpgrade(current.Version(), ch.Version()) {
+			logger.Debugf(
+				"not upgrading application %q from charm version %q to %q: not a patch upgrade",
+				app.Name(), current.Version(), ch.Version(),
+			)
+			return nil
+		}
+	}
+	if p.CanaryBranch != "" {
+		return errors.Trace(app.StageCharmRefreshCanary(ch))
+	}
+	return errors.Trace(app.RefreshCharm(ch))
+}
@@ -0,0 +1,108 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrevisionupdater_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+
+	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/state"
+)
+
+func (s *charmVersionSuite) setUpRefreshPolicy(c *gc.C, now time.Time, canaryBranch string) *state.Application {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+
+	s.PatchValue(charmrevisionupdater.AddStoreCharm, func(st *state.State, curl *charm.URL, channel csparams.Channel) error {
+		c.Check(curl.String(), gc.Equals, "cs:quantal/mysql-23")
+		s.AddCharmWithRevision(c, "mysql", curl.Revision)
+		return nil
+	})
+	charmrevisionupdater.SetClock(s.charmrevisionupdater, testclock.NewClock(now))
+
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetCharmRefreshPolicy(&application.RefreshPolicy{
+		Window:       "0 2 * * *",
+		Duration:     2 * time.Hour,
+		CanaryBranch: canaryBranch,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The latest revisions are only recorded for applications
+	// with a refresh policy.
+	result, err := s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	return app
+}
+
+func (s *charmVersionSuite) applyCharmRefreshPolicies(c *gc.C, app *state.Application) {
+	result, err := s.charmrevisionupdater.ApplyCharmRefreshPolicies()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *charmVersionSuite) TestApplyCharmRefreshPolicies(c *gc.C) {
+	app := s.setUpRefreshPolicy(c, time.Date(2019, 4, 1, 3, 0, 0, 0, time.UTC), "")
+	s.applyCharmRefreshPolicies(c, app)
+
+	curl, _ := app.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-23")
+	policy, err := app.CharmRefreshPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy.LastMessage(), gc.Equals, "upgraded to cs:quantal/mysql-23")
+}
+
+func (s *charmVersionSuite) TestApplyCharmRefreshPoliciesOtherChannel(c *gc.C) {
+	app := s.setUpRefreshPolicy(c, time.Date(2019, 4, 1, 3, 0, 0, 0, time.UTC), "")
+	err := app.SetCharmRefreshLatest(charm.MustParseURL("cs:quantal/mysql-23"), csparams.EdgeChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	s.applyCharmRefreshPolicies(c, app)
+
+	// The application doesn't track the edge channel, so the
+	// revision recorded for it is ignored.
+	curl, _ := app.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-22")
+}
+
+func (s *charmVersionSuite) TestApplyCharmRefreshPoliciesOutsideWindow(c *gc.C) {
+	app := s.setUpRefreshPolicy(c, time.Date(2019, 4, 1, 12, 0, 0, 0, time.UTC), "")
+	s.applyCharmRefreshPolicies(c, app)
+
+	curl, _ := app.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-22")
+}
+
+func (s *charmVersionSuite) TestApplyCharmRefreshPoliciesCanary(c *gc.C) {
+	c.Assert(s.State.AddBranch("canary", "test-user"), jc.ErrorIsNil)
+	app := s.setUpRefreshPolicy(c, time.Date(2019, 4, 1, 3, 0, 0, 0, time.UTC), "canary")
+	s.applyCharmRefreshPolicies(c, app)
+
+	// The new charm is only staged on the canary branch.
+	curl, _ := app.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-22")
+	curl, err := app.BranchCharmURL("canary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-23")
+
+	// The canary has no units, so the upgrade waits.
+	s.applyCharmRefreshPolicies(c, app)
+	curl, _ = app.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-22")
+	policy, err := app.CharmRefreshPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy.CanaryCharmURL(), gc.Equals, "cs:quantal/mysql-23")
+}
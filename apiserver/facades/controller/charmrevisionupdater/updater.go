@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
@@ -24,6 +25,7 @@ var logger = loggo.GetLogger("juju.apiserver.charmrevisionupdater")
 // CharmRevisionUpdater defines the methods on the charmrevisionupdater API end point.
 type CharmRevisionUpdater interface {
 	UpdateLatestRevisions() (params.ErrorResult, error)
+	ApplyCharmRefreshPolicies() (params.ErrorResult, error)
 }
 
 // CharmRevisionUpdaterAPI implements the CharmRevisionUpdater interface and is the concrete
@@ -32,6 +34,13 @@ type CharmRevisionUpdaterAPI struct {
 	state      *state.State
 	resources  facade.Resources
 	authorizer facade.Authorizer
+	clock      clock.Clock
+}
+
+// CharmRevisionUpdaterAPIV2 provides the v2 CharmRevisionUpdater API,
+// which does not apply charm refresh policies.
+type CharmRevisionUpdaterAPIV2 struct {
+	*CharmRevisionUpdaterAPI
 }
 
 var _ CharmRevisionUpdater = (*CharmRevisionUpdaterAPI)(nil)
@@ -46,9 +55,26 @@ func NewCharmRevisionUpdaterAPI(
 		return nil, common.ErrPerm
 	}
 	return &CharmRevisionUpdaterAPI{
-		state: st, resources: resources, authorizer: authorizer}, nil
+		state: st, resources: resources, authorizer: authorizer, clock: clock.WallClock}, nil
 }
 
+// NewCharmRevisionUpdaterAPIV2 creates a new server-side v2
+// charmrevisionupdater API end point.
+func NewCharmRevisionUpdaterAPIV2(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*CharmRevisionUpdaterAPIV2, error) {
+	api, err := NewCharmRevisionUpdaterAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &CharmRevisionUpdaterAPIV2{api}, nil
+}
+
+// ApplyCharmRefreshPolicies isn't on the v2 API.
+func (*CharmRevisionUpdaterAPIV2) ApplyCharmRefreshPolicies(_, _ struct{}) {}
+
 // UpdateLatestRevisions retrieves the latest revision information from the charm store for all deployed charms
 // and records this information in state.
 func (api *CharmRevisionUpdaterAPI) UpdateLatestRevisions() (params.ErrorResult, error) {
@@ -78,6 +104,9 @@ func (api *CharmRevisionUpdaterAPI) updateLatestRevisions() error {
 		if err = api.state.AddStoreCharmPlaceholder(info.LatestURL()); err != nil {
 			return err
 		}
+		if err := recordLatestCharm(info.application, info.LatestURL()); err != nil {
+			return err
+		}
 
 		// Then run through the handlers.
 		tag := info.application.ApplicationTag()
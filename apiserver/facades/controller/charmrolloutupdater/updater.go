@@ -0,0 +1,45 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmrolloutupdater implements the API used by the charm rollout
+// worker to hand upgraded charms to units a batch at a time.
+package charmrolloutupdater
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Backend provides the state methods used by the facade.
+type Backend interface {
+	UpdateCharmRollouts() error
+}
+
+// API implements the API used by the charm rollout worker.
+type API struct {
+	backend Backend
+}
+
+// NewStateAPI provides the signature required for facade registration.
+func NewStateAPI(ctx facade.Context) (*API, error) {
+	return NewAPI(ctx.State(), ctx.Auth())
+}
+
+// NewAPI returns a new charm rollout API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// UpdateCharmRollouts advances the charm rollouts in progress in the
+// model, releasing the next batch of units of each application once
+// the units already upgraded are healthy.
+func (api *API) UpdateCharmRollouts() (params.ErrorResult, error) {
+	if err := api.backend.UpdateCharmRollouts(); err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}, nil
+	}
+	return params.ErrorResult{}, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrolloutupdater_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/charmrolloutupdater"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type CharmRolloutSuite struct {
+	coretesting.BaseSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *charmrolloutupdater.API
+}
+
+var _ = gc.Suite(&CharmRolloutSuite{})
+
+func (s *CharmRolloutSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{Stub: &testing.Stub{}}
+	s.authorizer = apiservertesting.FakeAuthorizer{Controller: true}
+
+	var err error
+	s.api, err = charmrolloutupdater.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmRolloutSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	api, err := charmrolloutupdater.NewAPI(s.backend, s.authorizer)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(common.ServerError(err), jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *CharmRolloutSuite) TestUpdateCharmRollouts(c *gc.C) {
+	result, err := s.api.UpdateCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.backend.CheckCallNames(c, "UpdateCharmRollouts")
+}
+
+func (s *CharmRolloutSuite) TestUpdateCharmRolloutsError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+
+	result, err := s.api.UpdateCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	*testing.Stub
+}
+
+func (b *mockBackend) UpdateCharmRollouts() error {
+	b.MethodCall(b, "UpdateCharmRollouts")
+	return b.NextErr()
+}
@@ -223,6 +223,21 @@ func (api *CrossModelRelationsAPI) registerRemoteRelation(relation params.Regist
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+
+	// Before adding anything to the model, check that the offer accepts
+	// a new connection from the consuming model.
+	_, err = api.st.EndpointsRelation(*localEndpoint, remoteEndpoint)
+	if errors.IsNotFound(err) {
+		err = api.st.CheckOfferConnection(state.AddOfferConnectionParams{
+			SourceModelUUID: sourceModelTag.Id(),
+			Username:        username,
+			OfferUUID:       appOffer.OfferUUID,
+		})
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	_, err = api.st.AddRemoteApplication(state.AddRemoteApplicationParams{
 		Name:            uniqueRemoteApplicationName,
 		OfferUUID:       relation.OfferUUID,
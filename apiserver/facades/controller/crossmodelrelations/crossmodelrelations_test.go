@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"regexp"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -272,6 +273,50 @@ func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsIdempotent(c *gc.C
 	s.assertRegisterRemoteRelations(c)
 }
 
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsOfferLimit(c *gc.C) {
+	app := &mockApplication{}
+	app.eps = []state.Endpoint{{
+		ApplicationName: "offeredapp",
+		Relation:        charm.Relation{Name: "local"},
+	}}
+	s.st.applications["offeredapp"] = app
+	s.st.offers = map[string]*crossmodel.ApplicationOffer{
+		"offer-uuid": {
+			OfferUUID:       "offer-uuid",
+			OfferName:       "offered",
+			ApplicationName: "offeredapp",
+		}}
+	s.st.checkOfferConnection = errors.New(`offer "offered" accepts at most 1 relation`)
+	mac, err := s.bakery.NewMacaroon(
+		[]checkers.Caveat{
+			checkers.DeclaredCaveat("source-model-uuid", s.st.ModelUUID()),
+			checkers.DeclaredCaveat("offer-uuid", "offer-uuid"),
+			checkers.DeclaredCaveat("username", "mary"),
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.RegisterRemoteRelations(params.RegisterRemoteRelationArgs{
+		Relations: []params.RegisterRemoteRelationArg{{
+			ApplicationToken:  "app-token",
+			SourceModelTag:    coretesting.ModelTag.String(),
+			RelationToken:     "rel-token",
+			RemoteEndpoint:    params.RemoteEndpoint{Name: "remote"},
+			OfferUUID:         "offer-uuid",
+			LocalEndpointName: "local",
+			Macaroons:         macaroon.Slice{mac},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `offer "offered" accepts at most 1 relation`)
+	s.st.CheckCall(c, 1, "CheckOfferConnection", state.AddOfferConnectionParams{
+		SourceModelUUID: coretesting.ModelTag.Id(),
+		Username:        "mary",
+		OfferUUID:       "offer-uuid",
+	})
+	c.Assert(s.st.remoteApplications, gc.HasLen, 0)
+	c.Assert(s.st.relations, gc.HasLen, 0)
+}
+
 func (s *crossmodelRelationsSuite) TestRelationUnitSettings(c *gc.C) {
 	djangoRelationUnit := newMockRelationUnit()
 	djangoRelationUnit.settings["key"] = "value"
@@ -50,6 +50,7 @@ type mockState struct {
 	remoteEntities        map[names.Tag]string
 	firewallRules         map[state.WellKnownServiceType]*state.FirewallRule
 	ingressNetworks       map[string][]string
+	checkOfferConnection  error
 }
 
 func newMockState() *mockState {
@@ -119,6 +120,11 @@ func (st *mockState) AddOfferConnection(arg state.AddOfferConnectionParams) (cro
 	return oc, nil
 }
 
+func (st *mockState) CheckOfferConnection(arg state.AddOfferConnectionParams) error {
+	st.MethodCall(st, "CheckOfferConnection", arg)
+	return st.checkOfferConnection
+}
+
 func (st *mockState) FirewallRule(service state.WellKnownServiceType) (*state.FirewallRule, error) {
 	if r, ok := st.firewallRules[service]; ok {
 		return r, nil
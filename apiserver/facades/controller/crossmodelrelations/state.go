@@ -23,6 +23,10 @@ type CrossModelRelationsState interface {
 	// relation made from a remote model to an offer in the local model.
 	AddOfferConnection(state.AddOfferConnectionParams) (OfferConnection, error)
 
+	// CheckOfferConnection returns an error if the offer does not accept
+	// the specified connection.
+	CheckOfferConnection(state.AddOfferConnectionParams) error
+
 	// OfferConnectionForRelation returns the offer connection details for the given relation key.
 	OfferConnectionForRelation(string) (OfferConnection, error)
 }
@@ -43,6 +47,10 @@ func (st stateShim) AddOfferConnection(arg state.AddOfferConnectionParams) (Offe
 	return st.st.AddOfferConnection(arg)
 }
 
+func (st stateShim) CheckOfferConnection(arg state.AddOfferConnectionParams) error {
+	return st.st.CheckOfferConnection(arg)
+}
+
 func (st stateShim) OfferConnectionForRelation(relationKey string) (OfferConnection, error) {
 	return st.st.OfferConnectionForRelation(relationKey)
 }
@@ -51,6 +51,11 @@ type FirewallerAPIV5 struct {
 	*FirewallerAPIV4
 }
 
+// FirewallerAPIV6 provides access to the Firewaller v6 API facade.
+type FirewallerAPIV6 struct {
+	*FirewallerAPIV5
+}
+
 // NewStateFirewallerAPIV3 creates a new server-side FirewallerAPIV3 facade.
 func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 	st := context.State()
@@ -92,6 +97,17 @@ func NewStateFirewallerAPIV5(context facade.Context) (*FirewallerAPIV5, error) {
 	}, nil
 }
 
+// NewStateFirewallerAPIV6 creates a new server-side FirewallerAPIV6 facade.
+func NewStateFirewallerAPIV6(context facade.Context) (*FirewallerAPIV6, error) {
+	facadev5, err := NewStateFirewallerAPIV5(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV6{
+		FirewallerAPIV5: facadev5,
+	}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPIV3 facade.
 func NewFirewallerAPI(
 	st State,
@@ -246,6 +262,7 @@ func (f *FirewallerAPIV3) GetMachinePorts(args params.MachinePortsParams) (param
 		}
 		if ports != nil {
 			portRangeMap := ports.AllPortRanges()
+			portRangeEndpoints := ports.PortRangeEndpoints()
 			var portRanges []network.PortRange
 			for portRange := range portRangeMap {
 				portRanges = append(portRanges, portRange)
@@ -258,6 +275,7 @@ func (f *FirewallerAPIV3) GetMachinePorts(args params.MachinePortsParams) (param
 					params.MachinePortRange{
 						UnitTag:   unitTag,
 						PortRange: params.FromNetworkPortRange(portRange),
+						Endpoints: portRangeEndpoints[portRange],
 					})
 			}
 		}
@@ -534,3 +552,101 @@ func (f *FirewallerAPIV5) AreManuallyProvisioned(args params.Entities) (params.B
 	}
 	return result, nil
 }
+
+// ExposeInfo returns whether each given application is exposed and,
+// if so, where each of its endpoints may be accessed from. Spaces are
+// resolved to the CIDRs of their subnets, so the firewaller only ever
+// needs to deal with CIDRs.
+func (f *FirewallerAPIV6) ExposeInfo(args params.Entities) (params.ExposeInfoResults, error) {
+	result := params.ExposeInfoResults{
+		Results: make([]params.ExposeInfoResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.ExposeInfoResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !application.IsExposed() {
+			continue
+		}
+		result.Results[i].Exposed = true
+		exposedEndpoints, err := f.exposedEndpoints(application.ExposedEndpoints())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].ExposedEndpoints = exposedEndpoints
+	}
+	return result, nil
+}
+
+// WatchSubnets returns a new StringsWatcher for each given model tag,
+// notifying of changes to the model's subnets. The CIDRs of the spaces
+// that applications are exposed to may change with them.
+func (f *FirewallerAPIV6) WatchSubnets(args params.Entities) (params.StringsWatchResults, error) {
+	result := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	if len(args.Entities) == 0 {
+		return result, nil
+	}
+	canWatch, err := f.accessModel()
+	if err != nil {
+		return params.StringsWatchResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canWatch(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		// NOTE: tag is ignored, as there is only one model in the
+		// state DB.
+		watch := f.st.WatchSubnets(nil)
+		// Consume the initial event and forward it to the result.
+		changes, ok := <-watch.Changes()
+		if !ok {
+			result.Results[i].Error = common.ServerError(watcher.EnsureErr(watch))
+			continue
+		}
+		result.Results[i].StringsWatcherId = f.resources.Register(watch)
+		result.Results[i].Changes = changes
+	}
+	return result, nil
+}
+
+func (f *FirewallerAPIV6) exposedEndpoints(in map[string]state.ExposedEndpoint) (map[string]params.ExposedEndpoint, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]params.ExposedEndpoint, len(in))
+	for endpoint, exposed := range in {
+		cidrs := append([]string(nil), exposed.ExposeToCIDRs...)
+		for _, space := range exposed.ExposeToSpaces {
+			spaceCIDRs, err := f.st.SpaceCIDRs(space)
+			if err != nil {
+				return nil, errors.Annotatef(err, "resolving space %q for endpoint %q", space, endpoint)
+			}
+			cidrs = append(cidrs, spaceCIDRs...)
+		}
+		out[endpoint] = params.ExposedEndpoint{
+			ExposeToSpaces: exposed.ExposeToSpaces,
+			ExposeToCIDRs:  cidrs,
+		}
+	}
+	return out, nil
+}
@@ -272,3 +272,96 @@ func (s *firewallerSuite) TestAreManuallyProvisioned(c *gc.C) {
 		},
 	})
 }
+
+func (s *firewallerSuite) apiV6() *firewaller.FirewallerAPIV6 {
+	return &firewaller.FirewallerAPIV6{
+		&firewaller.FirewallerAPIV5{
+			&firewaller.FirewallerAPIV4{
+				FirewallerAPIV3:     s.firewaller,
+				ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+			}}}
+}
+
+func (s *firewallerSuite) TestExposeInfoSpaceWithoutSubnets(c *gc.C) {
+	_, err := s.State.AddSpace("dmz", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToSpaces: []string{"dmz"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.apiV6().ExposeInfo(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `resolving space "dmz" for endpoint "url": space "dmz" has no subnets`)
+}
+
+func (s *firewallerSuite) TestWatchSubnets(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.Model.ModelTag().String()},
+		{Tag: s.application.Tag().String()},
+	}}
+	result, err := s.apiV6().WatchSubnets(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{Changes: []string{"10.20.30.0/24"}, StringsWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("192.168.1.0/24")
+	wc.AssertNoChange()
+}
+
+func (s *firewallerSuite) TestExposeInfo(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("dmz", "", []string{"192.168.1.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err = s.application.SetExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToSpaces: []string{"dmz"}, ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.apiV6().ExposeInfo(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+		{Tag: mysql.Tag().String()},
+		{Tag: s.units[0].Tag().String()},
+		{Tag: "application-bar"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ExposeInfoResults{
+		Results: []params.ExposeInfoResult{{
+			Exposed: true,
+			ExposedEndpoints: map[string]params.ExposedEndpoint{
+				"url": {
+					ExposeToSpaces: []string{"dmz"},
+					ExposeToCIDRs:  []string{"10.0.0.0/8", "192.168.1.0/24"},
+				},
+			},
+		}, {
+			Exposed: false,
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}, {
+			Error: apiservertesting.NotFoundError(`application "bar"`),
+		}},
+	})
+}
@@ -120,6 +120,12 @@ func (st *mockState) FirewallRule(service state.WellKnownServiceType) (*state.Fi
 	return r, nil
 }
 
+func (st *mockState) SpaceCIDRs(space string) ([]string, error) {
+	st.MethodCall(st, "SpaceCIDRs", space)
+	// TODO - implement when remaining firewaller tests become unit tests
+	return nil, errors.NotImplementedf("SpaceCIDRs")
+}
+
 type mockWatcher struct {
 	testing.Stub
 	tomb.Tomb
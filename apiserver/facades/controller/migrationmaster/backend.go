@@ -21,6 +21,7 @@ type Backend interface {
 	ModelOwner() (names.UserTag, error)
 	AgentVersion() (version.Number, error)
 	RemoveExportingModelDocs() error
+	ExportMigrationExtras() ([]byte, error)
 
 	migration.StateExporter
 }
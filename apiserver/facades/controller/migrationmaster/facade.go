@@ -209,6 +209,10 @@ func (api *API) Export() (params.SerializedModel, error) {
 	if model.Type() == string(coremodel.IAAS) {
 		serialized.Tools = getUsedTools(model)
 	}
+	serialized.Extras, err = api.backend.ExportMigrationExtras()
+	if err != nil {
+		return serialized, err
+	}
 	return serialized, nil
 }
 
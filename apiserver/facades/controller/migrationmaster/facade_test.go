@@ -273,6 +273,7 @@ func (s *Suite) assertExport(c *gc.C, modelType string) {
 	c.Check(string(serialized.Bytes), jc.Contains, jujuversion.Current.String())
 
 	c.Check(serialized.Charms, gc.DeepEquals, []string{"cs:foo-0"})
+	c.Check(serialized.Extras, gc.DeepEquals, []byte("extras"))
 	if modelType == "caas" {
 		c.Check(serialized.Tools, gc.HasLen, 0)
 	} else {
@@ -483,6 +484,11 @@ func (b *stubBackend) Export() (description.Model, error) {
 	return b.model, nil
 }
 
+func (b *stubBackend) ExportMigrationExtras() ([]byte, error) {
+	b.stub.AddCall("ExportMigrationExtras")
+	return []byte("extras"), nil
+}
+
 type stubMigration struct {
 	state.ModelMigration
 
@@ -54,9 +54,16 @@ type ActionResult struct {
 	Status    string                 `json:"status,omitempty"`
 	Message   string                 `json:"message,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
+	Log       []ActionMessage        `json:"log,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
 }
 
+// ActionMessage is a timestamped progress message logged by an action.
+type ActionMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
 // ActionsByReceivers wrap a slice of Actions for API calls.
 type ActionsByReceivers struct {
 	Actions []ActionsByReceiver `json:"actions,omitempty"`
@@ -116,6 +123,12 @@ type ActionExecutionResult struct {
 	Message   string                 `json:"message,omitempty"`
 }
 
+// ActionMessageParams holds the progress messages to be logged by
+// actions, keyed by action tag.
+type ActionMessageParams struct {
+	Messages []EntityString `json:"messages"`
+}
+
 // ApplicationsCharmActionsResults holds a slice of ApplicationCharmActionsResult for
 // a bulk result of charm Actions for Applications.
 type ApplicationsCharmActionsResults struct {
@@ -143,3 +156,62 @@ type ActionPruneArgs struct {
 	MaxHistoryTime time.Duration `json:"max-history-time"`
 	MaxHistoryMB   int           `json:"max-history-mb"`
 }
+
+// AddActionScheduleArgs holds the arguments for scheduling actions.
+type AddActionScheduleArgs struct {
+	Schedules []AddActionScheduleArg `json:"schedules"`
+}
+
+// AddActionScheduleArg describes an action to be enqueued on a unit
+// according to a cron schedule.
+type AddActionScheduleArg struct {
+	Receiver   string                 `json:"receiver"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Cron       string                 `json:"cron"`
+}
+
+// ActionScheduleIds holds the ids of action schedules.
+type ActionScheduleIds struct {
+	Ids []string `json:"ids"`
+}
+
+// ActionSchedule describes an action that is enqueued on a unit
+// according to a cron schedule.
+type ActionSchedule struct {
+	Id         string                 `json:"id"`
+	Receiver   string                 `json:"receiver"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Cron       string                 `json:"cron"`
+	Created    time.Time              `json:"created"`
+	NextRun    time.Time              `json:"next-run"`
+	History    []ActionScheduleRun    `json:"history,omitempty"`
+}
+
+// ActionScheduleRun records the outcome of one run of an action
+// schedule.
+type ActionScheduleRun struct {
+	Time      time.Time `json:"time"`
+	ActionTag string    `json:"action-tag,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ActionScheduleResult holds an action schedule or an error.
+type ActionScheduleResult struct {
+	Result *ActionSchedule `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// ActionScheduleResults holds the results of bulk action schedule
+// calls.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results"`
+}
+
+// NextActionScheduleResult holds when the next action schedule is due,
+// or an error. NextRun is nil if there are no schedules.
+type NextActionScheduleResult struct {
+	NextRun *time.Time `json:"next-run,omitempty"`
+	Error   *Error     `json:"error,omitempty"`
+}
@@ -144,11 +144,38 @@ type ApplicationSetCharm struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]StorageConstraints `json:"storage-constraints,omitempty"`
+
+	// BatchSize, if positive, rolls the new charm out to the
+	// application's units this many at a time, waiting for each batch
+	// to become healthy before moving on to the next. This field is
+	// only understood by Application facade version 12 and greater.
+	BatchSize int `json:"batch-size,omitempty"`
+
+	// PauseOnError pauses a batched rollout when a unit fails, rather
+	// than stopping it, so that it resumes once the unit recovers.
+	PauseOnError bool `json:"pause-on-error,omitempty"`
+
+	// LeaderLast upgrades the application's leader in the final batch
+	// of a batched rollout.
+	LeaderLast bool `json:"leader-last,omitempty"`
 }
 
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints restricts where each endpoint of the exposed
+	// application may be accessed from, keyed by endpoint name. The
+	// empty name applies to all endpoints. If it is empty, the
+	// application may be accessed from anywhere.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ExposedEndpoint describes where the ports opened for an exposed
+// application endpoint may be accessed from.
+type ExposedEndpoint struct {
+	ExposeToSpaces []string `json:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs  []string `json:"expose-to-cidrs,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -406,15 +433,16 @@ type ScaleApplicationInfo struct {
 
 // ApplicationInfo holds an application info.
 type ApplicationInfo struct {
-	Tag              string            `json:"tag"`
-	Charm            string            `json:"charm,omitempty"`
-	Series           string            `json:"series,omitempty"`
-	Channel          string            `json:"channel,omitempty"`
-	Constraints      constraints.Value `json:"constraints,omitempty"`
-	Principal        bool              `json:"principal"`
-	Exposed          bool              `json:"exposed"`
-	Remote           bool              `json:"remote"`
-	EndpointBindings map[string]string `json:"endpoint-bindings,omitempty"`
+	Tag              string              `json:"tag"`
+	Charm            string              `json:"charm,omitempty"`
+	Series           string              `json:"series,omitempty"`
+	Channel          string              `json:"channel,omitempty"`
+	Constraints      constraints.Value   `json:"constraints,omitempty"`
+	Principal        bool                `json:"principal"`
+	Exposed          bool                `json:"exposed"`
+	Remote           bool                `json:"remote"`
+	EndpointBindings map[string]string   `json:"endpoint-bindings,omitempty"`
+	RefreshPolicy    *CharmRefreshPolicy `json:"refresh-policy,omitempty"`
 }
 
 // ApplicationInfoResults holds an application info result or a retrieval error.
@@ -427,3 +455,56 @@ type ApplicationInfoResult struct {
 type ApplicationInfoResults struct {
 	Results []ApplicationInfoResult `json:"results"`
 }
+
+// CharmRollout describes the progress of a batched charm upgrade.
+type CharmRollout struct {
+	Application   string    `json:"application"`
+	FromCharmURL  string    `json:"from-charm-url"`
+	ToCharmURL    string    `json:"to-charm-url"`
+	BatchSize     int       `json:"batch-size"`
+	PauseOnError  bool      `json:"pause-on-error"`
+	LeaderLast    bool      `json:"leader-last"`
+	ReleasedUnits []string  `json:"released-units"`
+	PendingUnits  []string  `json:"pending-units"`
+	Status        string    `json:"status"`
+	Message       string    `json:"message,omitempty"`
+	Started       time.Time `json:"started"`
+	Updated       time.Time `json:"updated"`
+}
+
+// CharmRolloutResult holds a charm rollout or a retrieval error.
+type CharmRolloutResult struct {
+	Result *CharmRollout `json:"result,omitempty"`
+	Error  *Error        `json:"error,omitempty"`
+}
+
+// CharmRolloutResults holds the charm rollouts of applications.
+type CharmRolloutResults struct {
+	Results []CharmRolloutResult `json:"results"`
+}
+
+// CharmRefreshPolicy describes when an application's charm is
+// automatically upgraded to newer revisions in its channel, along with
+// the most recent action taken under the policy.
+type CharmRefreshPolicy struct {
+	Window         string        `json:"window"`
+	Duration       time.Duration `json:"duration"`
+	PatchOnly      bool          `json:"patch-only,omitempty"`
+	CanaryBranch   string        `json:"canary-branch,omitempty"`
+	CanaryCharmURL string        `json:"canary-charm-url,omitempty"`
+	LastRefreshed  *time.Time    `json:"last-refreshed,omitempty"`
+	LastMessage    string        `json:"last-message,omitempty"`
+}
+
+// SetCharmRefreshPolicyArg sets the charm refresh policy of an
+// application. A nil policy removes any existing policy.
+type SetCharmRefreshPolicyArg struct {
+	ApplicationTag string              `json:"application-tag"`
+	Policy         *CharmRefreshPolicy `json:"policy,omitempty"`
+}
+
+// SetCharmRefreshPolicyArgs holds the arguments for setting the charm
+// refresh policies of applications.
+type SetCharmRefreshPolicyArgs struct {
+	Args []SetCharmRefreshPolicyArg `json:"args"`
+}
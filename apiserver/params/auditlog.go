@@ -0,0 +1,67 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AuditLogQueryArgs holds the filter for a query of the audit log.
+// Empty fields don't restrict the results.
+type AuditLogQueryArgs struct {
+	// Who matches the user that started a conversation.
+	Who string `json:"who,omitempty"`
+
+	// Model matches the name ("user/name") or UUID of the model a
+	// conversation was with.
+	Model string `json:"model,omitempty"`
+
+	// Facade and Method match conversations containing at least
+	// one request to the facade and/or method.
+	Facade string `json:"facade,omitempty"`
+	Method string `json:"method,omitempty"`
+
+	// After and Before bound the time a conversation started.
+	After  *time.Time `json:"after,omitempty"`
+	Before *time.Time `json:"before,omitempty"`
+
+	// Limit is the maximum number of conversations returned.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditLogQueryResult holds the conversations matching an audit log
+// query, most recent first.
+type AuditLogQueryResult struct {
+	Conversations []AuditConversation `json:"conversations"`
+}
+
+// AuditConversation holds a single conversation from the audit log,
+// along with the requests made as part of it.
+type AuditConversation struct {
+	ConversationID string         `json:"conversation-id"`
+	ConnectionID   string         `json:"connection-id"`
+	Who            string         `json:"who"`
+	What           string         `json:"what"`
+	When           string         `json:"when"`
+	ModelName      string         `json:"model-name"`
+	ModelOwner     string         `json:"model-owner,omitempty"`
+	ModelUUID      string         `json:"model-uuid"`
+	Requests       []AuditRequest `json:"requests,omitempty"`
+}
+
+// AuditRequest holds an API request recorded in the audit log, and
+// any errors that were returned in response to it.
+type AuditRequest struct {
+	RequestID uint64       `json:"request-id"`
+	When      string       `json:"when"`
+	Facade    string       `json:"facade"`
+	Method    string       `json:"method"`
+	Version   int          `json:"version"`
+	Args      string       `json:"args,omitempty"`
+	Errors    []AuditError `json:"errors,omitempty"`
+}
+
+// AuditError holds an error returned in response to an API request.
+type AuditError struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
@@ -4,6 +4,8 @@
 package params
 
 import (
+	"time"
+
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/macaroon.v2-unstable"
 )
@@ -91,6 +93,12 @@ type ApplicationOfferAdminDetails struct {
 	ApplicationName string            `json:"application-name"`
 	CharmURL        string            `json:"charm-url"`
 	Connections     []OfferConnection `json:"connections,omitempty"`
+
+	// RequireApproval, MaxConsumers and MaxRelations were added in
+	// ApplicationOffers v3.
+	RequireApproval bool `json:"require-approval,omitempty"`
+	MaxConsumers    int  `json:"max-consumers,omitempty"`
+	MaxRelations    int  `json:"max-relations,omitempty"`
 }
 
 // OfferConnection holds details about a connection to an offer.
@@ -121,6 +129,46 @@ type AddApplicationOffer struct {
 	ApplicationName        string            `json:"application-name"`
 	ApplicationDescription string            `json:"application-description"`
 	Endpoints              map[string]string `json:"endpoints"`
+
+	// RequireApproval, MaxConsumers and MaxRelations were added in
+	// ApplicationOffers v3.
+	RequireApproval bool `json:"require-approval,omitempty"`
+	MaxConsumers    int  `json:"max-consumers,omitempty"`
+	MaxRelations    int  `json:"max-relations,omitempty"`
+}
+
+// OfferConnectionRequest holds details about a user's request to consume
+// an offer which requires approval.
+type OfferConnectionRequest struct {
+	Username  string    `json:"username"`
+	Status    string    `json:"status"`
+	Requested time.Time `json:"requested"`
+	Updated   time.Time `json:"updated"`
+}
+
+// OfferConnectionRequestsResult holds the requests to consume an offer.
+type OfferConnectionRequestsResult struct {
+	Requests []OfferConnectionRequest `json:"requests,omitempty"`
+	Error    *Error                   `json:"error,omitempty"`
+}
+
+// OfferConnectionRequestsResults holds the requests to consume offers.
+type OfferConnectionRequestsResults struct {
+	Results []OfferConnectionRequestsResult `json:"results"`
+}
+
+// ChangeOfferConnectionRequest approves or rejects a user's request
+// to consume an offer.
+type ChangeOfferConnectionRequest struct {
+	OfferURL string `json:"offer-url"`
+	Username string `json:"username"`
+	Approve  bool   `json:"approve"`
+}
+
+// ChangeOfferConnectionRequests holds the changes to make to requests
+// to consume offers.
+type ChangeOfferConnectionRequests struct {
+	Changes []ChangeOfferConnectionRequest `json:"changes"`
 }
 
 // DestroyApplicationOffers holds parameters for the DestroyOffers call.
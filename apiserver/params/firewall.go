@@ -60,3 +60,19 @@ func (v KnownServiceValue) Validate() error {
 	}
 	return errors.NotValidf("known service %q", v)
 }
+
+// ExposeInfoResults holds the expose settings for a number of
+// applications.
+type ExposeInfoResults struct {
+	Results []ExposeInfoResult `json:"results"`
+}
+
+// ExposeInfoResult holds whether an application is exposed and, if so,
+// where each of its endpoints may be accessed from. Any spaces the
+// application was exposed to have already been resolved to the CIDRs
+// of their subnets.
+type ExposeInfoResult struct {
+	Error            *Error                     `json:"error,omitempty"`
+	Exposed          bool                       `json:"exposed,omitempty"`
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
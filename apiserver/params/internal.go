@@ -380,6 +380,51 @@ type EntityWorkloadVersions struct {
 	Entities []EntityWorkloadVersion `json:"entities"`
 }
 
+// UnitStateResult holds the private key/value state stored
+// by a unit's charm.
+type UnitStateResult struct {
+	State map[string]string `json:"state,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// UnitStateResults holds the results of a call to retrieve
+// the state of a set of units.
+type UnitStateResults struct {
+	Results []UnitStateResult `json:"results"`
+}
+
+// SetUnitStateArg holds the private key/value state to store
+// for a unit, replacing any existing state.
+type SetUnitStateArg struct {
+	Tag   string            `json:"tag"`
+	State map[string]string `json:"state"`
+}
+
+// SetUnitStateArgs holds the parameters for setting the state
+// of a set of units.
+type SetUnitStateArgs struct {
+	Args []SetUnitStateArg `json:"args"`
+}
+
+// CommitHookChangesArg holds the changes made to the model by one
+// of a unit's charm hooks, which are committed together.
+type CommitHookChangesArg struct {
+	Tag              string                 `json:"tag"`
+	RelationSettings []RelationUnitSettings `json:"relation-settings,omitempty"`
+	OpenPorts        []EntityPortRange      `json:"open-ports,omitempty"`
+	ClosePorts       []EntityPortRange      `json:"close-ports,omitempty"`
+	AddStorage       []StorageAddParams     `json:"add-storage,omitempty"`
+
+	// State, if not nil, replaces the unit's charm state.
+	State *map[string]string `json:"state,omitempty"`
+}
+
+// CommitHookChangesArgs holds the parameters for committing the
+// changes made by the hooks of a set of units.
+type CommitHookChangesArgs struct {
+	Args []CommitHookChangesArg `json:"args"`
+}
+
 // BytesResult holds the result of an API call that returns a slice
 // of bytes.
 type BytesResult struct {
@@ -672,6 +717,11 @@ type RunParams struct {
 	Machines     []string      `json:"machines,omitempty"`
 	Applications []string      `json:"applications,omitempty"`
 	Units        []string      `json:"units,omitempty"`
+
+	// StreamOutput requests that the units log each line of the
+	// commands' output as an action message as it is written. This
+	// field is only understood by Action facade version 6 and greater.
+	StreamOutput bool `json:"stream-output,omitempty"`
 }
 
 // RunResult contains the result from an individual run call on a machine.
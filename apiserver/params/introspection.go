@@ -0,0 +1,76 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// IntrospectArgs holds the introspection queries to relay to agents.
+type IntrospectArgs struct {
+	Args []IntrospectArg `json:"args"`
+}
+
+// IntrospectArg holds a single introspection query for an agent.
+type IntrospectArg struct {
+	// Tag is the tag of the machine or unit agent to query.
+	Tag string `json:"tag"`
+
+	// Path is the introspection path to query, e.g. "/depengine".
+	Path string `json:"path"`
+}
+
+// IntrospectResults holds the results of relaying introspection
+// queries to agents.
+type IntrospectResults struct {
+	Results []IntrospectResult `json:"results"`
+}
+
+// IntrospectResult holds the agent's response to a single
+// introspection query.
+type IntrospectResult struct {
+	Response string `json:"response,omitempty"`
+	Error    *Error `json:"error,omitempty"`
+}
+
+// IntrospectionRequestIds holds the ids of introspection requests.
+type IntrospectionRequestIds struct {
+	Ids []string `json:"ids"`
+}
+
+// IntrospectionRequest holds the details of a request for an agent to
+// query its introspection endpoint.
+type IntrospectionRequest struct {
+	Id   string `json:"id"`
+	Path string `json:"path"`
+
+	// Completed is true if the request has already been answered.
+	Completed bool `json:"completed"`
+}
+
+// IntrospectionRequestResults holds introspection requests.
+type IntrospectionRequestResults struct {
+	Results []IntrospectionRequestResult `json:"results"`
+}
+
+// IntrospectionRequestResult holds an introspection request or an
+// error retrieving it.
+type IntrospectionRequestResult struct {
+	Result *IntrospectionRequest `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// IntrospectionResponses holds agents' responses to introspection
+// requests.
+type IntrospectionResponses struct {
+	Responses []IntrospectionResponse `json:"responses"`
+}
+
+// IntrospectionResponse holds an agent's response to a single
+// introspection request.
+type IntrospectionResponse struct {
+	Id string `json:"id"`
+
+	// Response holds the body returned by the introspection endpoint.
+	Response string `json:"response,omitempty"`
+
+	// Error holds the reason the request could not be answered.
+	Error string `json:"error,omitempty"`
+}
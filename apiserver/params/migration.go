@@ -53,6 +53,27 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// MigrationDryRunResults is used to return the results of one or more
+// migration dry runs.
+type MigrationDryRunResults struct {
+	Results []MigrationDryRunResult `json:"results"`
+}
+
+// MigrationDryRunResult holds the outcome of checking whether a single
+// model could be migrated, without starting the migration. Issues lists
+// the problems which would prevent the migration from succeeding, and
+// the sizes estimate the data which would be transferred to the target
+// controller.
+type MigrationDryRunResult struct {
+	ModelTag             string   `json:"model-tag"`
+	Issues               []string `json:"issues,omitempty"`
+	ModelDescriptionSize int64    `json:"model-description-size"`
+	CharmsSize           int64    `json:"charms-size"`
+	ToolsSize            int64    `json:"tools-size"`
+	ResourcesSize        int64    `json:"resources-size"`
+	Error                *Error   `json:"error,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -72,6 +93,10 @@ type SerializedModel struct {
 	Charms    []string                  `json:"charms"`
 	Tools     []SerializedModelTools    `json:"tools"`
 	Resources []SerializedModelResource `json:"resources"`
+
+	// Extras holds serialized model data that the model description
+	// format cannot yet represent.
+	Extras []byte `json:"extras,omitempty"`
 }
 
 // SerializedModelTools holds the version and URI for a given tools
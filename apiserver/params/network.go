@@ -264,12 +264,15 @@ type EntitiesPorts struct {
 	Entities []EntityPort `json:"entities"`
 }
 
-// EntityPortRange holds an entity's tag, a protocol and a port range.
+// EntityPortRange holds an entity's tag, a protocol and a port range,
+// along with the endpoint the range applies to, which is empty for all
+// of the entity's endpoints.
 type EntityPortRange struct {
 	Tag      string `json:"tag"`
 	Protocol string `json:"protocol"`
 	FromPort int    `json:"from-port"`
 	ToPort   int    `json:"to-port"`
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 // EntitiesPortRanges holds the parameters for making an OpenPorts or
@@ -440,11 +443,14 @@ type MachineAddressesResults struct {
 }
 
 // MachinePortRange holds a single port range open on a machine for
-// the given unit and relation tags.
+// the given unit and relation tags. Endpoints holds the names of the
+// unit's endpoints the range was opened for; it is empty if the range
+// was opened for all of them.
 type MachinePortRange struct {
 	UnitTag     string    `json:"unit-tag"`
 	RelationTag string    `json:"relation-tag"`
 	PortRange   PortRange `json:"port-range"`
+	Endpoints   []string  `json:"endpoints,omitempty"`
 }
 
 // MachinePorts holds a machine and subnet tags. It's used when referring to
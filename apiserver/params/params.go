@@ -491,6 +491,10 @@ type GetConstraintsResults struct {
 type SetConstraints struct {
 	ApplicationName string            `json:"application"` //optional, if empty, model constraints are set.
 	Constraints     constraints.Value `json:"constraints"`
+
+	// Generation is the branch under which application
+	// constraints are set. It is ignored for model constraints.
+	Generation string `json:"generation,omitempty"`
 }
 
 // ResolveCharms stores charm references for a ResolveCharms call.
@@ -1172,6 +1176,14 @@ type GenerationApplication struct {
 	// Config changes are the effective new configuration values resulting from
 	// changes made under this branch.
 	ConfigChanges map[string]interface{} `json:"config"`
+
+	// CharmURL is the charm URL staged for the application under this
+	// branch. It is empty if no charm upgrade has been staged.
+	CharmURL string `json:"charm-url,omitempty"`
+
+	// Constraints are the application constraints set under this branch,
+	// if any.
+	Constraints *constraints.Value `json:"constraints,omitempty"`
 }
 
 // Generation represents a model generation's details including config changes.
@@ -149,6 +149,10 @@ type ApplicationStatus struct {
 	CharmProfile     string                 `json:"charm-profile"`
 	EndpointBindings map[string]string      `json:"endpoint-bindings"`
 
+	// ExposedEndpoints holds the spaces and CIDRs the endpoints of
+	// an exposed application are exposed to, keyed by endpoint name.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+
 	// The following are for CAAS models.
 	Scale         int    `json:"int,omitempty"`
 	ProviderId    string `json:"provider-id,omitempty"`
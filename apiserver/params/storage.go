@@ -98,6 +98,9 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     Life        `json:"life"`
+
+	// Size is the size of the attached volume or filesystem in MiB.
+	Size uint64 `json:"size,omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -258,6 +261,7 @@ type VolumeParams struct {
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+	SnapshotId string                  `json:"snapshot-id,omitempty"`
 }
 
 // RemoveVolumeParams holds the parameters for destroying or releasing a
@@ -854,6 +858,10 @@ type StorageAddParams struct {
 
 	// Constraints are specified storage constraints.
 	Constraints StorageConstraints `json:"storage"`
+
+	// SnapshotId, if non-empty, is the provider ID of the volume
+	// snapshot from which the storage should be restored.
+	SnapshotId string `json:"snapshot-id,omitempty"`
 }
 
 // StoragesAddParams holds storage details to add to units dynamically.
@@ -950,3 +958,73 @@ type AddStorageDetails struct {
 	// of the added storage instances.
 	StorageTags []string `json:"storage-tags"`
 }
+
+// VolumeSnapshot describes a point-in-time snapshot of a volume.
+type VolumeSnapshot struct {
+	// SnapshotId is the storage provider's unique ID for the snapshot.
+	SnapshotId string `json:"snapshot-id"`
+
+	// VolumeTag is the tag of the volume that the snapshot was taken of.
+	VolumeTag string `json:"volume-tag"`
+
+	// Size is the size of the snapshotted volume, in MiB.
+	Size uint64 `json:"size"`
+
+	// Created is the time at which the snapshot was taken.
+	Created time.Time `json:"created"`
+
+	// Status is the provider-specific status of the snapshot.
+	Status string `json:"status,omitempty"`
+}
+
+// VolumeSnapshotResult contains the result of taking a volume snapshot.
+type VolumeSnapshotResult struct {
+	Result *VolumeSnapshot `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// VolumeSnapshotResults contains the results of taking volume snapshots.
+type VolumeSnapshotResults struct {
+	Results []VolumeSnapshotResult `json:"results"`
+}
+
+// VolumeSnapshotsResult contains the snapshots of a single volume.
+type VolumeSnapshotsResult struct {
+	Result []VolumeSnapshot `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// VolumeSnapshotsResults contains the snapshots of a collection of volumes.
+type VolumeSnapshotsResults struct {
+	Results []VolumeSnapshotsResult `json:"results"`
+}
+
+// DeleteVolumeSnapshotArg identifies a volume snapshot to delete.
+type DeleteVolumeSnapshotArg struct {
+	// VolumeTag is the tag of the volume that the snapshot was taken of,
+	// which determines the storage provider that manages the snapshot.
+	VolumeTag string `json:"volume-tag"`
+
+	// SnapshotId is the storage provider's unique ID for the snapshot.
+	SnapshotId string `json:"snapshot-id"`
+}
+
+// DeleteVolumeSnapshotArgs holds the arguments for deleting volume snapshots.
+type DeleteVolumeSnapshotArgs struct {
+	Snapshots []DeleteVolumeSnapshotArg `json:"snapshots"`
+}
+
+// ResizeStorageArg identifies a storage instance to resize.
+type ResizeStorageArg struct {
+	// StorageTag is the tag of the storage instance to resize.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the new size of the storage instance, in MiB. It must
+	// be larger than the current size.
+	Size uint64 `json:"size"`
+}
+
+// ResizeStorageArgs holds the arguments for resizing storage instances.
+type ResizeStorageArgs struct {
+	Storage []ResizeStorageArg `json:"storage"`
+}
@@ -0,0 +1,189 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	k8sannotations "github.com/juju/juju/core/annotations"
+	"github.com/juju/juju/core/application"
+)
+
+const (
+	kindDeployment  = "Deployment"
+	kindStatefulSet = "StatefulSet"
+)
+
+// autoscalingSpec returns the autoscaling policy for an application.
+// The policy declared by the charm in its pod spec is used as a base,
+// with any values set by the operator in the application config taking
+// precedence. A nil result means the application is not autoscaled.
+func autoscalingSpec(podSpec *caas.PodSpec, config application.ConfigAttributes) (*K8sAutoscalingSpec, error) {
+	var result K8sAutoscalingSpec
+	declared := false
+	if k8sSpec, ok := podSpec.ProviderPod.(*K8sPodSpec); ok && k8sSpec.Autoscaling != nil {
+		result = *k8sSpec.Autoscaling
+		declared = true
+	}
+
+	configInt32 := func(key string) (int32, bool) {
+		if _, ok := config[key]; !ok {
+			return 0, false
+		}
+		return int32(config.GetInt(key, 0)), true
+	}
+	if v, ok := configInt32(autoscalingMinReplicasKey); ok {
+		result.MinReplicas = v
+		declared = true
+	}
+	if v, ok := configInt32(autoscalingMaxReplicasKey); ok {
+		result.MaxReplicas = v
+		declared = true
+	}
+	if v, ok := configInt32(autoscalingTargetCPUKey); ok {
+		result.TargetCPUUtilizationPercentage = &v
+		declared = true
+	}
+	if v, ok := configInt32(autoscalingTargetMemoryKey); ok {
+		result.TargetMemoryUtilizationPercentage = &v
+		declared = true
+	}
+	if !declared {
+		return nil, nil
+	}
+	if err := result.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
+
+// AutoscalingEnabled reports whether an application with the input pod
+// spec and application config is scaled by a horizontal pod autoscaler.
+// The pod spec is empty if the charm has not yet set one.
+func AutoscalingEnabled(podSpec string, config application.ConfigAttributes) (bool, error) {
+	spec := &caas.PodSpec{}
+	if podSpec != "" {
+		var err error
+		if spec, err = parseK8sPodSpec(podSpec); err != nil {
+			return false, errors.Trace(err)
+		}
+	}
+	policy, err := autoscalingSpec(spec, config)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return policy != nil, nil
+}
+
+// minReplicas returns the lower bound on the number of pods,
+// defaulting to 1 as per k8s.
+func (a *K8sAutoscalingSpec) minReplicas() int32 {
+	if a.MinReplicas < 1 {
+		return 1
+	}
+	return a.MinReplicas
+}
+
+// autoscaledReplicas returns the number of pods an autoscaled workload
+// should run. Once the workload exists the autoscaler owns its replica
+// count, so the current value is kept rather than resetting it to Juju's
+// view of the scale, which may lag behind. Either way the result is kept
+// within the policy's bounds.
+func autoscaledReplicas(policy *K8sAutoscalingSpec, current *int32, numUnits int) int32 {
+	replicas := int32(numUnits)
+	if current != nil && *current > 0 {
+		replicas = *current
+	}
+	if min := policy.minReplicas(); replicas < min {
+		replicas = min
+	}
+	if replicas > policy.MaxReplicas {
+		replicas = policy.MaxReplicas
+	}
+	return replicas
+}
+
+// currentDeploymentReplicas returns the replica count of the named
+// deployment, or nil if it doesn't exist.
+func (k *kubernetesClient) currentDeploymentReplicas(name string) (*int32, error) {
+	deployment, err := k.client().AppsV1().Deployments(k.namespace).Get(name, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return deployment.Spec.Replicas, nil
+}
+
+// configureHorizontalPodAutoscaler creates or updates the autoscaler
+// which scales the application's deployment or stateful set.
+func (k *kubernetesClient) configureHorizontalPodAutoscaler(
+	appName, deploymentName, kind string,
+	annotations k8sannotations.Annotation,
+	policy *K8sAutoscalingSpec,
+) error {
+	logger.Debugf("creating/updating horizontal pod autoscaler for %s", appName)
+
+	minReplicas := policy.minReplicas()
+	var metrics []autoscalingv2beta1.MetricSpec
+	addMetric := func(resource core.ResourceName, target *int32) {
+		if target == nil {
+			return
+		}
+		metrics = append(metrics, autoscalingv2beta1.MetricSpec{
+			Type: autoscalingv2beta1.ResourceMetricSourceType,
+			Resource: &autoscalingv2beta1.ResourceMetricSource{
+				Name:                     resource,
+				TargetAverageUtilization: target,
+			},
+		})
+	}
+	addMetric(core.ResourceCPU, policy.TargetCPUUtilizationPercentage)
+	addMetric(core.ResourceMemory, policy.TargetMemoryUtilizationPercentage)
+
+	return k.ensureHorizontalPodAutoscaler(&autoscalingv2beta1.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        deploymentName,
+			Labels:      map[string]string{labelApplication: appName},
+			Annotations: annotations.ToMap(),
+		},
+		Spec: autoscalingv2beta1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       kind,
+				Name:       deploymentName,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: policy.MaxReplicas,
+			Metrics:     metrics,
+		},
+	})
+}
+
+// ensureHorizontalPodAutoscaler ensures a HorizontalPodAutoscaler resource.
+func (k *kubernetesClient) ensureHorizontalPodAutoscaler(spec *autoscalingv2beta1.HorizontalPodAutoscaler) error {
+	autoscalers := k.client().AutoscalingV2beta1().HorizontalPodAutoscalers(k.namespace)
+	_, err := autoscalers.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = autoscalers.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+// deleteHorizontalPodAutoscaler deletes a HorizontalPodAutoscaler resource.
+func (k *kubernetesClient) deleteHorizontalPodAutoscaler(name string) error {
+	err := k.client().AutoscalingV2beta1().HorizontalPodAutoscalers(k.namespace).Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
@@ -12,6 +12,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -58,6 +59,12 @@ type BaseSuite struct {
 	mockIngressInterface       *mocks.MockIngressInterface
 	mockNodes                  *mocks.MockNodeInterface
 	mockEvents                 *mocks.MockEventInterface
+	mockServiceAccounts        *mocks.MockServiceAccountInterface
+	mockRbacV1                 *mocks.MockRbacV1Interface
+	mockRoles                  *mocks.MockRoleInterface
+	mockRoleBindings           *mocks.MockRoleBindingInterface
+	mockAutoscaling            *mocks.MockAutoscalingV2beta1Interface
+	mockAutoscalers            *mocks.MockHorizontalPodAutoscalerInterface
 
 	mockApiextensionsV1          *mocks.MockApiextensionsV1beta1Interface
 	mockApiextensionsClient      *mocks.MockApiExtensionsClientInterface
@@ -173,6 +180,16 @@ func (s *BaseSuite) setupK8sRestClient(c *gc.C, ctrl *gomock.Controller, namespa
 	s.mockEvents = mocks.NewMockEventInterface(ctrl)
 	mockCoreV1.EXPECT().Events(namespace).AnyTimes().Return(s.mockEvents)
 
+	s.mockServiceAccounts = mocks.NewMockServiceAccountInterface(ctrl)
+	mockCoreV1.EXPECT().ServiceAccounts(namespace).AnyTimes().Return(s.mockServiceAccounts)
+
+	s.mockRbacV1 = mocks.NewMockRbacV1Interface(ctrl)
+	s.k8sClient.EXPECT().RbacV1().AnyTimes().Return(s.mockRbacV1)
+	s.mockRoles = mocks.NewMockRoleInterface(ctrl)
+	s.mockRbacV1.EXPECT().Roles(namespace).AnyTimes().Return(s.mockRoles)
+	s.mockRoleBindings = mocks.NewMockRoleBindingInterface(ctrl)
+	s.mockRbacV1.EXPECT().RoleBindings(namespace).AnyTimes().Return(s.mockRoleBindings)
+
 	s.mockApps = mocks.NewMockAppsV1Interface(ctrl)
 	s.mockExtensions = mocks.NewMockExtensionsV1beta1Interface(ctrl)
 	s.mockStatefulSets = mocks.NewMockStatefulSetInterface(ctrl)
@@ -189,6 +206,11 @@ func (s *BaseSuite) setupK8sRestClient(c *gc.C, ctrl *gomock.Controller, namespa
 	s.k8sClient.EXPECT().StorageV1().AnyTimes().Return(s.mockStorage)
 	s.mockStorage.EXPECT().StorageClasses().AnyTimes().Return(s.mockStorageClass)
 
+	s.mockAutoscaling = mocks.NewMockAutoscalingV2beta1Interface(ctrl)
+	s.mockAutoscalers = mocks.NewMockHorizontalPodAutoscalerInterface(ctrl)
+	s.k8sClient.EXPECT().AutoscalingV2beta1().AnyTimes().Return(s.mockAutoscaling)
+	s.mockAutoscaling.EXPECT().HorizontalPodAutoscalers(namespace).AnyTimes().Return(s.mockAutoscalers)
+
 	s.mockApiextensionsClient = mocks.NewMockApiExtensionsClientInterface(ctrl)
 	s.mockApiextensionsV1 = mocks.NewMockApiextensionsV1beta1Interface(ctrl)
 	s.mockCustomResourceDefinition = mocks.NewMockCustomResourceDefinitionInterface(ctrl)
@@ -220,6 +242,22 @@ func (s *BaseSuite) deleteOptions(policy v1.DeletionPropagation) *v1.DeleteOptio
 	return &v1.DeleteOptions{PropagationPolicy: &policy}
 }
 
+// expectNoKubernetesResources expects the supporting resources of the
+// application to be listed, and none to be found.
+func (s *BaseSuite) expectNoKubernetesResources(appName string) {
+	listOptions := v1.ListOptions{LabelSelector: "juju-app==" + appName}
+	s.mockServiceAccounts.EXPECT().List(listOptions).Times(1).
+		Return(&core.ServiceAccountList{}, nil)
+	s.mockRoles.EXPECT().List(listOptions).Times(1).
+		Return(&rbacv1.RoleList{}, nil)
+	s.mockRoleBindings.EXPECT().List(listOptions).Times(1).
+		Return(&rbacv1.RoleBindingList{}, nil)
+	s.mockSecrets.EXPECT().List(listOptions).Times(1).
+		Return(&core.SecretList{}, nil)
+	s.mockConfigMaps.EXPECT().List(listOptions).Times(1).
+		Return(&core.ConfigMapList{}, nil)
+}
+
 func (s *BaseSuite) k8sNewFakeWatcher() *watch.RaceFreeFakeWatcher {
 	return watch.NewRaceFreeFake()
 }
@@ -28,6 +28,11 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+
+	autoscalingMinReplicasKey  = "kubernetes-autoscaling-min-replicas"
+	autoscalingMaxReplicasKey  = "kubernetes-autoscaling-max-replicas"
+	autoscalingTargetCPUKey    = "kubernetes-autoscaling-target-cpu-utilization"
+	autoscalingTargetMemoryKey = "kubernetes-autoscaling-target-memory-utilization"
 )
 
 var configFields = environschema.Fields{
@@ -86,6 +91,26 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	autoscalingMinReplicasKey: {
+		Description: "minimum number of pods when autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingMaxReplicasKey: {
+		Description: "maximum number of pods when autoscaling; enables autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingTargetCPUKey: {
+		Description: "target average CPU utilization percentage for autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingTargetMemoryKey: {
+		Description: "target average memory utilization percentage for autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
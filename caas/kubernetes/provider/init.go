@@ -75,6 +75,14 @@ func compileK8sCloudCheckers() map[string][]k8slabels.Selector {
 			newLabelRequirements(
 				requirementParams{"manufacturer", selection.Equals, []string{"amazon_ec2"}},
 			),
+			// EKS managed node groups.
+			newLabelRequirements(
+				requirementParams{"eks.amazonaws.com/nodegroup", selection.Exists, nil},
+			),
+			// EKS node groups created by eksctl.
+			newLabelRequirements(
+				requirementParams{"alpha.eksctl.io/cluster-name", selection.Exists, nil},
+			),
 			// CDK on AWS.
 			newLabelRequirements(
 				requirementParams{"juju.io/cloud", selection.Equals, []string{"ec2"}},
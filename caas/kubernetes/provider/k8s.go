@@ -112,6 +112,7 @@ type kubernetesClient struct {
 //go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,SecretInterface,NodeInterface
 //go:generate mockgen -package mocks -destination mocks/extenstionsv1_mock.go k8s.io/client-go/kubernetes/typed/extensions/v1beta1 ExtensionsV1beta1Interface,IngressInterface
 //go:generate mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
+//go:generate mockgen -package mocks -destination mocks/autoscalingv2beta1_mock.go k8s.io/client-go/kubernetes/typed/autoscaling/v2beta1 AutoscalingV2beta1Interface,HorizontalPodAutoscalerInterface
 
 // NewK8sClientFunc defines a function which returns a k8s client based on the supplied config.
 type NewK8sClientFunc func(c *rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, error)
@@ -998,6 +999,9 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	if err := k.deleteDeployment(deploymentName); err != nil {
 		return errors.Trace(err)
 	}
+	if err := k.deleteHorizontalPodAutoscaler(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
 	secrets := k.client().CoreV1().Secrets(k.namespace)
 	secretList, err := secrets.List(v1.ListOptions{
 		LabelSelector: applicationSelector(appName),
@@ -1010,7 +1014,7 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 			return errors.Trace(err)
 		}
 	}
-	return nil
+	return errors.Trace(k.deleteKubernetesResources(appName))
 }
 
 // EnsureCustomResourceDefinition creates or updates a custom resource definition resource.
@@ -1202,8 +1206,19 @@ func (k *kubernetesClient) EnsureService(
 			})
 	}
 
+	autoscaling, err := autoscalingSpec(params.PodSpec, config)
+	if err != nil {
+		return errors.Annotatef(err, "configuring autoscaling for %s", appName)
+	}
+
 	annotations := resourceTagsToAnnotations(params.ResourceTags)
 
+	resourceCleanups, err := k.ensureKubernetesResources(appName, deploymentName, annotations.Copy(), params.PodSpec)
+	cleanups = append(cleanups, resourceCleanups...)
+	if err != nil {
+		return errors.Annotatef(err, "creating kubernetes resources for %s", appName)
+	}
+
 	for _, c := range params.PodSpec.Containers {
 		if c.ImageDetails.Password == "" {
 			continue
@@ -1251,7 +1266,24 @@ func (k *kubernetesClient) EnsureService(
 	}
 
 	numPods := int32(numUnits)
+	if autoscaling != nil {
+		// Don't fight the autoscaler over the number of pods. The
+		// unit provisioner watches the workload and its autoscaler
+		// (see WatchService), and reports the replica count chosen
+		// by the autoscaler back as the application scale.
+		var currentReplicas *int32
+		if useStatefulSet {
+			if existingStatefulSet != nil {
+				currentReplicas = existingStatefulSet.Spec.Replicas
+			}
+		} else if currentReplicas, err = k.currentDeploymentReplicas(deploymentName); err != nil {
+			return errors.Trace(err)
+		}
+		numPods = autoscaledReplicas(autoscaling, currentReplicas, numUnits)
+	}
+	workloadKind := kindDeployment
 	if useStatefulSet {
+		workloadKind = kindStatefulSet
 		if err := k.configureStatefulSet(appName, deploymentName, randPrefix, annotations.Copy(), unitSpec, params.PodSpec.Containers, &numPods, params.Filesystems); err != nil {
 			return errors.Annotate(err, "creating or updating StatefulSet")
 		}
@@ -1262,6 +1294,14 @@ func (k *kubernetesClient) EnsureService(
 		}
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
 	}
+	if autoscaling != nil {
+		if err := k.configureHorizontalPodAutoscaler(appName, deploymentName, workloadKind, annotations.Copy(), autoscaling); err != nil {
+			return errors.Annotatef(err, "creating or updating horizontal pod autoscaler for %v", appName)
+		}
+		cleanups = append(cleanups, func() { k.deleteHorizontalPodAutoscaler(deploymentName) })
+	} else if err := k.deleteHorizontalPodAutoscaler(deploymentName); err != nil {
+		return errors.Annotatef(err, "removing horizontal pod autoscaler for %v", appName)
+	}
 
 	var ports []core.ContainerPort
 	for _, c := range unitSpec.Pod.Containers {
@@ -1893,7 +1933,8 @@ func (k *kubernetesClient) WatchUnits(appName string) (watcher.NotifyWatcher, er
 }
 
 // WatchService returns a watcher which notifies when there
-// are changes to the deployment of the specified application.
+// are changes to the deployment, or autoscaler, of the specified
+// application.
 func (k *kubernetesClient) WatchService(appName string) (watcher.NotifyWatcher, error) {
 	// Application may be a statefulset or deployment. It may not have
 	// been set up when the watcher is started so we don't know which it
@@ -1924,7 +1965,23 @@ func (k *kubernetesClient) WatchService(appName string) (watcher.NotifyWatcher,
 		return nil, errors.Trace(err)
 	}
 
-	return watcher.NewMultiNotifyWatcher(w1, w2), nil
+	// The autoscaler, if any, changes the replica count of the
+	// statefulset or deployment; watch it too so that its scaling
+	// decisions are reported promptly.
+	autoscalers := k.client().AutoscalingV2beta1().HorizontalPodAutoscalers(k.namespace)
+	awatcher, err := autoscalers.Watch(v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+		Watch:         true,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w3, err := k.newWatcher(awatcher, appName, k.clock)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return watcher.NewMultiNotifyWatcher(w1, w2, w3), nil
 }
 
 // WatchOperator returns a watcher which notifies when there
@@ -2503,7 +2560,7 @@ func makeUnitSpec(appName, deploymentName string, podSpec *caas.PodSpec) (*unitS
 			return nil, errors.Errorf("unexpected kubernetes pod spec type %T", podSpec.ProviderPod)
 		}
 		unitSpec.Pod.ActiveDeadlineSeconds = spec.ActiveDeadlineSeconds
-		unitSpec.Pod.ServiceAccountName = spec.ServiceAccountName
+		unitSpec.Pod.ServiceAccountName = podServiceAccountName(appName, spec)
 		unitSpec.Pod.TerminationGracePeriodSeconds = spec.TerminationGracePeriodSeconds
 		unitSpec.Pod.Hostname = spec.Hostname
 		unitSpec.Pod.Subdomain = spec.Subdomain
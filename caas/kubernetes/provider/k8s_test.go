@@ -17,7 +17,9 @@ import (
 	"gopkg.in/juju/worker.v1/workertest"
 	apps "k8s.io/api/apps/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
 	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	k8sstorage "k8s.io/api/storage/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
@@ -958,12 +960,38 @@ func (s *K8sBrokerSuite) TestDeleteServiceForApplication(c *gc.C) {
 			Return(s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
+		s.mockAutoscalers.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==test"}).Times(1).
 			Return(&core.SecretList{Items: []core.Secret{{
 				ObjectMeta: v1.ObjectMeta{Name: "secret"},
 			}}}, nil),
 		s.mockSecrets.EXPECT().Delete("secret", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==test"}).Times(1).
+			Return(&core.ConfigMapList{Items: []core.ConfigMap{{
+				ObjectMeta: v1.ObjectMeta{Name: "config"},
+			}}}, nil),
+		s.mockConfigMaps.EXPECT().Delete("config", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockRoleBindings.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==test"}).Times(1).
+			Return(&rbacv1.RoleBindingList{Items: []rbacv1.RoleBinding{{
+				ObjectMeta: v1.ObjectMeta{Name: "binding"},
+			}}}, nil),
+		s.mockRoleBindings.EXPECT().Delete("binding", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockRoles.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==test"}).Times(1).
+			Return(&rbacv1.RoleList{Items: []rbacv1.Role{{
+				ObjectMeta: v1.ObjectMeta{Name: "role"},
+			}}}, nil),
+		s.mockRoles.EXPECT().Delete("role", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockServiceAccounts.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==test"}).Times(1).
+			Return(&core.ServiceAccountList{Items: []core.ServiceAccount{{
+				ObjectMeta: v1.ObjectMeta{Name: "account"},
+			}}}, nil),
+		s.mockServiceAccounts.EXPECT().Delete("account", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 	)
 
 	err := s.broker.DeleteService("test")
@@ -1053,6 +1081,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 	}
 
 	secretArg := s.secretArg(c, map[string]string{"fred": "mary"})
+	s.expectNoKubernetesResources("app-name")
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
@@ -1064,6 +1093,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
 			Return(nil, nil),
+		s.mockAutoscalers.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(serviceArg).Times(1).
@@ -1085,6 +1116,407 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) TestEnsureServiceWithKubernetesResources(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	basicPodSpec := *basicPodspec
+	basicPodSpec.ProviderPod = &provider.K8sPodSpec{
+		ServiceAccountName: "watcher",
+		KubernetesResources: &provider.K8sResources{
+			Secrets: []provider.K8sSecret{{
+				Name: "creds",
+				Data: map[string]string{"password": "sekrit"},
+			}},
+			ConfigMaps: []provider.K8sConfigMap{{
+				Name: "settings",
+				Data: map[string]string{"level": "debug"},
+			}},
+			ServiceAccounts: []provider.K8sServiceAccount{{Name: "watcher"}},
+			Roles: []provider.K8sRole{{
+				Name: "pod-reader",
+				Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{""},
+					Resources: []string{"pods"},
+					Verbs:     []string{"get", "watch", "list"},
+				}},
+			}},
+			RoleBindings: []provider.K8sRoleBinding{{
+				Name:            "read-pods",
+				RoleName:        "pod-reader",
+				ServiceAccounts: []string{"watcher"},
+			}},
+		},
+	}
+
+	numUnits := int32(2)
+	unitSpec, err := provider.MakeUnitSpec("app-name", "app-name", &basicPodSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+
+	objectMeta := func(name string) v1.ObjectMeta {
+		return v1.ObjectMeta{
+			Name:        name,
+			Namespace:   "test",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{"fred": "mary"},
+		}
+	}
+	serviceAccountArg := &core.ServiceAccount{ObjectMeta: objectMeta("app-name-watcher")}
+	roleArg := &rbacv1.Role{
+		ObjectMeta: objectMeta("app-name-pod-reader"),
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "watch", "list"},
+		}},
+	}
+	roleBindingArg := &rbacv1.RoleBinding{
+		ObjectMeta: objectMeta("app-name-read-pods"),
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     "app-name-pod-reader",
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      "ServiceAccount",
+			Name:      "app-name-watcher",
+			Namespace: "test",
+		}},
+	}
+	charmSecretArg := &core.Secret{
+		ObjectMeta: objectMeta("app-name-creds"),
+		Type:       core.SecretTypeOpaque,
+		StringData: map[string]string{"password": "sekrit"},
+	}
+	configMapArg := &core.ConfigMap{
+		ObjectMeta: objectMeta("app-name-settings"),
+		Data:       map[string]string{"level": "debug"},
+	}
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{
+				"fred": "mary",
+			}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "app-name-",
+					Labels: map[string]string{
+						"juju-app": "app-name",
+					},
+					Annotations: map[string]string{
+						"apparmor.security.beta.kubernetes.io/pod": "runtime/default",
+						"seccomp.security.beta.kubernetes.io/pod":  "docker/default",
+						"fred": "mary",
+					},
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	c.Assert(deploymentArg.Spec.Template.Spec.ServiceAccountName, gc.Equals, "app-name-watcher")
+
+	secretArg := s.secretArg(c, map[string]string{"fred": "mary"})
+	listOptions := v1.ListOptions{LabelSelector: "juju-app==app-name"}
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().List(listOptions).Times(1).
+			Return(&core.ServiceAccountList{}, nil),
+		s.mockRoles.EXPECT().List(listOptions).Times(1).
+			Return(&rbacv1.RoleList{}, nil),
+		s.mockRoleBindings.EXPECT().List(listOptions).Times(1).
+			Return(&rbacv1.RoleBindingList{}, nil),
+		s.mockSecrets.EXPECT().List(listOptions).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(listOptions).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Update(serviceAccountArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Create(serviceAccountArg).Times(1).
+			Return(nil, nil),
+		s.mockRoles.EXPECT().Update(roleArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Create(roleArg).Times(1).
+			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Get("app-name-read-pods", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().Create(roleBindingArg).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().Update(charmSecretArg).Times(1).
+			Return(nil, nil),
+		s.mockConfigMaps.EXPECT().Update(configMapArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockConfigMaps.EXPECT().Create(configMapArg).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().Update(secretArg).Times(1).
+			Return(nil, nil),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockAutoscalers.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(gomock.Any()).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec:      &basicPodSpec,
+		ResourceTags: map[string]string{"fred": "mary"},
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type": "nodeIP",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceKubernetesResourcesPrunedAndCleanedUp(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	basicPodSpec := *basicPodspec
+	basicPodSpec.ProviderPod = &provider.K8sPodSpec{
+		ServiceAccountName: "watcher",
+		KubernetesResources: &provider.K8sResources{
+			ConfigMaps: []provider.K8sConfigMap{{
+				Name: "settings",
+				Data: map[string]string{"level": "debug"},
+			}},
+			ServiceAccounts: []provider.K8sServiceAccount{{Name: "watcher"}},
+			Roles: []provider.K8sRole{{
+				Name: "pod-reader",
+				Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{""},
+					Resources: []string{"pods"},
+					Verbs:     []string{"get", "watch", "list"},
+				}},
+			}},
+		},
+	}
+
+	objectMeta := func(name string) v1.ObjectMeta {
+		return v1.ObjectMeta{
+			Name:        name,
+			Namespace:   "test",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{"fred": "mary"},
+		}
+	}
+	serviceAccountArg := &core.ServiceAccount{ObjectMeta: objectMeta("app-name-watcher")}
+	roleArg := &rbacv1.Role{
+		ObjectMeta: objectMeta("app-name-pod-reader"),
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "watch", "list"},
+		}},
+	}
+	configMapArg := &core.ConfigMap{
+		ObjectMeta: objectMeta("app-name-settings"),
+		Data:       map[string]string{"level": "debug"},
+	}
+
+	// The service account and image pull secret already exist, and the
+	// role binding and "creds" secret are left over from an earlier
+	// spec. Only the role and config map created here are cleaned up
+	// when the image pull secret can't be updated.
+	secretArg := s.secretArg(c, map[string]string{"fred": "mary"})
+	listOptions := v1.ListOptions{LabelSelector: "juju-app==app-name"}
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().List(listOptions).Times(1).
+			Return(&core.ServiceAccountList{Items: []core.ServiceAccount{{
+				ObjectMeta: v1.ObjectMeta{Name: "app-name-watcher"},
+			}}}, nil),
+		s.mockRoles.EXPECT().List(listOptions).Times(1).
+			Return(&rbacv1.RoleList{}, nil),
+		s.mockRoleBindings.EXPECT().List(listOptions).Times(1).
+			Return(&rbacv1.RoleBindingList{Items: []rbacv1.RoleBinding{{
+				ObjectMeta: v1.ObjectMeta{Name: "app-name-read-pods"},
+			}}}, nil),
+		s.mockSecrets.EXPECT().List(listOptions).Times(1).
+			Return(&core.SecretList{Items: []core.Secret{{
+				ObjectMeta: v1.ObjectMeta{Name: "app-name-test-secret"},
+			}, {
+				ObjectMeta: v1.ObjectMeta{Name: "app-name-creds"},
+			}}}, nil),
+		s.mockConfigMaps.EXPECT().List(listOptions).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Update(serviceAccountArg).Times(1).
+			Return(nil, nil),
+		s.mockRoles.EXPECT().Update(roleArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Create(roleArg).Times(1).
+			Return(nil, nil),
+		s.mockConfigMaps.EXPECT().Update(configMapArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockConfigMaps.EXPECT().Create(configMapArg).Times(1).
+			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Delete("app-name-read-pods", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockSecrets.EXPECT().Delete("app-name-creds", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockSecrets.EXPECT().Update(secretArg).Times(1).
+			Return(nil, errors.New("boom")),
+		s.mockRoles.EXPECT().Delete("app-name-pod-reader", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockConfigMaps.EXPECT().Delete("app-name-settings", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec:      &basicPodSpec,
+		ResourceTags: map[string]string{"fred": "mary"},
+	}
+	err := s.broker.EnsureService("app-name", func(appName string, settableStatus status.Status, statusMessage string, statusData map[string]interface{}) error {
+		return nil
+	}, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type": "nodeIP",
+	})
+	c.Assert(err, gc.ErrorMatches, "creating secrets for container: test: boom")
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithAutoscaling(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	targetCPU := int32(80)
+	basicPodSpec := *basicPodspec
+	basicPodSpec.ProviderPod = &provider.K8sPodSpec{
+		Autoscaling: &provider.K8sAutoscalingSpec{
+			MinReplicas:                    2,
+			MaxReplicas:                    5,
+			TargetCPUUtilizationPercentage: &targetCPU,
+		},
+	}
+
+	unitSpec, err := provider.MakeUnitSpec("app-name", "app-name", &basicPodSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+
+	// The autoscaler has already scaled the deployment up, so that
+	// number of replicas is retained rather than Juju's lagging scale.
+	currentReplicas := int32(4)
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &currentReplicas,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "app-name-",
+					Labels: map[string]string{
+						"juju-app": "app-name",
+					},
+					Annotations: map[string]string{
+						"apparmor.security.beta.kubernetes.io/pod": "runtime/default",
+						"seccomp.security.beta.kubernetes.io/pod":  "docker/default",
+					},
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+
+	// The operator config overrides the max replicas and adds a memory target.
+	minReplicas := int32(2)
+	targetMemory := int32(70)
+	autoscalerArg := &autoscalingv2beta1.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{},
+		},
+		Spec: autoscalingv2beta1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "app-name",
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: 10,
+			Metrics: []autoscalingv2beta1.MetricSpec{{
+				Type: autoscalingv2beta1.ResourceMetricSourceType,
+				Resource: &autoscalingv2beta1.ResourceMetricSource{
+					Name:                     core.ResourceCPU,
+					TargetAverageUtilization: &targetCPU,
+				},
+			}, {
+				Type: autoscalingv2beta1.ResourceMetricSourceType,
+				Resource: &autoscalingv2beta1.ResourceMetricSource{
+					Name:                     core.ResourceMemory,
+					TargetAverageUtilization: &targetMemory,
+				},
+			}},
+		},
+	}
+
+	s.expectNoKubernetesResources("app-name")
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(&appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: &currentReplicas}}, nil),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockAutoscalers.EXPECT().Update(autoscalerArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockAutoscalers.EXPECT().Create(autoscalerArg).Times(1).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &basicPodSpec,
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type":                          "nodeIP",
+		"kubernetes-service-loadbalancer-ip":               "10.0.0.1",
+		"kubernetes-service-externalname":                  "ext-name",
+		"kubernetes-autoscaling-max-replicas":              10,
+		"kubernetes-autoscaling-target-memory-utilization": 70,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithInvalidAutoscaling(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+	}
+	err := s.broker.EnsureService("app-name", func(appName string, settableStatus status.Status, statusMessage string, statusData map[string]interface{}) error {
+		return nil
+	}, params, 2, application.ConfigAttributes{
+		"kubernetes-autoscaling-max-replicas": 3,
+	})
+	c.Assert(err, gc.ErrorMatches, `configuring autoscaling for app-name: autoscaling with no cpu or memory utilization target not valid`)
+}
+
 func (s *K8sBrokerSuite) TestEnsureServiceNoStorageStateful(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -1122,6 +1554,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorageStateful(c *gc.C) {
 
 	serviceArg := *basicServiceArg
 	serviceArg.Spec.Type = core.ServiceTypeExternalName
+	s.expectNoKubernetesResources("app-name")
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
@@ -1133,6 +1566,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorageStateful(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockAutoscalers.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(&serviceArg).Times(1).
@@ -1401,6 +1836,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 	}}
 	statefulSetArg := unitStatefulSetArg(2, "workload-storage", podSpec)
 
+	s.expectNoKubernetesResources("app-name")
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
@@ -1416,6 +1852,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockAutoscalers.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
@@ -1497,6 +1935,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithDevices(c *gc.C) {
 		},
 	}
 
+	s.expectNoKubernetesResources("app-name")
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
@@ -1508,6 +1947,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
 			Return(nil, nil),
+		s.mockAutoscalers.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
@@ -1558,6 +1999,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 	}
 	statefulSetArg := unitStatefulSetArg(2, "workload-storage", podSpec)
 
+	s.expectNoKubernetesResources("app-name")
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
@@ -1573,6 +2015,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockAutoscalers.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
@@ -1630,6 +2074,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithConstraints(c *gc.C) {
 	}
 	statefulSetArg := unitStatefulSetArg(2, "workload-storage", podSpec)
 
+	s.expectNoKubernetesResources("app-name")
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
@@ -1645,6 +2090,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithConstraints(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockAutoscalers.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
@@ -1709,6 +2156,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithNodeAffinity(c *gc.C) {
 	}
 	statefulSetArg := unitStatefulSetArg(2, "workload-storage", podSpec)
 
+	s.expectNoKubernetesResources("app-name")
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
@@ -1724,6 +2172,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithNodeAffinity(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockAutoscalers.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
@@ -1780,6 +2230,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithZones(c *gc.C) {
 	}
 	statefulSetArg := unitStatefulSetArg(2, "workload-storage", podSpec)
 
+	s.expectNoKubernetesResources("app-name")
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
@@ -1795,6 +2246,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithZones(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockAutoscalers.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
@@ -1869,6 +2322,7 @@ func (s *K8sBrokerSuite) TestWatchService(c *gc.C) {
 
 	ssWatcher := watch.NewRaceFreeFake()
 	deployWatcher := watch.NewRaceFreeFake()
+	autoscalerWatcher := watch.NewRaceFreeFake()
 
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Watch(v1.ListOptions{
@@ -1879,6 +2333,10 @@ func (s *K8sBrokerSuite) TestWatchService(c *gc.C) {
 			LabelSelector: "juju-app==test",
 			Watch:         true,
 		}).Return(deployWatcher, nil),
+		s.mockAutoscalers.EXPECT().Watch(v1.ListOptions{
+			LabelSelector: "juju-app==test",
+			Watch:         true,
+		}).Return(autoscalerWatcher, nil),
 	)
 
 	w, err := s.broker.WatchService("test")
@@ -1901,6 +2359,84 @@ func (s *K8sBrokerSuite) TestWatchService(c *gc.C) {
 	}
 }
 
+func (s *K8sBrokerSuite) TestWatchServiceAutoscaler(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	autoscalerWatcher := watch.NewRaceFreeFake()
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Watch(v1.ListOptions{
+			LabelSelector: "juju-app==test",
+			Watch:         true,
+		}).Return(watch.NewRaceFreeFake(), nil),
+		s.mockDeployments.EXPECT().Watch(v1.ListOptions{
+			LabelSelector: "juju-app==test",
+			Watch:         true,
+		}).Return(watch.NewRaceFreeFake(), nil),
+		s.mockAutoscalers.EXPECT().Watch(v1.ListOptions{
+			LabelSelector: "juju-app==test",
+			Watch:         true,
+		}).Return(autoscalerWatcher, nil),
+	)
+
+	w, err := s.broker.WatchService("test")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A scaling decision made by the autoscaler fires the watcher,
+	// so that the new replica count is reported as the scale.
+	autoscaler := &autoscalingv2beta1.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{Name: "test"},
+		Status:     autoscalingv2beta1.HorizontalPodAutoscalerStatus{DesiredReplicas: 4},
+	}
+	go func(w *watch.RaceFreeFakeWatcher, clk *testclock.Clock) {
+		if !w.IsStopped() {
+			clk.WaitAdvance(time.Second, testing.ShortWait, 1)
+			w.Modify(autoscaler)
+		}
+	}(autoscalerWatcher, s.clock)
+
+	select {
+	case _, ok := <-w.Changes():
+		c.Assert(ok, jc.IsTrue)
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out waiting for event")
+	}
+}
+
+func (s *K8sBrokerSuite) TestGetServiceAutoscaledReplicas(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	// The autoscaler has scaled the deployment to 4 replicas, which
+	// is reported as the application's scale.
+	replicas := int32(4)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{Replicas: 4, ReadyReplicas: 4},
+	}
+	gomock.InOrder(
+		s.mockServices.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==app-name", IncludeUninitialized: true}).Times(1).
+			Return(&core.ServiceList{}, nil),
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(deployment, nil),
+		s.mockEvents.EXPECT().List(v1.ListOptions{
+			IncludeUninitialized: true,
+			FieldSelector:        "involvedObject.name=app-name",
+		}).Times(1).Return(&core.EventList{}, nil),
+	)
+
+	service, err := s.broker.GetService("app-name", false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.Scale, gc.NotNil)
+	c.Assert(*service.Scale, gc.Equals, 4)
+	c.Assert(service.Status.Status, gc.Equals, status.Active)
+}
+
 func (s *K8sBrokerSuite) TestUpgradeController(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -11,7 +11,9 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/yaml.v2"
 	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 
 	"github.com/juju/juju/caas"
@@ -74,10 +76,176 @@ type K8sPodSpec struct {
 	DNSConfig                     *core.PodDNSConfig       `json:"dnsConfig,omitempty"`
 	ReadinessGates                []core.PodReadinessGate  `json:"readinessGates,omitempty"`
 	Service                       *K8sServiceSpec          `json:"service,omitempty"`
+	KubernetesResources           *K8sResources            `json:"kubernetesResources,omitempty"`
+	Autoscaling                   *K8sAutoscalingSpec      `json:"autoscaling,omitempty"`
 }
 
 // Validate is defined on ProviderPod.
-func (*K8sPodSpec) Validate() error {
+func (spec *K8sPodSpec) Validate() error {
+	if spec.KubernetesResources != nil {
+		if err := spec.KubernetesResources.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if spec.Autoscaling != nil {
+		return spec.Autoscaling.Validate()
+	}
+	return nil
+}
+
+// K8sAutoscalingSpec defines the horizontal autoscaling policy
+// for the application's pods. At least one utilisation target
+// must be specified.
+type K8sAutoscalingSpec struct {
+	MinReplicas                       int32  `json:"minReplicas,omitempty"`
+	MaxReplicas                       int32  `json:"maxReplicas"`
+	TargetCPUUtilizationPercentage    *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+}
+
+// Validate returns an error if the autoscaling spec is not valid.
+func (a *K8sAutoscalingSpec) Validate() error {
+	if a.MaxReplicas < 1 {
+		return errors.NotValidf("autoscaling max replicas %d", a.MaxReplicas)
+	}
+	if a.MinReplicas < 0 {
+		return errors.NotValidf("autoscaling min replicas %d", a.MinReplicas)
+	}
+	if a.MinReplicas > a.MaxReplicas {
+		return errors.NotValidf("autoscaling min replicas %d greater than max replicas %d", a.MinReplicas, a.MaxReplicas)
+	}
+	if a.TargetCPUUtilizationPercentage == nil && a.TargetMemoryUtilizationPercentage == nil {
+		return errors.NotValidf("autoscaling with no cpu or memory utilization target")
+	}
+	if err := validateUtilizationTarget("cpu", a.TargetCPUUtilizationPercentage); err != nil {
+		return errors.Trace(err)
+	}
+	return validateUtilizationTarget("memory", a.TargetMemoryUtilizationPercentage)
+}
+
+func validateUtilizationTarget(kind string, target *int32) error {
+	if target != nil && (*target < 1 || *target > 100) {
+		return errors.NotValidf("autoscaling %s utilization target %d%%", kind, *target)
+	}
+	return nil
+}
+
+// K8sResources defines the supporting k8s resources a charm
+// needs created alongside its workload. The resources are created
+// with the application name as a prefix, so that applications
+// deployed from the same charm don't clash; references between them,
+// and the pod's service account name, are resolved to the prefixed
+// names.
+type K8sResources struct {
+	Secrets         []K8sSecret         `json:"secrets,omitempty"`
+	ConfigMaps      []K8sConfigMap      `json:"configMaps,omitempty"`
+	ServiceAccounts []K8sServiceAccount `json:"serviceAccounts,omitempty"`
+	Roles           []K8sRole           `json:"roles,omitempty"`
+	RoleBindings    []K8sRoleBinding    `json:"roleBindings,omitempty"`
+}
+
+// K8sSecret defines a secret to be created in the model namespace.
+// Data values are plain text; k8s does the base64 encoding.
+type K8sSecret struct {
+	Name string            `json:"name"`
+	Type core.SecretType   `json:"type,omitempty"`
+	Data map[string]string `json:"data,omitempty"`
+}
+
+// K8sConfigMap defines a config map to be created in the model namespace.
+type K8sConfigMap struct {
+	Name string            `json:"name"`
+	Data map[string]string `json:"data,omitempty"`
+}
+
+// K8sServiceAccount defines a service account to be created
+// in the model namespace.
+type K8sServiceAccount struct {
+	Name                         string `json:"name"`
+	AutomountServiceAccountToken *bool  `json:"automountServiceAccountToken,omitempty"`
+}
+
+// K8sRole defines a namespaced role.
+type K8sRole struct {
+	Name  string              `json:"name"`
+	Rules []rbacv1.PolicyRule `json:"rules"`
+}
+
+// K8sRoleBinding binds a role declared in the same spec to
+// one or more service accounts declared in the same spec.
+type K8sRoleBinding struct {
+	Name            string   `json:"name"`
+	RoleName        string   `json:"roleName"`
+	ServiceAccounts []string `json:"serviceAccounts"`
+}
+
+// Validate returns an error if the resources are not valid.
+func (r *K8sResources) Validate() error {
+	secrets := set.NewStrings()
+	for _, secret := range r.Secrets {
+		if err := validateResourceName("secret", secret.Name, secrets); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	configMaps := set.NewStrings()
+	for _, cm := range r.ConfigMaps {
+		if err := validateResourceName("config map", cm.Name, configMaps); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	serviceAccounts := set.NewStrings()
+	for _, sa := range r.ServiceAccounts {
+		if err := validateResourceName("service account", sa.Name, serviceAccounts); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	roles := set.NewStrings()
+	for _, role := range r.Roles {
+		if err := validateResourceName("role", role.Name, roles); err != nil {
+			return errors.Trace(err)
+		}
+		if len(role.Rules) == 0 {
+			return errors.NotValidf("role %q with no rules", role.Name)
+		}
+		for _, rule := range role.Rules {
+			if len(rule.Verbs) == 0 {
+				return errors.NotValidf("rule with no verbs in role %q", role.Name)
+			}
+		}
+	}
+	roleBindings := set.NewStrings()
+	for _, rb := range r.RoleBindings {
+		if err := validateResourceName("role binding", rb.Name, roleBindings); err != nil {
+			return errors.Trace(err)
+		}
+		if !roles.Contains(rb.RoleName) {
+			return errors.NotValidf("role binding %q referencing undeclared role %q", rb.Name, rb.RoleName)
+		}
+		if len(rb.ServiceAccounts) == 0 {
+			return errors.NotValidf("role binding %q with no service accounts", rb.Name)
+		}
+		for _, sa := range rb.ServiceAccounts {
+			if !serviceAccounts.Contains(sa) {
+				return errors.NotValidf("role binding %q referencing undeclared service account %q", rb.Name, sa)
+			}
+		}
+	}
+	return nil
+}
+
+// validateResourceName checks that name is a valid, unique k8s resource
+// name, recording it in seen.
+func validateResourceName(kind, name string, seen set.Strings) error {
+	if name == "" {
+		return errors.Errorf("%s name is missing", kind)
+	}
+	if msgs := validation.IsDNS1123Subdomain(name); len(msgs) > 0 {
+		return errors.NotValidf("%s name %q: %s", kind, name, strings.Join(msgs, ", "))
+	}
+	if seen.Contains(name) {
+		return errors.NotValidf("duplicate %s name %q", kind, name)
+	}
+	seen.Add(name)
 	return nil
 }
 
@@ -7,11 +7,13 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/testing"
 )
 
@@ -406,3 +408,252 @@ containers:
 	err = spec.Validate()
 	c.Assert(err, gc.ErrorMatches, `mount path is missing for file set "configuration"`)
 }
+
+func (s *ContainersSuite) TestParseKubernetesResources(c *gc.C) {
+
+	specStr := `
+serviceAccountName: watcher
+containers:
+  - name: gitlab
+    image: gitlab/latest
+kubernetesResources:
+  secrets:
+    - name: creds
+      type: Opaque
+      data:
+        password: sekrit
+  configMaps:
+    - name: settings
+      data:
+        level: debug
+  serviceAccounts:
+    - name: watcher
+      automountServiceAccountToken: true
+  roles:
+    - name: pod-reader
+      rules:
+        - apiGroups: [""]
+          resources: ["pods"]
+          verbs: ["get", "watch", "list"]
+  roleBindings:
+    - name: read-pods
+      roleName: pod-reader
+      serviceAccounts: [watcher]
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
+	c.Assert(spec.ProviderPod, jc.DeepEquals, &provider.K8sPodSpec{
+		ServiceAccountName: "watcher",
+		KubernetesResources: &provider.K8sResources{
+			Secrets: []provider.K8sSecret{{
+				Name: "creds",
+				Type: core.SecretTypeOpaque,
+				Data: map[string]string{"password": "sekrit"},
+			}},
+			ConfigMaps: []provider.K8sConfigMap{{
+				Name: "settings",
+				Data: map[string]string{"level": "debug"},
+			}},
+			ServiceAccounts: []provider.K8sServiceAccount{{
+				Name:                         "watcher",
+				AutomountServiceAccountToken: boolPtr(true),
+			}},
+			Roles: []provider.K8sRole{{
+				Name: "pod-reader",
+				Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{""},
+					Resources: []string{"pods"},
+					Verbs:     []string{"get", "watch", "list"},
+				}},
+			}},
+			RoleBindings: []provider.K8sRoleBinding{{
+				Name:            "read-pods",
+				RoleName:        "pod-reader",
+				ServiceAccounts: []string{"watcher"},
+			}},
+		},
+	})
+}
+
+func (s *ContainersSuite) TestValidateKubernetesResources(c *gc.C) {
+	for i, t := range []struct {
+		resources string
+		err       string
+	}{{
+		resources: `
+  secrets:
+    - data: {a: b}
+`,
+		err: `secret name is missing`,
+	}, {
+		resources: `
+  configMaps:
+    - name: Not_Valid
+`,
+		err: `config map name "Not_Valid": .* not valid`,
+	}, {
+		resources: `
+  serviceAccounts:
+    - name: watcher
+    - name: watcher
+`,
+		err: `duplicate service account name "watcher" not valid`,
+	}, {
+		resources: `
+  roles:
+    - name: pod-reader
+`,
+		err: `role "pod-reader" with no rules not valid`,
+	}, {
+		resources: `
+  roles:
+    - name: pod-reader
+      rules:
+        - resources: ["pods"]
+`,
+		err: `rule with no verbs in role "pod-reader" not valid`,
+	}, {
+		resources: `
+  serviceAccounts:
+    - name: watcher
+  roleBindings:
+    - name: read-pods
+      roleName: pod-reader
+      serviceAccounts: [watcher]
+`,
+		err: `role binding "read-pods" referencing undeclared role "pod-reader" not valid`,
+	}, {
+		resources: `
+  roles:
+    - name: pod-reader
+      rules:
+        - resources: ["pods"]
+          verbs: ["get"]
+  roleBindings:
+    - name: read-pods
+      roleName: pod-reader
+      serviceAccounts: [watcher]
+`,
+		err: `role binding "read-pods" referencing undeclared service account "watcher" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+kubernetesResources:`[1:] + t.resources
+
+		spec, err := provider.ParseK8sPodSpec(specStr)
+		c.Assert(err, jc.ErrorIsNil)
+		err = spec.Validate()
+		c.Assert(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *ContainersSuite) TestParseAutoscaling(c *gc.C) {
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+autoscaling:
+  minReplicas: 2
+  maxReplicas: 10
+  targetCPUUtilizationPercentage: 80
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
+	c.Assert(spec.ProviderPod, jc.DeepEquals, &provider.K8sPodSpec{
+		Autoscaling: &provider.K8sAutoscalingSpec{
+			MinReplicas:                    2,
+			MaxReplicas:                    10,
+			TargetCPUUtilizationPercentage: int32Ptr(80),
+		},
+	})
+}
+
+func (s *ContainersSuite) TestAutoscalingEnabled(c *gc.C) {
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+autoscaling:
+  maxReplicas: 10
+  targetCPUUtilizationPercentage: 80
+`[1:]
+	plainSpecStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:]
+	for i, t := range []struct {
+		podSpec string
+		config  application.ConfigAttributes
+		enabled bool
+	}{{
+		podSpec: specStr,
+		enabled: true,
+	}, {
+		podSpec: plainSpecStr,
+		enabled: false,
+	}, {
+		enabled: false,
+	}, {
+		podSpec: plainSpecStr,
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-max-replicas":           5,
+			"kubernetes-autoscaling-target-cpu-utilization": 70,
+		},
+		enabled: true,
+	}} {
+		c.Logf("test %d", i)
+		enabled, err := provider.AutoscalingEnabled(t.podSpec, t.config)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(enabled, gc.Equals, t.enabled)
+	}
+}
+
+func (s *ContainersSuite) TestValidateAutoscaling(c *gc.C) {
+	for i, t := range []struct {
+		autoscaling string
+		err         string
+	}{{
+		autoscaling: `
+  targetCPUUtilizationPercentage: 80
+`,
+		err: `autoscaling max replicas 0 not valid`,
+	}, {
+		autoscaling: `
+  minReplicas: 5
+  maxReplicas: 3
+  targetCPUUtilizationPercentage: 80
+`,
+		err: `autoscaling min replicas 5 greater than max replicas 3 not valid`,
+	}, {
+		autoscaling: `
+  maxReplicas: 3
+`,
+		err: `autoscaling with no cpu or memory utilization target not valid`,
+	}, {
+		autoscaling: `
+  maxReplicas: 3
+  targetMemoryUtilizationPercentage: 120
+`,
+		err: `autoscaling memory utilization target 120% not valid`,
+	}} {
+		c.Logf("test %d", i)
+		specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+autoscaling:`[1:] + t.autoscaling
+
+		spec, err := provider.ParseK8sPodSpec(specStr)
+		c.Assert(err, jc.ErrorIsNil)
+		err = spec.Validate()
+		c.Assert(err, gc.ErrorMatches, t.err)
+	}
+}
@@ -0,0 +1,386 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	k8sannotations "github.com/juju/juju/core/annotations"
+)
+
+// ensureKubernetesResources creates or updates the secrets, config maps,
+// service accounts, roles and role bindings declared in the pod spec.
+// All resources are named with the application name as a prefix, and
+// labelled with it so they can be removed when the application is
+// deleted. Resources created for an earlier pod spec that are no longer
+// declared are removed. The returned cleanups remove the resources
+// created here, but not the ones that already existed, if the caller
+// fails later on.
+func (k *kubernetesClient) ensureKubernetesResources(
+	appName, deploymentName string, annotations k8sannotations.Annotation, podSpec *caas.PodSpec,
+) (cleanups []func(), err error) {
+	resources := &K8sResources{}
+	if podSpec.ProviderPod != nil {
+		spec, ok := podSpec.ProviderPod.(*K8sPodSpec)
+		if !ok {
+			return nil, errors.Errorf("unexpected kubernetes pod spec type %T", podSpec.ProviderPod)
+		}
+		if spec.KubernetesResources != nil {
+			resources = spec.KubernetesResources
+		}
+	}
+
+	existing, err := k.listKubernetesResources(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	wanted := newKubernetesResourceNames()
+	// The image pull secrets are labelled with the application too.
+	for _, c := range podSpec.Containers {
+		if c.ImageDetails.Password != "" {
+			wanted.secrets.Add(appSecretName(deploymentName, c.Name))
+		}
+	}
+
+	objectMeta := func(name string) v1.ObjectMeta {
+		return v1.ObjectMeta{
+			Name:        name,
+			Namespace:   k.namespace,
+			Labels:      map[string]string{labelApplication: appName},
+			Annotations: annotations.ToMap(),
+		}
+	}
+
+	for _, sa := range resources.ServiceAccounts {
+		name := applicationResourceName(appName, sa.Name)
+		wanted.serviceAccounts.Add(name)
+		if err := k.ensureServiceAccount(&core.ServiceAccount{
+			ObjectMeta:                   objectMeta(name),
+			AutomountServiceAccountToken: sa.AutomountServiceAccountToken,
+		}); err != nil {
+			return cleanups, errors.Annotatef(err, "creating or updating service account %q", name)
+		}
+		if !existing.serviceAccounts.Contains(name) {
+			cleanups = append(cleanups, func() { _ = k.deleteServiceAccount(name) })
+		}
+	}
+	for _, role := range resources.Roles {
+		name := applicationResourceName(appName, role.Name)
+		wanted.roles.Add(name)
+		if err := k.ensureRole(&rbacv1.Role{
+			ObjectMeta: objectMeta(name),
+			Rules:      role.Rules,
+		}); err != nil {
+			return cleanups, errors.Annotatef(err, "creating or updating role %q", name)
+		}
+		if !existing.roles.Contains(name) {
+			cleanups = append(cleanups, func() { _ = k.deleteRole(name) })
+		}
+	}
+	for _, rb := range resources.RoleBindings {
+		name := applicationResourceName(appName, rb.Name)
+		wanted.roleBindings.Add(name)
+		binding := &rbacv1.RoleBinding{
+			ObjectMeta: objectMeta(name),
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     applicationResourceName(appName, rb.RoleName),
+			},
+		}
+		for _, sa := range rb.ServiceAccounts {
+			binding.Subjects = append(binding.Subjects, rbacv1.Subject{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      applicationResourceName(appName, sa),
+				Namespace: k.namespace,
+			})
+		}
+		if err := k.ensureRoleBinding(binding); err != nil {
+			return cleanups, errors.Annotatef(err, "creating or updating role binding %q", name)
+		}
+		if !existing.roleBindings.Contains(name) {
+			cleanups = append(cleanups, func() { _ = k.deleteRoleBinding(name) })
+		}
+	}
+	for _, secret := range resources.Secrets {
+		name := applicationResourceName(appName, secret.Name)
+		wanted.secrets.Add(name)
+		secretType := secret.Type
+		if secretType == "" {
+			secretType = core.SecretTypeOpaque
+		}
+		if err := k.ensureSecret(&core.Secret{
+			ObjectMeta: objectMeta(name),
+			Type:       secretType,
+			StringData: secret.Data,
+		}); err != nil {
+			return cleanups, errors.Annotatef(err, "creating or updating secret %q", name)
+		}
+		if !existing.secrets.Contains(name) {
+			cleanups = append(cleanups, func() { _ = k.deleteSecret(name) })
+		}
+	}
+	for _, cm := range resources.ConfigMaps {
+		name := applicationResourceName(appName, cm.Name)
+		wanted.configMaps.Add(name)
+		if err := k.ensureConfigMap(&core.ConfigMap{
+			ObjectMeta: objectMeta(name),
+			Data:       cm.Data,
+		}); err != nil {
+			return cleanups, errors.Annotatef(err, "creating or updating config map %q", name)
+		}
+		if !existing.configMaps.Contains(name) {
+			cleanups = append(cleanups, func() { _ = k.deleteConfigMap(name) })
+		}
+	}
+
+	if err := k.pruneKubernetesResources(existing, wanted); err != nil {
+		return cleanups, errors.Annotate(err, "removing undeclared kubernetes resources")
+	}
+	return cleanups, nil
+}
+
+// applicationResourceName returns the name of the k8s resource
+// created for the named resource declared in an application's pod spec.
+func applicationResourceName(appName, name string) string {
+	return appName + "-" + name
+}
+
+// podServiceAccountName returns the name of the service account the
+// application's pods run as. A service account declared in the pod
+// spec is referred to by the name it is created with.
+func podServiceAccountName(appName string, spec *K8sPodSpec) string {
+	if spec.KubernetesResources != nil {
+		for _, sa := range spec.KubernetesResources.ServiceAccounts {
+			if sa.Name == spec.ServiceAccountName {
+				return applicationResourceName(appName, sa.Name)
+			}
+		}
+	}
+	return spec.ServiceAccountName
+}
+
+// kubernetesResourceNames holds the names of an application's
+// supporting k8s resources, by kind.
+type kubernetesResourceNames struct {
+	secrets         set.Strings
+	configMaps      set.Strings
+	serviceAccounts set.Strings
+	roles           set.Strings
+	roleBindings    set.Strings
+}
+
+func newKubernetesResourceNames() kubernetesResourceNames {
+	return kubernetesResourceNames{
+		secrets:         set.NewStrings(),
+		configMaps:      set.NewStrings(),
+		serviceAccounts: set.NewStrings(),
+		roles:           set.NewStrings(),
+		roleBindings:    set.NewStrings(),
+	}
+}
+
+// listKubernetesResources returns the names of the supporting resources
+// labelled as belonging to the application.
+func (k *kubernetesClient) listKubernetesResources(appName string) (kubernetesResourceNames, error) {
+	names := newKubernetesResourceNames()
+	listOptions := v1.ListOptions{LabelSelector: applicationSelector(appName)}
+
+	serviceAccounts, err := k.client().CoreV1().ServiceAccounts(k.namespace).List(listOptions)
+	if err != nil {
+		return names, errors.Trace(err)
+	}
+	for _, sa := range serviceAccounts.Items {
+		names.serviceAccounts.Add(sa.Name)
+	}
+	roles, err := k.client().RbacV1().Roles(k.namespace).List(listOptions)
+	if err != nil {
+		return names, errors.Trace(err)
+	}
+	for _, role := range roles.Items {
+		names.roles.Add(role.Name)
+	}
+	roleBindings, err := k.client().RbacV1().RoleBindings(k.namespace).List(listOptions)
+	if err != nil {
+		return names, errors.Trace(err)
+	}
+	for _, rb := range roleBindings.Items {
+		names.roleBindings.Add(rb.Name)
+	}
+	secrets, err := k.client().CoreV1().Secrets(k.namespace).List(listOptions)
+	if err != nil {
+		return names, errors.Trace(err)
+	}
+	for _, secret := range secrets.Items {
+		names.secrets.Add(secret.Name)
+	}
+	configMaps, err := k.client().CoreV1().ConfigMaps(k.namespace).List(listOptions)
+	if err != nil {
+		return names, errors.Trace(err)
+	}
+	for _, cm := range configMaps.Items {
+		names.configMaps.Add(cm.Name)
+	}
+	return names, nil
+}
+
+// pruneKubernetesResources deletes the existing resources that are not
+// wanted. Role bindings are deleted before the roles and service
+// accounts they refer to.
+func (k *kubernetesClient) pruneKubernetesResources(existing, wanted kubernetesResourceNames) error {
+	for _, name := range existing.roleBindings.Difference(wanted.roleBindings).SortedValues() {
+		if err := k.deleteRoleBinding(name); err != nil {
+			return errors.Annotatef(err, "deleting role binding %q", name)
+		}
+	}
+	for _, name := range existing.roles.Difference(wanted.roles).SortedValues() {
+		if err := k.deleteRole(name); err != nil {
+			return errors.Annotatef(err, "deleting role %q", name)
+		}
+	}
+	for _, name := range existing.serviceAccounts.Difference(wanted.serviceAccounts).SortedValues() {
+		if err := k.deleteServiceAccount(name); err != nil {
+			return errors.Annotatef(err, "deleting service account %q", name)
+		}
+	}
+	for _, name := range existing.secrets.Difference(wanted.secrets).SortedValues() {
+		if err := k.deleteSecret(name); err != nil {
+			return errors.Annotatef(err, "deleting secret %q", name)
+		}
+	}
+	for _, name := range existing.configMaps.Difference(wanted.configMaps).SortedValues() {
+		if err := k.deleteConfigMap(name); err != nil {
+			return errors.Annotatef(err, "deleting config map %q", name)
+		}
+	}
+	return nil
+}
+
+// deleteKubernetesResources deletes the config maps, service accounts,
+// roles and role bindings created for the application's pod spec.
+// Secrets are removed along with the image pull secrets.
+func (k *kubernetesClient) deleteKubernetesResources(appName string) error {
+	listOptions := v1.ListOptions{LabelSelector: applicationSelector(appName)}
+
+	configMaps, err := k.client().CoreV1().ConfigMaps(k.namespace).List(listOptions)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, cm := range configMaps.Items {
+		if err := k.deleteConfigMap(cm.Name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	roleBindings, err := k.client().RbacV1().RoleBindings(k.namespace).List(listOptions)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, rb := range roleBindings.Items {
+		if err := k.deleteRoleBinding(rb.Name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	roles, err := k.client().RbacV1().Roles(k.namespace).List(listOptions)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, role := range roles.Items {
+		if err := k.deleteRole(role.Name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	serviceAccounts, err := k.client().CoreV1().ServiceAccounts(k.namespace).List(listOptions)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, sa := range serviceAccounts.Items {
+		if err := k.deleteServiceAccount(sa.Name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// ensureServiceAccount ensures a ServiceAccount resource.
+func (k *kubernetesClient) ensureServiceAccount(sa *core.ServiceAccount) error {
+	serviceAccounts := k.client().CoreV1().ServiceAccounts(k.namespace)
+	_, err := serviceAccounts.Update(sa)
+	if k8serrors.IsNotFound(err) {
+		_, err = serviceAccounts.Create(sa)
+	}
+	return errors.Trace(err)
+}
+
+// deleteServiceAccount deletes a ServiceAccount resource.
+func (k *kubernetesClient) deleteServiceAccount(name string) error {
+	err := k.client().CoreV1().ServiceAccounts(k.namespace).Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// ensureRole ensures a Role resource.
+func (k *kubernetesClient) ensureRole(role *rbacv1.Role) error {
+	roles := k.client().RbacV1().Roles(k.namespace)
+	_, err := roles.Update(role)
+	if k8serrors.IsNotFound(err) {
+		_, err = roles.Create(role)
+	}
+	return errors.Trace(err)
+}
+
+// deleteRole deletes a Role resource.
+func (k *kubernetesClient) deleteRole(name string) error {
+	err := k.client().RbacV1().Roles(k.namespace).Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// ensureRoleBinding ensures a RoleBinding resource. The role reference
+// of an existing binding can't be changed, so if it differs the binding
+// is replaced. Bindings have no dependents so the delete is immediate.
+func (k *kubernetesClient) ensureRoleBinding(rb *rbacv1.RoleBinding) error {
+	roleBindings := k.client().RbacV1().RoleBindings(k.namespace)
+	existing, err := roleBindings.Get(rb.Name, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		_, err = roleBindings.Create(rb)
+		return errors.Trace(err)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if existing.RoleRef != rb.RoleRef {
+		if err := roleBindings.Delete(rb.Name, &v1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		_, err = roleBindings.Create(rb)
+		return errors.Trace(err)
+	}
+	_, err = roleBindings.Update(rb)
+	return errors.Trace(err)
+}
+
+// deleteRoleBinding deletes a RoleBinding resource.
+func (k *kubernetesClient) deleteRoleBinding(name string) error {
+	err := k.client().RbacV1().RoleBindings(k.namespace).Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
@@ -147,7 +147,11 @@ func (k *kubernetesClient) GetClusterMetadata(storageClass string) (*caas.Cluste
 	}
 
 	// Even if no storage class was marked as default for the cluster, if there's only
-	// one of them, use it for workload storage.
+	// one of them, use it for workload storage. Otherwise, fall back to the only one
+	// matching Juju's preferred storage for the cloud, eg gp2 on EKS.
+	if result.NominatedStorageClass == nil && len(possibleWorkloadStorage) > 1 {
+		possibleWorkloadStorage = preferredStorageClasses(result.Cloud, possibleWorkloadStorage)
+	}
 	if result.NominatedStorageClass == nil && len(possibleWorkloadStorage) == 1 {
 		sc := possibleWorkloadStorage[0]
 		result.NominatedStorageClass = &caas.StorageProvisioner{
@@ -162,6 +166,27 @@ func (k *kubernetesClient) GetClusterMetadata(storageClass string) (*caas.Cluste
 	return &result, nil
 }
 
+// preferredStorageClasses returns the storage classes which match
+// Juju's preferred workload storage for the specified cloud.
+func preferredStorageClasses(cloud string, storageClasses []storage.StorageClass) []storage.StorageClass {
+	preferredStorage, ok := jujuPreferredWorkloadStorage[cloud]
+	if !ok {
+		return storageClasses
+	}
+	var result []storage.StorageClass
+	for _, sc := range storageClasses {
+		sp := &caas.StorageProvisioner{
+			Name:        sc.Name,
+			Provisioner: sc.Provisioner,
+			Parameters:  sc.Parameters,
+		}
+		if err := storageClassMatches(preferredStorage, sp); err == nil {
+			result = append(result, sc)
+		}
+	}
+	return result
+}
+
 // listHostCloudRegions lists all the cloud regions that this cluster has worker nodes/instances running in.
 func (k *kubernetesClient) listHostCloudRegions() (string, set.Strings, error) {
 	// we only check 5 worker nodes as of now just run in the one region and
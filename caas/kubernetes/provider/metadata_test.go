@@ -128,6 +128,22 @@ var hostRegionsTestCases = []hostRegionTestcase{
 			"manufacturer": "amazon_ec2",
 		}),
 	},
+	{
+		expectedCloud:   "ec2",
+		expectedRegions: set.NewStrings("us-west-2"),
+		nodes: newNodeList(map[string]string{
+			"failure-domain.beta.kubernetes.io/region": "us-west-2",
+			"eks.amazonaws.com/nodegroup":              "workers",
+		}),
+	},
+	{
+		expectedCloud:   "ec2",
+		expectedRegions: set.NewStrings("us-west-2"),
+		nodes: newNodeList(map[string]string{
+			"failure-domain.beta.kubernetes.io/region": "us-west-2",
+			"alpha.eksctl.io/cluster-name":             "mycluster",
+		}),
+	},
 	{
 		expectedRegions: set.NewStrings(),
 		nodes: newNodeList(map[string]string{
@@ -240,6 +256,36 @@ func (s *K8sMetadataSuite) TestNoDefaultStorageClassesTooMany(c *gc.C) {
 	c.Check(metadata.NominatedStorageClass, gc.IsNil)
 }
 
+func (s *K8sMetadataSuite) TestNoDefaultStorageClassesPreferredForCloud(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockNodes.EXPECT().List(v1.ListOptions{Limit: 5}).Times(1).
+			Return(newNodeList(map[string]string{
+				"failure-domain.beta.kubernetes.io/region": "us-west-2",
+				"eks.amazonaws.com/nodegroup":              "workers",
+			}), nil),
+		s.mockStorageClass.EXPECT().List(v1.ListOptions{}).Times(1).
+			Return(&storagev1.StorageClassList{Items: []storagev1.StorageClass{{
+				ObjectMeta:  v1.ObjectMeta{Name: "efs"},
+				Provisioner: "efs.csi.aws.com",
+			}, {
+				ObjectMeta:  v1.ObjectMeta{Name: "gp2"},
+				Provisioner: "kubernetes.io/aws-ebs",
+				Parameters:  map[string]string{"type": "gp2"},
+			}}}, nil),
+	)
+	metadata, err := s.broker.GetClusterMetadata("")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(metadata.Cloud, gc.Equals, "ec2")
+	c.Check(metadata.NominatedStorageClass, jc.DeepEquals, &caas.StorageProvisioner{
+		Name:        "gp2",
+		Provisioner: "kubernetes.io/aws-ebs",
+		Parameters:  map[string]string{"type": "gp2"},
+	})
+}
+
 func (s *K8sMetadataSuite) TestPreferDefaultStorageClass(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
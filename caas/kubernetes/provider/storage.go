@@ -12,6 +12,7 @@ import (
 	"github.com/juju/schema"
 	core "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/juju/juju/environs/context"
@@ -185,6 +186,7 @@ type volumeSource struct {
 }
 
 var _ storage.VolumeSource = (*volumeSource)(nil)
+var _ storage.VolumeResizer = (*volumeSource)(nil)
 
 // CreateVolumes is specified on the storage.VolumeSource interface.
 func (v *volumeSource) CreateVolumes(ctx context.ProviderCallContext, params []storage.VolumeParams) (_ []storage.CreateVolumesResult, err error) {
@@ -287,6 +289,48 @@ func (v *volumeSource) DetachVolumes(ctx context.ProviderCallContext, attachPara
 	return make([]error, len(attachParams)), nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+// Volumes are resized by expanding the claims they are bound to, which
+// requires the claims' storage class to allow volume expansion.
+func (v *volumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		if err := v.resizeVolume(p); err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Size = p.Size
+	}
+	return results, nil
+}
+
+func (v *volumeSource) resizeVolume(p storage.VolumeResizeParams) error {
+	pVolumes := v.client.client().CoreV1().PersistentVolumes()
+	vol, err := pVolumes.Get(p.VolumeId, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return errors.NotFoundf("volume %v", p.VolumeId)
+	} else if err != nil {
+		return errors.Annotatef(err, "getting volume %v to resize", p.VolumeId)
+	}
+	claimRef := vol.Spec.ClaimRef
+	if claimRef == nil {
+		return errors.Errorf("volume %v is not bound to a claim", p.VolumeId)
+	}
+	pClaims := v.client.client().CoreV1().PersistentVolumeClaims(claimRef.Namespace)
+	pvc, err := pClaims.Get(claimRef.Name, v1.GetOptions{})
+	if err != nil {
+		return errors.Annotatef(err, "getting volume claim %v", claimRef.Name)
+	}
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = make(core.ResourceList)
+	}
+	pvc.Spec.Resources.Requests[core.ResourceStorage] = resource.MustParse(fmt.Sprintf("%dMi", p.Size))
+	if _, err := pClaims.Update(pvc); err != nil {
+		return errors.Annotatef(err, "expanding volume claim %v", claimRef.Name)
+	}
+	return nil
+}
+
 func foreachVolume(volumeIds []string, f func(string) error) []error {
 	results := make([]error, len(volumeIds))
 	var wg sync.WaitGroup
@@ -143,6 +143,53 @@ func (s *storageSuite) TestDestroyVolumesNotFoundIgnored(c *gc.C) {
 	c.Assert(errs, jc.DeepEquals, []error{nil})
 }
 
+func (s *storageSuite) TestResizeVolumes(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	pvc := &core.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{Name: "vol-1-pvc"},
+		Spec: core.PersistentVolumeClaimSpec{
+			Resources: core.ResourceRequirements{
+				Requests: core.ResourceList{
+					core.ResourceStorage: resource.MustParse("1024Mi"),
+				},
+			},
+		},
+	}
+	expanded := *pvc
+	expanded.Spec.Resources.Requests = core.ResourceList{
+		core.ResourceStorage: resource.MustParse("2048Mi"),
+	}
+	gomock.InOrder(
+		s.mockPersistentVolumes.EXPECT().Get("vol-1", v1.GetOptions{}).Times(1).
+			Return(&core.PersistentVolume{
+				Spec: core.PersistentVolumeSpec{
+					ClaimRef: &core.ObjectReference{Namespace: "test", Name: "vol-1-pvc"},
+				}}, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Get("vol-1-pvc", v1.GetOptions{}).Times(1).
+			Return(pvc, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Update(&expanded).Times(1).
+			Return(&expanded, nil),
+		s.mockPersistentVolumes.EXPECT().Get("vol-2", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	p := s.k8sProvider(c, ctrl)
+	vs, err := p.VolumeSource(&storage.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vs, gc.Implements, new(storage.VolumeResizer))
+
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(&context.CloudCallContext{}, []storage.VolumeResizeParams{
+		{VolumeId: "vol-1", Size: 2048},
+		{VolumeId: "vol-2", Size: 2048},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeVolumesResult{Size: 2048})
+	c.Assert(results[1].Error, gc.ErrorMatches, "volume vol-2 not found")
+}
+
 func (s *storageSuite) TestListVolumes(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/watcher"
 )
 
 // type APIClient represents the action API functionality.
@@ -60,6 +61,26 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// AddActionSchedules adds schedules on which actions are enqueued
+	// on units repeatedly.
+	AddActionSchedules(params.AddActionScheduleArgs) (params.ActionScheduleResults, error)
+
+	// ListActionSchedules returns all the action schedules in the
+	// model, without their history.
+	ListActionSchedules() ([]params.ActionSchedule, error)
+
+	// ActionSchedules returns the action schedules with the given ids,
+	// including the history of their runs.
+	ActionSchedules(params.ActionScheduleIds) (params.ActionScheduleResults, error)
+
+	// RemoveActionSchedules removes the action schedules with the
+	// given ids.
+	RemoveActionSchedules(params.ActionScheduleIds) (params.ErrorResults, error)
+
+	// WatchActionProgress returns a watcher that reports the progress
+	// messages logged by the action with the given id.
+	WatchActionProgress(actionId string) (watcher.StringsWatcher, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
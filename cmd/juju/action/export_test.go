@@ -98,3 +98,27 @@ func NewRunCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RunComman
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
+
+func NewScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &scheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewListSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listSchedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewShowScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &showScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &removeScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
@@ -17,6 +17,8 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
 	"github.com/juju/juju/jujuclient"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -130,6 +132,13 @@ type fakeAPIClient struct {
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       map[string]params.ActionSpec
+	schedules          []params.ActionSchedule
+	scheduleResults    []params.ActionScheduleResult
+	errorResults       []params.ErrorResult
+	addedSchedules     params.AddActionScheduleArgs
+	scheduleIds        params.ActionScheduleIds
+	progressMessages   []string
+	watchedActionIds   []string
 	apiVersion         int
 	apiErr             error
 }
@@ -218,3 +227,32 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) AddActionSchedules(args params.AddActionScheduleArgs) (params.ActionScheduleResults, error) {
+	c.addedSchedules = args
+	return params.ActionScheduleResults{Results: c.scheduleResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) ListActionSchedules() ([]params.ActionSchedule, error) {
+	return c.schedules, c.apiErr
+}
+
+func (c *fakeAPIClient) ActionSchedules(args params.ActionScheduleIds) (params.ActionScheduleResults, error) {
+	c.scheduleIds = args
+	return params.ActionScheduleResults{Results: c.scheduleResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	c.scheduleIds = args
+	return params.ErrorResults{Results: c.errorResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) WatchActionProgress(actionId string) (watcher.StringsWatcher, error) {
+	c.watchedActionIds = append(c.watchedActionIds, actionId)
+	if c.apiErr != nil {
+		return nil, c.apiErr
+	}
+	changes := make(chan []string, 1)
+	changes <- c.progressMessages
+	return watchertest.NewMockStringsWatcher(changes), nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// watchActionProgress starts watching the progress messages logged by
+// the action with the given id, writing each message to w as it arrives
+// with the given prefix. The returned function stops the watch and
+// must be called once the caller is no longer interested in progress.
+func watchActionProgress(api APIClient, actionId, prefix string, w io.Writer) (func(), error) {
+	watcher, err := api.WatchActionProgress(actionId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		write := func(messages []string) {
+			for _, m := range messages {
+				var msg params.ActionMessage
+				if err := json.Unmarshal([]byte(m), &msg); err != nil {
+					logger.Warningf("cannot decode action message %q: %v", m, err)
+					continue
+				}
+				fmt.Fprintf(w, "%s%s\n", prefix, formatActionMessage(msg))
+			}
+		}
+		for {
+			select {
+			case <-stop:
+				// Write out any change that is already waiting.
+				select {
+				case messages, ok := <-watcher.Changes():
+					if ok {
+						write(messages)
+					}
+				default:
+				}
+				return
+			case messages, ok := <-watcher.Changes():
+				if !ok {
+					return
+				}
+				write(messages)
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-done
+		watcher.Kill()
+		if err := watcher.Wait(); err != nil {
+			logger.Debugf("watching progress of action %s: %v", actionId, err)
+		}
+	}, nil
+}
+
+// formatActionMessage returns a single line representation of an
+// action progress message.
+func formatActionMessage(msg params.ActionMessage) string {
+	return fmt.Sprintf("%s %s", msg.Timestamp.UTC().Format(time.RFC3339), msg.Message)
+}
+
+// lockedWriter serialises writes to an io.Writer shared by several
+// progress watchers.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
@@ -74,6 +74,10 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+When --wait is used, progress messages logged by the action with the
+action-log hook tool are written to stderr as they arrive, prefixed with the
+unit that is running the action.
+
 Examples:
 
     juju run-action mysql/3 backup --wait
@@ -125,24 +129,31 @@ func (c *runCommand) Init(args []string) (err error) {
 	}
 
 	// Parse CLI key-value args if they exist.
-	c.args = make([][]string, 0)
-	for _, arg := range args[len(c.unitReceivers)+1:] {
+	c.args, err = parseActionArgs(args[len(c.unitReceivers)+1:])
+	return err
+}
+
+// parseActionArgs parses key.key.key...=value arguments into slices of
+// the form [key, key, key, ..., value].
+func parseActionArgs(args []string) ([][]string, error) {
+	result := make([][]string, 0)
+	for _, arg := range args {
 		thisArg := strings.SplitN(arg, "=", 2)
 		if len(thisArg) != 2 {
-			return errors.Errorf("argument %q must be of the form key...=value", arg)
+			return nil, errors.Errorf("argument %q must be of the form key...=value", arg)
 		}
 		keySlice := strings.Split(thisArg[0], ".")
 		// check each key for validity
 		for _, key := range keySlice {
 			if valid := nameRule.MatchString(key); !valid {
-				return errors.Errorf("key %q must start and end with lowercase alphanumeric, "+
+				return nil, errors.Errorf("key %q must start and end with lowercase alphanumeric, "+
 					"and contain only lowercase alphanumeric and hyphens", key)
 			}
 		}
-		// c.args={..., [key, key, key, key, value]}
-		c.args = append(c.args, append(keySlice, thisArg[1]))
+		// result={..., [key, key, key, key, value]}
+		result = append(result, append(keySlice, thisArg[1]))
 	}
-	return nil
+	return result, nil
 }
 
 func (c *runCommand) Run(ctx *cmd.Context) error {
@@ -151,58 +162,11 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	defer c.api.Close()
 
-	actionParams := map[string]interface{}{}
-	if c.paramsYAML.Path != "" {
-		b, err := c.paramsYAML.Read(ctx)
-		if err != nil {
-			return err
-		}
-
-		err = yaml.Unmarshal(b, &actionParams)
-		if err != nil {
-			return err
-		}
-
-		conformantParams, err := common.ConformYAML(actionParams)
-		if err != nil {
-			return err
-		}
-
-		betterParams, ok := conformantParams.(map[string]interface{})
-		if !ok {
-			return errors.New("params must contain a YAML map with string keys")
-		}
-
-		actionParams = betterParams
-	}
-
-	// If we had explicit args {..., [key, key, key, key, value], ...}
-	// then iterate and set params ..., key.key.key.key=value, ...
-	for _, argSlice := range c.args {
-		valueIndex := len(argSlice) - 1
-		keys := argSlice[:valueIndex]
-		value := argSlice[valueIndex]
-		cleansedValue := interface{}(value)
-		if !c.parseStrings {
-			err := yaml.Unmarshal([]byte(value), &cleansedValue)
-			if err != nil {
-				return err
-			}
-		}
-		// Insert the value in the map.
-		addValueToMap(keys, cleansedValue, actionParams)
-	}
-
-	conformantParams, err := common.ConformYAML(actionParams)
+	actionParams, err := buildActionParams(ctx, c.paramsYAML, c.args, c.parseStrings)
 	if err != nil {
 		return err
 	}
 
-	typedConformantParams, ok := conformantParams.(map[string]interface{})
-	if !ok {
-		return errors.Errorf("params must be a map, got %T", typedConformantParams)
-	}
-
 	actions := make([]params.Action, len(c.unitReceivers))
 	for i, unitReceiver := range c.unitReceivers {
 		if strings.HasSuffix(unitReceiver, "leader") {
@@ -280,6 +244,35 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		wait = time.NewTimer(c.wait.d)
 	}
 
+	// Stream progress messages while waiting, stopping the watchers
+	// before the results are written.
+	var stopWatching []func()
+	stopAll := func() {
+		for _, stop := range stopWatching {
+			stop()
+		}
+		stopWatching = nil
+	}
+	defer stopAll()
+	if c.api.BestAPIVersion() >= 5 {
+		stderr := &lockedWriter{w: ctx.Stderr}
+		for _, result := range results.Results {
+			tag, err := names.ParseActionTag(result.Action.Tag)
+			if err != nil {
+				return err
+			}
+			unitTag, err := names.ParseUnitTag(result.Action.Receiver)
+			if err != nil {
+				return err
+			}
+			stop, err := watchActionProgress(c.api, tag.Id(), unitTag.Id()+": ", stderr)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			stopWatching = append(stopWatching, stop)
+		}
+	}
+
 	for _, result := range results.Results {
 		tag, err := names.ParseActionTag(result.Action.Tag)
 		if err != nil {
@@ -298,6 +291,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		d["unit"] = unitTag.Id() // Formatted unit is nice to have.
 		out[result.Action.Receiver] = d
 	}
+	stopAll()
 	return c.out.Write(ctx, out)
 }
 
@@ -308,3 +302,60 @@ func (c *runCommand) ensureAPI() (err error) {
 	c.api, err = c.NewActionAPIClient()
 	return errors.Trace(err)
 }
+
+// buildActionParams builds the parameters for an action from the
+// YAML file and key...=value arguments given on the command line.
+func buildActionParams(ctx *cmd.Context, paramsYAML cmd.FileVar, args [][]string, parseStrings bool) (map[string]interface{}, error) {
+	actionParams := map[string]interface{}{}
+	if paramsYAML.Path != "" {
+		b, err := paramsYAML.Read(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = yaml.Unmarshal(b, &actionParams)
+		if err != nil {
+			return nil, err
+		}
+
+		conformantParams, err := common.ConformYAML(actionParams)
+		if err != nil {
+			return nil, err
+		}
+
+		betterParams, ok := conformantParams.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("params must contain a YAML map with string keys")
+		}
+
+		actionParams = betterParams
+	}
+
+	// If we had explicit args {..., [key, key, key, key, value], ...}
+	// then iterate and set params ..., key.key.key.key=value, ...
+	for _, argSlice := range args {
+		valueIndex := len(argSlice) - 1
+		keys := argSlice[:valueIndex]
+		value := argSlice[valueIndex]
+		cleansedValue := interface{}(value)
+		if !parseStrings {
+			err := yaml.Unmarshal([]byte(value), &cleansedValue)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// Insert the value in the map.
+		addValueToMap(keys, cleansedValue, actionParams)
+	}
+
+	conformantParams, err := common.ConformYAML(actionParams)
+	if err != nil {
+		return nil, err
+	}
+
+	typedConformantParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("params must be a map, got %T", typedConformantParams)
+	}
+	return actionParams, nil
+}
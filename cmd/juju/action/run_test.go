@@ -6,6 +6,7 @@ package action_test
 import (
 	"bytes"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd/cmdtesting"
@@ -463,3 +464,58 @@ func (s *RunSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+func (s *RunSuite) TestRunWaitShowsProgress(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		apiVersion: 5,
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+			Status: params.ActionCompleted,
+			Log: []params.ActionMessage{{
+				Timestamp: time.Date(2019, time.March, 1, 10, 0, 0, 0, time.UTC),
+				Message:   "copying files",
+			}},
+		}},
+		actionTagMatches: tagsForIdPrefix(validActionId, validActionTagString),
+		progressMessages: []string{
+			`{"timestamp":"2019-03-01T10:00:00Z","message":"copying files"}`,
+		},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin", validUnitId, "some-action", "--wait")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fakeClient.watchedActionIds, jc.DeepEquals, []string{validActionId})
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "mysql/0: 2019-03-01T10:00:00Z copying files\n")
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `
+  log:
+  - 2019-03-01T10:00:00Z copying files
+`[1:])
+}
+
+func (s *RunSuite) TestRunWaitOldControllerNoProgress(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		apiVersion: 4,
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+			Status: params.ActionCompleted,
+		}},
+		actionTagMatches: tagsForIdPrefix(validActionId, validActionTagString),
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin", validUnitId, "some-action", "--wait")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fakeClient.watchedActionIds, gc.HasLen, 0)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "")
+}
@@ -0,0 +1,422 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewScheduleCommand returns a command to schedule an action.
+func NewScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&scheduleCommand{})
+}
+
+// scheduleCommand adds a schedule on which an action is enqueued on a
+// unit repeatedly.
+type scheduleCommand struct {
+	ActionCommandBase
+	unit         string
+	actionName   string
+	cron         string
+	paramsYAML   cmd.FileVar
+	parseStrings bool
+	args         [][]string
+	out          cmd.Output
+}
+
+const scheduleDoc = `
+Schedule an action to be queued for execution on a unit repeatedly.
+
+The schedule is given with --cron, using the standard five-field cron
+format (minute, hour, day of month, month and day of week), or one of
+the descriptors @yearly, @monthly, @weekly, @daily, @hourly or
+"@every <duration>". Schedules are evaluated in UTC, unless prefixed
+with TZ=<location>.
+
+The controller queues the action each time it is due; the queued
+actions can be seen with 'juju show-action-schedule <ID>'. Params are
+given as for 'juju run-action', and are validated against the charm
+when the action is scheduled.
+
+Examples:
+
+    juju schedule-action mysql/0 backup --cron "0 3 * * *"
+    juju schedule-action mysql/0 backup --cron "@every 6h" out=out.tar.bz2
+    juju schedule-action mysql/0 backup --cron "TZ=Europe/London 30 2 * * 1-5"
+
+See also:
+    action-schedules
+    show-action-schedule
+    remove-action-schedule
+    run-action
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *scheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.StringVar(&c.cron, "cron", "", "The schedule on which to queue the action")
+	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
+	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+}
+
+// Info is part of the cmd.Command interface.
+func (c *scheduleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "schedule-action",
+		Args:    "<unit> <action name> --cron <schedule> [key.key.key...=value]",
+		Purpose: "Queue an action for execution on a schedule.",
+		Doc:     scheduleDoc,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *scheduleCommand) Init(args []string) (err error) {
+	switch len(args) {
+	case 0:
+		return errors.New("no unit specified")
+	case 1:
+		return errors.New("no action specified")
+	}
+	if !names.IsValidUnit(args[0]) {
+		return errors.Errorf("invalid unit name %q", args[0])
+	}
+	if !nameRule.MatchString(args[1]) {
+		return errors.Errorf("invalid action name %q", args[1])
+	}
+	if c.cron == "" {
+		return errors.New("no schedule specified, use --cron")
+	}
+	c.unit, c.actionName = args[0], args[1]
+	c.args, err = parseActionArgs(args[2:])
+	return err
+}
+
+// Run is part of the cmd.Command interface.
+func (c *scheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	actionParams, err := buildActionParams(ctx, c.paramsYAML, c.args, c.parseStrings)
+	if err != nil {
+		return err
+	}
+	results, err := api.AddActionSchedules(params.AddActionScheduleArgs{
+		Schedules: []params.AddActionScheduleArg{{
+			Receiver:   names.NewUnitTag(c.unit).String(),
+			Name:       c.actionName,
+			Parameters: actionParams,
+			Cron:       c.cron,
+		}},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return err
+	}
+	schedule := results.Results[0].Result
+	return c.out.Write(ctx, map[string]string{
+		"id":       schedule.Id,
+		"next-run": common.FormatTime(&schedule.NextRun, true),
+	})
+}
+
+// NewListSchedulesCommand returns a command to list action schedules.
+func NewListSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&listSchedulesCommand{})
+}
+
+// listSchedulesCommand lists the action schedules in the model.
+type listSchedulesCommand struct {
+	ActionCommandBase
+	isoTime bool
+	out     cmd.Output
+}
+
+const listSchedulesDoc = `
+List the actions that are scheduled to be queued on units, with their
+schedules and when they are next due.
+
+See also:
+    schedule-action
+    show-action-schedule
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *listSchedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.printTabular,
+	})
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+}
+
+// Info is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "action-schedules",
+		Purpose: "List scheduled actions.",
+		Doc:     listSchedulesDoc,
+		Aliases: []string{"list-action-schedules"},
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	schedules, err := api.ListActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(schedules) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No action schedules in the model.")
+		return nil
+	}
+	out := make([]scheduleOutput, len(schedules))
+	for i, schedule := range schedules {
+		out[i] = formatSchedule(schedule, c.isoTime)
+	}
+	return c.out.Write(ctx, out)
+}
+
+// printTabular prints the action schedules in tabular format.
+func (c *listSchedulesCommand) printTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.([]scheduleOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", "ID", "Unit", "Action", "Schedule", "Next run")
+	for _, schedule := range schedules {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			schedule.Id, schedule.Unit, schedule.Action, schedule.Cron, schedule.NextRun)
+	}
+	tw.Flush()
+	return nil
+}
+
+// NewShowScheduleCommand returns a command to show an action schedule.
+func NewShowScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&showScheduleCommand{})
+}
+
+// showScheduleCommand shows an action schedule and the history of
+// its runs.
+type showScheduleCommand struct {
+	ActionCommandBase
+	id      string
+	isoTime bool
+	out     cmd.Output
+}
+
+const showScheduleDoc = `
+Show the details of an action schedule, including the most recent times
+the action was due and the IDs of the actions queued then. The output of
+those actions can be seen with 'juju show-action-output <ID>'.
+
+See also:
+    action-schedules
+    show-action-output
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *showScheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+}
+
+// Info is part of the cmd.Command interface.
+func (c *showScheduleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-action-schedule",
+		Args:    "<schedule ID>",
+		Purpose: "Show an action schedule and its history.",
+		Doc:     showScheduleDoc,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *showScheduleCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no schedule ID specified")
+	case 1:
+		c.id = args[0]
+		return nil
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+}
+
+// Run is part of the cmd.Command interface.
+func (c *showScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.ActionSchedules(params.ActionScheduleIds{Ids: []string{c.id}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return err
+	}
+	return c.out.Write(ctx, formatSchedule(*results.Results[0].Result, c.isoTime))
+}
+
+// NewRemoveScheduleCommand returns a command to remove action
+// schedules.
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&removeScheduleCommand{})
+}
+
+// removeScheduleCommand removes action schedules.
+type removeScheduleCommand struct {
+	ActionCommandBase
+	ids []string
+}
+
+const removeScheduleDoc = `
+Remove action schedules, so their actions are no longer queued. Actions
+already queued by the schedules are not affected; use 'juju
+cancel-action' to cancel those.
+
+Examples:
+
+    juju remove-action-schedule 3
+    juju remove-action-schedule 3 4
+
+See also:
+    action-schedules
+    cancel-action
+`
+
+// Info is part of the cmd.Command interface.
+func (c *removeScheduleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-action-schedule",
+		Args:    "<schedule ID> [<schedule ID>...]",
+		Purpose: "Remove action schedules.",
+		Doc:     removeScheduleDoc,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *removeScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule IDs specified")
+	}
+	c.ids = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *removeScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.RemoveActionSchedules(params.ActionScheduleIds{Ids: c.ids})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(c.ids) {
+		return errors.Errorf("expected %d results, got %d", len(c.ids), len(results.Results))
+	}
+	var failed []string
+	for i, result := range results.Results {
+		if result.Error != nil {
+			ctx.Infof("cannot remove action schedule %s: %v", c.ids[i], result.Error)
+			failed = append(failed, c.ids[i])
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to remove action schedules %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+type scheduleOutput struct {
+	Id         string                 `yaml:"id" json:"id"`
+	Unit       string                 `yaml:"unit" json:"unit"`
+	Action     string                 `yaml:"action" json:"action"`
+	Parameters map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Cron       string                 `yaml:"schedule" json:"schedule"`
+	Created    string                 `yaml:"created" json:"created"`
+	NextRun    string                 `yaml:"next-run" json:"next-run"`
+	History    []scheduleRunOutput    `yaml:"history,omitempty" json:"history,omitempty"`
+}
+
+type scheduleRunOutput struct {
+	Time   string `yaml:"time" json:"time"`
+	Action string `yaml:"action-id,omitempty" json:"action-id,omitempty"`
+	Error  string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// formatSchedule converts an action schedule to its output form.
+func formatSchedule(schedule params.ActionSchedule, isoTime bool) scheduleOutput {
+	out := scheduleOutput{
+		Id:         schedule.Id,
+		Unit:       schedule.Receiver,
+		Action:     schedule.Name,
+		Parameters: schedule.Parameters,
+		Cron:       schedule.Cron,
+		Created:    common.FormatTime(&schedule.Created, isoTime),
+		NextRun:    common.FormatTime(&schedule.NextRun, isoTime),
+	}
+	if tag, err := names.ParseUnitTag(schedule.Receiver); err == nil {
+		out.Unit = tag.Id()
+	}
+	for _, run := range schedule.History {
+		runOut := scheduleRunOutput{
+			Time:  common.FormatTime(&run.Time, isoTime),
+			Error: run.Error,
+		}
+		if tag, err := names.ParseActionTag(run.ActionTag); err == nil {
+			runOut.Action = tag.Id()
+		}
+		out.History = append(out.History, runOut)
+	}
+	return out
+}
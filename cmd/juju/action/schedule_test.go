@@ -0,0 +1,173 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ScheduleSuite struct {
+	BaseActionSuite
+	client *fakeAPIClient
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func (s *ScheduleSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.store.Models["ctrl"].CurrentModel = "admin/admin"
+	s.client = &fakeAPIClient{apiVersion: 4}
+	restore := s.patchAPIClient(s.client)
+	s.AddCleanup(func(*gc.C) { restore() })
+}
+
+func (s *ScheduleSuite) schedule() params.ActionSchedule {
+	return params.ActionSchedule{
+		Id:         "1",
+		Receiver:   "unit-mysql-0",
+		Name:       "backup",
+		Parameters: map[string]interface{}{"out": "db.tar.bz2"},
+		Cron:       "0 3 * * *",
+		Created:    time.Date(2019, 4, 1, 10, 0, 0, 0, time.UTC),
+		NextRun:    time.Date(2019, 4, 2, 3, 0, 0, 0, time.UTC),
+	}
+}
+
+func (s *ScheduleSuite) TestScheduleInit(c *gc.C) {
+	for i, test := range []struct {
+		args   []string
+		errMsg string
+	}{{
+		errMsg: "no unit specified",
+	}, {
+		args:   []string{"mysql/0"},
+		errMsg: "no action specified",
+	}, {
+		args:   []string{"mysql", "backup", "--cron", "@daily"},
+		errMsg: `invalid unit name "mysql"`,
+	}, {
+		args:   []string{"mysql/0", "Backup!", "--cron", "@daily"},
+		errMsg: `invalid action name "Backup!"`,
+	}, {
+		args:   []string{"mysql/0", "backup"},
+		errMsg: "no schedule specified, use --cron",
+	}, {
+		args:   []string{"mysql/0", "backup", "--cron", "@daily", "out"},
+		errMsg: `argument "out" must be of the form key...=value`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, action.NewScheduleCommandForTest(s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.errMsg)
+	}
+}
+
+func (s *ScheduleSuite) TestSchedule(c *gc.C) {
+	schedule := s.schedule()
+	s.client.scheduleResults = []params.ActionScheduleResult{{Result: &schedule}}
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewScheduleCommandForTest(s.store),
+		"mysql/0", "backup", "--cron", "0 3 * * *", "out=db.tar.bz2", "level=9",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.addedSchedules, jc.DeepEquals, params.AddActionScheduleArgs{
+		Schedules: []params.AddActionScheduleArg{{
+			Receiver:   "unit-mysql-0",
+			Name:       "backup",
+			Parameters: map[string]interface{}{"out": "db.tar.bz2", "level": 9},
+			Cron:       "0 3 * * *",
+		}},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+id: "1"
+next-run: 2019-04-02 03:00:00Z
+`[1:])
+}
+
+func (s *ScheduleSuite) TestScheduleError(c *gc.C) {
+	s.client.scheduleResults = []params.ActionScheduleResult{{
+		Error: &params.Error{Message: `cannot schedule action "backup" on unit mysql/0: boom`},
+	}}
+	_, err := cmdtesting.RunCommand(c, action.NewScheduleCommandForTest(s.store),
+		"mysql/0", "backup", "--cron", "@daily",
+	)
+	c.Assert(err, gc.ErrorMatches, `cannot schedule action "backup" on unit mysql/0: boom`)
+}
+
+func (s *ScheduleSuite) TestListSchedules(c *gc.C) {
+	s.client.schedules = []params.ActionSchedule{s.schedule()}
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+ID  Unit     Action  Schedule   Next run
+1   mysql/0  backup  0 3 * * *  2019-04-02 03:00:00Z
+`[1:])
+}
+
+func (s *ScheduleSuite) TestListSchedulesNone(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No action schedules in the model.\n")
+}
+
+func (s *ScheduleSuite) TestShowSchedule(c *gc.C) {
+	schedule := s.schedule()
+	schedule.History = []params.ActionScheduleRun{{
+		Time:      time.Date(2019, 4, 1, 3, 0, 0, 0, time.UTC),
+		ActionTag: validActionTagString,
+	}, {
+		Time:  time.Date(2019, 3, 31, 3, 0, 0, 0, time.UTC),
+		Error: `unit "mysql/0" not found`,
+	}}
+	s.client.scheduleResults = []params.ActionScheduleResult{{Result: &schedule}}
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewShowScheduleCommandForTest(s.store), "1", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.scheduleIds.Ids, jc.DeepEquals, []string{"1"})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+id: "1"
+unit: mysql/0
+action: backup
+parameters:
+  out: db.tar.bz2
+schedule: 0 3 * * *
+created: 2019-04-01 10:00:00Z
+next-run: 2019-04-02 03:00:00Z
+history:
+- time: 2019-04-01 03:00:00Z
+  action-id: `+validActionId+`
+- time: 2019-03-31 03:00:00Z
+  error: unit "mysql/0" not found
+`[1:])
+}
+
+func (s *ScheduleSuite) TestShowScheduleNotFound(c *gc.C) {
+	s.client.scheduleResults = []params.ActionScheduleResult{{
+		Error: &params.Error{Message: `action schedule "42" not found`, Code: params.CodeNotFound},
+	}}
+	_, err := cmdtesting.RunCommand(c, action.NewShowScheduleCommandForTest(s.store), "42")
+	c.Assert(err, gc.ErrorMatches, `action schedule "42" not found`)
+}
+
+func (s *ScheduleSuite) TestRemoveSchedules(c *gc.C) {
+	s.client.errorResults = []params.ErrorResult{{}, {
+		Error: &params.Error{Message: `action schedule "42" not found`},
+	}}
+	ctx, err := cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store), "1", "42")
+	c.Assert(err, gc.ErrorMatches, "failed to remove action schedules 42")
+	c.Assert(s.client.scheduleIds.Ids, jc.DeepEquals, []string{"1", "42"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "cannot remove action schedule 42: action schedule \"42\" not found\n")
+}
+
+func (s *ScheduleSuite) TestRemoveSchedulesNoIds(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store))
+	c.Assert(err, gc.ErrorMatches, "no schedule IDs specified")
+}
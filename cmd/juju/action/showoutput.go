@@ -28,6 +28,7 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	watch       bool
 }
 
 const showOutputDoc = `
@@ -39,6 +40,11 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+To follow the progress of a running action, use the --watch option.  Progress
+messages logged by the action with the action-log hook tool are written to
+stderr as they arrive, until the action completes.  Unless a positive --wait
+duration is also given, --watch waits indefinitely.
 `
 
 // Set up the output.
@@ -46,6 +52,7 @@ func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.BoolVar(&c.watch, "watch", false, "Show progress messages until the action completes")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -84,6 +91,10 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.watch && waitDur < 0 {
+		// Watching implies waiting for the action to complete.
+		waitDur = 0
+	}
 
 	api, err := c.NewActionAPIClient()
 	if err != nil {
@@ -105,7 +116,25 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 		wait = time.NewTimer(waitDur)
 	}
 
+	var stopWatching func()
+	if c.watch {
+		if api.BestAPIVersion() < 5 {
+			return errors.New("--watch is not supported by this controller")
+		}
+		actionTag, err := getActionTagByPrefix(api, c.requestedId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		stopWatching, err = watchActionProgress(api, actionTag.Id(), "", ctx.Stderr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	result, err := GetActionResult(api, c.requestedId, wait)
+	if stopWatching != nil {
+		stopWatching()
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -205,6 +234,13 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
+	if len(result.Log) != 0 {
+		logs := make([]string, len(result.Log))
+		for i, msg := range result.Log {
+			logs[i] = formatActionMessage(msg)
+		}
+		response["log"] = logs
+	}
 
 	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
 		return response
@@ -330,3 +330,54 @@ func makeFakeClient(
 	}
 	return client
 }
+
+func (s *ShowOutputSuite) TestRunWatch(c *gc.C) {
+	client := makeFakeClient(
+		0, 10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status: "completed",
+			Log: []params.ActionMessage{{
+				Timestamp: time.Date(2019, time.March, 1, 10, 0, 0, 0, time.UTC),
+				Message:   "copying files",
+			}},
+		}},
+		params.ActionsByNames{},
+		"",
+	)
+	client.apiVersion = 5
+	client.progressMessages = []string{
+		`{"timestamp":"2019-03-01T10:00:00Z","message":"copying files"}`,
+	}
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--watch")
+	c.Assert(err, gc.IsNil)
+	c.Check(client.watchedActionIds, gc.DeepEquals, []string{validActionId})
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "2019-03-01T10:00:00Z copying files\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+log:
+- 2019-03-01T10:00:00Z copying files
+status: completed
+`[1:])
+}
+
+func (s *ShowOutputSuite) TestRunWatchNotSupported(c *gc.C) {
+	client := makeFakeClient(
+		0, 10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{Status: "completed"}},
+		params.ActionsByNames{},
+		"",
+	)
+	client.apiVersion = 4
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--watch")
+	c.Assert(err, gc.ErrorMatches, "--watch is not supported by this controller")
+	c.Check(client.watchedActionIds, gc.HasLen, 0)
+}
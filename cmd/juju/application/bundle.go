@@ -117,6 +117,7 @@ func deployBundle(
 	ctx *cmd.Context,
 	bundleStorage map[string]map[string]storage.Constraints,
 	bundleDevices map[string]map[string]devices.Constraints,
+	bundleExposedEndpoints map[string]map[string]params.ExposedEndpoint,
 	dryRun bool,
 	useExistingMachines bool,
 	bundleMachines map[string]string,
@@ -130,7 +131,7 @@ func deployBundle(
 	}
 
 	// TODO: move bundle parsing and checking into the handler.
-	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleURL, bundleStorage, bundleDevices)
+	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleURL, bundleStorage, bundleDevices, bundleExposedEndpoints)
 	if err := h.makeModel(useExistingMachines, bundleMachines); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -191,6 +192,10 @@ type bundleHandler struct {
 	// in the bundle itself.
 	bundleDevices map[string]map[string]devices.Constraints
 
+	// bundleExposedEndpoints maps application names to the spaces and
+	// CIDRs their endpoints are exposed to, keyed on endpoint name.
+	bundleExposedEndpoints map[string]map[string]params.ExposedEndpoint
+
 	// ctx is the command context, which is used to output messages to the
 	// user, so that the user can keep track of the bundle deployment
 	// progress.
@@ -236,6 +241,7 @@ func makeBundleHandler(
 	bundleURL *charm.URL,
 	bundleStorage map[string]map[string]storage.Constraints,
 	bundleDevices map[string]map[string]devices.Constraints,
+	bundleExposedEndpoints map[string]map[string]params.ExposedEndpoint,
 ) *bundleHandler {
 	applications := set.NewStrings()
 	for name := range data.Applications {
@@ -256,6 +262,8 @@ func makeBundleHandler(
 		unitStatus:    make(map[string]string),
 		macaroons:     make(map[*charm.URL]*macaroon.Macaroon),
 		channels:      make(map[*charm.URL]csparams.Channel),
+
+		bundleExposedEndpoints: bundleExposedEndpoints,
 	}
 }
 
@@ -984,7 +992,8 @@ func (h *bundleHandler) exposeApplication(change *bundlechanges.ExposeChange) er
 	}
 
 	application := resolve(change.Params.Application, h.results)
-	if err := h.api.Expose(application); err != nil {
+	exposedEndpoints := h.bundleExposedEndpoints[change.Params.Application]
+	if err := h.api.Expose(application, exposedEndpoints); err != nil {
 		return errors.Annotatef(err, "cannot expose application %s", application)
 	}
 	return nil
@@ -1246,6 +1255,63 @@ type bundleOverlayValueExists struct {
 	Applications map[string]map[string]interface{} `yaml:"applications"`
 }
 
+// bundleExposedEndpointsData holds the parts of a bundle that
+// describe which spaces and CIDRs the endpoints of its exposed
+// applications are exposed to. The charm package does not know about
+// these, so they are read directly from the bundle YAML.
+type bundleExposedEndpointsData struct {
+	Applications map[string]struct {
+		ExposedEndpoints map[string]struct {
+			ExposeToSpaces []string `yaml:"expose-to-spaces"`
+			ExposeToCIDRs  []string `yaml:"expose-to-cidrs"`
+		} `yaml:"exposed-endpoints"`
+	} `yaml:"applications"`
+}
+
+// readBundleExposedEndpoints returns the expose settings found in
+// the given bundle files, keyed on application name and then endpoint
+// name. Settings in later files replace those of earlier ones. Bundle
+// archives are skipped, as are files that have already been rejected
+// when reading the bundle itself.
+func readBundleExposedEndpoints(bundleFiles ...string) (map[string]map[string]params.ExposedEndpoint, error) {
+	result := make(map[string]map[string]params.ExposedEndpoint)
+	for _, bundleFile := range bundleFiles {
+		path, err := utils.NormalizePath(bundleFile)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			path = filepath.Join(path, "bundle.yaml")
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Trace(err)
+		}
+		var data bundleExposedEndpointsData
+		if err := yaml.Unmarshal(content, &data); err != nil {
+			logger.Debugf("not reading expose settings from %q: %v", bundleFile, err)
+			continue
+		}
+		for name, app := range data.Applications {
+			if len(app.ExposedEndpoints) == 0 {
+				continue
+			}
+			exposedEndpoints := make(map[string]params.ExposedEndpoint)
+			for endpoint, exposed := range app.ExposedEndpoints {
+				exposedEndpoints[endpoint] = params.ExposedEndpoint{
+					ExposeToSpaces: exposed.ExposeToSpaces,
+					ExposeToCIDRs:  exposed.ExposeToCIDRs,
+				}
+			}
+			result[name] = exposedEndpoints
+		}
+	}
+	return result, nil
+}
+
 func processBundleOverlay(data *charm.BundleData, bundleOverlayFiles ...string) error {
 	for _, filename := range bundleOverlayFiles {
 		bundleOverlayFile, err := utils.NormalizePath(filename)
@@ -1230,6 +1230,28 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundleExpose(c *gc.C) {
 	c.Check(stdOut, gc.Equals, "") // Nothing to do.
 }
 
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleExposedEndpoints(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-42", "wordpress")
+	err := s.DeployBundleYAML(c, `
+        applications:
+            wordpress:
+                charm: wordpress
+                num_units: 1
+                expose: true
+                exposed-endpoints:
+                    url:
+                        expose-to-cidrs: [10.0.0.0/8]
+    `)
+	c.Assert(err, jc.ErrorIsNil)
+
+	app, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleApplicationUpgradeFailure(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
 
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/model"
 )
 
 var usageGetConstraintsSummary = `
@@ -58,6 +59,9 @@ This command requires that the application to have at least one unit. To apply
 constraints to
 the first unit set them at the model level or pass them as an argument
 when deploying.
+If the active branch is not "master", the constraints are set under that
+branch, and are only used for new machines provisioned for units tracking
+it until the branch is committed.
 
 Examples:
     juju set-constraints mysql mem=8G cores=4
@@ -77,6 +81,7 @@ type applicationConstraintsAPI interface {
 	Close() error
 	GetConstraints(...string) ([]constraints.Value, error)
 	SetConstraints(string, constraints.Value) error
+	SetBranchConstraints(string, string, constraints.Value) error
 }
 
 type applicationConstraintsCommand struct {
@@ -190,6 +195,14 @@ func (c *applicationSetConstraintsCommand) Run(_ *cmd.Context) (err error) {
 	}
 	defer apiclient.Close()
 
-	err = apiclient.SetConstraints(c.ApplicationName, c.Constraints)
+	branchName, err := c.ActiveBranch()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if branchName != "" && branchName != model.GenerationMaster {
+		err = apiclient.SetBranchConstraints(branchName, c.ApplicationName, c.Constraints)
+	} else {
+		err = apiclient.SetConstraints(c.ApplicationName, c.Constraints)
+	}
 	return block.ProcessBlockedError(err, block.BlockChange)
 }
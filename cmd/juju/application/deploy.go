@@ -58,7 +58,7 @@ type ApplicationAPI interface {
 	AddMachines(machineParams []apiparams.AddMachineParams) ([]apiparams.AddMachinesResult, error)
 	AddRelation(endpoints, viaCIDRs []string) (*apiparams.AddRelationResults, error)
 	AddUnits(application.AddUnitsParams) ([]string, error)
-	Expose(application string) error
+	Expose(application string, exposedEndpoints map[string]apiparams.ExposedEndpoint) error
 	GetAnnotations(tags []string) ([]apiparams.AnnotationsGetResult, error)
 	GetConfig(branchName string, appNames ...string) ([]map[string]interface{}, error)
 	GetConstraints(appNames ...string) ([]constraints.Value, error)
@@ -816,6 +816,7 @@ var getModelConfig = func(api ModelConfigGetter) (*config.Config, error) {
 func (c *DeployCommand) deployBundle(
 	ctx *cmd.Context,
 	filePath string,
+	bundleFile string,
 	data *charm.BundleData,
 	bundleURL *charm.URL,
 	channel params.Channel,
@@ -864,6 +865,15 @@ func (c *DeployCommand) deployBundle(
 		}
 	}
 
+	bundleFiles := c.BundleOverlayFile
+	if bundleFile != "" {
+		bundleFiles = append([]string{bundleFile}, bundleFiles...)
+	}
+	exposedEndpoints, err := readBundleExposedEndpoints(bundleFiles...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	// TODO(ericsnow) Do something with the CS macaroons that were returned?
 	// Deploying bundles does not allow the use force, it's expected that the
 	// bundle is correct and therefore the charms are also.
@@ -877,6 +887,7 @@ func (c *DeployCommand) deployBundle(
 		ctx,
 		bundleStorage,
 		bundleDevices,
+		exposedEndpoints,
 		c.DryRun,
 		c.UseExisting,
 		c.BundleMachines,
@@ -1317,6 +1328,7 @@ func (c *DeployCommand) maybeReadLocalBundle(ctx *cmd.Context) (deployFn, error)
 		return errors.Trace(c.deployBundle(
 			ctx,
 			bundleDir,
+			bundleFile,
 			bundleData,
 			nil,
 			c.Channel,
@@ -1479,6 +1491,7 @@ func (c *DeployCommand) maybeReadCharmstoreBundleFn(apiRoot DeployAPI) func() (d
 			return errors.Trace(c.deployBundle(
 				ctx,
 				"", // filepath
+				"", // bundleFile
 				data,
 				bundleURL,
 				channel,
@@ -288,3 +288,27 @@ func (c charmstoreClientToTestcharmsClientShim) WithChannel(channel params.Chann
 	client := c.Client.WithChannel(channel)
 	return charmstoreClientToTestcharmsClientShim{client}
 }
+
+func NewUnitStateCommandForTest(api UnitStateAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &unitStateCommand{newAPIFunc: func() (UnitStateAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewShowUpgradeCommandForTest(api ShowUpgradeAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showUpgradeCommand{newAPIFunc: func() (ShowUpgradeAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewSetRefreshPolicyCommandForTest(api SetRefreshPolicyAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &setRefreshPolicyCommand{newAPIFunc: func() (SetRefreshPolicyAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
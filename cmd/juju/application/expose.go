@@ -4,10 +4,15 @@
 package application
 
 import (
+	"net"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -20,8 +25,23 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+By default the ports opened by the application's units are accessible
+from anywhere. Access can be restricted to a comma-separated list of
+CIDRs with --to-cidrs, or to the subnets of a comma-separated list of
+spaces with --to-spaces. The restriction applies to all of the
+application's endpoints unless a comma-separated list of endpoints is
+given with --endpoints. Running expose again replaces any previous
+restrictions; running it without restrictions allows access from
+anywhere again.
+
+Ports are currently not opened per endpoint, so the ports opened by an
+application are accessible from the combination of the CIDRs and spaces
+of all of its exposed endpoints.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to-cidrs 10.0.0.0/8,192.168.0.0/16
+    juju expose wordpress --endpoints website --to-spaces public
 
 See also: 
     unexpose`[1:]
@@ -35,6 +55,12 @@ func NewExposeCommand() modelcmd.ModelCommand {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+
+	endpoints string
+	toSpaces  string
+	toCIDRs   string
+
+	ExposedEndpoints map[string]params.ExposedEndpoint
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -46,17 +72,64 @@ func (c *exposeCommand) Info() *cmd.Info {
 	})
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.endpoints, "endpoints", "", "Comma-separated list of endpoints the restrictions apply to")
+	f.StringVar(&c.toSpaces, "to-spaces", "", "Comma-separated list of spaces that can access the application")
+	f.StringVar(&c.toCIDRs, "to-cidrs", "", "Comma-separated list of CIDRs that can access the application")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
-	return cmd.CheckEmpty(args[1:])
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return err
+	}
+
+	spaces := splitCommaList(c.toSpaces)
+	cidrs := splitCommaList(c.toCIDRs)
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	endpoints := splitCommaList(c.endpoints)
+	if len(spaces) == 0 && len(cidrs) == 0 {
+		if len(endpoints) > 0 {
+			return errors.New("--endpoints requires --to-spaces or --to-cidrs")
+		}
+		return nil
+	}
+	if len(endpoints) == 0 {
+		endpoints = []string{""}
+	}
+	c.ExposedEndpoints = make(map[string]params.ExposedEndpoint)
+	for _, endpoint := range endpoints {
+		c.ExposedEndpoints[endpoint] = params.ExposedEndpoint{
+			ExposeToSpaces: spaces,
+			ExposeToCIDRs:  cidrs,
+		}
+	}
+	return nil
+}
+
+// splitCommaList returns the non-empty elements of the
+// given comma-separated list.
+func splitCommaList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
 }
 
 type applicationExposeAPI interface {
 	Close() error
-	Expose(applicationName string) error
+	Expose(applicationName string, exposedEndpoints map[string]params.ExposedEndpoint) error
 	Unexpose(applicationName string) error
 }
 
@@ -76,5 +149,5 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	return block.ProcessBlockedError(client.Expose(c.ApplicationName, c.ExposedEndpoints), block.BlockChange)
 }
@@ -9,8 +9,11 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 )
@@ -64,3 +67,61 @@ func (s *ExposeSuite) TestBlockExpose(c *gc.C) {
 	err := runExpose(c, "some-application-name")
 	s.AssertBlocked(c, err, ".*TestBlockExpose.*")
 }
+
+func (s *ExposeSuite) TestExposeToCIDRs(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "some-application-name"})
+
+	err := runExpose(c, "some-application-name", "--endpoints", "server", "--to-cidrs", "10.0.0.0/8,192.168.0.0/16")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name")
+
+	app, err := s.State.Application("some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+	})
+
+	// A plain expose removes the restrictions again.
+	err = runExpose(c, "some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.ExposedEndpoints(), gc.HasLen, 0)
+}
+
+func (s *ExposeSuite) TestExposeInit(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		expected map[string]params.ExposedEndpoint
+		err      string
+	}{{
+		args: []string{"app"},
+	}, {
+		args: []string{"app", "--to-spaces", "dmz,public"},
+		expected: map[string]params.ExposedEndpoint{
+			"": {ExposeToSpaces: []string{"dmz", "public"}},
+		},
+	}, {
+		args: []string{"app", "--endpoints", "a,b", "--to-cidrs", "10.0.0.0/8"},
+		expected: map[string]params.ExposedEndpoint{
+			"a": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+			"b": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		},
+	}, {
+		args: []string{"app", "--to-cidrs", "10.0.0.0"},
+		err:  `CIDR "10.0.0.0" not valid`,
+	}, {
+		args: []string{"app", "--endpoints", "a"},
+		err:  "--endpoints requires --to-spaces or --to-cidrs",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		var command exposeCommand
+		err := cmdtesting.InitCommand(modelcmd.Wrap(&command), test.args)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(command.ExposedEndpoints, jc.DeepEquals, test.expected)
+	}
+}
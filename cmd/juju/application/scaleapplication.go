@@ -44,6 +44,11 @@ Scale a Kubernetes application by specifying how many units there should be.
 The new number of units can be greater or less than the current number, thus
 allowing both scale up and scale down.
 
+An application scaled by a horizontal pod autoscaler, declared by its charm
+or its kubernetes-autoscaling-* config, cannot be scaled this way; its scale
+is bounded by the kubernetes-autoscaling-min-replicas and
+kubernetes-autoscaling-max-replicas config instead.
+
 Examples:
 
     juju scale-application mariadb 2
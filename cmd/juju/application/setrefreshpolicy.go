@@ -0,0 +1,176 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	coreapplication "github.com/juju/juju/core/application"
+)
+
+const setRefreshPolicyDoc = `
+Sets the policy by which an application's charm is automatically upgraded
+to newer revisions published in the charm store channel it tracks.
+
+Newer revisions are found by the controller's periodic charm revision
+check. The application is only upgraded while its maintenance window is
+open. The window opens on the schedule given by --window, in cron format
+("minute hour day-of-month month day-of-week") and UTC, and stays open
+for --duration.
+
+With --patch-only, the application is only upgraded if the new charm's
+version differs from the deployed charm's version in its patch number
+alone; e.g. from 1.2.3 to 1.2.4, but not to 1.3.0.
+
+With --canary-branch, upgrades are first staged on the named model branch.
+The whole application is upgraded, in a later maintenance window, once
+all of its units tracking the branch are healthy on the new charm. The
+branch must already exist; see "juju add-branch" and "juju track".
+
+Only the charm is upgraded; the application's resources are not refreshed
+by the policy. Upgrades to charm revisions that declare different resources
+are skipped, and must be made with "juju upgrade-charm".
+
+Each upgrade made under the policy is recorded in the application's status
+history. The policy, and the last action taken under it, are shown by
+"juju show-application".
+
+Examples:
+    juju set-refresh-policy mysql --window "0 2 * * SAT" --duration 2h
+    juju set-refresh-policy mysql --window "30 1 * * *" --duration 1h --patch-only
+    juju set-refresh-policy mysql --window "0 3 * * *" --duration 3h --canary-branch canary
+    juju set-refresh-policy mysql --remove
+
+See also:
+    show-application
+    upgrade-charm
+    add-branch
+`
+
+// NewSetRefreshPolicyCommand returns a command that sets the charm
+// refresh policy of an application.
+func NewSetRefreshPolicyCommand() cmd.Command {
+	c := &setRefreshPolicyCommand{}
+	c.newAPIFunc = func() (SetRefreshPolicyAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+// SetRefreshPolicyAPI defines the API methods that the set-refresh-policy
+// command uses.
+type SetRefreshPolicyAPI interface {
+	Close() error
+	BestAPIVersion() int
+	SetCharmRefreshPolicy(string, *params.CharmRefreshPolicy) error
+}
+
+// setRefreshPolicyCommand sets the charm refresh policy of an application.
+type setRefreshPolicyCommand struct {
+	modelcmd.ModelCommandBase
+
+	application  string
+	window       string
+	duration     time.Duration
+	patchOnly    bool
+	canaryBranch string
+	remove       bool
+	newAPIFunc   func() (SetRefreshPolicyAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *setRefreshPolicyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-refresh-policy",
+		Args:    "<application name>",
+		Purpose: "Sets the policy for automatically upgrading an application's charm.",
+		Doc:     setRefreshPolicyDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setRefreshPolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.window, "window", "", "Schedule on which the maintenance window opens, in cron format")
+	f.DurationVar(&c.duration, "duration", 0, "How long the maintenance window stays open")
+	f.BoolVar(&c.patchOnly, "patch-only", false, "Only upgrade to charms whose version differs in its patch number")
+	f.StringVar(&c.canaryBranch, "canary-branch", "", "Model branch on which to stage upgrades first")
+	f.BoolVar(&c.remove, "remove", false, "Remove the application's refresh policy")
+}
+
+// Init implements Command.Init.
+func (c *setRefreshPolicyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("an application name must be supplied")
+	}
+	c.application = args[0]
+	if !names.IsValidApplication(c.application) {
+		return errors.NotValidf("application name %q", c.application)
+	}
+	if c.remove {
+		if c.window != "" || c.duration != 0 || c.patchOnly || c.canaryBranch != "" {
+			return errors.New("--remove cannot be used with other policy options")
+		}
+		return cmd.CheckEmpty(args[1:])
+	}
+	if c.window == "" {
+		return errors.New("--window must be specified")
+	}
+	if c.duration == 0 {
+		return errors.New("--duration must be specified")
+	}
+	policy := coreapplication.RefreshPolicy{
+		Window:       c.window,
+		Duration:     c.duration,
+		PatchOnly:    c.patchOnly,
+		CanaryBranch: c.canaryBranch,
+	}
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *setRefreshPolicyCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if v := client.BestAPIVersion(); v < 13 {
+		return errors.NotSupportedf("charm refresh policies on API server version %v", v)
+	}
+
+	var policy *params.CharmRefreshPolicy
+	if !c.remove {
+		policy = &params.CharmRefreshPolicy{
+			Window:       c.window,
+			Duration:     c.duration,
+			PatchOnly:    c.patchOnly,
+			CanaryBranch: c.canaryBranch,
+		}
+	}
+	if err := client.SetCharmRefreshPolicy(c.application, policy); err != nil {
+		return errors.Trace(err)
+	}
+	if c.remove {
+		ctx.Infof("Removed refresh policy for application %q", c.application)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type SetRefreshPolicySuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+
+	mockAPI *mockSetRefreshPolicyAPI
+}
+
+var _ = gc.Suite(&SetRefreshPolicySuite{})
+
+func (s *SetRefreshPolicySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	s.mockAPI = &mockSetRefreshPolicyAPI{version: 13}
+}
+
+func (s *SetRefreshPolicySuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "an application name must be supplied",
+	}, {
+		args: []string{"mysql/0"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "--duration", "2h"},
+		err:  "--window must be specified",
+	}, {
+		args: []string{"mysql", "--window", "0 2 * * SAT"},
+		err:  "--duration must be specified",
+	}, {
+		args: []string{"mysql", "--window", "whenever", "--duration", "2h"},
+		err:  `maintenance window: schedule "whenever" with 1 fields \(expected 5\) not valid`,
+	}, {
+		args: []string{"mysql", "--remove", "--patch-only"},
+		err:  "--remove cannot be used with other policy options",
+	}, {
+		args: []string{"mysql", "wordpress", "--remove"},
+		err:  `unrecognized args: \["wordpress"\]`,
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		_, err := cmdtesting.RunCommand(c, application.NewSetRefreshPolicyCommandForTest(s.mockAPI, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Assert(s.mockAPI.called, jc.IsFalse)
+}
+
+func (s *SetRefreshPolicySuite) TestSetRefreshPolicy(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, application.NewSetRefreshPolicyCommandForTest(s.mockAPI, s.store),
+		"mysql", "--window", "0 2 * * SAT", "--duration", "2h", "--patch-only", "--canary-branch", "canary",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.called, jc.IsTrue)
+	c.Assert(s.mockAPI.application, gc.Equals, "mysql")
+	c.Assert(s.mockAPI.policy, jc.DeepEquals, &params.CharmRefreshPolicy{
+		Window:       "0 2 * * SAT",
+		Duration:     2 * time.Hour,
+		PatchOnly:    true,
+		CanaryBranch: "canary",
+	})
+}
+
+func (s *SetRefreshPolicySuite) TestRemoveRefreshPolicy(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, application.NewSetRefreshPolicyCommandForTest(s.mockAPI, s.store), "mysql", "--remove")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Removed refresh policy for application \"mysql\"\n")
+	c.Assert(s.mockAPI.called, jc.IsTrue)
+	c.Assert(s.mockAPI.policy, gc.IsNil)
+}
+
+func (s *SetRefreshPolicySuite) TestSetRefreshPolicyError(c *gc.C) {
+	s.mockAPI.err = &params.Error{Message: `branch "canary" not found`, Code: params.CodeNotFound}
+	_, err := cmdtesting.RunCommand(c, application.NewSetRefreshPolicyCommandForTest(s.mockAPI, s.store),
+		"mysql", "--window", "0 2 * * SAT", "--duration", "2h", "--canary-branch", "canary",
+	)
+	c.Assert(err, gc.ErrorMatches, `branch "canary" not found`)
+}
+
+func (s *SetRefreshPolicySuite) TestSetRefreshPolicyOldAPI(c *gc.C) {
+	s.mockAPI.version = 12
+	_, err := cmdtesting.RunCommand(c, application.NewSetRefreshPolicyCommandForTest(s.mockAPI, s.store), "mysql", "--remove")
+	c.Assert(err, gc.ErrorMatches, "charm refresh policies on API server version 12 not supported")
+	c.Assert(s.mockAPI.called, jc.IsFalse)
+}
+
+type mockSetRefreshPolicyAPI struct {
+	version     int
+	err         error
+	called      bool
+	application string
+	policy      *params.CharmRefreshPolicy
+}
+
+func (s *mockSetRefreshPolicyAPI) Close() error {
+	return nil
+}
+
+func (s *mockSetRefreshPolicyAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s *mockSetRefreshPolicyAPI) SetCharmRefreshPolicy(application string, policy *params.CharmRefreshPolicy) error {
+	s.called = true
+	s.application = application
+	s.policy = policy
+	return s.err
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/constraints"
 )
@@ -176,14 +177,28 @@ func formatApplicationInfos(all []params.ApplicationInfo) (map[string]Applicatio
 
 // ApplicationInfo defines the serialization behaviour of the application information.
 type ApplicationInfo struct {
-	Charm            string            `yaml:"charm,omitempty" json:"charm,omitempty"`
-	Series           string            `yaml:"series,omitempty" json:"series,omitempty"`
-	Channel          string            `yaml:"channel,omitempty" json:"channel,omitempty"`
-	Constraints      constraints.Value `yaml:"constraints,omitempty" json:"constraints,omitempty"`
-	Principal        bool              `yaml:"principal" json:"principal"`
-	Exposed          bool              `yaml:"exposed" json:"exposed"`
-	Remote           bool              `yaml:"remote" json:"remote"`
-	EndpointBindings map[string]string `yaml:"endpoint-bindings,omitempty" json:"endpoint-bindings,omitempty"`
+	Charm            string             `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Series           string             `yaml:"series,omitempty" json:"series,omitempty"`
+	Channel          string             `yaml:"channel,omitempty" json:"channel,omitempty"`
+	Constraints      constraints.Value  `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Principal        bool               `yaml:"principal" json:"principal"`
+	Exposed          bool               `yaml:"exposed" json:"exposed"`
+	Remote           bool               `yaml:"remote" json:"remote"`
+	EndpointBindings map[string]string  `yaml:"endpoint-bindings,omitempty" json:"endpoint-bindings,omitempty"`
+	RefreshPolicy    *RefreshPolicyInfo `yaml:"refresh-policy,omitempty" json:"refresh-policy,omitempty"`
+}
+
+// RefreshPolicyInfo defines the serialization behaviour of an
+// application's charm refresh policy. Times are shown in UTC, in
+// which the maintenance window is scheduled.
+type RefreshPolicyInfo struct {
+	Window        string `yaml:"window" json:"window"`
+	Duration      string `yaml:"duration" json:"duration"`
+	PatchOnly     bool   `yaml:"patch-only" json:"patch-only"`
+	CanaryBranch  string `yaml:"canary-branch,omitempty" json:"canary-branch,omitempty"`
+	CanaryCharm   string `yaml:"canary-charm,omitempty" json:"canary-charm,omitempty"`
+	LastRefreshed string `yaml:"last-refreshed,omitempty" json:"last-refreshed,omitempty"`
+	LastMessage   string `yaml:"last-message,omitempty" json:"last-message,omitempty"`
 }
 
 func createApplicationInfo(details params.ApplicationInfo) (names.ApplicationTag, ApplicationInfo, error) {
@@ -202,5 +217,18 @@ func createApplicationInfo(details params.ApplicationInfo) (names.ApplicationTag
 		Remote:           details.Remote,
 		EndpointBindings: details.EndpointBindings,
 	}
+	if policy := details.RefreshPolicy; policy != nil {
+		info.RefreshPolicy = &RefreshPolicyInfo{
+			Window:       policy.Window,
+			Duration:     policy.Duration.String(),
+			PatchOnly:    policy.PatchOnly,
+			CanaryBranch: policy.CanaryBranch,
+			CanaryCharm:  policy.CanaryCharmURL,
+			LastMessage:  policy.LastMessage,
+		}
+		if policy.LastRefreshed != nil {
+			info.RefreshPolicy.LastRefreshed = common.FormatTime(policy.LastRefreshed, true)
+		}
+	}
 	return tag, info, nil
 }
@@ -5,6 +5,7 @@ package application_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -179,6 +180,50 @@ wordpress:
 `[1:],
 	})
 }
+func (s *ShowSuite) TestShowRefreshPolicy(c *gc.C) {
+	lastRefreshed := time.Date(2019, 4, 6, 2, 30, 0, 0, time.UTC)
+	info := s.createTestApplicationInfo("wordpress", "")
+	info.RefreshPolicy = &params.CharmRefreshPolicy{
+		Window:         "0 2 * * SAT",
+		Duration:       2 * time.Hour,
+		PatchOnly:      true,
+		CanaryBranch:   "canary",
+		CanaryCharmURL: "cs:wordpress-43",
+		LastRefreshed:  &lastRefreshed,
+		LastMessage:    `staged cs:wordpress-43 on branch "canary"`,
+	}
+	s.mockAPI.applicationsInfoFunc = func([]names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
+		return []params.ApplicationInfoResult{{Result: info}}, nil
+	}
+	s.assertRunShow(c, showTest{
+		args: []string{"wordpress"},
+		stdout: `
+wordpress:
+  charm: charm-wordpress
+  series: quantal
+  channel: development
+  constraints:
+    arch: amd64
+    cores: 1
+    mem: 4096
+    root-disk: 8192
+  principal: true
+  exposed: false
+  remote: false
+  endpoint-bindings:
+    juju-info: myspace
+  refresh-policy:
+    window: 0 2 * * SAT
+    duration: 2h0m0s
+    patch-only: true
+    canary-branch: canary
+    canary-charm: cs:wordpress-43
+    last-refreshed: 2019-04-06 02:30:00Z
+    last-message: staged cs:wordpress-43 on branch "canary"
+`[1:],
+	})
+}
+
 func (s *ShowSuite) TestShowJSON(c *gc.C) {
 	s.mockAPI.applicationsInfoFunc = func([]names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
 		return []params.ApplicationInfoResult{
@@ -0,0 +1,156 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const showUpgradeDoc = `
+Shows the progress of a batched charm upgrade started with
+"juju upgrade-charm --batch-size". Released units have been handed the
+new charm; pending units are still running the charm from before the
+upgrade, and are released a batch at a time as the previous batch
+becomes healthy.
+
+The status of the upgrade is one of:
+    running    units are being upgraded
+    paused     a unit failed; the upgrade resumes once it recovers
+    failed     a unit failed, and the upgrade was stopped
+    completed  all units have been released
+
+Examples:
+    juju show-upgrade mysql
+    juju show-upgrade mysql --format json
+
+See also:
+    upgrade-charm
+`
+
+// NewShowUpgradeCommand returns a command that displays the progress
+// of an application's batched charm upgrade.
+func NewShowUpgradeCommand() cmd.Command {
+	c := &showUpgradeCommand{}
+	c.newAPIFunc = func() (ShowUpgradeAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+// ShowUpgradeAPI defines the API methods that the show-upgrade command
+// uses.
+type ShowUpgradeAPI interface {
+	Close() error
+	BestAPIVersion() int
+	CharmRollout(string) (*params.CharmRollout, error)
+}
+
+// showUpgradeCommand displays the progress of a batched charm upgrade.
+type showUpgradeCommand struct {
+	modelcmd.ModelCommandBase
+
+	out         cmd.Output
+	isoTime     bool
+	application string
+	newAPIFunc  func() (ShowUpgradeAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *showUpgradeCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-upgrade",
+		Args:    "<application name>",
+		Purpose: "Displays the progress of a batched charm upgrade.",
+		Doc:     showUpgradeDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showUpgradeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+// Init implements Command.Init.
+func (c *showUpgradeCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("an application name must be supplied")
+	}
+	c.application = args[0]
+	if !names.IsValidApplication(c.application) {
+		return errors.NotValidf("application name %q", c.application)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// charmRollout is the serialisation format of a batched charm upgrade.
+type charmRollout struct {
+	Application  string   `yaml:"application" json:"application"`
+	From         string   `yaml:"from" json:"from"`
+	To           string   `yaml:"to" json:"to"`
+	Status       string   `yaml:"status" json:"status"`
+	Message      string   `yaml:"message,omitempty" json:"message,omitempty"`
+	BatchSize    int      `yaml:"batch-size" json:"batch-size"`
+	PauseOnError bool     `yaml:"pause-on-error" json:"pause-on-error"`
+	LeaderLast   bool     `yaml:"leader-last" json:"leader-last"`
+	Released     []string `yaml:"released" json:"released"`
+	Pending      []string `yaml:"pending" json:"pending"`
+	Started      string   `yaml:"started" json:"started"`
+	Updated      string   `yaml:"updated" json:"updated"`
+}
+
+// Run implements Command.Run.
+func (c *showUpgradeCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if v := client.BestAPIVersion(); v < 12 {
+		return errors.NotSupportedf("showing charm upgrades on API server version %v", v)
+	}
+
+	rollout, err := client.CharmRollout(c.application)
+	if params.IsCodeNotFound(err) {
+		return errors.Errorf("application %q has no batched charm upgrade", c.application)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	out := charmRollout{
+		Application:  rollout.Application,
+		From:         rollout.FromCharmURL,
+		To:           rollout.ToCharmURL,
+		Status:       rollout.Status,
+		Message:      rollout.Message,
+		BatchSize:    rollout.BatchSize,
+		PauseOnError: rollout.PauseOnError,
+		LeaderLast:   rollout.LeaderLast,
+		Released:     rollout.ReleasedUnits,
+		Pending:      rollout.PendingUnits,
+		Started:      common.FormatTime(&rollout.Started, c.isoTime),
+		Updated:      common.FormatTime(&rollout.Updated, c.isoTime),
+	}
+	if out.Released == nil {
+		out.Released = []string{}
+	}
+	if out.Pending == nil {
+		out.Pending = []string{}
+	}
+	return c.out.Write(ctx, out)
+}
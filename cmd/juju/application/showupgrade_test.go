@@ -0,0 +1,130 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type ShowUpgradeSuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+
+	mockAPI *mockShowUpgradeAPI
+}
+
+var _ = gc.Suite(&ShowUpgradeSuite{})
+
+func (s *ShowUpgradeSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	started := time.Date(2019, 4, 1, 10, 0, 0, 0, time.UTC)
+	s.mockAPI = &mockShowUpgradeAPI{
+		version: 12,
+		rollout: &params.CharmRollout{
+			Application:   "mysql",
+			FromCharmURL:  "cs:mysql-1",
+			ToCharmURL:    "cs:mysql-2",
+			BatchSize:     1,
+			PauseOnError:  true,
+			ReleasedUnits: []string{"mysql/0"},
+			PendingUnits:  []string{"mysql/1", "mysql/2"},
+			Status:        "paused",
+			Message:       `unit failed: mysql/0: hook failed: "upgrade-charm"`,
+			Started:       started,
+			Updated:       started.Add(time.Minute),
+		},
+	}
+}
+
+func (s *ShowUpgradeSuite) TestInit(c *gc.C) {
+	cmd := application.NewShowUpgradeCommandForTest(s.mockAPI, s.store)
+	_, err := cmdtesting.RunCommand(c, cmd)
+	c.Assert(err, gc.ErrorMatches, "an application name must be supplied")
+
+	cmd = application.NewShowUpgradeCommandForTest(s.mockAPI, s.store)
+	_, err = cmdtesting.RunCommand(c, cmd, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `application name "mysql/0" not valid`)
+
+	cmd = application.NewShowUpgradeCommandForTest(s.mockAPI, s.store)
+	_, err = cmdtesting.RunCommand(c, cmd, "mysql", "wordpress")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["wordpress"\]`)
+}
+
+func (s *ShowUpgradeSuite) TestShowUpgrade(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, application.NewShowUpgradeCommandForTest(s.mockAPI, s.store), "mysql", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+application: mysql
+from: cs:mysql-1
+to: cs:mysql-2
+status: paused
+message: 'unit failed: mysql/0: hook failed: "upgrade-charm"'
+batch-size: 1
+pause-on-error: true
+leader-last: false
+released:
+- mysql/0
+pending:
+- mysql/1
+- mysql/2
+started: 2019-04-01 10:00:00Z
+updated: 2019-04-01 10:01:00Z
+`[1:])
+	c.Assert(s.mockAPI.application, gc.Equals, "mysql")
+}
+
+func (s *ShowUpgradeSuite) TestShowUpgradeNotFound(c *gc.C) {
+	s.mockAPI.err = &params.Error{Message: "not found", Code: params.CodeNotFound}
+	_, err := cmdtesting.RunCommand(c, application.NewShowUpgradeCommandForTest(s.mockAPI, s.store), "mysql")
+	c.Assert(err, gc.ErrorMatches, `application "mysql" has no batched charm upgrade`)
+}
+
+func (s *ShowUpgradeSuite) TestShowUpgradeOldAPI(c *gc.C) {
+	s.mockAPI.version = 11
+	_, err := cmdtesting.RunCommand(c, application.NewShowUpgradeCommandForTest(s.mockAPI, s.store), "mysql")
+	c.Assert(err, gc.ErrorMatches, "showing charm upgrades on API server version 11 not supported")
+}
+
+type mockShowUpgradeAPI struct {
+	version     int
+	rollout     *params.CharmRollout
+	err         error
+	application string
+}
+
+func (s *mockShowUpgradeAPI) Close() error {
+	return nil
+}
+
+func (s *mockShowUpgradeAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s *mockShowUpgradeAPI) CharmRollout(application string) (*params.CharmRollout, error) {
+	s.application = application
+	return s.rollout, s.err
+}
@@ -0,0 +1,115 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const unitStateDoc = `
+Charms may store private key/value state for each of their units on the
+controller, using the state-set and state-delete hook tools. This command
+displays the state currently stored for a unit.
+
+Only model administrators may view a unit's state.
+
+Examples:
+    juju unit-state mysql/0
+    juju unit-state mysql/0 --format json
+
+See also:
+    show-unit
+`
+
+// NewUnitStateCommand returns a command that displays the charm state
+// stored for a unit.
+func NewUnitStateCommand() cmd.Command {
+	c := &unitStateCommand{}
+	c.newAPIFunc = func() (UnitStateAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+// UnitStateAPI defines the API methods that the unit-state command uses.
+type UnitStateAPI interface {
+	Close() error
+	BestAPIVersion() int
+	UnitsState([]names.UnitTag) ([]params.UnitStateResult, error)
+}
+
+// unitStateCommand displays the charm state stored for a unit.
+type unitStateCommand struct {
+	modelcmd.ModelCommandBase
+
+	out        cmd.Output
+	unit       string
+	newAPIFunc func() (UnitStateAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *unitStateCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "unit-state",
+		Args:    "<unit name>",
+		Purpose: "Displays the charm state stored for a unit.",
+		Doc:     unitStateDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *unitStateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+// Init implements Command.Init.
+func (c *unitStateCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("a unit name must be supplied")
+	}
+	c.unit = args[0]
+	if !names.IsValidUnit(c.unit) {
+		return errors.NotValidf("unit name %q", c.unit)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *unitStateCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if v := client.BestAPIVersion(); v < 11 {
+		return errors.NotSupportedf("showing unit state on API server version %v", v)
+	}
+
+	results, err := client.UnitsState([]names.UnitTag{names.NewUnitTag(c.unit)})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+	unitState := results[0].State
+	if unitState == nil {
+		unitState = map[string]string{}
+	}
+	return c.out.Write(ctx, unitState)
+}
@@ -0,0 +1,109 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type UnitStateSuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+
+	mockAPI *mockUnitStateAPI
+}
+
+var _ = gc.Suite(&UnitStateSuite{})
+
+func (s *UnitStateSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	s.mockAPI = &mockUnitStateAPI{
+		version: 11,
+		results: []params.UnitStateResult{{
+			State: map[string]string{"foo": "bar", "baz": "qux"},
+		}},
+	}
+}
+
+func (s *UnitStateSuite) TestInit(c *gc.C) {
+	cmd := application.NewUnitStateCommandForTest(s.mockAPI, s.store)
+	_, err := cmdtesting.RunCommand(c, cmd)
+	c.Assert(err, gc.ErrorMatches, "a unit name must be supplied")
+
+	cmd = application.NewUnitStateCommandForTest(s.mockAPI, s.store)
+	_, err = cmdtesting.RunCommand(c, cmd, "mysql")
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+
+	cmd = application.NewUnitStateCommandForTest(s.mockAPI, s.store)
+	_, err = cmdtesting.RunCommand(c, cmd, "mysql/0", "mysql/1")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["mysql/1"\]`)
+}
+
+func (s *UnitStateSuite) TestUnitState(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, application.NewUnitStateCommandForTest(s.mockAPI, s.store), "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "baz: qux\nfoo: bar\n")
+	c.Assert(s.mockAPI.tags, jc.DeepEquals, []names.UnitTag{names.NewUnitTag("mysql/0")})
+}
+
+func (s *UnitStateSuite) TestUnitStateJSON(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, application.NewUnitStateCommandForTest(s.mockAPI, s.store), "mysql/0", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"baz":"qux","foo":"bar"}`+"\n")
+}
+
+func (s *UnitStateSuite) TestUnitStateError(c *gc.C) {
+	s.mockAPI.results = []params.UnitStateResult{{
+		Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+	}}
+	_, err := cmdtesting.RunCommand(c, application.NewUnitStateCommandForTest(s.mockAPI, s.store), "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *UnitStateSuite) TestUnitStateOldAPI(c *gc.C) {
+	s.mockAPI.version = 10
+	_, err := cmdtesting.RunCommand(c, application.NewUnitStateCommandForTest(s.mockAPI, s.store), "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "showing unit state on API server version 10 not supported")
+}
+
+type mockUnitStateAPI struct {
+	version int
+	results []params.UnitStateResult
+	tags    []names.UnitTag
+}
+
+func (s *mockUnitStateAPI) Close() error {
+	return nil
+}
+
+func (s *mockUnitStateAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s *mockUnitStateAPI) UnitsState(tags []names.UnitTag) ([]params.UnitStateResult, error) {
+	s.tags = tags
+	return s.results, nil
+}
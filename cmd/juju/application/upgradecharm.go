@@ -137,6 +137,18 @@ type upgradeCharmCommand struct {
 	// defined in charm storage metadata, to add or update during upgrade.
 	Storage map[string]storage.Constraints
 
+	// BatchSize, if positive, is the number of units to hand the new
+	// charm to at a time.
+	BatchSize int
+
+	// PauseOnError pauses a batched upgrade when a unit fails, rather
+	// than stopping it.
+	PauseOnError bool
+
+	// LeaderLast upgrades the application's leader in the final batch
+	// of a batched upgrade.
+	LeaderLast bool
+
 	catacomb catacomb.Catacomb
 	plan     catacomb.Plan
 }
@@ -209,6 +221,16 @@ is determined by the contents of the charm at the specified path.
 number with --switch, give it in the charm URL, for instance "cs:wordpress-5"
 would specify revision number 5 of the wordpress charm.
 
+By default all units are upgraded at once. The --batch-size option instead
+hands the new charm to that many units at a time; the next batch is released
+once the units of the previous batch have run their upgrade-charm hooks and
+report an active workload status. If a unit fails, the upgrade is stopped;
+with --pause-on-error it is paused instead, and resumes once the unit
+recovers. With --leader-last the application's leader is upgraded in the
+final batch. Use "juju show-upgrade" to follow the progress of the upgrade.
+
+  juju upgrade-charm foo --batch-size 2 --pause-on-error --leader-last
+
 Use of the --force-units option is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
@@ -239,6 +261,9 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
+	f.IntVar(&c.BatchSize, "batch-size", 0, "Upgrade this many units at a time")
+	f.BoolVar(&c.PauseOnError, "pause-on-error", false, "Pause a batched upgrade rather than stopping it when a unit fails")
+	f.BoolVar(&c.LeaderLast, "leader-last", false, "Upgrade the leader in the final batch")
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -262,6 +287,15 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.BatchSize < 0 {
+		return errors.Errorf("--batch-size must be positive")
+	}
+	if c.BatchSize == 0 && (c.PauseOnError || c.LeaderLast) {
+		return errors.Errorf("--pause-on-error and --leader-last require --batch-size")
+	}
+	if c.BatchSize > 0 && c.ForceUnits {
+		return errors.Errorf("--batch-size and --force-units are mutually exclusive")
+	}
 	return nil
 }
 
@@ -289,6 +323,13 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 			return errors.New(action + " at upgrade-charm time is not supported by " + suffix)
 		}
 	}
+	if c.BatchSize > 0 && apiRoot.BestFacadeVersion("Application") < 12 {
+		suffix := "this server"
+		if version, ok := apiRoot.ServerVersion(); ok {
+			suffix = fmt.Sprintf("server version %s", version)
+		}
+		return errors.New("--batch-size is not supported by " + suffix)
+	}
 
 	generation, err := c.ActiveBranch()
 	if err != nil {
@@ -387,8 +428,18 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ForceUnits:         c.ForceUnits,
 		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
+		BatchSize:          c.BatchSize,
+		PauseOnError:       c.PauseOnError,
+		LeaderLast:         c.LeaderLast,
 	}
-	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(generation, cfg), block.BlockChange)
+	if err := charmUpgradeClient.SetCharm(generation, cfg); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if c.BatchSize > 0 {
+		ctx.Infof("Upgrading %q in batches of %d; use \"juju show-upgrade %s\" to follow progress.",
+			c.ApplicationName, c.BatchSize, c.ApplicationName)
+	}
+	return nil
 }
 
 // upgradeResources pushes metadata up to the server for each resource defined
@@ -249,6 +249,54 @@ func (s *UpgradeCharmSuite) TestConfigSettingsMinFacadeVersion(c *gc.C) {
 		"updating config at upgrade-charm time is not supported by server version 1.2.3")
 }
 
+func (s *UpgradeCharmSuite) TestBatchSize(c *gc.C) {
+	s.apiConnection.bestFacadeVersion = 12
+	ctx, err := s.runUpgradeCharm(c, "foo", "--batch-size", "2", "--pause-on-error", "--leader-last")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmAPIClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharm")
+
+	s.charmAPIClient.CheckCall(c, 2, "SetCharm", model.GenerationMaster, application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     s.resolvedCharmURL,
+			Channel: csclientparams.StableChannel,
+		},
+		BatchSize:    2,
+		PauseOnError: true,
+		LeaderLast:   true,
+	})
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `use "juju show-upgrade foo" to follow progress`)
+}
+
+func (s *UpgradeCharmSuite) TestBatchSizeMinFacadeVersion(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--batch-size", "2")
+	c.Assert(err, gc.ErrorMatches, "--batch-size is not supported by server version 1.2.3")
+	s.charmAPIClient.CheckCallNames(c)
+}
+
+func (s *UpgradeCharmSuite) TestBatchFlagsInvalid(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--batch-size", "-1"},
+		err:  "--batch-size must be positive",
+	}, {
+		args: []string{"--pause-on-error"},
+		err:  "--pause-on-error and --leader-last require --batch-size",
+	}, {
+		args: []string{"--leader-last"},
+		err:  "--pause-on-error and --leader-last require --batch-size",
+	}, {
+		args: []string{"--batch-size", "2", "--force-units"},
+		err:  "--batch-size and --force-units are mutually exclusive",
+	}} {
+		c.Logf("args: %v", t.args)
+		_, err := s.runUpgradeCharm(c, append([]string{"foo"}, t.args...)...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
 type UpgradeCharmErrorsStateSuite struct {
 	jujutesting.RepoSuite
 	handler charmstore.HTTPCloseHandler
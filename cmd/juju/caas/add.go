@@ -74,9 +74,10 @@ detected to enable storage to be correctly configured. If the cloud/region canno
 be detected automatically, use --region <cloudType/region> to specify the host
 cloud type and region.
 
-When adding a GKE or AKS cluster, you can use the --gke or --aks option to
-interactively be stepped through the registration process, or you can supply the
-necessary parameters directly.
+When adding a GKE, AKS or EKS cluster, you can use the --gke, --aks or --eks
+option to interactively be stepped through the registration process, or you can
+supply the necessary parameters directly. For EKS, --credential is the name of
+the AWS CLI profile to use; the eksctl and aws CLIs must be installed.
 
 Examples:
     juju add-k8s myk8scloud
@@ -97,6 +98,10 @@ Examples:
     juju add-k8s --aks --cluster-name mycluster myk8scloud
     juju add-k8s --aks --cluster-name mycluster --resource-group myrg myk8scloud
 
+    juju add-k8s --eks myk8scloud
+    juju add-k8s --eks --region us-west-2 myk8scloud
+    juju add-k8s --eks --credential myprofile --region us-west-2 --cluster-name mycluster myk8scloud
+
 See also:
     remove-k8s
 `
@@ -144,6 +149,7 @@ type AddCAASCommand struct {
 
 	gke        bool
 	aks        bool
+	eks        bool
 	k8sCluster k8sCluster
 
 	cloudMetadataStore    CloudMetadataStore
@@ -198,6 +204,7 @@ func (c *AddCAASCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.resourceGroup, "resource-group", "", "the Azure resource group of the AKS cluster")
 	f.BoolVar(&c.gke, "gke", false, "used when adding a GKE cluster")
 	f.BoolVar(&c.aks, "aks", false, "used when adding an AKS cluster")
+	f.BoolVar(&c.eks, "eks", false, "used when adding an EKS cluster")
 }
 
 // Init populates the command with the args from the command line.
@@ -205,8 +212,14 @@ func (c *AddCAASCommand) Init(args []string) (err error) {
 	if len(args) == 0 {
 		return errors.Errorf("missing k8s name.")
 	}
-	if c.gke && c.aks {
-		return errors.BadRequestf("only one of '--gke' or '--aks' can be supplied")
+	clusterProviders := 0
+	for _, selected := range []bool{c.gke, c.aks, c.eks} {
+		if selected {
+			clusterProviders++
+		}
+	}
+	if clusterProviders > 1 {
+		return errors.BadRequestf("only one of '--gke', '--aks' or '--eks' can be supplied")
 	}
 	c.caasType = "kubernetes"
 	c.caasName = args[0]
@@ -228,8 +241,19 @@ func (c *AddCAASCommand) Init(args []string) (err error) {
 		if c.project != "" {
 			return errors.New("do not specify project unless adding a GKE cluster")
 		}
-		if c.credential != "" {
-			return errors.New("do not specify credential unless adding a GKE cluster")
+		if c.credential != "" && !c.eks {
+			return errors.New("do not specify credential unless adding a GKE or EKS cluster")
+		}
+		if c.eks {
+			if c.contextName != "" {
+				return errors.New("do not specify context name when adding an EKS cluster")
+			}
+			if c.k8sCluster == nil {
+				c.k8sCluster = newEKSCluster()
+			}
+			if err := c.k8sCluster.ensureExecutable(); err != nil {
+				return errors.Trace(err)
+			}
 		}
 		if c.aks {
 			if c.contextName != "" {
@@ -278,6 +302,9 @@ func (c *AddCAASCommand) getConfigReader(ctx *cmd.Context) (io.Reader, string, e
 	if c.aks {
 		return c.getAKSKubeConfig(ctx)
 	}
+	if c.eks {
+		return c.getEKSKubeConfig(ctx)
+	}
 	rdr, err := getStdinPipe(ctx)
 	return rdr, c.clusterName, err
 }
@@ -323,6 +350,27 @@ func (c *AddCAASCommand) getAKSKubeConfig(ctx *cmd.Context) (io.Reader, string,
 	return c.k8sCluster.getKubeConfig(p)
 }
 
+func (c *AddCAASCommand) getEKSKubeConfig(ctx *cmd.Context) (io.Reader, string, error) {
+	p := &clusterParams{
+		name:       c.clusterName,
+		region:     c.hostCloudRegion,
+		credential: c.credential,
+	}
+
+	// If any items are missing, prompt for them. The AWS profile is
+	// optional, the default one is used if not specified.
+	if p.name == "" || p.region == "" {
+		var err error
+		p, err = c.k8sCluster.interactiveParams(ctx, p)
+		if err != nil {
+			return nil, "", errors.Trace(err)
+		}
+	}
+	c.clusterName = p.name
+	c.hostCloudRegion = c.k8sCluster.cloud() + "/" + p.region
+	return c.k8sCluster.getKubeConfig(p)
+}
+
 var clusterQueryErrMsg = `
 	Juju needs to query the k8s cluster to ensure that the recommended
 	storage defaults are available and to detect the cluster's cloud/region.
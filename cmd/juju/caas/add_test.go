@@ -368,7 +368,15 @@ func (s *addCAASSuite) TestInit(c *gc.C) {
 		},
 		{
 			args:           []string{"--credential", "a"},
-			expectedErrStr: "do not specify credential unless adding a GKE cluster",
+			expectedErrStr: "do not specify credential unless adding a GKE or EKS cluster",
+		},
+		{
+			args:           []string{"--eks", "--context-name", "a"},
+			expectedErrStr: "do not specify context name when adding an EKS cluster",
+		},
+		{
+			args:           []string{"--eks", "--project", "a"},
+			expectedErrStr: "do not specify project unless adding a GKE cluster",
 		},
 	} {
 		args := append([]string{"myk8s"}, ts.args...)
@@ -879,5 +887,9 @@ func (s *addCAASSuite) TestCorrectSelectContext(c *gc.C) {
 func (s *addCAASSuite) TestOnlyOneClusterProvider(c *gc.C) {
 	cmd := s.makeCommand(c, true, false, true)
 	_, err := s.runCommand(c, nil, cmd, "myk8s", "-c", "foo", "--aks", "--gke")
-	c.Assert(err, gc.ErrorMatches, "only one of '--gke' or '--aks' can be supplied")
+	c.Assert(err, gc.ErrorMatches, "only one of '--gke', '--aks' or '--eks' can be supplied")
+
+	cmd = s.makeCommand(c, true, false, true)
+	_, err = s.runCommand(c, nil, cmd, "myk8s", "-c", "foo", "--aks", "--eks")
+	c.Assert(err, gc.ErrorMatches, "only one of '--gke', '--aks' or '--eks' can be supplied")
 }
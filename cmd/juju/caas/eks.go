@@ -0,0 +1,245 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/clientconfig"
+	"github.com/juju/juju/cmd/juju/interact"
+)
+
+const defaultAWSProfile = "default"
+
+type eks struct {
+	CommandRunner
+}
+
+func newEKSCluster() k8sCluster {
+	return &eks{CommandRunner: &defaultRunner{}}
+}
+
+func (e *eks) cloud() string {
+	return caas.K8sCloudEC2
+}
+
+func (e *eks) ensureExecutable() error {
+	cmd := []string{"which", "eksctl"}
+	err := collapseRunError(runCommand(e, cmd, ""))
+	if err != nil {
+		return errors.Errorf("eksctl not found. Please install eksctl (see: https://eksctl.io/introduction/installation/) and try again")
+	}
+
+	// The aws CLI is needed to query regions and credentials, and
+	// by the kubeconfig eksctl writes to fetch tokens.
+	cmd = []string{"which", "aws"}
+	err = collapseRunError(runCommand(e, cmd, ""))
+	if err != nil {
+		return errors.Errorf("aws not found. Please 'snap install aws-cli --classic', run 'aws configure' and try again")
+	}
+	return nil
+}
+
+// withProfile appends the --profile argument to cmd for any
+// non default AWS profile.
+func withProfile(cmd []string, profile string) []string {
+	if profile != "" && profile != defaultAWSProfile {
+		cmd = append(cmd, "--profile", profile)
+	}
+	return cmd
+}
+
+func (e *eks) getKubeConfig(p *clusterParams) (io.ReadCloser, string, error) {
+	kubeconfig := clientconfig.GetKubeConfigPath()
+	cmd := []string{
+		"eksctl", "utils", "write-kubeconfig",
+		"--cluster", p.name, "--region", p.region,
+		"--kubeconfig", kubeconfig,
+	}
+	cmd = withProfile(cmd, p.credential)
+
+	err := collapseRunError(runCommand(e, cmd, kubeconfig))
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	// eksctl names the cluster in the kubeconfig <name>.<region>.eksctl.io.
+	qualifiedClusterName := fmt.Sprintf("%s.%s.eksctl.io", p.name, p.region)
+	rdr, err := os.Open(kubeconfig)
+	return rdr, qualifiedClusterName, err
+}
+
+func (e *eks) interactiveParams(ctxt *cmd.Context, p *clusterParams) (*clusterParams, error) {
+	errout := interact.NewErrWriter(ctxt.Stdout)
+	pollster := interact.New(ctxt.Stdin, ctxt.Stdout, errout)
+
+	var err error
+	if p.credential == "" {
+		p.credential, err = e.queryProfile(pollster)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	if p.region == "" {
+		p.region, err = e.queryRegion(pollster, p.credential)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	if p.name == "" {
+		p.name, err = e.queryCluster(pollster, p.credential, p.region)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return p, nil
+}
+
+// listProfiles returns the named AWS CLI profiles. Older versions of the
+// CLI can't list profiles, in which case only the default is returned.
+func (e *eks) listProfiles() []string {
+	cmd := []string{"aws", "configure", "list-profiles"}
+	result, err := runCommand(e, cmd, "")
+	if err := collapseRunError(result, err); err != nil {
+		logger.Debugf("cannot list aws profiles: %v", err)
+		return []string{defaultAWSProfile}
+	}
+	var profiles []string
+	for _, line := range strings.Split(string(result.Stdout), "\n") {
+		if profile := strings.TrimSpace(line); profile != "" {
+			profiles = append(profiles, profile)
+		}
+	}
+	if len(profiles) == 0 {
+		profiles = []string{defaultAWSProfile}
+	}
+	return profiles
+}
+
+func (e *eks) queryProfile(pollster *interact.Pollster) (string, error) {
+	profiles := e.listProfiles()
+	if len(profiles) == 1 {
+		return profiles[0], nil
+	}
+	defaultProfile := profiles[0]
+	for _, profile := range profiles {
+		if profile == defaultAWSProfile {
+			defaultProfile = profile
+		}
+	}
+	profile, err := pollster.Select(interact.List{
+		Singular: "profile",
+		Plural:   "Available profiles",
+		Options:  profiles,
+		Default:  defaultProfile,
+	})
+	return profile, errors.Trace(err)
+}
+
+func (e *eks) listRegions(profile string) ([]string, string, error) {
+	cmd := withProfile([]string{
+		"aws", "ec2", "describe-regions",
+		"--query", `"Regions[].RegionName"`,
+		"--output", "text",
+	}, profile)
+	result, err := runCommand(e, cmd, "")
+	if err := collapseRunError(result, err); err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	regions := strings.Fields(string(result.Stdout))
+	sort.Strings(regions)
+
+	// The configured region, if any, is used as the default.
+	cmd = withProfile([]string{"aws", "configure", "get", "region"}, profile)
+	result, err = runCommand(e, cmd, "")
+	if err := collapseRunError(result, err); err != nil {
+		return regions, "", nil
+	}
+	return regions, strings.TrimSpace(string(result.Stdout)), nil
+}
+
+func (e *eks) queryRegion(pollster *interact.Pollster, profile string) (string, error) {
+	regions, defaultRegion, err := e.listRegions(profile)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(regions) == 0 {
+		return "", errors.New("no regions are available.\n" +
+			"See 'aws configure help'.",
+		)
+	}
+	if defaultRegion == "" {
+		defaultRegion = regions[0]
+	}
+	region, err := pollster.Select(interact.List{
+		Singular: "region",
+		Plural:   "Available regions",
+		Options:  regions,
+		Default:  defaultRegion,
+	})
+	return region, errors.Trace(err)
+}
+
+type eksClusterDetails struct {
+	Name   string `json:"name"`
+	Region string `json:"region"`
+}
+
+func (e *eks) listClusters(profile, region string) ([]cluster, error) {
+	cmd := withProfile([]string{
+		"eksctl", "get", "cluster",
+		"--region", region,
+		"--output", "json",
+	}, profile)
+	result, err := runCommand(e, cmd, "")
+	if err := collapseRunError(result, err); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var clusterInfo []eksClusterDetails
+	if err := json.Unmarshal(result.Stdout, &clusterInfo); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var clusters []cluster
+	for _, ci := range clusterInfo {
+		clusters = append(clusters, cluster{
+			name:   ci.Name,
+			region: ci.Region,
+		})
+	}
+	return clusters, nil
+}
+
+func (e *eks) queryCluster(pollster *interact.Pollster, profile, region string) (string, error) {
+	clusters, err := e.listClusters(profile, region)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(clusters) == 0 {
+		return "", errors.Errorf("no clusters have been set up in region %s.\n"+
+			"You can create a k8s cluster using 'eksctl create cluster'",
+			region,
+		)
+	}
+	var clusterNames []string
+	for _, c := range clusters {
+		clusterNames = append(clusterNames, c.name)
+	}
+	name, err := pollster.Select(interact.List{
+		Singular: "cluster",
+		Plural:   "Available clusters",
+		Options:  clusterNames,
+		Default:  clusterNames[0],
+	})
+	return name, errors.Trace(err)
+}
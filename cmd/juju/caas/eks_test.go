@@ -0,0 +1,262 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/exec"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/caas/mocks"
+)
+
+type eksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&eksSuite{})
+
+func (s *eksSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	err := os.Setenv("PATH", "/path/to/here")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *eksSuite) TestInteractiveParams(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRunner := mocks.NewMockCommandRunner(ctrl)
+	eks := &eks{CommandRunner: mockRunner}
+
+	gomock.InOrder(
+		mockRunner.EXPECT().RunCommands(exec.RunParams{
+			Commands:    "aws configure list-profiles",
+			Environment: []string{"KUBECONFIG=", "PATH=/path/to/here"},
+		}).Times(1).
+			Return(&exec.ExecResponse{
+				Code:   0,
+				Stdout: []byte("default\nstaging\n"),
+			}, nil),
+		mockRunner.EXPECT().RunCommands(exec.RunParams{
+			Commands:    `aws ec2 describe-regions --query "Regions[].RegionName" --output text --profile staging`,
+			Environment: []string{"KUBECONFIG=", "PATH=/path/to/here"},
+		}).Times(1).
+			Return(&exec.ExecResponse{
+				Code:   0,
+				Stdout: []byte("us-west-2\teu-west-1\tus-east-1\n"),
+			}, nil),
+		mockRunner.EXPECT().RunCommands(exec.RunParams{
+			Commands:    "aws configure get region --profile staging",
+			Environment: []string{"KUBECONFIG=", "PATH=/path/to/here"},
+		}).Times(1).
+			Return(&exec.ExecResponse{
+				Code:   0,
+				Stdout: []byte("us-east-1\n"),
+			}, nil),
+		mockRunner.EXPECT().RunCommands(exec.RunParams{
+			Commands:    "eksctl get cluster --region us-west-2 --output json --profile staging",
+			Environment: []string{"KUBECONFIG=", "PATH=/path/to/here"},
+		}).Times(1).
+			Return(&exec.ExecResponse{
+				Code: 0,
+				Stdout: []byte(`
+[
+  {"name": "mycluster", "region": "us-west-2"},
+  {"name": "othercluster", "region": "us-west-2"}
+]`),
+			}, nil),
+	)
+	stdin := strings.NewReader("staging\nus-west-2\n\n")
+	out := &bytes.Buffer{}
+	ctx := &cmd.Context{
+		Dir:    c.MkDir(),
+		Stdout: out,
+		Stderr: ioutil.Discard,
+		Stdin:  stdin,
+	}
+	expected := `
+Available Profiles
+  default
+  staging
+
+Select profile [default]: 
+Available Regions
+  eu-west-1
+  us-east-1
+  us-west-2
+
+Select region [us-east-1]: 
+Available Clusters
+  mycluster
+  othercluster
+
+Select cluster [mycluster]: 
+`[1:]
+
+	outParams, err := eks.interactiveParams(ctx, &clusterParams{})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
+	c.Assert(outParams, jc.DeepEquals, &clusterParams{
+		name:       "mycluster",
+		region:     "us-west-2",
+		credential: "staging",
+	})
+}
+
+func (s *eksSuite) TestInteractiveParamsRegionSpecified(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRunner := mocks.NewMockCommandRunner(ctrl)
+	eks := &eks{CommandRunner: mockRunner}
+
+	gomock.InOrder(
+		mockRunner.EXPECT().RunCommands(exec.RunParams{
+			Commands:    "aws configure list-profiles",
+			Environment: []string{"KUBECONFIG=", "PATH=/path/to/here"},
+		}).Times(1).
+			Return(&exec.ExecResponse{
+				Code:   255,
+				Stderr: []byte("Invalid choice: 'list-profiles'"),
+			}, nil),
+		mockRunner.EXPECT().RunCommands(exec.RunParams{
+			Commands:    "eksctl get cluster --region eu-west-1 --output json",
+			Environment: []string{"KUBECONFIG=", "PATH=/path/to/here"},
+		}).Times(1).
+			Return(&exec.ExecResponse{
+				Code:   0,
+				Stdout: []byte(`[{"name": "mycluster", "region": "eu-west-1"}]`),
+			}, nil),
+	)
+	stdin := strings.NewReader("mycluster\n")
+	out := &bytes.Buffer{}
+	ctx := &cmd.Context{
+		Dir:    c.MkDir(),
+		Stdout: out,
+		Stderr: ioutil.Discard,
+		Stdin:  stdin,
+	}
+	outParams, err := eks.interactiveParams(ctx, &clusterParams{region: "eu-west-1"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(outParams, jc.DeepEquals, &clusterParams{
+		name:       "mycluster",
+		region:     "eu-west-1",
+		credential: "default",
+	})
+}
+
+func (s *eksSuite) TestInteractiveParamsNoClusters(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRunner := mocks.NewMockCommandRunner(ctrl)
+	eks := &eks{CommandRunner: mockRunner}
+
+	gomock.InOrder(
+		mockRunner.EXPECT().RunCommands(exec.RunParams{
+			Commands:    "eksctl get cluster --region eu-west-1 --output json",
+			Environment: []string{"KUBECONFIG=", "PATH=/path/to/here"},
+		}).Times(1).
+			Return(&exec.ExecResponse{
+				Code:   0,
+				Stdout: []byte(`[]`),
+			}, nil),
+	)
+	ctx := cmdtesting.Context(c)
+	_, err := eks.interactiveParams(ctx, &clusterParams{region: "eu-west-1", credential: "default"})
+	c.Assert(err, gc.ErrorMatches, `no clusters have been set up in region eu-west-1.\n.*`)
+}
+
+func (s *eksSuite) TestGetKubeConfig(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRunner := mocks.NewMockCommandRunner(ctrl)
+	configFile := filepath.Join(c.MkDir(), "config")
+	err := os.Setenv("KUBECONFIG", configFile)
+	c.Assert(err, jc.ErrorIsNil)
+	eks := &eks{CommandRunner: mockRunner}
+	err = ioutil.WriteFile(configFile, []byte("data"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	gomock.InOrder(
+		mockRunner.EXPECT().RunCommands(exec.RunParams{
+			Commands:    "eksctl utils write-kubeconfig --cluster mycluster --region us-west-2 --kubeconfig " + configFile + " --profile staging",
+			Environment: []string{"KUBECONFIG=" + configFile, "PATH=/path/to/here"},
+		}).Times(1).
+			Return(&exec.ExecResponse{
+				Code: 0,
+			}, nil),
+	)
+	rdr, clusterName, err := eks.getKubeConfig(&clusterParams{
+		name:       "mycluster",
+		region:     "us-west-2",
+		credential: "staging",
+	})
+	c.Check(err, jc.ErrorIsNil)
+	defer rdr.Close()
+
+	c.Assert(clusterName, gc.Equals, "mycluster.us-west-2.eksctl.io")
+	data, err := ioutil.ReadAll(rdr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.DeepEquals, "data")
+}
+
+func (s *eksSuite) TestEnsureExecutableEksctlNotFound(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRunner := mocks.NewMockCommandRunner(ctrl)
+	eks := &eks{CommandRunner: mockRunner}
+
+	gomock.InOrder(
+		mockRunner.EXPECT().RunCommands(exec.RunParams{
+			Commands:    "which eksctl",
+			Environment: []string{"KUBECONFIG=", "PATH=/path/to/here"},
+		}).Times(1).
+			Return(&exec.ExecResponse{
+				Code: 1,
+			}, nil),
+	)
+	err := eks.ensureExecutable()
+	c.Assert(err, gc.ErrorMatches, "eksctl not found. .*")
+}
+
+func (s *eksSuite) TestEnsureExecutableFound(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockRunner := mocks.NewMockCommandRunner(ctrl)
+	eks := &eks{CommandRunner: mockRunner}
+
+	gomock.InOrder(
+		mockRunner.EXPECT().RunCommands(exec.RunParams{
+			Commands:    "which eksctl",
+			Environment: []string{"KUBECONFIG=", "PATH=/path/to/here"},
+		}).Times(1).
+			Return(&exec.ExecResponse{
+				Code: 0,
+			}, nil),
+		mockRunner.EXPECT().RunCommands(exec.RunParams{
+			Commands:    "which aws",
+			Environment: []string{"KUBECONFIG=", "PATH=/path/to/here"},
+		}).Times(1).
+			Return(&exec.ExecResponse{
+				Code: 0,
+			}, nil),
+	)
+	err := eks.ensureExecutable()
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -6,6 +6,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 
 	"github.com/juju/juju/api/common"
 	jujucmd "github.com/juju/juju/cmd"
+	cmdcommon "github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/model"
 )
@@ -52,13 +54,23 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--since' and '--until' options bound the time window of the messages
+shown. Each takes either an RFC3339 timestamp (e.g. 2019-04-01T10:00:00Z) or
+a duration (e.g. 2h) which is interpreted as that long before now. Once the
+'--until' time has passed, no further messages are waited for.
+
+The '--grep' option shows only those messages matching the given regular
+expression. The matching is done by the controller, so only matching messages
+are sent to the client.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --since, --until and --grep selections are logically ANDed to form the
+  complete filter.
 
 Examples:
 
@@ -101,6 +113,16 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show all messages logged in the last hour that mention a failed hook, and
+then stop:
+
+    juju debug-log --replay --no-tail --since 1h --grep "hook failed"
+
+Show all messages logged between two times:
+
+    juju debug-log --replay --since 2019-04-01T10:00:00Z \
+        --until 2019-04-01T10:30:00Z
+
 See also:
     status
     ssh`
@@ -118,7 +140,7 @@ func newDebugLogCommand(store jujuclient.ClientStore) cmd.Command {
 }
 
 func newDebugLogCommandTZ(store jujuclient.ClientStore, tz *time.Location) cmd.Command {
-	cmd := &debugLogCommand{tz: tz}
+	cmd := &debugLogCommand{tz: tz, now: time.Now}
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
@@ -127,6 +149,8 @@ type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
 	level  string
+	since  string
+	until  string
 	params common.DebugLogParams
 
 	utc      bool
@@ -140,6 +164,7 @@ type debugLogCommand struct {
 
 	format string
 	tz     *time.Location
+	now    func() time.Time
 }
 
 func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -158,6 +183,9 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.UintVar(&c.params.Backlog, "lines", defaultLineCount, "")
 	f.UintVar(&c.params.Limit, "limit", 0, "Exit once this many of the most recent (possibly filtered) lines are shown")
 	f.BoolVar(&c.params.Replay, "replay", false, "Show the entire (possibly filtered) log and continue to append")
+	f.StringVar(&c.since, "since", "", "Only show log messages logged at or after this time (RFC3339 or duration ago)")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged before this time (RFC3339 or duration ago)")
+	f.StringVar(&c.params.MessagePattern, "grep", "", "Only show log messages matching this regular expression")
 
 	f.BoolVar(&c.notail, "no-tail", false, "Stop after returning existing log messages")
 	f.BoolVar(&c.tail, "tail", false, "Wait for new logs")
@@ -181,6 +209,14 @@ func (c *debugLogCommand) Init(args []string) error {
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
+	if err := c.parseTimeWindow(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.params.MessagePattern != "" {
+		if _, err := regexp.Compile(c.params.MessagePattern); err != nil {
+			return errors.Errorf("--grep: %q is not a valid regular expression", c.params.MessagePattern)
+		}
+	}
 	if c.utc {
 		c.tz = time.UTC
 	}
@@ -202,6 +238,27 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// parseTimeWindow sets the start and end times of the log window from
+// the --since and --until arguments.
+func (c *debugLogCommand) parseTimeWindow() error {
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+	var err error
+	if c.params.StartTime, err = cmdcommon.ParseTimeFlag("--since", c.since, now); err != nil {
+		return err
+	}
+	if c.params.EndTime, err = cmdcommon.ParseTimeFlag("--until", c.until, now); err != nil {
+		return err
+	}
+	if !c.params.StartTime.IsZero() && !c.params.EndTime.IsZero() &&
+		!c.params.EndTime.After(c.params.StartTime) {
+		return errors.New("--until must be later than --since")
+	}
+	return nil
+}
+
 func (c *debugLogCommand) processEntities(isCAAS bool, entities []string) []string {
 	if entities == nil {
 		return nil
@@ -104,6 +104,28 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{"--since", "2019-04-01T10:00:00Z", "--until", "2019-04-01T10:30:00Z"},
+			expected: common.DebugLogParams{
+				Backlog:   10,
+				StartTime: time.Date(2019, 4, 1, 10, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2019, 4, 1, 10, 30, 0, 0, time.UTC),
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `--since: expected RFC3339 timestamp or positive duration, got "yesterday"`,
+		}, {
+			args:     []string{"--since", "2019-04-01T10:30:00Z", "--until", "2019-04-01T10:00:00Z"},
+			errMatch: `--until must be later than --since`,
+		}, {
+			args: []string{"--grep", "hook (install|start) failed"},
+			expected: common.DebugLogParams{
+				Backlog:        10,
+				MessagePattern: "hook (install|start) failed",
+			},
+		}, {
+			args:     []string{"--grep", "hook (failed"},
+			errMatch: `--grep: "hook \(failed" is not a valid regular expression`,
 		},
 	} {
 		c.Logf("test %v", i)
@@ -119,6 +141,16 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 	}
 }
 
+func (s *DebugLogSuite) TestSinceUntilDurations(c *gc.C) {
+	now := time.Date(2019, 4, 1, 12, 0, 0, 0, time.UTC)
+	command := &debugLogCommand{now: func() time.Time { return now }}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	err := cmdtesting.InitCommand(modelcmd.Wrap(command), []string{"--since", "2h", "--until", "30m"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.params.StartTime, gc.Equals, now.Add(-2*time.Hour))
+	c.Assert(command.params.EndTime, gc.Equals, now.Add(-30*time.Minute))
+}
+
 func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	fake := &fakeDebugLogAPI{}
 	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
@@ -87,6 +87,7 @@ Aliases: help-tool, hook-tools
 var expectedCommands = []string{
 	"action-fail",
 	"action-get",
+	"action-log",
 	"action-set",
 	"add-metric",
 	"application-version-set",
@@ -111,6 +112,9 @@ var expectedCommands = []string{
 	"relation-list",
 	"relation-set",
 	"resource-get",
+	"state-delete",
+	"state-get",
+	"state-set",
 	"status-get",
 	"status-set",
 	"storage-add",
@@ -0,0 +1,139 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/introspection"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+var usageIntrospectSummary = `
+Queries the introspection endpoint of a machine or unit agent.`[1:]
+
+var usageIntrospectDetails = `
+The introspect command relays a query to the introspection endpoint of the
+specified machine or unit agent through the controller, and prints the
+response. This gives access to the same information as the
+juju-introspect command run on the agent's host, without needing shell
+access to it.
+
+The agent may be given as a machine id, a unit name, or the corresponding
+tag. The path is the introspection endpoint to query, for example
+/depengine, /statepool or /metrics.
+
+Introspecting agents requires admin access to the model.
+
+Examples:
+
+    juju introspect 0 /depengine
+    juju introspect mysql/0 /metrics
+    juju introspect unit-mysql-0 /debug/pprof/goroutine?debug=1
+
+See also:
+    debug-log`
+
+func newIntrospectCommand(store jujuclient.ClientStore) cmd.Command {
+	c := &introspectCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+// introspectCommand queries the introspection endpoint of an agent.
+type introspectCommand struct {
+	modelcmd.ModelCommandBase
+
+	agent names.Tag
+	path  string
+}
+
+// IntrospectAPI defines the API methods used by the introspect command.
+type IntrospectAPI interface {
+	Introspect(agent names.Tag, path string) (string, error)
+	Close() error
+}
+
+var getIntrospectAPI = func(c *introspectCommand) (IntrospectAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return introspection.NewClient(root), nil
+}
+
+// Info implements Command.
+func (c *introspectCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "introspect",
+		Args:    "<machine|unit> <path>",
+		Purpose: usageIntrospectSummary,
+		Doc:     usageIntrospectDetails,
+	})
+}
+
+// Init implements Command.
+func (c *introspectCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no machine or unit specified")
+	case 1:
+		return errors.New("no path specified")
+	}
+	agent, err := parseAgentTag(args[0])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.agent = agent
+	c.path = args[1]
+	if !strings.HasPrefix(c.path, "/") {
+		c.path = "/" + c.path
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// parseAgentTag interprets s as a machine id, unit name, or machine
+// or unit tag.
+func parseAgentTag(s string) (names.Tag, error) {
+	switch {
+	case names.IsValidMachine(s):
+		return names.NewMachineTag(s), nil
+	case names.IsValidUnit(s):
+		return names.NewUnitTag(s), nil
+	}
+	tag, err := names.ParseTag(s)
+	if err == nil {
+		switch tag.(type) {
+		case names.MachineTag, names.UnitTag:
+			return tag, nil
+		}
+	}
+	return nil, errors.Errorf("%q is not a valid machine or unit", s)
+}
+
+// Run implements Command.
+func (c *introspectCommand) Run(ctx *cmd.Context) error {
+	client, err := getIntrospectAPI(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	response, err := client.Introspect(c.agent, c.path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprint(ctx.Stdout, response)
+	if !strings.HasSuffix(response, "\n") {
+		fmt.Fprintln(ctx.Stdout)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type IntrospectSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+
+	api *fakeIntrospectAPI
+}
+
+var _ = gc.Suite(&IntrospectSuite{})
+
+func (s *IntrospectSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeIntrospectAPI{response: "Dependency Engine Report\n"}
+	s.PatchValue(&getIntrospectAPI, func(*introspectCommand) (IntrospectAPI, error) {
+		return s.api, nil
+	})
+}
+
+func (s *IntrospectSuite) run(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, newIntrospectCommand(jujuclienttesting.MinimalStore()), args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx), nil
+}
+
+func (s *IntrospectSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		errMatch string
+	}{{
+		errMatch: "no machine or unit specified",
+	}, {
+		args:     []string{"0"},
+		errMatch: "no path specified",
+	}, {
+		args:     []string{"mysql", "/depengine"},
+		errMatch: `"mysql" is not a valid machine or unit`,
+	}, {
+		args:     []string{"application-mysql", "/depengine"},
+		errMatch: `"application-mysql" is not a valid machine or unit`,
+	}, {
+		args:     []string{"0", "/depengine", "extra"},
+		errMatch: `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.errMatch)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *IntrospectSuite) TestAgentArgs(c *gc.C) {
+	for i, test := range []struct {
+		arg      string
+		expected names.Tag
+	}{
+		{"0", names.NewMachineTag("0")},
+		{"0/lxd/1", names.NewMachineTag("0/lxd/1")},
+		{"machine-1", names.NewMachineTag("1")},
+		{"mysql/0", names.NewUnitTag("mysql/0")},
+		{"unit-mysql-1", names.NewUnitTag("mysql/1")},
+	} {
+		c.Logf("test %d: %s", i, test.arg)
+		s.api.ResetCalls()
+		_, err := s.run(c, test.arg, "/depengine")
+		c.Assert(err, jc.ErrorIsNil)
+		s.api.CheckCall(c, 0, "Introspect", test.expected, "/depengine")
+	}
+}
+
+func (s *IntrospectSuite) TestIntrospect(c *gc.C) {
+	out, err := s.run(c, "mysql/0", "depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "Dependency Engine Report\n")
+	s.api.CheckCalls(c, []jtesting.StubCall{
+		{"Introspect", []interface{}{names.NewUnitTag("mysql/0"), "/depengine"}},
+		{"Close", nil},
+	})
+}
+
+func (s *IntrospectSuite) TestIntrospectAddsTrailingNewline(c *gc.C) {
+	s.api.response = "42"
+	out, err := s.run(c, "0", "/metrics")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "42\n")
+}
+
+func (s *IntrospectSuite) TestIntrospectError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "0", "/depengine")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.api.CheckCallNames(c, "Introspect", "Close")
+}
+
+type fakeIntrospectAPI struct {
+	jtesting.Stub
+	response string
+}
+
+func (f *fakeIntrospectAPI) Introspect(agent names.Tag, path string) (string, error) {
+	f.MethodCall(f, "Introspect", agent, path)
+	if err := f.NextErr(); err != nil {
+		return "", err
+	}
+	return f.response, nil
+}
+
+func (f *fakeIntrospectAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -250,6 +250,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(crossmodel.NewShowOfferedEndpointCommand())
 	r.Register(crossmodel.NewListEndpointsCommand())
 	r.Register(crossmodel.NewFindEndpointsCommand())
+	r.Register(crossmodel.NewOfferRequestsCommand())
+	r.Register(crossmodel.NewApproveOfferCommand())
 	r.Register(application.NewConsumeCommand())
 	r.Register(application.NewSuspendRelationCommand())
 	r.Register(application.NewResumeRelationCommand())
@@ -276,6 +278,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewResolvedCommand())
 	r.Register(newDebugLogCommand(nil))
 	r.Register(newDebugHooksCommand(nil))
+	r.Register(newIntrospectCommand(nil))
 
 	// Configuration commands.
 	r.Register(model.NewModelGetConstraintsCommand())
@@ -284,6 +287,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newUpgradeJujuCommand())
 	r.Register(newUpgradeControllerCommand())
 	r.Register(application.NewUpgradeCharmCommand())
+	r.Register(application.NewShowUpgradeCommand())
+	r.Register(application.NewSetRefreshPolicyCommand())
 	r.Register(application.NewSetSeriesCommand())
 
 	// Charm tool commands.
@@ -342,6 +347,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	if featureflag.Enabled(feature.Generations) {
 		r.Register(model.NewBranchCommand())
 		r.Register(model.NewCommitCommand())
+		r.Register(model.NewAbortCommand())
 		r.Register(model.NewTrackBranchCommand())
 		r.Register(model.NewCheckoutCommand())
 		r.Register(model.NewDiffCommand())
@@ -361,6 +367,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
 	r.Register(action.NewCancelCommand())
+	r.Register(action.NewScheduleCommand())
+	r.Register(action.NewListSchedulesCommand())
+	r.Register(action.NewShowScheduleCommand())
+	r.Register(action.NewRemoveScheduleCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
@@ -375,6 +385,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewApplicationSetConstraintsCommand())
 	r.Register(application.NewBundleDiffCommand())
 	r.Register(application.NewShowApplicationCommand())
+	r.Register(application.NewUnitStateCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
@@ -392,6 +403,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewRemoveStorageCommandWithAPI())
 	r.Register(storage.NewDetachStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
+	r.Register(storage.NewSnapshotStorageCommand())
+	r.Register(storage.NewSnapshotListCommand())
+	r.Register(storage.NewResizeStorageCommand())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
 
 	// Manage spaces
@@ -423,6 +437,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
+	r.Register(controller.NewAuditLogCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -411,6 +411,7 @@ func assertNoArgs(c *gc.C, argChan <-chan []string) {
 }
 
 var commandNames = []string{
+	"action-schedules",
 	"actions",
 	"add-cloud",
 	"add-credential",
@@ -426,9 +427,11 @@ var commandNames = []string{
 	"add-user",
 	"agree",
 	"agreements",
+	"approve-offer",
 	"attach",
 	"attach-resource",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bootstrap",
@@ -479,7 +482,9 @@ var commandNames = []string{
 	"hook-tools",
 	"import-filesystem",
 	"import-ssh-key",
+	"introspect",
 	"kill-controller",
+	"list-action-schedules",
 	"list-actions",
 	"list-agreements",
 	"list-backups",
@@ -497,6 +502,7 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-snapshots",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
@@ -514,6 +520,7 @@ var commandNames = []string{
 	"model-defaults",
 	"models",
 	"offer",
+	"offer-requests",
 	"offers",
 	"payloads",
 	"plans",
@@ -521,6 +528,7 @@ var commandNames = []string{
 	"register",
 	"relate", //alias for add-relation
 	"reload-spaces",
+	"remove-action-schedule",
 	"remove-application",
 	"remove-backup",
 	"remove-cached-images",
@@ -539,6 +547,7 @@ var commandNames = []string{
 	"remove-user",
 	"resolved",
 	"resolve",
+	"resize-storage",
 	"resources",
 	"restore-backup",
 	"resume-relation",
@@ -548,6 +557,7 @@ var commandNames = []string{
 	"run",
 	"run-action",
 	"scale-application",
+	"schedule-action",
 	"scp",
 	"set-credential",
 	"set-constraints",
@@ -557,9 +567,11 @@ var commandNames = []string{
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
+	"set-refresh-policy",
 	"set-series",
 	"set-wallet",
 	"show-action-output",
+	"show-action-schedule",
 	"show-action-status",
 	"show-application",
 	"show-backup",
@@ -573,9 +585,12 @@ var commandNames = []string{
 	"show-status",
 	"show-status-log",
 	"show-storage",
+	"show-upgrade",
 	"show-user",
 	"show-wallet",
 	"sla",
+	"snapshot-storage",
+	"snapshots",
 	"spaces",
 	"ssh",
 	"ssh-keys",
@@ -589,6 +604,7 @@ var commandNames = []string{
 	"sync-tools",
 	"trust",
 	"unexpose",
+	"unit-state",
 	"unregister",
 	"update-cloud",
 	"update-clouds",
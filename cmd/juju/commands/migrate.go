@@ -4,11 +4,14 @@
 package commands
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
 	"gopkg.in/macaroon.v2-unstable"
@@ -33,6 +36,7 @@ func newMigrateCommand() modelcmd.ModelCommand {
 type migrateCommand struct {
 	modelcmd.ModelCommandBase
 	targetController string
+	dryRun           bool
 
 	// Overridden by tests
 	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error)
@@ -43,6 +47,7 @@ type migrateCommand struct {
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	DryRunMigration(spec controller.MigrationSpec) (controller.MigrationDryRunReport, error)
 	IdentityProviderURL() (string, error)
 	Close() error
 }
@@ -80,6 +85,19 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+The --dry-run option checks whether the model could be migrated without
+starting the migration. The migration prechecks are run against both
+controllers and the model is exported, but neither controller is
+changed. Any issues which would prevent the migration from succeeding
+are reported, along with an estimate of the amount of data which would
+be transferred to the target controller. The command fails if any
+blocking issues are found.
+
+Examples:
+
+    juju migrate mymodel target-controller
+    juju migrate --dry-run mymodel target-controller
+
 See also:
     login
     controllers
@@ -96,6 +114,12 @@ func (c *migrateCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the model could be migrated, without migrating it")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -128,6 +152,12 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	spec.ModelUUID = uuids[0]
+	if c.dryRun {
+		// The controller checks that the model's users exist on the
+		// target controller as part of the dry run, and reports any
+		// missing users along with the other issues it finds.
+		return c.dryRunMigration(ctx, modelName, spec)
+	}
 	if err := c.checkMigrationFeasibility(spec); err != nil {
 		return errors.Trace(err)
 	}
@@ -148,6 +178,40 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *migrateCommand) dryRunMigration(ctx *cmd.Context, modelName string, spec *controller.MigrationSpec) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return err
+	}
+	api, err := c.getMigrationAPI(controllerName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+	report, err := api.DryRunMigration(*spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	total := report.ModelDescriptionSize + report.CharmsSize + report.ToolsSize + report.ResourcesSize
+	fmt.Fprintf(ctx.Stdout, "Estimated data to transfer to controller %q:\n", c.targetController)
+	fmt.Fprintf(ctx.Stdout, "  model description: %s\n", humanize.IBytes(uint64(report.ModelDescriptionSize)))
+	fmt.Fprintf(ctx.Stdout, "  charms:            %s\n", humanize.IBytes(uint64(report.CharmsSize)))
+	fmt.Fprintf(ctx.Stdout, "  agent binaries:    %s\n", humanize.IBytes(uint64(report.ToolsSize)))
+	fmt.Fprintf(ctx.Stdout, "  resources:         %s\n", humanize.IBytes(uint64(report.ResourcesSize)))
+	fmt.Fprintf(ctx.Stdout, "  total:             %s\n", humanize.IBytes(uint64(total)))
+
+	if len(report.Issues) == 0 {
+		ctx.Infof("Model %q can be migrated to controller %q", modelName, c.targetController)
+		return nil
+	}
+	fmt.Fprintf(ctx.Stdout, "Blocking issues:\n")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(ctx.Stdout, "  - %s\n", strings.Replace(issue, "\n", "\n    ", -1))
+	}
+	return errors.Errorf("model %q cannot be migrated to controller %q", modelName, c.targetController)
+}
+
 func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	store := c.ClientStore()
 
@@ -288,6 +288,56 @@ func (s *MigrateSuite) TestSuccessMacaroons(c *gc.C) {
 	})
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	s.api.dryRunReport = controller.MigrationDryRunReport{
+		ModelDescriptionSize: 2048,
+		CharmsSize:           3 * 1024 * 1024,
+		ToolsSize:            1024 * 1024,
+		ResourcesSize:        0,
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Estimated data to transfer to controller "target":
+  model description: 2.0 KiB
+  charms:            3.0 MiB
+  agent binaries:    1.0 MiB
+  resources:         0 B
+  total:             4.0 MiB
+`[1:])
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Model \"model\" can be migrated to controller \"target\"\n")
+	c.Check(s.api.specSeen, gc.IsNil) // Migration shouldn't have been started.
+	c.Check(s.api.dryRunSpec, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:             modelUUID,
+		TargetControllerUUID:  targetControllerUUID,
+		TargetControllerAlias: "target",
+		TargetAddrs:           []string{"1.2.3.4:5"},
+		TargetCACert:          "cert",
+		TargetUser:            "targetuser",
+		TargetPassword:        "secret",
+	})
+}
+
+func (s *MigrateSuite) TestDryRunIssues(c *gc.C) {
+	s.api.dryRunReport = controller.MigrationDryRunReport{
+		Issues: []string{
+			"source prechecks failed: machine 0 is dying",
+			"users missing:\n  - bob",
+		},
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, `model "model" cannot be migrated to controller "target"`)
+
+	c.Check(cmdtesting.Stdout(ctx), jc.HasSuffix, `
+Blocking issues:
+  - source prechecks failed: machine 0 is dying
+  - users missing:
+      - bob
+`)
+	c.Check(s.api.specSeen, gc.IsNil) // Migration shouldn't have been started.
+}
+
 func (s *MigrateSuite) TestModelDoesntExist(c *gc.C) {
 	cmd := s.makeCommand()
 	_, err := cmdtesting.RunCommand(c, cmd, "wat", "target")
@@ -439,8 +489,10 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 }
 
 type fakeMigrateAPI struct {
-	specSeen    *controller.MigrationSpec
-	identityURL string
+	specSeen     *controller.MigrationSpec
+	identityURL  string
+	dryRunReport controller.MigrationDryRunReport
+	dryRunSpec   *controller.MigrationSpec
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
@@ -448,6 +500,11 @@ func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (strin
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) DryRunMigration(spec controller.MigrationSpec) (controller.MigrationDryRunReport, error) {
+	a.dryRunSpec = &spec
+	return a.dryRunReport, nil
+}
+
 func (a *fakeMigrateAPI) IdentityProviderURL() (string, error) {
 	return a.identityURL, nil
 }
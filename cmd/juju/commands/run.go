@@ -5,15 +5,19 @@ package commands
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/naturalsort"
 	"github.com/juju/utils"
 	"gopkg.in/juju/names.v2"
 
@@ -23,6 +27,8 @@ import (
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/jujuclient"
 )
 
@@ -55,6 +61,10 @@ type runCommand struct {
 	applications []string
 	units        []string
 	commands     string
+	batchSize    int
+	batchDelay   time.Duration
+	failFast     bool
+	stream       bool
 	timeAfter    func(time.Duration) <-chan time.Time
 }
 
@@ -96,11 +106,31 @@ targets.
 Since juju run creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
 
+By default the command is run on all targets at once. --batch-size runs
+the command on at most that many targets at a time, waiting for each batch
+to finish (and for --batch-delay, if given) before starting the next. The
+--timeout applies to each batch. Applications are expanded to their units,
+and --all to every machine, when the batches are worked out.
+
+--fail-fast stops before the next batch once any target in a batch exits
+with a non-zero code or fails to run; the targets that were not run are
+reported in the error.
+
+--stream writes the output of each target as it is produced, with every
+line prefixed by the unit name (or machine tag) it came from. Standard
+error of the command is written to standard error. Output from machines
+is written once the command completes on the machine.
+
 If you need to pass options to the command being run, you must precede the
 command and its arguments with "--", to tell "juju run" to stop processing
 those arguments. For example:
 
     juju run --all -- hostname -f
+
+To restart a service two units at a time, showing the output as it
+happens and stopping at the first failure:
+
+    juju run --application mysql --batch-size 2 --stream --fail-fast -- sudo systemctl restart mysql
 `
 
 func (c *runCommand) Info() *cmd.Info {
@@ -128,6 +158,10 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.applications), "application", "")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "u", "One or more unit ids")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "")
+	f.IntVar(&c.batchSize, "batch-size", 0, "Run the commands on at most this many targets at a time")
+	f.DurationVar(&c.batchDelay, "batch-delay", 0, "How long to wait between batches")
+	f.BoolVar(&c.failFast, "fail-fast", false, "Do not run any more batches once the commands fail on a target")
+	f.BoolVar(&c.stream, "stream", false, "Write the output of each target as it runs, prefixed by the target name")
 }
 
 func (c *runCommand) Init(args []string) error {
@@ -186,6 +220,21 @@ func (c *runCommand) Init(args []string) error {
 			strings.Join(nameErrors, "\n"))
 	}
 
+	if c.batchSize < 0 {
+		return errors.Errorf("--batch-size must not be negative")
+	}
+	if c.batchDelay < 0 {
+		return errors.Errorf("--batch-delay must not be negative")
+	}
+	if c.batchSize == 0 {
+		if c.batchDelay != 0 {
+			return errors.Errorf("--batch-delay requires --batch-size")
+		}
+		if c.failFast {
+			return errors.Errorf("--fail-fast requires --batch-size")
+		}
+	}
+
 	return nil
 }
 
@@ -244,10 +293,11 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
-	var runResults []params.ActionResult
-	if c.all {
-		runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
-	} else {
+	if c.stream && client.BestAPIVersion() < 6 {
+		return errors.Errorf("streaming output is unsupported by this API" +
+			"\neither upgrade your controller, or run without --stream")
+	}
+	if !c.all {
 		// Make sure the server supports <application>/leader syntax
 		for _, unit := range c.units {
 			if validLeader.MatchString(unit) && client.BestAPIVersion() < 3 {
@@ -257,13 +307,22 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 					"\neither upgrade your controller, or explicitly specify a unit", app)
 			}
 		}
+	}
+	if c.batchSize > 0 {
+		return c.runBatches(ctx, client)
+	}
 
+	var runResults []params.ActionResult
+	if c.all {
+		runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
+	} else {
 		params := params.RunParams{
 			Commands:     c.commands,
 			Timeout:      c.timeout,
 			Machines:     c.machines,
 			Applications: c.applications,
 			Units:        c.units,
+			StreamOutput: c.stream,
 		}
 		runResults, err = client.Run(params)
 	}
@@ -272,6 +331,175 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
 
+	values, actionsToQuery, err := c.waitForResults(ctx, client, runResults)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.stream {
+		return streamedResultsError(values, actionsToQuery)
+	}
+	return c.writeResults(ctx, values, actionsToQuery)
+}
+
+// runBatches runs the commands on the targets batchSize at a time,
+// waiting for each batch to complete before starting the next.
+func (c *runCommand) runBatches(ctx *cmd.Context, client RunClient) error {
+	targets, err := c.expandTargets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		values         []interface{}
+		actionsToQuery []actionQuery
+		failed         string
+		notRun         []string
+	)
+	for start := 0; start < len(targets); start += c.batchSize {
+		if failed != "" {
+			for _, target := range targets[start:] {
+				notRun = append(notRun, target.String())
+			}
+			break
+		}
+		if start > 0 && c.batchDelay > 0 {
+			<-c.timeAfter(c.batchDelay)
+		}
+		end := start + c.batchSize
+		if end > len(targets) {
+			end = len(targets)
+		}
+		runParams := params.RunParams{
+			Commands:     c.commands,
+			Timeout:      c.timeout,
+			StreamOutput: c.stream,
+		}
+		for _, target := range targets[start:end] {
+			if target.machine {
+				runParams.Machines = append(runParams.Machines, target.name)
+			} else {
+				runParams.Units = append(runParams.Units, target.name)
+			}
+		}
+		runResults, err := client.Run(runParams)
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		batchValues, batchActionsToQuery, err := c.waitForResults(ctx, client, runResults)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		values = append(values, batchValues...)
+		actionsToQuery = append(actionsToQuery, batchActionsToQuery...)
+
+		if c.failFast {
+			if failures := failedTargets(batchValues); len(failures) > 0 {
+				failed = failures[0]
+			} else if len(batchActionsToQuery) > 0 {
+				failed = names.ReadableString(batchActionsToQuery[0].receiver.tag)
+			}
+		}
+	}
+
+	if c.stream {
+		err = streamedResultsError(values, actionsToQuery)
+	} else {
+		err = c.writeResults(ctx, values, actionsToQuery)
+	}
+	if len(notRun) == 0 {
+		return err
+	}
+	if err != nil && !cmd.IsRcPassthroughError(err) {
+		fmt.Fprintf(ctx.GetStderr(), "%v\n", err)
+	}
+	return errors.Errorf("stopped after failure on %s, not run on: %s", failed, strings.Join(notRun, ", "))
+}
+
+// runTarget is a unit or machine that the commands are run on.
+type runTarget struct {
+	name    string
+	machine bool
+}
+
+// String returns a readable representation of the target.
+func (t runTarget) String() string {
+	if t.machine {
+		return "machine " + t.name
+	}
+	return "unit " + t.name
+}
+
+// expandTargets returns the individual units and machines selected by
+// the command's arguments. Applications are replaced by their units,
+// and --all by every machine in the model.
+func (c *runCommand) expandTargets() ([]runTarget, error) {
+	var (
+		targets []runTarget
+		seen    = make(map[runTarget]bool)
+	)
+	add := func(target runTarget) {
+		if !seen[target] {
+			seen[target] = true
+			targets = append(targets, target)
+		}
+	}
+	for _, unit := range c.units {
+		add(runTarget{name: unit})
+	}
+	for _, machine := range c.machines {
+		add(runTarget{name: machine, machine: true})
+	}
+	if len(c.applications) == 0 && !c.all {
+		return targets, nil
+	}
+
+	client, err := getRunStatusClient(c)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+	status, err := client.Status(nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, application := range c.applications {
+		appStatus, ok := status.Applications[application]
+		if !ok {
+			return nil, errors.NotFoundf("application %q", application)
+		}
+		units := make([]string, 0, len(appStatus.Units))
+		for unit := range appStatus.Units {
+			units = append(units, unit)
+		}
+		naturalsort.Sort(units)
+		for _, unit := range units {
+			add(runTarget{name: unit})
+		}
+	}
+	if c.all {
+		var machines []string
+		var addMachines func(map[string]params.MachineStatus)
+		addMachines = func(statuses map[string]params.MachineStatus) {
+			for id, machine := range statuses {
+				machines = append(machines, id)
+				addMachines(machine.Containers)
+			}
+		}
+		addMachines(status.Machines)
+		naturalsort.Sort(machines)
+		for _, machine := range machines {
+			add(runTarget{name: machine, machine: true})
+		}
+	}
+	return targets, nil
+}
+
+// waitForResults waits for the actions queued to run the commands to
+// complete, returning the formatted results of those that completed
+// and the queries for any still running when the timeout expired.
+func (c *runCommand) waitForResults(
+	ctx *cmd.Context, client RunClient, runResults []params.ActionResult,
+) ([]interface{}, []actionQuery, error) {
 	actionsToQuery := []actionQuery{}
 	for _, result := range runResults {
 		if result.Error != nil {
@@ -306,7 +534,16 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 
 	if len(actionsToQuery) == 0 {
-		return errors.New("no actions were successfully enqueued, aborting")
+		return nil, nil, errors.New("no actions were successfully enqueued, aborting")
+	}
+
+	var streamer *runOutputStreamer
+	if c.stream {
+		streamer = newRunOutputStreamer(client, ctx.Stdout, ctx.Stderr)
+		defer streamer.stopAll()
+		for _, query := range actionsToQuery {
+			streamer.watch(query)
+		}
 	}
 
 	timeout := c.timeAfter(c.timeout)
@@ -314,7 +551,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	for len(actionsToQuery) > 0 {
 		actionResults, err := client.Actions(entities(actionsToQuery))
 		if err != nil {
-			return errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 
 		newActionsToQuery := []actionQuery{}
@@ -327,7 +564,11 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 				}
 			}
 
-			values = append(values, ConvertActionResults(result, actionsToQuery[i]))
+			value := ConvertActionResults(result, actionsToQuery[i])
+			if streamer != nil {
+				streamer.finish(actionsToQuery[i], value)
+			}
+			values = append(values, value)
 		}
 		actionsToQuery = newActionsToQuery
 
@@ -347,7 +588,12 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 			}
 		}
 	}
+	return values, actionsToQuery, nil
+}
 
+// writeResults writes the results of the commands in the requested
+// format, returning an error if any of the actions did not complete.
+func (c *runCommand) writeResults(ctx *cmd.Context, values []interface{}, actionsToQuery []actionQuery) error {
 	// If we are just dealing with one result, AND we are using the default
 	// format, then pretend we were running it locally.
 	if len(actionsToQuery) == 0 && len(values) == 1 && c.out.Name() == "default" {
@@ -376,23 +622,72 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 			return err
 		}
 	}
+	return timedOutError(actionsToQuery)
+}
+
+// timedOutError returns an error naming the receivers of the actions
+// that did not complete, or nil if there are none.
+func timedOutError(actionsToQuery []actionQuery) error {
+	n := len(actionsToQuery)
+	if n == 0 {
+		return nil
+	}
+	// There are action results remaining, so return an error.
+	suffix := ""
+	if n > 1 {
+		suffix = "s"
+	}
+	receivers := make([]string, n)
+	for i, actionToQuery := range actionsToQuery {
+		receivers[i] = names.ReadableString(actionToQuery.receiver.tag)
+	}
+	return errors.Errorf(
+		"timed out waiting for result%s from: %s",
+		suffix, strings.Join(receivers, ", "),
+	)
+}
+
+// streamedResultsError returns an error naming the targets on which the
+// commands failed or did not complete, once their output has already
+// been streamed.
+func streamedResultsError(values []interface{}, actionsToQuery []actionQuery) error {
+	var messages []string
+	if failures := failedTargets(values); len(failures) > 0 {
+		messages = append(messages, "commands failed on: "+strings.Join(failures, ", "))
+	}
+	if err := timedOutError(actionsToQuery); err != nil {
+		messages = append(messages, err.Error())
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(messages, "\n"))
+}
 
-	if n := len(actionsToQuery); n > 0 {
-		// There are action results remaining, so return an error.
-		suffix := ""
-		if n > 1 {
-			suffix = "s"
+// failedTargets returns the readable names of the targets whose results
+// report an error, a message or a non-zero exit code.
+func failedTargets(values []interface{}) []string {
+	var failed []string
+	for _, value := range values {
+		result, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		_, isError := result["Error"]
+		code, _ := result["ReturnCode"].(int)
+		message, _ := result["Message"].(string)
+		if !isError && code == 0 && message == "" {
+			continue
 		}
-		receivers := make([]string, n)
-		for i, actionToQuery := range actionsToQuery {
-			receivers[i] = names.ReadableString(actionToQuery.receiver.tag)
+		if id, ok := result["UnitId"]; ok {
+			failed = append(failed, fmt.Sprintf("unit %v", id))
+		} else if id, ok := result["MachineId"]; ok {
+			failed = append(failed, fmt.Sprintf("machine %v", id))
+		} else {
+			failed = append(failed, fmt.Sprintf("%v", result["ReceiverId"]))
 		}
-		return errors.Errorf(
-			"timed out waiting for result%s from: %s",
-			suffix, strings.Join(receivers, ", "),
-		)
 	}
-	return nil
+	return failed
 }
 
 type actionReceiver struct {
@@ -422,6 +717,23 @@ var getRunAPIClient = func(c *runCommand) (RunClient, error) {
 	return actionapi.NewClient(root), errors.Trace(err)
 }
 
+// RunStatusClient exposes the capabilities required to expand the
+// targets of the commands into batches.
+type RunStatusClient interface {
+	Close() error
+	Status(patterns []string) (*params.FullStatus, error)
+}
+
+// getRunStatusClient is used to retrieve the client used to expand
+// the targets of the commands, so that it can be mocked out in tests.
+var getRunStatusClient = func(c *runCommand) (RunStatusClient, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
+
 // getActionResult abstracts over the action CLI function that we use here to fetch results
 var getActionResult = func(c RunClient, actionId string, wait *time.Timer) (params.ActionResult, error) {
 	return action.GetActionResult(c, actionId, wait)
@@ -457,3 +769,177 @@ func formatOutput(results map[string]interface{}, key string) []byte {
 	}
 	return []byte(res)
 }
+
+// runOutputStreamer writes the output of juju-run actions as it is
+// logged, prefixing each line with the name of the target it came from.
+type runOutputStreamer struct {
+	client RunClient
+	stdout io.Writer
+	stderr io.Writer
+
+	mu      sync.Mutex
+	streams map[string]*runOutputStream
+}
+
+// runOutputStream tracks the output streamed for a single action.
+type runOutputStream struct {
+	prefix  string
+	stdout  int
+	stderr  int
+	watcher watcher.StringsWatcher
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newRunOutputStreamer(client RunClient, stdout, stderr io.Writer) *runOutputStreamer {
+	return &runOutputStreamer{
+		client:  client,
+		stdout:  stdout,
+		stderr:  stderr,
+		streams: make(map[string]*runOutputStream),
+	}
+}
+
+// runTargetPrefix returns the prefix for the lines of output written
+// by the receiver with the given tag.
+func runTargetPrefix(tag names.Tag) string {
+	if _, ok := tag.(names.UnitTag); ok {
+		return tag.Id() + ": "
+	}
+	return tag.String() + ": "
+}
+
+// watch starts streaming the output of the queried action. Only units
+// log their output as it is written; the output of other receivers is
+// written when their action completes.
+func (s *runOutputStreamer) watch(query actionQuery) {
+	stream := &runOutputStream{prefix: runTargetPrefix(query.receiver.tag)}
+	s.streams[query.actionTag.Id()] = stream
+	if _, ok := query.receiver.tag.(names.UnitTag); !ok {
+		return
+	}
+	w, err := s.client.WatchActionProgress(query.actionTag.Id())
+	if err != nil {
+		logger.Warningf("cannot stream output of %s: %v", names.ReadableString(query.receiver.tag), err)
+		return
+	}
+	stream.watcher = w
+	stream.stop = make(chan struct{})
+	stream.done = make(chan struct{})
+	go s.loop(stream)
+}
+
+func (s *runOutputStreamer) loop(stream *runOutputStream) {
+	defer close(stream.done)
+	for {
+		select {
+		case <-stream.stop:
+			// Write out any messages that are already waiting.
+			select {
+			case messages, ok := <-stream.watcher.Changes():
+				if ok {
+					s.write(stream, messages)
+				}
+			default:
+			}
+			return
+		case messages, ok := <-stream.watcher.Changes():
+			if !ok {
+				return
+			}
+			s.write(stream, messages)
+		}
+	}
+}
+
+// write writes the lines of output carried by the given JSON encoded
+// action messages; other progress messages are ignored.
+func (s *runOutputStreamer) write(stream *runOutputStream, messages []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, m := range messages {
+		var msg params.ActionMessage
+		if err := json.Unmarshal([]byte(m), &msg); err != nil {
+			logger.Warningf("cannot decode action message %q: %v", m, err)
+			continue
+		}
+		switch {
+		case strings.HasPrefix(msg.Message, actions.JujuRunStdoutPrefix):
+			lines := strings.Split(strings.TrimPrefix(msg.Message, actions.JujuRunStdoutPrefix), "\n")
+			for _, line := range lines {
+				fmt.Fprintf(s.stdout, "%s%s\n", stream.prefix, line)
+			}
+			stream.stdout += len(lines)
+		case strings.HasPrefix(msg.Message, actions.JujuRunStderrPrefix):
+			lines := strings.Split(strings.TrimPrefix(msg.Message, actions.JujuRunStderrPrefix), "\n")
+			for _, line := range lines {
+				fmt.Fprintf(s.stderr, "%s%s\n", stream.prefix, line)
+			}
+			stream.stderr += len(lines)
+		}
+	}
+}
+
+// stopStream stops streaming the output of an action, once any output
+// already received has been written.
+func (s *runOutputStreamer) stopStream(stream *runOutputStream) {
+	if stream.watcher == nil {
+		return
+	}
+	close(stream.stop)
+	<-stream.done
+	stream.watcher.Kill()
+	if err := stream.watcher.Wait(); err != nil {
+		logger.Debugf("streaming action output: %v", err)
+	}
+	stream.watcher = nil
+}
+
+// finish stops streaming the output of the queried action, and writes
+// the rest of its output, along with any error, from its results.
+func (s *runOutputStreamer) finish(query actionQuery, result map[string]interface{}) {
+	stream, ok := s.streams[query.actionTag.Id()]
+	if !ok {
+		stream = &runOutputStream{prefix: runTargetPrefix(query.receiver.tag)}
+	}
+	s.stopStream(stream)
+	delete(s.streams, query.actionTag.Id())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writePrefixedLines(s.stdout, stream.prefix, formatOutput(result, "Stdout"), stream.stdout)
+	writePrefixedLines(s.stderr, stream.prefix, formatOutput(result, "Stderr"), stream.stderr)
+	if res, ok := result["Error"].(string); ok {
+		fmt.Fprintf(s.stderr, "%serror: %s\n", stream.prefix, res)
+	}
+	if res, ok := result["Message"].(string); ok && res != "" {
+		fmt.Fprintf(s.stderr, "%s%s\n", stream.prefix, res)
+	}
+	if code, ok := result["ReturnCode"].(int); ok && code != 0 {
+		fmt.Fprintf(s.stderr, "%sexit code %d\n", stream.prefix, code)
+	}
+}
+
+// stopAll stops streaming the output of all the actions still running.
+func (s *runOutputStreamer) stopAll() {
+	for id, stream := range s.streams {
+		s.stopStream(stream)
+		delete(s.streams, id)
+	}
+}
+
+// writePrefixedLines writes the lines of output after the first skip
+// lines, which have already been streamed, each with the given prefix.
+func writePrefixedLines(w io.Writer, prefix string, output []byte, skip int) {
+	text := strings.Replace(string(output), "\r\n", "\n", -1)
+	if text == "" {
+		return
+	}
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if skip >= len(lines) {
+		return
+	}
+	for _, line := range lines[skip:] {
+		fmt.Fprintf(w, "%s%s\n", prefix, line)
+	}
+}
@@ -5,6 +5,7 @@ package commands
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"time"
@@ -23,6 +24,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	"github.com/juju/juju/testing"
 )
@@ -190,6 +193,59 @@ func (*RunSuite) TestTimeoutArgParsing(c *gc.C) {
 	}
 }
 
+func (*RunSuite) TestBatchArgParsing(c *gc.C) {
+	for i, test := range []struct {
+		message    string
+		args       []string
+		errMatch   string
+		batchSize  int
+		batchDelay time.Duration
+		failFast   bool
+		stream     bool
+	}{{
+		message: "defaults",
+		args:    []string{"--all", "uptime"},
+	}, {
+		message:    "batches",
+		args:       []string{"--batch-size=2", "--batch-delay=30s", "--fail-fast", "--all", "uptime"},
+		batchSize:  2,
+		batchDelay: 30 * time.Second,
+		failFast:   true,
+	}, {
+		message: "stream",
+		args:    []string{"--stream", "--all", "uptime"},
+		stream:  true,
+	}, {
+		message:  "negative batch size",
+		args:     []string{"--batch-size=-1", "--all", "uptime"},
+		errMatch: "--batch-size must not be negative",
+	}, {
+		message:  "negative batch delay",
+		args:     []string{"--batch-size=1", "--batch-delay=-1s", "--all", "uptime"},
+		errMatch: "--batch-delay must not be negative",
+	}, {
+		message:  "batch delay without batch size",
+		args:     []string{"--batch-delay=1s", "--all", "uptime"},
+		errMatch: "--batch-delay requires --batch-size",
+	}, {
+		message:  "fail fast without batch size",
+		args:     []string{"--fail-fast", "--all", "uptime"},
+		errMatch: "--fail-fast requires --batch-size",
+	}} {
+		c.Log(fmt.Sprintf("%v: %s", i, test.message))
+		cmd := &runCommand{}
+		cmd.SetClientStore(jujuclienttesting.MinimalStore())
+		runCmd := modelcmd.Wrap(cmd)
+		cmdtesting.TestInit(c, runCmd, test.args, test.errMatch)
+		if test.errMatch == "" {
+			c.Check(cmd.batchSize, gc.Equals, test.batchSize)
+			c.Check(cmd.batchDelay, gc.Equals, test.batchDelay)
+			c.Check(cmd.failFast, gc.Equals, test.failFast)
+			c.Check(cmd.stream, gc.Equals, test.stream)
+		}
+	}
+}
+
 func (s *RunSuite) TestConvertRunResults(c *gc.C) {
 	for i, test := range []struct {
 		message  string
@@ -427,6 +483,222 @@ func (s *RunSuite) TestUnitLeaderSyntaxWithUnsupportedAPIVersion(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, expErr)
 }
 
+func (s *RunSuite) setUnitResponses(c *gc.C, mock *mockRunAPI, responses ...mockResponse) []interface{} {
+	mock.actionResponses = make(map[string]params.ActionResult)
+	var values []interface{}
+	for _, response := range responses {
+		tag, err := names.ParseUnitTag(response.unitTag)
+		c.Assert(err, jc.ErrorIsNil)
+		mock.setResponse(tag.Id(), response)
+		result := mock.runResponses[tag.Id()]
+		mock.actionResponses[mock.receiverIdMap[tag.Id()]] = result
+		query := makeActionQuery(mock.receiverIdMap[tag.Id()], "UnitId", tag)
+		values = append(values, ConvertActionResults(result, query))
+	}
+	return values
+}
+
+func (s *RunSuite) TestRunInBatches(c *gc.C) {
+	mock := s.setupMockAPI()
+	values := s.setUnitResponses(c, mock,
+		mockResponse{stdout: "one\n", unitTag: "unit-unit-0"},
+		mockResponse{stdout: "two\n", unitTag: "unit-unit-1"},
+		mockResponse{stdout: "three\n", unitTag: "unit-unit-2"},
+	)
+	var buf bytes.Buffer
+	err := cmd.FormatJson(&buf, values)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var clock readyClock
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&clock),
+		"--format=json", "--unit=unit/0,unit/1,unit/2", "--batch-size=2", "--batch-delay=10s", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, buf.String())
+	c.Check(mock.runCalls, jc.DeepEquals, []params.RunParams{{
+		Commands: "hostname",
+		Timeout:  5 * time.Minute,
+		Units:    []string{"unit/0", "unit/1"},
+	}, {
+		Commands: "hostname",
+		Timeout:  5 * time.Minute,
+		Units:    []string{"unit/2"},
+	}})
+	clock.CheckCalls(c, []gitjujutesting.StubCall{
+		{"After", []interface{}{5 * time.Minute}},
+		{"After", []interface{}{10 * time.Second}},
+		{"After", []interface{}{5 * time.Minute}},
+	})
+}
+
+func (s *RunSuite) TestRunInBatchesExpandsApplications(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.setupMockStatus(&params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {Units: map[string]params.UnitStatus{
+				"mysql/10": {}, "mysql/0": {}, "mysql/1": {},
+			}},
+		},
+	})
+	s.setUnitResponses(c, mock,
+		mockResponse{unitTag: "unit-mysql-0"},
+		mockResponse{unitTag: "unit-mysql-1"},
+		mockResponse{unitTag: "unit-mysql-10"},
+	)
+
+	_, err := cmdtesting.RunCommand(c, newTestRunCommand(&readyClock{}),
+		"--format=json", "--application=mysql", "--batch-size=2", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mock.runCalls, gc.HasLen, 2)
+	c.Check(mock.runCalls[0].Units, jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+	c.Check(mock.runCalls[1].Units, jc.DeepEquals, []string{"mysql/10"})
+}
+
+func (s *RunSuite) TestRunInBatchesUnknownApplication(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.setupMockStatus(&params.FullStatus{})
+
+	_, err := cmdtesting.RunCommand(c, newTestRunCommand(&readyClock{}),
+		"--application=mysql", "--batch-size=2", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, `application "mysql" not found`)
+	c.Check(mock.runCalls, gc.HasLen, 0)
+}
+
+func (s *RunSuite) TestRunInBatchesExpandsAllMachines(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.setupMockStatus(&params.FullStatus{
+		Machines: map[string]params.MachineStatus{
+			"1": {},
+			"0": {Containers: map[string]params.MachineStatus{
+				"0/lxd/0": {},
+			}},
+		},
+	})
+	mock.actionResponses = make(map[string]params.ActionResult)
+	for _, id := range []string{"0", "0/lxd/0", "1"} {
+		mock.setResponse(id, mockResponse{machineTag: names.NewMachineTag(id).String()})
+		mock.actionResponses[mock.receiverIdMap[id]] = mock.runResponses[id]
+	}
+
+	_, err := cmdtesting.RunCommand(c, newTestRunCommand(&readyClock{}),
+		"--format=json", "--all", "--batch-size=5", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mock.runCalls, gc.HasLen, 1)
+	c.Check(mock.runCalls[0].Machines, jc.DeepEquals, []string{"0", "0/lxd/0", "1"})
+}
+
+func (s *RunSuite) TestRunInBatchesFailFast(c *gc.C) {
+	mock := s.setupMockAPI()
+	values := s.setUnitResponses(c, mock,
+		mockResponse{stderr: "oops\n", code: "1", unitTag: "unit-unit-0"},
+		mockResponse{unitTag: "unit-unit-1"},
+		mockResponse{unitTag: "unit-unit-2"},
+	)
+	var buf bytes.Buffer
+	err := cmd.FormatJson(&buf, values[:1])
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&readyClock{}),
+		"--format=json", "--unit=unit/0,unit/1,unit/2", "--batch-size=1", "--fail-fast", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "stopped after failure on unit unit/0, not run on: unit unit/1, unit unit/2")
+	c.Check(cmdtesting.Stdout(context), gc.Equals, buf.String())
+	c.Check(mock.runCalls, gc.HasLen, 1)
+}
+
+func (s *RunSuite) TestRunInBatchesWithoutFailFast(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.setUnitResponses(c, mock,
+		mockResponse{code: "1", unitTag: "unit-unit-0"},
+		mockResponse{unitTag: "unit-unit-1"},
+	)
+
+	_, err := cmdtesting.RunCommand(c, newTestRunCommand(&readyClock{}),
+		"--format=json", "--unit=unit/0,unit/1", "--batch-size=1", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mock.runCalls, gc.HasLen, 2)
+}
+
+func (s *RunSuite) TestRunStreamOutput(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.bestAPIVersion = 6
+	s.setUnitResponses(c, mock, mockResponse{
+		stdout:  "hello\nbig\nworld\n",
+		stderr:  "oops\n",
+		code:    "1",
+		unitTag: "unit-unit-0",
+	})
+	mock.progress = map[string][]string{
+		mock.receiverIdMap["unit/0"]: {
+			actionMessage(c, "stdout: hello\nbig"),
+			actionMessage(c, "some other progress"),
+			actionMessage(c, "stderr: oops"),
+		},
+	}
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&readyClock{}),
+		"--unit=unit/0", "--stream", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "commands failed on: unit unit/0")
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "unit/0: hello\nunit/0: big\nunit/0: world\n")
+	c.Check(cmdtesting.Stderr(context), gc.Equals, "unit/0: oops\nunit/0: exit code 1\n")
+	c.Check(mock.runCalls, jc.DeepEquals, []params.RunParams{{
+		Commands:     "hostname",
+		Timeout:      5 * time.Minute,
+		Units:        []string{"unit/0"},
+		StreamOutput: true,
+	}})
+}
+
+func (s *RunSuite) TestRunStreamOutputMachine(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.bestAPIVersion = 6
+	mock.setResponse("0", mockResponse{stdout: "megatron\n", machineTag: "machine-0"})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]: mock.runResponses["0"],
+	}
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&readyClock{}),
+		"--machine=0", "--stream", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "machine-0: megatron\n")
+	c.Check(cmdtesting.Stderr(context), gc.Equals, "")
+}
+
+func (s *RunSuite) TestRunStreamOutputUnsupportedAPIVersion(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.bestAPIVersion = 5
+	_, err := cmdtesting.RunCommand(c, newTestRunCommand(&readyClock{}),
+		"--unit=unit/0", "--stream", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "streaming output is unsupported by this API\n.*")
+	c.Check(mock.runCalls, gc.HasLen, 0)
+}
+
+func actionMessage(c *gc.C, message string) string {
+	data, err := json.Marshal(params.ActionMessage{Message: message})
+	c.Assert(err, jc.ErrorIsNil)
+	return string(data)
+}
+
+// readyClock records the durations waited for, without ever blocking.
+type readyClock struct {
+	gitjujutesting.Stub
+	clock.Clock
+}
+
+func (c *readyClock) After(d time.Duration) <-chan time.Time {
+	c.MethodCall(c, "After", d)
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
 type mockClock struct {
 	gitjujutesting.Stub
 	clock.Clock
@@ -541,6 +813,12 @@ func (s *RunSuite) setupMockAPI() *mockRunAPI {
 	return mock
 }
 
+func (s *RunSuite) setupMockStatus(status *params.FullStatus) {
+	s.PatchValue(&getRunStatusClient, func(_ *runCommand) (RunStatusClient, error) {
+		return &mockRunStatusClient{status: status}, nil
+	})
+}
+
 type mockRunAPI struct {
 	action.APIClient
 	stdout string
@@ -552,10 +830,24 @@ type mockRunAPI struct {
 	actionResponses map[string]params.ActionResult
 	receiverIdMap   map[string]string
 	block           bool
+	runCalls        []params.RunParams
+	progress        map[string][]string
 	//
 	bestAPIVersion int
 }
 
+type mockRunStatusClient struct {
+	status *params.FullStatus
+}
+
+func (*mockRunStatusClient) Close() error {
+	return nil
+}
+
+func (m *mockRunStatusClient) Status(patterns []string) (*params.FullStatus, error) {
+	return m.status, nil
+}
+
 type mockResponse struct {
 	stdout     interface{}
 	stderr     interface{}
@@ -661,6 +953,7 @@ func (m *mockRunAPI) RunOnAllMachines(commands string, timeout time.Duration) ([
 
 func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.ActionResult, error) {
 	var result []params.ActionResult
+	m.runCalls = append(m.runCalls, runParams)
 
 	if m.block {
 		return result, common.OperationBlockedError("the operation has been blocked")
@@ -706,5 +999,11 @@ func (m *mockRunAPI) BestAPIVersion() int {
 	return m.bestAPIVersion
 }
 
+func (m *mockRunAPI) WatchActionProgress(actionId string) (watcher.StringsWatcher, error) {
+	changes := make(chan []string, 1)
+	changes <- m.progress[actionId]
+	return watchertest.NewMockStringsWatcher(changes), nil
+}
+
 // validUUID is a UUID used in tests
 var validUUID = "01234567-89ab-cdef-0123-456789abcdef"
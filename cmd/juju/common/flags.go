@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -140,3 +141,20 @@ func ParseConstraints(ctx *cmd.Context, cons string) (constraints.Value, error)
 	}
 	return constraint, nil
 }
+
+// ParseTimeFlag interprets the value of the named time flag as either
+// an RFC3339 timestamp or a duration before the time returned by now.
+// An empty value results in the zero time.
+func ParseTimeFlag(name, value string, now func() time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, errors.Errorf("%s: expected RFC3339 timestamp or positive duration, got %q", name, value)
+	}
+	return now().Add(-d).UTC(), nil
+}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
@@ -138,3 +139,27 @@ func assertConfigFlagReadAttrs(c *gc.C, f ConfigFlag, expect map[string]interfac
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(attrs, jc.DeepEquals, expect)
 }
+
+func (*FlagsSuite) TestParseTimeFlag(c *gc.C) {
+	now := func() time.Time {
+		return time.Date(2018, 10, 2, 0, 0, 0, 0, time.UTC)
+	}
+	t, err := ParseTimeFlag("--after", "", now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t.IsZero(), jc.IsTrue)
+
+	t, err = ParseTimeFlag("--after", "2018-10-01T18:00:00+02:00", now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t, gc.Equals, time.Date(2018, 10, 1, 16, 0, 0, 0, time.UTC))
+
+	t, err = ParseTimeFlag("--after", "24h", now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t, gc.Equals, time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func (*FlagsSuite) TestParseTimeFlagErrors(c *gc.C) {
+	_, err := ParseTimeFlag("--after", "yesterday", time.Now)
+	c.Assert(err, gc.ErrorMatches, `--after: expected RFC3339 timestamp or positive duration, got "yesterday"`)
+	_, err = ParseTimeFlag("--before", "-1h", time.Now)
+	c.Assert(err, gc.ErrorMatches, `--before: expected RFC3339 timestamp or positive duration, got "-1h"`)
+}
@@ -0,0 +1,225 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewAuditLogCommand returns a command that queries the audit log
+// stored in the controller database.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{})
+}
+
+// AuditLogAPI defines the API methods used by the audit-log command.
+type AuditLogAPI interface {
+	Close() error
+	Query(params.AuditLogQueryArgs) ([]params.AuditConversation, error)
+}
+
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	api AuditLogAPI
+	now func() time.Time
+
+	who    string
+	model  string
+	facade string
+	method string
+	after  string
+	before string
+	limit  int
+
+	args params.AuditLogQueryArgs
+}
+
+const auditLogDoc = `
+Shows the API conversations recorded in the controller's audit log,
+most recent first. Each conversation is a single connection by a
+user (usually one invocation of a juju command); the API requests
+made in that conversation, and any errors returned, are included in
+the yaml and json output.
+
+Only controller superusers can query the audit log. Querying
+requires the "database" backend to be enabled in the controller's
+audit-log-backends setting; conversations recorded before it was
+enabled are not available.
+
+The --model option accepts either a model UUID or a model name; names
+not qualified with an owner ("owner/name") are taken to be owned by the
+current user.
+
+The --after and --before options accept either an RFC3339 timestamp
+or a duration, which is taken to be relative to the current time.
+
+Examples:
+
+    juju audit-log
+    juju audit-log --user bob --model admin/default
+    juju audit-log --facade Application --method Deploy --after 24h
+    juju audit-log --after 2018-10-01T00:00:00Z --format yaml
+
+See also:
+    controller-config
+`
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Queries the controller's audit log.",
+		Doc:     auditLogDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.who, "user", "", "Only show conversations started by this user")
+	f.StringVar(&c.model, "model", "", "Only show conversations with this model (name or UUID)")
+	f.StringVar(&c.facade, "facade", "", "Only show conversations that called this facade")
+	f.StringVar(&c.method, "method", "", "Only show conversations that called this method")
+	f.StringVar(&c.after, "after", "", "Only show conversations started after this time or duration ago")
+	f.StringVar(&c.before, "before", "", "Only show conversations started before this time or duration ago")
+	f.IntVar(&c.limit, "limit", 50, "The maximum number of conversations to show")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.limit < 0 {
+		return errors.Errorf("--limit must not be negative")
+	}
+	c.args = params.AuditLogQueryArgs{
+		Who:    c.who,
+		Model:  c.model,
+		Facade: c.facade,
+		Method: c.method,
+		Limit:  c.limit,
+	}
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+	after, err := common.ParseTimeFlag("--after", c.after, now)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !after.IsZero() {
+		c.args.After = &after
+	}
+	before, err := common.ParseTimeFlag("--before", c.before, now)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !before.IsZero() {
+		c.args.Before = &before
+	}
+	if c.args.After != nil && c.args.Before != nil && c.args.Before.Before(*c.args.After) {
+		return errors.Errorf("--before must not be earlier than --after")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *auditLogCommand) getAPI() (AuditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auditlog.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := c.qualifyModel(); err != nil {
+		return errors.Trace(err)
+	}
+	conversations, err := client.Query(c.args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(conversations) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No audit log entries found.")
+		return nil
+	}
+	return c.out.Write(ctx, conversations)
+}
+
+// qualifyModel qualifies the model name being queried, if any, with
+// the current user, so that conversations with same-named models
+// owned by other users are not included.
+func (c *auditLogCommand) qualifyModel() error {
+	model := c.args.Model
+	if model == "" || jujuclient.IsQualifiedModelName(model) || utils.IsValidUUIDString(model) {
+		return nil
+	}
+	account, err := c.CurrentAccountDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.args.Model = jujuclient.JoinOwnerModelName(names.NewUserTag(account.User), model)
+	return nil
+}
+
+func (c *auditLogCommand) formatTabular(writer io.Writer, value interface{}) error {
+	conversations, ok := value.([]params.AuditConversation)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", conversations, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "User", "Model", "Requests", "Errors", "Command")
+	for _, conv := range conversations {
+		var errorCount int
+		for _, req := range conv.Requests {
+			errorCount += len(req.Errors)
+		}
+		model := conv.ModelName
+		if model == "" {
+			model = "-"
+		} else if conv.ModelOwner != "" {
+			model = conv.ModelOwner + "/" + model
+		}
+		w.Print(conv.When, conv.Who, model, len(conv.Requests))
+		if errorCount > 0 {
+			w.PrintColor(output.ErrorHighlight, errorCount)
+		} else {
+			w.Print(errorCount)
+		}
+		w.Println(conv.What)
+	}
+	tw.Flush()
+	return nil
+}
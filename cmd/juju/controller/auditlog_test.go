@@ -0,0 +1,193 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+type auditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	store *jujuclient.MemStore
+	now   time.Time
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeAuditLogAPI{
+		conversations: []params.AuditConversation{{
+			ConversationID: "0123456789abcdef",
+			Who:            "user-bob",
+			What:           "juju deploy mysql",
+			When:           "2018-10-01T12:00:00Z",
+			ModelName:      "default",
+			ModelOwner:     "admin",
+			Requests: []params.AuditRequest{{
+				RequestID: 1,
+				Facade:    "Application",
+				Method:    "Deploy",
+				Errors:    []params.AuditError{{Message: "boom"}},
+			}, {
+				RequestID: 2,
+				Facade:    "Application",
+				Method:    "Get",
+			}},
+		}, {
+			ConversationID: "fedcba9876543210",
+			Who:            "user-admin",
+			What:           "juju controllers",
+			When:           "2018-10-01T11:00:00Z",
+		}},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+	s.now = time.Date(2018, 10, 2, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *auditLogSuite) newCommand() cmd.Command {
+	return controller.NewAuditLogCommandForTest(s.api, s.store, func() time.Time { return s.now })
+}
+
+func (s *auditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Time                  User        Model          Requests  Errors  Command\n"+
+		"2018-10-01T12:00:00Z  user-bob    admin/default  2         1       juju deploy mysql\n"+
+		"2018-10-01T11:00:00Z  user-admin  -              0         0       juju controllers\n")
+	s.api.CheckCall(c, 0, "Query", params.AuditLogQueryArgs{Limit: 50})
+}
+
+func (s *auditLogSuite) TestNoEntries(c *gc.C) {
+	s.api.conversations = nil
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No audit log entries found.\n")
+}
+
+func (s *auditLogSuite) TestFilters(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(),
+		"--user", "bob",
+		"--model", "admin/default",
+		"--facade", "Application",
+		"--method", "Deploy",
+		"--after", "24h",
+		"--before", "2018-10-01T18:00:00+02:00",
+		"--limit", "5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	after := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2018, 10, 1, 16, 0, 0, 0, time.UTC)
+	s.api.CheckCall(c, 0, "Query", params.AuditLogQueryArgs{
+		Who:    "bob",
+		Model:  "admin/default",
+		Facade: "Application",
+		Method: "Deploy",
+		After:  &after,
+		Before: &before,
+		Limit:  5,
+	})
+}
+
+func (s *auditLogSuite) TestUnqualifiedModel(c *gc.C) {
+	s.store.Accounts["fake"] = jujuclient.AccountDetails{User: "bob"}
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--model", "default")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Query", params.AuditLogQueryArgs{
+		Model: "bob/default",
+		Limit: 50,
+	})
+}
+
+func (s *auditLogSuite) TestModelUUID(c *gc.C) {
+	uuid := "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--model", uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Query", params.AuditLogQueryArgs{
+		Model: uuid,
+		Limit: 50,
+	})
+}
+
+func (s *auditLogSuite) TestYAML(c *gc.C) {
+	s.api.conversations = s.api.conversations[1:]
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- conversation-id: fedcba9876543210
+  connection-id: ""
+  who: user-admin
+  what: juju controllers
+  when: "2018-10-01T11:00:00Z"
+  model-name: ""
+  model-uuid: ""
+`[1:])
+}
+
+func (s *auditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--after", "yesterday"},
+		err:  `--after: expected RFC3339 timestamp or positive duration, got "yesterday"`,
+	}, {
+		args: []string{"--before", "-1h"},
+		err:  `--before: expected RFC3339 timestamp or positive duration, got "-1h"`,
+	}, {
+		args: []string{"--after", "1h", "--before", "2h"},
+		err:  `--before must not be earlier than --after`,
+	}, {
+		args: []string{"--limit", "-1"},
+		err:  `--limit must not be negative`,
+	}, {
+		args: []string{"whoops"},
+		err:  `unrecognized args: \["whoops"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, s.newCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	s.api.SetErrors(common.ErrPerm)
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeAuditLogAPI struct {
+	testing.Stub
+	conversations []params.AuditConversation
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	return nil
+}
+
+func (f *fakeAuditLogAPI) Query(args params.AuditLogQueryArgs) ([]params.AuditConversation, error) {
+	f.MethodCall(f, "Query", args)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.conversations, nil
+}
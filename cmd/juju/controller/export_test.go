@@ -189,3 +189,14 @@ func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, er
 var (
 	NoModelsMessage = noModelsMessage
 )
+
+// NewAuditLogCommandForTest returns an auditLogCommand with the API
+// and clock provided as specified.
+func NewAuditLogCommandForTest(api AuditLogAPI, store jujuclient.ClientStore, now func() time.Time) cmd.Command {
+	c := &auditLogCommand{
+		api: api,
+		now: now,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
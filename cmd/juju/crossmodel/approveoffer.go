@@ -0,0 +1,125 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const approveOfferCommandDoc = `
+Approve or reject a user's request to consume an offer.
+
+An offer created with the --require-approval option can only be consumed
+by users whose request to consume it has been approved by an offer admin.
+Pending requests are listed by the offer-requests command.
+
+Rejecting a request, including one that was previously approved, stops
+the user from making new relations to the offer. Relations the user has
+already made are left in place; remove them with the remove-relation
+command if they should end.
+
+The offer is normally specified by its URL. It's also possible to specify
+just the offer name, in which case the offer is considered to reside in
+the current model.
+
+Examples:
+
+    juju approve-offer fred/prod.hosted-mysql mary
+    juju approve-offer hosted-mysql mary --reject
+
+See also:
+    offer
+    offer-requests
+    remove-relation
+`
+
+// NewApproveOfferCommand returns a command used to approve or reject
+// a request to consume an offer.
+func NewApproveOfferCommand() cmd.Command {
+	approveCmd := &approveOfferCommand{}
+	approveCmd.newAPIFunc = func(controllerName string) (ApproveOfferAPI, error) {
+		return approveCmd.NewRemoteEndpointsAPI(controllerName)
+	}
+	return modelcmd.WrapController(approveCmd)
+}
+
+type approveOfferCommand struct {
+	RemoteEndpointsCommandBase
+
+	url        string
+	user       string
+	reject     bool
+	newAPIFunc func(string) (ApproveOfferAPI, error)
+}
+
+// ApproveOfferAPI defines the API methods that the approve-offer command uses.
+type ApproveOfferAPI interface {
+	Close() error
+	BestAPIVersion() int
+	ChangeOfferConnectionRequest(offerURL, user string, approve bool) error
+}
+
+// Info implements Command.Info.
+func (c *approveOfferCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "approve-offer",
+		Args:    "<offer-url> <user>",
+		Purpose: "Approves or rejects a request to consume an offer.",
+		Doc:     approveOfferCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *approveOfferCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.RemoteEndpointsCommandBase.SetFlags(f)
+	f.BoolVar(&c.reject, "reject", false, "Reject the request instead of approving it; existing relations are kept")
+}
+
+// Init implements Command.Init.
+func (c *approveOfferCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no offer specified")
+	case 1:
+		return errors.New("no user specified")
+	}
+	c.url, c.user = args[0], args[1]
+	if !names.IsValidUser(c.user) {
+		return errors.NotValidf("user name %q", c.user)
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements Command.Run.
+func (c *approveOfferCommand) Run(ctx *cmd.Context) error {
+	controllerName, url, err := c.resolveOfferURL(c.url)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	api, err := c.newAPIFunc(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if api.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("on this juju controller, approve-offer")
+	}
+	if err := api.ChangeOfferConnectionRequest(url.String(), c.user, !c.reject); err != nil {
+		return errors.Trace(err)
+	}
+	if c.reject {
+		ctx.Infof("Rejected request by %q to consume offer %q", c.user, url.String())
+	} else {
+		ctx.Infof("Approved request by %q to consume offer %q", c.user, url.String())
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/crossmodel"
+)
+
+type approveOfferSuite struct {
+	BaseCrossModelSuite
+	mockAPI *mockApproveOfferAPI
+}
+
+var _ = gc.Suite(&approveOfferSuite{})
+
+func (s *approveOfferSuite) SetUpTest(c *gc.C) {
+	s.BaseCrossModelSuite.SetUpTest(c)
+	s.mockAPI = &mockApproveOfferAPI{version: 3}
+}
+
+func (s *approveOfferSuite) runApproveOffer(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, crossmodel.NewApproveOfferCommandForTest(s.store, s.mockAPI), args...)
+}
+
+func (s *approveOfferSuite) TestInit(c *gc.C) {
+	_, err := s.runApproveOffer(c)
+	c.Assert(err, gc.ErrorMatches, "no offer specified")
+	_, err = s.runApproveOffer(c, "fred/test.db2")
+	c.Assert(err, gc.ErrorMatches, "no user specified")
+	_, err = s.runApproveOffer(c, "fred/test.db2", "not a user")
+	c.Assert(err, gc.ErrorMatches, `user name "not a user" not valid`)
+	_, err = s.runApproveOffer(c, "fred/test.db2", "mary", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *approveOfferSuite) TestApprove(c *gc.C) {
+	ctx, err := s.runApproveOffer(c, "fred/test.db2", "mary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.offerURL, gc.Equals, "fred/test.db2")
+	c.Assert(s.mockAPI.user, gc.Equals, "mary")
+	c.Assert(s.mockAPI.approve, jc.IsTrue)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Approved request by \"mary\" to consume offer \"fred/test.db2\"\n")
+}
+
+func (s *approveOfferSuite) TestReject(c *gc.C) {
+	ctx, err := s.runApproveOffer(c, "db2", "mary", "--reject")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.offerURL, gc.Equals, "fred/test.db2")
+	c.Assert(s.mockAPI.approve, jc.IsFalse)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Rejected request by \"mary\" to consume offer \"fred/test.db2\"\n")
+}
+
+func (s *approveOfferSuite) TestAPIError(c *gc.C) {
+	s.mockAPI.msg = "fail"
+	_, err := s.runApproveOffer(c, "fred/test.db2", "mary")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func (s *approveOfferSuite) TestNotSupported(c *gc.C) {
+	s.mockAPI.version = 2
+	_, err := s.runApproveOffer(c, "fred/test.db2", "mary")
+	c.Assert(err, gc.ErrorMatches, "on this juju controller, approve-offer not supported")
+}
+
+type mockApproveOfferAPI struct {
+	version  int
+	msg      string
+	offerURL string
+	user     string
+	approve  bool
+}
+
+func (s *mockApproveOfferAPI) Close() error {
+	return nil
+}
+
+func (s *mockApproveOfferAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s *mockApproveOfferAPI) ChangeOfferConnectionRequest(offerURL, user string, approve bool) error {
+	if s.msg != "" {
+		return errors.New(s.msg)
+	}
+	s.offerURL, s.user, s.approve = offerURL, user, approve
+	return nil
+}
@@ -70,3 +70,19 @@ func NewRemoveCommandForTest(store jujuclient.ClientStore, api RemoveAPI) cmd.Co
 	aCmd.SetClientStore(store)
 	return modelcmd.WrapController(aCmd)
 }
+
+func NewOfferRequestsCommandForTest(store jujuclient.ClientStore, api OfferRequestsAPI) cmd.Command {
+	aCmd := &offerRequestsCommand{newAPIFunc: func(controllerName string) (OfferRequestsAPI, error) {
+		return api, nil
+	}}
+	aCmd.SetClientStore(store)
+	return modelcmd.WrapController(aCmd)
+}
+
+func NewApproveOfferCommandForTest(store jujuclient.ClientStore, api ApproveOfferAPI) cmd.Command {
+	aCmd := &approveOfferCommand{newAPIFunc: func(controllerName string) (ApproveOfferAPI, error) {
+		return api, nil
+	}}
+	aCmd.SetClientStore(store)
+	return modelcmd.WrapController(aCmd)
+}
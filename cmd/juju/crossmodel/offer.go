@@ -32,10 +32,19 @@ $ juju offer mysql:db
 $ juju offer mymodel.mysql:db
 $ juju offer db2:db hosted-db2
 $ juju offer db2:db,log hosted-db2
+$ juju offer --require-approval --max-consumers 3 mysql:db
+
+If --require-approval is specified, consumers with consume access must
+still have their request to consume the offer approved by an offer admin.
+The --max-consumers and --max-relations options limit the number of
+models which can consume the offer and the number of relations which
+can be made to it.
 
 See also:
     consume
     relate
+    offer-requests
+    approve-offer
 `
 )
 
@@ -66,6 +75,9 @@ type offerCommand struct {
 
 	// QualifiedModelName stores the name of the model hosting the offer.
 	QualifiedModelName string
+
+	// Options stores the approval and connection limit settings of the offer.
+	Options applicationoffers.OfferOptions
 }
 
 // NewApplicationOffersAPI returns an application offers api for the root api endpoint
@@ -99,12 +111,21 @@ func (c *offerCommand) Init(args []string) error {
 		argCount = 2
 		c.OfferName = args[1]
 	}
+	if c.Options.MaxConsumers < 0 {
+		return errors.Errorf("--max-consumers must not be negative")
+	}
+	if c.Options.MaxRelations < 0 {
+		return errors.Errorf("--max-relations must not be negative")
+	}
 	return cmd.CheckEmpty(args[argCount:])
 }
 
 // SetFlags implements Command.SetFlags.
 func (c *offerCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.Options.RequireApproval, "require-approval", false, "Require requests to consume the offer to be approved by an offer admin")
+	f.IntVar(&c.Options.MaxConsumers, "max-consumers", 0, "The maximum number of models which can consume the offer (0 for no limit)")
+	f.IntVar(&c.Options.MaxRelations, "max-relations", 0, "The maximum number of relations to the offer (0 for no limit)")
 }
 
 // Run implements Command.Run.
@@ -151,7 +172,7 @@ func (c *offerCommand) Run(ctx *cmd.Context) error {
 		c.OfferName = c.Application
 	}
 	// TODO (anastasiamac 2015-11-16) Add a sensible way for user to specify long-ish (at times) description when offering
-	results, err := api.Offer(modelDetails.ModelUUID, c.Application, c.Endpoints, c.OfferName, "")
+	results, err := api.OfferWithOptions(modelDetails.ModelUUID, c.Application, c.Endpoints, c.OfferName, "", c.Options)
 	if err != nil {
 		return err
 	}
@@ -172,7 +193,10 @@ func (c *offerCommand) Run(ctx *cmd.Context) error {
 // OfferAPI defines the API methods that the offer command uses.
 type OfferAPI interface {
 	Close() error
-	Offer(modelUUID, application string, endpoints []string, offerName string, desc string) ([]params.ErrorResult, error)
+	OfferWithOptions(
+		modelUUID, application string, endpoints []string, offerName string, desc string,
+		opts applicationoffers.OfferOptions,
+	) ([]params.ErrorResult, error)
 }
 
 // applicationParse is used to split an application string
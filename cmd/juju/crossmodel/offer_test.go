@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api/applicationoffers"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/crossmodel"
@@ -107,6 +108,23 @@ func (s *offerSuite) TestOfferMultipleEndpoints(c *gc.C) {
 	s.assertOfferOutput(c, "test", "tst", "tst", []string{"db", "admin"})
 }
 
+func (s *offerSuite) TestOfferWithOptions(c *gc.C) {
+	s.args = []string{"--require-approval", "--max-consumers", "2", "--max-relations", "3", "tst:db"}
+	s.assertOfferOutput(c, "test", "tst", "tst", []string{"db"})
+	c.Assert(s.mockAPI.options, jc.DeepEquals, applicationoffers.OfferOptions{
+		RequireApproval: true,
+		MaxConsumers:    2,
+		MaxRelations:    3,
+	})
+}
+
+func (s *offerSuite) TestOfferNegativeLimits(c *gc.C) {
+	s.args = []string{"--max-consumers", "-1", "tst:db"}
+	s.assertOfferErrorOutput(c, "--max-consumers must not be negative")
+	s.args = []string{"--max-relations", "-1", "tst:db"}
+	s.assertOfferErrorOutput(c, "--max-relations must not be negative")
+}
+
 func (s *offerSuite) assertOfferOutput(c *gc.C, expectedModel, expectedOffer, expectedApplication string, endpoints []string) {
 	_, err := s.runOffer(c, s.args...)
 	c.Assert(err, jc.ErrorIsNil)
@@ -119,6 +137,7 @@ type mockOfferAPI struct {
 	offers           map[string][]string
 	applications     map[string]string
 	descs            map[string]string
+	options          applicationoffers.OfferOptions
 }
 
 func newMockOfferAPI() *mockOfferAPI {
@@ -133,7 +152,10 @@ func (s *mockOfferAPI) Close() error {
 	return nil
 }
 
-func (s *mockOfferAPI) Offer(modelUUID, application string, endpoints []string, offerName, desc string) ([]params.ErrorResult, error) {
+func (s *mockOfferAPI) OfferWithOptions(
+	modelUUID, application string, endpoints []string, offerName, desc string,
+	opts applicationoffers.OfferOptions,
+) ([]params.ErrorResult, error) {
 	if s.errCall {
 		return nil, errors.New("aborted")
 	}
@@ -149,5 +171,6 @@ func (s *mockOfferAPI) Offer(modelUUID, application string, endpoints []string,
 	s.offers[offerName] = endpoints
 	s.applications[offerName] = application
 	s.descs[offerName] = desc
+	s.options = opts
 	return result, nil
 }
@@ -0,0 +1,183 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/crossmodel"
+)
+
+const offerRequestsCommandDoc = `
+List the requests made by users to consume an offer which requires approval.
+
+An offer requires approval if it was created with the --require-approval
+option. A request is made the first time a user attempts to consume such
+an offer, and remains pending until an offer admin approves or rejects it
+with the approve-offer command.
+
+The offer is normally specified by its URL. It's also possible to specify
+just the offer name, in which case the offer is considered to reside in
+the current model.
+
+Examples:
+
+    juju offer-requests fred/prod.hosted-mysql
+    juju offer-requests hosted-mysql --format yaml
+
+See also:
+    offer
+    approve-offer
+`
+
+// NewOfferRequestsCommand returns a command used to list the requests
+// to consume an offer.
+func NewOfferRequestsCommand() cmd.Command {
+	requestsCmd := &offerRequestsCommand{}
+	requestsCmd.newAPIFunc = func(controllerName string) (OfferRequestsAPI, error) {
+		return requestsCmd.NewRemoteEndpointsAPI(controllerName)
+	}
+	return modelcmd.WrapController(requestsCmd)
+}
+
+type offerRequestsCommand struct {
+	RemoteEndpointsCommandBase
+
+	url        string
+	isoTime    bool
+	out        cmd.Output
+	newAPIFunc func(string) (OfferRequestsAPI, error)
+}
+
+// OfferRequestsAPI defines the API methods that the offer-requests command uses.
+type OfferRequestsAPI interface {
+	Close() error
+	BestAPIVersion() int
+	OfferConnectionRequests(offerURL string) ([]params.OfferConnectionRequest, error)
+}
+
+// Info implements Command.Info.
+func (c *offerRequestsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "offer-requests",
+		Args:    "<offer-url>",
+		Purpose: "Lists the requests to consume an offer.",
+		Doc:     offerRequestsCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *offerRequestsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.RemoteEndpointsCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatOfferRequestsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *offerRequestsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no offer specified")
+	}
+	c.url = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *offerRequestsCommand) Run(ctx *cmd.Context) error {
+	controllerName, url, err := c.resolveOfferURL(c.url)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	api, err := c.newAPIFunc(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if api.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("on this juju controller, offer-requests")
+	}
+	requests, err := api.OfferConnectionRequests(url.String())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(requests) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No requests to consume offer %q", url.String())
+		return nil
+	}
+
+	result := make([]OfferRequest, len(requests))
+	for i, req := range requests {
+		result[i] = OfferRequest{
+			User:      req.Username,
+			Status:    req.Status,
+			Requested: common.FormatTime(&req.Requested, c.isoTime),
+			Updated:   common.FormatTime(&req.Updated, c.isoTime),
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
+// resolveOfferURL returns the controller hosting the specified offer, and
+// the offer URL relative to that controller. The offer may be specified
+// by just its name, in which case it's assumed to be in the current model.
+func (c *RemoteEndpointsCommandBase) resolveOfferURL(urlStr string) (string, *crossmodel.OfferURL, error) {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	url, err := crossmodel.ParseOfferURL(urlStr)
+	if err != nil {
+		currentModel, err := c.ClientStore().CurrentModel(controllerName)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		url, err = makeURLFromCurrentModel(urlStr, controllerName, currentModel)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+	}
+	if url.Source != "" {
+		controllerName = url.Source
+	}
+	url.Source = ""
+	return controllerName, url, nil
+}
+
+// OfferRequest defines the serialization behaviour of a request to
+// consume an offer.
+type OfferRequest struct {
+	User      string `yaml:"user" json:"user"`
+	Status    string `yaml:"status" json:"status"`
+	Requested string `yaml:"requested" json:"requested"`
+	Updated   string `yaml:"updated" json:"updated"`
+}
+
+func formatOfferRequestsTabular(writer io.Writer, value interface{}) error {
+	requests, ok := value.([]OfferRequest)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", requests, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("User", "Status", "Requested", "Updated")
+	for _, req := range requests {
+		w.Println(req.User, req.Status, req.Requested, req.Updated)
+	}
+	return tw.Flush()
+}
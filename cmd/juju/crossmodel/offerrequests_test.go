@@ -0,0 +1,127 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/crossmodel"
+)
+
+type offerRequestsSuite struct {
+	BaseCrossModelSuite
+	mockAPI *mockOfferRequestsAPI
+}
+
+var _ = gc.Suite(&offerRequestsSuite{})
+
+func (s *offerRequestsSuite) SetUpTest(c *gc.C) {
+	s.BaseCrossModelSuite.SetUpTest(c)
+	requested := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	s.mockAPI = &mockOfferRequestsAPI{
+		version: 3,
+		requests: []params.OfferConnectionRequest{{
+			Username:  "mary",
+			Status:    "pending",
+			Requested: requested,
+			Updated:   requested,
+		}, {
+			Username:  "sam",
+			Status:    "approved",
+			Requested: requested,
+			Updated:   requested.Add(time.Hour),
+		}},
+	}
+}
+
+func (s *offerRequestsSuite) runOfferRequests(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, crossmodel.NewOfferRequestsCommandForTest(s.store, s.mockAPI), args...)
+}
+
+func (s *offerRequestsSuite) TestNoOffer(c *gc.C) {
+	_, err := s.runOfferRequests(c)
+	c.Assert(err, gc.ErrorMatches, "no offer specified")
+}
+
+func (s *offerRequestsSuite) TestTooManyArgs(c *gc.C) {
+	_, err := s.runOfferRequests(c, "fred/test.db2", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *offerRequestsSuite) TestTabular(c *gc.C) {
+	ctx, err := s.runOfferRequests(c, "fred/test.db2", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.offerURL, gc.Equals, "fred/test.db2")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+User  Status    Requested             Updated
+mary  pending   2019-01-01 10:00:00Z  2019-01-01 10:00:00Z
+sam   approved  2019-01-01 10:00:00Z  2019-01-01 11:00:00Z
+`[1:])
+}
+
+func (s *offerRequestsSuite) TestYAML(c *gc.C) {
+	ctx, err := s.runOfferRequests(c, "db2", "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.offerURL, gc.Equals, "fred/test.db2")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- user: mary
+  status: pending
+  requested: 2019-01-01 10:00:00Z
+  updated: 2019-01-01 10:00:00Z
+- user: sam
+  status: approved
+  requested: 2019-01-01 10:00:00Z
+  updated: 2019-01-01 11:00:00Z
+`[1:])
+}
+
+func (s *offerRequestsSuite) TestNoRequests(c *gc.C) {
+	s.mockAPI.requests = nil
+	ctx, err := s.runOfferRequests(c, "fred/test.db2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No requests to consume offer \"fred/test.db2\"\n")
+}
+
+func (s *offerRequestsSuite) TestAPIError(c *gc.C) {
+	s.mockAPI.msg = "fail"
+	_, err := s.runOfferRequests(c, "fred/test.db2")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func (s *offerRequestsSuite) TestNotSupported(c *gc.C) {
+	s.mockAPI.version = 2
+	_, err := s.runOfferRequests(c, "fred/test.db2")
+	c.Assert(err, gc.ErrorMatches, "on this juju controller, offer-requests not supported")
+}
+
+type mockOfferRequestsAPI struct {
+	version  int
+	msg      string
+	offerURL string
+	requests []params.OfferConnectionRequest
+}
+
+func (s *mockOfferRequestsAPI) Close() error {
+	return nil
+}
+
+func (s *mockOfferRequestsAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s *mockOfferRequestsAPI) OfferConnectionRequests(offerURL string) ([]params.OfferConnectionRequest, error) {
+	if s.msg != "" {
+		return nil, errors.New(s.msg)
+	}
+	s.offerURL = offerURL
+	return s.requests, nil
+}
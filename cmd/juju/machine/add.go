@@ -58,6 +58,25 @@ and bringing it under Juju's management; currently this requires that the
 machine be running Ubuntu, that it be accessible via SSH, and be running on
 the same network as the API server.
 
+Many existing machines may be manually provisioned at once by listing
+them in an inventory file passed with "--inventory". The hosts are
+provisioned over SSH in parallel, so each must be accessible without
+an interactive password prompt. For example:
+
+    hosts:
+      - host: 10.10.0.3
+      - host: 10.10.0.4
+        user: admin
+        private-key: ~/.ssh/admin_rsa
+        series: bionic
+        tags: ssd,rack-1
+
+The outcome for each host is reported separately. Hosts which have
+already been provisioned as machines in the model are skipped, so the
+same inventory may be used again to retry any hosts which failed. A
+host provisioned for another model, or for a machine since removed,
+is reported as a failure.
+
 It is possible to override or augment constraints by passing provider-specific
 "placement directives" as an argument; these give the provider additional
 information about how to allocate the machine. For example, one can direct the
@@ -74,6 +93,8 @@ Examples:
    juju add-machine winrm:user@10.10.0.3 (manually provisions machine with winrm)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
    juju add-machine maas2.name           (acquire machine maas2.name on MAAS)
+   juju add-machine --inventory hosts.yaml
+                                         (manually provisions the hosts in hosts.yaml)
 
 See also:
     remove-machine
@@ -115,6 +136,8 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// Inventory is the path to a file listing hosts to be manually provisioned.
+	Inventory string
 }
 
 func (c *addCommand) Info() *cmd.Info {
@@ -132,6 +155,7 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Additional machine constraints")
 	f.Var(disksFlag{&c.Disks}, "disks", "Constraints for disks to attach to the machine")
+	f.StringVar(&c.Inventory, "inventory", "", "Path to a YAML file listing existing hosts to manually provision")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -153,6 +177,17 @@ func (c *addCommand) Init(args []string) error {
 	if c.NumMachines > 1 && c.Placement != nil && c.Placement.Directive != "" {
 		return errors.New("cannot use -n when specifying a placement directive")
 	}
+	if c.Inventory != "" {
+		if c.Placement != nil {
+			return errors.New("cannot use --inventory when specifying a placement directive")
+		}
+		if c.NumMachines > 1 {
+			return errors.New("cannot use -n with --inventory")
+		}
+		if len(c.Disks) > 0 {
+			return errors.New("cannot use --disks with --inventory")
+		}
+	}
 	return nil
 }
 
@@ -163,6 +198,7 @@ type AddMachineAPI interface {
 	DestroyMachinesWithParams(force, keep bool, machines ...string) error
 	ModelUUID() (string, bool)
 	ProvisioningScript(params.ProvisioningScriptParams) (script string, err error)
+	Status(patterns []string) (*params.FullStatus, error)
 }
 
 type ModelConfigAPI interface {
@@ -261,6 +297,10 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	if c.Inventory != "" {
+		return c.provisionInventory(client, config, ctx)
+	}
+
 	if c.Placement != nil {
 		err := c.tryManualProvision(client, config, ctx)
 		if err != errNonManualScope {
@@ -4,8 +4,11 @@
 package machine_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -90,6 +93,15 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 			args:      []string{"something:special"},
 			count:     1,
 			placement: "something:special",
+		}, {
+			args:  []string{"--inventory", "hosts.yaml"},
+			count: 1,
+		}, {
+			args:        []string{"--inventory", "hosts.yaml", "ssh:10.10.0.3"},
+			errorString: "cannot use --inventory when specifying a placement directive",
+		}, {
+			args:        []string{"--inventory", "hosts.yaml", "-n", "2"},
+			errorString: "cannot use -n with --inventory",
 		},
 	} {
 		c.Logf("test %d", i)
@@ -156,6 +168,87 @@ func (s *AddMachineSuite) TestSSHPlacementError(c *gc.C) {
 	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
 }
 
+func (s *AddMachineSuite) writeInventory(c *gc.C, content string) string {
+	path := filepath.Join(c.MkDir(), "hosts.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *AddMachineSuite) TestInventory(c *gc.C) {
+	path := s.writeInventory(c, `
+hosts:
+  - host: 10.1.2.3
+  - host: admin@10.1.2.4
+    private-key: /home/admin/.ssh/id_rsa
+    series: bionic
+    tags: ssd, rack-1
+  - host: 10.1.2.5
+  - host: 10.1.2.6
+  - host: 10.1.2.7
+`)
+	s.fakeAddMachine.machines = map[string]params.MachineStatus{
+		"5": {Id: "5", InstanceId: "manual:10.1.2.5"},
+	}
+	var mu sync.Mutex
+	provisioned := make(map[string]manual.ProvisionMachineArgs)
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		provisioned[args.Host] = args
+		switch args.Host {
+		case "10.1.2.5", "10.1.2.7":
+			return "", manual.ErrProvisioned
+		case "10.1.2.6":
+			return "", errors.New("failed to initialize warp core")
+		}
+		return strings.Replace(args.Host, "10.1.2.", "", 1), nil
+	})
+	context, err := s.run(c, "--inventory", path)
+	c.Assert(err, gc.ErrorMatches, "failed to provision 2 of 5 hosts")
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, `
+10.1.2.3: created machine 3
+10.1.2.4: created machine 4
+10.1.2.5: already provisioned as machine 5, skipping
+10.1.2.6: failed to provision: failed to initialize warp core
+10.1.2.7: already provisioned, but not as a machine in this model
+`[1:])
+
+	c.Assert(provisioned, gc.HasLen, 5)
+	args := provisioned["10.1.2.4"]
+	c.Check(args.User, gc.Equals, "admin")
+	c.Check(args.PrivateKey, gc.Equals, "/home/admin/.ssh/id_rsa")
+	c.Check(args.Series, gc.Equals, "bionic")
+	c.Check(args.Tags, jc.DeepEquals, []string{"ssd", "rack-1"})
+	args = provisioned["10.1.2.3"]
+	c.Check(args.User, gc.Equals, "")
+	c.Check(args.Tags, gc.HasLen, 0)
+}
+
+func (s *AddMachineSuite) TestInventoryInvalid(c *gc.C) {
+	for i, test := range []struct {
+		content string
+		err     string
+	}{{
+		content: "hosts: []",
+		err:     `inventory ".*" contains no hosts`,
+	}, {
+		content: "hosts:\n  - user: admin\n",
+		err:     `inventory ".*": host 0: missing host`,
+	}, {
+		content: "hosts:\n  - host: 10.1.2.3\n  - host: admin@10.1.2.3\n",
+		err:     `inventory ".*": host "10.1.2.3" specified more than once`,
+	}, {
+		content: "hosts:\n  - host: 10.1.2.3\n    flavour: vanilla\n",
+		err:     `(?s)parsing inventory ".*": .*field flavour not found.*`,
+	}} {
+		c.Logf("test %d", i)
+		path := s.writeInventory(c, test.content)
+		_, err := s.run(c, "--inventory", path)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *AddMachineSuite) TestParamsPassedOn(c *gc.C) {
 	_, err := s.run(c, "--constraints", "mem=8G", "--series=special", "zone=nz")
 	c.Assert(err, jc.ErrorIsNil)
@@ -218,6 +311,7 @@ type fakeAddMachineAPI struct {
 	addError         error
 	addModelGetError error
 	providerType     string
+	machines         map[string]params.MachineStatus
 }
 
 func (f *fakeAddMachineAPI) Close() error {
@@ -263,6 +357,10 @@ func (f *fakeAddMachineAPI) ProvisioningScript(params.ProvisioningScriptParams)
 	return "", errors.NotImplementedf("ProvisioningScript")
 }
 
+func (f *fakeAddMachineAPI) Status(patterns []string) (*params.FullStatus, error) {
+	return &params.FullStatus{Machines: f.machines}, nil
+}
+
 func (f *fakeAddMachineAPI) ModelGet() (map[string]interface{}, error) {
 	if f.addModelGetError != nil {
 		return nil, f.addModelGetError
@@ -0,0 +1,213 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/manual"
+)
+
+// maxParallelProvisioning is the maximum number of hosts in an
+// inventory that will be provisioned at the same time.
+const maxParallelProvisioning = 10
+
+// inventory describes a set of existing hosts to be manually
+// provisioned with "add-machine --inventory".
+type inventory struct {
+	Hosts []inventoryHost `yaml:"hosts"`
+}
+
+// inventoryHost describes a single host in an inventory.
+type inventoryHost struct {
+	// Host is the address of the host, optionally
+	// prefixed with "user@".
+	Host string `yaml:"host"`
+
+	// User is the user to connect to the host as,
+	// if the ubuntu user has not yet been set up.
+	User string `yaml:"user,omitempty"`
+
+	// PrivateKey is the path to the private key used to
+	// authenticate with the host.
+	PrivateKey string `yaml:"private-key,omitempty"`
+
+	// Series is the series the host is expected to be running.
+	Series string `yaml:"series,omitempty"`
+
+	// Tags is a comma-separated list of hardware tags
+	// to record for the machine, as used by the "tags"
+	// constraint.
+	Tags string `yaml:"tags,omitempty"`
+}
+
+// readInventory reads and validates the inventory file at the given path.
+func readInventory(path string) ([]inventoryHost, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading inventory")
+	}
+	var inv inventory
+	if err := yaml.UnmarshalStrict(data, &inv); err != nil {
+		return nil, errors.Annotatef(err, "parsing inventory %q", path)
+	}
+	if len(inv.Hosts) == 0 {
+		return nil, errors.Errorf("inventory %q contains no hosts", path)
+	}
+	seen := make(map[string]bool)
+	for i, h := range inv.Hosts {
+		if h.User == "" {
+			h.User, h.Host = splitUserHost(h.Host)
+		}
+		if h.Host == "" {
+			return nil, errors.Errorf("inventory %q: host %d: missing host", path, i)
+		}
+		if seen[h.Host] {
+			return nil, errors.Errorf("inventory %q: host %q specified more than once", path, h.Host)
+		}
+		seen[h.Host] = true
+		if h.PrivateKey != "" {
+			if h.PrivateKey, err = utils.NormalizePath(h.PrivateKey); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		inv.Hosts[i] = h
+	}
+	return inv.Hosts, nil
+}
+
+// tags returns the hardware tags specified for the host.
+func (h inventoryHost) tags() []string {
+	var tags []string
+	for _, tag := range strings.Split(h.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+type inventoryResult struct {
+	machineId string
+	err       error
+}
+
+// provisionInventory manually provisions each of the hosts in the
+// inventory over ssh, in parallel, and reports the outcome for each.
+// Hosts which have already been provisioned as machines in the model
+// are skipped, so that an inventory may be re-applied to retry the
+// hosts which failed.
+func (c *addCommand) provisionInventory(client AddMachineAPI, config *config.Config, ctx *cmd.Context) error {
+	hosts, err := readInventory(ctx.AbsPath(c.Inventory))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	authKeys, err := common.ReadAuthorizedKeys(ctx, "")
+	if err != nil {
+		return errors.Annotatef(err, "cannot reading authorized-keys")
+	}
+
+	results := make([]inventoryResult, len(hosts))
+	sem := make(chan struct{}, maxParallelProvisioning)
+	var wg sync.WaitGroup
+	for i, h := range hosts {
+		wg.Add(1)
+		go func(i int, h inventoryHost) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			// Hosts are provisioned concurrently, so they can't
+			// share the terminal for sudo password prompts; each
+			// host must allow non-interactive access.
+			var output bytes.Buffer
+			args := manual.ProvisionMachineArgs{
+				Host:           h.Host,
+				User:           h.User,
+				PrivateKey:     h.PrivateKey,
+				Series:         h.Series,
+				Tags:           h.tags(),
+				Client:         client,
+				Stdin:          strings.NewReader(""),
+				Stdout:         &output,
+				Stderr:         &output,
+				AuthorizedKeys: authKeys,
+				UpdateBehavior: &params.UpdateBehavior{
+					EnableOSRefreshUpdate: config.EnableOSRefreshUpdate(),
+					EnableOSUpgrade:       config.EnableOSUpgrade(),
+				},
+			}
+			machineId, err := sshProvisioner(args)
+			if err != nil {
+				logger.Debugf("provisioning %s failed, output:\n%s", h.Host, output.String())
+			}
+			results[i] = inventoryResult{machineId, err}
+		}(i, h)
+	}
+	wg.Wait()
+
+	machineIds, err := provisionedMachineIds(client, results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var failed int
+	for i, h := range hosts {
+		result := results[i]
+		switch {
+		case result.err == nil:
+			ctx.Infof("%s: created machine %v", h.Host, result.machineId)
+		case errors.Cause(result.err) == manual.ErrProvisioned:
+			// The host may have been provisioned for another model,
+			// or for a machine since removed from this one.
+			machineId, ok := machineIds[instance.Id(manual.ManualInstancePrefix+h.Host)]
+			if !ok {
+				failed++
+				fmt.Fprintf(ctx.Stderr, "%s: already provisioned, but not as a machine in this model\n", h.Host)
+				continue
+			}
+			ctx.Infof("%s: already provisioned as machine %v, skipping", h.Host, machineId)
+		default:
+			failed++
+			fmt.Fprintf(ctx.Stderr, "%s: failed to provision: %v\n", h.Host, result.err)
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to provision %d of %d hosts", failed, len(hosts))
+	}
+	return nil
+}
+
+// provisionedMachineIds returns the ids of the model's machines, keyed
+// by instance id, if any of the results report that the host was
+// already provisioned.
+func provisionedMachineIds(client AddMachineAPI, results []inventoryResult) (map[instance.Id]string, error) {
+	machineIds := make(map[instance.Id]string)
+	for _, result := range results {
+		if errors.Cause(result.err) != manual.ErrProvisioned {
+			continue
+		}
+		status, err := client.Status(nil)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting machines in the model")
+		}
+		for id, m := range status.Machines {
+			machineIds[m.InstanceId] = id
+		}
+		break
+	}
+	return machineIds, nil
+}
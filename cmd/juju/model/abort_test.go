@@ -0,0 +1,107 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/cmd/juju/model/mocks"
+	coremodel "github.com/juju/juju/core/model"
+)
+
+type abortSuite struct {
+	generationBaseSuite
+}
+
+var _ = gc.Suite(&abortSuite{})
+
+func (s *abortSuite) TestInit(c *gc.C) {
+	err := s.runInit(s.branchName)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *abortSuite) TestInitFail(c *gc.C) {
+	err := s.runInit()
+	c.Assert(err, gc.ErrorMatches, "must specify a branch name to abort")
+}
+
+func (s *abortSuite) TestInitMaster(c *gc.C) {
+	err := s.runInit(coremodel.GenerationMaster)
+	c.Assert(err, gc.ErrorMatches, `branch name "master" not valid`)
+}
+
+func (s *abortSuite) TestRunCommandInactiveBranch(c *gc.C) {
+	ctrl, api := setUpAbortMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().AbortBranch(s.branchName).Return(nil)
+
+	ctx, err := s.runCommand(c, api)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Branch "new-branch" aborted
+`[1:])
+}
+
+func (s *abortSuite) TestRunCommandActiveBranch(c *gc.C) {
+	ctrl, api := setUpAbortMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().AbortBranch(s.branchName).Return(nil)
+
+	s.setActiveBranch(c, s.branchName)
+	ctx, err := s.runCommand(c, api)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Branch "new-branch" aborted
+Active branch set to "master"
+`[1:])
+
+	// Ensure the local store has "master" as the target.
+	details, err := s.store.ModelByName(
+		s.store.CurrentControllerName, s.store.Models[s.store.CurrentControllerName].CurrentModel)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(details.ActiveBranch, gc.Equals, coremodel.GenerationMaster)
+}
+
+func (s *abortSuite) TestRunCommandFail(c *gc.C) {
+	ctrl, api := setUpAbortMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().AbortBranch(s.branchName).Return(errors.Errorf("fail"))
+
+	_, err := s.runCommand(c, api)
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func (s *abortSuite) setActiveBranch(c *gc.C, branchName string) {
+	controllerName := s.store.CurrentControllerName
+	modelName := s.store.Models[controllerName].CurrentModel
+	details, err := s.store.ModelByName(controllerName, modelName)
+	c.Assert(err, jc.ErrorIsNil)
+	details.ActiveBranch = branchName
+	err = s.store.UpdateModel(controllerName, modelName, *details)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *abortSuite) runInit(args ...string) error {
+	return cmdtesting.InitCommand(model.NewAbortCommandForTest(nil, s.store), args)
+}
+
+func (s *abortSuite) runCommand(c *gc.C, api model.AbortCommandAPI) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewAbortCommandForTest(api, s.store), s.branchName)
+}
+
+func setUpAbortMocks(c *gc.C) (*gomock.Controller, *mocks.MockAbortCommandAPI) {
+	ctrl := gomock.NewController(c)
+	api := mocks.NewMockAbortCommandAPI(ctrl)
+	api.EXPECT().Close()
+	return ctrl, api
+}
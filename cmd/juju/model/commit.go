@@ -19,9 +19,10 @@ import (
 const (
 	commitSummary = "Commits a branch to the model."
 	commitDoc     = `
-Committing a branch writes changes to charm configuration made under the 
-branch, to the model. All units who's applications were changed under the 
-branch realise those changes, as will any new units.
+Committing a branch writes changes to charm configuration, charm upgrades 
+and application constraints made under the branch, to the model. All units 
+who's applications were changed under the branch realise those changes, as 
+will any new units.
 
 Examples:
     juju commit upgrade-postgresql
@@ -4,6 +4,8 @@
 package status
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 
 	"github.com/juju/juju/cmd/juju/storage"
@@ -18,3 +20,19 @@ func NewTestStatusCommand(statusapi statusAPI, storageapi storage.StorageListAPI
 	return modelcmd.Wrap(
 		&statusCommand{statusAPI: statusapi, storageAPI: storageapi, clock: clock})
 }
+
+func NewTestStatusWatchCommand(
+	statusapi statusAPI,
+	storageapi storage.StorageListAPI,
+	watcher allWatcher,
+	clock Clock,
+	now func() time.Time,
+) cmd.Command {
+	return modelcmd.Wrap(&statusCommand{
+		statusAPI:  statusapi,
+		storageAPI: storageapi,
+		watcher:    watcher,
+		clock:      clock,
+		now:        now,
+	})
+}
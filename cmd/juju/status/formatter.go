@@ -240,6 +240,7 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		CharmVersion:     application.CharmVersion,
 		CharmProfile:     application.CharmProfile,
 		Exposed:          application.Exposed,
+		ExposedEndpoints: formatExposedEndpoints(application.ExposedEndpoints),
 		Life:             application.Life,
 		Scale:            application.Scale,
 		ProviderId:       application.ProviderId,
@@ -264,6 +265,20 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 	return out
 }
 
+func formatExposedEndpoints(in map[string]params.ExposedEndpoint) map[string]exposedEndpoint {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]exposedEndpoint, len(in))
+	for endpoint, exposed := range in {
+		out[endpoint] = exposedEndpoint{
+			ExposeToSpaces: exposed.ExposeToSpaces,
+			ExposeToCIDRs:  exposed.ExposeToCIDRs,
+		}
+	}
+	return out
+}
+
 func (sf *statusFormatter) formatRemoteApplication(name string, application params.RemoteApplicationStatus) remoteApplicationStatus {
 	out := remoteApplicationStatus{
 		Err:        typedNilCheck(application.Err),
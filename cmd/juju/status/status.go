@@ -68,6 +68,26 @@ type statusCommand struct {
 
 	// storage indicates if 'storage' section is displayed
 	storage bool
+
+	// watch indicates that the status should be redisplayed as the
+	// model changes, until interrupted.
+	watch bool
+
+	// feed indicates that, when watching, only the changed
+	// entities should be printed, as a scrolling feed of events.
+	feed bool
+
+	// watchInterval is the minimum time between redisplays of the
+	// status when watching.
+	watchInterval time.Duration
+
+	// watcher is used when watching, and is obtained on demand
+	// if not set.
+	watcher allWatcher
+
+	// now returns the current time, used to timestamp the
+	// changes reported by --feed.
+	now func() time.Time
 }
 
 var usageSummary = `
@@ -110,12 +130,25 @@ In tabular format, 'Relations' section is not displayed by default.
 Use --relations option to see this section. This option is ignored in all other
 formats.
 
+The --watch option keeps the status displayed and up to date until interrupted.
+Rather than polling, it fetches the status once and then subscribes to the
+changes made to the model, applying them to the displayed status at most once
+every --watch-interval. On a terminal the status is redrawn in place. Watching
+is supported by the tabular, oneline and summary formats.
+
+Adding the --feed option to --watch prints a timestamped line for each machine,
+application, unit and relation as it changes, rather than redrawing the whole
+status; the first lines describe the current state of the model.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
     juju show-status --relations
     juju show-status --storage
+    juju show-status --watch
+    juju show-status --watch --format oneline mysql
+    juju show-status --watch --feed
 
 See also:
     machines
@@ -145,6 +178,10 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.retryCount, "retry-count", 3, "Number of times to retry API failures")
 	f.DurationVar(&c.retryDelay, "retry-delay", 100*time.Millisecond, "Time to wait between retry attempts")
 
+	f.BoolVar(&c.watch, "watch", false, "Keep the status up to date as the model changes")
+	f.BoolVar(&c.feed, "feed", false, "When watching, only print the entities that change")
+	f.DurationVar(&c.watchInterval, "watch-interval", time.Second, "Minimum time between status updates when watching")
+
 	c.checkProvidedIgnoredFlagF = func() set.Strings {
 		ignoredFlagForNonTabularFormat := set.NewStrings(
 			"relations",
@@ -189,6 +226,18 @@ func (c *statusCommand) Init(args []string) error {
 	if c.clock == nil {
 		c.clock = clock.WallClock
 	}
+	if c.now == nil {
+		c.now = time.Now
+	}
+	if c.feed && !c.watch {
+		return errors.New("--feed requires --watch")
+	}
+	if c.watch && !c.feed && !watchableFormats.Contains(c.out.Name()) {
+		return errors.Errorf("--watch is not supported with the %q format", c.out.Name())
+	}
+	if c.watchInterval < 0 {
+		return errors.NotValidf("negative --watch-interval")
+	}
 	return nil
 }
 
@@ -253,6 +302,56 @@ func (c *statusCommand) getStorageInfo(ctx *cmd.Context) (*storage.CombinedStora
 func (c *statusCommand) Run(ctx *cmd.Context) error {
 	defer c.close()
 
+	if c.out.Name() != "tabular" {
+		providedIgnoredFlags := c.checkProvidedIgnoredFlagF()
+		if !providedIgnoredFlags.IsEmpty() {
+			// For non-tabular formats this is redundant and needs to be mentioned to the user.
+			joinedMsg := strings.Join(providedIgnoredFlags.SortedValues(), ", ")
+			if providedIgnoredFlags.Size() > 1 {
+				joinedMsg += " options are"
+			} else {
+				joinedMsg += " option is"
+			}
+			ctx.Infof("provided %s always enabled in non tabular formats", joinedMsg)
+		}
+	}
+	if c.watch {
+		return c.runWatch(ctx)
+	}
+
+	status, err := c.getStatusWithRetries(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.writeStatus(ctx, status); err != nil {
+		return errors.Trace(err)
+	}
+
+	if !status.IsEmpty() {
+		return nil
+	}
+	if len(c.patterns) == 0 {
+		modelName, err := c.ModelName()
+		if err != nil {
+			return err
+		}
+		ctx.Infof("Model %q is empty.", modelName)
+	} else {
+		plural := func() string {
+			if len(c.patterns) == 1 {
+				return ""
+			}
+			return "s"
+		}
+		ctx.Infof("Nothing matched specified filter%v.", plural())
+	}
+	return nil
+}
+
+// getStatusWithRetries gets the model status, retrying on failure as
+// configured. If only partial status is available, the error is
+// reported and the partial status returned.
+func (c *statusCommand) getStatusWithRetries(ctx *cmd.Context) (*params.FullStatus, error) {
 	// Always attempt to get the status at least once, and retry if it fails.
 	status, err := c.getStatus()
 	if err != nil && !modelcmd.IsModelMigratedError(err) {
@@ -271,14 +370,19 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		if status == nil {
 			// Status call completely failed, there is nothing to report
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		// Display any error, but continue to print status if some was returned
 		fmt.Fprintf(ctx.Stderr, "%v\n", err)
 	} else if status == nil {
-		return errors.Errorf("unable to obtain the current status")
+		return nil, errors.Errorf("unable to obtain the current status")
 	}
+	return status, nil
+}
 
+// writeStatus formats the given status and writes it out in the
+// selected output format.
+func (c *statusCommand) writeStatus(ctx *cmd.Context, status *params.FullStatus) error {
 	controllerName, err := c.ControllerName()
 	if err != nil {
 		return errors.Trace(err)
@@ -289,17 +393,6 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	if c.out.Name() != "tabular" {
 		showRelations = true
 		showStorage = true
-		providedIgnoredFlags := c.checkProvidedIgnoredFlagF()
-		if !providedIgnoredFlags.IsEmpty() {
-			// For non-tabular formats this is redundant and needs to be mentioned to the user.
-			joinedMsg := strings.Join(providedIgnoredFlags.SortedValues(), ", ")
-			if providedIgnoredFlags.Size() > 1 {
-				joinedMsg += " options are"
-			} else {
-				joinedMsg += " option is"
-			}
-			ctx.Infof("provided %s always enabled in non tabular formats", joinedMsg)
-		}
 	}
 	formatterParams := newStatusFormatterParams{
 		status:         status,
@@ -325,30 +418,7 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-
-	if err = c.out.Write(ctx, formatted); err != nil {
-		return err
-	}
-
-	if !status.IsEmpty() {
-		return nil
-	}
-	if len(c.patterns) == 0 {
-		modelName, err := c.ModelName()
-		if err != nil {
-			return err
-		}
-		ctx.Infof("Model %q is empty.", modelName)
-	} else {
-		plural := func() string {
-			if len(c.patterns) == 1 {
-				return ""
-			}
-			return "s"
-		}
-		ctx.Infof("Nothing matched specified filter%v.", plural())
-	}
-	return nil
+	return c.out.Write(ctx, formatted)
 }
 
 func (c *statusCommand) FormatTabular(writer io.Writer, value interface{}) error {
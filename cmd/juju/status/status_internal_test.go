@@ -5913,6 +5913,38 @@ func (s *StatusSuite) TestControllerTimestampInFullStatus(c *gc.C) {
 	})
 }
 
+func (s *StatusSuite) TestFormatApplicationExposedEndpoints(c *gc.C) {
+	formatter := NewStatusFormatter(&params.FullStatus{}, true)
+	formatted := formatter.formatApplication("wordpress", params.ApplicationStatus{
+		Charm:   "cs:wordpress-1",
+		Exposed: true,
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"website": {
+				ExposeToSpaces: []string{"dmz"},
+				ExposeToCIDRs:  []string{"10.0.0.0/8"},
+			},
+		},
+	})
+	c.Check(formatted.Exposed, jc.IsTrue)
+	c.Check(formatted.ExposedEndpoints, jc.DeepEquals, map[string]exposedEndpoint{
+		"website": {
+			ExposeToSpaces: []string{"dmz"},
+			ExposeToCIDRs:  []string{"10.0.0.0/8"},
+		},
+	})
+
+	out, err := goyaml.Marshal(formatted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), jc.Contains, `
+exposed-endpoints:
+  website:
+    expose-to-spaces:
+    - dmz
+    expose-to-cidrs:
+    - 10.0.0.0/8
+`[1:])
+}
+
 func (s *StatusSuite) TestTabularNoRelations(c *gc.C) {
 	ctx := s.FilteringTestSetup(c)
 	defer s.resetContext(c, ctx)
@@ -0,0 +1,278 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// clearScreen moves the cursor to the top left of the terminal and
+// clears it, so the status can be redrawn in place.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// watchableFormats holds the output formats that may be redrawn
+// with --watch.
+var watchableFormats = set.NewStrings("tabular", "short", "oneline", "line", "summary")
+
+// statusKinds holds the kinds of entity whose changes are reflected
+// in the status output.
+var statusKinds = set.NewStrings(
+	"model",
+	"machine",
+	"application",
+	"remoteApplication",
+	"applicationOffer",
+	"unit",
+	"relation",
+)
+
+// allWatcher is the part of api.AllWatcher used when watching the
+// status.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// apiAllWatcher is an allWatcher that closes its API connection
+// when stopped.
+type apiAllWatcher struct {
+	*api.AllWatcher
+	conn api.Connection
+}
+
+// Stop is part of the allWatcher interface.
+func (w apiAllWatcher) Stop() error {
+	err := w.AllWatcher.Stop()
+	w.conn.Close()
+	return err
+}
+
+var newAllWatcherForStatus = func(c *statusCommand) (allWatcher, error) {
+	if c.watcher == nil {
+		conn, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		w, err := conn.Client().WatchAll()
+		if err != nil {
+			conn.Close()
+			return nil, errors.Trace(err)
+		}
+		c.watcher = apiAllWatcher{AllWatcher: w, conn: conn}
+	}
+	return c.watcher, nil
+}
+
+// runWatch displays the status each time the model changes, until
+// interrupted. The full status is fetched only once; it is then kept
+// up to date by applying the changes reported by the AllWatcher. The
+// first changes reported describe the whole model, so the status is
+// always displayed at least once.
+func (c *statusCommand) runWatch(ctx *cmd.Context) error {
+	w, err := newAllWatcherForStatus(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	stopped := make(chan struct{})
+	var stopOnce sync.Once
+	stop := func() {
+		stopOnce.Do(func() {
+			close(stopped)
+			w.Stop()
+		})
+	}
+	defer stop()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+	go func() {
+		select {
+		case <-interrupted:
+			stop()
+		case <-stopped:
+		}
+	}()
+
+	redraw := isTerminal(ctx.Stdout)
+	feed := make(map[multiwatcher.EntityId]string)
+	var cache *statusCache
+	for {
+		deltas, err := w.Next()
+		if err != nil {
+			select {
+			case <-stopped:
+				return nil
+			default:
+			}
+			return errors.Annotate(err, "watching model")
+		}
+		if c.feed {
+			c.writeChanges(ctx.Stdout, feed, deltas)
+		} else if affectsStatus(deltas) {
+			changed := true
+			if cache == nil {
+				status, err := c.getStatusWithRetries(ctx)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				cache = newStatusCache(status, c.matchesPatterns)
+			} else {
+				changed = cache.apply(deltas, c.now())
+			}
+			if changed {
+				if redraw {
+					fmt.Fprint(ctx.Stdout, clearScreen)
+				}
+				if err := c.writeStatus(ctx, cache.status); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+
+		// The AllWatcher accumulates changes while we wait, so
+		// nothing is missed by limiting how often we ask for them.
+		select {
+		case <-c.clock.After(c.watchInterval):
+		case <-stopped:
+			return nil
+		}
+	}
+}
+
+// affectsStatus reports whether any of the deltas are for entities
+// that appear in the status output.
+func affectsStatus(deltas []multiwatcher.Delta) bool {
+	for _, delta := range deltas {
+		if statusKinds.Contains(delta.Entity.EntityId().Kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeChanges writes a line for each entity whose status has changed,
+// been added or been removed, according to the deltas. The last line
+// written for each entity is recorded in rows.
+func (c *statusCommand) writeChanges(w io.Writer, rows map[multiwatcher.EntityId]string, deltas []multiwatcher.Delta) {
+	now := c.now()
+	timestamp := common.FormatTimeAsTimestamp(&now, c.isoTime)
+	for _, delta := range deltas {
+		kind, name, row, ok := feedRow(delta.Entity)
+		if !ok || !c.matchesPatterns(delta.Entity) {
+			continue
+		}
+		id := delta.Entity.EntityId()
+		last, seen := rows[id]
+		if delta.Removed {
+			if !seen {
+				continue
+			}
+			delete(rows, id)
+			row = "removed"
+		} else {
+			if seen && last == row {
+				continue
+			}
+			rows[id] = row
+		}
+		fields := []string{timestamp, kind, name}
+		if row != "" {
+			fields = append(fields, row)
+		}
+		fmt.Fprintln(w, strings.Join(fields, "  "))
+	}
+}
+
+// feedRow returns the kind and name of the given entity as printed by
+// --feed, along with a description of its current status. It returns
+// false if the entity is not included in the feed.
+func feedRow(entity multiwatcher.EntityInfo) (kind, name, row string, ok bool) {
+	switch info := entity.(type) {
+	case *multiwatcher.ModelInfo:
+		return "model", info.Name, statusRow(info.Status.Current, info.Status.Message), true
+	case *multiwatcher.MachineInfo:
+		message := info.InstanceStatus.Message
+		if info.AgentStatus.Message != "" {
+			message = info.AgentStatus.Message
+		}
+		return "machine", info.Id, statusRow(info.AgentStatus.Current, info.InstanceStatus.Current, message), true
+	case *multiwatcher.ApplicationInfo:
+		return "application", info.Name, statusRow(info.Status.Current, info.Status.Message), true
+	case *multiwatcher.RemoteApplicationInfo:
+		return "saas", info.Name, statusRow(info.Status.Current, info.Status.Message), true
+	case *multiwatcher.UnitInfo:
+		return "unit", info.Name, statusRow(
+			info.WorkloadStatus.Current,
+			info.AgentStatus.Current,
+			info.WorkloadStatus.Message,
+		), true
+	case *multiwatcher.RelationInfo:
+		return "relation", info.Key, "", true
+	}
+	return "", "", "", false
+}
+
+// statusRow joins the non-empty status values and message.
+func statusRow(values ...interface{}) string {
+	var fields []string
+	for _, v := range values {
+		if s := fmt.Sprint(v); s != "" {
+			fields = append(fields, s)
+		}
+	}
+	return strings.Join(fields, "  ")
+}
+
+// matchesPatterns reports whether the entity is matched by any of the
+// filter patterns given on the command line, or there are none.
+func (c *statusCommand) matchesPatterns(entity multiwatcher.EntityInfo) bool {
+	if len(c.patterns) == 0 {
+		return true
+	}
+	var candidates []string
+	switch info := entity.(type) {
+	case *multiwatcher.ModelInfo:
+		return true
+	case *multiwatcher.MachineInfo:
+		candidates = []string{info.Id}
+	case *multiwatcher.ApplicationInfo:
+		candidates = []string{info.Name}
+	case *multiwatcher.RemoteApplicationInfo:
+		candidates = []string{info.Name}
+	case *multiwatcher.UnitInfo:
+		candidates = []string{info.Name, info.Application, info.MachineId}
+	case *multiwatcher.RelationInfo:
+		for _, ep := range info.Endpoints {
+			candidates = append(candidates, ep.ApplicationName)
+		}
+	}
+	for _, pattern := range c.patterns {
+		for _, candidate := range candidates {
+			if matched, _ := path.Match(pattern, candidate); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && terminal.IsTerminal(int(f.Fd()))
+}
@@ -0,0 +1,205 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/status"
+	corestatus "github.com/juju/juju/core/status"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/testing"
+)
+
+type WatchStatusSuite struct {
+	testing.BaseSuite
+
+	statusapi  *fakeStatusAPI
+	storageapi *mockListStorageAPI
+	watcher    *fakeAllWatcher
+	clock      *timeRecorder
+	now        time.Time
+}
+
+var _ = gc.Suite(&WatchStatusSuite{})
+
+func (s *WatchStatusSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.statusapi = &fakeStatusAPI{
+		result: &params.FullStatus{
+			Model: params.ModelStatusInfo{
+				Name:     "test",
+				CloudTag: "cloud-foo",
+			},
+		},
+	}
+	s.storageapi = &mockListStorageAPI{}
+	s.watcher = &fakeAllWatcher{}
+	s.clock = &timeRecorder{}
+	s.now = time.Date(2019, 4, 1, 10, 0, 0, 0, time.UTC)
+	s.SetModelAndController(c, "test", "admin/test")
+}
+
+func (s *WatchStatusSuite) runStatus(c *gc.C, args ...string) (*cmd.Context, error) {
+	statusCmd := status.NewTestStatusWatchCommand(
+		s.statusapi, s.storageapi, s.watcher, s.clock,
+		func() time.Time { return s.now },
+	)
+	return cmdtesting.RunCommand(c, statusCmd, args...)
+}
+
+func (s *WatchStatusSuite) TestFeedRequiresWatch(c *gc.C) {
+	_, err := s.runStatus(c, "--feed")
+	c.Assert(err, gc.ErrorMatches, "--feed requires --watch")
+}
+
+func (s *WatchStatusSuite) TestWatchUnsupportedFormat(c *gc.C) {
+	_, err := s.runStatus(c, "--watch", "--format", "yaml")
+	c.Assert(err, gc.ErrorMatches, `--watch is not supported with the "yaml" format`)
+}
+
+func (s *WatchStatusSuite) TestWatchRedisplaysOnChange(c *gc.C) {
+	s.watcher.deltas = [][]multiwatcher.Delta{
+		{{Entity: &multiwatcher.UnitInfo{Name: "mysql/0"}}},
+		{{Entity: &multiwatcher.AnnotationInfo{Tag: "unit-mysql-0"}}},
+		{{Entity: &multiwatcher.MachineInfo{Id: "0"}}},
+	}
+	ctx, err := s.runStatus(c, "--watch", "--watch-interval", "5s")
+	c.Assert(err, gc.ErrorMatches, "watching model: no more deltas")
+
+	// The annotation change doesn't affect the status, so it is
+	// displayed only twice. The full status is only fetched once;
+	// the machine change is applied to it.
+	c.Assert(s.statusapi.calls, gc.Equals, 1)
+	c.Assert(strings.Count(cmdtesting.Stdout(ctx), "Model  Controller  Cloud/Region  Version\n"), gc.Equals, 2)
+	c.Assert(s.clock.waits, jc.DeepEquals, []time.Duration{5 * time.Second, 5 * time.Second, 5 * time.Second})
+	c.Assert(s.watcher.stopped, jc.IsTrue)
+}
+
+func (s *WatchStatusSuite) TestWatchAppliesDeltas(c *gc.C) {
+	s.statusapi.result.Applications = map[string]params.ApplicationStatus{
+		"mysql": {
+			Charm:  "cs:mysql-1",
+			Status: params.DetailedStatus{Status: "waiting"},
+			Units: map[string]params.UnitStatus{
+				"mysql/0": {
+					WorkloadStatus: params.DetailedStatus{Status: "waiting", Info: "waiting for machine"},
+					AgentStatus:    params.DetailedStatus{Status: "allocating"},
+				},
+			},
+		},
+	}
+	unit := func(name string, workload corestatus.Status, message string) *multiwatcher.UnitInfo {
+		return &multiwatcher.UnitInfo{
+			Name:           name,
+			Application:    "mysql",
+			CharmURL:       "cs:mysql-1",
+			MachineId:      "0",
+			WorkloadStatus: multiwatcher.StatusInfo{Current: workload, Message: message},
+			AgentStatus:    multiwatcher.StatusInfo{Current: corestatus.Idle},
+		}
+	}
+	s.watcher.deltas = [][]multiwatcher.Delta{
+		{{Entity: unit("mysql/0", corestatus.Waiting, "waiting for machine")}},
+		{{Entity: unit("mysql/0", corestatus.Active, "ready to serve")}},
+		{{Entity: unit("mysql/1", corestatus.Maintenance, "installing")}},
+		{{Removed: true, Entity: unit("mysql/0", corestatus.Active, "ready to serve")}},
+	}
+	ctx, err := s.runStatus(c, "--watch")
+	c.Assert(err, gc.ErrorMatches, "watching model: no more deltas")
+	c.Assert(s.statusapi.calls, gc.Equals, 1)
+
+	displays := strings.Split(cmdtesting.Stdout(ctx), "Model  Controller  Cloud/Region  Version\n")
+	c.Assert(displays, gc.HasLen, 5)
+	c.Check(displays[1], jc.Contains, "waiting for machine")
+	c.Check(displays[2], jc.Contains, "ready to serve")
+	c.Check(displays[2], gc.Not(jc.Contains), "mysql/1")
+	c.Check(displays[3], jc.Contains, "ready to serve")
+	c.Check(displays[3], jc.Contains, "installing")
+	c.Check(displays[4], gc.Not(jc.Contains), "ready to serve")
+	c.Check(displays[4], jc.Contains, "installing")
+}
+
+func (s *WatchStatusSuite) TestWatchFeed(c *gc.C) {
+	unit := func(workload corestatus.Status, message string, agent corestatus.Status) *multiwatcher.UnitInfo {
+		return &multiwatcher.UnitInfo{
+			Name:           "mysql/0",
+			Application:    "mysql",
+			MachineId:      "0",
+			WorkloadStatus: multiwatcher.StatusInfo{Current: workload, Message: message},
+			AgentStatus:    multiwatcher.StatusInfo{Current: agent},
+		}
+	}
+	s.watcher.deltas = [][]multiwatcher.Delta{{
+		{Entity: &multiwatcher.MachineInfo{
+			Id:             "0",
+			AgentStatus:    multiwatcher.StatusInfo{Current: corestatus.Started},
+			InstanceStatus: multiwatcher.StatusInfo{Current: corestatus.Running, Message: "Running"},
+		}},
+		{Entity: &multiwatcher.ApplicationInfo{
+			Name:   "mysql",
+			Status: multiwatcher.StatusInfo{Current: corestatus.Waiting, Message: "waiting for machine"},
+		}},
+		{Entity: unit(corestatus.Waiting, "waiting for machine", corestatus.Allocating)},
+		{Entity: &multiwatcher.ApplicationInfo{Name: "wordpress"}},
+	}, {
+		// Only the public address has changed.
+		{Entity: func() *multiwatcher.UnitInfo {
+			u := unit(corestatus.Waiting, "waiting for machine", corestatus.Allocating)
+			u.PublicAddress = "10.0.0.1"
+			return u
+		}()},
+		{Entity: &multiwatcher.AnnotationInfo{Tag: "unit-mysql-0"}},
+	}, {
+		{Entity: unit(corestatus.Active, "ready", corestatus.Idle)},
+		{Entity: &multiwatcher.RelationInfo{
+			Key: "mysql:cluster",
+			Endpoints: []multiwatcher.Endpoint{{
+				ApplicationName: "mysql",
+			}},
+		}},
+	}, {
+		{Removed: true, Entity: unit(corestatus.Active, "ready", corestatus.Idle)},
+		{Removed: true, Entity: &multiwatcher.ApplicationInfo{Name: "postgresql"}},
+	}}
+	ctx, err := s.runStatus(c, "--watch", "--feed", "--utc", "mysql", "0")
+	c.Assert(err, gc.ErrorMatches, "watching model: no more deltas")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+10:00:00  machine  0  started  running  Running
+10:00:00  application  mysql  waiting  waiting for machine
+10:00:00  unit  mysql/0  waiting  allocating  waiting for machine
+10:00:00  unit  mysql/0  active  idle  ready
+10:00:00  relation  mysql:cluster
+10:00:00  unit  mysql/0  removed
+`[1:])
+	// The feed doesn't need the full status.
+	c.Assert(s.statusapi.calls, gc.Equals, 0)
+}
+
+type fakeAllWatcher struct {
+	deltas  [][]multiwatcher.Delta
+	stopped bool
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if len(w.deltas) == 0 {
+		return nil, errors.New("no more deltas")
+	}
+	next := w.deltas[0]
+	w.deltas = w.deltas[1:]
+	return next, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
@@ -0,0 +1,359 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// statusCache holds the status displayed by --watch. It is fetched
+// once, and then kept up to date by applying the changes reported by
+// the AllWatcher, so the controller isn't asked for the full status
+// each time the model changes.
+type statusCache struct {
+	status *params.FullStatus
+
+	// matches reports whether an entity that isn't yet in the
+	// status should be added to it.
+	matches func(multiwatcher.EntityInfo) bool
+}
+
+func newStatusCache(status *params.FullStatus, matches func(multiwatcher.EntityInfo) bool) *statusCache {
+	if status.Machines == nil {
+		status.Machines = make(map[string]params.MachineStatus)
+	}
+	if status.Applications == nil {
+		status.Applications = make(map[string]params.ApplicationStatus)
+	}
+	if status.RemoteApplications == nil {
+		status.RemoteApplications = make(map[string]params.RemoteApplicationStatus)
+	}
+	return &statusCache{status: status, matches: matches}
+}
+
+// apply updates the status with the deltas, and reports whether any
+// of them changed it.
+func (s *statusCache) apply(deltas []multiwatcher.Delta, now time.Time) bool {
+	changed := false
+	for _, delta := range deltas {
+		var updated bool
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.ModelInfo:
+			updated = s.applyModel(info)
+		case *multiwatcher.MachineInfo:
+			updated = s.applyMachine(info, delta.Removed)
+		case *multiwatcher.ApplicationInfo:
+			updated = s.applyApplication(info, delta.Removed)
+		case *multiwatcher.RemoteApplicationInfo:
+			updated = s.applyRemoteApplication(info, delta.Removed)
+		case *multiwatcher.UnitInfo:
+			updated = s.applyUnit(info, delta.Removed)
+		case *multiwatcher.RelationInfo:
+			updated = s.applyRelation(info, delta.Removed)
+		}
+		changed = changed || updated
+	}
+	if changed {
+		s.status.ControllerTimestamp = &now
+	}
+	return changed
+}
+
+func (s *statusCache) applyModel(info *multiwatcher.ModelInfo) bool {
+	model := &s.status.Model
+	model.ModelStatus = detailedStatus(model.ModelStatus, info.Status, info.Life)
+	model.SLA = info.SLA.Level
+	return true
+}
+
+func (s *statusCache) applyMachine(info *multiwatcher.MachineInfo, removed bool) bool {
+	machines := s.machineMap(info.Id)
+	if machines == nil {
+		// The machine's host isn't shown.
+		return false
+	}
+	m, ok := machines[info.Id]
+	if removed {
+		delete(machines, info.Id)
+		return ok
+	}
+	if !ok {
+		if !s.matches(info) {
+			return false
+		}
+		m = params.MachineStatus{Id: info.Id}
+	}
+	m.AgentStatus = detailedStatus(m.AgentStatus, info.AgentStatus, info.Life)
+	m.InstanceStatus = detailedStatus(m.InstanceStatus, info.InstanceStatus, info.Life)
+	m.InstanceId = instance.Id(info.InstanceId)
+	m.Series = info.Series
+	m.Jobs = info.Jobs
+	m.HasVote = info.HasVote
+	m.WantsVote = info.WantsVote
+	if info.HardwareCharacteristics != nil {
+		m.Hardware = info.HardwareCharacteristics.String()
+	}
+	if len(info.Addresses) > 0 {
+		m.IPAddresses = nil
+		m.DNSName = ""
+		for _, addr := range info.Addresses {
+			m.IPAddresses = append(m.IPAddresses, addr.Value)
+			if m.DNSName == "" && addr.Scope == "public" {
+				m.DNSName = addr.Value
+			}
+		}
+		if m.DNSName == "" {
+			m.DNSName = info.Addresses[0].Value
+		}
+	}
+	machines[info.Id] = m
+	return true
+}
+
+// machineMap returns the map holding the status of the machine with
+// the given id: the model's machines, or the containers of its host.
+// It returns nil if the machine's host is not in the status.
+func (s *statusCache) machineMap(id string) map[string]params.MachineStatus {
+	parts := strings.Split(id, "/")
+	if len(parts) < 3 {
+		return s.status.Machines
+	}
+	hostId := strings.Join(parts[:len(parts)-2], "/")
+	hostMachines := s.machineMap(hostId)
+	if hostMachines == nil {
+		return nil
+	}
+	host, ok := hostMachines[hostId]
+	if !ok {
+		return nil
+	}
+	if host.Containers == nil {
+		host.Containers = make(map[string]params.MachineStatus)
+		hostMachines[hostId] = host
+	}
+	return host.Containers
+}
+
+func (s *statusCache) applyApplication(info *multiwatcher.ApplicationInfo, removed bool) bool {
+	app, ok := s.status.Applications[info.Name]
+	if removed {
+		delete(s.status.Applications, info.Name)
+		return ok
+	}
+	if !ok {
+		if !s.matches(info) {
+			return false
+		}
+		app = params.ApplicationStatus{
+			Units:     make(map[string]params.UnitStatus),
+			Relations: make(map[string][]string),
+		}
+	}
+	app.Charm = info.CharmURL
+	app.Exposed = info.Exposed
+	app.Life = life(info.Life)
+	app.Status = detailedStatus(app.Status, info.Status, info.Life)
+	app.WorkloadVersion = info.WorkloadVersion
+	s.status.Applications[info.Name] = app
+	return true
+}
+
+func (s *statusCache) applyRemoteApplication(info *multiwatcher.RemoteApplicationInfo, removed bool) bool {
+	app, ok := s.status.RemoteApplications[info.Name]
+	if removed {
+		delete(s.status.RemoteApplications, info.Name)
+		return ok
+	}
+	if !ok {
+		if !s.matches(info) {
+			return false
+		}
+		app = params.RemoteApplicationStatus{Relations: make(map[string][]string)}
+	}
+	app.OfferURL = info.OfferURL
+	app.Life = life(info.Life)
+	app.Status = detailedStatus(app.Status, info.Status, info.Life)
+	s.status.RemoteApplications[info.Name] = app
+	return true
+}
+
+func (s *statusCache) applyUnit(info *multiwatcher.UnitInfo, removed bool) bool {
+	units, appCharm := s.unitMap(info)
+	if units == nil {
+		// The unit's application or principal isn't shown.
+		return false
+	}
+	unit, ok := units[info.Name]
+	if removed {
+		delete(units, info.Name)
+		return ok
+	}
+	if !ok && !s.matches(info) {
+		return false
+	}
+	unit.AgentStatus = detailedStatus(unit.AgentStatus, info.AgentStatus, info.Life)
+	unit.WorkloadStatus = detailedStatus(unit.WorkloadStatus, info.WorkloadStatus, info.Life)
+	unit.PublicAddress = info.PublicAddress
+	if info.Principal == "" {
+		unit.Machine = info.MachineId
+	}
+	unit.Charm = ""
+	if info.CharmURL != appCharm {
+		unit.Charm = info.CharmURL
+	}
+	unit.OpenedPorts = nil
+	for _, pr := range info.PortRanges {
+		unit.OpenedPorts = append(unit.OpenedPorts, network.PortRange{
+			FromPort: pr.FromPort,
+			ToPort:   pr.ToPort,
+			Protocol: pr.Protocol,
+		}.String())
+	}
+	units[info.Name] = unit
+	return true
+}
+
+// unitMap returns the map holding the status of the unit: the units
+// of its application, or the subordinates of its principal; along with
+// the charm URL of its application. It returns nil if the application
+// or principal unit is not in the status.
+func (s *statusCache) unitMap(info *multiwatcher.UnitInfo) (map[string]params.UnitStatus, string) {
+	app, ok := s.status.Applications[info.Application]
+	if !ok {
+		return nil, ""
+	}
+	if info.Principal == "" {
+		if app.Units == nil {
+			app.Units = make(map[string]params.UnitStatus)
+			s.status.Applications[info.Application] = app
+		}
+		return app.Units, app.Charm
+	}
+	principalApp, ok := s.status.Applications[strings.Split(info.Principal, "/")[0]]
+	if !ok {
+		return nil, ""
+	}
+	principal, ok := principalApp.Units[info.Principal]
+	if !ok {
+		return nil, ""
+	}
+	if principal.Subordinates == nil {
+		principal.Subordinates = make(map[string]params.UnitStatus)
+		principalApp.Units[info.Principal] = principal
+	}
+	return principal.Subordinates, app.Charm
+}
+
+func (s *statusCache) applyRelation(info *multiwatcher.RelationInfo, removed bool) bool {
+	index := -1
+	for i, rel := range s.status.Relations {
+		if rel.Key == info.Key {
+			index = i
+			break
+		}
+	}
+	if removed {
+		if index < 0 {
+			return false
+		}
+		s.status.Relations = append(s.status.Relations[:index], s.status.Relations[index+1:]...)
+		s.updateApplicationRelations(info, true)
+		return true
+	}
+	if index < 0 && !s.matches(info) {
+		return false
+	}
+
+	rel := params.RelationStatus{Id: info.Id, Key: info.Key}
+	if index >= 0 {
+		rel.Status = s.status.Relations[index].Status
+	}
+	for _, ep := range info.Endpoints {
+		rel.Interface = ep.Relation.Interface
+		rel.Scope = ep.Relation.Scope
+		rel.Endpoints = append(rel.Endpoints, params.EndpointStatus{
+			ApplicationName: ep.ApplicationName,
+			Name:            ep.Relation.Name,
+			Role:            ep.Relation.Role,
+		})
+	}
+	if index >= 0 {
+		s.status.Relations[index] = rel
+	} else {
+		s.status.Relations = append(s.status.Relations, rel)
+	}
+	s.updateApplicationRelations(info, false)
+	return true
+}
+
+// updateApplicationRelations adds or removes, for each endpoint of the
+// relation, the names of the applications related through it.
+func (s *statusCache) updateApplicationRelations(info *multiwatcher.RelationInfo, removed bool) {
+	for _, ep := range info.Endpoints {
+		related := ep.ApplicationName
+		for _, other := range info.Endpoints {
+			if other.ApplicationName != ep.ApplicationName {
+				related = other.ApplicationName
+			}
+		}
+		relations := map[string][]string(nil)
+		if app, ok := s.status.Applications[ep.ApplicationName]; ok {
+			if app.Relations == nil {
+				app.Relations = make(map[string][]string)
+				s.status.Applications[ep.ApplicationName] = app
+			}
+			relations = app.Relations
+		} else if app, ok := s.status.RemoteApplications[ep.ApplicationName]; ok {
+			if app.Relations == nil {
+				app.Relations = make(map[string][]string)
+				s.status.RemoteApplications[ep.ApplicationName] = app
+			}
+			relations = app.Relations
+		} else {
+			continue
+		}
+		names := relations[ep.Relation.Name]
+		var updated []string
+		for _, name := range names {
+			if name != related {
+				updated = append(updated, name)
+			}
+		}
+		if !removed {
+			updated = append(updated, related)
+		}
+		if len(updated) == 0 {
+			delete(relations, ep.Relation.Name)
+		} else {
+			relations[ep.Relation.Name] = updated
+		}
+	}
+}
+
+// detailedStatus returns the given status, updated from the status
+// reported by the AllWatcher.
+func detailedStatus(current params.DetailedStatus, info multiwatcher.StatusInfo, entityLife multiwatcher.Life) params.DetailedStatus {
+	current.Status = string(info.Current)
+	current.Info = info.Message
+	current.Data = info.Data
+	current.Since = info.Since
+	current.Version = info.Version
+	current.Life = life(entityLife)
+	return current
+}
+
+// life returns the life as shown in the status, in which "alive"
+// is omitted since it is usual.
+func life(l multiwatcher.Life) string {
+	if l == "alive" {
+		return ""
+	}
+	return string(l)
+}
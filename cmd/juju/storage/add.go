@@ -11,6 +11,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
@@ -74,6 +75,13 @@ Examples:
       juju add-storage u/0 data=1 
     or
       juju add-storage u/0 data 
+
+
+    # Add a "data" volume to unit u/0 restored from a snapshot,
+    # as output by "juju snapshots". The snapshot must be of a
+    # volume in the model:
+
+      juju add-storage u/0 data=ebs --from-snapshot snap-0123456789abcdef0
 `
 	addCommandAgs = `<unit name> <charm storage name>[=<storage constraints>]`
 )
@@ -88,6 +96,16 @@ type addCommand struct {
 	// defined in charm storage metadata.
 	storageCons map[string]storage.Constraints
 	newAPIFunc  func() (StorageAddAPI, error)
+
+	// snapshotId, if non-empty, is the ID of the volume snapshot
+	// from which the new storage should be restored.
+	snapshotId string
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.snapshotId, "from-snapshot", "", "Restore the new block storage from the specified volume snapshot")
 }
 
 // Init implements Command.Init.
@@ -103,7 +121,13 @@ func (c *addCommand) Init(args []string) (err error) {
 	c.unitTag = names.NewUnitTag(u)
 
 	c.storageCons, err = storage.ParseConstraintsMap(args[1:], false)
-	return
+	if err != nil {
+		return err
+	}
+	if c.snapshotId != "" && len(c.storageCons) > 1 {
+		return errors.New("--from-snapshot may only be used with a single storage directive")
+	}
+	return nil
 }
 
 // Info implements Command.Info.
@@ -192,6 +216,7 @@ func (c *addCommand) createStorageAddParams() []params.StorageAddParams {
 				&cons.Size,
 				&cons.Count,
 			},
+			SnapshotId: c.snapshotId,
 		})
 	}
 
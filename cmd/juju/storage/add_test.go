@@ -243,6 +243,27 @@ func (s *addSuite) TestUnauthorizedMentionsJujuGrant(c *gc.C) {
 	c.Assert(errString, gc.Matches, `.*juju grant.*`)
 }
 
+func (s *addSuite) TestAddFromSnapshot(c *gc.C) {
+	var added []params.StorageAddParams
+	addToUnit := s.mockAPI.addToUnitFunc
+	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.AddStorageResult, error) {
+		added = storages
+		return addToUnit(storages)
+	}
+	_, err := s.runAdd(c, "tst/123", "data=ebs", "--from-snapshot", "snap-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added, gc.HasLen, 1)
+	c.Assert(added[0].StorageName, gc.Equals, "data")
+	c.Assert(added[0].Constraints.Pool, gc.Equals, "ebs")
+	c.Assert(added[0].SnapshotId, gc.Equals, "snap-0")
+}
+
+func (s *addSuite) TestAddFromSnapshotMultipleDirectives(c *gc.C) {
+	s.args = []string{"tst/123", "data=ebs", "logs=ebs", "--from-snapshot", "snap-0"}
+	expectedErr := "--from-snapshot may only be used with a single storage directive"
+	s.assertAddErrorOutput(c, expectedErr, visibleErrorMessage(expectedErr))
+}
+
 func (s *addSuite) assertAddErrorOutput(c *gc.C, expected string, expectedErr string) {
 	context, err := s.runAdd(c, s.args...)
 	c.Assert(errors.Cause(err), gc.ErrorMatches, expected)
@@ -91,3 +91,27 @@ func NewDetachStorageCommandForTest(new NewEntityDetacherCloserFunc, store jujuc
 	cmd.newEntityDetacherCloser = new
 	return modelcmd.Wrap(cmd)
 }
+
+func NewSnapshotStorageCommandForTest(api StorageSnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &snapshotStorageCommand{newAPIFunc: func() (StorageSnapshotAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewSnapshotListCommandForTest(api SnapshotListAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &snapshotListCommand{newAPIFunc: func() (SnapshotListAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewResizeStorageCommandForTest(api StorageResizeAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &resizeStorageCommand{newAPIFunc: func() (StorageResizeAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -0,0 +1,112 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewResizeStorageCommand returns a command used to grow
+// storage instances.
+func NewResizeStorageCommand() cmd.Command {
+	command := &resizeStorageCommand{}
+	command.newAPIFunc = func() (StorageResizeAPI, error) {
+		return command.NewStorageAPI()
+	}
+	return modelcmd.Wrap(command)
+}
+
+const (
+	resizeStorageCommandDoc = `
+Grows a storage instance to the specified size. The size is a number
+with an optional unit suffix (M, G, T, P, E); if no suffix is given,
+the size is in megabytes. Storage can only be grown, never shrunk.
+
+The storage is resized by its storage provider while it remains
+attached, so only model-scoped storage whose provider supports resizing
+(e.g. gce, azure managed disks and kubernetes) can be resized. Once the
+storage has been resized, the "storage-resized" hook is run on the units
+it is attached to, so that the charm can grow its filesystem.
+
+Examples:
+    juju resize-storage data/0 100G
+
+See also:
+    storage
+    add-storage
+`
+
+	resizeStorageCommandArgs = `<storage ID> <size>`
+)
+
+// resizeStorageCommand grows a storage instance.
+type resizeStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageResizeAPI, error)
+	storageId  string
+	size       uint64
+}
+
+// Init implements Command.Init.
+func (c *resizeStorageCommand) Init(args []string) error {
+	if len(args) != 2 {
+		return errors.New("resize-storage requires a storage ID and a size")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotatef(err, "cannot parse size %q", args[1])
+	}
+	if size == 0 {
+		return errors.NotValidf("size 0")
+	}
+	c.storageId = args[0]
+	c.size = size
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *resizeStorageCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "Grows a storage instance.",
+		Doc:     resizeStorageCommandDoc,
+		Args:    resizeStorageCommandArgs,
+	})
+}
+
+// Run implements Command.Run.
+func (c *resizeStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.ResizeStorage(c.storageId, c.size); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "resize storage")
+		}
+		return err
+	}
+	ctx.Infof("resized storage %s to %dMiB", c.storageId, c.size)
+	return nil
+}
+
+// StorageResizeAPI defines the API methods that the
+// resize-storage command uses.
+type StorageResizeAPI interface {
+	Close() error
+	ResizeStorage(storageId string, size uint64) error
+}
@@ -0,0 +1,102 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	_ "github.com/juju/juju/provider/dummy"
+)
+
+type ResizeStorageSuite struct {
+	SubStorageSuite
+	mockAPI *mockResizeAPI
+}
+
+var _ = gc.Suite(&ResizeStorageSuite{})
+
+func (s *ResizeStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockResizeAPI{}
+}
+
+func (s *ResizeStorageSuite) runResizeStorage(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewResizeStorageCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *ResizeStorageSuite) TestResizeStorageInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		expectedErr string
+	}{{
+		args:        []string{},
+		expectedErr: "resize-storage requires a storage ID and a size",
+	}, {
+		args:        []string{"data/0"},
+		expectedErr: "resize-storage requires a storage ID and a size",
+	}, {
+		args:        []string{"data/0", "10G", "extra"},
+		expectedErr: "resize-storage requires a storage ID and a size",
+	}, {
+		args:        []string{"data", "10G"},
+		expectedErr: `storage ID "data" not valid`,
+	}, {
+		args:        []string{"data/0", "lots"},
+		expectedErr: `cannot parse size "lots": .*`,
+	}, {
+		args:        []string{"data/0", "0"},
+		expectedErr: "size 0 not valid",
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		_, err := s.runResizeStorage(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.expectedErr)
+	}
+}
+
+func (s *ResizeStorageSuite) TestResizeStorage(c *gc.C) {
+	s.mockAPI.resizeStorageFunc = func(storageId string, size uint64) error {
+		c.Assert(storageId, gc.Equals, "data/0")
+		c.Assert(size, gc.Equals, uint64(10*1024))
+		return nil
+	}
+	ctx, err := s.runResizeStorage(c, "data/0", "10G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "resized storage data/0 to 10240MiB\n")
+}
+
+func (s *ResizeStorageSuite) TestResizeStorageError(c *gc.C) {
+	s.mockAPI.resizeStorageFunc = func(storageId string, size uint64) error {
+		return errors.New("new size 1024MiB must be larger than current size 2048MiB")
+	}
+	_, err := s.runResizeStorage(c, "data/0", "1G")
+	c.Assert(err, gc.ErrorMatches, "new size 1024MiB must be larger than current size 2048MiB")
+}
+
+func (s *ResizeStorageSuite) TestResizeStorageUnauthorized(c *gc.C) {
+	s.mockAPI.resizeStorageFunc = func(storageId string, size uint64) error {
+		return &params.Error{Code: params.CodeUnauthorized, Message: "nope"}
+	}
+	ctx, err := s.runResizeStorage(c, "data/0", "10G")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `You do not have permission to resize storage.`)
+}
+
+type mockResizeAPI struct {
+	resizeStorageFunc func(string, uint64) error
+}
+
+func (*mockResizeAPI) Close() error {
+	return nil
+}
+
+func (m *mockResizeAPI) ResizeStorage(storageId string, size uint64) error {
+	return m.resizeStorageFunc(storageId, size)
+}
@@ -0,0 +1,113 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewSnapshotStorageCommand returns a command used to take
+// snapshots of volumes.
+func NewSnapshotStorageCommand() cmd.Command {
+	command := &snapshotStorageCommand{}
+	command.newAPIFunc = func() (StorageSnapshotAPI, error) {
+		return command.NewStorageAPI()
+	}
+	return modelcmd.Wrap(command)
+}
+
+const (
+	snapshotStorageCommandDoc = `
+Takes point-in-time snapshots of volumes. Specify one or more volume IDs,
+as output by "juju storage --volume".
+
+Snapshots are taken by the storage provider that manages the volume, and
+only model-scoped volumes whose provider supports snapshots (e.g. ebs and
+cinder) can be snapshotted. The snapshot is crash-consistent; applications
+should be quiesced beforehand if a stronger guarantee is required.
+
+A snapshot may later be restored to a new volume with
+"juju add-storage --from-snapshot".
+
+Examples:
+    juju snapshot-storage 0
+    juju snapshot-storage 0 2/1
+
+See also:
+    snapshots
+    add-storage
+`
+
+	snapshotStorageCommandArgs = `<volume ID> [<volume ID> ...]`
+)
+
+// snapshotStorageCommand takes snapshots of volumes.
+type snapshotStorageCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc func() (StorageSnapshotAPI, error)
+	volumeIds  []string
+}
+
+// Init implements Command.Init.
+func (c *snapshotStorageCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("snapshot-storage requires at least one volume ID")
+	}
+	c.volumeIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *snapshotStorageCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "snapshot-storage",
+		Purpose: "Takes snapshots of volumes.",
+		Doc:     snapshotStorageCommandDoc,
+		Args:    snapshotStorageCommandArgs,
+	})
+}
+
+// Run implements Command.Run.
+func (c *snapshotStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.CreateSnapshots(c.volumeIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "snapshot storage")
+		}
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to snapshot volume %s: %s", c.volumeIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("created snapshot %s of volume %s", result.Result.SnapshotId, c.volumeIds[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageSnapshotAPI defines the API methods that the
+// snapshot-storage command uses.
+type StorageSnapshotAPI interface {
+	Close() error
+	CreateSnapshots(volumeIds []string) ([]params.VolumeSnapshotResult, error)
+}
@@ -0,0 +1,187 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	_ "github.com/juju/juju/provider/dummy"
+)
+
+type SnapshotStorageSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&SnapshotStorageSuite{})
+
+func (s *SnapshotStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockSnapshotAPI{}
+}
+
+func (s *SnapshotStorageSuite) runSnapshotStorage(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewSnapshotStorageCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *SnapshotStorageSuite) TestSnapshotStorageNoArgs(c *gc.C) {
+	_, err := s.runSnapshotStorage(c)
+	c.Assert(err, gc.ErrorMatches, "snapshot-storage requires at least one volume ID")
+}
+
+func (s *SnapshotStorageSuite) TestSnapshotStorage(c *gc.C) {
+	s.mockAPI.createSnapshotsFunc = func(volumeIds []string) ([]params.VolumeSnapshotResult, error) {
+		c.Assert(volumeIds, jc.DeepEquals, []string{"0", "1"})
+		return []params.VolumeSnapshotResult{
+			{Result: &params.VolumeSnapshot{SnapshotId: "snap-0", VolumeTag: "volume-0"}},
+			{Result: &params.VolumeSnapshot{SnapshotId: "snap-1", VolumeTag: "volume-1"}},
+		}, nil
+	}
+	ctx, err := s.runSnapshotStorage(c, "0", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+created snapshot snap-0 of volume 0
+created snapshot snap-1 of volume 1
+`[1:])
+}
+
+func (s *SnapshotStorageSuite) TestSnapshotStoragePartialFailure(c *gc.C) {
+	s.mockAPI.createSnapshotsFunc = func(volumeIds []string) ([]params.VolumeSnapshotResult, error) {
+		return []params.VolumeSnapshotResult{
+			{Result: &params.VolumeSnapshot{SnapshotId: "snap-0", VolumeTag: "volume-0"}},
+			{Error: &params.Error{Message: `snapshots with storage provider "loop" not supported`}},
+		}, nil
+	}
+	ctx, err := s.runSnapshotStorage(c, "0", "1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+created snapshot snap-0 of volume 0
+failed to snapshot volume 1: snapshots with storage provider "loop" not supported
+`[1:])
+}
+
+func (s *SnapshotStorageSuite) TestSnapshotStorageUnauthorized(c *gc.C) {
+	s.mockAPI.createSnapshotsFunc = func(volumeIds []string) ([]params.VolumeSnapshotResult, error) {
+		return nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"}
+	}
+	ctx, err := s.runSnapshotStorage(c, "0")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `You do not have permission to snapshot storage.`)
+}
+
+type SnapshotListSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&SnapshotListSuite{})
+
+func (s *SnapshotListSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	created := time.Date(2019, 5, 1, 12, 30, 0, 0, time.UTC)
+	s.mockAPI = &mockSnapshotAPI{
+		listSnapshotsFunc: func(volumeIds []string) ([]params.VolumeSnapshotsResult, error) {
+			results := make([]params.VolumeSnapshotsResult, len(volumeIds))
+			for i, id := range volumeIds {
+				if id == "2" {
+					results[i].Error = &params.Error{Message: "boom"}
+					continue
+				}
+				results[i].Result = []params.VolumeSnapshot{{
+					SnapshotId: "snap-" + id + "b",
+					VolumeTag:  "volume-" + id,
+					Size:       1024,
+					Status:     "completed",
+					Created:    created.Add(time.Hour),
+				}, {
+					SnapshotId: "snap-" + id + "a",
+					VolumeTag:  "volume-" + id,
+					Size:       1024,
+					Status:     "completed",
+					Created:    created,
+				}}
+			}
+			return results, nil
+		},
+	}
+}
+
+func (s *SnapshotListSuite) runSnapshotList(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewSnapshotListCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *SnapshotListSuite) TestSnapshotListNoArgs(c *gc.C) {
+	_, err := s.runSnapshotList(c)
+	c.Assert(err, gc.ErrorMatches, "snapshots requires at least one volume ID")
+}
+
+func (s *SnapshotListSuite) TestSnapshotListTabular(c *gc.C) {
+	ctx, err := s.runSnapshotList(c, "1", "0", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Volume  Snapshot  Size    Status     Created
+0       snap-0a   1.0GiB  completed  2019-05-01 12:30:00Z
+0       snap-0b   1.0GiB  completed  2019-05-01 13:30:00Z
+1       snap-1a   1.0GiB  completed  2019-05-01 12:30:00Z
+1       snap-1b   1.0GiB  completed  2019-05-01 13:30:00Z
+`[1:])
+}
+
+func (s *SnapshotListSuite) TestSnapshotListYAML(c *gc.C) {
+	ctx, err := s.runSnapshotList(c, "0", "--format", "yaml", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+"0":
+  snap-0a:
+    size: 1024
+    status: completed
+    created: 2019-05-01 12:30:00Z
+  snap-0b:
+    size: 1024
+    status: completed
+    created: 2019-05-01 13:30:00Z
+`[1:])
+}
+
+func (s *SnapshotListSuite) TestSnapshotListNone(c *gc.C) {
+	s.mockAPI.listSnapshotsFunc = func(volumeIds []string) ([]params.VolumeSnapshotsResult, error) {
+		return []params.VolumeSnapshotsResult{{}}, nil
+	}
+	ctx, err := s.runSnapshotList(c, "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No snapshots to display.\n")
+}
+
+func (s *SnapshotListSuite) TestSnapshotListError(c *gc.C) {
+	ctx, err := s.runSnapshotList(c, "0", "2", "--format", "json", "--utc")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"0":{"snap-0a":{"size":1024,"status":"completed","created":"2019-05-01 12:30:00Z"},"snap-0b":{"size":1024,"status":"completed","created":"2019-05-01 13:30:00Z"}}}`+"\n")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "failed to list snapshots of volume 2: boom\n")
+}
+
+type mockSnapshotAPI struct {
+	createSnapshotsFunc func([]string) ([]params.VolumeSnapshotResult, error)
+	listSnapshotsFunc   func([]string) ([]params.VolumeSnapshotsResult, error)
+}
+
+func (*mockSnapshotAPI) Close() error {
+	return nil
+}
+
+func (m *mockSnapshotAPI) CreateSnapshots(volumeIds []string) ([]params.VolumeSnapshotResult, error) {
+	return m.createSnapshotsFunc(volumeIds)
+}
+
+func (m *mockSnapshotAPI) ListSnapshots(volumeIds []string) ([]params.VolumeSnapshotsResult, error) {
+	return m.listSnapshotsFunc(volumeIds)
+}
@@ -0,0 +1,190 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewSnapshotListCommand returns a command used to list
+// the snapshots of volumes.
+func NewSnapshotListCommand() cmd.Command {
+	command := &snapshotListCommand{}
+	command.newAPIFunc = func() (SnapshotListAPI, error) {
+		return command.NewStorageAPI()
+	}
+	return modelcmd.Wrap(command)
+}
+
+const snapshotListCommandDoc = `
+Lists the snapshots of volumes. Specify one or more volume IDs, as output
+by "juju storage --volume".
+
+Examples:
+    juju snapshots 0
+    juju snapshots 0 2/1 --format yaml
+
+See also:
+    snapshot-storage
+    add-storage
+`
+
+// SnapshotInfo defines the serialization behaviour of
+// volume snapshot information.
+type SnapshotInfo struct {
+	Size    uint64 `yaml:"size" json:"size"`
+	Status  string `yaml:"status,omitempty" json:"status,omitempty"`
+	Created string `yaml:"created" json:"created"`
+}
+
+// snapshotListCommand lists the snapshots of volumes.
+type snapshotListCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc func() (SnapshotListAPI, error)
+	volumeIds  []string
+	isoTime    bool
+	out        cmd.Output
+}
+
+// Init implements Command.Init.
+func (c *snapshotListCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("snapshots requires at least one volume ID")
+	}
+	c.volumeIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *snapshotListCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "snapshots",
+		Args:    "<volume ID> [<volume ID> ...]",
+		Purpose: "Lists snapshots of volumes.",
+		Doc:     snapshotListCommandDoc,
+		Aliases: []string{"list-snapshots"},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *snapshotListCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *snapshotListCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.ListSnapshots(c.volumeIds)
+	if err != nil {
+		return err
+	}
+	infos := make(map[string]map[string]SnapshotInfo)
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to list snapshots of volume %s: %s", c.volumeIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		for _, snapshot := range result.Result {
+			volumeTag, err := names.ParseVolumeTag(snapshot.VolumeTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			snapshots, ok := infos[volumeTag.Id()]
+			if !ok {
+				snapshots = make(map[string]SnapshotInfo)
+				infos[volumeTag.Id()] = snapshots
+			}
+			snapshots[snapshot.SnapshotId] = SnapshotInfo{
+				Size:    snapshot.Size,
+				Status:  snapshot.Status,
+				Created: common.FormatTime(&snapshot.Created, c.isoTime),
+			}
+		}
+	}
+	if len(infos) == 0 && !anyFailed {
+		ctx.Infof("No snapshots to display.")
+		return nil
+	}
+	if len(infos) > 0 {
+		if err := c.out.Write(ctx, infos); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// formatTabular returns a tabular summary of volume snapshots.
+func (c *snapshotListCommand) formatTabular(writer io.Writer, value interface{}) error {
+	infos, ok := value.(map[string]map[string]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("Volume", "Snapshot", "Size", "Status", "Created")
+
+	volumeIds := make([]string, 0, len(infos))
+	for volumeId := range infos {
+		volumeIds = append(volumeIds, volumeId)
+	}
+	sort.Strings(volumeIds)
+	for _, volumeId := range volumeIds {
+		snapshots := infos[volumeId]
+		snapshotIds := make([]string, 0, len(snapshots))
+		for snapshotId := range snapshots {
+			snapshotIds = append(snapshotIds, snapshotId)
+		}
+		sort.Strings(snapshotIds)
+		for _, snapshotId := range snapshotIds {
+			info := snapshots[snapshotId]
+			var size string
+			if info.Size > 0 {
+				size = humanize.IBytes(info.Size * humanize.MiByte)
+			}
+			print(volumeId, snapshotId, size, info.Status, info.Created)
+		}
+	}
+	return tw.Flush()
+}
+
+// SnapshotListAPI defines the API methods that the
+// snapshots command uses.
+type SnapshotListAPI interface {
+	Close() error
+	ListSnapshots(volumeIds []string) ([]params.VolumeSnapshotsResult, error)
+}
@@ -33,8 +33,10 @@ var (
 	}
 	requireValidCredentialModelWorkers = []string{
 		"action-pruner",          // tertiary dependency: will be inactive because migration workers will be inactive
+		"action-scheduler",       // tertiary dependency: will be inactive because migration workers will be inactive
 		"application-scaler",     // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-revision-updater", // tertiary dependency: will be inactive because migration workers will be inactive
+		"charm-rollout-updater",  // tertiary dependency: will be inactive because migration workers will be inactive
 		"compute-provisioner",
 		"environ-tracker",
 		"firewaller",
@@ -55,8 +57,10 @@ var (
 	}
 	aliveModelWorkers = []string{
 		"action-pruner",
+		"action-scheduler",
 		"application-scaler",
 		"charm-revision-updater",
+		"charm-rollout-updater",
 		"compute-provisioner",
 		"environ-tracker",
 		"firewaller",
@@ -580,6 +580,7 @@ func (a *MachineAgent) makeEngineCreator(agentName string, previousAgentVersion
 			MachineLock:                       a.machineLock,
 			SetStatePool:                      statePoolReporter.set,
 			RegisterIntrospectionHTTPHandlers: registerIntrospectionHandlers,
+			IntrospectionSocketName:           a.newIntrospectionSocketName,
 			NewModelWorker:                    a.startModelWorkers,
 			MuxShutdownWait:                   1 * time.Minute,
 			NewContainerBrokerFunc:            newCAASBroker,
@@ -1004,6 +1005,7 @@ func (a *MachineAgent) startModelWorkers(modelUUID string, modelType state.Model
 		Clock:                       clock.WallClock,
 		RunFlagDuration:             time.Minute,
 		CharmRevisionUpdateInterval: 24 * time.Hour,
+		CharmRefreshPolicyInterval:  5 * time.Minute,
 		InstPollerAggregationDelay:  3 * time.Second,
 		StatusHistoryPrunerInterval: 5 * time.Minute,
 		ActionPrunerInterval:        24 * time.Hour,
@@ -34,6 +34,7 @@ import (
 	"github.com/juju/juju/core/raftlease"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups/remote"
 	proxyconfig "github.com/juju/juju/utils/proxy"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/agent"
@@ -45,6 +46,7 @@ import (
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/caasupgrader"
 	"github.com/juju/juju/worker/centralhub"
 	"github.com/juju/juju/worker/certupdater"
@@ -86,6 +88,7 @@ import (
 	"github.com/juju/juju/worker/raft/raftforwarder"
 	"github.com/juju/juju/worker/raft/rafttransport"
 	"github.com/juju/juju/worker/reboot"
+	"github.com/juju/juju/worker/remoteintrospection"
 	"github.com/juju/juju/worker/restorewatcher"
 	"github.com/juju/juju/worker/resumer"
 	"github.com/juju/juju/worker/singular"
@@ -239,6 +242,11 @@ type ManifoldsConfig struct {
 	// alter the path as it sees fit, e.g. by adding a prefix.
 	RegisterIntrospectionHTTPHandlers func(func(path string, _ http.Handler))
 
+	// IntrospectionSocketName returns the name of the abstract domain
+	// socket on which the agent with the given tag serves introspection
+	// requests.
+	IntrospectionSocketName func(names.Tag) string
+
 	// NewModelWorker returns a new worker for managing the model with
 	// the specified UUID and type.
 	NewModelWorker func(modelUUID string, modelType state.ModelType) (worker.Worker, error)
@@ -629,6 +637,15 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:     machineactions.NewMachineActionsWorker,
 		})),
 
+		remoteIntrospectionName: ifNotMigrating(remoteintrospection.Manifold(remoteintrospection.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			SocketName:    config.IntrospectionSocketName,
+			Clock:         config.Clock,
+			NewFacade:     remoteintrospection.NewFacade,
+			NewWorker:     remoteintrospection.NewWorker,
+		})),
+
 		externalControllerUpdaterName: ifNotMigrating(ifPrimaryController(externalcontrollerupdater.Manifold(
 			externalcontrollerupdater.ManifoldConfig{
 				APICallerName:                      apiCallerName,
@@ -947,6 +964,19 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewClient:     instancemutater.NewClient,
 			NewWorker:     instancemutater.NewContainerWorker,
 		})),
+
+		// The backup scheduler takes backups of the controller at the
+		// interval set in the controller config. Backups aren't
+		// supported on kubernetes controllers, so it is IAAS only.
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(
+			backupscheduler.ManifoldConfig{
+				AgentName:  agentName,
+				ClockName:  clockName,
+				StateName:  stateName,
+				NewWorker:  backupscheduler.NewWorker,
+				NewStorage: remote.NewStorage,
+			},
+		))),
 	}
 
 	return mergeManifolds(config, manifolds)
@@ -1068,6 +1098,7 @@ const (
 	identityFileWriterName        = "ssh-identity-writer"
 	toolsVersionCheckerName       = "tools-version-checker"
 	machineActionName             = "machine-action-runner"
+	remoteIntrospectionName       = "remote-introspection"
 	hostKeyReporterName           = "host-key-reporter"
 	fanConfigurerName             = "fan-configurer"
 	externalControllerUpdaterName = "external-controller-updater"
@@ -1077,6 +1108,7 @@ const (
 	isControllerFlagName          = "is-controller-flag"
 	instanceMutaterName           = "instance-mutater"
 	logPrunerName                 = "log-pruner"
+	backupSchedulerName           = "backup-scheduler"
 	txnPrunerName                 = "transaction-pruner"
 	certificateWatcherName        = "certificate-watcher"
 	modelCacheName                = "model-cache"
@@ -63,6 +63,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"api-config-watcher",
 			"api-server",
 			"audit-config-updater",
+			"backup-scheduler",
 			"broker-tracker",
 			"central-hub",
 			"certificate-updater",
@@ -104,6 +105,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"raft-leader-flag",
 			"raft-transport",
 			"reboot-executor",
+			"remote-introspection",
 			"restore-watcher",
 			"ssh-authkeys-updater",
 			"ssh-identity-writer",
@@ -176,6 +178,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"raft-forwarder",
 			"raft-leader-flag",
 			"raft-transport",
+			"remote-introspection",
 			"restore-watcher",
 			"ssh-identity-writer",
 			"state",
@@ -288,6 +291,7 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 		"raft-transport",
 	)
 	primaryControllerWorkers := set.NewStrings(
+		"backup-scheduler",
 		"external-controller-updater",
 		"log-pruner",
 		"transaction-pruner",
@@ -429,6 +433,23 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"backup-scheduler": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"central-hub": {"agent", "state-config-watcher"},
 
 	"certificate-updater": {
@@ -880,6 +901,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"remote-introspection": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"restore-watcher": {"agent", "state", "state-config-watcher"},
 
 	"ssh-authkeys-updater": {
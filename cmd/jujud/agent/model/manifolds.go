@@ -23,7 +23,10 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/worker/actionpruner"
+	"github.com/juju/juju/worker/actionscheduler"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
@@ -35,6 +38,7 @@ import (
 	"github.com/juju/juju/worker/caasunitprovisioner"
 	"github.com/juju/juju/worker/charmrevision"
 	"github.com/juju/juju/worker/charmrevision/charmrevisionmanifold"
+	"github.com/juju/juju/worker/charmrollout"
 	"github.com/juju/juju/worker/cleaner"
 	"github.com/juju/juju/worker/common"
 	"github.com/juju/juju/worker/credentialvalidator"
@@ -96,6 +100,11 @@ type ManifoldsConfig struct {
 	// revision worker will check for new revisions of known charms.
 	CharmRevisionUpdateInterval time.Duration
 
+	// CharmRefreshPolicyInterval determines how often the charm-
+	// revision worker will apply charm refresh policies, in addition
+	// to applying them after each charm revision update.
+	CharmRefreshPolicyInterval time.Duration
+
 	// StatusHistoryPruner* values control status-history pruning
 	// behaviour.
 	StatusHistoryPrunerInterval time.Duration
@@ -236,6 +245,7 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			ClockName:     clockName,
 			Period:        config.CharmRevisionUpdateInterval,
+			RefreshPeriod: config.CharmRefreshPolicyInterval,
 
 			NewFacade: charmrevisionmanifold.NewAPIFacade,
 			NewWorker: charmrevision.NewWorker,
@@ -267,11 +277,20 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     actionpruner.NewFacade,
 			PruneInterval: config.ActionPrunerInterval,
 		})),
+		actionSchedulerName: ifNotMigrating(actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+		})),
 		logForwarderName: ifNotDead(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
+				Type:   syslog.SinkType,
 				OpenFn: sinks.OpenSyslog,
+			}, {
+				Name:   "juju-log-forward-http",
+				Type:   httpjson.SinkType,
+				OpenFn: sinks.OpenHTTP,
 			}},
 		})),
 		// The environ upgrader runs on all controller agents, and
@@ -376,6 +395,10 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Delay:                        config.InstPollerAggregationDelay,
 			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
 		}))),
+		charmRolloutUpdaterName: ifNotMigrating(charmrollout.Manifold(charmrollout.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+		})),
 		metricWorkerName: ifNotMigrating(metricworker.Manifold(metricworker.ManifoldConfig{
 			APICallerName: apiCallerName,
 		})),
@@ -590,10 +613,12 @@ const (
 	applicationScalerName    = "application-scaler"
 	instancePollerName       = "instance-poller"
 	charmRevisionUpdaterName = "charm-revision-updater"
+	charmRolloutUpdaterName  = "charm-rollout-updater"
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
+	actionSchedulerName      = "action-scheduler"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
@@ -33,11 +33,13 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
 		"action-pruner",
+		"action-scheduler",
 		"agent",
 		"api-caller",
 		"api-config-watcher",
 		"application-scaler",
 		"charm-revision-updater",
+		"charm-rollout-updater",
 		"clock",
 		"compute-provisioner",
 		"environ-tracker",
@@ -78,6 +80,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
 		"action-pruner",
+		"action-scheduler",
 		"agent",
 		"api-caller",
 		"api-config-watcher",
@@ -201,6 +204,17 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"environ-upgraded-flag",
 		"not-dead-flag"},
 
+	"action-scheduler": {
+		"agent",
+		"api-caller",
+		"clock",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"not-dead-flag"},
+
 	"agent": {},
 
 	"api-caller": {"agent"},
@@ -383,6 +397,17 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"valid-credential-flag",
 	},
 
+	"action-scheduler": {
+		"agent",
+		"api-caller",
+		"clock",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"not-dead-flag"},
+
 	"agent": {},
 
 	"api-caller": {"agent"},
@@ -411,6 +436,17 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"environ-upgraded-flag",
 		"not-dead-flag"},
 
+	"charm-rollout-updater": {
+		"agent",
+		"api-caller",
+		"clock",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"not-dead-flag"},
+
 	"clock": {},
 
 	"compute-provisioner": {
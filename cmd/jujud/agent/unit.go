@@ -192,18 +192,19 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 	}
 
 	manifolds := unitManifolds(unit.ManifoldsConfig{
-		Agent:                agent.APIHostPortsSetter{a},
-		LogSource:            a.bufferedLogger.Logs(),
-		LeadershipGuarantee:  30 * time.Second,
-		AgentConfigChanged:   a.configChangedVal,
-		ValidateMigration:    a.validateMigration,
-		PrometheusRegisterer: a.prometheusRegistry,
-		UpdateLoggerConfig:   updateAgentConfLogging,
-		PreviousAgentVersion: agentConfig.UpgradedToVersion(),
-		PreUpgradeSteps:      a.preUpgradeSteps,
-		UpgradeStepsLock:     a.upgradeComplete,
-		UpgradeCheckLock:     a.initialUpgradeCheckComplete,
-		MachineLock:          machineLock,
+		Agent:                   agent.APIHostPortsSetter{a},
+		LogSource:               a.bufferedLogger.Logs(),
+		LeadershipGuarantee:     30 * time.Second,
+		AgentConfigChanged:      a.configChangedVal,
+		ValidateMigration:       a.validateMigration,
+		PrometheusRegisterer:    a.prometheusRegistry,
+		UpdateLoggerConfig:      updateAgentConfLogging,
+		PreviousAgentVersion:    agentConfig.UpgradedToVersion(),
+		PreUpgradeSteps:         a.preUpgradeSteps,
+		UpgradeStepsLock:        a.upgradeComplete,
+		UpgradeCheckLock:        a.initialUpgradeCheckComplete,
+		MachineLock:             machineLock,
+		IntrospectionSocketName: DefaultIntrospectionSocketName,
 	})
 
 	engine, err := dependency.NewEngine(dependencyEngineConfig())
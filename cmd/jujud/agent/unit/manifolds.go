@@ -12,6 +12,7 @@ import (
 	"github.com/juju/utils/voyeur"
 	"github.com/juju/version"
 	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1/dependency"
 
 	coreagent "github.com/juju/juju/agent"
@@ -40,6 +41,7 @@ import (
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationminion"
 	"github.com/juju/juju/worker/proxyupdater"
+	"github.com/juju/juju/worker/remoteintrospection"
 	"github.com/juju/juju/worker/retrystrategy"
 	"github.com/juju/juju/worker/uniter"
 	"github.com/juju/juju/worker/upgrader"
@@ -99,6 +101,11 @@ type ManifoldsConfig struct {
 	// This is used by a number of workers to ensure serialisation of actions
 	// across the machine.
 	MachineLock machinelock.Lock
+
+	// IntrospectionSocketName returns the name of the abstract domain
+	// socket on which the agent with the given tag serves introspection
+	// requests.
+	IntrospectionSocketName func(names.Tag) string
 }
 
 // Manifolds returns a set of co-configured manifolds covering the various
@@ -344,6 +351,17 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName:   apiCallerName,
 			MetricSpoolName: metricSpoolName,
 		})),
+
+		// The remote introspection worker answers introspection
+		// requests relayed to the unit agent through the controller.
+		remoteIntrospectionName: ifNotMigrating(remoteintrospection.Manifold(remoteintrospection.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			SocketName:    config.IntrospectionSocketName,
+			Clock:         clock.WallClock,
+			NewFacade:     remoteintrospection.NewFacade,
+			NewWorker:     remoteintrospection.NewWorker,
+		})),
 	}
 }
 
@@ -391,6 +409,8 @@ const (
 	meterStatusName   = "meter-status"
 	metricCollectName = "metric-collect"
 	metricSenderName  = "metric-sender"
+
+	remoteIntrospectionName = "remote-introspection"
 )
 
 type noopStatusSetter struct{}
@@ -54,6 +54,7 @@ func (s *ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"meter-status",
 		"metric-collect",
 		"metric-sender",
+		"remote-introspection",
 		"upgrade-steps-flag",
 		"upgrade-steps-runner",
 		"upgrade-steps-gate",
@@ -261,6 +262,17 @@ var expectedUnitManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-flag",
 		"upgrade-steps-gate"},
 
+	"remote-introspection": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate"},
+
 	"uniter": {
 		"agent",
 		"api-caller",
@@ -57,7 +57,8 @@ const (
 	AuditLogCaptureArgs = "audit-log-capture-args"
 
 	// AuditLogMaxSize is the maximum size for the current audit log
-	// file, eg "250M".
+	// file, eg "250M". It is also the size to which the audit log
+	// recorded in the database is pruned.
 	AuditLogMaxSize = "audit-log-max-size"
 
 	// AuditLogMaxBackups is the number of old audit log files to keep
@@ -71,6 +72,11 @@ const (
 	// interesting calls though.)
 	AuditLogExcludeMethods = "audit-log-exclude-methods"
 
+	// AuditLogBackends is the list of backends that audit log
+	// entries are written to. See the AuditLogBackend* constants for
+	// the valid values.
+	AuditLogBackends = "audit-log-backends"
+
 	// ReadOnlyMethodsWildcard is the special value that can be added
 	// to the exclude-methods list that represents all of the read
 	// only methods (see apiserver/observer/auditfilter.go). This
@@ -79,6 +85,20 @@ const (
 	// new versions of Juju will be honoured.
 	ReadOnlyMethodsWildcard = "ReadOnlyMethods"
 
+	// AuditLogBackendFile writes audit log entries to a rotated file
+	// in the log directory of each controller machine.
+	AuditLogBackendFile = "file"
+
+	// AuditLogBackendLogForward writes audit log entries to the
+	// controller's logs, so they are sent to any log forwarding
+	// targets that are configured.
+	AuditLogBackendLogForward = "log-forward"
+
+	// AuditLogBackendDatabase writes audit log entries to the
+	// controller database, from where they can be queried with
+	// "juju audit-log".
+	AuditLogBackendDatabase = "database"
+
 	// StatePort is the port used for mongo connections.
 	StatePort = "state-port"
 
@@ -210,6 +230,14 @@ const (
 	// DefaultPruneTxnQueryCount is the number of transactions to read in a single query.
 	DefaultPruneTxnQueryCount = 1000
 
+	// DefaultBackupS3Endpoint is the default S3 service used for
+	// automatic backups.
+	DefaultBackupS3Endpoint = "https://s3.amazonaws.com"
+
+	// DefaultBackupS3Region is the default region used to sign
+	// requests to the S3 service.
+	DefaultBackupS3Region = "us-east-1"
+
 	// DefaultPruneTxnSleepTime is the amount of time to sleep between processing each
 	// batch query. This is used to reduce load on the system, allowing other queries
 	// to time to operate. On large controllers, processing 1000 txs seems to take
@@ -239,6 +267,40 @@ const (
 
 	// MeteringURL is the key for the url to use for metrics
 	MeteringURL = "metering-url"
+
+	// BackupInterval is how often the controller takes a backup of
+	// itself, eg "24h". Automatic backups are disabled if it is not set.
+	BackupInterval = "backup-interval"
+
+	// BackupRetentionCount is the number of automatic backups to keep.
+	// Older backups are removed. Zero keeps them all.
+	BackupRetentionCount = "backup-retention-count"
+
+	// BackupRetentionAge is the age after which automatic backups are
+	// removed, eg "720h". The most recent backup is always kept.
+	BackupRetentionAge = "backup-retention-age"
+
+	// BackupTarget is a URL to which automatic backups are copied, in
+	// addition to being stored on the controller. It may be a local
+	// directory ("file:///var/backups/juju") or an S3-compatible
+	// bucket with an optional key prefix ("s3://bucket/prefix").
+	BackupTarget = "backup-target"
+
+	// BackupS3Endpoint is the URL of the S3-compatible service used
+	// when the backup target is an s3 URL.
+	BackupS3Endpoint = "backup-s3-endpoint"
+
+	// BackupS3Region is the region used to sign requests to the S3
+	// service.
+	BackupS3Region = "backup-s3-region"
+
+	// BackupS3AccessKey is the access key used for the S3 service.
+	BackupS3AccessKey = "backup-s3-access-key"
+
+	// BackupS3SecretKey is the secret key used for the S3 service.
+	// It is stored with the controller's secrets, and is not
+	// returned with the rest of the controller config.
+	BackupS3SecretKey = "backup-s3-secret-key"
 )
 
 var (
@@ -273,10 +335,19 @@ var (
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
 		AuditLogExcludeMethods,
+		AuditLogBackends,
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
 		MeteringURL,
+		BackupInterval,
+		BackupRetentionCount,
+		BackupRetentionAge,
+		BackupTarget,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 	}
 
 	// AllowedUpdateConfigAttributes contains all of the controller
@@ -287,6 +358,7 @@ var (
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogExcludeMethods,
+		AuditLogBackends,
 		// TODO Juju 3.0: ControllerAPIPort should be required and treated
 		// more like api-port.
 		ControllerAPIPort,
@@ -302,6 +374,21 @@ var (
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
+		BackupInterval,
+		BackupRetentionCount,
+		BackupRetentionAge,
+		BackupTarget,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
+	)
+
+	// SecretConfigAttributes are controller config attributes which
+	// are stored apart from the rest of the controller config, so
+	// that they are never returned by ControllerConfig.
+	SecretConfigAttributes = set.NewStrings(
+		BackupS3SecretKey,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -312,6 +399,20 @@ var (
 		ReadOnlyMethodsWildcard,
 	}
 
+	// DefaultAuditLogBackends is the default list of backends that
+	// audit log entries are written to.
+	DefaultAuditLogBackends = []string{
+		AuditLogBackendFile,
+	}
+
+	validBackupTargetSchemes = set.NewStrings("file", "s3")
+
+	validAuditLogBackends = set.NewStrings(
+		AuditLogBackendFile,
+		AuditLogBackendLogForward,
+		AuditLogBackendDatabase,
+	)
+
 	methodNameRE = regexp.MustCompile(`[[:alpha:]][[:alnum:]]*\.[[:alpha:]][[:alnum:]]*`)
 )
 
@@ -473,6 +574,20 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// AuditLogBackends returns the set of backends that audit log entries
+// should be written to.
+func (c Config) AuditLogBackends() set.Strings {
+	if value, ok := c[AuditLogBackends]; ok {
+		value := value.([]interface{})
+		items := set.NewStrings()
+		for _, item := range value {
+			items.Add(item.(string))
+		}
+		return items
+	}
+	return set.NewStrings(DefaultAuditLogBackends...)
+}
+
 // Features returns the controller config set features flags.
 func (c Config) Features() set.Strings {
 	features := set.NewStrings()
@@ -653,6 +768,58 @@ func (c Config) MeteringURL() string {
 	return url
 }
 
+// BackupInterval returns how often the controller takes a backup of
+// itself. Zero means automatic backups are disabled.
+func (c Config) BackupInterval() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(BackupInterval))
+	return val
+}
+
+// BackupRetentionCount returns the number of automatic backups to
+// keep. Zero means they are all kept.
+func (c Config) BackupRetentionCount() int {
+	return c.intOrDefault(BackupRetentionCount, 0)
+}
+
+// BackupRetentionAge returns the age after which automatic backups
+// are removed. Zero means they are kept regardless of age.
+func (c Config) BackupRetentionAge() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(BackupRetentionAge))
+	return val
+}
+
+// BackupTarget returns the URL to which automatic backups are copied,
+// or "" if they are only stored on the controller.
+func (c Config) BackupTarget() string {
+	return c.asString(BackupTarget)
+}
+
+// BackupS3Endpoint returns the URL of the S3-compatible service used
+// for automatic backups.
+func (c Config) BackupS3Endpoint() string {
+	if v := c.asString(BackupS3Endpoint); v != "" {
+		return v
+	}
+	return DefaultBackupS3Endpoint
+}
+
+// BackupS3Region returns the region used to sign requests to the S3
+// service used for automatic backups.
+func (c Config) BackupS3Region() string {
+	if v := c.asString(BackupS3Region); v != "" {
+		return v
+	}
+	return DefaultBackupS3Region
+}
+
+// BackupS3Credentials returns the access key and secret key used for
+// the S3 service used for automatic backups.
+func (c Config) BackupS3Credentials() (accessKey, secretKey string) {
+	return c.asString(BackupS3AccessKey), c.asString(BackupS3SecretKey)
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -772,6 +939,20 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[AuditLogBackends].([]interface{}); ok {
+		for i, name := range v {
+			name := name.(string)
+			if !validAuditLogBackends.Contains(name) {
+				return errors.Errorf(
+					`invalid audit log backends: should be a list of %q, got %q at position %d`,
+					validAuditLogBackends.SortedValues(),
+					name,
+					i+1,
+				)
+			}
+		}
+	}
+
 	if v, ok := c[ControllerAPIPort].(int); ok {
 		// TODO: change the validation so 0 is invalide and --reset is used.
 		// However that doesn't exist yet.
@@ -792,6 +973,55 @@ func Validate(c Config) error {
 		}
 	}
 
+	if err := c.validateBackupConfig(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (c Config) validateBackupConfig() error {
+	for _, key := range []string{BackupInterval, BackupRetentionAge} {
+		if v, ok := c[key].(string); ok && v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return errors.Errorf("%s value %q must be a valid duration", key, v)
+			}
+			if d < 0 {
+				return errors.Errorf("%s value %q must not be negative", key, v)
+			}
+		}
+	}
+
+	if v, ok := c[BackupRetentionCount].(int); ok && v < 0 {
+		return errors.Errorf("invalid backup retention count: should be a number of backups (or 0 to keep all), got %d", v)
+	}
+
+	if v, ok := c[BackupTarget].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid backup target")
+		}
+		if !validBackupTargetSchemes.Contains(u.Scheme) {
+			return errors.Errorf("invalid backup target %q: scheme must be one of %q", v, validBackupTargetSchemes.SortedValues())
+		}
+		if u.Scheme == "file" && u.Path == "" {
+			return errors.Errorf("invalid backup target %q: missing directory", v)
+		}
+		if u.Scheme == "s3" && u.Host == "" {
+			return errors.Errorf("invalid backup target %q: missing bucket", v)
+		}
+	}
+
+	if v, ok := c[BackupS3Endpoint].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid backup S3 endpoint")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("invalid backup S3 endpoint %q: scheme must be http or https", v)
+		}
+	}
 	return nil
 }
 
@@ -852,6 +1082,7 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogMaxSize:         schema.String(),
 	AuditLogMaxBackups:      schema.ForceInt(),
 	AuditLogExcludeMethods:  schema.List(schema.String()),
+	AuditLogBackends:        schema.List(schema.String()),
 	APIPort:                 schema.ForceInt(),
 	APIPortOpenDelay:        schema.String(),
 	ControllerAPIPort:       schema.ForceInt(),
@@ -877,6 +1108,14 @@ var configChecker = schema.FieldMap(schema.Fields{
 	Features:                schema.List(schema.String()),
 	CharmStoreURL:           schema.String(),
 	MeteringURL:             schema.String(),
+	BackupInterval:          schema.String(),
+	BackupRetentionCount:    schema.ForceInt(),
+	BackupRetentionAge:      schema.String(),
+	BackupTarget:            schema.String(),
+	BackupS3Endpoint:        schema.String(),
+	BackupS3Region:          schema.String(),
+	BackupS3AccessKey:       schema.String(),
+	BackupS3SecretKey:       schema.String(),
 }, schema.Defaults{
 	APIPort:                 DefaultAPIPort,
 	APIPortOpenDelay:        DefaultAPIPortOpenDelay,
@@ -886,6 +1125,7 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogMaxSize:         fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
 	AuditLogMaxBackups:      DefaultAuditLogMaxBackups,
 	AuditLogExcludeMethods:  DefaultAuditLogExcludeMethods,
+	AuditLogBackends:        DefaultAuditLogBackends,
 	StatePort:               DefaultStatePort,
 	IdentityURL:             schema.Omit,
 	IdentityPublicKey:       schema.Omit,
@@ -908,4 +1148,12 @@ var configChecker = schema.FieldMap(schema.Fields{
 	Features:                schema.Omit,
 	CharmStoreURL:           csclient.ServerURL,
 	MeteringURL:             romulus.DefaultAPIRoot,
+	BackupInterval:          schema.Omit,
+	BackupRetentionCount:    schema.Omit,
+	BackupRetentionAge:      schema.Omit,
+	BackupTarget:            schema.Omit,
+	BackupS3Endpoint:        schema.Omit,
+	BackupS3Region:          schema.Omit,
+	BackupS3AccessKey:       schema.Omit,
+	BackupS3SecretKey:       schema.Omit,
 })
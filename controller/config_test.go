@@ -188,6 +188,13 @@ var validateTests = []struct {
 		controller.AuditLogExcludeMethods: []interface{}{"Dap.Kings", "ReadOnlyMethods", "Sharon Jones"},
 	},
 	expectError: `invalid audit log exclude methods: should be a list of "Facade.Method" names \(or "ReadOnlyMethods"\), got "Sharon Jones" at position 3`,
+}, {
+	about: "invalid audit log backends",
+	config: controller.Config{
+		controller.CACertKey:        testing.CACert,
+		controller.AuditLogBackends: []interface{}{"file", "carrier-pigeon"},
+	},
+	expectError: `invalid audit log backends: should be a list of \["database" "file" "log-forward"\], got "carrier-pigeon" at position 2`,
 }, {
 	about: "invalid CAAS docker image repo",
 	config: controller.Config{
@@ -275,6 +282,48 @@ var validateTests = []struct {
 		controller.MongoMemoryProfile: "not-valid",
 	},
 	expectError: `mongo-memory-profile: expected one of "low" or "default" got string\("not-valid"\)`,
+}, {
+	about: "backup-interval not a duration",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.BackupInterval: "daily",
+	},
+	expectError: `backup-interval value "daily" must be a valid duration`,
+}, {
+	about: "negative backup-retention-age",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.BackupRetentionAge: "-24h",
+	},
+	expectError: `backup-retention-age value "-24h" must not be negative`,
+}, {
+	about: "negative backup-retention-count",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.BackupRetentionCount: -1,
+	},
+	expectError: `invalid backup retention count: should be a number of backups \(or 0 to keep all\), got -1`,
+}, {
+	about: "invalid backup-target scheme",
+	config: controller.Config{
+		controller.CACertKey:    testing.CACert,
+		controller.BackupTarget: "ftp://example.com/backups",
+	},
+	expectError: `invalid backup target "ftp://example.com/backups": scheme must be one of \["file" "s3"\]`,
+}, {
+	about: "backup-target missing bucket",
+	config: controller.Config{
+		controller.CACertKey:    testing.CACert,
+		controller.BackupTarget: "s3:///prefix",
+	},
+	expectError: `invalid backup target "s3:///prefix": missing bucket`,
+}, {
+	about: "invalid backup-s3-endpoint",
+	config: controller.Config{
+		controller.CACertKey:        testing.CACert,
+		controller.BackupS3Endpoint: "minio:9000",
+	},
+	expectError: `invalid backup S3 endpoint "minio:9000": scheme must be http or https`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -409,6 +458,7 @@ func (s *ConfigSuite) TestAuditLogDefaults(c *gc.C) {
 	c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, 10)
 	c.Assert(cfg.AuditLogExcludeMethods(), gc.DeepEquals,
 		set.NewStrings(controller.DefaultAuditLogExcludeMethods...))
+	c.Assert(cfg.AuditLogBackends(), gc.DeepEquals, set.NewStrings("file"))
 }
 
 func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
@@ -421,6 +471,7 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 			"audit-log-max-size":        "100M",
 			"audit-log-max-backups":     10.0,
 			"audit-log-exclude-methods": []string{"Fleet.Foxes", "King.Gizzard", "ReadOnlyMethods"},
+			"audit-log-backends":        []string{"file", "database"},
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -433,6 +484,7 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 		"King.Gizzard",
 		"ReadOnlyMethods",
 	))
+	c.Assert(cfg.AuditLogBackends(), gc.DeepEquals, set.NewStrings("file", "database"))
 }
 
 func (s *ConfigSuite) TestAuditLogExcludeMethodsType(c *gc.C) {
@@ -569,3 +621,41 @@ func (s *ConfigSuite) TestMeteringURLSettingValue(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.MeteringURL(), gc.Equals, mURL)
 }
+
+func (s *ConfigSuite) TestBackupConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupInterval(), gc.Equals, time.Duration(0))
+	c.Check(cfg.BackupRetentionCount(), gc.Equals, 0)
+	c.Check(cfg.BackupRetentionAge(), gc.Equals, time.Duration(0))
+	c.Check(cfg.BackupTarget(), gc.Equals, "")
+	c.Check(cfg.BackupS3Endpoint(), gc.Equals, controller.DefaultBackupS3Endpoint)
+	c.Check(cfg.BackupS3Region(), gc.Equals, controller.DefaultBackupS3Region)
+}
+
+func (s *ConfigSuite) TestBackupConfigValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-interval":        "24h",
+			"backup-retention-count": 7,
+			"backup-retention-age":   "720h",
+			"backup-target":          "s3://backups/juju",
+			"backup-s3-endpoint":     "http://10.0.0.1:9000",
+			"backup-s3-region":       "eu-west-1",
+			"backup-s3-access-key":   "access",
+			"backup-s3-secret-key":   "secret",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupInterval(), gc.Equals, 24*time.Hour)
+	c.Check(cfg.BackupRetentionCount(), gc.Equals, 7)
+	c.Check(cfg.BackupRetentionAge(), gc.Equals, 720*time.Hour)
+	c.Check(cfg.BackupTarget(), gc.Equals, "s3://backups/juju")
+	c.Check(cfg.BackupS3Endpoint(), gc.Equals, "http://10.0.0.1:9000")
+	c.Check(cfg.BackupS3Region(), gc.Equals, "eu-west-1")
+	accessKey, secretKey := cfg.BackupS3Credentials()
+	c.Check(accessKey, gc.Equals, "access")
+	c.Check(secretKey, gc.Equals, "secret")
+}
@@ -11,6 +11,18 @@ import (
 // JujuRunActionName defines the action name used by juju-run.
 const JujuRunActionName = "juju-run"
 
+const (
+	// JujuRunStdoutPrefix prefixes the messages of a juju-run action
+	// that carry lines written by the command to stdout, when the
+	// action's output is streamed. The lines are separated by newlines.
+	JujuRunStdoutPrefix = "stdout: "
+
+	// JujuRunStderrPrefix prefixes the messages of a juju-run action
+	// that carry lines written by the command to stderr, when the
+	// action's output is streamed. The lines are separated by newlines.
+	JujuRunStderrPrefix = "stderr: "
+)
+
 // PredefinedActionsSpec defines a spec for each predefined action.
 var PredefinedActionsSpec = map[string]charm.ActionSpec{
 	JujuRunActionName: {
@@ -29,6 +41,10 @@ var PredefinedActionsSpec = map[string]charm.ActionSpec{
 					"type":        "number",
 					"description": "timeout for command execution",
 				},
+				"stream-output": map[string]interface{}{
+					"type":        "boolean",
+					"description": "log each line of output as an action message as it is written",
+				},
 			},
 		},
 	},
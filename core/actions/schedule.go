@@ -0,0 +1,49 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/robfig/cron.v2"
+)
+
+// Schedule describes when a scheduled action is to be run.
+type Schedule interface {
+	// Next returns the first time after t at which the action
+	// should be run.
+	Next(t time.Time) time.Time
+}
+
+// ParseSchedule parses the cron specification of a scheduled action.
+// The specification is either in the standard five field crontab
+// format (minute, hour, day of month, month, day of week), or one of
+// the descriptors @yearly, @monthly, @weekly, @daily, @hourly or
+// "@every <duration>". It may be prefixed with "TZ=<location> " to
+// interpret the times in that location; otherwise UTC is used.
+func ParseSchedule(spec string) (Schedule, error) {
+	tz, rest := "UTC", strings.TrimSpace(spec)
+	if strings.HasPrefix(rest, "TZ=") {
+		fields := strings.SplitN(rest, " ", 2)
+		if len(fields) != 2 {
+			return nil, errors.NotValidf("schedule %q", spec)
+		}
+		tz, rest = strings.TrimPrefix(fields[0], "TZ="), strings.TrimSpace(fields[1])
+	}
+	if !strings.HasPrefix(rest, "@") {
+		if n := len(strings.Fields(rest)); n != 5 {
+			return nil, errors.NotValidf("schedule %q with %d fields (expected 5)", spec, n)
+		}
+		// The cron package expects a leading seconds field.
+		rest = "0 " + rest
+	}
+	schedule, err := cron.Parse(fmt.Sprintf("TZ=%s %s", tz, rest))
+	if err != nil {
+		return nil, errors.NewNotValid(err, fmt.Sprintf("schedule %q", spec))
+	}
+	return schedule, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type scheduleSuite struct{}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func (*scheduleSuite) TestParseSchedule(c *gc.C) {
+	now := time.Date(2019, 4, 1, 10, 30, 15, 0, time.UTC)
+	for i, test := range []struct {
+		spec string
+		next time.Time
+	}{{
+		spec: "0 3 * * *",
+		next: time.Date(2019, 4, 2, 3, 0, 0, 0, time.UTC),
+	}, {
+		spec: "*/15 * * * *",
+		next: time.Date(2019, 4, 1, 10, 45, 0, 0, time.UTC),
+	}, {
+		spec: "0 0 * * SUN",
+		next: time.Date(2019, 4, 7, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "@hourly",
+		next: time.Date(2019, 4, 1, 11, 0, 0, 0, time.UTC),
+	}, {
+		spec: "@every 10m",
+		next: time.Date(2019, 4, 1, 10, 40, 15, 0, time.UTC),
+	}, {
+		spec: "TZ=Europe/London 0 3 * * *",
+		next: time.Date(2019, 4, 2, 2, 0, 0, 0, time.UTC),
+	}} {
+		c.Logf("test %d: %s", i, test.spec)
+		schedule, err := actions.ParseSchedule(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.Next(now).UTC(), gc.Equals, test.next)
+	}
+}
+
+func (*scheduleSuite) TestParseScheduleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		spec     string
+		errMatch string
+	}{{
+		spec:     "",
+		errMatch: `schedule "" with 0 fields \(expected 5\) not valid`,
+	}, {
+		spec:     "0 0 3 * * *",
+		errMatch: `schedule "0 0 3 \* \* \*" with 6 fields \(expected 5\) not valid`,
+	}, {
+		spec:     "61 * * * *",
+		errMatch: `schedule "61 \* \* \* \*": .*`,
+	}, {
+		spec:     "@fortnightly",
+		errMatch: `schedule "@fortnightly": .*`,
+	}, {
+		spec:     "TZ=Nowhere/Special 0 3 * * *",
+		errMatch: `schedule "TZ=Nowhere/Special 0 3 \* \* \*": .*`,
+	}} {
+		c.Logf("test %d: %s", i, test.spec)
+		_, err := actions.ParseSchedule(test.spec)
+		c.Check(err, gc.ErrorMatches, test.errMatch)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// RefreshPolicy describes when an application's charm is automatically
+// upgraded to newer revisions published in the charm store channel the
+// application tracks.
+type RefreshPolicy struct {
+	// Window is the schedule on which the maintenance window, during
+	// which upgrades may be made, opens. It is in the cron format
+	// accepted by actions.ParseSchedule.
+	Window string
+
+	// Duration is how long the maintenance window stays open.
+	Duration time.Duration
+
+	// PatchOnly limits upgrades to revisions whose charm version
+	// differs from the deployed charm's version only in its patch
+	// number.
+	PatchOnly bool
+
+	// CanaryBranch, if set, is the name of a model branch on which
+	// upgrades are staged first. The upgrade is only made to the
+	// whole application once the units tracking the branch are
+	// healthy on the new charm.
+	CanaryBranch string
+}
+
+// Validate returns an error if the policy is not valid.
+func (p RefreshPolicy) Validate() error {
+	if _, err := actions.ParseSchedule(p.Window); err != nil {
+		return errors.Annotate(err, "maintenance window")
+	}
+	if p.Duration <= 0 {
+		return errors.NotValidf("maintenance window duration %v", p.Duration)
+	}
+	return nil
+}
+
+// InWindow reports whether the maintenance window is open at time t.
+func (p RefreshPolicy) InWindow(t time.Time) (bool, error) {
+	schedule, err := actions.ParseSchedule(p.Window)
+	if err != nil {
+		return false, errors.Annotate(err, "maintenance window")
+	}
+	// The window is open if it was due to open within the
+	// last Duration.
+	return !schedule.Next(t.Add(-p.Duration)).After(t), nil
+}
+
+// IsPatchUpgrade reports whether upgrading from a charm with the first
+// version to one with the second changes only the patch number of the
+// version. Charm versions are free-form; only versions starting with
+// at least a major and minor number, such as "1.2", "v1.2.3" or
+// "1.2.3-4-gdeadbeef", are considered.
+func IsPatchUpgrade(fromVersion, toVersion string) bool {
+	from, ok := majorMinor(fromVersion)
+	if !ok {
+		return false
+	}
+	to, ok := majorMinor(toVersion)
+	if !ok {
+		return false
+	}
+	return from == to && fromVersion != toVersion
+}
+
+// majorMinor returns the "major.minor" prefix of a charm version.
+func majorMinor(v string) (string, bool) {
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexFunc(v, func(r rune) bool {
+		return r != '.' && (r < '0' || r > '9')
+	}); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) < 2 {
+		return "", false
+	}
+	for _, part := range parts[:2] {
+		if _, err := strconv.Atoi(part); err != nil {
+			return "", false
+		}
+	}
+	return parts[0] + "." + parts[1], true
+}
@@ -0,0 +1,79 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+type refreshPolicySuite struct{}
+
+var _ = gc.Suite(&refreshPolicySuite{})
+
+func (*refreshPolicySuite) TestValidate(c *gc.C) {
+	policy := application.RefreshPolicy{
+		Window:   "0 2 * * SAT",
+		Duration: 2 * time.Hour,
+	}
+	c.Assert(policy.Validate(), jc.ErrorIsNil)
+
+	policy.Duration = 0
+	c.Assert(policy.Validate(), gc.ErrorMatches, "maintenance window duration 0s not valid")
+
+	policy.Window = "whenever"
+	c.Assert(policy.Validate(), gc.ErrorMatches, `maintenance window: schedule "whenever" with 1 fields \(expected 5\) not valid`)
+}
+
+func (*refreshPolicySuite) TestInWindow(c *gc.C) {
+	policy := application.RefreshPolicy{
+		Window:   "0 2 * * *",
+		Duration: 2 * time.Hour,
+	}
+	for i, test := range []struct {
+		t    time.Time
+		open bool
+	}{{
+		t:    time.Date(2019, 4, 1, 1, 59, 59, 0, time.UTC),
+		open: false,
+	}, {
+		t:    time.Date(2019, 4, 1, 2, 0, 0, 0, time.UTC),
+		open: true,
+	}, {
+		t:    time.Date(2019, 4, 1, 3, 30, 0, 0, time.UTC),
+		open: true,
+	}, {
+		t:    time.Date(2019, 4, 1, 4, 0, 0, 0, time.UTC),
+		open: false,
+	}} {
+		c.Logf("test %d: %v", i, test.t)
+		open, err := policy.InWindow(test.t)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(open, gc.Equals, test.open)
+	}
+}
+
+func (*refreshPolicySuite) TestIsPatchUpgrade(c *gc.C) {
+	for i, test := range []struct {
+		from, to string
+		patch    bool
+	}{
+		{"1.2.3", "1.2.4", true},
+		{"v1.2", "v1.2.1", true},
+		{"1.2.3-4-gdeadbeef", "1.2.3-7-gfeedface", true},
+		{"1.2.3", "1.2.3", false},
+		{"1.2.3", "1.3.0", false},
+		{"1.2.3", "2.2.3", false},
+		{"", "1.2.3", false},
+		{"deadbeef", "feedface", false},
+		{"1", "1", false},
+	} {
+		c.Logf("test %d: %q -> %q", i, test.from, test.to)
+		c.Check(application.IsPatchUpgrade(test.from, test.to), gc.Equals, test.patch)
+	}
+}
@@ -30,6 +30,7 @@ type Conversation struct {
 	What           string `json:"what"`       // "juju deploy ./foo/bar"
 	When           string `json:"when"`       // ISO 8601 to second precision
 	ModelName      string `json:"model-name"` // full representation "user/name"
+	ModelOwner     string `json:"model-owner,omitempty"`
 	ModelUUID      string `json:"model-uuid"`
 	ConversationID string `json:"conversation-id"` // uint64 in hex
 	ConnectionID   string `json:"connection-id"`   // uint64 in hex (using %X to match the value in log files)
@@ -40,6 +41,7 @@ type ConversationArgs struct {
 	Who          string
 	What         string
 	ModelName    string
+	ModelOwner   string
 	ModelUUID    string
 	ConnectionID uint64
 }
@@ -127,6 +129,7 @@ func NewRecorder(log AuditLog, clock clock.Clock, c ConversationArgs) (*Recorder
 		What:           c.What,
 		When:           clock.Now().Format(time.RFC3339),
 		ModelName:      c.ModelName,
+		ModelOwner:     c.ModelOwner,
 		ModelUUID:      c.ModelUUID,
 	})
 	if err != nil {
@@ -0,0 +1,109 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+// NewTeeLog returns an AuditLog that writes every record to each of
+// the given logs. A failure to write to one log doesn't prevent the
+// record being written to the others; the errors are combined and
+// returned once all of the logs have been tried.
+func NewTeeLog(logs ...AuditLog) AuditLog {
+	return teeLog(logs)
+}
+
+type teeLog []AuditLog
+
+// AddConversation implements AuditLog.
+func (t teeLog) AddConversation(c Conversation) error {
+	return t.each(func(log AuditLog) error {
+		return log.AddConversation(c)
+	})
+}
+
+// AddRequest implements AuditLog.
+func (t teeLog) AddRequest(r Request) error {
+	return t.each(func(log AuditLog) error {
+		return log.AddRequest(r)
+	})
+}
+
+// AddResponse implements AuditLog.
+func (t teeLog) AddResponse(r ResponseErrors) error {
+	return t.each(func(log AuditLog) error {
+		return log.AddResponse(r)
+	})
+}
+
+// Close implements AuditLog.
+func (t teeLog) Close() error {
+	return t.each(func(log AuditLog) error {
+		return log.Close()
+	})
+}
+
+func (t teeLog) each(f func(AuditLog) error) error {
+	var messages []string
+	for _, log := range t {
+		if err := f(log); err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+	switch len(messages) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(messages[0])
+	}
+	return errors.Errorf("multiple audit log errors: %s", strings.Join(messages, "; "))
+}
+
+// NewForwardingLog returns an AuditLog that writes each record as a
+// JSON-encoded message to the given logger, at INFO level. On a
+// controller the agent's log messages end up in the logs collection,
+// so the records are picked up by log forwarding along with everything
+// else. The logging-config of the controller model needs to include
+// the logger's module at INFO or lower for the records to be kept.
+func NewForwardingLog(logger loggo.Logger) AuditLog {
+	return &forwardingLog{logger: logger}
+}
+
+type forwardingLog struct {
+	logger loggo.Logger
+}
+
+// AddConversation implements AuditLog.
+func (f *forwardingLog) AddConversation(c Conversation) error {
+	return errors.Trace(f.addRecord(Record{Conversation: &c}))
+}
+
+// AddRequest implements AuditLog.
+func (f *forwardingLog) AddRequest(r Request) error {
+	return errors.Trace(f.addRecord(Record{Request: &r}))
+}
+
+// AddResponse implements AuditLog.
+func (f *forwardingLog) AddResponse(r ResponseErrors) error {
+	return errors.Trace(f.addRecord(Record{Errors: &r}))
+}
+
+// Close implements AuditLog.
+func (f *forwardingLog) Close() error {
+	return nil
+}
+
+func (f *forwardingLog) addRecord(r Record) error {
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	f.logger.Infof("%s", bytes)
+	return nil
+}
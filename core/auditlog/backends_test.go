@@ -0,0 +1,86 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type BackendsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&BackendsSuite{})
+
+func (s *BackendsSuite) TestTeeLogWritesToAll(c *gc.C) {
+	var log1, log2 fakeLog
+	tee := auditlog.NewTeeLog(&log1, &log2)
+
+	err := tee.AddConversation(auditlog.Conversation{ConversationID: "0123"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = tee.AddRequest(auditlog.Request{ConversationID: "0123"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = tee.AddResponse(auditlog.ResponseErrors{ConversationID: "0123"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = tee.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	log1.stub.CheckCallNames(c, "AddConversation", "AddRequest", "AddResponse", "Close")
+	log2.stub.CheckCallNames(c, "AddConversation", "AddRequest", "AddResponse", "Close")
+}
+
+func (s *BackendsSuite) TestTeeLogContinuesAfterError(c *gc.C) {
+	var log1, log2 fakeLog
+	log1.stub.SetErrors(errors.New("disk full"))
+	tee := auditlog.NewTeeLog(&log1, &log2)
+
+	err := tee.AddRequest(auditlog.Request{ConversationID: "0123"})
+	c.Assert(err, gc.ErrorMatches, "disk full")
+	log2.stub.CheckCallNames(c, "AddRequest")
+}
+
+func (s *BackendsSuite) TestTeeLogCombinesErrors(c *gc.C) {
+	var log1, log2 fakeLog
+	log1.stub.SetErrors(errors.New("disk full"))
+	log2.stub.SetErrors(errors.New("no database"))
+	tee := auditlog.NewTeeLog(&log1, &log2)
+
+	err := tee.AddRequest(auditlog.Request{ConversationID: "0123"})
+	c.Assert(err, gc.ErrorMatches, "multiple audit log errors: disk full; no database")
+}
+
+func (s *BackendsSuite) TestForwardingLog(c *gc.C) {
+	logger := loggo.GetLogger("test.auditlog")
+	logger.SetLogLevel(loggo.INFO)
+	var writer loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("audit-test", &writer), jc.ErrorIsNil)
+	defer loggo.RemoveWriter("audit-test")
+
+	log := auditlog.NewForwardingLog(logger)
+	err := log.AddConversation(auditlog.Conversation{
+		Who:            "deerhoof",
+		What:           "gojira",
+		When:           "2017-11-27T13:21:24Z",
+		ModelName:      "admin/default",
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(writer.Log(), jc.LogMatches, []jc.SimpleMessage{{
+		loggo.INFO,
+		`{"conversation":{"who":"deerhoof","what":"gojira","when":"2017-11-27T13:21:24Z","model-name":"admin/default","model-uuid":"","conversation-id":"0123456789abcdef","connection-id":"AC1"}}`,
+	}})
+}
+
+func (s *BackendsSuite) TestFilterValidate(c *gc.C) {
+	c.Check(auditlog.Filter{}.Validate(), jc.ErrorIsNil)
+	c.Check(auditlog.Filter{Limit: -2}.Validate(), gc.ErrorMatches, "negative limit -2 not valid")
+}
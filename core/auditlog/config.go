@@ -29,6 +29,10 @@ type Config struct {
 	// consists of these method calls we won't log it.
 	ExcludeMethods set.Strings
 
+	// Backends names the kinds of AuditLog that entries should be
+	// written to (see the controller.AuditLogBackend* values).
+	Backends set.Strings
+
 	// Target is the AuditLog entries should be written to.
 	Target AuditLog
 }
@@ -0,0 +1,61 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Filter selects the conversations returned from an audit log query.
+// Empty fields don't restrict the results.
+type Filter struct {
+	// Who matches the user that started the conversation.
+	Who string
+
+	// Model matches either the UUID of the model the conversation
+	// was with, or its name, which may be qualified with the model's
+	// owner ("owner/name").
+	Model string
+
+	// Facade and Method match conversations that include at least
+	// one request to the given facade and/or method.
+	Facade string
+	Method string
+
+	// After and Before bound the time the conversation started.
+	After  time.Time
+	Before time.Time
+
+	// Limit is the maximum number of conversations to return, most
+	// recent first. Zero means no limit.
+	Limit int
+}
+
+// Validate checks that the filter is usable.
+func (f Filter) Validate() error {
+	if f.Limit < 0 {
+		return errors.NotValidf("negative limit %d", f.Limit)
+	}
+	if !f.After.IsZero() && !f.Before.IsZero() && f.Before.Before(f.After) {
+		return errors.NotValidf("time range ending before it starts")
+	}
+	return nil
+}
+
+// ConversationDetails holds a conversation along with the requests
+// and response errors recorded as part of it.
+type ConversationDetails struct {
+	Conversation Conversation
+	Requests     []Request
+	Errors       []ResponseErrors
+}
+
+// Querier is implemented by audit logs that can be searched.
+type Querier interface {
+	// Query returns the conversations matching the filter, along
+	// with their requests and response errors.
+	Query(Filter) ([]ConversationDetails, error)
+}
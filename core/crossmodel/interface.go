@@ -29,6 +29,18 @@ type ApplicationOffer struct {
 	// Endpoints is the collection of endpoint names offered (internal->published).
 	// The map allows for advertised endpoint names to be aliased.
 	Endpoints map[string]charm.Relation
+
+	// RequireApproval is true if users need to have a request to
+	// consume the offer approved by an offer admin.
+	RequireApproval bool
+
+	// MaxConsumers is the maximum number of models which may be
+	// connected to the offer, or 0 if there is no limit.
+	MaxConsumers int
+
+	// MaxRelations is the maximum number of relations which may be
+	// made to the offer, or 0 if there is no limit.
+	MaxRelations int
 }
 
 // AddApplicationOfferArgs contains parameters used to create an application offer.
@@ -56,6 +68,18 @@ type AddApplicationOfferArgs struct {
 	// Icon is an icon to display when browsing the ApplicationOffers, which by default
 	// comes from the charm.
 	Icon []byte
+
+	// RequireApproval is true if users need to have a request to
+	// consume the offer approved by an offer admin.
+	RequireApproval bool
+
+	// MaxConsumers is the maximum number of models which may be
+	// connected to the offer, or 0 if there is no limit.
+	MaxConsumers int
+
+	// MaxRelations is the maximum number of relations which may be
+	// made to the offer, or 0 if there is no limit.
+	MaxRelations int
 }
 
 // ConsumeApplicationArgs contains parameters used to consume an offer.
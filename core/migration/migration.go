@@ -51,6 +51,11 @@ type SerializedModel struct {
 
 	// Resources represents all the resources in use in the model.
 	Resources []SerializedModelResource
+
+	// Extras contains serialized model data that is not yet
+	// represented in the model description, and which is applied
+	// to the model after it has been imported.
+	Extras []byte
 }
 
 // SerializedModelResource defines the resource revisions for a
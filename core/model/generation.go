@@ -55,6 +55,14 @@ type GenerationApplication struct {
 	// TODO (manadart 2018-02-22) This data-type will evolve as more aspects
 	// of the application are made generational.
 	ConfigChanges map[string]interface{} `yaml:"config"`
+
+	// CharmURL is the charm URL that units tracking the generation
+	// are upgraded to. It is empty if no upgrade was staged.
+	CharmURL string `yaml:"charm,omitempty"`
+
+	// Constraints are the application constraints
+	// set under the generation, if any.
+	Constraints string `yaml:"constraints,omitempty"`
 }
 
 // Generation represents detail of a model generation including config changes.
@@ -77,8 +77,9 @@ func groupKeyFor(key lease.Key) groupKey {
 // NewFSM returns a new FSM to store lease information.
 func NewFSM() *FSM {
 	return &FSM{
-		groups: make(map[groupKey]map[lease.Key]*entry),
-		pinned: make(map[lease.Key]set.Strings),
+		groups:   make(map[groupKey]map[lease.Key]*entry),
+		pinned:   make(map[lease.Key]set.Strings),
+		expiries: make(map[string]uint64),
 	}
 }
 
@@ -96,6 +97,11 @@ type FSM struct {
 	// to a lease pinned by another concern operating under under the
 	// assumption that the lease holder will not change.
 	pinned map[lease.Key]set.Strings
+
+	// expiries counts the leases that have expired since the FSM was
+	// created, by namespace. It is only used for metrics, so is not
+	// included in snapshots.
+	expiries map[string]uint64
 }
 
 func (f *FSM) getGroup(key lease.Key) (map[lease.Key]*entry, bool) {
@@ -184,6 +190,7 @@ func (f *FSM) removeExpired(newTime time.Time) []lease.Key {
 			if expiry.Before(newTime) && !f.isPinned(key) {
 				delete(entries, key)
 				expired = append(expired, key)
+				f.expiries[key.Namespace]++
 			}
 		}
 		if len(entries) == 0 {
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/yaml.v2"
@@ -901,3 +902,75 @@ pinned:
 func timeDelegate(t time.Time) func() time.Time {
 	return func() time.Time { return t }
 }
+
+func (s *fsmSuite) TestCollect(c *gc.C) {
+	for _, command := range []raftlease.Command{{
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "short",
+		Holder:    "you",
+		Duration:  time.Second,
+	}, {
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "long",
+		Holder:    "you",
+		Duration:  time.Minute,
+	}, {
+		Operation: raftlease.OperationClaim,
+		Namespace: "other",
+		ModelUUID: "model",
+		Lease:     "pinned",
+		Holder:    "them",
+		Duration:  time.Second,
+	}, {
+		Operation: raftlease.OperationPin,
+		Namespace: "other",
+		ModelUUID: "model",
+		Lease:     "pinned",
+		PinEntity: "machine-0",
+	}, {
+		Operation: raftlease.OperationSetTime,
+		OldTime:   zero,
+		NewTime:   offset(2 * time.Second),
+	}} {
+		command.Version = 1
+		c.Assert(s.apply(c, command).Error(), jc.ErrorIsNil)
+	}
+
+	c.Assert(gatherValues(c, s.fsm), jc.DeepEquals, map[string]float64{
+		"juju_raftlease_leases/ns":           1,
+		"juju_raftlease_leases/other":        1,
+		"juju_raftlease_pinned_leases/other": 1,
+		"juju_raftlease_expiries_total/ns":   1,
+	})
+}
+
+// gatherValues returns the values of the gauges and counters collected
+// by the given collector, keyed by the metric name followed by its
+// label values, separated by slashes.
+func gatherValues(c *gc.C, collector prometheus.Collector) map[string]float64 {
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(collector)
+	families, err := registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+
+	values := make(map[string]float64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			name := family.GetName()
+			for _, label := range metric.GetLabel() {
+				name += "/" + label.GetValue()
+			}
+			switch {
+			case metric.Gauge != nil:
+				values[name] = metric.Gauge.GetValue()
+			case metric.Counter != nil:
+				values[name] = metric.Counter.GetValue()
+			}
+		}
+	}
+	return values
+}
@@ -11,10 +11,34 @@ const (
 	metricsNamespace = "juju_raftlease"
 )
 
+var (
+	globalTimeLagDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "globaltime_lag_seconds"),
+		"How far the lease FSM's global time is behind the global time last set through this store",
+		nil, nil,
+	)
+	leasesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "leases"),
+		"Number of leases held in the lease FSM",
+		[]string{"namespace"}, nil,
+	)
+	pinnedLeasesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "pinned_leases"),
+		"Number of pinned leases in the lease FSM",
+		[]string{"namespace"}, nil,
+	)
+	expiriesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "expiries_total"),
+		"Number of leases expired by the lease FSM",
+		[]string{"namespace"}, nil,
+	)
+)
+
 // metricsCollector is a prometheus.Collector that collects metrics
 // about lease store operations.
 type metricsCollector struct {
 	requests *prometheus.SummaryVec
+	claims   *prometheus.CounterVec
 }
 
 func newMetricsCollector() *metricsCollector {
@@ -32,15 +56,65 @@ func newMetricsCollector() *metricsCollector {
 			"operation", // claim, extend, pin, unpin or settime
 			"result",    // success, failure, timeout or error
 		}),
+		claims: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "operations_total",
+			Help:      "Number of lease store operations by lease namespace",
+		}, []string{
+			"operation", // claim, extend, pin or unpin
+			"namespace", // the lease namespace, e.g. application-leadership
+			"result",    // success, failure, timeout or error
+		}),
 	}
 }
 
 // Describe is part of prometheus.Collector.
 func (c *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.requests.Describe(ch)
+	c.claims.Describe(ch)
+	ch <- globalTimeLagDesc
 }
 
 // Collect is part of prometheus.Collector.
 func (c *metricsCollector) Collect(ch chan<- prometheus.Metric) {
 	c.requests.Collect(ch)
+	c.claims.Collect(ch)
+}
+
+// Describe is part of prometheus.Collector.
+func (f *FSM) Describe(ch chan<- *prometheus.Desc) {
+	ch <- leasesDesc
+	ch <- pinnedLeasesDesc
+	ch <- expiriesDesc
+}
+
+// Collect is part of prometheus.Collector.
+func (f *FSM) Collect(ch chan<- prometheus.Metric) {
+	leases := make(map[string]int)
+	pinned := make(map[string]int)
+	expiries := make(map[string]uint64)
+
+	f.mu.Lock()
+	for gKey, entries := range f.groups {
+		leases[gKey.namespace] += len(entries)
+	}
+	for key, entities := range f.pinned {
+		if !entities.IsEmpty() {
+			pinned[key.Namespace]++
+		}
+	}
+	for namespace, count := range f.expiries {
+		expiries[namespace] = count
+	}
+	f.mu.Unlock()
+
+	for namespace, count := range leases {
+		ch <- prometheus.MustNewConstMetric(leasesDesc, prometheus.GaugeValue, float64(count), namespace)
+	}
+	for namespace, count := range pinned {
+		ch <- prometheus.MustNewConstMetric(pinnedLeasesDesc, prometheus.GaugeValue, float64(count), namespace)
+	}
+	for namespace, count := range expiries {
+		ch <- prometheus.MustNewConstMetric(expiriesDesc, prometheus.CounterValue, float64(count), namespace)
+	}
 }
@@ -86,6 +86,7 @@ func NewStore(config StoreConfig) *Store {
 		hub:      config.Hub,
 		config:   config,
 		prevTime: config.FSM.GlobalTime(),
+		lastTime: config.FSM.GlobalTime(),
 		metrics:  newMetricsCollector(),
 	}
 }
@@ -100,6 +101,12 @@ type Store struct {
 
 	prevTimeMu sync.Mutex
 	prevTime   time.Time
+
+	// lastTime holds the global time last set through this store,
+	// for reporting the FSM's lag. It is guarded separately from
+	// prevTime so that collecting metrics doesn't block on Advance.
+	lastTimeMu sync.Mutex
+	lastTime   time.Time
 }
 
 // Autoexpire is part of lease.Store.
@@ -201,6 +208,9 @@ func (s *Store) Advance(duration time.Duration, stop <-chan struct{}) error {
 	} else if err == nil {
 		s.prevTime = newTime
 	}
+	s.lastTimeMu.Lock()
+	s.lastTime = s.prevTime
+	s.lastTimeMu.Unlock()
 	return errors.Trace(err)
 }
 
@@ -239,18 +249,18 @@ func (s *Store) runOnLeader(command *Command, stop <-chan struct{}) error {
 		ResponseTopic: responseTopic,
 	})
 	if err != nil {
-		s.record(command.Operation, "error", start)
+		s.record(command, "error", start)
 		return errors.Trace(err)
 	}
 
 	select {
 	case <-s.config.Clock.After(s.config.ForwardTimeout):
 		logger.Infof("timeout")
-		s.record(command.Operation, "timeout", start)
+		s.record(command, "timeout", start)
 		return lease.ErrTimeout
 	case err := <-errChan:
 		logger.Errorf("%v", err)
-		s.record(command.Operation, "error", start)
+		s.record(command, "error", start)
 		return errors.Trace(err)
 	case response := <-responseChan:
 		err := RecoverError(response.Error)
@@ -259,19 +269,26 @@ func (s *Store) runOnLeader(command *Command, stop <-chan struct{}) error {
 		if err == nil {
 			result = "success"
 		}
-		s.record(command.Operation, result, start)
+		s.record(command, result, start)
 		return err
 	case <-stop:
 		return aborted(command)
 	}
 }
 
-func (s *Store) record(operation, result string, start time.Time) {
+func (s *Store) record(command *Command, result string, start time.Time) {
 	elapsedMS := float64(time.Now().Sub(start)) / float64(time.Millisecond)
 	s.metrics.requests.With(prometheus.Labels{
-		"operation": operation,
+		"operation": command.Operation,
 		"result":    result,
 	}).Observe(elapsedMS)
+	if command.Operation != OperationSetTime {
+		s.metrics.claims.With(prometheus.Labels{
+			"operation": command.Operation,
+			"namespace": command.Namespace,
+			"result":    result,
+		}).Inc()
+	}
 }
 
 // ForwardRequest is a message sent over the hub to the raft forwarder
@@ -335,9 +352,28 @@ func RecoverError(resp *ResponseError) error {
 // Describe is part of prometheus.Collector.
 func (s *Store) Describe(ch chan<- *prometheus.Desc) {
 	s.metrics.Describe(ch)
+	if collector, ok := s.fsm.(prometheus.Collector); ok {
+		collector.Describe(ch)
+	}
 }
 
-// Collect is part of prometheus.Collector.
+// Collect is part of prometheus.Collector. The metrics of the FSM are
+// included if it is also a collector, as only the store gets
+// registered with the controller's registry.
 func (s *Store) Collect(ch chan<- prometheus.Metric) {
 	s.metrics.Collect(ch)
+
+	s.lastTimeMu.Lock()
+	lastTime := s.lastTime
+	s.lastTimeMu.Unlock()
+	lag := lastTime.Sub(s.fsm.GlobalTime())
+	if lag < 0 {
+		// The FSM has been advanced by another controller.
+		lag = 0
+	}
+	ch <- prometheus.MustNewConstMetric(globalTimeLagDesc, prometheus.GaugeValue, lag.Seconds())
+
+	if collector, ok := s.fsm.(prometheus.Collector); ok {
+		collector.Collect(ch)
+	}
 }
@@ -799,6 +799,59 @@ func (s *storeSuite) TestRecoverError(c *gc.C) {
 	c.Assert(re("something", "else"), gc.ErrorMatches, "something")
 }
 
+func (s *storeSuite) TestCollect(c *gc.C) {
+	s.handleHubRequest(c,
+		func() {
+			err := s.store.ClaimLease(
+				lease.Key{"warframe", "rhino", "prime"},
+				lease.Request{"lotus", time.Second},
+				nil,
+			)
+			c.Assert(err, jc.ErrorIsNil)
+		},
+		raftlease.Command{
+			Version:   1,
+			Operation: raftlease.OperationClaim,
+			Namespace: "warframe",
+			ModelUUID: "rhino",
+			Lease:     "prime",
+			Holder:    "lotus",
+			Duration:  time.Second,
+		},
+		func(req raftlease.ForwardRequest) {
+			_, err := s.hub.Publish(req.ResponseTopic, raftlease.ForwardResponse{})
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+	fromTime := s.clock.Now()
+	s.handleHubRequest(c,
+		func() {
+			err := s.store.Advance(10*time.Second, nil)
+			c.Assert(err, jc.ErrorIsNil)
+		},
+		raftlease.Command{
+			Version:   1,
+			Operation: raftlease.OperationSetTime,
+			OldTime:   fromTime,
+			NewTime:   fromTime.Add(10 * time.Second),
+		},
+		func(req raftlease.ForwardRequest) {
+			_, err := s.hub.Publish(req.ResponseTopic, raftlease.ForwardResponse{})
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+
+	// The fake FSM's time isn't advanced, so it lags behind the
+	// time set through the store.
+	values := gatherValues(c, s.store)
+	c.Check(values["juju_raftlease_operations_total/warframe/claim/success"], gc.Equals, float64(1))
+	c.Check(values["juju_raftlease_globaltime_lag_seconds"], gc.Equals, float64(10))
+
+	s.fsm.globalTime = fromTime.Add(10 * time.Second)
+	values = gatherValues(c, s.store)
+	c.Check(values["juju_raftlease_globaltime_lag_seconds"], gc.Equals, float64(0))
+}
+
 type fakeFSM struct {
 	testing.Stub
 	leases     map[lease.Key]lease.Info
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	jujuversion "github.com/juju/juju/juju/version"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/network"
 )
@@ -188,6 +189,26 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdHTTPEnabled determines whether log records are forwarded
+	// to an HTTP endpoint as newline-delimited JSON.
+	LogFwdHTTPEnabled = "logforward-http-enabled"
+
+	// LogFwdHTTPURL sets the URL to which batches of log records are
+	// POSTed.
+	LogFwdHTTPURL = "logforward-http-url"
+
+	// LogFwdHTTPCACert sets the certificate of the CA that signed the
+	// HTTP log endpoint's server certificate.
+	LogFwdHTTPCACert = "logforward-http-ca-cert"
+
+	// LogFwdHTTPBatchSize sets the maximum number of log records sent
+	// to the HTTP endpoint in a single request.
+	LogFwdHTTPBatchSize = "logforward-http-batch-size"
+
+	// LogFwdHTTPMaxRetries sets the number of times a failed request
+	// to the HTTP endpoint is retried before giving up.
+	LogFwdHTTPMaxRetries = "logforward-http-max-retries"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -459,6 +480,7 @@ var defaultConfigValues = map[string]interface{}{
 
 	// Log forward settings.
 	LogForwardEnabled: false,
+	LogFwdHTTPEnabled: false,
 
 	// Proxy settings.
 	HTTPProxyKey:      "",
@@ -595,6 +617,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if lfCfg, ok := cfg.LogFwdHTTP(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid http log forwarding config")
+		}
+	}
+
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -1068,6 +1096,42 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdHTTP returns the HTTP/JSON log forwarding config.
+func (c *Config) LogFwdHTTP() (*httpjson.RawConfig, bool) {
+	partial := false
+	var lfCfg httpjson.RawConfig
+
+	if s, ok := c.defined[LogFwdHTTPEnabled]; ok {
+		partial = true
+		lfCfg.Enabled = s.(bool)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPURL]; ok && s != "" {
+		partial = true
+		lfCfg.URL = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPCACert]; ok && s != "" {
+		partial = true
+		lfCfg.CACert = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPBatchSize]; ok {
+		partial = true
+		lfCfg.BatchSize = s.(int)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPMaxRetries]; ok {
+		partial = true
+		lfCfg.MaxRetries = s.(int)
+	}
+
+	if !partial {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1442,6 +1506,11 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdHTTPEnabled:      schema.Omit,
+	LogFwdHTTPURL:          schema.Omit,
+	LogFwdHTTPCACert:       schema.Omit,
+	LogFwdHTTPBatchSize:    schema.Omit,
+	LogFwdHTTPMaxRetries:   schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -1898,6 +1967,31 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdHTTPEnabled: {
+		Description: `Whether log forwarding to an HTTP endpoint is enabled.`,
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPURL: {
+		Description: `The URL to which log records are POSTed as newline-delimited JSON.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPCACert: {
+		Description: `The certificate of the CA that signed the HTTP log endpoint's server certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPBatchSize: {
+		Description: `The maximum number of log records sent to the HTTP endpoint in a single request.`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPMaxRetries: {
+		Description: `The number of times a failed request to the HTTP log endpoint is retried before giving up.`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
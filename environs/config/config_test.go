@@ -522,6 +522,31 @@ var configTests = []configTest{
 			"syslog-client-key":  serverKey2,
 		}),
 		err: `invalid syslog forwarding config: validating TLS config: parsing client key pair: (crypto/)?tls: private key does not match public key`,
+	}, {
+		about:       "Valid http log forwarding config",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-http-enabled":     true,
+			"logforward-http-url":         "https://logs.example.com/ingest",
+			"logforward-http-ca-cert":     testing.CACert,
+			"logforward-http-batch-size":  50,
+			"logforward-http-max-retries": 3,
+		}),
+	}, {
+		about:       "http log forwarding enabled without url",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-http-enabled": true,
+		}),
+		err: `invalid http log forwarding config: empty URL not valid`,
+	}, {
+		about:       "Invalid http log forwarding url scheme",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-http-enabled": true,
+			"logforward-http-url":     "udp://10.0.0.1:514",
+		}),
+		err: `invalid http log forwarding config: URL scheme "udp" not valid`,
 	}, {
 		about:       "net-bond-reconfigure-delay value",
 		useDefaults: config.UseDefaults,
@@ -673,6 +698,20 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Check(lfCfg.ClientKey, gc.Equals, "")
 	}
 
+	httpCfg, hasHTTPCfg := cfg.LogFwdHTTP()
+	if v, ok := test.attrs["logforward-http-enabled"].(bool); ok {
+		c.Assert(hasHTTPCfg, jc.IsTrue)
+		c.Assert(httpCfg.Enabled, gc.Equals, v)
+	}
+	if v, ok := test.attrs["logforward-http-url"].(string); ok {
+		c.Assert(hasHTTPCfg, jc.IsTrue)
+		c.Assert(httpCfg.URL, gc.Equals, v)
+	}
+	if v, ok := test.attrs["logforward-http-batch-size"].(int); ok {
+		c.Assert(hasHTTPCfg, jc.IsTrue)
+		c.Assert(httpCfg.BatchSize, gc.Equals, v)
+	}
+
 	if v, ok := test.attrs["ssl-hostname-verification"]; ok {
 		c.Assert(cfg.SSLHostnameVerification(), gc.Equals, v)
 	}
@@ -28,3 +28,19 @@ func RecordMachineInState(client ProvisioningClientAPI, machineParams params.Add
 	}
 	return machineInfo.Machine, nil
 }
+
+// UpdateMachineParams updates the parameters gathered from a host being
+// provisioned with the series and hardware tags specified in args.
+func UpdateMachineParams(args ProvisionMachineArgs, machineParams *params.AddMachineParams) error {
+	if args.Series != "" && args.Series != machineParams.Series {
+		return errors.Errorf(
+			"host %q is running series %q, expected %q",
+			args.Host, machineParams.Series, args.Series,
+		)
+	}
+	if len(args.Tags) > 0 {
+		tags := append([]string(nil), args.Tags...)
+		machineParams.HardwareCharacteristics.Tags = &tags
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manual_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/testing"
+)
+
+type commonSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&commonSuite{})
+
+func (s *commonSuite) TestUpdateMachineParams(c *gc.C) {
+	machineParams := params.AddMachineParams{Series: "bionic"}
+	err := manual.UpdateMachineParams(manual.ProvisionMachineArgs{
+		Host:   "10.0.0.1",
+		Series: "bionic",
+		Tags:   []string{"ssd", "rack-1"},
+	}, &machineParams)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineParams.HardwareCharacteristics.Tags, gc.NotNil)
+	c.Assert(*machineParams.HardwareCharacteristics.Tags, jc.DeepEquals, []string{"ssd", "rack-1"})
+}
+
+func (s *commonSuite) TestUpdateMachineParamsNoOverrides(c *gc.C) {
+	machineParams := params.AddMachineParams{Series: "bionic"}
+	err := manual.UpdateMachineParams(manual.ProvisionMachineArgs{Host: "10.0.0.1"}, &machineParams)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineParams.Series, gc.Equals, "bionic")
+	c.Assert(machineParams.HardwareCharacteristics.Tags, gc.IsNil)
+}
+
+func (s *commonSuite) TestUpdateMachineParamsSeriesMismatch(c *gc.C) {
+	machineParams := params.AddMachineParams{Series: "xenial"}
+	err := manual.UpdateMachineParams(manual.ProvisionMachineArgs{
+		Host:   "10.0.0.1",
+		Series: "bionic",
+	}, &machineParams)
+	c.Assert(err, gc.ErrorMatches, `host "10.0.0.1" is running series "xenial", expected "bionic"`)
+}
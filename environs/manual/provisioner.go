@@ -30,6 +30,18 @@ type ProvisionMachineArgs struct {
 	Host string
 	User string
 
+	// PrivateKey is the path to a private key file to use for
+	// authenticating ssh connections to the host. If left blank,
+	// the user's default ssh identities will be used.
+	PrivateKey string
+
+	// Series, if set, is the series the host is expected to be
+	// running. Provisioning fails if the detected series differs.
+	Series string
+
+	// Tags, if set, are recorded as the machine's hardware tags.
+	Tags []string
+
 	// DataDir is the root directory for juju data.
 	// If left blank, the default location "/var/lib/juju" will be used.
 	DataDir string
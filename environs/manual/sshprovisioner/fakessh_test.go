@@ -118,6 +118,10 @@ type fakeSSH struct {
 	// exit code for the machine agent provisioning script.
 	ProvisionAgentExitCode int
 
+	// exit code for the script that cleans up after a failed
+	// machine agent provisioning script.
+	CleanupExitCode int
+
 	// InitUbuntuUser should be set to true if the fakeSSH script
 	// should respond to an attempt to initialise the ubuntu user.
 	InitUbuntuUser bool
@@ -142,6 +146,9 @@ func (r fakeSSH) install(c *gc.C) testing.Restorer {
 		restore = restore.Add(installFakeSSH(c, input, output, rc))
 	}
 	if !r.SkipProvisionAgent {
+		if r.ProvisionAgentExitCode != 0 {
+			add(sshprovisioner.CleanupScript(), nil, r.CleanupExitCode)
+		}
 		add(nil, nil, r.ProvisionAgentExitCode)
 	}
 	if !r.SkipDetection {
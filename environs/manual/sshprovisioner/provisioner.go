@@ -32,15 +32,18 @@ func ProvisionMachine(args manual.ProvisionMachineArgs) (machineId string, err e
 	// the ubuntu user's authorized_keys file with the public keys in the current
 	// user's ~/.ssh directory. The authenticationworker will later update the
 	// ubuntu user's authorized_keys.
-	if err = InitUbuntuUser(args.Host, args.User,
-		args.AuthorizedKeys, args.Stdin, args.Stdout); err != nil {
+	if err = initUbuntuUser(args.Host, args.User, args.AuthorizedKeys,
+		args.PrivateKey, args.Stdin, args.Stdout); err != nil {
 		return "", err
 	}
 
-	machineParams, err := gatherMachineParams(args.Host)
+	machineParams, err := gatherMachineParams(args.Host, args.PrivateKey)
 	if err != nil {
 		return "", err
 	}
+	if err := manual.UpdateMachineParams(args, machineParams); err != nil {
+		return "", err
+	}
 
 	// Inform Juju that the machine exists.
 	machineId, err = manual.RecordMachineInState(args.Client, *machineParams)
@@ -60,8 +63,14 @@ func ProvisionMachine(args manual.ProvisionMachineArgs) (machineId string, err e
 	}
 
 	// Finally, provision the machine agent.
-	err = runProvisionScript(provisioningScript, args.Host, args.Stderr)
+	err = runProvisionScript(provisioningScript, args.Host, args.PrivateKey, args.Stderr)
 	if err != nil {
+		// The script may have failed after installing the agent, in
+		// which case the host would be reported as already provisioned
+		// when provisioning is retried. Remove whatever was installed.
+		if cleanupErr := CleanupProvisioned(args.Host, args.PrivateKey); cleanupErr != nil {
+			logger.Errorf("error cleaning up host %s: %v", args.Host, cleanupErr)
+		}
 		return machineId, err
 	}
 
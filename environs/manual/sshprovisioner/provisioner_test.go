@@ -127,6 +127,47 @@ func (s *provisionerSuite) TestProvisionMachine(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "error checking if provisioned: subprocess encountered error code 255")
 }
 
+func (s *provisionerSuite) TestProvisionMachineFailureCleansUpHost(c *gc.C) {
+	var series = jujuversion.SupportedLTS()
+	const arch = "amd64"
+	args := s.getArgs(c)
+
+	var cleanedUp []string
+	cleanupProvisioned := sshprovisioner.CleanupProvisioned
+	s.PatchValue(&sshprovisioner.CleanupProvisioned, func(host, privateKey string) error {
+		cleanedUp = append(cleanedUp, host)
+		err := cleanupProvisioned(host, privateKey)
+		c.Check(err, jc.ErrorIsNil)
+		return err
+	})
+
+	// The provisioning script fails, so the agent it may have
+	// installed is removed along with the machine.
+	restore := fakeSSH{
+		Series:                 series,
+		Arch:                   arch,
+		InitUbuntuUser:         true,
+		ProvisionAgentExitCode: 255,
+	}.install(c)
+	machineId, err := sshprovisioner.ProvisionMachine(args)
+	restore.Restore()
+	c.Assert(err, gc.ErrorMatches, "subprocess encountered error code 255")
+	c.Assert(machineId, gc.Equals, "")
+	c.Assert(cleanedUp, jc.DeepEquals, []string{args.Host})
+
+	// The host is no longer reported as provisioned, so
+	// provisioning it again succeeds.
+	defer fakeSSH{
+		Series:         series,
+		Arch:           arch,
+		InitUbuntuUser: true,
+	}.install(c).Restore()
+	machineId, err = sshprovisioner.ProvisionMachine(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, "1")
+	c.Assert(cleanedUp, gc.HasLen, 1)
+}
+
 func (s *provisionerSuite) TestFinishInstancConfig(c *gc.C) {
 	var series = jujuversion.SupportedLTS()
 	const arch = "amd64"
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/utils/shell"
 	"github.com/juju/utils/ssh"
 
+	"github.com/juju/juju/agent"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig"
 	"github.com/juju/juju/cloudconfig/cloudinit"
@@ -40,6 +41,10 @@ import (
 // authorizedKeys may be empty, in which case the file
 // will be created and left empty.
 func InitUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io.Writer) error {
+	return initUbuntuUser(host, login, authorizedKeys, "", read, write)
+}
+
+func initUbuntuUser(host, login, authorizedKeys, privateKey string, read io.Reader, write io.Writer) error {
 	logger.Infof("initialising %q, user %q", host, login)
 
 	// To avoid unnecessary prompting for the specified login,
@@ -49,7 +54,7 @@ func InitUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io
 	//
 	// Note that we explicitly do not allocate a PTY, so we
 	// get a failure if sudo prompts.
-	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "-n", "true"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "-n", "true"}, sshOptions(privateKey))
 	if cmd.Run() == nil {
 		logger.Infof("ubuntu user is already initialised")
 		return nil
@@ -62,6 +67,9 @@ func InitUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io
 	}
 	script := fmt.Sprintf(initUbuntuScript, utils.ShQuote(authorizedKeys))
 	var options ssh.Options
+	if privateKey != "" {
+		options.SetIdentities(privateKey)
+	}
 	options.AllowPasswordAuthentication()
 	options.EnablePTY()
 	cmd = ssh.Command(host, []string{"sudo", "/bin/bash -c " + utils.ShQuote(script)}, &options)
@@ -96,11 +104,13 @@ fi`
 // DetectSeriesAndHardwareCharacteristics detects the OS
 // series and hardware characteristics of the remote machine
 // by connecting to the machine and executing a bash script.
+// The connection is authenticated with the specified private
+// key file, or with the default identities if it is empty.
 var DetectSeriesAndHardwareCharacteristics = detectSeriesAndHardwareCharacteristics
 
-func detectSeriesAndHardwareCharacteristics(host string) (hc instance.HardwareCharacteristics, series string, err error) {
+func detectSeriesAndHardwareCharacteristics(host, privateKey string) (hc instance.HardwareCharacteristics, series string, err error) {
 	logger.Infof("Detecting series and characteristics on %s", host)
-	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, sshOptions(privateKey))
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -160,15 +170,16 @@ func detectSeriesAndHardwareCharacteristics(host string) (hc instance.HardwareCh
 }
 
 // CheckProvisioned checks if any juju init service already
-// exist on the host machine.
+// exist on the host machine. The connection is authenticated
+// as for DetectSeriesAndHardwareCharacteristics.
 var CheckProvisioned = checkProvisioned
 
-func checkProvisioned(host string) (bool, error) {
+func checkProvisioned(host, privateKey string) (bool, error) {
 	logger.Infof("Checking if %s is already provisioned", host)
 
 	script := service.ListServicesScript()
 
-	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, sshOptions(privateKey))
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -185,6 +196,51 @@ func checkProvisioned(host string) (bool, error) {
 	return provisioned, nil
 }
 
+// CleanupProvisioned removes any juju agent installed on the host
+// machine by a provisioning script that subsequently failed, so that
+// the host can be provisioned again. The connection is authenticated
+// as for DetectSeriesAndHardwareCharacteristics.
+var CleanupProvisioned = cleanupProvisioned
+
+func cleanupProvisioned(host, privateKey string) error {
+	logger.Infof("Removing partially provisioned agent from %s", host)
+
+	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "/bin/bash"}, sshOptions(privateKey))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Stdin = strings.NewReader(cleanupScript())
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() != 0 {
+			err = fmt.Errorf("%v (%v)", err, strings.TrimSpace(stderr.String()))
+		}
+		return err
+	}
+	return nil
+}
+
+// cleanupScript returns the script to run on the remote machine to
+// stop and remove the juju agent services, along with the agent's
+// data and log directories.
+func cleanupScript() string {
+	const script = `#!/bin/bash
+pkill -SIGKILL jujud
+for service in $(ls /lib/systemd/system /etc/systemd/system /etc/init 2>/dev/null | grep '^jujud-'); do
+    service=${service%%.*}
+    systemctl stop "$service" 2>/dev/null || stop "$service" 2>/dev/null
+done
+rm -f /etc/init/jujud-*
+rm -fr /lib/systemd/system/jujud-* /etc/systemd/system/jujud-*
+rm -f /etc/systemd/system/multi-user.target.wants/jujud-*
+systemctl daemon-reload 2>/dev/null
+rm -fr %s %s
+exit 0`
+	return fmt.Sprintf(
+		script,
+		utils.ShQuote(agent.DefaultPaths.DataDir),
+		utils.ShQuote(agent.DefaultPaths.LogDir),
+	)
+}
+
 // detectionScript is the script to run on the remote machine to
 // detect the OS series and hardware characteristics.
 const detectionScript = `#!/bin/bash
@@ -210,7 +266,7 @@ cat /proc/cpuinfo`
 // The hostname supplied should not include a username.
 // If we can, we will reverse lookup the hostname by its IP address, and use
 // the DNS resolved name, rather than the name that was supplied
-func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
+func gatherMachineParams(hostname, privateKey string) (*params.AddMachineParams, error) {
 
 	// Generate a unique nonce for the machine.
 	uuid, err := utils.NewUUID()
@@ -223,7 +279,7 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 		return nil, errors.Annotatef(err, "failed to compute public address for %q", hostname)
 	}
 
-	provisioned, err := checkProvisioned(hostname)
+	provisioned, err := CheckProvisioned(hostname, privateKey)
 	if err != nil {
 		return nil, errors.Annotatef(err, "error checking if provisioned")
 	}
@@ -231,7 +287,7 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 		return nil, manual.ErrProvisioned
 	}
 
-	hc, series, err := DetectSeriesAndHardwareCharacteristics(hostname)
+	hc, series, err := DetectSeriesAndHardwareCharacteristics(hostname, privateKey)
 	if err != nil {
 		return nil, errors.Annotatef(err, "error detecting linux hardware characteristics")
 	}
@@ -254,14 +310,26 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 	return machineParams, nil
 }
 
-func runProvisionScript(script, host string, progressWriter io.Writer) error {
+func runProvisionScript(script, host, privateKey string, progressWriter io.Writer) error {
 	params := sshinit.ConfigureParams{
 		Host:           "ubuntu@" + host,
+		SSHOptions:     sshOptions(privateKey),
 		ProgressWriter: progressWriter,
 	}
 	return sshinit.RunConfigureScript(script, params)
 }
 
+// sshOptions returns the options for ssh connections authenticated
+// with the specified private key, or nil to use the default identities.
+func sshOptions(privateKey string) *ssh.Options {
+	if privateKey == "" {
+		return nil
+	}
+	var options ssh.Options
+	options.SetIdentities(privateKey)
+	return &options
+}
+
 // ProvisioningScript generates a bash script that can be
 // executed on a remote host to carry out the cloud-init
 // configuration.
@@ -37,6 +37,9 @@ func ProvisionMachine(args manual.ProvisionMachineArgs) (machineId string, err e
 	if err != nil {
 		return "", err
 	}
+	if err := manual.UpdateMachineParams(args, machineParams); err != nil {
+		return "", err
+	}
 
 	machineId, err = manual.RecordMachineInState(args.Client, *machineParams)
 	if err != nil {
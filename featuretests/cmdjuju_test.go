@@ -166,6 +166,22 @@ application-config:
     source: user
     type: string
     value: ext-host
+  kubernetes-autoscaling-max-replicas:
+    description: maximum number of pods when autoscaling; enables autoscaling
+    source: unset
+    type: int
+  kubernetes-autoscaling-min-replicas:
+    description: minimum number of pods when autoscaling
+    source: unset
+    type: int
+  kubernetes-autoscaling-target-cpu-utilization:
+    description: target average CPU utilization percentage for autoscaling
+    source: unset
+    type: int
+  kubernetes-autoscaling-target-memory-utilization:
+    description: target average memory utilization percentage for autoscaling
+    source: unset
+    type: int
   kubernetes-ingress-allow-http:
     default: false
     description: whether to allow HTTP traffic to the ingress controller
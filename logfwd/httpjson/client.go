@@ -0,0 +1,233 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/retry"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/logfwd"
+)
+
+var logger = loggo.GetLogger("juju.logfwd.httpjson")
+
+// ContentType is the media type of the request bodies sent by Client.
+const ContentType = "application/x-ndjson"
+
+const (
+	retryDelay    = time.Second
+	maxRetryDelay = 30 * time.Second
+	sendTimeout   = 30 * time.Second
+)
+
+// Doer exposes the underlying functionality needed by Client.
+// *http.Client satisfies it.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client sends log records to a remote HTTP endpoint as
+// newline-delimited JSON.
+type Client struct {
+	// URL is the endpoint to which records are POSTed.
+	URL string
+
+	// BatchSize is the maximum number of records sent per request.
+	BatchSize int
+
+	// MaxRetries is the number of times a failed request is retried.
+	MaxRetries int
+
+	// Doer is used to send the HTTP requests.
+	Doer Doer
+
+	// Clock is used when backing off between retries.
+	Clock clock.Clock
+}
+
+// Open returns a new client that sends records to the HTTP endpoint
+// described by the config.
+func Open(cfg RawConfig) (*Client, error) {
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	doer := &http.Client{
+		Transport: utils.NewHttpTLSTransport(tlsCfg),
+		Timeout:   sendTimeout,
+	}
+	client, err := OpenForDoer(cfg, doer, clock.WallClock)
+	return client, errors.Trace(err)
+}
+
+// OpenForDoer returns a new client that uses the given Doer to send
+// records to the HTTP endpoint described by the config.
+func OpenForDoer(cfg RawConfig, doer Doer, clock clock.Clock) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		URL:        cfg.URL,
+		BatchSize:  cfg.batchSize(),
+		MaxRetries: cfg.maxRetries(),
+		Doer:       doer,
+		Clock:      clock,
+	}, nil
+}
+
+// Close implements io.Closer. The client holds no connections of its
+// own, so there is nothing to release.
+func (client Client) Close() error {
+	return nil
+}
+
+// Send sends the records to the remote endpoint, in batches of at most
+// BatchSize records. Each batch is retried with an exponential backoff
+// until it is accepted or MaxRetries is exceeded.
+func (client Client) Send(records []logfwd.Record) error {
+	batchSize := client.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	for len(records) > 0 {
+		n := batchSize
+		if n > len(records) {
+			n = len(records)
+		}
+		if err := client.sendBatch(records[:n]); err != nil {
+			return errors.Trace(err)
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+func (client Client) sendBatch(records []logfwd.Record) error {
+	body, err := encodeRecords(records)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = retry.Call(retry.CallArgs{
+		Func: func() error {
+			return client.post(body)
+		},
+		IsFatalError: func(err error) bool {
+			_, ok := errors.Cause(err).(*rejectedError)
+			return ok
+		},
+		NotifyFunc: func(err error, attempt int) {
+			logger.Debugf("sending %d log records to %s, attempt %d: %v", len(records), client.URL, attempt, err)
+		},
+		Attempts:    client.MaxRetries + 1,
+		Delay:       retryDelay,
+		MaxDelay:    maxRetryDelay,
+		BackoffFunc: retry.DoubleDelay,
+		Clock:       client.Clock,
+	})
+	if err != nil {
+		return errors.Annotatef(retry.LastError(err), "sending log records to %s", client.URL)
+	}
+	return nil
+}
+
+func (client Client) post(body []byte) error {
+	req, err := http.NewRequest("POST", client.URL, bytes.NewReader(body))
+	if err != nil {
+		return &rejectedError{err.Error()}
+	}
+	req.Header.Set("Content-Type", ContentType)
+	resp, err := client.Doer.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return errors.Errorf("unexpected response %q", resp.Status)
+	default:
+		// Any other client error will not be fixed by retrying.
+		return &rejectedError{fmt.Sprintf("records rejected: %s", resp.Status)}
+	}
+}
+
+// rejectedError is returned when a request will never succeed, so
+// retrying it is pointless.
+type rejectedError struct {
+	msg string
+}
+
+func (e *rejectedError) Error() string {
+	return e.msg
+}
+
+// Record is the JSON representation of a single forwarded log record.
+type Record struct {
+	ID              int64     `json:"id"`
+	Timestamp       time.Time `json:"timestamp"`
+	Level           string    `json:"level"`
+	Module          string    `json:"module,omitempty"`
+	Source          string    `json:"source,omitempty"`
+	Message         string    `json:"message"`
+	ControllerUUID  string    `json:"controller-uuid"`
+	ModelUUID       string    `json:"model-uuid"`
+	Hostname        string    `json:"hostname,omitempty"`
+	OriginType      string    `json:"origin-type"`
+	OriginName      string    `json:"origin-name"`
+	Software        string    `json:"software"`
+	SoftwareVersion string    `json:"software-version"`
+}
+
+func newRecord(rec logfwd.Record) Record {
+	var source string
+	if rec.Location.Filename != "" {
+		source = rec.Location.Filename
+		if rec.Location.Line > 0 {
+			source = fmt.Sprintf("%s:%d", source, rec.Location.Line)
+		}
+	}
+	return Record{
+		ID:              rec.ID,
+		Timestamp:       rec.Timestamp.UTC(),
+		Level:           rec.Level.String(),
+		Module:          rec.Location.Module,
+		Source:          source,
+		Message:         rec.Message,
+		ControllerUUID:  rec.Origin.ControllerUUID,
+		ModelUUID:       rec.Origin.ModelUUID,
+		Hostname:        rec.Origin.Hostname,
+		OriginType:      rec.Origin.Type.String(),
+		OriginName:      rec.Origin.Name,
+		Software:        rec.Origin.Software.Name,
+		SoftwareVersion: rec.Origin.Software.Version.String(),
+	}
+}
+
+func encodeRecords(records []logfwd.Record) ([]byte, error) {
+	var buf bytes.Buffer
+	// Encode writes a trailing newline after each value, which is
+	// exactly the newline-delimited format we want.
+	enc := json.NewEncoder(&buf)
+	for _, rec := range records {
+		if err := enc.Encode(newRecord(rec)); err != nil {
+			return nil, errors.Annotatef(err, "encoding record %d", rec.ID)
+		}
+	}
+	return buf.Bytes(), nil
+}
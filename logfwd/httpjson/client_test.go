@@ -0,0 +1,227 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	clock *testclock.Clock
+	rec   logfwd.Record
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.clock = testclock.NewClock(time.Now())
+	s.rec = logfwd.Record{
+		Origin: logfwd.Origin{
+			ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Type:           logfwd.OriginTypeMachine,
+			Name:           "99",
+			Software: logfwd.Software{
+				PrivateEnterpriseNumber: 28978,
+				Name:                    "jujud-machine-agent",
+				Version:                 version.MustParse("2.0.1"),
+			},
+		},
+		ID:        10,
+		Timestamp: time.Unix(12345, 0).UTC(),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.api.logstream.test",
+			Filename: "test.go",
+			Line:     42,
+		},
+		Message: "send to 10.0.0.1",
+	}
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, httpjson.ContentType)
+		data, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		bodies = append(bodies, string(data))
+	}))
+	defer srv.Close()
+
+	client, err := httpjson.OpenForDoer(httpjson.RawConfig{
+		Enabled: true,
+		URL:     srv.URL,
+	}, http.DefaultClient, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(bodies, gc.HasLen, 1)
+	var got httpjson.Record
+	err = json.Unmarshal([]byte(bodies[0]), &got)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(got, jc.DeepEquals, httpjson.Record{
+		ID:              10,
+		Timestamp:       time.Unix(12345, 0).UTC(),
+		Level:           "ERROR",
+		Module:          "juju.api.logstream.test",
+		Source:          "test.go:42",
+		Message:         "send to 10.0.0.1",
+		ControllerUUID:  "9f484882-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Hostname:        "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		OriginType:      "machine",
+		OriginName:      "99",
+		Software:        "jujud-machine-agent",
+		SoftwareVersion: "2.0.1",
+	})
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	var lineCounts []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		scanner := bufio.NewScanner(req.Body)
+		n := 0
+		for scanner.Scan() {
+			n++
+		}
+		lineCounts = append(lineCounts, n)
+	}))
+	defer srv.Close()
+
+	client, err := httpjson.OpenForDoer(httpjson.RawConfig{
+		Enabled:   true,
+		URL:       srv.URL,
+		BatchSize: 2,
+	}, http.DefaultClient, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	records := []logfwd.Record{s.rec, s.rec, s.rec, s.rec, s.rec}
+	err = client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(lineCounts, jc.DeepEquals, []int{2, 2, 1})
+}
+
+func (s *ClientSuite) TestSendRetries(c *gc.C) {
+	doer := &stubDoer{
+		stub:     &testing.Stub{},
+		statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+	}
+	client, err := httpjson.OpenForDoer(httpjson.RawConfig{
+		Enabled: true,
+		URL:     "http://logs.example.com",
+	}, doer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	done := make(chan error)
+	go func() {
+		done <- client.Send([]logfwd.Record{s.rec})
+	}()
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for send")
+	}
+	doer.stub.CheckCallNames(c, "Do", "Do")
+}
+
+func (s *ClientSuite) TestSendRejected(c *gc.C) {
+	doer := &stubDoer{
+		stub:     &testing.Stub{},
+		statuses: []int{http.StatusBadRequest},
+	}
+	client, err := httpjson.OpenForDoer(httpjson.RawConfig{
+		Enabled: true,
+		URL:     "http://logs.example.com",
+	}, doer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, gc.ErrorMatches, `sending log records to http://logs.example.com: records rejected: 400 Bad Request`)
+
+	// Client errors are not retried.
+	doer.stub.CheckCallNames(c, "Do")
+}
+
+func (s *ClientSuite) TestSendTransportError(c *gc.C) {
+	doer := &stubDoer{
+		stub: &testing.Stub{},
+	}
+	doer.stub.SetErrors(errors.New("boom"))
+	client, err := httpjson.OpenForDoer(httpjson.RawConfig{
+		Enabled:    true,
+		URL:        "http://logs.example.com",
+		MaxRetries: 1,
+	}, doer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	done := make(chan error)
+	go func() {
+		done <- client.Send([]logfwd.Record{s.rec})
+	}()
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case err := <-done:
+		c.Assert(err, gc.ErrorMatches, `sending log records to http://logs.example.com: unexpected response "503 Service Unavailable"`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for send")
+	}
+	doer.stub.CheckCallNames(c, "Do", "Do")
+}
+
+type stubDoer struct {
+	stub     *testing.Stub
+	statuses []int
+}
+
+func (d *stubDoer) Do(req *http.Request) (*http.Response, error) {
+	d.stub.AddCall("Do", req.URL.String())
+	if err := d.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	status := http.StatusServiceUnavailable
+	if len(d.statuses) > 0 {
+		status = d.statuses[0]
+		d.statuses = d.statuses[1:]
+	}
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode: status,
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/cert"
+)
+
+// SinkType is the log forwarding sink type for HTTP/JSON targets.
+const SinkType = "http"
+
+const (
+	// DefaultBatchSize is the maximum number of records sent in a
+	// single request when BatchSize is not set.
+	DefaultBatchSize = 100
+
+	// DefaultMaxRetries is the number of times a failed request is
+	// retried when MaxRetries is not set.
+	DefaultMaxRetries = 5
+)
+
+// RawConfig holds the raw configuration data for a connection to an
+// HTTP/JSON forwarding target.
+type RawConfig struct {
+	// Enabled is true if forwarding to the HTTP target is enabled.
+	Enabled bool
+
+	// URL is the endpoint to which batches of records are POSTed.
+	// The scheme must be either "http" or "https".
+	URL string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting. If it
+	// is not set then the system certificate pool is used.
+	CACert string
+
+	// BatchSize is the maximum number of records sent in a single
+	// request. If it is zero then DefaultBatchSize is used.
+	BatchSize int
+
+	// MaxRetries is the number of times a failed request is retried,
+	// with an exponential backoff, before giving up. If it is zero
+	// then DefaultMaxRetries is used.
+	MaxRetries int
+}
+
+// SinkType implements logfwd.SinkConfig.
+func (cfg RawConfig) SinkType() string {
+	return SinkType
+}
+
+// IsEnabled implements logfwd.SinkConfig.
+func (cfg RawConfig) IsEnabled() bool {
+	return cfg.Enabled
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if err := cfg.validateURL(); err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.BatchSize < 0 {
+		return errors.NotValidf("negative BatchSize %d", cfg.BatchSize)
+	}
+	if cfg.MaxRetries < 0 {
+		return errors.NotValidf("negative MaxRetries %d", cfg.MaxRetries)
+	}
+	if cfg.CACert != "" {
+		if _, err := cfg.tlsConfig(); err != nil {
+			return errors.Annotate(err, "validating TLS config")
+		}
+	}
+	return nil
+}
+
+func (cfg RawConfig) validateURL() error {
+	if cfg.URL == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("empty URL")
+		}
+		return nil
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.NotValidf("URL %q", cfg.URL)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("URL scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.NotValidf("URL %q without host", cfg.URL)
+	}
+	return nil
+}
+
+func (cfg RawConfig) batchSize() int {
+	if cfg.BatchSize == 0 {
+		return DefaultBatchSize
+	}
+	return cfg.BatchSize
+}
+
+func (cfg RawConfig) maxRetries() int {
+	if cfg.MaxRetries == 0 {
+		return DefaultMaxRetries
+	}
+	return cfg.MaxRetries
+}
+
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		return nil, nil
+	}
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing CA certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+
+	return &tls.Config{
+		RootCAs: rootCAs,
+	}, nil
+}
@@ -0,0 +1,17 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+// SinkConfig is the configuration of a single log forwarding target.
+// Each kind of target (e.g. syslog) provides its own implementation.
+type SinkConfig interface {
+	// SinkType identifies the kind of target the config is for.
+	SinkType() string
+
+	// IsEnabled reports whether forwarding to the target is enabled.
+	IsEnabled() bool
+
+	// Validate ensures that the config is currently valid.
+	Validate() error
+}
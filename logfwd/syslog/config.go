@@ -12,6 +12,9 @@ import (
 	"github.com/juju/utils/cert"
 )
 
+// SinkType is the log forwarding sink type for syslog targets.
+const SinkType = "syslog"
+
 // RawConfig holds the raw configuration data for a connection to a
 // syslog forwarding target.
 type RawConfig struct {
@@ -39,6 +42,16 @@ type RawConfig struct {
 	ClientKey string
 }
 
+// SinkType implements logfwd.SinkConfig.
+func (cfg RawConfig) SinkType() string {
+	return SinkType
+}
+
+// IsEnabled implements logfwd.SinkConfig.
+func (cfg RawConfig) IsEnabled() bool {
+	return cfg.Enabled
+}
+
 // Validate ensures that the config is currently valid.
 func (cfg RawConfig) Validate() error {
 	if err := cfg.validateHost(); err != nil {
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/description"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/migration"
+	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
@@ -49,6 +51,82 @@ func ExportModel(st StateExporter) ([]byte, error) {
 	return bytes, nil
 }
 
+// TransferSizes holds the estimated number of bytes transferred to the
+// target controller when a model is migrated.
+type TransferSizes struct {
+	ModelDescription int64
+	Charms           int64
+	Tools            int64
+	Resources        int64
+}
+
+// Total returns the total estimated number of bytes transferred.
+func (s TransferSizes) Total() int64 {
+	return s.ModelDescription + s.Charms + s.Tools + s.Resources
+}
+
+// CharmSizeFunc returns the size in bytes of the archive of the charm
+// with the specified URL.
+type CharmSizeFunc func(curl string) (int64, error)
+
+// EstimateTransferSizes returns the estimated amount of data which would
+// be transferred to the target controller to migrate the exported model,
+// given its serialized description.
+func EstimateTransferSizes(model description.Model, serialized []byte, charmSize CharmSizeFunc) (TransferSizes, error) {
+	sizes := TransferSizes{
+		ModelDescription: int64(len(serialized)),
+	}
+
+	charms := set.NewStrings()
+	for _, app := range model.Applications() {
+		charms.Add(app.CharmURL())
+	}
+	for _, curl := range charms.SortedValues() {
+		size, err := charmSize(curl)
+		if err != nil {
+			return TransferSizes{}, errors.Annotatef(err, "getting size of charm %q", curl)
+		}
+		sizes.Charms += size
+	}
+
+	// Tools are only uploaded once per version, and are not needed for
+	// CAAS models.
+	if model.Type() == string(coremodel.IAAS) {
+		tools := make(map[version.Binary]int64)
+		var addMachineTools func(description.Machine)
+		addMachineTools = func(machine description.Machine) {
+			if t := machine.Tools(); t != nil {
+				tools[t.Version()] = t.Size()
+			}
+			for _, container := range machine.Containers() {
+				addMachineTools(container)
+			}
+		}
+		for _, machine := range model.Machines() {
+			addMachineTools(machine)
+		}
+		for _, app := range model.Applications() {
+			for _, unit := range app.Units() {
+				if t := unit.Tools(); t != nil {
+					tools[t.Version()] = t.Size()
+				}
+			}
+		}
+		for _, size := range tools {
+			sizes.Tools += size
+		}
+	}
+
+	for _, app := range model.Applications() {
+		for _, res := range app.Resources() {
+			if rev := res.ApplicationRevision(); rev != nil {
+				sizes.Resources += rev.Size()
+			}
+		}
+	}
+	return sizes, nil
+}
+
 // StateImporter describes the method needed to import a model
 // into the database.
 type StateImporter interface {
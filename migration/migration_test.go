@@ -20,6 +20,7 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/component/all"
 	"github.com/juju/juju/core/leadership"
@@ -384,6 +385,83 @@ func (s *ExportSuite) TestExportModel(c *gc.C) {
 	c.Assert(modelDesc.Validate(), jc.ErrorIsNil)
 }
 
+type EstimateSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&EstimateSuite{})
+
+func (s *EstimateSuite) makeModel(modelType string) description.Model {
+	model := description.NewModel(description.ModelArgs{
+		Type:  modelType,
+		Owner: names.NewUserTag("admin"),
+	})
+	for _, name := range []string{"foo", "bar"} {
+		app := model.AddApplication(description.ApplicationArgs{
+			Tag:      names.NewApplicationTag(name),
+			CharmURL: "cs:foo-0",
+		})
+		unit := app.AddUnit(description.UnitArgs{
+			Tag: names.NewUnitTag(name + "/0"),
+		})
+		unit.SetTools(description.AgentToolsArgs{
+			Version: version.MustParseBinary("2.0.0-xenial-amd64"),
+			Size:    100,
+		})
+		res := app.AddResource(description.ResourceArgs{"bin"})
+		res.SetApplicationRevision(description.ResourceRevisionArgs{
+			Revision: 1,
+			Type:     "file",
+			Path:     "bin.tar.gz",
+			Origin:   "upload",
+			Size:     1000,
+		})
+	}
+	m := model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("0")})
+	m.SetTools(description.AgentToolsArgs{
+		Version: version.MustParseBinary("2.0.0-xenial-amd64"),
+		Size:    100,
+	})
+	container := m.AddContainer(description.MachineArgs{Id: names.NewMachineTag("0/lxd/0")})
+	container.SetTools(description.AgentToolsArgs{
+		Version: version.MustParseBinary("2.0.1-xenial-amd64"),
+		Size:    200,
+	})
+	return model
+}
+
+func (s *EstimateSuite) TestEstimateTransferSizes(c *gc.C) {
+	var charms []string
+	charmSize := func(curl string) (int64, error) {
+		charms = append(charms, curl)
+		return 10000, nil
+	}
+	sizes, err := migration.EstimateTransferSizes(s.makeModel("iaas"), make([]byte, 42), charmSize)
+	c.Assert(err, jc.ErrorIsNil)
+	// The charm and the shared tools version are only counted once.
+	c.Check(charms, jc.DeepEquals, []string{"cs:foo-0"})
+	c.Check(sizes, jc.DeepEquals, migration.TransferSizes{
+		ModelDescription: 42,
+		Charms:           10000,
+		Tools:            300,
+		Resources:        2000,
+	})
+	c.Check(sizes.Total(), gc.Equals, int64(12342))
+}
+
+func (s *EstimateSuite) TestEstimateTransferSizesCAAS(c *gc.C) {
+	charmSize := func(string) (int64, error) { return 10000, nil }
+	sizes, err := migration.EstimateTransferSizes(s.makeModel("caas"), nil, charmSize)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sizes.Tools, gc.Equals, int64(0))
+}
+
+func (s *EstimateSuite) TestEstimateTransferSizesCharmError(c *gc.C) {
+	charmSize := func(string) (int64, error) { return 0, errors.New("boom") }
+	_, err := migration.EstimateTransferSizes(s.makeModel("iaas"), nil, charmSize)
+	c.Assert(err, gc.ErrorMatches, `getting size of charm "cs:foo-0": boom`)
+}
+
 func fakeGetClaimer(string) (leadership.Claimer, error) {
 	return &fakeClaimer{}, nil
 }
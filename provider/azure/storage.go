@@ -69,6 +69,7 @@ type azureStorageProvider struct {
 }
 
 var _ storage.Provider = (*azureStorageProvider)(nil)
+var _ storage.VolumeResizer = (*azureVolumeSource)(nil)
 
 var azureStorageConfigFields = schema.Fields{
 	accountTypeAttr: schema.OneOf(
@@ -533,6 +534,55 @@ func (v *azureVolumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volu
 	return nil, errors.NotSupportedf("ReleaseVolumes")
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *azureVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	if v.maybeStorageClient != nil {
+		// Unmanaged disks are page blobs, which we do not
+		// support resizing.
+		return nil, errors.NotSupportedf("resizing unmanaged disks")
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeManagedDiskVolume(ctx, p)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (v *azureVolumeSource) resizeManagedDiskVolume(ctx context.ProviderCallContext, p storage.VolumeResizeParams) (uint64, error) {
+	sizeInGib := mibToGib(p.Size)
+	if sizeInGib > volumeSizeMaxGiB {
+		return 0, errors.Errorf(
+			"%d GiB exceeds the maximum of %d GiB",
+			sizeInGib, volumeSizeMaxGiB,
+		)
+	}
+	diskUpdate := compute.DiskUpdate{
+		DiskUpdateProperties: &compute.DiskUpdateProperties{
+			DiskSizeGB: to.Int32Ptr(int32(sizeInGib)),
+		},
+	}
+	diskClient := compute.DisksClient{v.env.disk}
+	sdkCtx := stdcontext.Background()
+	future, err := diskClient.Update(sdkCtx, v.env.resourceGroup, p.VolumeId, diskUpdate)
+	if err != nil {
+		return 0, errorutils.HandleCredentialError(errors.Annotatef(err, "resizing disk %q", p.VolumeId), ctx)
+	}
+	err = future.WaitForCompletionRef(sdkCtx, diskClient.Client)
+	if err != nil {
+		return 0, errorutils.HandleCredentialError(errors.Annotatef(err, "resizing disk %q", p.VolumeId), ctx)
+	}
+	result, err := future.Result(diskClient)
+	if err != nil {
+		return 0, errors.Annotatef(err, "resizing disk %q", p.VolumeId)
+	}
+	return gibToMib(uint64(to.Int32(result.DiskSizeGB))), nil
+}
+
 // ValidateVolumeParams is specified on the storage.VolumeSource interface.
 func (v *azureVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
 	if mibToGib(params.Size) > volumeSizeMaxGiB {
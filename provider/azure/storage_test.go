@@ -752,6 +752,60 @@ func (s *storageSuite) TestDestroyVolumesLegacy(c *gc.C) {
 	blob1.CheckCallNames(c, "DeleteIfExists")
 }
 
+func (s *storageSuite) TestResizeVolumes(c *gc.C) {
+	volumeSource := s.volumeSource(c, false)
+	c.Assert(volumeSource, gc.Implements, new(storage.VolumeResizer))
+
+	makeSender := func() *azuretesting.MockSender {
+		sender := azuretesting.NewSenderWithValue(&compute.Disk{
+			Name: to.StringPtr("volume-0"),
+			DiskProperties: &compute.DiskProperties{
+				DiskSizeGB: to.Int32Ptr(3),
+			},
+		})
+		sender.PathPattern = `.*/Microsoft\.Compute/disks/volume-0`
+		return sender
+	}
+	s.requests = nil
+	s.sender = azuretesting.Senders{
+		makeSender(),
+		makeSender(), // future.Results call
+	}
+
+	results, err := volumeSource.(storage.VolumeResizer).ResizeVolumes(s.cloudCallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     2049,
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "volume-1",
+		Size:     1024 * 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeVolumesResult{Size: 3 * 1024})
+	c.Assert(results[1].Error, gc.ErrorMatches, "1024 GiB exceeds the maximum of 1023 GiB")
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[0].Method, gc.Equals, "PATCH") // resize volume-0
+	c.Assert(s.requests[1].Method, gc.Equals, "GET")   // resize volume-0 - future.Results call
+	assertRequestBody(c, s.requests[0], &compute.DiskUpdate{
+		DiskUpdateProperties: &compute.DiskUpdateProperties{
+			DiskSizeGB: to.Int32Ptr(3),
+		},
+	})
+}
+
+func (s *storageSuite) TestResizeVolumesLegacy(c *gc.C) {
+	volumeSource := s.volumeSource(c, true)
+	_, err := volumeSource.(storage.VolumeResizer).ResizeVolumes(s.cloudCallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     2048,
+	}})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *storageSuite) TestAttachVolumes(c *gc.C) {
 	s.testAttachVolumes(c, false)
 }
@@ -5,6 +5,7 @@ package ec2
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -72,6 +73,7 @@ const (
 	deviceInUse        = "InvalidDevice.InUse"
 	attachmentNotFound = "InvalidAttachment.NotFound"
 	volumeNotFound     = "InvalidVolume.NotFound"
+	snapshotNotFound   = "InvalidSnapshot.NotFound"
 	incorrectState     = "IncorrectState"
 )
 
@@ -285,7 +287,11 @@ type ebsVolumeSource struct {
 	modelUUID string
 }
 
-var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var (
+	_ storage.VolumeSource      = (*ebsVolumeSource)(nil)
+	_ storage.VolumeSnapshotter = (*ebsVolumeSource)(nil)
+	_ storage.VolumeResizer     = (*ebsVolumeSource)(nil)
+)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -388,6 +394,7 @@ func (v *ebsVolumeSource) createVolume(ctx context.ProviderCallContext, p storag
 	}
 	vol, _ := parseVolumeOptions(p.Size, p.Attributes)
 	vol.AvailZone = inst.AvailZone
+	vol.SnapshotId = p.SnapshotId
 	resp, err := v.env.ec2.CreateVolume(vol)
 	if err != nil {
 		return nil, nil, errors.Trace(maybeConvertCredentialError(err, ctx))
@@ -981,6 +988,133 @@ func (v *ebsVolumeSource) ImportVolume(ctx context.ProviderCallContext, volumeId
 	}, nil
 }
 
+// CreateVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) CreateVolumeSnapshots(ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createVolumeSnapshot(ctx, p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "snapshotting %s", names.ReadableString(p.Volume))
+			if common.IsCredentialNotValid(err) {
+				break
+			}
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) createVolumeSnapshot(ctx context.ProviderCallContext, p storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	resp, err := v.env.ec2.CreateSnapshot(p.VolumeId, resourceName(p.Volume, v.envName))
+	if err != nil {
+		return nil, errors.Trace(maybeConvertCredentialError(err, ctx))
+	}
+	resourceTags := make(map[string]string)
+	for k, v := range p.ResourceTags {
+		resourceTags[k] = v
+	}
+	resourceTags[tagName] = resourceName(p.Volume, v.envName)
+	if err := tagResources(v.env.ec2, ctx, resourceTags, resp.Id); err != nil {
+		return nil, errors.Annotate(err, "tagging snapshot")
+	}
+	return ec2ToJujuSnapshot(resp.Snapshot), nil
+}
+
+// ListVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) ListVolumeSnapshots(ctx context.ProviderCallContext, volIds []string) ([]storage.ListVolumeSnapshotsResult, error) {
+	results := make([]storage.ListVolumeSnapshotsResult, len(volIds))
+	if len(volIds) == 0 {
+		return results, nil
+	}
+	filter := ec2.NewFilter()
+	filter.Add("volume-id", volIds...)
+	resp, err := v.env.ec2.Snapshots(nil, filter)
+	if err != nil {
+		return nil, maybeConvertCredentialError(err, ctx)
+	}
+	byVolumeId := make(map[string][]storage.VolumeSnapshot)
+	for _, snap := range resp.Snapshots {
+		byVolumeId[snap.VolumeId] = append(byVolumeId[snap.VolumeId], *ec2ToJujuSnapshot(snap))
+	}
+	for i, volId := range volIds {
+		results[i].Snapshots = byVolumeId[volId]
+	}
+	return results, nil
+}
+
+// DeleteVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) DeleteVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		_, err := v.env.ec2.DeleteSnapshots([]string{snapshotId})
+		if ec2Err, ok := err.(*ec2.Error); ok && ec2Err.Code == snapshotNotFound {
+			// The snapshot has already been deleted.
+			err = nil
+		}
+		if err != nil {
+			results[i] = errors.Annotatef(
+				maybeConvertCredentialError(err, ctx),
+				"deleting snapshot %s", snapshotId,
+			)
+		}
+	}
+	return results, nil
+}
+
+// RestoreVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) RestoreVolumeSnapshots(ctx context.ProviderCallContext, params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	for _, p := range params {
+		if p.SnapshotId == "" {
+			return nil, errors.Errorf("no snapshot specified for %s", names.ReadableString(p.Tag))
+		}
+	}
+	// The snapshot ID is passed through to the EC2 CreateVolume
+	// call, so restoring is otherwise the same as creating a volume.
+	return v.CreateVolumes(ctx, params)
+}
+
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *ebsVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeVolume(ctx, p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing %s", names.ReadableString(p.Tag))
+			if common.IsCredentialNotValid(err) {
+				break
+			}
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) resizeVolume(ctx context.ProviderCallContext, p storage.VolumeResizeParams) (uint64, error) {
+	// EBS volumes are sized in whole GiB, so the volume
+	// may end up larger than requested. The new size is
+	// reported as soon as the modification is accepted,
+	// while the volume is optimised in the background.
+	sizeGiB := mibToGib(p.Size)
+	if err := modifyVolume(v.env.ec2, p.VolumeId, int(sizeGiB)); err != nil {
+		return 0, errors.Trace(maybeConvertCredentialError(err, ctx))
+	}
+	return gibToMib(sizeGiB), nil
+}
+
+func ec2ToJujuSnapshot(snap ec2.Snapshot) *storage.VolumeSnapshot {
+	sizeGiB, _ := strconv.ParseUint(snap.VolumeSize, 10, 64)
+	created, _ := time.Parse(time.RFC3339, snap.StartTime)
+	return &storage.VolumeSnapshot{
+		SnapshotId: snap.Id,
+		VolumeId:   snap.VolumeId,
+		Size:       gibToMib(sizeGiB),
+		Created:    created,
+		Status:     snap.Status,
+	}
+}
+
 var errTooManyVolumes = errors.New("too many EBS volumes to attach")
 
 // blockDeviceNamer returns a function that cycles through block device names.
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"sort"
 	"strconv"
 	"time"
@@ -1039,6 +1040,15 @@ func (s *ebsSuite) TestImportVolume(c *gc.C) {
 	})
 }
 
+func (s *ebsSuite) TestRestoreVolumeSnapshotsRequiresSnapshot(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	c.Assert(vs, gc.Implements, new(storage.VolumeSnapshotter))
+
+	params := s.createVolumesParams("")
+	_, err := vs.(storage.VolumeSnapshotter).RestoreVolumeSnapshots(s.cloudCallCtx, params[:1])
+	c.Assert(err, gc.ErrorMatches, "no snapshot specified for volume 0")
+}
+
 func (s *ebsSuite) TestImportVolumeCredentialError(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	c.Assert(vs, gc.Implements, new(storage.VolumeImporter))
@@ -1074,6 +1084,70 @@ func (s *ebsSuite) TestImportVolumeInUse(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot import volume with status "in-use"`)
 }
 
+func (s *ebsSuite) TestResizeVolumes(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	resp, err := s.srv.client.CreateVolume(awsec2.CreateVolume{
+		VolumeSize: 1,
+		VolumeType: "gp2",
+		AvailZone:  "us-east-1a",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The test server does not support ModifyVolume,
+	// so record the request and report success.
+	var query url.Values
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		query = resp.Request.URL.Query()
+		resp.StatusCode = http.StatusOK
+		return replaceResponseBody(resp, struct {
+			XMLName xml.Name `xml:"ModifyVolumeResponse"`
+		}{})
+	}
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(s.cloudCallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: resp.Id,
+		Size:     1500,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 2048}})
+	c.Assert(query.Get("Action"), gc.Equals, "ModifyVolume")
+	c.Assert(query.Get("VolumeId"), gc.Equals, resp.Id)
+	c.Assert(query.Get("Size"), gc.Equals, "2")
+}
+
+func (s *ebsSuite) TestResizeVolumesCredentialError(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	c.Assert(vs, gc.Implements, new(storage.VolumeResizer))
+	resp, err := s.srv.client.CreateVolume(awsec2.CreateVolume{
+		VolumeSize: 1,
+		VolumeType: "gp2",
+		AvailZone:  "us-east-1a",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		resp.StatusCode = http.StatusBadRequest
+		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
+			Code: "Blocked",
+		}}})
+	}
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(s.cloudCallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: resp.Id,
+		Size:     2048,
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: resp.Id,
+		Size:     4096,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.Satisfies, common.IsCredentialNotValid)
+	c.Assert(results[0].Error, gc.ErrorMatches, "resizing volume 0: .*")
+	// No further volumes are resized once the credential is found invalid.
+	c.Assert(results[1], jc.DeepEquals, storage.ResizeVolumesResult{})
+}
+
 type blockDeviceMappingSuite struct {
 	testing.BaseSuite
 }
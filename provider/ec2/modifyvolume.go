@@ -0,0 +1,75 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/ec2"
+)
+
+// modifyVolumeAPIVersion is the version of the EC2 API that
+// introduced the ModifyVolume action.
+const modifyVolumeAPIVersion = "2016-11-15"
+
+// modifyVolume changes the size of the EBS volume with the specified
+// ID to the specified number of GiB. The EC2 client does not support
+// the ModifyVolume action, so the request is made directly, signed
+// with the client's credentials.
+func modifyVolume(client *ec2.EC2, volumeId string, sizeGiB int) error {
+	endpoint, err := url.Parse(client.Region.EC2Endpoint)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if endpoint.Path == "" {
+		endpoint.Path = "/"
+	}
+	endpoint.RawQuery = url.Values{
+		"Action":   {"ModifyVolume"},
+		"Version":  {modifyVolumeAPIVersion},
+		"VolumeId": {volumeId},
+		"Size":     {strconv.Itoa(sizeGiB)},
+	}.Encode()
+
+	req, err := http.NewRequest("GET", endpoint.String(), nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sign := aws.SignV4Factory(client.Region.Name, "ec2")
+	if err := sign(req, client.Auth); err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	// Errors are reported in the same form as for
+	// the actions that the EC2 client does support.
+	var errorsResp struct {
+		RequestId string      `xml:"RequestID"`
+		Errors    []ec2.Error `xml:"Errors>Error"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&errorsResp); err != nil || len(errorsResp.Errors) == 0 {
+		return &ec2.Error{
+			StatusCode: resp.StatusCode,
+			Message:    resp.Status,
+		}
+	}
+	ec2Err := errorsResp.Errors[0]
+	ec2Err.StatusCode = resp.StatusCode
+	if ec2Err.RequestId == "" {
+		ec2Err.RequestId = errorsResp.RequestId
+	}
+	return &ec2Err
+}
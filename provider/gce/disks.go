@@ -40,6 +40,7 @@ type storageProvider struct {
 }
 
 var _ storage.Provider = (*storageProvider)(nil)
+var _ storage.VolumeResizer = (*volumeSource)(nil)
 
 func (g *storageProvider) ValidateConfig(cfg *storage.Config) error {
 	return nil
@@ -389,6 +390,34 @@ func (v *volumeSource) ImportVolume(ctx context.ProviderCallContext, volName str
 	}, nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *volumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeOneVolume(ctx, p)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (v *volumeSource) resizeOneVolume(ctx context.ProviderCallContext, p storage.VolumeResizeParams) (uint64, error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return 0, errors.Annotatef(err, "invalid volume id %q", p.VolumeId)
+	}
+	// GCE disks are sized in whole GiB, so the disk
+	// may end up larger than requested.
+	sizeGB := mibToGib(p.Size)
+	if err := v.gce.ResizeDisk(zone, p.VolumeId, sizeGB); err != nil {
+		return 0, google.HandleCredentialError(errors.Annotatef(err, "cannot resize volume %q", p.VolumeId), ctx)
+	}
+	return sizeGB * 1024, nil
+}
+
 func (v *volumeSource) DescribeVolumes(ctx context.ProviderCallContext, volNames []string) ([]storage.DescribeVolumesResult, error) {
 	results := make([]storage.DescribeVolumesResult, len(volNames))
 	for i, vol := range volNames {
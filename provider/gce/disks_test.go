@@ -266,6 +266,30 @@ func (s *volumeSourceSuite) TestImportVolume(c *gc.C) {
 	})
 }
 
+func (s *volumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	c.Assert(s.source, gc.Implements, new(storage.VolumeResizer))
+	results, err := s.source.(storage.VolumeResizer).ResizeVolumes(s.CallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: s.BaseDisk.Name,
+		Size:     2000,
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "invalid",
+		Size:     2000,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeVolumesResult{Size: 2048})
+	c.Assert(results[1].Error, gc.ErrorMatches, `invalid volume id "invalid": malformed volume id "invalid"`)
+
+	called, calls := s.FakeConn.WasCalled("ResizeDisk")
+	c.Check(called, jc.IsTrue)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Assert(calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(calls[0].ID, gc.Equals, s.BaseDisk.Name)
+	c.Assert(calls[0].SizeGB, gc.Equals, uint64(2))
+}
+
 func (s *volumeSourceSuite) TestImportVolumeNotReady(c *gc.C) {
 	s.FakeConn.GoogleDisk = s.BaseDisk
 	s.FakeConn.GoogleDisk.Status = "floop"
@@ -170,6 +170,7 @@ type fakeCall struct {
 	Metadata         *compute.Metadata
 	LabelFingerprint string
 	Labels           map[string]string
+	SizeGB           int64
 }
 
 type fakeConn struct {
@@ -424,6 +425,23 @@ func (rc *fakeConn) SetDiskLabels(project, zone, id, labelFingerprint string, la
 	return err
 }
 
+func (rc *fakeConn) ResizeDisk(project, zone, id string, sizeGB int64) error {
+	call := fakeCall{
+		FuncName:  "ResizeDisk",
+		ProjectID: project,
+		ZoneName:  zone,
+		ID:        id,
+		SizeGB:    sizeGB,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
 func (rc *fakeConn) AttachDisk(project, zone, instanceId string, attachedDisk *compute.AttachedDisk) error {
 	call := fakeCall{
 		FuncName:     "AttachDisk",
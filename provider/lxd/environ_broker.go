@@ -244,7 +244,9 @@ func (env *environ) getContainerSpec(
 
 // getTargetServer checks to see if a valid zone was passed as a placement
 // directive in the start-up start-up arguments. If so, a server for the
-// specific node is returned.
+// specific node is returned. Otherwise if the provisioner selected an
+// availability zone, for example to spread the machines in a distribution
+// group across cluster members, a server for that node is returned.
 func (env *environ) getTargetServer(
 	ctx context.ProviderCallContext, args environs.StartInstanceParams,
 ) (Server, error) {
@@ -253,10 +255,14 @@ func (env *environ) getTargetServer(
 		return nil, errors.Trace(err)
 	}
 
-	if p.nodeName == "" {
+	nodeName := p.nodeName
+	if nodeName == "" && args.AvailabilityZone != "" && env.server().IsClustered() {
+		nodeName = args.AvailabilityZone
+	}
+	if nodeName == "" {
 		return env.server(), nil
 	}
-	return env.server().UseTargetServer(p.nodeName)
+	return env.server().UseTargetServer(nodeName)
 }
 
 type lxdPlacement struct {
@@ -304,11 +310,17 @@ func (env *environ) getHardwareCharacteristics(
 	}
 	cores := uint64(container.CPUs())
 	mem := uint64(container.Mem())
-	return &instance.HardwareCharacteristics{
+	hwc := &instance.HardwareCharacteristics{
 		Arch:     &archStr,
 		CpuCores: &cores,
 		Mem:      &mem,
 	}
+	// Record the cluster member the container was created on as its
+	// availability zone. Standalone servers report a location of "none".
+	if location := container.Location; location != "" && location != "none" {
+		hwc.AvailabilityZone = &location
+	}
+	return hwc
 }
 
 // AllInstances implements environs.InstanceBroker.
@@ -190,15 +190,67 @@ func (s *environBrokerSuite) TestStartInstanceWithPlacementAvailable(c *gc.C) {
 	// we don't bother with detailed parameter assertions here.
 	tExp.CreateContainerFromImage(gomock.Any(), gomock.Any(), gomock.Any()).Return(createOp, nil)
 	tExp.UpdateContainerState(gomock.Any(), gomock.Any(), "").Return(startOp, nil)
-	tExp.GetContainer(gomock.Any()).Return(&api.Container{}, lxdtesting.ETag, nil)
+	tExp.GetContainer(gomock.Any()).Return(&api.Container{Location: "node01"}, lxdtesting.ETag, nil)
 
 	env := s.NewEnviron(c, svr, nil)
 
 	args := s.GetStartInstanceArgs(c, "bionic")
 	args.Placement = "zone=node01"
 
-	_, err = env.StartInstance(s.callCtx, args)
+	result, err := env.StartInstance(s.callCtx, args)
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Hardware.AvailabilityZone, gc.NotNil)
+	c.Check(*result.Hardware.AvailabilityZone, gc.Equals, "node01")
+}
+
+func (s *environBrokerSuite) TestStartInstanceWithAvailabilityZone(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	target := lxdtesting.NewMockContainerServer(ctrl)
+	tExp := target.EXPECT()
+	serverRet := &api.Server{}
+	image := &api.Image{Filename: "container-image"}
+
+	tExp.GetServer().Return(serverRet, lxdtesting.ETag, nil)
+	tExp.GetImageAlias("juju/bionic/amd64").Return(&api.ImageAliasesEntry{}, lxdtesting.ETag, nil)
+	tExp.GetImage("").Return(image, lxdtesting.ETag, nil)
+
+	jujuTarget, err := containerlxd.NewServer(target)
+	c.Assert(err, jc.ErrorIsNil)
+
+	createOp := lxdtesting.NewMockRemoteOperation(ctrl)
+	createOp.EXPECT().Wait().Return(nil)
+	createOp.EXPECT().GetTarget().Return(&api.Operation{StatusCode: api.Success}, nil)
+
+	startOp := lxdtesting.NewMockOperation(ctrl)
+	startOp.EXPECT().Wait().Return(nil)
+
+	// With no placement directive, the zone chosen by the
+	// provisioner is used to target the cluster member.
+	sExp := svr.EXPECT()
+	gomock.InOrder(
+		sExp.HostArch().Return(arch.AMD64),
+		sExp.IsClustered().Return(true),
+		sExp.UseTargetServer("node02").Return(jujuTarget, nil),
+		sExp.GetNICsFromProfile("default").Return(s.defaultProfile.Devices, nil),
+		sExp.HostArch().Return(arch.AMD64),
+	)
+
+	tExp.CreateContainerFromImage(gomock.Any(), gomock.Any(), gomock.Any()).Return(createOp, nil)
+	tExp.UpdateContainerState(gomock.Any(), gomock.Any(), "").Return(startOp, nil)
+	tExp.GetContainer(gomock.Any()).Return(&api.Container{Location: "node02"}, lxdtesting.ETag, nil)
+
+	env := s.NewEnviron(c, svr, nil)
+
+	args := s.GetStartInstanceArgs(c, "bionic")
+	args.AvailabilityZone = "node02"
+
+	result, err := env.StartInstance(s.callCtx, args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Hardware.AvailabilityZone, gc.NotNil)
+	c.Check(*result.Hardware.AvailabilityZone, gc.Equals, "node02")
 }
 
 func (s *environBrokerSuite) TestStartInstanceWithPlacementNotPresent(c *gc.C) {
@@ -104,7 +104,7 @@ func (e *manualEnviron) Create(context.ProviderCallContext, environs.CreateParam
 
 // Bootstrap is part of the Environ interface.
 func (e *manualEnviron) Bootstrap(ctx environs.BootstrapContext, callCtx context.ProviderCallContext, args environs.BootstrapParams) (*environs.BootstrapResult, error) {
-	provisioned, err := sshprovisioner.CheckProvisioned(e.host)
+	provisioned, err := sshprovisioner.CheckProvisioned(e.host, "")
 	if err != nil {
 		return nil, errors.Annotate(err, "failed to check provisioned status")
 	}
@@ -352,7 +352,7 @@ func (e *manualEnviron) seriesAndHardwareCharacteristics() (_ *instance.Hardware
 	if e.hw != nil {
 		return e.hw, e.series, nil
 	}
-	hw, series, err := sshprovisioner.DetectSeriesAndHardwareCharacteristics(e.host)
+	hw, series, err := sshprovisioner.DetectSeriesAndHardwareCharacteristics(e.host, "")
 	if err != nil {
 		return nil, "", errors.Trace(err)
 	}
@@ -4,9 +4,16 @@
 package openstack
 
 import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"math"
+	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -38,6 +45,13 @@ const (
 	volumeStatusDeleting  = "deleting"
 	volumeStatusError     = "error"
 	volumeStatusInUse     = "in-use"
+
+	volumeStatusExtending      = "extending"
+	volumeStatusErrorExtending = "error_extending"
+
+	// cinderTimeLayout is the layout of timestamps returned by
+	// the Cinder API, which omit the time zone.
+	cinderTimeLayout = "2006-01-02T15:04:05.000000"
 )
 
 var cinderConfigFields = schema.Fields{
@@ -117,17 +131,12 @@ var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
 		logger.Debugf("volume URL: %v", url)
 	}
 
-	cinderCl := cinderClient{cinder.Basic(env.volumeURL, client.TenantId(), client.Token)}
-
+	var cinderTLSConfig *tls.Config
 	cloudSpec := env.cloudUnlocked
 	if len(cloudSpec.CACertificates) > 0 {
-		cinderCl = cinderClient{cinder.BasicTLSConfig(
-			env.volumeURL,
-			client.TenantId(),
-			client.Token,
-			tlsConfig(cloudSpec.CACertificates)),
-		}
+		cinderTLSConfig = tlsConfig(cloudSpec.CACertificates)
 	}
+	cinderCl := newCinderClient(env.volumeURL, client.TenantId(), client.Token, cinderTLSConfig)
 
 	return &openstackStorageAdapter{
 		cinderCl,
@@ -212,7 +221,11 @@ type cinderVolumeSource struct {
 	namespace      instance.Namespace
 }
 
-var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var (
+	_ storage.VolumeSource      = (*cinderVolumeSource)(nil)
+	_ storage.VolumeSnapshotter = (*cinderVolumeSource)(nil)
+	_ storage.VolumeResizer     = (*cinderVolumeSource)(nil)
+)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -251,6 +264,7 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Vo
 		// TODO(axw) use the AZ of the initially attached machine.
 		AvailabilityZone: "",
 		Metadata:         metadata,
+		SnapshotId:       arg.SnapshotId,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -532,6 +546,113 @@ func (s *cinderVolumeSource) ImportVolume(ctx context.ProviderCallContext, volum
 	return cinderToJujuVolumeInfo(volume), nil
 }
 
+// CreateVolumeSnapshots is part of the storage.VolumeSnapshotter interface.
+func (s *cinderVolumeSource) CreateVolumeSnapshots(ctx context.ProviderCallContext, args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := s.storageAdapter.CreateSnapshot(cinder.CreateSnapshotSnapshotParams{
+			VolumeId: arg.VolumeId,
+			Name:     resourceName(s.namespace, s.envName, arg.Volume.String()),
+			// Volumes are normally attached while the snapshot
+			// is taken, so the snapshot is crash-consistent.
+			Force: true,
+		})
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "snapshotting volume %q", arg.VolumeId)
+			if denied := common.MaybeHandleCredentialError(IsAuthorisationFailure, err, ctx); denied {
+				break
+			}
+			continue
+		}
+		results[i].Snapshot = cinderToJujuVolumeSnapshot(snapshot)
+	}
+	return results, nil
+}
+
+// ListVolumeSnapshots is part of the storage.VolumeSnapshotter interface.
+func (s *cinderVolumeSource) ListVolumeSnapshots(ctx context.ProviderCallContext, volumeIds []string) ([]storage.ListVolumeSnapshotsResult, error) {
+	// As with DescribeVolumes, get all snapshots and filter locally.
+	snapshots, err := s.storageAdapter.GetSnapshotsDetail()
+	if err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return nil, errors.Trace(err)
+	}
+	byVolumeId := make(map[string][]storage.VolumeSnapshot)
+	for i, snapshot := range snapshots {
+		byVolumeId[snapshot.VolumeID] = append(
+			byVolumeId[snapshot.VolumeID], *cinderToJujuVolumeSnapshot(&snapshots[i]),
+		)
+	}
+	results := make([]storage.ListVolumeSnapshotsResult, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		results[i].Snapshots = byVolumeId[volumeId]
+	}
+	return results, nil
+}
+
+// DeleteVolumeSnapshots is part of the storage.VolumeSnapshotter interface.
+func (s *cinderVolumeSource) DeleteVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		err := s.storageAdapter.DeleteSnapshot(snapshotId)
+		if err != nil && !errors.IsNotFound(err) {
+			common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+			results[i] = errors.Annotatef(err, "deleting snapshot %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+// RestoreVolumeSnapshots is part of the storage.VolumeSnapshotter interface.
+func (s *cinderVolumeSource) RestoreVolumeSnapshots(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	for _, arg := range args {
+		if arg.SnapshotId == "" {
+			return nil, errors.Errorf("no snapshot specified for volume %q", arg.Tag.Id())
+		}
+	}
+	// The snapshot ID is passed through to Cinder when creating
+	// the volume, so restoring is otherwise the same as creating.
+	return s.CreateVolumes(ctx, args)
+}
+
+// ResizeVolumes is part of the storage.VolumeResizer interface.
+func (s *cinderVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := s.resizeVolume(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %q", p.VolumeId)
+			if denied := common.MaybeHandleCredentialError(IsAuthorisationFailure, err, ctx); denied {
+				break
+			}
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (s *cinderVolumeSource) resizeVolume(p storage.VolumeResizeParams) (uint64, error) {
+	// Cinder volumes are sized in whole GiB, so the volume
+	// may end up larger than requested.
+	sizeGiB := int(math.Ceil(float64(p.Size) / 1024))
+	if err := s.storageAdapter.ExtendVolume(p.VolumeId, sizeGiB); err != nil {
+		return 0, err
+	}
+	// The volume is extended asynchronously.
+	volume, err := waitVolume(s.storageAdapter, p.VolumeId, func(v *cinder.Volume) (bool, error) {
+		switch v.Status {
+		case volumeStatusError, volumeStatusErrorExtending:
+			return false, errors.Errorf("volume %q has status %q", v.ID, v.Status)
+		}
+		return v.Size >= sizeGiB && v.Status != volumeStatusExtending, nil
+	})
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return uint64(volume.Size * 1024), nil
+}
+
 func waitVolume(
 	storageAdapter OpenstackStorage,
 	volumeId string,
@@ -593,6 +714,17 @@ func cinderToJujuVolumeInfo(volume *cinder.Volume) storage.VolumeInfo {
 	}
 }
 
+func cinderToJujuVolumeSnapshot(snapshot *cinder.Snapshot) *storage.VolumeSnapshot {
+	created, _ := time.Parse(cinderTimeLayout, snapshot.CreatedAt)
+	return &storage.VolumeSnapshot{
+		SnapshotId: snapshot.ID,
+		VolumeId:   snapshot.VolumeID,
+		Size:       uint64(snapshot.Size * 1024),
+		Created:    created,
+		Status:     snapshot.Status,
+	}
+}
+
 func detachVolume(instanceId, volumeId string, storageAdapter OpenstackStorage) error {
 	err := storageAdapter.DetachVolume(instanceId, volumeId)
 	if err != nil && !errors.IsNotFound(err) {
@@ -621,6 +753,10 @@ type OpenstackStorage interface {
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
 	SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error)
+	CreateSnapshot(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	GetSnapshotsDetail() ([]cinder.Snapshot, error)
+	DeleteSnapshot(snapshotId string) error
+	ExtendVolume(volumeId string, newSize int) error
 }
 
 type endpointResolver interface {
@@ -655,6 +791,64 @@ type openstackStorageAdapter struct {
 
 type cinderClient struct {
 	*cinder.Client
+
+	// endpoint, token and httpClient are used to make the
+	// volume action requests that cinder.Client does not support.
+	endpoint   *url.URL
+	token      func() string
+	httpClient *http.Client
+}
+
+func newCinderClient(endpoint *url.URL, tenantId string, token func() string, tlsConfig *tls.Config) cinderClient {
+	if tlsConfig == nil {
+		return cinderClient{
+			Client:     cinder.Basic(endpoint, tenantId, token),
+			endpoint:   endpoint,
+			token:      token,
+			httpClient: http.DefaultClient,
+		}
+	}
+	return cinderClient{
+		Client:     cinder.BasicTLSConfig(endpoint, tenantId, token, tlsConfig),
+		endpoint:   endpoint,
+		token:      token,
+		httpClient: &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}},
+	}
+}
+
+// extendVolume extends the volume with the specified ID to the new
+// size in GiB, using the Block Storage API's os-extend volume action.
+func (c cinderClient) extendVolume(volumeId string, newSize int) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"os-extend": map[string]int{"new_size": newSize},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	actionURL := strings.TrimSuffix(c.endpoint.String(), "/") + "/volumes/" + url.PathEscape(volumeId) + "/action"
+	req, err := http.NewRequest("POST", actionURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Auth-Token", c.token())
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted:
+		return nil
+	case http.StatusNotFound:
+		return errors.NotFoundf("volume %q", volumeId)
+	case http.StatusUnauthorized:
+		return gooseerrors.NewUnauthorisedf(nil, "", "extending volume %q: %s", volumeId, resp.Status)
+	}
+	message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return errors.Errorf("extending volume %q: %s: %s", volumeId, resp.Status, strings.TrimSpace(string(message)))
 }
 
 type novaClient struct {
@@ -707,6 +901,40 @@ func (ga *openstackStorageAdapter) DeleteVolume(volumeId string) error {
 	return nil
 }
 
+// CreateSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.CreateSnapshot(args)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
+// GetSnapshotsDetail is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.GetSnapshotsDetail()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Snapshots, nil
+}
+
+// DeleteSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) DeleteSnapshot(snapshotId string) error {
+	if err := ga.cinderClient.DeleteSnapshot(snapshotId); err != nil {
+		if gooseerrors.IsNotFound(err) {
+			return errors.NotFoundf("snapshot %q", snapshotId)
+		}
+		return err
+	}
+	return nil
+}
+
+// ExtendVolume is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) ExtendVolume(volumeId string, newSize int) error {
+	return ga.cinderClient.extendVolume(volumeId, newSize)
+}
+
 // DetachVolume is part of the OpenstackStorage interface.
 func (ga *openstackStorageAdapter) DetachVolume(serverId, attachmentId string) error {
 	if err := ga.novaClient.DetachVolume(serverId, attachmentId); err != nil {
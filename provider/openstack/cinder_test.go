@@ -4,7 +4,11 @@
 package openstack_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"time"
 
 	"github.com/juju/errors"
@@ -715,6 +719,231 @@ func (s *cinderVolumeSourceSuite) TestImportVolume(c *gc.C) {
 	})
 }
 
+func (s *cinderVolumeSourceSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+			c.Assert(args, jc.DeepEquals, cinder.CreateSnapshotSnapshotParams{
+				VolumeId: mockVolId,
+				Name:     "juju-testmodel-volume-123",
+				Force:    true,
+			})
+			return &cinder.Snapshot{
+				ID:        "snap-0",
+				VolumeID:  mockVolId,
+				Size:      mockVolSize / 1024,
+				Status:    "creating",
+				CreatedAt: "2019-05-01T10:20:30.000000",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	c.Assert(volSource, gc.Implements, new(storage.VolumeSnapshotter))
+	results, err := volSource.(storage.VolumeSnapshotter).CreateVolumeSnapshots(s.callCtx, []storage.VolumeSnapshotParams{{
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateVolumeSnapshotsResult{{
+		Snapshot: &storage.VolumeSnapshot{
+			SnapshotId: "snap-0",
+			VolumeId:   mockVolId,
+			Size:       mockVolSize,
+			Created:    time.Date(2019, 5, 1, 10, 20, 30, 0, time.UTC),
+			Status:     "creating",
+		},
+	}})
+}
+
+func (s *cinderVolumeSourceSuite) TestListVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getSnapshotsDetail: func() ([]cinder.Snapshot, error) {
+			return []cinder.Snapshot{
+				{ID: "snap-0", VolumeID: mockVolId, Size: 1, Status: "available"},
+				{ID: "snap-1", VolumeID: "other", Size: 1, Status: "available"},
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeSnapshotter).ListVolumeSnapshots(s.callCtx, []string{mockVolId, "unknown"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ListVolumeSnapshotsResult{{
+		Snapshots: []storage.VolumeSnapshot{{
+			SnapshotId: "snap-0",
+			VolumeId:   mockVolId,
+			Size:       1024,
+			Status:     "available",
+		}},
+	}, {}})
+}
+
+func (s *cinderVolumeSourceSuite) TestDeleteVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		deleteSnapshot: func(snapshotId string) error {
+			if snapshotId == "snap-1" {
+				return errors.NotFoundf("snapshot %q", snapshotId)
+			}
+			if snapshotId == "snap-2" {
+				return errors.New("boom")
+			}
+			return nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	errs, err := volSource.(storage.VolumeSnapshotter).DeleteVolumeSnapshots(s.callCtx, []string{"snap-0", "snap-1", "snap-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `deleting snapshot "snap-2": boom`)
+}
+
+func (s *cinderVolumeSourceSuite) TestRestoreVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createVolume: func(args cinder.CreateVolumeVolumeParams) (*cinder.Volume, error) {
+			c.Assert(args, jc.DeepEquals, cinder.CreateVolumeVolumeParams{
+				Size:       1,
+				Name:       "juju-testmodel-volume-123",
+				SnapshotId: "snap-0",
+			})
+			return &cinder.Volume{ID: mockVolId}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeSnapshotter).RestoreVolumeSnapshots(s.callCtx, []storage.VolumeParams{{
+		Provider:   openstack.CinderProviderType,
+		Tag:        mockVolumeTag,
+		Size:       1024,
+		SnapshotId: "snap-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.VolumeId, gc.Equals, mockVolId)
+}
+
+func (s *cinderVolumeSourceSuite) TestRestoreVolumeSnapshotsNoSnapshot(c *gc.C) {
+	volSource := openstack.NewCinderVolumeSource(&mockAdapter{})
+	_, err := volSource.(storage.VolumeSnapshotter).RestoreVolumeSnapshots(s.callCtx, []storage.VolumeParams{{
+		Provider: openstack.CinderProviderType,
+		Tag:      mockVolumeTag,
+		Size:     1024,
+	}})
+	c.Assert(err, gc.ErrorMatches, `no snapshot specified for volume "123"`)
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   2,
+				Status: "in-use",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Tag:      mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     1536,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 2048}})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ExtendVolume", []interface{}{mockVolId, 2}},
+		{"GetVolume", []interface{}{mockVolId}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesError(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   1,
+				Status: "error_extending",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Tag:      mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `resizing volume "0": volume "0" has status "error_extending"`)
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesInvalidCredential(c *gc.C) {
+	c.Assert(s.invalidCredential, jc.IsFalse)
+	mockAdapter := &mockAdapter{
+		extendVolume: func(string, int) error {
+			return testUnauthorisedGooseError
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Tag:      mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `resizing volume "0": invalid auth`)
+	c.Assert(s.invalidCredential, jc.IsTrue)
+}
+
+func (s *cinderVolumeSourceSuite) TestStorageAdapterExtendVolume(c *gc.C) {
+	var (
+		method, path, token string
+		body                map[string]interface{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		method, path = req.Method, req.URL.Path
+		token = req.Header.Get("X-Auth-Token")
+		c.Check(json.NewDecoder(req.Body).Decode(&body), jc.ErrorIsNil)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+	endpoint, err := url.Parse(srv.URL + "/v2/tenant")
+	c.Assert(err, jc.ErrorIsNil)
+
+	adapter := openstack.NewCinderStorageAdapter(endpoint, func() string { return "token" })
+	err = adapter.ExtendVolume(mockVolId, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(method, gc.Equals, "POST")
+	c.Assert(path, gc.Equals, "/v2/tenant/volumes/0/action")
+	c.Assert(token, gc.Equals, "token")
+	c.Assert(body, jc.DeepEquals, map[string]interface{}{
+		"os-extend": map[string]interface{}{"new_size": float64(2)},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestStorageAdapterExtendVolumeErrors(c *gc.C) {
+	var status int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "computer says no", status)
+	}))
+	defer srv.Close()
+	endpoint, err := url.Parse(srv.URL + "/v2/tenant")
+	c.Assert(err, jc.ErrorIsNil)
+	adapter := openstack.NewCinderStorageAdapter(endpoint, func() string { return "token" })
+
+	status = http.StatusNotFound
+	err = adapter.ExtendVolume(mockVolId, 2)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	status = http.StatusUnauthorized
+	err = adapter.ExtendVolume(mockVolId, 2)
+	c.Assert(err, jc.Satisfies, openstack.IsAuthorisationFailure)
+
+	status = http.StatusBadRequest
+	err = adapter.ExtendVolume(mockVolId, 2)
+	c.Assert(err, gc.ErrorMatches, `extending volume "0": 400 Bad Request: computer says no`)
+}
+
 func (s *cinderVolumeSourceSuite) TestImportVolumeInUse(c *gc.C) {
 	mockAdapter := &mockAdapter{
 		getVolume: func(volumeId string) (*cinder.Volume, error) {
@@ -759,6 +988,10 @@ type mockAdapter struct {
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
 	setVolumeMetadata     func(string, map[string]string) (map[string]string, error)
+	createSnapshot        func(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	getSnapshotsDetail    func() ([]cinder.Snapshot, error)
+	deleteSnapshot        func(string) error
+	extendVolume          func(string, int) error
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -828,6 +1061,38 @@ func (ma *mockAdapter) SetVolumeMetadata(volumeId string, metadata map[string]st
 	return nil, nil
 }
 
+func (ma *mockAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	ma.MethodCall(ma, "CreateSnapshot", args)
+	if ma.createSnapshot != nil {
+		return ma.createSnapshot(args)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshot")
+}
+
+func (ma *mockAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	ma.MethodCall(ma, "GetSnapshotsDetail")
+	if ma.getSnapshotsDetail != nil {
+		return ma.getSnapshotsDetail()
+	}
+	return nil, nil
+}
+
+func (ma *mockAdapter) DeleteSnapshot(snapshotId string) error {
+	ma.MethodCall(ma, "DeleteSnapshot", snapshotId)
+	if ma.deleteSnapshot != nil {
+		return ma.deleteSnapshot(snapshotId)
+	}
+	return nil
+}
+
+func (ma *mockAdapter) ExtendVolume(volumeId string, newSize int) error {
+	ma.MethodCall(ma, "ExtendVolume", volumeId, newSize)
+	if ma.extendVolume != nil {
+		return ma.extendVolume(volumeId, newSize)
+	}
+	return nil
+}
+
 type testEndpointResolver struct {
 	authenticated   bool
 	regionEndpoints map[string]identity.ServiceURLs
@@ -5,6 +5,7 @@ package openstack
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 
 	"gopkg.in/goose.v2/neutron"
@@ -54,6 +55,14 @@ func NewCinderVolumeSourceForModel(s OpenstackStorage, modelUUID string) storage
 	}
 }
 
+// NewCinderStorageAdapter returns an OpenstackStorage that sends
+// Cinder requests to the specified endpoint.
+func NewCinderStorageAdapter(endpoint *url.URL, token func() string) OpenstackStorage {
+	return &openstackStorageAdapter{
+		cinderClient: newCinderClient(endpoint, "tenant", token, nil),
+	}
+}
+
 type fakeNamespace struct {
 	instance.Namespace
 }
@@ -104,6 +104,19 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Logs holds the progress messages logged by the action.
+	Logs []ActionMessage `bson:"messages"`
+
+	// MessageCount is the number of progress messages ever logged by
+	// the action, including those discarded from Logs.
+	MessageCount int `bson:"message-count"`
+}
+
+// ActionMessage represents a progress message logged by an action.
+type ActionMessage struct {
+	Timestamp time.Time `bson:"timestamp" json:"timestamp"`
+	Message   string    `bson:"message" json:"message"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -162,6 +175,11 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Messages returns the progress messages logged by the action.
+func (a *action) Messages() []ActionMessage {
+	return a.doc.Logs
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -210,6 +228,38 @@ func (a *action) Begin() (Action, error) {
 	return m.Action(a.Id())
 }
 
+// maxActionMessages is the number of progress messages kept for each
+// action; older messages are discarded as new ones are logged.
+var maxActionMessages = 1000
+
+// Log adds a timestamped progress message to the action. Only running
+// actions may log messages.
+func (a *action) Log(message string) error {
+	m, err := a.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = m.st.db().RunTransaction([]txn.Op{{
+		C:      actionsC,
+		Id:     a.doc.DocId,
+		Assert: bson.D{{"status", ActionRunning}},
+		Update: bson.D{
+			{"$push", bson.D{{"messages", bson.D{
+				{"$each", []ActionMessage{{
+					Timestamp: a.st.nowToTheSecond(),
+					Message:   message,
+				}}},
+				{"$slice", -maxActionMessages},
+			}}}},
+			{"$inc", bson.D{{"message-count", 1}}},
+		},
+	}})
+	if err == txn.ErrAborted {
+		return errors.Errorf("cannot log message to action %q: action is not running", a.Id())
+	}
+	return errors.Annotatef(err, "cannot log message to action %q", a.Id())
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *action) Finish(results ActionResults) (Action, error) {
@@ -5,6 +5,7 @@ package state_test
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -484,6 +485,145 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestLog(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("one")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("two")
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err := s.model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message, gc.Equals, "one")
+	c.Assert(messages[0].Timestamp.IsZero(), jc.IsFalse)
+	c.Assert(messages[1].Message, gc.Equals, "two")
+}
+
+func (s *ActionSuite) TestLogKeepsLatestMessages(c *gc.C) {
+	s.PatchValue(state.MaxActionMessages, 2)
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, message := range []string{"one", "two", "three"} {
+		err = a.Log(message)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	action, err := s.model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message, gc.Equals, "two")
+	c.Assert(messages[1].Message, gc.Equals, "three")
+}
+
+func (s *ActionSuite) TestLogNotRunning(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("pending")
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(`cannot log message to action %q: action is not running`, a.Id()))
+
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("completed")
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(`cannot log message to action %q: action is not running`, a.Id()))
+	c.Assert(a.Messages(), gc.HasLen, 0)
+}
+
+func (s *ActionSuite) TestWatchActionLogs(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("first")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.model.WatchActionLogs(a.Id())
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+
+	s.State.StartSync()
+	assertActionLogsChange(c, w, "first")
+	wc.AssertNoChange()
+
+	err = a.Log("second")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("third")
+	c.Assert(err, jc.ErrorIsNil)
+	s.State.StartSync()
+	assertActionLogsChange(c, w, "second", "third")
+	wc.AssertNoChange()
+
+	// Finishing the action does not produce any more messages.
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *ActionSuite) TestWatchActionLogsBeyondMaxMessages(c *gc.C) {
+	s.PatchValue(state.MaxActionMessages, 2)
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.model.WatchActionLogs(a.Id())
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+
+	// Messages keep being reported once the action has logged more
+	// than are kept.
+	for _, message := range []string{"one", "two", "three", "four"} {
+		err = a.Log(message)
+		c.Assert(err, jc.ErrorIsNil)
+		s.State.StartSync()
+		assertActionLogsChange(c, w, message)
+		wc.AssertNoChange()
+	}
+	err = a.Log("five")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("six")
+	c.Assert(err, jc.ErrorIsNil)
+	s.State.StartSync()
+	assertActionLogsChange(c, w, "five", "six")
+	wc.AssertNoChange()
+}
+
+// assertActionLogsChange asserts that the action logs watcher reports
+// the expected messages. Messages logged in separate transactions may
+// be reported in separate events.
+func assertActionLogsChange(c *gc.C, w state.StringsWatcher, expect ...string) {
+	var received []string
+	for len(received) < len(expect) {
+		select {
+		case changes, ok := <-w.Changes():
+			c.Assert(ok, jc.IsTrue)
+			for _, m := range changes {
+				var msg state.ActionMessage
+				err := json.Unmarshal([]byte(m), &msg)
+				c.Assert(err, jc.ErrorIsNil)
+				c.Assert(msg.Timestamp.IsZero(), jc.IsFalse)
+				received = append(received, msg.Message)
+			}
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("watcher did not send change")
+		}
+	}
+	c.Assert(received, jc.DeepEquals, expect)
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
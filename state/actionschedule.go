@@ -0,0 +1,338 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// maxActionScheduleHistory is the number of runs recorded in the
+// history of each action schedule.
+const maxActionScheduleHistory = 20
+
+// actionScheduleDoc records an action that is to be enqueued on a unit
+// repeatedly, according to a cron schedule.
+type actionScheduleDoc struct {
+	DocId      string                 `bson:"_id"`
+	ModelUUID  string                 `bson:"model-uuid"`
+	Receiver   string                 `bson:"receiver"`
+	Name       string                 `bson:"name"`
+	Parameters map[string]interface{} `bson:"parameters"`
+	Cron       string                 `bson:"cron"`
+	Created    time.Time              `bson:"created"`
+	NextRun    time.Time              `bson:"next-run"`
+	History    []actionScheduleRunDoc `bson:"history"`
+}
+
+// actionScheduleRunDoc records one run of an action schedule.
+type actionScheduleRunDoc struct {
+	Time     time.Time `bson:"time"`
+	ActionId string    `bson:"action-id,omitempty"`
+	Error    string    `bson:"error,omitempty"`
+}
+
+// ActionScheduleRun records the outcome of enqueueing a scheduled
+// action.
+type ActionScheduleRun struct {
+	// Time is when the action was due to be enqueued.
+	Time time.Time
+
+	// ActionId is the id of the action that was enqueued, if any.
+	ActionId string
+
+	// Error describes why the action could not be enqueued, if it
+	// could not.
+	Error string
+}
+
+// ActionSchedule represents an action that is enqueued on a unit
+// repeatedly, according to a cron schedule.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+// Id returns the id of the schedule.
+func (s *ActionSchedule) Id() string {
+	return s.st.localID(s.doc.DocId)
+}
+
+// Receiver returns the name of the unit on which the action is
+// enqueued.
+func (s *ActionSchedule) Receiver() string {
+	return s.doc.Receiver
+}
+
+// Name returns the name of the scheduled action.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Parameters returns the parameters the action is enqueued with.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Cron returns the cron specification of the schedule.
+func (s *ActionSchedule) Cron() string {
+	return s.doc.Cron
+}
+
+// Created returns when the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return s.doc.Created
+}
+
+// NextRun returns when the action is next due to be enqueued.
+func (s *ActionSchedule) NextRun() time.Time {
+	return s.doc.NextRun
+}
+
+// History returns the most recent runs of the schedule, oldest first.
+func (s *ActionSchedule) History() []ActionScheduleRun {
+	runs := make([]ActionScheduleRun, len(s.doc.History))
+	for i, doc := range s.doc.History {
+		runs[i] = ActionScheduleRun{
+			Time:     doc.Time,
+			ActionId: doc.ActionId,
+			Error:    doc.Error,
+		}
+	}
+	return runs
+}
+
+// run enqueues the scheduled action, if it is due, and records the
+// outcome in the schedule's history. The schedule is advanced to its
+// next run before the action is enqueued, so that the action is not
+// enqueued twice for the same run.
+func (s *ActionSchedule) run(now time.Time) error {
+	if s.doc.NextRun.After(now) {
+		return nil
+	}
+	schedule, err := actions.ParseSchedule(s.doc.Cron)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	due := s.doc.NextRun
+	next := schedule.Next(now).UTC()
+	err = s.st.db().RunTransaction([]txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: bson.D{{"next-run", due}},
+		Update: bson.D{{"$set", bson.D{{"next-run", next}}}},
+	}})
+	if err == txn.ErrAborted {
+		// The schedule has been run, or removed, by someone else.
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "advancing action schedule %q", s.Id())
+	}
+	s.doc.NextRun = next
+
+	run := actionScheduleRunDoc{Time: due}
+	if action, err := s.enqueue(); err != nil {
+		run.Error = err.Error()
+	} else {
+		run.ActionId = action.Id()
+	}
+	err = s.st.db().RunTransaction([]txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$push", bson.D{{"history", bson.D{
+			{"$each", []actionScheduleRunDoc{run}},
+			{"$slice", -maxActionScheduleHistory},
+		}}}}},
+	}})
+	if err != nil && err != txn.ErrAborted {
+		return errors.Annotatef(err, "recording run of action schedule %q", s.Id())
+	}
+	return nil
+}
+
+// enqueue adds the scheduled action to the unit's queue.
+func (s *ActionSchedule) enqueue() (Action, error) {
+	unit, err := s.st.Unit(s.doc.Receiver)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unit.AddAction(s.doc.Name, s.doc.Parameters)
+}
+
+// AddActionSchedule adds a schedule on which the named action is to be
+// enqueued on the unit, with the given parameters. The cron
+// specification is described by actions.ParseSchedule.
+func (m *Model) AddActionSchedule(receiver names.UnitTag, name string, parameters map[string]interface{}, cron string) (_ *ActionSchedule, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot schedule action %q on %s", name, names.ReadableString(receiver))
+
+	schedule, err := actions.ParseSchedule(cron)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := m.st.Unit(receiver.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if unit.Life() != Alive {
+		return nil, errors.Errorf("unit is not alive")
+	}
+	spec, err := unit.actionSpec(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := spec.ValidateParams(parameters); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	seq, err := sequence(m.st, "actionschedule")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := strconv.Itoa(seq)
+	now := m.st.nowToTheSecond()
+	doc := actionScheduleDoc{
+		DocId:      m.st.docID(id),
+		ModelUUID:  m.st.ModelUUID(),
+		Receiver:   receiver.Id(),
+		Name:       name,
+		Parameters: parameters,
+		Cron:       cron,
+		Created:    now,
+		NextRun:    schedule.Next(now).UTC(),
+	}
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     unit.doc.DocID,
+		Assert: isAliveDoc,
+	}, {
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.Errorf("unit is not alive")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// ActionSchedule returns the action schedule with the given id.
+func (m *Model) ActionSchedule(id string) (*ActionSchedule, error) {
+	coll, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", id)
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// AllActionSchedules returns all the action schedules in the model,
+// ordered by when they are next due.
+func (m *Model) AllActionSchedules() ([]*ActionSchedule, error) {
+	return m.st.actionSchedules(nil)
+}
+
+// RemoveActionSchedule removes the action schedule with the given id.
+// Actions already enqueued by the schedule are unaffected.
+func (m *Model) RemoveActionSchedule(id string) error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     m.st.docID(id),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("action schedule %q", id)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove action schedule %q", id)
+	}
+	return nil
+}
+
+// RunDueActionSchedules enqueues the actions of all schedules that are
+// due, and returns when the next schedule will be due. The zero time
+// is returned if there are no schedules.
+func (m *Model) RunDueActionSchedules() (time.Time, error) {
+	now := m.st.clock().Now()
+	due, err := m.st.actionSchedules(bson.D{{"next-run", bson.D{{"$lte", now}}}})
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	for _, schedule := range due {
+		if err := schedule.run(now); err != nil {
+			return time.Time{}, errors.Trace(err)
+		}
+	}
+
+	coll, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+	var doc actionScheduleDoc
+	err = coll.Find(nil).Sort("next-run").Select(bson.D{{"next-run", 1}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, errors.Annotate(err, "cannot get next action schedule")
+	}
+	return doc.NextRun, nil
+}
+
+// WatchActionSchedules returns a NotifyWatcher that notifies of
+// changes to the model's action schedules.
+func (m *Model) WatchActionSchedules() NotifyWatcher {
+	return newNotifyCollWatcher(m.st, actionSchedulesC, isLocalID(m.st))
+}
+
+// actionSchedules returns the action schedules matching the query,
+// ordered by when they are next due.
+func (st *State) actionSchedules(query bson.D) ([]*ActionSchedule, error) {
+	coll, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := coll.Find(query).Sort("next-run", "_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	schedules := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		schedules[i] = &ActionSchedule{st: st, doc: doc}
+	}
+	return schedules, nil
+}
+
+// removeActionSchedulesForUnit removes all the action schedules for the
+// named unit.
+func (st *State) removeActionSchedulesForUnit(unitName string) error {
+	schedules, err := st.actionSchedules(bson.D{{"receiver", unitName}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, schedule := range schedules {
+		ops = append(ops, txn.Op{
+			C:      actionSchedulesC,
+			Id:     schedule.doc.DocId,
+			Remove: true,
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return errors.Annotatef(st.db().RunTransaction(ops), "removing action schedules for unit %q", unitName)
+}
@@ -0,0 +1,199 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	unit  *state.Unit
+	model *state.Model
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.InitialTime = time.Date(2019, 4, 1, 10, 0, 0, 0, time.UTC)
+	s.ConnSuite.SetUpTest(c)
+
+	ch := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingApplication(c, "dummy", ch)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	err = unit.SetCharmURL(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit = unit
+
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C, cron string) *state.ActionSchedule {
+	schedule, err := s.model.AddActionSchedule(
+		s.unit.UnitTag(), "snapshot", map[string]interface{}{"outfile": "db.bz2"}, cron,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return schedule
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	schedule := s.addSchedule(c, "0 3 * * *")
+	c.Check(schedule.Receiver(), gc.Equals, "dummy/0")
+	c.Check(schedule.Name(), gc.Equals, "snapshot")
+	c.Check(schedule.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "db.bz2"})
+	c.Check(schedule.Cron(), gc.Equals, "0 3 * * *")
+	c.Check(schedule.Created().IsZero(), jc.IsFalse)
+	c.Check(schedule.NextRun().Equal(time.Date(2019, 4, 2, 3, 0, 0, 0, time.UTC)), jc.IsTrue)
+	c.Check(schedule.History(), gc.HasLen, 0)
+
+	other, err := s.model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(other.Id(), gc.Equals, schedule.Id())
+	c.Check(other.Cron(), gc.Equals, "0 3 * * *")
+	c.Check(other.NextRun().Equal(schedule.NextRun()), jc.IsTrue)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		unit     names.UnitTag
+		name     string
+		params   map[string]interface{}
+		cron     string
+		errMatch string
+	}{{
+		unit:     s.unit.UnitTag(),
+		name:     "snapshot",
+		cron:     "every tuesday",
+		errMatch: `cannot schedule action "snapshot" on unit dummy/0: schedule "every tuesday" with 2 fields \(expected 5\) not valid`,
+	}, {
+		unit:     s.unit.UnitTag(),
+		name:     "backup",
+		cron:     "@daily",
+		errMatch: `cannot schedule action "backup" on unit dummy/0: action "backup" not defined on unit "dummy/0"`,
+	}, {
+		unit:     s.unit.UnitTag(),
+		name:     "snapshot",
+		params:   map[string]interface{}{"outfile": 42},
+		cron:     "@daily",
+		errMatch: `cannot schedule action "snapshot" on unit dummy/0: validation failed: .*`,
+	}, {
+		unit:     names.NewUnitTag("dummy/42"),
+		name:     "snapshot",
+		cron:     "@daily",
+		errMatch: `cannot schedule action "snapshot" on unit dummy/42: unit "dummy/42" not found`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.model.AddActionSchedule(test.unit, test.name, test.params, test.cron)
+		c.Check(err, gc.ErrorMatches, test.errMatch)
+	}
+	schedules, err := s.model.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestAllActionSchedulesOrderedByNextRun(c *gc.C) {
+	daily := s.addSchedule(c, "0 3 * * *")
+	hourly := s.addSchedule(c, "@hourly")
+
+	schedules, err := s.model.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 2)
+	c.Check(schedules[0].Id(), gc.Equals, hourly.Id())
+	c.Check(schedules[1].Id(), gc.Equals, daily.Id())
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedules(c *gc.C) {
+	schedule := s.addSchedule(c, "0 3 * * *")
+	s.addSchedule(c, "@hourly")
+
+	// Nothing is due yet.
+	next, err := s.model.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(next.Equal(time.Date(2019, 4, 1, 11, 0, 0, 0, time.UTC)), jc.IsTrue)
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(actions, gc.HasLen, 0)
+
+	// Both schedules are due, and each enqueues its action once.
+	s.Clock.Advance(17*time.Hour + 30*time.Minute)
+	next, err = s.model.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(next.Equal(time.Date(2019, 4, 2, 4, 0, 0, 0, time.UTC)), jc.IsTrue)
+	actions, err = s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 2)
+	c.Check(actions[0].Name(), gc.Equals, "snapshot")
+	c.Check(actions[0].Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "db.bz2"})
+
+	schedule, err = s.model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.NextRun().Equal(time.Date(2019, 4, 3, 3, 0, 0, 0, time.UTC)), jc.IsTrue)
+	history := schedule.History()
+	c.Assert(history, gc.HasLen, 1)
+	c.Check(history[0].Time.Equal(time.Date(2019, 4, 2, 3, 0, 0, 0, time.UTC)), jc.IsTrue)
+	c.Check(history[0].Error, gc.Equals, "")
+	_, err = s.model.Action(history[0].ActionId)
+	c.Check(err, jc.ErrorIsNil)
+
+	// Running again doesn't enqueue anything more.
+	_, err = s.model.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	actions, err = s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 2)
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesNoSchedules(c *gc.C) {
+	next, err := s.model.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.IsZero(), jc.IsTrue)
+}
+
+func (s *ActionScheduleSuite) TestRemoveActionSchedule(c *gc.C) {
+	schedule := s.addSchedule(c, "@daily")
+	err := s.model.RemoveActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.model.RemoveActionSchedule(schedule.Id())
+	c.Assert(err, gc.ErrorMatches, `action schedule ".*" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionScheduleSuite) TestSchedulesRemovedWithUnit(c *gc.C) {
+	schedule := s.addSchedule(c, "@daily")
+	err := s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionScheduleSuite) TestWatchActionSchedules(c *gc.C) {
+	w := s.model.WatchActionSchedules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	schedule := s.addSchedule(c, "@daily")
+	wc.AssertOneChange()
+
+	err := s.model.RemoveActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
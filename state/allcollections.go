@@ -216,6 +216,22 @@ func allCollections() CollectionSchema {
 			}},
 		},
 
+		// This collection holds the audit log entries recorded by
+		// every controller, when the database audit log backend is
+		// enabled. It's written without transactions since entries
+		// are only ever inserted.
+		auditLogC: {
+			global:    true,
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"kind", "-time"},
+			}, {
+				Key: []string{"conversation-id"},
+			}, {
+				Key: []string{"kind", "facade", "method"},
+			}},
+		},
+
 		// This collection holds the last time the model user connected
 		// to the model.
 		modelUserLastConnectionC: {
@@ -279,6 +295,10 @@ func allCollections() CollectionSchema {
 		},
 		minUnitsC: {},
 
+		// This collection holds the private key/value state
+		// stored by units' charms.
+		unitStatesC: {},
+
 		// This collection holds documents that indicate units which are queued
 		// to be assigned to machines. It is used exclusively by the
 		// AssignUnitWorker.
@@ -397,6 +417,15 @@ func allCollections() CollectionSchema {
 			}},
 		},
 		actionNotificationsC: {},
+		actionSchedulesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "next-run"},
+			}},
+		},
+
+		// This collection holds requests for agents to report on their
+		// introspection endpoints, relayed through the controller.
+		introspectionRequestsC: {},
 
 		// -----
 
@@ -482,6 +511,13 @@ func allCollections() CollectionSchema {
 				{Key: []string{"model-uuid", "offer-uuid"}},
 			},
 		},
+		// This collection holds users' requests to consume offers
+		// which require approval.
+		offerConnectionRequestsC: {
+			indexes: []mgo.Index{
+				{Key: []string{"model-uuid", "offer-uuid"}},
+			},
+		},
 		remoteApplicationsC: {},
 		// remoteEntitiesC holds information about entities involved in
 		// cross-model relations.
@@ -531,10 +567,12 @@ func allCollections() CollectionSchema {
 const (
 	actionNotificationsC       = "actionnotifications"
 	actionresultsC             = "actionresults"
+	actionSchedulesC           = "actionschedules"
 	actionsC                   = "actions"
 	annotationsC               = "annotations"
 	autocertCacheC             = "autocertCache"
 	assignUnitC                = "assignUnits"
+	auditLogC                  = "auditlog"
 	bakeryStorageItemsC        = "bakeryStorageItems"
 	blockDevicesC              = "blockdevices"
 	blocksC                    = "blocks"
@@ -558,6 +596,7 @@ const (
 	guimetadataC               = "guimetadata"
 	guisettingsC               = "guisettings"
 	instanceDataC              = "instanceData"
+	introspectionRequestsC     = "introspectionrequests"
 	leasesC                    = "leases"
 	leaseHoldersC              = "leaseholders"
 	machinesC                  = "machines"
@@ -606,6 +645,7 @@ const (
 	txnLogC                    = "txns.log"
 	txnsC                      = "txns"
 	unitsC                     = "units"
+	unitStatesC                = "unitstates"
 	upgradeInfoC               = "upgradeInfo"
 	userLastLoginC             = "userLastLogin"
 	usermodelnameC             = "usermodelname"
@@ -617,11 +657,12 @@ const (
 	// "resources" (see resource/persistence/mongo.go)
 
 	// Cross model relations
-	applicationOffersC   = "applicationOffers"
-	remoteApplicationsC  = "remoteApplications"
-	offerConnectionsC    = "applicationOfferConnections"
-	remoteEntitiesC      = "remoteEntities"
-	externalControllersC = "externalControllers"
-	relationNetworksC    = "relationNetworks"
-	firewallRulesC       = "firewallRules"
+	applicationOffersC       = "applicationOffers"
+	remoteApplicationsC      = "remoteApplications"
+	offerConnectionsC        = "applicationOfferConnections"
+	offerConnectionRequestsC = "applicationOfferConnectionRequests"
+	remoteEntitiesC          = "remoteEntities"
+	externalControllersC     = "externalControllers"
+	relationNetworksC        = "relationNetworks"
+	firewallRulesC           = "firewallRules"
 )
@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -46,23 +47,32 @@ type Application struct {
 // applicationDoc represents the internal state of an application in MongoDB.
 // Note the correspondence with ApplicationInfo in apiserver.
 type applicationDoc struct {
-	DocID                string       `bson:"_id"`
-	Name                 string       `bson:"name"`
-	ModelUUID            string       `bson:"model-uuid"`
-	Series               string       `bson:"series"`
-	Subordinate          bool         `bson:"subordinate"`
-	CharmURL             *charm.URL   `bson:"charmurl"`
-	Channel              string       `bson:"cs-channel"`
-	CharmModifiedVersion int          `bson:"charmmodifiedversion"`
-	ForceCharm           bool         `bson:"forcecharm"`
-	Life                 Life         `bson:"life"`
-	UnitCount            int          `bson:"unitcount"`
-	RelationCount        int          `bson:"relationcount"`
-	Exposed              bool         `bson:"exposed"`
-	MinUnits             int          `bson:"minunits"`
-	Tools                *tools.Tools `bson:",omitempty"`
-	TxnRevno             int64        `bson:"txn-revno"`
-	MetricCredentials    []byte       `bson:"metric-credentials"`
+	DocID                string               `bson:"_id"`
+	Name                 string               `bson:"name"`
+	ModelUUID            string               `bson:"model-uuid"`
+	Series               string               `bson:"series"`
+	Subordinate          bool                 `bson:"subordinate"`
+	CharmURL             *charm.URL           `bson:"charmurl"`
+	Channel              string               `bson:"cs-channel"`
+	CharmModifiedVersion int                  `bson:"charmmodifiedversion"`
+	ForceCharm           bool                 `bson:"forcecharm"`
+	Life                 Life                 `bson:"life"`
+	UnitCount            int                  `bson:"unitcount"`
+	RelationCount        int                  `bson:"relationcount"`
+	Exposed              bool                 `bson:"exposed"`
+	ExposedEndpoints     []exposedEndpointDoc `bson:"exposed-endpoints,omitempty"`
+	MinUnits             int                  `bson:"minunits"`
+	Tools                *tools.Tools         `bson:",omitempty"`
+	TxnRevno             int64                `bson:"txn-revno"`
+	MetricCredentials    []byte               `bson:"metric-credentials"`
+
+	// CharmRollout records the progress of the most recent charm
+	// upgrade that was rolled out to the units in batches.
+	CharmRollout *charmRolloutDoc `bson:"charm-rollout,omitempty"`
+
+	// CharmRefreshPolicy describes when the application's charm is
+	// automatically upgraded to newer revisions in its channel.
+	CharmRefreshPolicy *charmRefreshPolicyDoc `bson:"charm-refresh-policy,omitempty"`
 
 	// CAAS related attributes.
 	DesiredScale int    `bson:"scale"`
@@ -521,6 +531,25 @@ func (a *Application) removeOps(asserts bson.D, op *ForcedOperation) ([]txn.Op,
 	return ops, nil
 }
 
+// ExposedEndpoint holds the sources from which the ports opened for an
+// exposed application endpoint may be accessed.
+type ExposedEndpoint struct {
+	// ExposeToSpaces are the names of the spaces whose subnets may
+	// access the endpoint.
+	ExposeToSpaces []string
+
+	// ExposeToCIDRs are the CIDRs that may access the endpoint.
+	ExposeToCIDRs []string
+}
+
+// exposedEndpointDoc records the expose settings of one endpoint of an
+// application. An empty endpoint name applies to all endpoints.
+type exposedEndpointDoc struct {
+	Endpoint string   `bson:"endpoint"`
+	Spaces   []string `bson:"to-spaces,omitempty"`
+	CIDRs    []string `bson:"to-cidrs,omitempty"`
+}
+
 // IsExposed returns whether this application is exposed. The explicitly open
 // ports (with open-port) for exposed applications may be accessed from machines
 // outside of the local deployment network. See SetExposed and ClearExposed.
@@ -528,29 +557,115 @@ func (a *Application) IsExposed() bool {
 	return a.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
+// ExposedEndpoints returns the expose settings of the application's
+// endpoints, keyed by endpoint name; the empty name applies to all
+// endpoints. If the application is exposed and no settings are
+// returned, its open ports may be accessed from anywhere.
+func (a *Application) ExposedEndpoints() map[string]ExposedEndpoint {
+	if len(a.doc.ExposedEndpoints) == 0 {
+		return nil
+	}
+	result := make(map[string]ExposedEndpoint, len(a.doc.ExposedEndpoints))
+	for _, doc := range a.doc.ExposedEndpoints {
+		result[doc.Endpoint] = ExposedEndpoint{
+			ExposeToSpaces: doc.Spaces,
+			ExposeToCIDRs:  doc.CIDRs,
+		}
+	}
+	return result
+}
+
+// SetExposed marks the application as exposed, so its open ports may be
+// accessed from anywhere. Any previous expose settings are removed.
 // See ClearExposed and IsExposed.
 func (a *Application) SetExposed() error {
-	return a.setExposed(true)
+	return a.setExposed(true, nil)
+}
+
+// SetExposeSettings marks the application as exposed, with the given
+// settings restricting where each endpoint may be accessed from. The
+// settings replace any set previously. An endpoint with neither spaces
+// nor CIDRs may be accessed from anywhere.
+func (a *Application) SetExposeSettings(exposedEndpoints map[string]ExposedEndpoint) error {
+	docs, err := a.exposedEndpointDocs(exposedEndpoints)
+	if err != nil {
+		return errors.Annotatef(err, "cannot set expose settings for application %q", a)
+	}
+	return a.setExposed(true, docs)
 }
 
-// ClearExposed removes the exposed flag from the application.
-// See SetExposed and IsExposed.
+// ClearExposed removes the exposed flag and any expose settings from
+// the application. See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
-	return a.setExposed(false)
+	return a.setExposed(false, nil)
 }
 
-func (a *Application) setExposed(exposed bool) (err error) {
+func (a *Application) exposedEndpointDocs(exposedEndpoints map[string]ExposedEndpoint) ([]exposedEndpointDoc, error) {
+	if len(exposedEndpoints) == 0 {
+		return nil, nil
+	}
+	endpoints, err := a.Endpoints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	known := set.NewStrings("")
+	for _, ep := range endpoints {
+		known.Add(ep.Name)
+	}
+
+	docs := make([]exposedEndpointDoc, 0, len(exposedEndpoints))
+	for name, exposed := range exposedEndpoints {
+		if !known.Contains(name) {
+			return nil, errors.NotFoundf("endpoint %q", name)
+		}
+		for _, spaceName := range exposed.ExposeToSpaces {
+			if _, err := a.st.Space(spaceName); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		cidrs := exposed.ExposeToCIDRs
+		for _, cidr := range cidrs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return nil, errors.NotValidf("CIDR %q", cidr)
+			}
+		}
+		if len(cidrs) == 0 && len(exposed.ExposeToSpaces) == 0 {
+			cidrs = []string{"0.0.0.0/0"}
+		}
+		docs = append(docs, exposedEndpointDoc{
+			Endpoint: name,
+			Spaces:   exposed.ExposeToSpaces,
+			CIDRs:    cidrs,
+		})
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].Endpoint < docs[j].Endpoint
+	})
+	return docs, nil
+}
+
+func (a *Application) setExposed(exposed bool, exposedEndpoints []exposedEndpointDoc) (err error) {
+	update := bson.D{
+		{"$set", bson.D{{"exposed", exposed}}},
+		{"$unset", bson.D{{"exposed-endpoints", nil}}},
+	}
+	if len(exposedEndpoints) > 0 {
+		update = bson.D{{"$set", bson.D{
+			{"exposed", exposed},
+			{"exposed-endpoints", exposedEndpoints},
+		}}}
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := a.st.db().RunTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, applicationNotAliveErr))
 	}
 	a.doc.Exposed = exposed
+	a.doc.ExposedEndpoints = exposedEndpoints
 	return nil
 }
 
@@ -584,6 +699,129 @@ func (a *Application) CharmURL() (curl *charm.URL, force bool) {
 	return a.doc.CharmURL, a.doc.ForceCharm
 }
 
+// BranchCharmURL returns the charm URL for the application under the branch
+// with the input name. If no charm upgrade is staged under the branch,
+// the application's current charm URL is returned.
+func (a *Application) BranchCharmURL(branchName string) (*charm.URL, error) {
+	if branchName == model.GenerationMaster {
+		return a.doc.CharmURL, nil
+	}
+	branch, err := a.st.Branch(branchName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	url, ok := branch.CharmURLs()[a.Name()]
+	if !ok {
+		return a.doc.CharmURL, nil
+	}
+	return charm.ParseURL(url)
+}
+
+// SetBranchCharm stages an upgrade of the application to the input charm
+// under the branch with the input name. Units tracking the branch will be
+// upgraded to the charm; the remaining units are upgraded when the branch
+// is committed.
+func (a *Application) SetBranchCharm(branchName string, ch *Charm) (err error) {
+	defer errors.DeferredAnnotatef(
+		&err, "cannot stage upgrade of application %q to charm %q on branch %q", a, ch, branchName,
+	)
+	if ch.Meta().Subordinate != a.doc.Subordinate {
+		return errors.Errorf("cannot change an application's subordinacy")
+	}
+	if ch.URL().Series != "" && ch.URL().Series != a.doc.Series {
+		return errors.Errorf("cannot change an application's series")
+	}
+	branch, err := a.st.Branch(branchName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(branch.UpdateCharmURL(a.Name(), ch.URL()))
+}
+
+// branchCharmOps returns the operations necessary for a branch to stage an
+// upgrade of the application to the input charm. The application's settings
+// and storage constraints for the charm are created from the current ones
+// if they do not yet exist, and references are taken to them and the charm
+// on behalf of the branch, so that units tracking the branch can be
+// upgraded to it.
+func (a *Application) branchCharmOps(ch *Charm) ([]txn.Op, error) {
+	var ops []txn.Op
+
+	settingsKey := applicationCharmConfigKey(a.doc.Name, ch.URL())
+	if _, err := readSettings(a.st.db(), settingsC, settingsKey); errors.IsNotFound(err) {
+		current, err := readSettings(a.st.db(), settingsC, a.charmConfigKey())
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", a.doc.Name)
+		}
+		ops = append(ops, createSettingsOp(settingsC, settingsKey, ch.Config().FilterSettings(current.Map())))
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "application %q", a.doc.Name)
+	}
+
+	storageConsKey := applicationStorageConstraintsKey(a.doc.Name, ch.URL())
+	if _, err := readStorageConstraints(a.st, storageConsKey); errors.IsNotFound(err) {
+		sb, err := NewStorageBackend(a.st)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		current, err := a.StorageConstraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		storageCons := make(map[string]StorageConstraints)
+		for name, cons := range current {
+			if _, ok := ch.Meta().Storage[name]; ok {
+				storageCons[name] = cons
+			}
+		}
+		if err := addDefaultStorageConstraints(sb, storageCons, ch.Meta()); err != nil {
+			return nil, errors.Annotate(err, "adding default storage constraints")
+		}
+		ops = append(ops, createStorageConstraintsOp(storageConsKey, storageCons))
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	incOps, err := appCharmIncRefOps(a.st, a.doc.Name, ch.URL(), true)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, incOps...)
+	return append(ops, branchCharmChangedOp(a.doc.Name)), nil
+}
+
+// branchCharmChangedOp returns an operation that asserts that the
+// application with the input name is alive, and touches its document.
+// The charm URL that a unit should be running is read by its uniter when
+// the application changes, so this notifies the units tracking a branch
+// of a change to the charm staged for them.
+func branchCharmChangedOp(appName string) txn.Op {
+	return txn.Op{
+		C:      applicationsC,
+		Id:     appName,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"charmmodifiedversion", 0}}}},
+	}
+}
+
+// branchCharmHandOverOps returns the operations that drop the references
+// held by a committed branch to the input charm and the application's
+// settings and storage constraints for it. They are run along with those
+// that take the application's own references, so the counts never reach
+// zero and the documents are kept.
+func branchCharmHandOverOps(appName string, curl *charm.URL) []txn.Op {
+	keys := []string{
+		applicationCharmConfigKey(appName, curl),
+		applicationStorageConstraintsKey(appName, curl),
+		charmGlobalKey(curl),
+	}
+	ops := make([]txn.Op, len(keys))
+	for i, key := range keys {
+		ops[i] = nsRefcounts.justDecRefOp(refcountsC, key, 0)
+	}
+	return ops
+}
+
 // Channel identifies the charm store channel from which the application's
 // charm was deployed. It is only needed when interacting with the charm
 // store.
@@ -1167,6 +1405,11 @@ type SetCharmConfig struct {
 	// unaffected; the storage constraints will only be used for
 	// provisioning new storage instances.
 	StorageConstraints map[string]StorageConstraints
+
+	// Rollout, if not nil, causes the new charm to be handed to the
+	// application's existing units a batch at a time, rather than to
+	// all of them at once. New units always use the new charm.
+	Rollout *CharmRolloutParams
 }
 
 // SetCharm changes the charm for the application.
@@ -1252,6 +1495,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	}
 
 	var newCharmModifiedVersion int
+	newCharmRollout := a.doc.CharmRollout
 	channel := string(cfg.Channel)
 	acopy := &Application{a.st, a.doc}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -1308,6 +1552,14 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 				return nil, errors.Trace(err)
 			}
 			ops = append(ops, chng...)
+			rolloutOp, rollout, err := a.charmRolloutOp(cfg.Rollout, cfg.Charm.URL())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if rolloutOp != nil {
+				ops = append(ops, *rolloutOp)
+			}
+			newCharmRollout = rollout
 			newCharmModifiedVersion++
 		}
 
@@ -1320,6 +1572,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	a.doc.Channel = channel
 	a.doc.ForceCharm = cfg.ForceUnits
 	a.doc.CharmModifiedVersion = newCharmModifiedVersion
+	a.doc.CharmRollout = newCharmRollout
 	return nil
 }
 
@@ -1958,6 +2211,7 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D, op *ForcedOperation
 		removeStatusOp(a.st, u.globalCloudContainerKey()),
 		removeConstraintsOp(u.globalAgentKey()),
 		annotationRemoveOp(a.st, u.globalKey()),
+		removeUnitStateOp(a.st, u.doc.DocID),
 		newCleanupOp(cleanupRemovedUnit, u.doc.Name, op.Force),
 	}
 	ops = append(ops, portsOps...)
@@ -2365,6 +2619,27 @@ func (a *Application) SetConstraints(cons constraints.Value) (err error) {
 	return onAbort(a.st.db().RunTransaction(ops), applicationNotAliveErr)
 }
 
+// SetBranchConstraints sets the application constraints under the branch
+// with the input name. Units tracking the branch that are yet to be
+// assigned to a machine will be provisioned using these constraints.
+func (a *Application) SetBranchConstraints(branchName string, cons constraints.Value) error {
+	unsupported, err := a.st.validateConstraints(cons)
+	if len(unsupported) > 0 {
+		logger.Warningf(
+			"setting constraints on application %q: unsupported constraints: %v", a.Name(), strings.Join(unsupported, ","))
+	} else if err != nil {
+		return err
+	}
+	if a.doc.Subordinate {
+		return ErrSubordinateConstraints
+	}
+	branch, err := a.st.Branch(branchName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotate(branch.UpdateConstraints(a.Name(), cons), "cannot set constraints")
+}
+
 // EndpointBindings returns the mapping for each endpoint name and the space
 // name it is bound to (or empty if unspecified). When no bindings are stored
 // for the application, defaults are returned.
@@ -2017,6 +2017,70 @@ func (s *ApplicationSuite) TestApplicationExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestApplicationExposeSettings(c *gc.C) {
+	_, err := s.State.AddSpace("dmz", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		"":       {ExposeToSpaces: []string{"dmz"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+
+	expected := map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		"":       {ExposeToSpaces: []string{"dmz"}},
+	}
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, expected)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, expected)
+
+	// An endpoint without spaces or CIDRs is open to everyone.
+	err = s.mysql.SetExposeSettings(map[string]state.ExposedEndpoint{"server": {}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+	})
+
+	// Setting the exposed flag removes the settings.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+
+	err = s.mysql.SetExposeSettings(expected)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestApplicationExposeSettingsInvalid(c *gc.C) {
+	err := s.mysql.SetExposeSettings(map[string]state.ExposedEndpoint{
+		"website": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set expose settings for application "mysql": endpoint "website" not found`)
+
+	err = s.mysql.SetExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set expose settings for application "mysql": CIDR "10.0.0.0" not valid`)
+
+	err = s.mysql.SetExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToSpaces: []string{"nowhere"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set expose settings for application "mysql": space "nowhere" not found`)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	c.Assert(s.mysql.UnitCount(), gc.Equals, 0)
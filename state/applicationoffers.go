@@ -53,6 +53,24 @@ type applicationOfferDoc struct {
 
 	// Endpoints are the charm endpoints supported by the applicationbob.
 	Endpoints map[string]string `bson:"endpoints"`
+
+	// RequireApproval is true if users need to have a request to
+	// consume the offer approved by an offer admin.
+	RequireApproval bool `bson:"require-approval,omitempty"`
+
+	// MaxConsumers is the maximum number of models which may be
+	// connected to the offer, or 0 if there is no limit.
+	MaxConsumers int `bson:"max-consumers,omitempty"`
+
+	// MaxRelations is the maximum number of relations which may be
+	// made to the offer, or 0 if there is no limit.
+	MaxRelations int `bson:"max-relations,omitempty"`
+
+	// ConnectionCount is the number of connections to the offer. It
+	// is asserted when a connection is added, so that the limits and
+	// approval of the offer are checked against the same connections
+	// as are recorded. It is nil until the first connection is added.
+	ConnectionCount *int `bson:"connection-count,omitempty"`
 }
 
 var _ crossmodel.ApplicationOffers = (*applicationOffers)(nil)
@@ -303,6 +321,11 @@ func (op *RemoveOfferOperation) internalRemove(offer *crossmodel.ApplicationOffe
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	requestOps, err := removeOfferConnectionRequestsOps(op.offers.st, offer.OfferUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, requestOps...)
 	ops = append(ops, txn.Op{
 		C:      applicationOffersC,
 		Id:     offer.OfferName,
@@ -341,6 +364,11 @@ func removeApplicationOffersOps(st *State, application string) ([]txn.Op, error)
 
 	var ops []txn.Op
 	for _, doc := range docs {
+		requestOps, err := removeOfferConnectionRequestsOps(st, doc.OfferUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, requestOps...)
 		ops = append(ops, txn.Op{
 			C:      applicationOffersC,
 			Id:     doc.OfferName,
@@ -372,6 +400,12 @@ func (s *applicationOffers) validateOfferArgs(offer crossmodel.AddApplicationOff
 			return errors.NotValidf("offer reader %q", readUser)
 		}
 	}
+	if offer.MaxConsumers < 0 {
+		return errors.NotValidf("maximum consumers %d", offer.MaxConsumers)
+	}
+	if offer.MaxRelations < 0 {
+		return errors.NotValidf("maximum relations %d", offer.MaxRelations)
+	}
 	return nil
 }
 
@@ -522,6 +556,9 @@ func (s *applicationOffers) makeApplicationOfferDoc(mb modelBackend, uuid string
 		ApplicationName:        offer.ApplicationName,
 		ApplicationDescription: offer.ApplicationDescription,
 		Endpoints:              offer.Endpoints,
+		RequireApproval:        offer.RequireApproval,
+		MaxConsumers:           offer.MaxConsumers,
+		MaxRelations:           offer.MaxRelations,
 	}
 	return doc
 }
@@ -706,6 +743,9 @@ func (s *applicationOffers) makeApplicationOffer(doc applicationOfferDoc) (*cros
 		OfferUUID:              doc.OfferUUID,
 		ApplicationName:        doc.ApplicationName,
 		ApplicationDescription: doc.ApplicationDescription,
+		RequireApproval:        doc.RequireApproval,
+		MaxConsumers:           doc.MaxConsumers,
+		MaxRelations:           doc.MaxRelations,
 	}
 	app, err := s.st.Application(doc.ApplicationName)
 	if err != nil {
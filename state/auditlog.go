@@ -0,0 +1,319 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/mongo"
+)
+
+const (
+	auditKindConversation = "conversation"
+	auditKindRequest      = "request"
+	auditKindErrors       = "errors"
+)
+
+// AuditLogStore is an auditlog.AuditLog that records audit entries in
+// the controller database. Since the database is shared by all of the
+// controllers in an HA set, querying the store returns the entries
+// recorded by every controller.
+type AuditLogStore struct {
+	st *State
+}
+
+// AuditLogStore returns an implementation of auditlog.AuditLog and
+// auditlog.Querier backed by the state.
+func (st *State) AuditLogStore() *AuditLogStore {
+	return &AuditLogStore{st: st}
+}
+
+// auditLogDoc holds a single audit log entry; Kind records whether
+// it's a conversation, request or response errors record, and only
+// the fields relevant to that kind are set.
+type auditLogDoc struct {
+	Id             bson.ObjectId `bson:"_id"`
+	Kind           string        `bson:"kind"`
+	ConversationID string        `bson:"conversation-id"`
+	ConnectionID   string        `bson:"connection-id"`
+	When           string        `bson:"when"`
+	Time           time.Time     `bson:"time"`
+
+	Who        string `bson:"who,omitempty"`
+	What       string `bson:"what,omitempty"`
+	ModelName  string `bson:"model-name,omitempty"`
+	ModelOwner string `bson:"model-owner,omitempty"`
+	ModelUUID  string `bson:"model-uuid,omitempty"`
+
+	RequestID int64  `bson:"request-id,omitempty"`
+	Facade    string `bson:"facade,omitempty"`
+	Method    string `bson:"method,omitempty"`
+	Version   int    `bson:"version,omitempty"`
+	Args      string `bson:"args,omitempty"`
+
+	Errors []auditErrorDoc `bson:"errors,omitempty"`
+}
+
+type auditErrorDoc struct {
+	Message string `bson:"message"`
+	Code    string `bson:"code"`
+}
+
+// AddConversation implements auditlog.AuditLog.
+func (s *AuditLogStore) AddConversation(c auditlog.Conversation) error {
+	return errors.Trace(s.insert(auditLogDoc{
+		Kind:           auditKindConversation,
+		ConversationID: c.ConversationID,
+		ConnectionID:   c.ConnectionID,
+		When:           c.When,
+		Who:            c.Who,
+		What:           c.What,
+		ModelName:      c.ModelName,
+		ModelOwner:     c.ModelOwner,
+		ModelUUID:      c.ModelUUID,
+	}))
+}
+
+// AddRequest implements auditlog.AuditLog.
+func (s *AuditLogStore) AddRequest(r auditlog.Request) error {
+	return errors.Trace(s.insert(auditLogDoc{
+		Kind:           auditKindRequest,
+		ConversationID: r.ConversationID,
+		ConnectionID:   r.ConnectionID,
+		When:           r.When,
+		RequestID:      int64(r.RequestID),
+		Facade:         r.Facade,
+		Method:         r.Method,
+		Version:        r.Version,
+		Args:           r.Args,
+	}))
+}
+
+// AddResponse implements auditlog.AuditLog.
+func (s *AuditLogStore) AddResponse(r auditlog.ResponseErrors) error {
+	errDocs := make([]auditErrorDoc, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		if e == nil {
+			continue
+		}
+		errDocs = append(errDocs, auditErrorDoc{Message: e.Message, Code: e.Code})
+	}
+	return errors.Trace(s.insert(auditLogDoc{
+		Kind:           auditKindErrors,
+		ConversationID: r.ConversationID,
+		ConnectionID:   r.ConnectionID,
+		When:           r.When,
+		RequestID:      int64(r.RequestID),
+		Errors:         errDocs,
+	}))
+}
+
+// Close implements auditlog.AuditLog.
+func (s *AuditLogStore) Close() error {
+	return nil
+}
+
+func (s *AuditLogStore) insert(doc auditLogDoc) error {
+	when, err := time.Parse(time.RFC3339, doc.When)
+	if err != nil {
+		return errors.Annotatef(err, "parsing audit log time %q", doc.When)
+	}
+	doc.Id = bson.NewObjectId()
+	doc.Time = when.UTC()
+
+	coll, closer := s.st.db().GetCollection(auditLogC)
+	defer closer()
+	return errors.Annotate(coll.Writeable().Insert(doc), "cannot write audit log entry")
+}
+
+// Query implements auditlog.Querier. Conversations are returned most
+// recent first.
+func (s *AuditLogStore) Query(filter auditlog.Filter) ([]auditlog.ConversationDetails, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	coll, closer := s.st.db().GetRawCollection(auditLogC)
+	defer closer()
+
+	query := bson.D{{"kind", auditKindConversation}}
+	if filter.Who != "" {
+		query = append(query, bson.DocElem{"who", filter.Who})
+	}
+	if filter.Model != "" {
+		models := []bson.D{
+			{{"model-name", filter.Model}},
+			{{"model-uuid", filter.Model}},
+		}
+		if parts := strings.SplitN(filter.Model, "/", 2); len(parts) == 2 {
+			models = append(models, bson.D{
+				{"model-owner", parts[0]},
+				{"model-name", parts[1]},
+			})
+		}
+		query = append(query, bson.DocElem{"$or", models})
+	}
+	timeRange := bson.D{}
+	if !filter.After.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$gte", filter.After.UTC()})
+	}
+	if !filter.Before.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$lte", filter.Before.UTC()})
+	}
+	if len(timeRange) > 0 {
+		query = append(query, bson.DocElem{"time", timeRange})
+	}
+	if filter.Facade != "" || filter.Method != "" {
+		ids, err := s.conversationsCalling(coll, filter)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		query = append(query, bson.DocElem{"conversation-id", bson.D{{"$in", ids}}})
+	}
+
+	q := coll.Find(query).Sort("-time")
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+	var convDocs []auditLogDoc
+	if err := q.All(&convDocs); err != nil {
+		return nil, errors.Annotate(err, "cannot query audit log conversations")
+	}
+	if len(convDocs) == 0 {
+		return nil, nil
+	}
+
+	results := make([]auditlog.ConversationDetails, len(convDocs))
+	index := make(map[string]*auditlog.ConversationDetails)
+	ids := make([]string, len(convDocs))
+	for i, doc := range convDocs {
+		results[i].Conversation = auditlog.Conversation{
+			Who:            doc.Who,
+			What:           doc.What,
+			When:           doc.When,
+			ModelName:      doc.ModelName,
+			ModelOwner:     doc.ModelOwner,
+			ModelUUID:      doc.ModelUUID,
+			ConversationID: doc.ConversationID,
+			ConnectionID:   doc.ConnectionID,
+		}
+		index[doc.ConversationID] = &results[i]
+		ids[i] = doc.ConversationID
+	}
+
+	var entryDocs []auditLogDoc
+	err := coll.Find(bson.D{
+		{"kind", bson.D{{"$ne", auditKindConversation}}},
+		{"conversation-id", bson.D{{"$in", ids}}},
+	}).Sort("time", "request-id").All(&entryDocs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot query audit log requests")
+	}
+	for _, doc := range entryDocs {
+		details, ok := index[doc.ConversationID]
+		if !ok {
+			continue
+		}
+		switch doc.Kind {
+		case auditKindRequest:
+			details.Requests = append(details.Requests, auditlog.Request{
+				ConversationID: doc.ConversationID,
+				ConnectionID:   doc.ConnectionID,
+				RequestID:      uint64(doc.RequestID),
+				When:           doc.When,
+				Facade:         doc.Facade,
+				Method:         doc.Method,
+				Version:        doc.Version,
+				Args:           doc.Args,
+			})
+		case auditKindErrors:
+			errs := make([]*auditlog.Error, len(doc.Errors))
+			for i, e := range doc.Errors {
+				errs[i] = &auditlog.Error{Message: e.Message, Code: e.Code}
+			}
+			details.Errors = append(details.Errors, auditlog.ResponseErrors{
+				ConversationID: doc.ConversationID,
+				ConnectionID:   doc.ConnectionID,
+				RequestID:      uint64(doc.RequestID),
+				When:           doc.When,
+				Errors:         errs,
+			})
+		}
+	}
+	return results, nil
+}
+
+// conversationsCalling returns the IDs of the conversations that
+// include a request matching the filter's facade and method.
+func (s *AuditLogStore) conversationsCalling(coll mongo.Collection, filter auditlog.Filter) ([]string, error) {
+	query := bson.D{{"kind", auditKindRequest}}
+	if filter.Facade != "" {
+		query = append(query, bson.DocElem{"facade", filter.Facade})
+	}
+	if filter.Method != "" {
+		query = append(query, bson.DocElem{"method", filter.Method})
+	}
+	// Requests are always made after their conversation starts, so
+	// the lower bound can be used to narrow the search.
+	if !filter.After.IsZero() {
+		query = append(query, bson.DocElem{"time", bson.D{{"$gte", filter.After.UTC()}}})
+	}
+	var ids []string
+	if err := coll.Find(query).Distinct("conversation-id", &ids); err != nil {
+		return nil, errors.Annotate(err, "cannot query audit log requests")
+	}
+	return ids, nil
+}
+
+// Prune removes audit log entries recorded before minTime. If the
+// audit log collection is still larger than maxSizeMB, the oldest
+// entries are removed until it is not. The number of entries removed
+// is returned.
+func (s *AuditLogStore) Prune(minTime time.Time, maxSizeMB int) (int, error) {
+	coll, closer := s.st.db().GetRawCollection(auditLogC)
+	defer closer()
+
+	info, err := coll.RemoveAll(bson.D{{"time", bson.D{{"$lt", minTime.UTC()}}}})
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot prune audit log by time")
+	}
+	removed := info.Removed
+
+	for {
+		sizeMB, err := getCollectionMB(coll)
+		if err != nil {
+			return removed, errors.Annotate(err, "cannot get audit log size")
+		}
+		if sizeMB <= maxSizeMB {
+			break
+		}
+		count, err := coll.Count()
+		if err != nil {
+			return removed, errors.Annotate(err, "cannot count audit log entries")
+		}
+		if count < 5000 {
+			break // Pruning is not worthwhile
+		}
+
+		// Remove the oldest 1% of entries.
+		var doc auditLogDoc
+		err = coll.Find(nil).Sort("time", "_id").Skip(count / 100).Select(bson.D{{"time", 1}}).One(&doc)
+		if err != nil {
+			return removed, errors.Annotate(err, "audit log pruning time query failed")
+		}
+		info, err := coll.RemoveAll(bson.D{{"time", bson.D{{"$lt", doc.Time}}}})
+		if err != nil {
+			return removed, errors.Annotate(err, "cannot prune audit log by size")
+		}
+		if info.Removed == 0 {
+			break
+		}
+		removed += info.Removed
+	}
+	return removed, nil
+}
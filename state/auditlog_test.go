@@ -0,0 +1,172 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type auditLogStoreSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&auditLogStoreSuite{})
+
+// addConversation records a conversation with the model specified as
+// "owner/name", followed by a request for each of the methods.
+func (s *auditLogStoreSuite) addConversation(c *gc.C, store auditlog.AuditLog, id, who, model, when string, methods ...string) {
+	parts := strings.SplitN(model, "/", 2)
+	c.Assert(parts, gc.HasLen, 2)
+	err := store.AddConversation(auditlog.Conversation{
+		Who:            who,
+		What:           "juju something",
+		When:           when,
+		ModelName:      parts[1],
+		ModelOwner:     parts[0],
+		ModelUUID:      "uuid-" + model,
+		ConversationID: id,
+		ConnectionID:   "AC1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for i, method := range methods {
+		err := store.AddRequest(auditlog.Request{
+			ConversationID: id,
+			ConnectionID:   "AC1",
+			RequestID:      uint64(i + 1),
+			When:           when,
+			Facade:         "Application",
+			Method:         method,
+			Version:        7,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *auditLogStoreSuite) TestAddAndQuery(c *gc.C) {
+	store := s.State.AuditLogStore()
+	s.addConversation(c, store, "0001", "fred", "fred/default", "2018-11-01T10:00:00Z", "Deploy")
+	err := store.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "0001",
+		ConnectionID:   "AC1",
+		RequestID:      1,
+		When:           "2018-11-01T10:00:01Z",
+		Errors:         []*auditlog.Error{{Message: "boom", Code: "bad"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := store.Query(auditlog.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []auditlog.ConversationDetails{{
+		Conversation: auditlog.Conversation{
+			Who:            "fred",
+			What:           "juju something",
+			When:           "2018-11-01T10:00:00Z",
+			ModelName:      "default",
+			ModelOwner:     "fred",
+			ModelUUID:      "uuid-fred/default",
+			ConversationID: "0001",
+			ConnectionID:   "AC1",
+		},
+		Requests: []auditlog.Request{{
+			ConversationID: "0001",
+			ConnectionID:   "AC1",
+			RequestID:      1,
+			When:           "2018-11-01T10:00:00Z",
+			Facade:         "Application",
+			Method:         "Deploy",
+			Version:        7,
+		}},
+		Errors: []auditlog.ResponseErrors{{
+			ConversationID: "0001",
+			ConnectionID:   "AC1",
+			RequestID:      1,
+			When:           "2018-11-01T10:00:01Z",
+			Errors:         []*auditlog.Error{{Message: "boom", Code: "bad"}},
+		}},
+	}})
+}
+
+func (s *auditLogStoreSuite) TestQueryFilters(c *gc.C) {
+	store := s.State.AuditLogStore()
+	s.addConversation(c, store, "0001", "fred", "fred/default", "2018-11-01T10:00:00Z", "Deploy")
+	s.addConversation(c, store, "0002", "mary", "mary/prod", "2018-11-02T10:00:00Z", "AddUnits")
+	s.addConversation(c, store, "0003", "fred", "mary/prod", "2018-11-03T10:00:00Z", "Deploy", "Expose")
+
+	ids := func(filter auditlog.Filter) []string {
+		results, err := store.Query(filter)
+		c.Assert(err, jc.ErrorIsNil)
+		var ids []string
+		for _, r := range results {
+			ids = append(ids, r.Conversation.ConversationID)
+		}
+		return ids
+	}
+
+	c.Check(ids(auditlog.Filter{}), jc.DeepEquals, []string{"0003", "0002", "0001"})
+	c.Check(ids(auditlog.Filter{Who: "fred"}), jc.DeepEquals, []string{"0003", "0001"})
+	c.Check(ids(auditlog.Filter{Model: "mary/prod"}), jc.DeepEquals, []string{"0003", "0002"})
+	c.Check(ids(auditlog.Filter{Model: "uuid-fred/default"}), jc.DeepEquals, []string{"0001"})
+	c.Check(ids(auditlog.Filter{Method: "Deploy"}), jc.DeepEquals, []string{"0003", "0001"})
+	c.Check(ids(auditlog.Filter{Facade: "Application", Method: "Expose"}), jc.DeepEquals, []string{"0003"})
+	c.Check(ids(auditlog.Filter{Facade: "Client"}), gc.HasLen, 0)
+	c.Check(ids(auditlog.Filter{
+		After:  time.Date(2018, 11, 1, 12, 0, 0, 0, time.UTC),
+		Before: time.Date(2018, 11, 2, 12, 0, 0, 0, time.UTC),
+	}), jc.DeepEquals, []string{"0002"})
+	c.Check(ids(auditlog.Filter{Limit: 1}), jc.DeepEquals, []string{"0003"})
+}
+
+func (s *auditLogStoreSuite) TestQueryModelOwnedByOtherUser(c *gc.C) {
+	store := s.State.AuditLogStore()
+	s.addConversation(c, store, "0001", "fred", "fred/default", "2018-11-01T10:00:00Z")
+	s.addConversation(c, store, "0002", "fred", "mary/default", "2018-11-02T10:00:00Z")
+
+	results, err := store.Query(auditlog.Filter{Model: "mary/default"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Conversation.ConversationID, gc.Equals, "0002")
+	c.Assert(results[0].Conversation.ModelName, gc.Equals, "default")
+	c.Assert(results[0].Conversation.ModelOwner, gc.Equals, "mary")
+
+	// Unqualified names match models with that name owned by anyone.
+	results, err = store.Query(auditlog.Filter{Model: "default"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+}
+
+func (s *auditLogStoreSuite) TestPrune(c *gc.C) {
+	store := s.State.AuditLogStore()
+	s.addConversation(c, store, "0001", "fred", "fred/default", "2018-11-01T10:00:00Z", "Deploy")
+	s.addConversation(c, store, "0002", "mary", "mary/prod", "2018-11-02T10:00:00Z", "AddUnits")
+
+	removed, err := store.Prune(time.Date(2018, 11, 2, 0, 0, 0, 0, time.UTC), 1000)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed, gc.Equals, 2)
+
+	results, err := store.Query(auditlog.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Conversation.ConversationID, gc.Equals, "0002")
+	c.Assert(results[0].Requests, gc.HasLen, 1)
+}
+
+func (s *auditLogStoreSuite) TestQueryInvalidFilter(c *gc.C) {
+	_, err := s.State.AuditLogStore().Query(auditlog.Filter{Limit: -1})
+	c.Assert(err, gc.ErrorMatches, "negative limit -1 not valid")
+}
+
+func (s *auditLogStoreSuite) TestAddBadTime(c *gc.C) {
+	err := s.State.AuditLogStore().AddConversation(auditlog.Conversation{
+		ConversationID: "0001",
+		When:           "yesterday",
+	})
+	c.Assert(err, gc.ErrorMatches, `parsing audit log time "yesterday": .*`)
+}
@@ -0,0 +1,97 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remote
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+)
+
+type localStorage struct {
+	dir string
+}
+
+// NewLocalStorage returns a Storage that keeps archives in the given
+// directory, which is created if it does not exist.
+func NewLocalStorage(dir string) (Storage, error) {
+	if !filepath.IsAbs(dir) {
+		return nil, errors.NotValidf("relative backup directory %q", dir)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Annotate(err, "creating backup directory")
+	}
+	return &localStorage{dir: dir}, nil
+}
+
+// Put is part of the Storage interface.
+func (s *localStorage) Put(name string, r io.Reader, size int64) error {
+	path, err := s.path(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Write to a temporary file first, so that a partially written
+	// archive is never mistaken for a complete one.
+	f, err := ioutil.TempFile(s.dir, ".tmp-"+name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.Remove(f.Name())
+	n, err := io.Copy(f, r)
+	if err != nil {
+		f.Close()
+		return errors.Annotatef(err, "writing backup archive %q", name)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	if n != size {
+		return errors.Errorf("writing backup archive %q: expected %d bytes, got %d", name, size, n)
+	}
+	return errors.Trace(os.Rename(f.Name(), path))
+}
+
+// List is part of the Storage interface.
+func (s *localStorage) List() ([]Archive, error) {
+	infos, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var archives []Archive
+	for _, info := range infos {
+		if !info.Mode().IsRegular() || info.Name()[0] == '.' {
+			continue
+		}
+		archives = append(archives, Archive{
+			Name:     info.Name(),
+			Size:     info.Size(),
+			Modified: info.ModTime().UTC(),
+		})
+	}
+	return archives, nil
+}
+
+// Remove is part of the Storage interface.
+func (s *localStorage) Remove(name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.Remove(path); os.IsNotExist(err) {
+		return errors.NotFoundf("backup archive %q", name)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (s *localStorage) path(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || name[0] == '.' {
+		return "", errors.NotValidf("backup archive name %q", name)
+	}
+	return filepath.Join(s.dir, name), nil
+}
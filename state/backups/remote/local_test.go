@@ -0,0 +1,93 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remote_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups/remote"
+)
+
+type localSuite struct {
+	testing.IsolationSuite
+	dir string
+}
+
+var _ = gc.Suite(&localSuite{})
+
+func (s *localSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = filepath.Join(c.MkDir(), "backups")
+}
+
+func (s *localSuite) TestRelativeDir(c *gc.C) {
+	_, err := remote.NewLocalStorage("backups")
+	c.Assert(err, gc.ErrorMatches, `relative backup directory "backups" not valid`)
+}
+
+func (s *localSuite) TestPutListRemove(c *gc.C) {
+	stor, err := remote.NewLocalStorage(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = stor.Put("juju-backup-20190101-000000.tar.gz", strings.NewReader("archive one"), 11)
+	c.Assert(err, jc.ErrorIsNil)
+	err = stor.Put("juju-backup-20190102-000000.tar.gz", strings.NewReader("two"), 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(filepath.Join(s.dir, "juju-backup-20190101-000000.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "archive one")
+
+	archives, err := stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archives, gc.HasLen, 2)
+	c.Check(archives[0].Name, gc.Equals, "juju-backup-20190101-000000.tar.gz")
+	c.Check(archives[0].Size, gc.Equals, int64(11))
+	c.Check(archives[0].Modified.IsZero(), jc.IsFalse)
+	c.Check(archives[1].Name, gc.Equals, "juju-backup-20190102-000000.tar.gz")
+	c.Check(archives[1].Size, gc.Equals, int64(3))
+
+	err = stor.Remove("juju-backup-20190101-000000.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	archives, err = stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archives, gc.HasLen, 1)
+	c.Check(archives[0].Name, gc.Equals, "juju-backup-20190102-000000.tar.gz")
+}
+
+func (s *localSuite) TestPutShortArchive(c *gc.C) {
+	stor, err := remote.NewLocalStorage(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = stor.Put("backup.tar.gz", strings.NewReader("short"), 10)
+	c.Assert(err, gc.ErrorMatches, `writing backup archive "backup.tar.gz": expected 10 bytes, got 5`)
+
+	archives, err := stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archives, gc.HasLen, 0)
+}
+
+func (s *localSuite) TestInvalidName(c *gc.C) {
+	stor, err := remote.NewLocalStorage(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = stor.Put("../escape.tar.gz", strings.NewReader(""), 0)
+	c.Assert(err, gc.ErrorMatches, `backup archive name "../escape.tar.gz" not valid`)
+}
+
+func (s *localSuite) TestRemoveNotFound(c *gc.C) {
+	stor, err := remote.NewLocalStorage(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = stor.Remove("missing.tar.gz")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `backup archive "missing.tar.gz" not found`)
+}
@@ -0,0 +1,378 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remote
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+)
+
+const (
+	// emptyPayloadHash is the SHA256 hash of an empty request body.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	// unsignedPayload is used in place of the payload hash when
+	// uploading archives, so they can be streamed rather than read
+	// twice.
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+
+	amzDateFormat = "20060102T150405Z"
+)
+
+// S3Config holds the details needed to store archives in an
+// S3-compatible service.
+type S3Config struct {
+	// Endpoint is the URL of the service, eg "https://s3.amazonaws.com".
+	// Buckets are always addressed using path-style URLs, so that
+	// services other than AWS can be used.
+	Endpoint string
+
+	// Region is the region used when signing requests.
+	Region string
+
+	// Bucket is the name of the bucket holding the archives.
+	Bucket string
+
+	// Prefix, if not empty, is prepended to the name of each archive
+	// to make its key within the bucket.
+	Prefix string
+
+	// AccessKey and SecretKey are the credentials used to sign
+	// requests.
+	AccessKey string
+	SecretKey string
+
+	// HTTPClient is used to make requests to the service. If it is
+	// nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// Clock is used to timestamp requests. If it is nil, the wall
+	// clock is used.
+	Clock clock.Clock
+}
+
+// Validate returns an error if the config is not valid.
+func (config S3Config) Validate() error {
+	if config.Endpoint == "" {
+		return errors.NotValidf("empty Endpoint")
+	}
+	if config.Region == "" {
+		return errors.NotValidf("empty Region")
+	}
+	if config.Bucket == "" {
+		return errors.NotValidf("empty Bucket")
+	}
+	if config.AccessKey == "" || config.SecretKey == "" {
+		return errors.NotValidf("missing credentials")
+	}
+	return nil
+}
+
+type s3Storage struct {
+	config   S3Config
+	endpoint *url.URL
+}
+
+// NewS3Storage returns a Storage that keeps archives in a bucket of an
+// S3-compatible service.
+func NewS3Storage(config S3Config) (Storage, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing S3 endpoint")
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	if config.Clock == nil {
+		config.Clock = clock.WallClock
+	}
+	config.Prefix = strings.Trim(config.Prefix, "/")
+	return &s3Storage{
+		config:   config,
+		endpoint: endpoint,
+	}, nil
+}
+
+// Put is part of the Storage interface.
+func (s *s3Storage) Put(name string, r io.Reader, size int64) error {
+	req, err := s.newRequest("PUT", s.key(name), nil, r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/x-tar-gz")
+	resp, err := s.do(req, unsignedPayload)
+	if err != nil {
+		return errors.Annotatef(err, "uploading backup archive %q", name)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// List is part of the Storage interface.
+func (s *s3Storage) List() ([]Archive, error) {
+	prefix := ""
+	if s.config.Prefix != "" {
+		prefix = s.config.Prefix + "/"
+	}
+	var archives []Archive
+	var token string
+	for {
+		query := url.Values{
+			"list-type": {"2"},
+			"prefix":    {prefix},
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		req, err := s.newRequest("GET", "", query, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		resp, err := s.do(req, emptyPayloadHash)
+		if err != nil {
+			return nil, errors.Annotate(err, "listing backup archives")
+		}
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Annotate(err, "decoding backup archive list")
+		}
+		for _, object := range result.Contents {
+			name := strings.TrimPrefix(object.Key, prefix)
+			if name == "" || strings.Contains(name, "/") {
+				continue
+			}
+			archives = append(archives, Archive{
+				Name:     name,
+				Size:     object.Size,
+				Modified: object.LastModified.UTC(),
+			})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return archives, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// Remove is part of the Storage interface.
+func (s *s3Storage) Remove(name string) error {
+	// S3 reports success when deleting a key that does not exist, so
+	// check for it first.
+	req, err := s.newRequest("HEAD", s.key(name), nil, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := s.do(req, emptyPayloadHash)
+	if errors.IsNotFound(err) {
+		return errors.NotFoundf("backup archive %q", name)
+	} else if err != nil {
+		return errors.Annotatef(err, "removing backup archive %q", name)
+	}
+	resp.Body.Close()
+
+	req, err = s.newRequest("DELETE", s.key(name), nil, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err = s.do(req, emptyPayloadHash)
+	if err != nil {
+		return errors.Annotatef(err, "removing backup archive %q", name)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *s3Storage) key(name string) string {
+	if s.config.Prefix == "" {
+		return name
+	}
+	return s.config.Prefix + "/" + name
+}
+
+// newRequest returns a request for the given key in the bucket, or
+// for the bucket itself if key is empty.
+func (s *s3Storage) newRequest(method, key string, query url.Values, body io.Reader) (*http.Request, error) {
+	u := *s.endpoint
+	u.Path = path.Join("/", u.Path, s.config.Bucket)
+	if key != "" {
+		u.Path += "/" + key
+	}
+	u.RawPath = escapePath(u.Path)
+	u.RawQuery = canonicalQuery(query)
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return req, nil
+}
+
+// do signs and sends the request, returning an error if the response
+// does not indicate success. A 404 response is returned as an error
+// satisfying errors.IsNotFound.
+func (s *s3Storage) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	signRequest(req, s.config.AccessKey, s.config.SecretKey, s.config.Region, s.config.Clock.Now(), payloadHash)
+	resp, err := s.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	var s3err struct {
+		Code    string
+		Message string
+	}
+	msg := resp.Status
+	if xml.Unmarshal(body, &s3err) == nil && s3err.Code != "" {
+		msg = fmt.Sprintf("%s: %s", s3err.Code, s3err.Message)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.NewNotFound(nil, msg)
+	}
+	return nil, errors.New(msg)
+}
+
+type listBucketResult struct {
+	IsTruncated           bool
+	NextContinuationToken string
+	Contents              []struct {
+		Key          string
+		LastModified time.Time
+		Size         int64
+	}
+}
+
+// signRequest adds AWS signature version 4 authentication to the
+// request. All headers already set on the request are signed, along
+// with the host and the x-amz-date and x-amz-content-sha256 headers
+// that it adds.
+func signRequest(req *http.Request, accessKey, secretKey, region string, now time.Time, payloadHash string) {
+	now = now.UTC()
+	amzDate := now.Format(amzDateFormat)
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, headers[name])
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, "s3", "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hashHex(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+// canonicalQuery returns the query string encoded as AWS requires for
+// signing, with the parameters sorted by name.
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var parts []string
+	for _, name := range names {
+		for _, value := range query[name] {
+			parts = append(parts, escape(name, false)+"="+escape(value, false))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func escapePath(p string) string {
+	return escape(p, true)
+}
+
+// escape percent-encodes all but the unreserved characters of s, as
+// required for AWS signatures. Slashes are left alone if keepSlash is
+// true.
+func escape(s string, keepSlash bool) string {
+	var buf bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '.', c == '_', c == '~':
+			buf.WriteByte(c)
+		case c == '/' && keepSlash:
+			buf.WriteByte(c)
+		default:
+			fmt.Fprintf(&buf, "%%%02X", c)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,73 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remote provides storage for backup archives outside of the
+// controller, so that they survive the loss of the controller itself.
+package remote
+
+import (
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+)
+
+// Archive describes a backup archive held in remote storage.
+type Archive struct {
+	// Name is the name of the archive within the storage.
+	Name string
+
+	// Size is the size of the archive in bytes.
+	Size int64
+
+	// Modified is when the archive was last written.
+	Modified time.Time
+}
+
+// Storage is a place to keep backup archives off the controller.
+type Storage interface {
+	// Put stores the archive read from r under the given name,
+	// replacing any archive already stored with that name. The size
+	// is the number of bytes that will be read from r.
+	Put(name string, r io.Reader, size int64) error
+
+	// List returns all the archives held in the storage.
+	List() ([]Archive, error)
+
+	// Remove deletes the archive with the given name. It returns an
+	// error satisfying errors.IsNotFound if there is no such archive.
+	Remove(name string) error
+}
+
+// NewStorage returns the storage described by the backup target in
+// the given controller config. It returns an error satisfying
+// errors.IsNotFound if no backup target is configured.
+func NewStorage(cfg controller.Config) (Storage, error) {
+	target := cfg.BackupTarget()
+	if target == "" {
+		return nil, errors.NotFoundf("backup target")
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing backup target")
+	}
+	switch u.Scheme {
+	case "file":
+		return NewLocalStorage(u.Path)
+	case "s3":
+		accessKey, secretKey := cfg.BackupS3Credentials()
+		return NewS3Storage(S3Config{
+			Endpoint:  cfg.BackupS3Endpoint(),
+			Region:    cfg.BackupS3Region(),
+			Bucket:    u.Host,
+			Prefix:    strings.Trim(u.Path, "/"),
+			AccessKey: accessKey,
+			SecretKey: secretKey,
+		})
+	}
+	return nil, errors.NotValidf("backup target scheme %q", u.Scheme)
+}
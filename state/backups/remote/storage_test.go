@@ -0,0 +1,56 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remote_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups/remote"
+)
+
+type storageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) TestNoTarget(c *gc.C) {
+	_, err := remote.NewStorage(controller.Config{})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageSuite) TestFileTarget(c *gc.C) {
+	dir := filepath.Join(c.MkDir(), "backups")
+	stor, err := remote.NewStorage(controller.Config{
+		controller.BackupTarget: "file://" + dir,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stor, gc.NotNil)
+	_, err = os.Stat(dir)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageSuite) TestS3Target(c *gc.C) {
+	stor, err := remote.NewStorage(controller.Config{
+		controller.BackupTarget:      "s3://bucket/prefix",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stor, gc.NotNil)
+}
+
+func (s *storageSuite) TestS3TargetMissingCredentials(c *gc.C) {
+	_, err := remote.NewStorage(controller.Config{
+		controller.BackupTarget: "s3://bucket/prefix",
+	})
+	c.Assert(err, gc.ErrorMatches, "missing credentials not valid")
+}
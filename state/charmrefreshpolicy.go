@@ -0,0 +1,321 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	charmresource "gopkg.in/juju/charm.v6/resource"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/application"
+)
+
+// charmRefreshPolicyDoc records the policy by which an application's
+// charm is automatically upgraded, along with the most recent action
+// taken under it.
+type charmRefreshPolicyDoc struct {
+	Window         string        `bson:"window"`
+	Duration       time.Duration `bson:"duration"`
+	PatchOnly      bool          `bson:"patch-only"`
+	CanaryBranch   string        `bson:"canary-branch,omitempty"`
+	CanaryCharmURL string        `bson:"canary-charm-url,omitempty"`
+	LastRefreshed  time.Time     `bson:"last-refreshed,omitempty"`
+	LastMessage    string        `bson:"last-message,omitempty"`
+	LatestCharmURL string        `bson:"latest-charm-url,omitempty"`
+	LatestChannel  string        `bson:"latest-channel,omitempty"`
+}
+
+// CharmRefreshPolicy represents the policy by which an application's
+// charm is automatically upgraded to newer revisions in its channel.
+type CharmRefreshPolicy struct {
+	appName string
+	doc     charmRefreshPolicyDoc
+}
+
+// ApplicationName returns the name of the application the policy
+// applies to.
+func (p *CharmRefreshPolicy) ApplicationName() string {
+	return p.appName
+}
+
+// Policy returns the parameters of the policy.
+func (p *CharmRefreshPolicy) Policy() application.RefreshPolicy {
+	return application.RefreshPolicy{
+		Window:       p.doc.Window,
+		Duration:     p.doc.Duration,
+		PatchOnly:    p.doc.PatchOnly,
+		CanaryBranch: p.doc.CanaryBranch,
+	}
+}
+
+// CanaryCharmURL returns the URL of the charm staged on the policy's
+// canary branch, if any.
+func (p *CharmRefreshPolicy) CanaryCharmURL() string {
+	return p.doc.CanaryCharmURL
+}
+
+// LastRefreshed returns when the policy last acted, or the zero time
+// if it never has.
+func (p *CharmRefreshPolicy) LastRefreshed() time.Time {
+	return p.doc.LastRefreshed
+}
+
+// LastMessage returns a description of the policy's most recent action.
+func (p *CharmRefreshPolicy) LastMessage() string {
+	return p.doc.LastMessage
+}
+
+// LatestCharmURL returns the URL of the latest revision of the
+// application's charm in the given channel, as last recorded by
+// SetCharmRefreshLatest, or nil if none is recorded for the channel.
+func (p *CharmRefreshPolicy) LatestCharmURL(channel csparams.Channel) *charm.URL {
+	if p.doc.LatestCharmURL == "" || p.doc.LatestChannel != string(channel) {
+		return nil
+	}
+	curl, err := charm.ParseURL(p.doc.LatestCharmURL)
+	if err != nil {
+		return nil
+	}
+	return curl
+}
+
+// CharmRefreshPolicy returns the application's charm refresh policy.
+// A not-found error is returned if the application has none.
+func (a *Application) CharmRefreshPolicy() (*CharmRefreshPolicy, error) {
+	if a.doc.CharmRefreshPolicy == nil {
+		return nil, errors.NotFoundf("charm refresh policy for application %q", a.doc.Name)
+	}
+	return &CharmRefreshPolicy{appName: a.doc.Name, doc: *a.doc.CharmRefreshPolicy}, nil
+}
+
+// SetCharmRefreshPolicy sets the policy by which the application's charm
+// is automatically upgraded. A nil policy removes any existing policy.
+// A charm staged on the previous policy's canary branch is forgotten
+// if the canary branch changes.
+func (a *Application) SetCharmRefreshPolicy(policy *application.RefreshPolicy) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set charm refresh policy for application %q", a)
+	var doc *charmRefreshPolicyDoc
+	update := bson.D{{"$unset", bson.D{{"charm-refresh-policy", nil}}}}
+	if policy != nil {
+		if err := policy.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		doc = &charmRefreshPolicyDoc{
+			Window:       policy.Window,
+			Duration:     policy.Duration,
+			PatchOnly:    policy.PatchOnly,
+			CanaryBranch: policy.CanaryBranch,
+		}
+		if prev := a.doc.CharmRefreshPolicy; prev != nil {
+			doc.LastRefreshed = prev.LastRefreshed
+			doc.LastMessage = prev.LastMessage
+			doc.LatestCharmURL = prev.LatestCharmURL
+			doc.LatestChannel = prev.LatestChannel
+			if prev.CanaryBranch == doc.CanaryBranch {
+				doc.CanaryCharmURL = prev.CanaryCharmURL
+			}
+		}
+		update = bson.D{{"$set", bson.D{{"charm-refresh-policy", doc}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return onAbort(err, applicationNotAliveErr)
+	}
+	a.doc.CharmRefreshPolicy = doc
+	return nil
+}
+
+// SetCharmRefreshLatest records the latest revision of the application's
+// charm in the given channel, to which the application is upgraded by
+// its charm refresh policy.
+func (a *Application) SetCharmRefreshLatest(curl *charm.URL, channel csparams.Channel) error {
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: bson.D{{"charm-refresh-policy", bson.D{{"$exists", true}}}},
+		Update: bson.D{{"$set", bson.D{
+			{"charm-refresh-policy.latest-charm-url", curl.String()},
+			{"charm-refresh-policy.latest-channel", string(channel)},
+		}}},
+	}}
+	if err := a.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("charm refresh policy for application %q", a.doc.Name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot record latest charm for application %q", a.doc.Name)
+	}
+	if policy := a.doc.CharmRefreshPolicy; policy != nil {
+		policy.LatestCharmURL = curl.String()
+		policy.LatestChannel = string(channel)
+	}
+	return nil
+}
+
+// StageCharmRefreshCanary stages an upgrade of the application to the
+// input charm on the canary branch of its charm refresh policy. The
+// upgrade is made to the whole application by RefreshCharm once the
+// units tracking the branch are healthy.
+func (a *Application) StageCharmRefreshCanary(ch *Charm) error {
+	policy := a.doc.CharmRefreshPolicy
+	if policy == nil || policy.CanaryBranch == "" {
+		return errors.NotFoundf("charm refresh canary branch for application %q", a.doc.Name)
+	}
+	if skip, err := a.skipCharmRefresh(ch); err != nil || skip {
+		return errors.Trace(err)
+	}
+	if err := a.SetBranchCharm(policy.CanaryBranch, ch); err != nil {
+		return errors.Trace(err)
+	}
+	message := fmt.Sprintf("staged %s on branch %q", ch.URL(), policy.CanaryBranch)
+	return errors.Trace(a.recordCharmRefresh(ch.URL().String(), message))
+}
+
+// RefreshCharm upgrades the application to the input charm, from the
+// channel it tracks, under its charm refresh policy. Any charm staged
+// on the policy's canary branch is forgotten.
+func (a *Application) RefreshCharm(ch *Charm) error {
+	if a.doc.CharmRefreshPolicy == nil {
+		return errors.NotFoundf("charm refresh policy for application %q", a.doc.Name)
+	}
+	if skip, err := a.skipCharmRefresh(ch); err != nil || skip {
+		return errors.Trace(err)
+	}
+	if err := a.SetCharm(SetCharmConfig{
+		Charm:   ch,
+		Channel: a.Channel(),
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(a.recordCharmRefresh("", fmt.Sprintf("upgraded to %s", ch.URL())))
+}
+
+// skipCharmRefresh reports whether the upgrade to the input charm under
+// the application's charm refresh policy must be skipped because the
+// charm declares different resources to the application's charm. The
+// policy does not resolve resources, so the upgrade must be made with
+// upgrade-charm instead; the reason is recorded under the policy.
+func (a *Application) skipCharmRefresh(ch *Charm) (bool, error) {
+	current, _, err := a.Charm()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if !charmResourcesChanged(current.Meta().Resources, ch.Meta().Resources) {
+		return false, nil
+	}
+	message := fmt.Sprintf("not upgrading to %s: charm resources changed, use upgrade-charm", ch.URL())
+	if a.doc.CharmRefreshPolicy.LastMessage == message {
+		return true, nil
+	}
+	return true, errors.Trace(a.recordCharmRefresh("", message))
+}
+
+// charmResourcesChanged reports whether the resources declared by a
+// charm differ from those of the charm it replaces, ignoring their
+// descriptions.
+func charmResourcesChanged(current, next map[string]charmresource.Meta) bool {
+	if len(current) != len(next) {
+		return true
+	}
+	for name, meta := range next {
+		old, ok := current[name]
+		if !ok || old.Type != meta.Type || old.Path != meta.Path {
+			return true
+		}
+	}
+	return false
+}
+
+// CharmRefreshCanaryHealthy reports whether all of the application's
+// units tracking the canary branch of its charm refresh policy are
+// healthy on the charm staged there: running the charm, with an idle
+// agent and active workload. If the branch has been committed or
+// aborted, or no longer stages the charm, the canary is forgotten and
+// false is returned.
+func (a *Application) CharmRefreshCanaryHealthy() (bool, error) {
+	policy := a.doc.CharmRefreshPolicy
+	if policy == nil || policy.CanaryCharmURL == "" {
+		return false, errors.NotFoundf("charm refresh canary for application %q", a.doc.Name)
+	}
+	branch, err := a.st.Branch(policy.CanaryBranch)
+	if err != nil && !errors.IsNotFound(err) {
+		return false, errors.Trace(err)
+	}
+	if err != nil || branch.IsCompleted() || branch.CharmURLs()[a.doc.Name] != policy.CanaryCharmURL {
+		message := fmt.Sprintf("abandoned %s on branch %q", policy.CanaryCharmURL, policy.CanaryBranch)
+		if a.doc.CharmURL.String() == policy.CanaryCharmURL {
+			message = fmt.Sprintf("upgraded to %s by branch %q", policy.CanaryCharmURL, policy.CanaryBranch)
+		}
+		return false, errors.Trace(a.recordCharmRefresh("", message))
+	}
+
+	unitNames := branch.AssignedUnits()[a.doc.Name]
+	if len(unitNames) == 0 {
+		return false, nil
+	}
+	for _, name := range unitNames {
+		unit, err := a.st.Unit(name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		healthy, _, err := unit.charmRolloutHealth(policy.CanaryCharmURL)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if !healthy {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// recordCharmRefresh records the input canary charm URL and a
+// description of the action taken under the application's charm
+// refresh policy. The description is also added to the application's
+// status history.
+func (a *Application) recordCharmRefresh(canaryCharmURL, message string) error {
+	now := a.st.clock().Now()
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: bson.D{{"charm-refresh-policy", bson.D{{"$exists", true}}}},
+		Update: bson.D{{"$set", bson.D{
+			{"charm-refresh-policy.canary-charm-url", canaryCharmURL},
+			{"charm-refresh-policy.last-refreshed", now},
+			{"charm-refresh-policy.last-message", message},
+		}}},
+	}}
+	if err := a.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("charm refresh policy for application %q", a.doc.Name)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if policy := a.doc.CharmRefreshPolicy; policy != nil {
+		policy.CanaryCharmURL = canaryCharmURL
+		policy.LastRefreshed = now
+		policy.LastMessage = message
+	}
+
+	appStatus, err := a.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = probablyUpdateStatusHistory(a.st.db(), a.globalKey(), statusDoc{
+		Status:     appStatus.Status,
+		StatusInfo: "charm refresh policy: " + message,
+		Updated:    now.UnixNano(),
+	})
+	return errors.Trace(err)
+}
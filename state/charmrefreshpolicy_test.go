@@ -0,0 +1,229 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+type CharmRefreshPolicySuite struct {
+	ConnSuite
+	charm    *state.Charm
+	newCharm *state.Charm
+	mysql    *state.Application
+	unit     *state.Unit
+}
+
+var _ = gc.Suite(&CharmRefreshPolicySuite{})
+
+func (s *CharmRefreshPolicySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.charm = s.AddTestingCharm(c, "mysql")
+	s.newCharm = state.AddCustomCharm(c, s.State, "mysql", "", "", "quantal", 42)
+	s.mysql = s.AddTestingApplication(c, "mysql", s.charm)
+	var err error
+	s.unit, err = s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	// Ensure that status history recorded by the policy is newer
+	// than that recorded when the application was added.
+	s.Clock.Advance(time.Minute)
+}
+
+func (s *CharmRefreshPolicySuite) setPolicy(c *gc.C, canaryBranch string) {
+	err := s.mysql.SetCharmRefreshPolicy(&application.RefreshPolicy{
+		Window:       "0 2 * * SAT",
+		Duration:     2 * time.Hour,
+		PatchOnly:    true,
+		CanaryBranch: canaryBranch,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmRefreshPolicySuite) policy(c *gc.C) *state.CharmRefreshPolicy {
+	err := s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	p, err := s.mysql.CharmRefreshPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	return p
+}
+
+func (s *CharmRefreshPolicySuite) lastStatusInfo(c *gc.C) string {
+	history, err := s.mysql.StatusHistory(status.StatusHistoryFilter{Size: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	return history[0].Message
+}
+
+func (s *CharmRefreshPolicySuite) TestNoPolicy(c *gc.C) {
+	_, err := s.mysql.CharmRefreshPolicy()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmRefreshPolicySuite) TestSetCharmRefreshPolicy(c *gc.C) {
+	s.setPolicy(c, "")
+	c.Assert(s.policy(c).Policy(), jc.DeepEquals, application.RefreshPolicy{
+		Window:    "0 2 * * SAT",
+		Duration:  2 * time.Hour,
+		PatchOnly: true,
+	})
+
+	err := s.mysql.SetCharmRefreshPolicy(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.mysql.CharmRefreshPolicy()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmRefreshPolicySuite) TestSetCharmRefreshPolicyInvalid(c *gc.C) {
+	err := s.mysql.SetCharmRefreshPolicy(&application.RefreshPolicy{Window: "0 2 * * SAT"})
+	c.Assert(err, gc.ErrorMatches, `cannot set charm refresh policy for application "mysql": maintenance window duration 0s not valid`)
+}
+
+func (s *CharmRefreshPolicySuite) TestSetCharmRefreshLatest(c *gc.C) {
+	latest := charm.MustParseURL("cs:quantal/mysql-23")
+	err := s.mysql.SetCharmRefreshLatest(latest, csparams.StableChannel)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	s.setPolicy(c, "")
+	err = s.mysql.SetCharmRefreshLatest(latest, csparams.StableChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	p := s.policy(c)
+	c.Assert(p.LatestCharmURL(csparams.StableChannel), jc.DeepEquals, latest)
+	c.Assert(p.LatestCharmURL(csparams.EdgeChannel), gc.IsNil)
+
+	// The latest revision is kept when the policy changes.
+	s.setPolicy(c, "canary")
+	c.Assert(s.policy(c).LatestCharmURL(csparams.StableChannel), jc.DeepEquals, latest)
+}
+
+func (s *CharmRefreshPolicySuite) TestRefreshCharm(c *gc.C) {
+	s.setPolicy(c, "")
+	err := s.mysql.RefreshCharm(s.newCharm)
+	c.Assert(err, jc.ErrorIsNil)
+
+	curl, _ := s.mysql.CharmURL()
+	c.Assert(curl, jc.DeepEquals, s.newCharm.URL())
+	p := s.policy(c)
+	c.Assert(p.LastMessage(), gc.Equals, "upgraded to local:quantal/quantal-mysql-42")
+	c.Assert(p.LastRefreshed().IsZero(), jc.IsFalse)
+	c.Assert(s.lastStatusInfo(c), gc.Equals, "charm refresh policy: upgraded to local:quantal/quantal-mysql-42")
+}
+
+func (s *CharmRefreshPolicySuite) TestRefreshCharmResourcesChanged(c *gc.C) {
+	resourcesCharm := state.AddCustomCharm(c, s.State, "mysql", "metadata.yaml", `
+name: mysql
+summary: "Database engine"
+description: "A pretty popular database"
+provides:
+  server:
+    interface: mysql
+  server-admin:
+    interface: mysql-root
+requires:
+  metrics-client:
+    interface: metrics
+resources:
+  backup-tool:
+    type: file
+    filename: backup.tgz
+`, "quantal", 43)
+	s.setPolicy(c, "")
+	err := s.mysql.RefreshCharm(resourcesCharm)
+	c.Assert(err, jc.ErrorIsNil)
+
+	curl, _ := s.mysql.CharmURL()
+	c.Assert(curl, jc.DeepEquals, s.charm.URL())
+	message := "not upgrading to local:quantal/quantal-mysql-43: charm resources changed, use upgrade-charm"
+	c.Assert(s.policy(c).LastMessage(), gc.Equals, message)
+	c.Assert(s.lastStatusInfo(c), gc.Equals, "charm refresh policy: "+message)
+
+	// A canary is not staged either.
+	c.Assert(s.State.AddBranch("canary", "test-user"), jc.ErrorIsNil)
+	s.setPolicy(c, "canary")
+	err = s.mysql.StageCharmRefreshCanary(resourcesCharm)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.policy(c).CanaryCharmURL(), gc.Equals, "")
+}
+
+func (s *CharmRefreshPolicySuite) TestStageCharmRefreshCanary(c *gc.C) {
+	c.Assert(s.State.AddBranch("canary", "test-user"), jc.ErrorIsNil)
+	s.setPolicy(c, "canary")
+	err := s.mysql.StageCharmRefreshCanary(s.newCharm)
+	c.Assert(err, jc.ErrorIsNil)
+
+	curl, err := s.mysql.BranchCharmURL("canary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl, jc.DeepEquals, s.newCharm.URL())
+	curl, _ = s.mysql.CharmURL()
+	c.Assert(curl, jc.DeepEquals, s.charm.URL())
+
+	p := s.policy(c)
+	c.Assert(p.CanaryCharmURL(), gc.Equals, s.newCharm.URL().String())
+	c.Assert(p.LastMessage(), gc.Equals, `staged local:quantal/quantal-mysql-42 on branch "canary"`)
+	c.Assert(s.lastStatusInfo(c), gc.Equals, `charm refresh policy: staged local:quantal/quantal-mysql-42 on branch "canary"`)
+}
+
+func (s *CharmRefreshPolicySuite) TestStageCharmRefreshCanaryNoBranch(c *gc.C) {
+	s.setPolicy(c, "")
+	err := s.mysql.StageCharmRefreshCanary(s.newCharm)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmRefreshPolicySuite) TestCharmRefreshCanaryHealthy(c *gc.C) {
+	c.Assert(s.State.AddBranch("canary", "test-user"), jc.ErrorIsNil)
+	s.setPolicy(c, "canary")
+	err := s.mysql.StageCharmRefreshCanary(s.newCharm)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// No units are tracking the branch yet.
+	healthy, err := s.mysql.CharmRefreshCanaryHealthy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(healthy, jc.IsFalse)
+
+	branch, err := s.State.Branch("canary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.AssignUnit(s.unit.Name()), jc.ErrorIsNil)
+	healthy, err = s.mysql.CharmRefreshCanaryHealthy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(healthy, jc.IsFalse)
+
+	err = s.unit.SetCharmURL(s.newCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetAgentStatus(status.StatusInfo{Status: status.Idle})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetStatus(status.StatusInfo{Status: status.Active})
+	c.Assert(err, jc.ErrorIsNil)
+	healthy, err = s.mysql.CharmRefreshCanaryHealthy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(healthy, jc.IsTrue)
+}
+
+func (s *CharmRefreshPolicySuite) TestCharmRefreshCanaryAbandoned(c *gc.C) {
+	c.Assert(s.State.AddBranch("canary", "test-user"), jc.ErrorIsNil)
+	s.setPolicy(c, "canary")
+	err := s.mysql.StageCharmRefreshCanary(s.newCharm)
+	c.Assert(err, jc.ErrorIsNil)
+
+	branch, err := s.State.Branch("canary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.Abort("test-user"), jc.ErrorIsNil)
+
+	healthy, err := s.mysql.CharmRefreshCanaryHealthy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(healthy, jc.IsFalse)
+	p := s.policy(c)
+	c.Assert(p.CanaryCharmURL(), gc.Equals, "")
+	c.Assert(p.LastMessage(), gc.Equals, `abandoned local:quantal/quantal-mysql-42 on branch "canary"`)
+}
@@ -0,0 +1,403 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/status"
+)
+
+// CharmRolloutStatus describes the progress of a charm rollout.
+type CharmRolloutStatus string
+
+const (
+	// CharmRolloutRunning indicates that units are being handed the
+	// new charm.
+	CharmRolloutRunning CharmRolloutStatus = "running"
+
+	// CharmRolloutPaused indicates that a unit failed during the
+	// rollout. The rollout resumes once the failed units recover.
+	CharmRolloutPaused CharmRolloutStatus = "paused"
+
+	// CharmRolloutFailed indicates that a unit failed during the
+	// rollout, and that no further units will be upgraded.
+	CharmRolloutFailed CharmRolloutStatus = "failed"
+
+	// CharmRolloutCompleted indicates that all units have been
+	// upgraded to the new charm.
+	CharmRolloutCompleted CharmRolloutStatus = "completed"
+)
+
+// CharmRolloutParams describes how a charm upgrade is rolled out to the
+// units of an application.
+type CharmRolloutParams struct {
+	// BatchSize is the number of units handed the new charm at a time.
+	BatchSize int
+
+	// PauseOnError causes the rollout to pause, rather than fail, when
+	// a unit fails. A paused rollout resumes once the failed units
+	// recover.
+	PauseOnError bool
+
+	// LeaderLast causes the application leader to be upgraded last.
+	LeaderLast bool
+}
+
+// Validate returns an error if the parameters are not valid.
+func (p CharmRolloutParams) Validate() error {
+	if p.BatchSize <= 0 {
+		return errors.NotValidf("batch size %d", p.BatchSize)
+	}
+	return nil
+}
+
+// charmRolloutDoc records the progress of handing a new charm to the
+// units of an application a batch at a time. It is stored on the
+// application document so that the unit agents, which watch the
+// application, are notified as each batch is released.
+type charmRolloutDoc struct {
+	FromCharmURL             string             `bson:"from-charm-url"`
+	FromCharmModifiedVersion int                `bson:"from-charmmodifiedversion"`
+	ToCharmURL               string             `bson:"to-charm-url"`
+	BatchSize                int                `bson:"batch-size"`
+	PauseOnError             bool               `bson:"pause-on-error"`
+	LeaderLast               bool               `bson:"leader-last"`
+	Units                    []string           `bson:"units"`
+	Released                 int                `bson:"released"`
+	Status                   CharmRolloutStatus `bson:"status"`
+	Message                  string             `bson:"message,omitempty"`
+	Started                  time.Time          `bson:"started"`
+	Updated                  time.Time          `bson:"updated"`
+}
+
+// holdsBack reports whether the unit with the input name is yet to be
+// handed the new charm by the rollout.
+func (r *charmRolloutDoc) holdsBack(unitName string) bool {
+	if r == nil {
+		return false
+	}
+	for _, name := range r.Units[r.Released:] {
+		if name == unitName {
+			return true
+		}
+	}
+	return false
+}
+
+// CharmRollout represents the rollout of a charm upgrade to the units
+// of an application.
+type CharmRollout struct {
+	appName string
+	doc     charmRolloutDoc
+}
+
+// ApplicationName returns the name of the application being upgraded.
+func (r *CharmRollout) ApplicationName() string {
+	return r.appName
+}
+
+// FromCharmURL returns the URL of the charm being upgraded from.
+func (r *CharmRollout) FromCharmURL() string {
+	return r.doc.FromCharmURL
+}
+
+// ToCharmURL returns the URL of the charm being upgraded to.
+func (r *CharmRollout) ToCharmURL() string {
+	return r.doc.ToCharmURL
+}
+
+// BatchSize returns the number of units handed the new charm at a time.
+func (r *CharmRollout) BatchSize() int {
+	return r.doc.BatchSize
+}
+
+// PauseOnError reports whether the rollout pauses, rather than fails,
+// when a unit fails.
+func (r *CharmRollout) PauseOnError() bool {
+	return r.doc.PauseOnError
+}
+
+// LeaderLast reports whether the application leader is upgraded last.
+func (r *CharmRollout) LeaderLast() bool {
+	return r.doc.LeaderLast
+}
+
+// ReleasedUnits returns the names of the units that have been handed
+// the new charm, in the order in which they were released.
+func (r *CharmRollout) ReleasedUnits() []string {
+	return append([]string(nil), r.doc.Units[:r.doc.Released]...)
+}
+
+// PendingUnits returns the names of the units that are yet to be
+// handed the new charm, in the order in which they will be released.
+func (r *CharmRollout) PendingUnits() []string {
+	return append([]string(nil), r.doc.Units[r.doc.Released:]...)
+}
+
+// Status returns the status of the rollout.
+func (r *CharmRollout) Status() CharmRolloutStatus {
+	return r.doc.Status
+}
+
+// Message returns a description of the rollout's progress.
+func (r *CharmRollout) Message() string {
+	return r.doc.Message
+}
+
+// Started returns when the rollout started.
+func (r *CharmRollout) Started() time.Time {
+	return r.doc.Started
+}
+
+// Updated returns when the rollout last changed.
+func (r *CharmRollout) Updated() time.Time {
+	return r.doc.Updated
+}
+
+// CharmRollout returns the most recent charm rollout for the
+// application. A not-found error is returned if the application's
+// charm has never been upgraded in batches.
+func (a *Application) CharmRollout() (*CharmRollout, error) {
+	if a.doc.CharmRollout == nil {
+		return nil, errors.NotFoundf("charm rollout for application %q", a.doc.Name)
+	}
+	return &CharmRollout{appName: a.doc.Name, doc: *a.doc.CharmRollout}, nil
+}
+
+// newCharmRolloutDoc returns a document describing the rollout of the
+// charm with the input URL to the application's existing units.
+// If a previous rollout did not complete, the units it held back are
+// still running its original charm; the new rollout starts from there,
+// and immediately releases the units that were already upgraded.
+func (a *Application) newCharmRolloutDoc(p CharmRolloutParams, curl *charm.URL) (*charmRolloutDoc, error) {
+	if err := p.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var leader string
+	if p.LeaderLast {
+		leaders, err := a.st.ApplicationLeaders()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		leader = leaders[a.doc.Name]
+	}
+	now := a.st.clock().Now()
+	doc := &charmRolloutDoc{
+		FromCharmURL:             a.doc.CharmURL.String(),
+		FromCharmModifiedVersion: a.doc.CharmModifiedVersion,
+		ToCharmURL:               curl.String(),
+		BatchSize:                p.BatchSize,
+		PauseOnError:             p.PauseOnError,
+		LeaderLast:               p.LeaderLast,
+		Status:                   CharmRolloutRunning,
+		Started:                  now,
+		Updated:                  now,
+	}
+	prev := a.doc.CharmRollout
+	incomplete := prev != nil && prev.Released < len(prev.Units)
+	if incomplete {
+		doc.FromCharmURL = prev.FromCharmURL
+		doc.FromCharmModifiedVersion = prev.FromCharmModifiedVersion
+	}
+
+	var upgraded, pending []string
+	for _, u := range units {
+		if incomplete && !prev.holdsBack(u.Name()) {
+			upgraded = append(upgraded, u.Name())
+		} else {
+			pending = append(pending, u.Name())
+		}
+	}
+	sortUnitNames(pending)
+	if leader != "" {
+		for i, name := range pending {
+			if name == leader {
+				pending = append(append(pending[:i:i], pending[i+1:]...), leader)
+				break
+			}
+		}
+	}
+	sortUnitNames(upgraded)
+	doc.Units = append(upgraded, pending...)
+	doc.Released = len(upgraded) + p.BatchSize
+	if doc.Released >= len(doc.Units) {
+		doc.Released = len(doc.Units)
+		doc.Status = CharmRolloutCompleted
+	} else {
+		doc.Message = fmt.Sprintf("upgrading %s", strings.Join(doc.Units[len(upgraded):doc.Released], ", "))
+	}
+	return doc, nil
+}
+
+// charmRolloutOp returns an operation recording the rollout of the charm
+// with the input URL to the application's units according to the input
+// parameters, along with the rollout document. If the parameters are
+// nil, the charm is handed to all units at once: any previous rollout
+// is removed, and a nil operation is returned if there is none.
+func (a *Application) charmRolloutOp(p *CharmRolloutParams, curl *charm.URL) (*txn.Op, *charmRolloutDoc, error) {
+	if p == nil {
+		if a.doc.CharmRollout == nil {
+			return nil, nil, nil
+		}
+		return &txn.Op{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Update: bson.D{{"$unset", bson.D{{"charm-rollout", nil}}}},
+		}, nil, nil
+	}
+	doc, err := a.newCharmRolloutDoc(*p, curl)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return &txn.Op{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Update: bson.D{{"$set", bson.D{{"charm-rollout", doc}}}},
+	}, doc, nil
+}
+
+// sortUnitNames sorts the input unit names by unit number.
+func sortUnitNames(unitNames []string) {
+	sort.Slice(unitNames, func(i, j int) bool {
+		return names.NewUnitTag(unitNames[i]).Number() < names.NewUnitTag(unitNames[j]).Number()
+	})
+}
+
+// UpdateCharmRollouts advances the charm rollouts in progress in the
+// model. The next batch of units of each application is handed the new
+// charm once the units already upgraded are healthy: running the new
+// charm, with an idle agent and active workload. A rollout is paused or
+// failed, according to its parameters, when an upgraded unit fails.
+func (st *State) UpdateCharmRollouts() error {
+	applications, closer := st.db().GetCollection(applicationsC)
+	defer closer()
+
+	var docs []applicationDoc
+	err := applications.Find(bson.D{{
+		"charm-rollout.status", bson.D{{"$in", []CharmRolloutStatus{
+			CharmRolloutRunning, CharmRolloutPaused,
+		}}},
+	}}).All(&docs)
+	if err != nil {
+		return errors.Annotate(err, "cannot get applications with charm rollouts")
+	}
+	for i := range docs {
+		app := newApplication(st, &docs[i])
+		if err := app.advanceCharmRollout(); err != nil {
+			logger.Errorf("cannot advance charm rollout for application %q: %v", app.Name(), err)
+		}
+	}
+	return nil
+}
+
+// advanceCharmRollout checks the health of the units already handed
+// the new charm and, if they are all healthy, releases the next batch.
+func (a *Application) advanceCharmRollout() error {
+	r := a.doc.CharmRollout
+	var waiting, failed []string
+	for _, name := range r.Units[:r.Released] {
+		unit, err := a.st.Unit(name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		healthy, failure, err := unit.charmRolloutHealth(r.ToCharmURL)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if failure != "" {
+			failed = append(failed, failure)
+		} else if !healthy {
+			waiting = append(waiting, name)
+		}
+	}
+
+	released := r.Released
+	var rolloutStatus CharmRolloutStatus
+	var message string
+	switch {
+	case len(failed) > 0:
+		rolloutStatus = CharmRolloutFailed
+		if r.PauseOnError {
+			rolloutStatus = CharmRolloutPaused
+		}
+		message = "unit failed: " + strings.Join(failed, "; ")
+	case len(waiting) > 0:
+		rolloutStatus = CharmRolloutRunning
+		message = "waiting for " + strings.Join(waiting, ", ")
+	case released == len(r.Units):
+		rolloutStatus = CharmRolloutCompleted
+	default:
+		released += r.BatchSize
+		if released > len(r.Units) {
+			released = len(r.Units)
+		}
+		rolloutStatus = CharmRolloutRunning
+		message = "upgrading " + strings.Join(r.Units[r.Released:released], ", ")
+	}
+	if released == r.Released && rolloutStatus == r.Status && message == r.Message {
+		return nil
+	}
+
+	ops := []txn.Op{{
+		C:  applicationsC,
+		Id: a.doc.DocID,
+		Assert: bson.D{
+			{"charm-rollout.to-charm-url", r.ToCharmURL},
+			{"charm-rollout.status", r.Status},
+			{"charm-rollout.released", r.Released},
+		},
+		Update: bson.D{{"$set", bson.D{
+			{"charm-rollout.released", released},
+			{"charm-rollout.status", rolloutStatus},
+			{"charm-rollout.message", message},
+			{"charm-rollout.updated", a.st.clock().Now()},
+		}}},
+	}}
+	if err := a.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		// The rollout was replaced or advanced concurrently;
+		// it will be checked again on the next update.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// charmRolloutHealth reports whether the unit is running the charm with
+// the input URL, with an idle agent and an active workload. If the unit
+// is in error, a description of the failure is returned.
+func (u *Unit) charmRolloutHealth(curl string) (bool, string, error) {
+	agentStatus, err := u.AgentStatus()
+	if err != nil {
+		return false, "", errors.Trace(err)
+	}
+	if agentStatus.Status == status.Error {
+		return false, fmt.Sprintf("%s: %s", u.Name(), agentStatus.Message), nil
+	}
+	unitURL, _ := u.CharmURL()
+	if unitURL == nil || unitURL.String() != curl || agentStatus.Status != status.Idle {
+		return false, "", nil
+	}
+	workloadStatus, err := u.Status()
+	if err != nil {
+		return false, "", errors.Trace(err)
+	}
+	return workloadStatus.Status == status.Active, "", nil
+}
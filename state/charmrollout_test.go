@@ -0,0 +1,236 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+type CharmRolloutSuite struct {
+	ConnSuite
+	charm    *state.Charm
+	newCharm *state.Charm
+	mysql    *state.Application
+	units    []*state.Unit
+}
+
+var _ = gc.Suite(&CharmRolloutSuite{})
+
+func (s *CharmRolloutSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.charm = s.AddTestingCharm(c, "mysql")
+	s.newCharm = state.AddCustomCharm(c, s.State, "mysql", "", "", "quantal", 42)
+	s.mysql = s.AddTestingApplication(c, "mysql", s.charm)
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		unit, err := s.mysql.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.SetCharmURL(s.charm.URL())
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+}
+
+func (s *CharmRolloutSuite) setCharm(c *gc.C, rollout *state.CharmRolloutParams) *state.CharmRollout {
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:   s.newCharm,
+		Rollout: rollout,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	r, err := s.mysql.CharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	return r
+}
+
+func (s *CharmRolloutSuite) rollout(c *gc.C) *state.CharmRollout {
+	err := s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	r, err := s.mysql.CharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	return r
+}
+
+func (s *CharmRolloutSuite) setHealthy(c *gc.C, unit *state.Unit) {
+	err := unit.SetCharmURL(s.newCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetAgentStatus(status.StatusInfo{Status: status.Idle})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetStatus(status.StatusInfo{Status: status.Active})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmRolloutSuite) TestNoRollout(c *gc.C) {
+	_, err := s.mysql.CharmRollout()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmRolloutSuite) TestSetCharmInvalidBatchSize(c *gc.C) {
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:   s.newCharm,
+		Rollout: &state.CharmRolloutParams{},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "mysql" to charm "local:quantal/quantal-mysql-42": batch size 0 not valid`)
+}
+
+func (s *CharmRolloutSuite) TestSetCharmReleasesFirstBatch(c *gc.C) {
+	r := s.setCharm(c, &state.CharmRolloutParams{BatchSize: 1})
+	c.Check(r.ApplicationName(), gc.Equals, "mysql")
+	c.Check(r.FromCharmURL(), gc.Equals, s.charm.URL().String())
+	c.Check(r.ToCharmURL(), gc.Equals, s.newCharm.URL().String())
+	c.Check(r.Status(), gc.Equals, state.CharmRolloutRunning)
+	c.Check(r.Message(), gc.Equals, "upgrading mysql/0")
+	c.Check(r.ReleasedUnits(), jc.DeepEquals, []string{"mysql/0"})
+	c.Check(r.PendingUnits(), jc.DeepEquals, []string{"mysql/1", "mysql/2"})
+
+	curl, _, err := s.units[0].ApplicationCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(curl, gc.DeepEquals, s.newCharm.URL())
+	version, err := s.units[0].ApplicationCharmModifiedVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, s.mysql.CharmModifiedVersion())
+
+	// Units held back see the charm from before the rollout.
+	curl, force, err := s.units[1].ApplicationCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(curl, gc.DeepEquals, s.charm.URL())
+	c.Check(force, jc.IsFalse)
+	version, err = s.units[1].ApplicationCharmModifiedVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, s.mysql.CharmModifiedVersion()-1)
+
+	// New units use the new charm.
+	unit, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _, err = unit.ApplicationCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(curl, gc.DeepEquals, s.newCharm.URL())
+}
+
+func (s *CharmRolloutSuite) TestSetCharmLeaderLast(c *gc.C) {
+	target := s.State.LeaseNotifyTarget(ioutil.Discard, loggo.GetLogger("charmrollout_test"))
+	target.Claimed(lease.Key{"application-leadership", s.State.ModelUUID(), "mysql"}, "mysql/0")
+
+	r := s.setCharm(c, &state.CharmRolloutParams{BatchSize: 2, LeaderLast: true})
+	c.Check(r.ReleasedUnits(), jc.DeepEquals, []string{"mysql/1", "mysql/2"})
+	c.Check(r.PendingUnits(), jc.DeepEquals, []string{"mysql/0"})
+}
+
+func (s *CharmRolloutSuite) TestUpdateCharmRollouts(c *gc.C) {
+	s.setCharm(c, &state.CharmRolloutParams{BatchSize: 2})
+
+	// The released units are not yet healthy.
+	err := s.State.UpdateCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	r := s.rollout(c)
+	c.Check(r.Status(), gc.Equals, state.CharmRolloutRunning)
+	c.Check(r.Message(), gc.Equals, "waiting for mysql/0, mysql/1")
+	c.Check(r.PendingUnits(), jc.DeepEquals, []string{"mysql/2"})
+
+	s.setHealthy(c, s.units[0])
+	s.setHealthy(c, s.units[1])
+	err = s.State.UpdateCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	r = s.rollout(c)
+	c.Check(r.Status(), gc.Equals, state.CharmRolloutRunning)
+	c.Check(r.Message(), gc.Equals, "upgrading mysql/2")
+	c.Check(r.PendingUnits(), gc.HasLen, 0)
+
+	s.setHealthy(c, s.units[2])
+	err = s.State.UpdateCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	r = s.rollout(c)
+	c.Check(r.Status(), gc.Equals, state.CharmRolloutCompleted)
+	c.Check(r.Message(), gc.Equals, "")
+}
+
+func (s *CharmRolloutSuite) TestUpdateCharmRolloutsFailed(c *gc.C) {
+	s.setCharm(c, &state.CharmRolloutParams{BatchSize: 1})
+	err := s.units[0].SetAgentStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: `hook failed: "upgrade-charm"`,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.UpdateCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	r := s.rollout(c)
+	c.Check(r.Status(), gc.Equals, state.CharmRolloutFailed)
+	c.Check(r.Message(), gc.Equals, `unit failed: mysql/0: hook failed: "upgrade-charm"`)
+
+	// A failed rollout is not advanced, even when the unit recovers.
+	s.setHealthy(c, s.units[0])
+	err = s.State.UpdateCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	r = s.rollout(c)
+	c.Check(r.Status(), gc.Equals, state.CharmRolloutFailed)
+	c.Check(r.PendingUnits(), jc.DeepEquals, []string{"mysql/1", "mysql/2"})
+}
+
+func (s *CharmRolloutSuite) TestUpdateCharmRolloutsPauseOnError(c *gc.C) {
+	s.setCharm(c, &state.CharmRolloutParams{BatchSize: 1, PauseOnError: true})
+	err := s.units[0].SetAgentStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: `hook failed: "upgrade-charm"`,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.UpdateCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	r := s.rollout(c)
+	c.Check(r.Status(), gc.Equals, state.CharmRolloutPaused)
+
+	// The rollout resumes once the unit recovers.
+	s.setHealthy(c, s.units[0])
+	err = s.State.UpdateCharmRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	r = s.rollout(c)
+	c.Check(r.Status(), gc.Equals, state.CharmRolloutRunning)
+	c.Check(r.ReleasedUnits(), jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+}
+
+func (s *CharmRolloutSuite) TestSetCharmWithoutRolloutReleasesAll(c *gc.C) {
+	s.setCharm(c, &state.CharmRolloutParams{BatchSize: 1})
+
+	ch := state.AddCustomCharm(c, s.State, "mysql", "", "", "quantal", 43)
+	err := s.mysql.SetCharm(state.SetCharmConfig{Charm: ch})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.mysql.CharmRollout()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	curl, _, err := s.units[2].ApplicationCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(curl, gc.DeepEquals, ch.URL())
+}
+
+func (s *CharmRolloutSuite) TestSetCharmReplacesIncompleteRollout(c *gc.C) {
+	s.setCharm(c, &state.CharmRolloutParams{BatchSize: 1})
+
+	ch := state.AddCustomCharm(c, s.State, "mysql", "", "", "quantal", 43)
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:   ch,
+		Rollout: &state.CharmRolloutParams{BatchSize: 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	r := s.rollout(c)
+
+	// The unit upgraded by the previous rollout is released at once,
+	// and the units it held back still run the original charm.
+	c.Check(r.FromCharmURL(), gc.Equals, s.charm.URL().String())
+	c.Check(r.ReleasedUnits(), jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+	c.Check(r.PendingUnits(), jc.DeepEquals, []string{"mysql/2"})
+	curl, _, err := s.units[2].ApplicationCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(curl, gc.DeepEquals, s.charm.URL())
+}
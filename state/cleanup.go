@@ -956,6 +956,13 @@ func (st *State) cleanupRemovedUnit(unitId string, cleanupArgs []bson.Raw) error
 		}
 	}
 
+	if err := st.removeActionSchedulesForUnit(unitId); err != nil {
+		if !force {
+			return errors.Trace(err)
+		}
+		logger.Warningf("could not remove action schedules for unit %v during cleanup of removed unit: %v", unitId, err)
+	}
+
 	change := payloadCleanupChange{
 		Unit: unitId,
 	}
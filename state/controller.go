@@ -18,6 +18,11 @@ const (
 	// controllerSettingsGlobalKey is the key for the controller and its settings.
 	controllerSettingsGlobalKey = "controllerSettings"
 
+	// controllerSecretsGlobalKey is the key for the controller config
+	// attributes which are kept secret; see
+	// controller.SecretConfigAttributes.
+	controllerSecretsGlobalKey = "controllerSecrets"
+
 	// controllerGlobalKey is the key for controller.
 	controllerGlobalKey = "c"
 )
@@ -79,6 +84,7 @@ func (ctlr *Controller) Ping() error {
 }
 
 // ControllerConfig returns the config values for the controller.
+// Secret values are not included; see ControllerSecretConfig.
 func (st *State) ControllerConfig() (jujucontroller.Config, error) {
 	settings, err := readSettings(st.db(), controllersC, controllerSettingsGlobalKey)
 	if err != nil {
@@ -87,6 +93,44 @@ func (st *State) ControllerConfig() (jujucontroller.Config, error) {
 	return settings.Map(), nil
 }
 
+// ControllerSecretConfig returns the controller config values which
+// are kept apart from the rest of the config, so that they are never
+// returned by ControllerConfig.
+func (st *State) ControllerSecretConfig() (map[string]interface{}, error) {
+	secrets, _, err := st.controllerSecretSettings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secrets.Map(), nil
+}
+
+// controllerSecretSettings returns the settings holding the controller's
+// secret config, and whether they have been written yet.
+func (st *State) controllerSecretSettings() (*Settings, bool, error) {
+	secrets, err := readSettings(st.db(), controllersC, controllerSecretsGlobalKey)
+	if errors.IsNotFound(err) {
+		return newSettings(st.db(), controllersC, controllerSecretsGlobalKey), false, nil
+	} else if err != nil {
+		return nil, false, errors.Annotatef(err, "controller %q secrets", st.ControllerUUID())
+	}
+	return secrets, true, nil
+}
+
+// splitControllerSecrets separates the controller config attributes
+// which are kept secret from the rest.
+func splitControllerSecrets(attrs map[string]interface{}) (config, secrets map[string]interface{}) {
+	config = make(map[string]interface{})
+	secrets = make(map[string]interface{})
+	for k, v := range attrs {
+		if jujucontroller.SecretConfigAttributes.Contains(k) {
+			secrets[k] = v
+		} else {
+			config[k] = v
+		}
+	}
+	return config, secrets
+}
+
 // UpdateControllerConfig allows changing some of the configuration
 // for the controller. Changes passed in updateAttrs will be applied
 // to the current config, and keys in removeAttrs will be unset (and
@@ -101,13 +145,26 @@ func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, remo
 	if err != nil {
 		return errors.Annotatef(err, "controller %q", st.ControllerUUID())
 	}
+	secrets, secretsExist, err := st.controllerSecretSettings()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	for _, r := range removeAttrs {
-		settings.Delete(r)
+		if jujucontroller.SecretConfigAttributes.Contains(r) {
+			secrets.Delete(r)
+		} else {
+			settings.Delete(r)
+		}
 	}
-	settings.Update(updateAttrs)
+	configAttrs, secretAttrs := splitControllerSecrets(updateAttrs)
+	settings.Update(configAttrs)
+	secrets.Update(secretAttrs)
 
 	// Ensure the resulting config is still valid.
 	newValues := settings.Map()
+	for k, v := range secrets.Map() {
+		newValues[k] = v
+	}
 	_, err = jujucontroller.NewConfig(
 		newValues[jujucontroller.ControllerUUIDKey].(string),
 		newValues[jujucontroller.CACertKey].(string),
@@ -118,6 +175,12 @@ func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, remo
 	}
 
 	_, ops := settings.settingsUpdateOps()
+	if !secretsExist {
+		ops = append(ops, createSettingsOp(controllersC, controllerSecretsGlobalKey, secrets.Map()))
+	} else {
+		_, secretOps := secrets.settingsUpdateOps()
+		ops = append(ops, secretOps...)
+	}
 	return errors.Trace(settings.write(ops))
 }
 
@@ -49,6 +49,14 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.MeteringURL,
 		controller.APIPortOpenDelay,
 		controller.ControllerAPIPort,
+		controller.BackupInterval,
+		controller.BackupRetentionCount,
+		controller.BackupRetentionAge,
+		controller.BackupTarget,
+		controller.BackupS3Endpoint,
+		controller.BackupS3Region,
+		controller.BackupS3AccessKey,
+		controller.BackupS3SecretKey,
 	)
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -118,6 +126,31 @@ func (s *ControllerSuite) TestUpdateControllerConfigRemoveYieldsDefaults(c *gc.C
 	c.Assert(newCfg.AuditLogCaptureArgs(), gc.Equals, false)
 }
 
+func (s *ControllerSuite) TestUpdateControllerConfigSecrets(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg[controller.BackupS3AccessKey], gc.Equals, "access")
+	_, ok := cfg[controller.BackupS3SecretKey]
+	c.Assert(ok, jc.IsFalse)
+	secrets, err := s.State.ControllerSecretConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secrets, jc.DeepEquals, map[string]interface{}{
+		controller.BackupS3SecretKey: "secret",
+	})
+
+	err = s.State.UpdateControllerConfig(nil, []string{controller.BackupS3SecretKey})
+	c.Assert(err, jc.ErrorIsNil)
+	secrets, err = s.State.ControllerSecretConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secrets, gc.HasLen, 0)
+}
+
 func (s *ControllerSuite) TestUpdateControllerConfigRejectsDisallowedUpdates(c *gc.C) {
 	// Sanity check.
 	c.Assert(controller.AllowedUpdateConfigAttributes.Contains(controller.APIPort), jc.IsFalse)
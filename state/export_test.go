@@ -54,6 +54,7 @@ const (
 var (
 	BinarystorageNew        = &binarystorageNew
 	ImageStorageNewStorage  = &imageStorageNewStorage
+	MaxActionMessages       = &maxActionMessages
 	MachineIdLessThan       = machineIdLessThan
 	GetOrCreatePorts        = getOrCreatePorts
 	GetPorts                = getPorts
@@ -312,6 +313,13 @@ func TxnRevno(st *State, collName string, id interface{}) (int64, error) {
 	return doc.TxnRevno, nil
 }
 
+// UnitStateCount returns the number of unit state documents in the model.
+func UnitStateCount(st *State) (int, error) {
+	coll, closer := st.db().GetCollection(unitStatesC)
+	defer closer()
+	return coll.Count()
+}
+
 // MinUnitsRevno returns the Revno of the minUnits document
 // associated with the given application name.
 func MinUnitsRevno(st *State, applicationname string) (int, error) {
@@ -588,9 +596,10 @@ func RemoveEndpointBindingsForApplication(c *gc.C, app *Application) {
 }
 
 func RemoveOfferConnectionsForRelation(c *gc.C, rel *Relation) {
-	removeOps := removeOfferConnectionsForRelationOps(rel.Id())
+	removeOps, err := removeOfferConnectionsForRelationOps(rel.st, rel.Id())
+	c.Assert(err, jc.ErrorIsNil)
 	txnError := rel.st.db().RunTransaction(removeOps)
-	err := onAbort(txnError, nil) // ignore ErrAborted as it asserts DocExists
+	err = onAbort(txnError, nil) // ignore ErrAborted as it asserts DocExists
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -1084,6 +1084,7 @@ func (sb *storageBackend) addFilesystemOps(params FilesystemParams, hostId strin
 			params.volumeInfo,
 			params.Pool,
 			params.Size,
+			"", // filesystems cannot be restored from snapshots
 		}
 		volumeOps, volumeTag, err = sb.addVolumeOps(volumeParams, hostId)
 		if err != nil {
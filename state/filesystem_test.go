@@ -362,6 +362,37 @@ func (s *FilesystemIAASModelSuite) TestWatchFilesystemAttachment(c *gc.C) {
 	wc.AssertOneChange()
 }
 
+func (s *FilesystemIAASModelSuite) TestWatchFilesystem(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	filesystemTag := filesystem.FilesystemTag()
+	// Ensure that all the creation events have flowed through the system.
+	s.WaitForModelWatchersIdle(c, s.Model.UUID())
+
+	w := s.storageBackend.WatchFilesystem(filesystemTag)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.st, w)
+	wc.AssertOneChange()
+
+	err = s.storageBackend.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Size:         1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.storageBackend.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Pool:         "rootfs",
+		Size:         2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *FilesystemStateSuite) TestFilesystemInfo(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
 	hostTag := s.maybeAssignUnit(c, u)
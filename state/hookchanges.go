@@ -0,0 +1,196 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// HookChanges describes the changes made to the model by one of a
+// unit's charm hooks. They are committed together by
+// Unit.CommitHookChanges, so that none are made if any fail.
+type HookChanges struct {
+	// RelationSettings holds the changes to the unit's settings
+	// in the relations it is in.
+	RelationSettings []HookRelationSettings
+
+	// OpenPorts and ClosePorts hold the port ranges opened and
+	// closed by the unit, as returned by Unit.EndpointPortRange.
+	OpenPorts  []PortRange
+	ClosePorts []PortRange
+
+	// AddStorage holds the storage to add to the unit.
+	AddStorage []HookStorage
+
+	// State, if not nil, replaces the unit's charm state.
+	State *map[string]string
+}
+
+// HookRelationSettings holds the changes made by a hook to the unit's
+// settings in a relation. Keys with empty values are deleted.
+type HookRelationSettings struct {
+	RelationUnit *RelationUnit
+	Settings     map[string]string
+}
+
+// HookStorage describes storage added to a unit by a hook.
+type HookStorage struct {
+	StorageName string
+	Constraints StorageConstraints
+}
+
+// CommitHookChanges commits the changes made by one of the unit's
+// charm hooks in a single transaction.
+func (u *Unit) CommitHookChanges(changes HookChanges) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot commit hook changes for unit %q", u)
+	return errors.Trace(u.commitHookChanges(changes))
+}
+
+func (u *Unit) commitHookChanges(changes HookChanges) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); errors.IsNotFound(err) {
+				return nil, errors.NotFoundf("unit")
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.doc.Life == Dead {
+			return nil, errors.Errorf("unit is dead")
+		}
+		ops, err := u.hookChangesOps(changes)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return append([]txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}, ops...), nil
+	}
+	return u.st.db().Run(buildTxn)
+}
+
+// hookChangesOps returns the operations needed to commit the changes,
+// which are built from the current contents of the database.
+func (u *Unit) hookChangesOps(changes HookChanges) ([]txn.Op, error) {
+	var ops []txn.Op
+	for _, rs := range changes.RelationSettings {
+		settings, err := rs.RelationUnit.Settings()
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading settings for relation %q", rs.RelationUnit.Relation())
+		}
+		for k, v := range rs.Settings {
+			if v == "" {
+				settings.Delete(k)
+			} else {
+				settings.Set(k, v)
+			}
+		}
+		_, settingsOps := settings.settingsUpdateOps()
+		ops = append(ops, settingsOps...)
+	}
+
+	if len(changes.OpenPorts) > 0 || len(changes.ClosePorts) > 0 {
+		portsOps, err := u.hookPortsOps(changes.OpenPorts, changes.ClosePorts)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, portsOps...)
+	}
+
+	if len(changes.AddStorage) > 0 {
+		sb, err := NewStorageBackend(u.st)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, s := range changes.AddStorage {
+			_, storageOps, err := sb.addStorageForUnitOps(u, s.StorageName, s.Constraints)
+			if err != nil {
+				return nil, errors.Annotatef(err, "adding %q storage", s.StorageName)
+			}
+			ops = append(ops, storageOps...)
+		}
+	}
+
+	if changes.State != nil {
+		stateOps, err := u.setStateOps(*changes.State)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, stateOps...)
+	}
+	return ops, nil
+}
+
+// hookPortsOps returns the operations needed to open and close the
+// given port ranges on the unit's assigned machine, or nil if the
+// ports opened on the machine would be unchanged. Ranges are closed
+// as by ClosePorts, and then opened as by OpenPorts.
+func (u *Unit) hookPortsOps(openPorts, closePorts []PortRange) ([]txn.Op, error) {
+	machinePorts, err := u.machinePorts("")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var newPorts []PortRange
+	for _, existing := range machinePorts.doc.Ports {
+		closed := false
+		for _, pr := range closePorts {
+			// Closing ports for all endpoints also closes them for
+			// each endpoint they were opened for.
+			if existing == pr || pr.Endpoint == "" && existing.sameRange(pr) {
+				closed = true
+				break
+			}
+			if existing.UnitName == pr.UnitName {
+				if err := existing.CheckConflicts(pr); err != nil {
+					return nil, errors.Annotatef(err, "cannot close ports %v", pr)
+				}
+			}
+		}
+		if !closed {
+			newPorts = append(newPorts, existing)
+		}
+	}
+	for _, pr := range openPorts {
+		opened := false
+		for _, existing := range newPorts {
+			if err := existing.CheckConflicts(pr); err != nil {
+				return nil, errors.Annotatef(err, "cannot open ports %v", pr)
+			} else if existing == pr {
+				opened = true
+				break
+			}
+		}
+		if !opened {
+			newPorts = append(newPorts, pr)
+		}
+	}
+	if len(newPorts) == len(machinePorts.doc.Ports) &&
+		(len(newPorts) == 0 || reflect.DeepEqual(newPorts, machinePorts.doc.Ports)) {
+		return nil, nil
+	}
+
+	ops := []txn.Op{assertModelActiveOp(u.st.ModelUUID())}
+	switch {
+	case machinePorts.areNew:
+		ops = append(ops, addPortsDocOps(u.st, &machinePorts.doc, txn.DocMissing, newPorts...)...)
+	case len(newPorts) == 0:
+		// All ports closed, so remove the ports doc instead.
+		ops = append(ops, machinePorts.removeOps()...)
+	default:
+		assert := bson.D{{"txn-revno", machinePorts.doc.TxnRevno}}
+		ops = append(ops, setPortsDocOps(u.st, machinePorts.doc, assert, newPorts...)...)
+	}
+	return ops, nil
+}
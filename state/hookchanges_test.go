@@ -0,0 +1,95 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/state"
+)
+
+func (s *UnitSuite) setUpHookChanges(c *gc.C) (*state.RelationUnit, *state.Unit) {
+	err := s.unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	mysqlCharm := s.AddTestingCharm(c, "mysql")
+	mysqlApp := s.AddTestingApplication(c, "mysql", mysqlCharm)
+	mysqlUnit, err := mysqlApp.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	endpoints, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(endpoints...)
+	c.Assert(err, jc.ErrorIsNil)
+	relationUnit, err := rel.Unit(s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relationUnit.EnterScope(map[string]interface{}{"deleted": "soon"}), jc.ErrorIsNil)
+	return relationUnit, mysqlUnit
+}
+
+func (s *UnitSuite) TestCommitHookChanges(c *gc.C) {
+	relationUnit, _ := s.setUpHookChanges(c)
+	err := s.unit.OpenPorts("tcp", 100, 200)
+	c.Assert(err, jc.ErrorIsNil)
+	openPorts, err := s.unit.EndpointPortRange("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	closePorts, err := s.unit.EndpointPortRange("", "tcp", 100, 200)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.CommitHookChanges(state.HookChanges{
+		RelationSettings: []state.HookRelationSettings{{
+			RelationUnit: relationUnit,
+			Settings:     map[string]string{"foo": "bar", "deleted": ""},
+		}},
+		OpenPorts:  []state.PortRange{openPorts},
+		ClosePorts: []state.PortRange{closePorts},
+		State:      &map[string]string{"leader-seen": "true"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := relationUnit.ReadSettings(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+	ports, err := s.unit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, []network.PortRange{{FromPort: 80, ToPort: 80, Protocol: "tcp"}})
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"leader-seen": "true"})
+}
+
+func (s *UnitSuite) TestCommitHookChangesNoneMadeOnFailure(c *gc.C) {
+	relationUnit, mysqlUnit := s.setUpHookChanges(c)
+	err := mysqlUnit.AssignToMachine(s.assignedMachine(c))
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlUnit.OpenPorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	openPorts, err := s.unit.EndpointPortRange("", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.CommitHookChanges(state.HookChanges{
+		RelationSettings: []state.HookRelationSettings{{
+			RelationUnit: relationUnit,
+			Settings:     map[string]string{"foo": "bar"},
+		}},
+		OpenPorts: []state.PortRange{openPorts},
+		State:     &map[string]string{"leader-seen": "true"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot commit hook changes for unit "wordpress/0": cannot open ports 80-80/tcp \("wordpress/0"\): port ranges 80-90/tcp \("mysql/0"\) and 80-80/tcp \("wordpress/0"\) conflict`)
+
+	settings, err := relationUnit.ReadSettings(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{"deleted": "soon"})
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, gc.HasLen, 0)
+}
+
+func (s *UnitSuite) assignedMachine(c *gc.C) *state.Machine {
+	id, err := s.unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+	return m
+}
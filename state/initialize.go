@@ -218,6 +218,7 @@ func Initialize(args InitializeParams) (_ *Controller, err error) {
 		userGlobalKey(userAccessID(args.ControllerModelArgs.Owner)),
 		permission.AdminAccess)
 
+	controllerConfig, controllerSecrets := splitControllerSecrets(args.ControllerConfig)
 	ops = append(ops,
 		txn.Op{
 			C:      controllersC,
@@ -255,7 +256,8 @@ func Initialize(args InitializeParams) (_ *Controller, err error) {
 			Assert: txn.DocMissing,
 			Insert: &hostedModelCountDoc{},
 		},
-		createSettingsOp(controllersC, controllerSettingsGlobalKey, args.ControllerConfig),
+		createSettingsOp(controllersC, controllerSettingsGlobalKey, controllerConfig),
+		createSettingsOp(controllersC, controllerSecretsGlobalKey, controllerSecrets),
 		createSettingsOp(globalSettingsC, cloudGlobalKey(args.Cloud.Name), args.ControllerInheritedConfig),
 	)
 	for k, v := range args.Cloud.RegionConfig {
@@ -210,6 +210,13 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// Messages returns the progress messages logged by the action.
+	Messages() []ActionMessage
+
+	// Log adds a timestamped progress message to the action. Only
+	// running actions may log messages.
+	Log(message string) error
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
@@ -0,0 +1,233 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// introspectionMarker separates the agent id from the sequence number
+// in the id of an introspection request.
+const introspectionMarker = "#introspect#"
+
+// introspectionRequestDoc records a request for an agent to query one of
+// its local introspection endpoints, along with the agent's response.
+type introspectionRequestDoc struct {
+	DocId     string    `bson:"_id"`
+	ModelUUID string    `bson:"model-uuid"`
+	Agent     string    `bson:"agent"`
+	Path      string    `bson:"path"`
+	Requested time.Time `bson:"requested"`
+	Completed bool      `bson:"completed"`
+	Response  string    `bson:"response"`
+	Error     string    `bson:"error"`
+}
+
+// IntrospectionRequest represents a query of an agent's introspection
+// endpoints that is relayed through the controller.
+type IntrospectionRequest struct {
+	st  *State
+	doc introspectionRequestDoc
+}
+
+// Id returns the id of the request.
+func (r *IntrospectionRequest) Id() string {
+	return r.st.localID(r.doc.DocId)
+}
+
+// Agent returns the tag of the agent that should answer the request.
+func (r *IntrospectionRequest) Agent() (names.Tag, error) {
+	return names.ParseTag(r.doc.Agent)
+}
+
+// Path returns the introspection path being queried.
+func (r *IntrospectionRequest) Path() string {
+	return r.doc.Path
+}
+
+// Requested returns the time the request was made.
+func (r *IntrospectionRequest) Requested() time.Time {
+	return r.doc.Requested
+}
+
+// Completed returns whether the agent has answered the request.
+func (r *IntrospectionRequest) Completed() bool {
+	return r.doc.Completed
+}
+
+// Response returns the body of the agent's response, if any.
+func (r *IntrospectionRequest) Response() string {
+	return r.doc.Response
+}
+
+// Error returns the error the agent reported when handling the
+// request, if any.
+func (r *IntrospectionRequest) Error() string {
+	return r.doc.Error
+}
+
+// Refresh reloads the request from the database.
+func (r *IntrospectionRequest) Refresh() error {
+	coll, closer := r.st.db().GetCollection(introspectionRequestsC)
+	defer closer()
+
+	var doc introspectionRequestDoc
+	err := coll.FindId(r.doc.DocId).One(&doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("introspection request %q", r.Id())
+	} else if err != nil {
+		return errors.Annotatef(err, "refreshing introspection request %q", r.Id())
+	}
+	r.doc = doc
+	return nil
+}
+
+// Complete records the agent's response to the request. Only one of
+// response and errMsg is expected to be set.
+func (r *IntrospectionRequest) Complete(response, errMsg string) error {
+	ops := []txn.Op{{
+		C:      introspectionRequestsC,
+		Id:     r.doc.DocId,
+		Assert: bson.D{{"completed", false}},
+		Update: bson.D{{"$set", bson.D{
+			{"completed", true},
+			{"response", response},
+			{"error", errMsg},
+		}}},
+	}}
+	err := r.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		if err := r.Refresh(); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Errorf("introspection request %q already completed", r.Id())
+	} else if err != nil {
+		return errors.Annotatef(err, "completing introspection request %q", r.Id())
+	}
+	r.doc.Completed = true
+	r.doc.Response = response
+	r.doc.Error = errMsg
+	return nil
+}
+
+// Remove removes the request. It is not an error to remove a request
+// that has already been removed.
+func (r *IntrospectionRequest) Remove() error {
+	ops := []txn.Op{{
+		C:      introspectionRequestsC,
+		Id:     r.doc.DocId,
+		Remove: true,
+	}}
+	if err := r.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "removing introspection request %q", r.Id())
+	}
+	return nil
+}
+
+// Watch returns a watcher that notifies when the request changes.
+func (r *IntrospectionRequest) Watch() NotifyWatcher {
+	return newEntityWatcher(r.st, introspectionRequestsC, r.doc.DocId)
+}
+
+// AddIntrospectionRequest records a request for the given machine or
+// unit agent to query the given path on its introspection endpoint.
+func (st *State) AddIntrospectionRequest(agent names.Tag, path string) (*IntrospectionRequest, error) {
+	switch agent.(type) {
+	case names.MachineTag, names.UnitTag:
+	default:
+		return nil, errors.NotValidf("introspecting %q", agent)
+	}
+	seq, err := sequence(st, "introspection")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := fmt.Sprintf("%s%s%d", agent.Id(), introspectionMarker, seq)
+	doc := introspectionRequestDoc{
+		DocId:     st.docID(id),
+		ModelUUID: st.ModelUUID(),
+		Agent:     agent.String(),
+		Path:      path,
+		Requested: st.nowToTheSecond(),
+	}
+	ops := []txn.Op{{
+		C:      introspectionRequestsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return nil, errors.Annotatef(err, "adding introspection request for %s", agent)
+	}
+	return &IntrospectionRequest{st: st, doc: doc}, nil
+}
+
+// RemoveStaleIntrospectionRequests removes the model's introspection
+// requests that were made more than maxAge ago. Requests are removed
+// once answered by the API server that made them, but are left behind
+// if it stops while waiting for the answer.
+func (st *State) RemoveStaleIntrospectionRequests(maxAge time.Duration) error {
+	coll, closer := st.db().GetCollection(introspectionRequestsC)
+	defer closer()
+
+	cutoff := st.nowToTheSecond().Add(-maxAge)
+	var docs []struct {
+		DocId string `bson:"_id"`
+	}
+	err := coll.Find(bson.D{{"requested", bson.D{{"$lt", cutoff}}}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return errors.Annotate(err, "finding stale introspection requests")
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      introspectionRequestsC,
+			Id:     doc.DocId,
+			Remove: true,
+		}
+	}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "removing stale introspection requests")
+	}
+	return nil
+}
+
+// IntrospectionRequest returns the introspection request with the
+// given id.
+func (st *State) IntrospectionRequest(id string) (*IntrospectionRequest, error) {
+	coll, closer := st.db().GetCollection(introspectionRequestsC)
+	defer closer()
+
+	var doc introspectionRequestDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("introspection request %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "getting introspection request %q", id)
+	}
+	return &IntrospectionRequest{st: st, doc: doc}, nil
+}
+
+// WatchIntrospectionRequests returns a StringsWatcher that notifies of
+// the ids of introspection requests made of the given agent.
+func (st *State) WatchIntrospectionRequests(agent names.Tag) StringsWatcher {
+	prefix := st.docID(agent.Id() + introspectionMarker)
+	return newCollectionWatcher(st, colWCfg{
+		col: introspectionRequestsC,
+		filter: func(key interface{}) bool {
+			id, ok := key.(string)
+			return ok && strings.HasPrefix(id, prefix)
+		},
+	})
+}
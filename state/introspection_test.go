@@ -0,0 +1,134 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type IntrospectionSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&IntrospectionSuite{})
+
+func (s *IntrospectionSuite) TestAddIntrospectionRequest(c *gc.C) {
+	req, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(req.Path(), gc.Equals, "/depengine")
+	c.Check(req.Completed(), jc.IsFalse)
+	agent, err := req.Agent()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(agent, gc.Equals, names.NewMachineTag("0"))
+
+	other, err := s.State.IntrospectionRequest(req.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(other.Id(), gc.Equals, req.Id())
+	c.Check(other.Path(), gc.Equals, "/depengine")
+}
+
+func (s *IntrospectionSuite) TestAddIntrospectionRequestInvalidAgent(c *gc.C) {
+	_, err := s.State.AddIntrospectionRequest(names.NewApplicationTag("mysql"), "/depengine")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *IntrospectionSuite) TestComplete(c *gc.C) {
+	req, err := s.State.AddIntrospectionRequest(names.NewUnitTag("mysql/0"), "/metrics/")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = req.Complete("some metrics", "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	other, err := s.State.IntrospectionRequest(req.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(other.Completed(), jc.IsTrue)
+	c.Check(other.Response(), gc.Equals, "some metrics")
+	c.Check(other.Error(), gc.Equals, "")
+
+	err = other.Complete("", "boom")
+	c.Assert(err, gc.ErrorMatches, `introspection request ".*" already completed`)
+}
+
+func (s *IntrospectionSuite) TestRemove(c *gc.C) {
+	req, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = req.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.IntrospectionRequest(req.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing again is fine.
+	err = req.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *IntrospectionSuite) TestRemoveStaleIntrospectionRequests(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	c.Assert(s.State.SetClockForTesting(clock), jc.ErrorIsNil)
+
+	stale, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	clock.Advance(2 * time.Minute)
+	current, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/pubsub")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveStaleIntrospectionRequests(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.IntrospectionRequest(stale.Id())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.IntrospectionRequest(current.Id())
+	c.Check(err, jc.ErrorIsNil)
+
+	// Nothing else is stale.
+	err = s.State.RemoveStaleIntrospectionRequests(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *IntrospectionSuite) TestWatchIntrospectionRequests(c *gc.C) {
+	req0, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	s.WaitForModelWatchersIdle(c, s.State.ModelUUID())
+
+	w := s.State.WatchIntrospectionRequests(names.NewMachineTag("0"))
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(req0.Id())
+	wc.AssertNoChange()
+
+	// Requests for other agents are not reported.
+	_, err = s.State.AddIntrospectionRequest(names.NewMachineTag("1"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddIntrospectionRequest(names.NewMachineTag("0/lxd/0"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	req1, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/pubsub")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(req1.Id())
+	wc.AssertNoChange()
+}
+
+func (s *IntrospectionSuite) TestWatchRequest(c *gc.C) {
+	req, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	s.WaitForModelWatchersIdle(c, s.State.ModelUUID())
+
+	w := req.Watch()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err = req.Complete("report", "")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -317,6 +317,7 @@ type LogRecord struct {
 type LogTailerParams struct {
 	StartID       int64
 	StartTime     time.Time
+	EndTime       time.Time
 	MinLevel      loggo.Level
 	InitialLines  int
 	NoTail        bool
@@ -324,7 +325,10 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
+	// MessagePattern, if set, is a regular expression that log
+	// messages must match to be returned.
+	MessagePattern string
+	Oplog          *mgo.Collection // For testing only
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -425,6 +429,10 @@ func (t *logTailer) loop() error {
 	if t.params.NoTail {
 		return nil
 	}
+	if !t.params.EndTime.IsZero() && !t.params.EndTime.After(time.Now()) {
+		// No new logs can fall inside the requested window.
+		return nil
+	}
 
 	return t.tailOplog()
 }
@@ -570,10 +578,18 @@ func (t *logTailer) tailOplog() error {
 	// a good value, or end the method.
 	deserialisationFailures := 0
 	skipCount := 0
+
+	// Stop tailing once the end of the requested window has passed.
+	var endOfWindow <-chan time.Time
+	if !t.params.EndTime.IsZero() {
+		endOfWindow = time.After(time.Until(t.params.EndTime))
+	}
 	for {
 		select {
 		case <-t.tomb.Dying():
 			return tomb.ErrDying
+		case <-endOfWindow:
+			return nil
 		case oplogDoc, ok := <-oplogTailer.Out():
 			if !ok {
 				return errors.Annotate(oplogTailer.Err(), "oplog tailer died")
@@ -621,8 +637,15 @@ func (t *logTailer) tailOplog() error {
 
 func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
+	timeSel := bson.M{}
 	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+		timeSel["$gte"] = params.StartTime.UnixNano()
+	}
+	if !params.EndTime.IsZero() {
+		timeSel["$lt"] = params.EndTime.UnixNano()
+	}
+	if len(timeSel) > 0 {
+		sel = append(sel, bson.DocElem{"t", timeSel})
 	}
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
@@ -643,6 +666,9 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if params.MessagePattern != "" {
+		sel = append(sel, bson.DocElem{"x", bson.RegEx{Pattern: params.MessagePattern}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
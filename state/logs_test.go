@@ -390,6 +390,41 @@ func (s *LogTailerSuite) TestTimeFiltering(c *gc.C) {
 
 }
 
+func (s *LogTailerSuite) TestEndTimeFiltering(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, threshT.Add(-5*time.Second), threshT.Add(-time.Millisecond), 5, want)
+	s.writeLogsT(c, s.otherUUID, threshT, threshT.Add(5*time.Second), 5, logTemplate{Message: "dont want"})
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		EndTime: threshT,
+		Oplog:   s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+
+	// The end of the window is in the past, so the tailer stops
+	// without tailing the oplog.
+	s.assertTailerStops(c, tailer)
+}
+
+func (s *LogTailerSuite) TestMessagePatternFiltering(c *gc.C) {
+	want := logTemplate{Message: "hook failed: install"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, logTemplate{Message: "all good"})
+		s.writeLogs(c, s.otherUUID, 2, want)
+		s.writeLogs(c, s.otherUUID, 1, logTemplate{Message: "failed to start"})
+	}
+	params := state.LogTailerParams{
+		MessagePattern: "^hook failed",
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 2, want)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) TestOplogTransition(c *gc.C) {
 	// Ensure that logs aren't repeated as the log tailer moves from
 	// reading from the logs collection to tailing the oplog.
@@ -609,21 +644,7 @@ func (s *LogTailerSuite) TestNoTail(c *gc.C) {
 	// Logs only in the oplog shouldn't be reported and the tailer
 	// should stop itself once the log collection has been read.
 	s.assertTailer(c, tailer, 2, expected)
-	select {
-	case _, ok := <-tailer.Logs():
-		if ok {
-			c.Fatal("shouldn't be any further logs")
-		}
-	case <-time.After(coretesting.LongWait):
-		c.Fatal("timed out waiting for logs channel to close")
-	}
-
-	select {
-	case <-tailer.Dying():
-		// Success.
-	case <-time.After(coretesting.LongWait):
-		c.Fatal("tailer didn't stop itself")
-	}
+	s.assertTailerStops(c, tailer)
 }
 
 func (s *LogTailerSuite) TestIncludeEntity(c *gc.C) {
@@ -932,6 +953,24 @@ func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedC
 	}
 }
 
+func (s *LogTailerSuite) assertTailerStops(c *gc.C, tailer state.LogTailer) {
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+
+	select {
+	case <-tailer.Dying():
+		// Success.
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("tailer didn't stop itself")
+	}
+}
+
 type DBLogSizeSuite struct {
 	coretesting.BaseSuite
 }
@@ -493,6 +493,8 @@ func (e *exporter) openedPortsArgsForMachine(machineId string, portsData []ports
 		// Don't bother including a subnet if there are no ports open on it.
 		if doc.MachineID == machineId && len(doc.Ports) > 0 {
 			args := description.OpenedPortsArgs{SubnetID: doc.SubnetID}
+			// The endpoints ports are opened for are exported with
+			// the migration extras; see ExportMigrationExtras.
 			for _, p := range doc.Ports {
 				args.OpenedPorts = append(args.OpenedPorts, description.PortRangeArgs{
 					UnitName: p.UnitName,
@@ -0,0 +1,288 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	mgoutils "github.com/juju/juju/mongo/utils"
+)
+
+// migrationExtras holds model data that the model description format
+// cannot yet represent. It is serialized alongside the description
+// when a model is migrated, and applied to the model once the
+// description has been imported on the target controller.
+type migrationExtras struct {
+	// UnitStates holds the charm state stored by each unit, keyed
+	// by unit name.
+	UnitStates map[string]map[string]string `json:"unit-states,omitempty"`
+
+	// ActionSchedules holds the model's action schedules.
+	ActionSchedules []migrationActionSchedule `json:"action-schedules,omitempty"`
+
+	// ExposedEndpoints holds the expose settings of each exposed
+	// application's endpoints, keyed by application name.
+	ExposedEndpoints map[string]map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+
+	// OpenedPorts holds the port ranges of each machine's ports
+	// document that has ranges opened for specific endpoints, keyed
+	// by the document's local id.
+	OpenedPorts map[string][]PortRange `json:"opened-ports,omitempty"`
+
+	// CharmRefreshPolicies holds the applications' charm refresh
+	// policies, keyed by application name.
+	CharmRefreshPolicies map[string]charmRefreshPolicyDoc `json:"charm-refresh-policies,omitempty"`
+}
+
+// migrationActionSchedule holds the details of an action schedule
+// being migrated.
+type migrationActionSchedule struct {
+	Id         string                 `json:"id"`
+	Receiver   string                 `json:"receiver"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Cron       string                 `json:"cron"`
+	Created    time.Time              `json:"created"`
+	NextRun    time.Time              `json:"next-run"`
+	History    []ActionScheduleRun    `json:"history,omitempty"`
+}
+
+// ExportMigrationExtras returns the serialized model data that is
+// migrated alongside the model description.
+func (st *State) ExportMigrationExtras() ([]byte, error) {
+	var extras migrationExtras
+	if err := st.exportUnitStates(&extras); err != nil {
+		return nil, errors.Annotate(err, "unit states")
+	}
+	if err := st.exportActionSchedules(&extras); err != nil {
+		return nil, errors.Annotate(err, "action schedules")
+	}
+	if err := st.exportExposedEndpoints(&extras); err != nil {
+		return nil, errors.Annotate(err, "exposed endpoints")
+	}
+	if err := st.exportOpenedPorts(&extras); err != nil {
+		return nil, errors.Annotate(err, "opened ports")
+	}
+	if err := st.exportCharmRefreshPolicies(&extras); err != nil {
+		return nil, errors.Annotate(err, "charm refresh policies")
+	}
+	data, err := json.Marshal(extras)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
+
+func (st *State) exportUnitStates(extras *migrationExtras) error {
+	coll, closer := st.db().GetCollection(unitStatesC)
+	defer closer()
+
+	var docs []unitStateDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return errors.Trace(err)
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	extras.UnitStates = make(map[string]map[string]string, len(docs))
+	for _, doc := range docs {
+		state := make(map[string]string, len(doc.State))
+		for k, v := range doc.State {
+			state[mgoutils.UnescapeKey(k)] = v
+		}
+		extras.UnitStates[st.localID(doc.DocID)] = state
+	}
+	return nil
+}
+
+func (st *State) exportActionSchedules(extras *migrationExtras) error {
+	schedules, err := st.actionSchedules(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, schedule := range schedules {
+		extras.ActionSchedules = append(extras.ActionSchedules, migrationActionSchedule{
+			Id:         schedule.Id(),
+			Receiver:   schedule.Receiver(),
+			Name:       schedule.Name(),
+			Parameters: schedule.Parameters(),
+			Cron:       schedule.Cron(),
+			Created:    schedule.Created(),
+			NextRun:    schedule.NextRun(),
+			History:    schedule.History(),
+		})
+	}
+	return nil
+}
+
+func (st *State) exportExposedEndpoints(extras *migrationExtras) error {
+	applications, err := st.AllApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, app := range applications {
+		exposed := app.ExposedEndpoints()
+		if len(exposed) == 0 {
+			continue
+		}
+		if extras.ExposedEndpoints == nil {
+			extras.ExposedEndpoints = make(map[string]map[string]ExposedEndpoint)
+		}
+		extras.ExposedEndpoints[app.Name()] = exposed
+	}
+	return nil
+}
+
+func (st *State) exportCharmRefreshPolicies(extras *migrationExtras) error {
+	applications, err := st.AllApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, app := range applications {
+		if app.doc.CharmRefreshPolicy == nil {
+			continue
+		}
+		if extras.CharmRefreshPolicies == nil {
+			extras.CharmRefreshPolicies = make(map[string]charmRefreshPolicyDoc)
+		}
+		extras.CharmRefreshPolicies[app.Name()] = *app.doc.CharmRefreshPolicy
+	}
+	return nil
+}
+
+func (st *State) exportOpenedPorts(extras *migrationExtras) error {
+	coll, closer := st.db().GetCollection(openedPortsC)
+	defer closer()
+
+	var docs []portsDoc
+	if err := coll.Find(bson.D{{"ports.endpoint", bson.D{{"$exists", true}}}}).All(&docs); err != nil {
+		return errors.Trace(err)
+	}
+	for _, doc := range docs {
+		if extras.OpenedPorts == nil {
+			extras.OpenedPorts = make(map[string][]PortRange)
+		}
+		extras.OpenedPorts[st.localID(doc.DocID)] = doc.Ports
+	}
+	return nil
+}
+
+// ImportMigrationExtras applies model data exported by
+// ExportMigrationExtras to the model, which must already have been
+// imported from its model description. Empty data is ignored, so
+// that models exported by controllers that do not send any extras
+// can still be imported.
+func (st *State) ImportMigrationExtras(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	var extras migrationExtras
+	if err := json.Unmarshal(data, &extras); err != nil {
+		return errors.Annotate(err, "cannot unmarshal migration extras")
+	}
+
+	var ops []txn.Op
+	units := set.NewStrings()
+	for unitName, state := range extras.UnitStates {
+		escaped := make(map[string]string, len(state))
+		for k, v := range state {
+			escaped[mgoutils.EscapeKey(k)] = v
+		}
+		docID := st.docID(unitName)
+		units.Add(unitName)
+		ops = append(ops, txn.Op{
+			C:      unitStatesC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+			Insert: &unitStateDoc{
+				DocID:     docID,
+				ModelUUID: st.ModelUUID(),
+				State:     escaped,
+			},
+		})
+	}
+	for _, schedule := range extras.ActionSchedules {
+		history := make([]actionScheduleRunDoc, len(schedule.History))
+		for i, run := range schedule.History {
+			history[i] = actionScheduleRunDoc{
+				Time:     run.Time,
+				ActionId: run.ActionId,
+				Error:    run.Error,
+			}
+		}
+		docID := st.docID(schedule.Id)
+		units.Add(schedule.Receiver)
+		ops = append(ops, txn.Op{
+			C:      actionSchedulesC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+			Insert: &actionScheduleDoc{
+				DocId:      docID,
+				ModelUUID:  st.ModelUUID(),
+				Receiver:   schedule.Receiver,
+				Name:       schedule.Name,
+				Parameters: schedule.Parameters,
+				Cron:       schedule.Cron,
+				Created:    schedule.Created,
+				NextRun:    schedule.NextRun,
+				History:    history,
+			},
+		})
+	}
+	appUpdates := make(map[string]bson.D)
+	for appName, exposed := range extras.ExposedEndpoints {
+		docs := make([]exposedEndpointDoc, 0, len(exposed))
+		for endpoint, settings := range exposed {
+			docs = append(docs, exposedEndpointDoc{
+				Endpoint: endpoint,
+				Spaces:   settings.ExposeToSpaces,
+				CIDRs:    settings.ExposeToCIDRs,
+			})
+		}
+		sort.Slice(docs, func(i, j int) bool {
+			return docs[i].Endpoint < docs[j].Endpoint
+		})
+		appUpdates[appName] = append(appUpdates[appName], bson.DocElem{Name: "exposed-endpoints", Value: docs})
+	}
+	for appName, policy := range extras.CharmRefreshPolicies {
+		appUpdates[appName] = append(appUpdates[appName], bson.DocElem{Name: "charm-refresh-policy", Value: policy})
+	}
+	for appName, update := range appUpdates {
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     st.docID(appName),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", update}},
+		})
+	}
+	for id, ports := range extras.OpenedPorts {
+		ops = append(ops, txn.Op{
+			C:      openedPortsC,
+			Id:     st.docID(id),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"ports", ports}}}},
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	for _, unitName := range units.SortedValues() {
+		ops = append(ops, txn.Op{
+			C:      unitsC,
+			Id:     st.docID(unitName),
+			Assert: txn.DocExists,
+		})
+	}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "cannot import migration extras")
+	}
+	return nil
+}
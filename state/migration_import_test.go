@@ -20,6 +20,7 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/yaml.v2"
 
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
@@ -810,6 +811,123 @@ func (s *MigrationImportSuite) TestUnits(c *gc.C) {
 	s.assertUnitsMigrated(c, s.State, constraints.MustParse("arch=amd64 mem=8G"))
 }
 
+func (s *MigrationImportSuite) TestUnitStateMigrationExtras(c *gc.C) {
+	exported := s.Factory.MakeUnit(c, nil)
+	err := exported.SetState(map[string]string{"foo.bar": "baz", "$qux": "quux"})
+	c.Assert(err, jc.ErrorIsNil)
+	extras, err := s.State.ExportMigrationExtras()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c, s.State)
+	err = newSt.ImportMigrationExtras(extras)
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported, err := newSt.Unit(exported.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	state, err := imported.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state, jc.DeepEquals, map[string]string{"foo.bar": "baz", "$qux": "quux"})
+}
+
+func (s *MigrationImportSuite) TestActionScheduleMigrationExtras(c *gc.C) {
+	app := state.AddTestingApplication(c, s.State, "dummy", state.AddTestingCharm(c, s.State, "dummy"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	exported, err := s.Model.AddActionSchedule(
+		unit.UnitTag(), "snapshot", map[string]interface{}{"outfile": "db.bz2"}, "0 3 * * *",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	extras, err := s.State.ExportMigrationExtras()
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModel, newSt := s.importModel(c, s.State)
+	err = newSt.ImportMigrationExtras(extras)
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedules, err := newModel.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 1)
+	imported := schedules[0]
+	c.Check(imported.Id(), gc.Equals, exported.Id())
+	c.Check(imported.Receiver(), gc.Equals, "dummy/0")
+	c.Check(imported.Name(), gc.Equals, "snapshot")
+	c.Check(imported.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "db.bz2"})
+	c.Check(imported.Cron(), gc.Equals, "0 3 * * *")
+	c.Check(imported.Created().Equal(exported.Created()), jc.IsTrue)
+	c.Check(imported.NextRun().Equal(exported.NextRun()), jc.IsTrue)
+}
+
+func (s *MigrationImportSuite) TestExposeSettingsMigrationExtras(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	err := app.SetExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.OpenPortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.OpenPorts("tcp", 443, 443)
+	c.Assert(err, jc.ErrorIsNil)
+	extras, err := s.State.ExportMigrationExtras()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c, s.State)
+	err = newSt.ImportMigrationExtras(extras)
+	c.Assert(err, jc.ErrorIsNil)
+
+	importedApp, err := newSt.Application(app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(importedApp.IsExposed(), jc.IsTrue)
+	c.Check(importedApp.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := newSt.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ports.AllPortRanges(), jc.DeepEquals, map[corenetwork.PortRange]string{
+		{80, 80, "tcp"}:   unit.Name(),
+		{443, 443, "tcp"}: unit.Name(),
+	})
+	c.Check(ports.PortRangeEndpoints(), jc.DeepEquals, map[corenetwork.PortRange][]string{
+		{80, 80, "tcp"}: {"url"},
+	})
+}
+
+func (s *MigrationImportSuite) TestCharmRefreshPolicyMigrationExtras(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	policy := coreapplication.RefreshPolicy{
+		Window:    "0 2 * * SAT",
+		Duration:  2 * time.Hour,
+		PatchOnly: true,
+	}
+	err := app.SetCharmRefreshPolicy(&policy)
+	c.Assert(err, jc.ErrorIsNil)
+	extras, err := s.State.ExportMigrationExtras()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c, s.State)
+	err = newSt.ImportMigrationExtras(extras)
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported, err := newSt.Application(app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	importedPolicy, err := imported.CharmRefreshPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(importedPolicy.Policy(), jc.DeepEquals, policy)
+}
+
+func (s *MigrationImportSuite) TestImportMigrationExtrasEmpty(c *gc.C) {
+	_, newSt := s.importModel(c, s.State)
+	err := newSt.ImportMigrationExtras(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *MigrationImportSuite) TestCAASUnits(c *gc.C) {
 	caasSt := s.Factory.MakeCAASModel(c, nil)
 	s.AddCleanup(func(_ *gc.C) { caasSt.Close() })
@@ -44,6 +44,8 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		meterStatusC, // red / green status for metrics of units
 		payloadsC,
 		"resources",
+		unitStatesC,      // exported alongside the model description
+		actionSchedulesC, // exported alongside the model description
 
 		// relation
 		relationsC,
@@ -112,6 +114,8 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		usermodelnameC,
 		// Metrics aren't migrated.
 		metricsC,
+		// The audit log is controller global, not migrated.
+		auditLogC,
 		// Backup and restore information is not migrated.
 		restoreInfoC,
 		// reference counts are implementation details that should be
@@ -166,6 +170,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Recreated whilst migrating actions.
 		actionNotificationsC,
 
+		// Introspection requests are transient and answered by the
+		// agents connected to the source controller.
+		introspectionRequestsC,
+
 		// Global settings store controller specific configuration settings
 		// and are not to be migrated.
 		globalSettingsC,
@@ -207,6 +215,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		remoteApplicationsC,
 		applicationOffersC,
 		offerConnectionsC,
+		// Requests to consume offers that require approval belong
+		// with the offers and their connections, so they will be
+		// migrated along with them.
+		offerConnectionRequestsC,
 		remoteEntitiesC,
 		externalControllersC,
 		relationNetworksC,
@@ -388,6 +400,13 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// ExposedEndpoints is exported alongside the model description.
+		"ExposedEndpoints",
+		// CharmRollout is not migrated; units held back by a rollout
+		// in progress are upgraded once the model has migrated.
+		"CharmRollout",
+		// CharmRefreshPolicy is exported alongside the model description.
+		"CharmRefreshPolicy",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -451,6 +470,18 @@ func (s *MigrationSuite) TestPortsDocFields(c *gc.C) {
 	s.AssertExportedFields(c, portsDoc{}, fields)
 }
 
+func (s *MigrationSuite) TestPortRangeFields(c *gc.C) {
+	fields := set.NewStrings(
+		"UnitName",
+		"FromPort",
+		"ToPort",
+		"Protocol",
+		// Endpoint is exported alongside the model description.
+		"Endpoint",
+	)
+	s.AssertExportedFields(c, PortRange{}, fields)
+}
+
 func (s *MigrationSuite) TestMeterStatusDocFields(c *gc.C) {
 	fields := set.NewStrings(
 		// DocID itself isn't migrated
@@ -707,6 +738,10 @@ func (s *MigrationSuite) TestSSHHostKeyDocFields(c *gc.C) {
 func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
+		// Progress messages are only of interest while the action
+		// is running, and the model description can't hold them.
+		"Logs",
+		"MessageCount",
 	)
 	migrated := set.NewStrings(
 		"DocId",
@@ -18,6 +18,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/mongo/utils"
 )
@@ -70,7 +71,15 @@ type generationDoc struct {
 	// Config is all changes made to charm configuration under this branch.
 	Config map[string][]itemChange `bson:"charm-config"`
 
-	// TODO (manadart 2019-04-02): CharmURLs, Resources.
+	// CharmURLs is the charm URL for each application that has had a charm
+	// upgrade staged under this branch, keyed by application name.
+	CharmURLs map[string]string `bson:"charm-urls,omitempty"`
+
+	// Constraints is the application constraints set under this branch,
+	// keyed by application name.
+	Constraints map[string]constraintsDoc `bson:"constraints,omitempty"`
+
+	// TODO (manadart 2019-04-02): Resources.
 
 	// Created is a Unix timestamp indicating when this generation was created.
 	Created int64 `bson:"created"`
@@ -128,6 +137,26 @@ func (g *Generation) Config() map[string]settings.ItemChanges {
 	return changes
 }
 
+// CharmURLs returns the charm URLs staged under the generation,
+// keyed by application name.
+func (g *Generation) CharmURLs() map[string]string {
+	urls := make(map[string]string, len(g.doc.CharmURLs))
+	for appName, url := range g.doc.CharmURLs {
+		urls[appName] = url
+	}
+	return urls
+}
+
+// Constraints returns the application constraints set under the
+// generation, keyed by application name.
+func (g *Generation) Constraints() map[string]constraints.Value {
+	cons := make(map[string]constraints.Value, len(g.doc.Constraints))
+	for appName, doc := range g.doc.Constraints {
+		cons[appName] = doc.value()
+	}
+	return cons
+}
+
 // Created returns the Unix timestamp at generation creation.
 func (g *Generation) Created() int64 {
 	return g.doc.Created
@@ -235,6 +264,9 @@ func (g *Generation) AssignAllUnits(appName string) error {
 		if len(ops) < 2 {
 			return nil, jujutxn.ErrNoOperations
 		}
+		if _, ok := g.doc.CharmURLs[appName]; ok {
+			ops = append(ops, branchCharmChangedOp(appName))
+		}
 		return ops, nil
 	}
 	return errors.Trace(g.st.db().Run(buildTxn))
@@ -264,7 +296,11 @@ func (g *Generation) AssignUnit(unitName string) error {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		return assignGenerationUnitTxnOps(g.doc.DocId, appName, unit), nil
+		ops := assignGenerationUnitTxnOps(g.doc.DocId, appName, unit)
+		if _, ok := g.doc.CharmURLs[appName]; ok {
+			ops = append(ops, branchCharmChangedOp(appName))
+		}
+		return ops, nil
 	}
 
 	return errors.Trace(g.st.db().Run(buildTxn))
@@ -353,6 +389,125 @@ func (g *Generation) UpdateCharmConfig(appName string, master *Settings, validCh
 	return errors.Trace(g.st.db().Run(buildTxn))
 }
 
+// UpdateCharmURL stages an upgrade of the input application to the charm
+// with the input URL under this branch.
+// The charm is assumed to have been validated as suitable for the
+// application.
+// The branch holds a reference to the charm, along with the application's
+// settings and storage constraints for it, until the branch is completed,
+// so that units tracking the branch can be upgraded to the charm.
+func (g *Generation) UpdateCharmURL(appName string, curl *charm.URL) error {
+	if curl == nil {
+		return errors.NotValidf("nil charm URL")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if g.doc.CharmURLs[appName] == curl.String() {
+			return nil, jujutxn.ErrNoOperations
+		}
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, err := g.st.Charm(curl)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops, err := app.branchCharmOps(ch)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if prev, ok := g.doc.CharmURLs[appName]; ok {
+			releaseOps, err := g.releaseCharmOps(appName, prev)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, releaseOps...)
+		}
+		return append(ops, g.updateAppTxnOp(appName, "charm-urls", curl.String())), nil
+	}
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// releaseCharmOps returns the operations that drop the references held by
+// this branch to the input charm, staged for the input application,
+// along with the application's settings and storage constraints for it.
+// The units of the application are notified of the change.
+func (g *Generation) releaseCharmOps(appName, url string) ([]txn.Op, error) {
+	ops, err := g.charmDecRefOps(appName, url)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, branchCharmChangedOp(appName)), nil
+}
+
+// charmDecRefOps returns the operations that drop the references held by
+// this branch to the input charm, and to the application's settings and
+// storage constraints for it, removing them if they are no longer used.
+// Unlike releaseCharmOps, they do not require the application to exist.
+func (g *Generation) charmDecRefOps(appName, url string) ([]txn.Op, error) {
+	curl, err := charm.ParseURL(url)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	op := &ForcedOperation{Force: true}
+	ops, err := appCharmDecRefOps(g.st, appName, curl, true, op)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(op.Errors) != 0 {
+		logger.Errorf("could not remove branch %q references to charm %v: %v", g.doc.Name, curl, op.Errors)
+	}
+	return ops, nil
+}
+
+// UpdateConstraints sets the input application's constraints
+// under this branch.
+func (g *Generation) UpdateConstraints(appName string, cons constraints.Value) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{
+			g.updateAppTxnOp(appName, "constraints", newConstraintsDoc(cons)),
+		}, nil
+	}
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// updateAppTxnOp returns a transaction operation that sets the input
+// application's value for the input field in the generation, asserting that
+// the generation is unchanged since it was read.
+// The application is also added to the generation if it is not already
+// present, so that it is reported as having changes in the branch.
+func (g *Generation) updateAppTxnOp(appName, field string, value interface{}) txn.Op {
+	set := bson.D{{field + "." + appName, value}}
+	if _, ok := g.doc.AssignedUnits[appName]; !ok {
+		set = append(set, bson.DocElem{"assigned-units." + appName, []string{}})
+	}
+	return txn.Op{
+		C:  generationsC,
+		Id: g.doc.DocId,
+		Assert: bson.D{{"$and", []bson.D{
+			{{"completed", 0}},
+			{{"txn-revno", g.doc.TxnRevno}},
+		}}},
+		Update: bson.D{{"$set", set}},
+	}
+}
+
 // Commit marks the generation as completed and assigns it the next value from
 // the generation sequence. The new generation ID is returned.
 func (g *Generation) Commit(userName string) (int, error) {
@@ -415,6 +570,19 @@ func (g *Generation) Commit(userName string) (int, error) {
 				},
 			},
 		}
+		if newGenId > 0 {
+			applyOps, err := g.applyToApplicationsOps()
+			if err != nil {
+				return nil, errors.Annotatef(err, "applying branch %q", g.BranchName())
+			}
+			ops = append(ops, applyOps...)
+		} else {
+			releaseOps, err := g.releaseAllCharmsOps()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, releaseOps...)
+		}
 		return ops, nil
 	}
 
@@ -424,7 +592,134 @@ func (g *Generation) Commit(userName string) (int, error) {
 	return newGenId, nil
 }
 
-// TODO (manadart 2019-03-19): Implement Abort().
+// applyToApplicationsOps returns the operations that set the charm URLs and
+// constraints staged under this branch on their applications, so that they
+// apply to the whole model. They are run in the same transaction that
+// commits the branch, so that either all or none of them are applied.
+func (g *Generation) applyToApplicationsOps() ([]txn.Op, error) {
+	var ops []txn.Op
+	for appName, url := range g.doc.CharmURLs {
+		app, err := g.st.Application(appName)
+		if errors.IsNotFound(err) {
+			// The application was removed, so there is nothing to
+			// apply, but the references held by the branch must
+			// still be dropped.
+			decRefOps, err := g.charmDecRefOps(appName, url)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, decRefOps...)
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		curl, err := charm.ParseURL(url)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// The references held by the branch are passed on to the
+		// application, which takes its own when its charm is changed.
+		ops = append(ops, branchCharmHandOverOps(appName, curl)...)
+		if app.doc.CharmURL.String() == url {
+			continue
+		}
+		ch, err := g.st.Charm(curl)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		changeOps, err := app.changeCharmOps(ch, app.doc.Channel, nil, false, nil, nil)
+		if err != nil {
+			return nil, errors.Annotatef(err, "upgrading application %q to charm %q", appName, url)
+		}
+		ops = append(ops, txn.Op{
+			C:  applicationsC,
+			Id: app.doc.DocID,
+			Assert: append(notDeadDoc, bson.DocElem{
+				"charmmodifiedversion", app.doc.CharmModifiedVersion,
+			}),
+		})
+		ops = append(ops, changeOps...)
+	}
+	for appName, cons := range g.Constraints() {
+		app, err := g.st.Application(appName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}, setConstraintsOp(app.globalKey(), cons))
+	}
+	return ops, nil
+}
+
+// releaseAllCharmsOps returns the operations that drop the references held
+// by this branch to all of the charms staged under it.
+func (g *Generation) releaseAllCharmsOps() ([]txn.Op, error) {
+	var ops []txn.Op
+	for appName, url := range g.doc.CharmURLs {
+		decRefOps, err := g.charmDecRefOps(appName, url)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, decRefOps...)
+		// Watchers of removed applications need not be notified.
+		if _, err := g.st.Application(appName); errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, branchCharmChangedOp(appName))
+	}
+	return ops, nil
+}
+
+// Abort marks the generation as completed without assigning it a generation
+// ID, discarding all of the changes made under it.
+// Units that were tracking the branch revert to the master generation.
+func (g *Generation) Abort(userName string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if g.IsCompleted() {
+			if g.GenerationId() == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return nil, errors.New("branch was already committed")
+		}
+		now, err := g.st.ControllerTimestamp()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		releaseOps, err := g.releaseAllCharmsOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append([]txn.Op{
+			{
+				C:  generationsC,
+				Id: g.doc.DocId,
+				Assert: bson.D{{"$and", []bson.D{
+					{{"completed", 0}},
+					{{"txn-revno", g.doc.TxnRevno}},
+				}}},
+				Update: bson.D{
+					{"$set", bson.D{
+						{"completed", now.Unix()},
+						{"completed-by", userName},
+					}},
+				},
+			},
+		}, releaseOps...), nil
+	}
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
 
 // CheckNotComplete returns an error if this
 // generation was committed or aborted.
@@ -569,6 +864,34 @@ func (st *State) getBranchDoc(name string) (*generationDoc, error) {
 	}
 }
 
+// unitBranch returns the in-flight branch that the unit with the input name
+// is tracking. A not-found error is returned if the unit is not tracking any
+// branch.
+func (st *State) unitBranch(unitName string) (*Generation, error) {
+	appName, err := names.UnitApplication(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	col, closer := st.db().GetCollection(generationsC)
+	defer closer()
+
+	doc := &generationDoc{}
+	err = col.Find(bson.M{
+		"completed":                 0,
+		"assigned-units." + appName: unitName,
+	}).One(doc)
+
+	switch err {
+	case nil:
+		return newGeneration(st, doc), nil
+	case mgo.ErrNotFound:
+		return nil, errors.NotFoundf("branch tracked by unit %q", unitName)
+	default:
+		return nil, errors.Annotatef(err, "retrieving branch tracked by unit %q", unitName)
+	}
+}
+
 func newGeneration(st *State, doc *generationDoc) *Generation {
 	return &Generation{
 		st:  st,
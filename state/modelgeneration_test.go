@@ -12,8 +12,10 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
 
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
 )
 
@@ -208,7 +210,237 @@ func (s *generationSuite) TestCommitNoChangesEffectivelyAborted(c *gc.C) {
 	c.Check(gen.CompletedBy(), gc.Equals, branchCommitter)
 }
 
-// TODO (manadart 2019-03-21): Tests for abort.
+func (s *generationSuite) TestAbort(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+	c.Assert(gen.Abort(branchCommitter), jc.ErrorIsNil)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.IsCompleted(), jc.IsTrue)
+	c.Check(gen.GenerationId(), gc.Equals, 0)
+	c.Check(gen.CompletedBy(), gc.Equals, branchCommitter)
+	c.Check(gen.CheckNotComplete(), gc.ErrorMatches, "branch was already aborted")
+
+	_, err := s.Model.Branch(newBranchName)
+	c.Check(errors.IsNotFound(err), jc.IsTrue)
+
+	// Idempotent.
+	c.Assert(gen.Abort(branchCommitter), jc.ErrorIsNil)
+
+	// The branch name can be re-used.
+	c.Assert(s.Model.AddBranch(newBranchName, newBranchCreator), jc.ErrorIsNil)
+}
+
+func (s *generationSuite) TestAbortCommittedError(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	_, err := gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(gen.Abort(branchCommitter), gc.ErrorMatches, "branch was already committed")
+}
+
+func (s *generationSuite) TestUpdateCharmURL(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+	c.Assert(gen.CharmURLs(), gc.HasLen, 0)
+
+	ch := state.AddCustomCharm(c, s.State, "riak", "", "", "quantal", 42)
+	c.Assert(gen.UpdateCharmURL("riak", ch.URL()), jc.ErrorIsNil)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.CharmURLs(), gc.DeepEquals, map[string]string{"riak": ch.URL().String()})
+	c.Check(gen.AssignedUnits(), gc.DeepEquals, map[string][]string{"riak": {}})
+
+	// Idempotent.
+	c.Assert(gen.UpdateCharmURL("riak", ch.URL()), jc.ErrorIsNil)
+}
+
+func (s *generationSuite) TestUpdateCharmURLCharmNotFound(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	err := gen.UpdateCharmURL("riak", charm.MustParseURL("local:quantal/riak-666"))
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *generationSuite) TestUpdateConstraints(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+	c.Assert(gen.Constraints(), gc.HasLen, 0)
+
+	cons := constraints.MustParse("mem=4G cores=2")
+	c.Assert(gen.UpdateConstraints("riak", cons), jc.ErrorIsNil)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.Constraints(), jc.DeepEquals, map[string]constraints.Value{"riak": cons})
+	c.Check(gen.AssignedUnits(), gc.DeepEquals, map[string][]string{"riak": {}})
+}
+
+func (s *generationSuite) TestUnitTrackingBranchGetsStagedChanges(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	ch := state.AddCustomCharm(c, s.State, "riak", "", "", "quantal", 42)
+	c.Assert(gen.UpdateCharmURL("riak", ch.URL()), jc.ErrorIsNil)
+	c.Assert(gen.UpdateConstraints("riak", constraints.MustParse("mem=4G")), jc.ErrorIsNil)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+
+	tracking, err := s.State.Unit("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _, err := tracking.ApplicationCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(curl, gc.DeepEquals, ch.URL())
+	cons, err := tracking.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cons.String(), gc.Equals, "mem=4096M")
+
+	// Units not tracking the branch are unaffected.
+	notTracking, err := s.State.Unit("riak/1")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _, err = notTracking.ApplicationCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(curl.Revision, gc.Not(gc.Equals), 42)
+	cons, err = notTracking.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cons.HasMem(), jc.IsFalse)
+
+	// Aborting the branch puts the tracking unit back on master.
+	s.setupTestingClock(c)
+	c.Assert(gen.Abort(branchCommitter), jc.ErrorIsNil)
+	curl, _, err = tracking.ApplicationCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(curl.Revision, gc.Not(gc.Equals), 42)
+	cons, err = tracking.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cons.HasMem(), jc.IsFalse)
+}
+
+func (s *generationSuite) TestCommitAppliesCharmURLAndConstraints(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	ch := state.AddCustomCharm(c, s.State, "riak", "", "", "quantal", 42)
+	c.Assert(gen.UpdateCharmURL("riak", ch.URL()), jc.ErrorIsNil)
+	c.Assert(gen.UpdateConstraints("riak", constraints.MustParse("mem=4G")), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	genId, err := gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(genId, gc.Not(gc.Equals), 0)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Check(curl, gc.DeepEquals, ch.URL())
+	cons, err := app.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cons, jc.DeepEquals, constraints.MustParse("mem=4G"))
+}
+
+func (s *generationSuite) TestUnitTrackingBranchUpgradesToStagedCharm(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	ch := state.AddCustomCharm(c, s.State, "riak", "", "", "quantal", 42)
+	c.Assert(gen.UpdateCharmURL("riak", ch.URL()), jc.ErrorIsNil)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+
+	unit, err := s.State.Unit("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.SetCharmURL(ch.URL()), jc.ErrorIsNil)
+	_, err = unit.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unit keeps its references when the branch is committed.
+	s.setupTestingClock(c)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	_, err = gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = unit.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *generationSuite) TestUpdateCharmURLNotifiesApplicationWatcher(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	w := app.Watch()
+	defer testing.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	ch := state.AddCustomCharm(c, s.State, "riak", "", "", "quantal", 42)
+	c.Assert(gen.UpdateCharmURL("riak", ch.URL()), jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// The staged charm does not affect the application's own charm.
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Check(curl.Revision, gc.Not(gc.Equals), 42)
+
+	s.setupTestingClock(c)
+	c.Assert(gen.Abort(branchCommitter), jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *generationSuite) TestUpdateCharmURLReplacesStagedCharm(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	ch1 := state.AddCustomCharm(c, s.State, "riak", "", "", "quantal", 42)
+	c.Assert(gen.UpdateCharmURL("riak", ch1.URL()), jc.ErrorIsNil)
+	ch2 := state.AddCustomCharm(c, s.State, "riak", "", "", "quantal", 43)
+	c.Assert(gen.UpdateCharmURL("riak", ch2.URL()), jc.ErrorIsNil)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.CharmURLs(), gc.DeepEquals, map[string]string{"riak": ch2.URL().String()})
+
+	// The first charm is no longer referenced, so it can be removed.
+	c.Assert(ch1.Destroy(), jc.ErrorIsNil)
+	c.Assert(ch2.Destroy(), gc.ErrorMatches, "charm in use")
+}
+
+func (s *generationSuite) TestCommitReleasesCharmOfRemovedApplication(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupRemovedApplicationWithStagedCharm(c)
+
+	_, err := gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStagedCharmReleased(c)
+}
+
+func (s *generationSuite) TestAbortReleasesCharmOfRemovedApplication(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupRemovedApplicationWithStagedCharm(c)
+
+	c.Assert(gen.Abort(branchCommitter), jc.ErrorIsNil)
+	s.assertStagedCharmReleased(c)
+}
+
+// setupRemovedApplicationWithStagedCharm returns a branch with a charm
+// staged for an application that has since been removed.
+func (s *generationSuite) setupRemovedApplicationWithStagedCharm(c *gc.C) *state.Generation {
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	gen := s.addBranch(c)
+
+	ch := state.AddCustomCharm(c, s.State, "mysql", "", "", "quantal", 42)
+	c.Assert(gen.UpdateCharmURL("mysql", ch.URL()), jc.ErrorIsNil)
+	c.Assert(mysql.Destroy(), jc.ErrorIsNil)
+	_, err := s.State.Application("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	return gen
+}
+
+func (s *generationSuite) assertStagedCharmReleased(c *gc.C) {
+	ch, err := s.State.Charm(charm.MustParseURL("local:quantal/mysql-42"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.Destroy(), jc.ErrorIsNil)
+}
 
 func (s *generationSuite) TestBranchCharmConfigDeltas(c *gc.C) {
 	gen := s.setupAssignAllUnits(c)
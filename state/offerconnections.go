@@ -5,13 +5,18 @@ package state
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/juju/core/status"
+	jujutxn "github.com/juju/txn"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
 )
 
 // OfferConnection represents the state of an relation
@@ -64,13 +69,41 @@ func (oc *OfferConnection) RelationKey() string {
 	return oc.doc.RelationKey
 }
 
-func removeOfferConnectionsForRelationOps(relId int) []txn.Op {
+func removeOfferConnectionsForRelationOps(st *State, relId int) ([]txn.Op, error) {
 	op := txn.Op{
 		C:      offerConnectionsC,
 		Id:     fmt.Sprintf("%d", relId),
 		Remove: true,
 	}
-	return []txn.Op{op}
+	offerConnectionCollection, closer := st.db().GetCollection(offerConnectionsC)
+	defer closer()
+
+	var connDoc offerConnectionDoc
+	err := offerConnectionCollection.FindId(op.Id).One(&connDoc)
+	if err == mgo.ErrNotFound {
+		return []txn.Op{op}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get offer connection for relation id %d", relId)
+	}
+	ops := []txn.Op{op}
+
+	offers := &applicationOffers{st: st}
+	offerDoc, err := offers.offerQuery(bson.D{{"offer-uuid", connDoc.OfferUUID}})
+	if err == mgo.ErrNotFound {
+		return ops, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot load application offer %q", connDoc.OfferUUID)
+	}
+	if offerDoc.ConnectionCount != nil {
+		// The count is never unset once added, so it can be
+		// decremented without asserting that it exists.
+		ops = append(ops, txn.Op{
+			C:      applicationOffersC,
+			Id:     offerDoc.DocID,
+			Update: bson.D{{"$inc", bson.D{{"connection-count", -1}}}},
+		})
+	}
+	return ops, nil
 }
 
 // String returns the details of the connection.
@@ -134,13 +167,25 @@ func (st *State) AddOfferConnection(args AddOfferConnectionParams) (_ *OfferConn
 		DocID:           fmt.Sprintf("%d", args.RelationId),
 	}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
-		// If we've tried once already and failed, check that
-		// model may have been destroyed.
+		// If we've tried once already and failed, check whether
+		// the model has been destroyed or the connection added;
+		// otherwise the offer's connections changed, so check
+		// them again.
 		if attempt > 0 {
 			if err := checkModelActive(st); err != nil {
 				return nil, errors.Trace(err)
 			}
-			return nil, errors.AlreadyExistsf("offer connection for relation id %d", args.RelationId)
+			offerConnectionCollection, closer := st.db().GetCollection(offerConnectionsC)
+			defer closer()
+			if n, err := offerConnectionCollection.FindId(offerConnectionDoc.DocID).Count(); err != nil {
+				return nil, errors.Trace(err)
+			} else if n > 0 {
+				return nil, errors.AlreadyExistsf("offer connection for relation id %d", args.RelationId)
+			}
+		}
+		checkOps, err := st.checkOfferConnectionOps(args)
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
 		ops := []txn.Op{
 			model.assertActiveOp(),
@@ -151,7 +196,7 @@ func (st *State) AddOfferConnection(args AddOfferConnectionParams) (_ *OfferConn
 				Insert: &offerConnectionDoc,
 			},
 		}
-		return ops, nil
+		return append(ops, checkOps...), nil
 	}
 	if err = st.db().Run(buildTxn); err != nil {
 		return nil, errors.Trace(err)
@@ -159,6 +204,118 @@ func (st *State) AddOfferConnection(args AddOfferConnectionParams) (_ *OfferConn
 	return &OfferConnection{doc: offerConnectionDoc}, nil
 }
 
+// CheckOfferConnection returns an error if the offer does not accept the
+// connection described by args: the user's request to consume the offer
+// may need approval, and the offer may limit the number of consuming
+// models and relations. A connection for a relation which is already
+// connected to the offer is always accepted.
+func (st *State) CheckOfferConnection(args AddOfferConnectionParams) error {
+	_, err := st.checkOfferConnectionOps(args)
+	return errors.Trace(err)
+}
+
+// checkOfferConnectionOps checks the connection as CheckOfferConnection
+// does, returning the operations needed to add it to the offer's
+// connection count and to assert that the checks still hold.
+func (st *State) checkOfferConnectionOps(args AddOfferConnectionParams) ([]txn.Op, error) {
+	offers := &applicationOffers{st: st}
+	doc, err := offers.offerQuery(bson.D{{"offer-uuid", args.OfferUUID}})
+	if err == mgo.ErrNotFound {
+		// Connections are recorded regardless of whether the offer
+		// exists, in which case there is nothing to enforce.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot load application offer %q", args.OfferUUID)
+	}
+
+	var ops []txn.Op
+	if doc.RequireApproval {
+		approved, admin, err := st.offerConnectionApproved(args.OfferUUID, args.Username)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !approved {
+			return nil, errors.Unauthorizedf("user %q is not approved to consume offer %q", args.Username, doc.OfferName)
+		}
+		if !admin {
+			ops = append(ops, txn.Op{
+				C:      offerConnectionRequestsC,
+				Id:     offerConnectionRequestKey(args.OfferUUID, args.Username),
+				Assert: bson.D{{"status", string(OfferConnectionRequestApproved)}},
+			})
+		}
+	}
+
+	conns, err := st.OfferConnections(args.OfferUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	countOp := txn.Op{
+		C:  applicationOffersC,
+		Id: doc.DocID,
+	}
+	if doc.ConnectionCount == nil {
+		countOp.Assert = bson.D{{"connection-count", bson.D{{"$exists", false}}}}
+		countOp.Update = bson.D{{"$set", bson.D{{"connection-count", len(conns) + 1}}}}
+	} else {
+		countOp.Assert = bson.D{{"connection-count", *doc.ConnectionCount}}
+		countOp.Update = bson.D{{"$inc", bson.D{{"connection-count", 1}}}}
+	}
+	ops = append(ops, countOp)
+	if doc.MaxConsumers == 0 && doc.MaxRelations == 0 {
+		return ops, nil
+	}
+
+	models := set.NewStrings()
+	for _, conn := range conns {
+		if args.RelationKey != "" && conn.RelationKey() == args.RelationKey {
+			return ops, nil
+		}
+		models.Add(conn.SourceModelUUID())
+	}
+	if doc.MaxRelations > 0 && len(conns) >= doc.MaxRelations {
+		return nil, errors.Errorf(
+			"offer %q accepts at most %d relation%s",
+			doc.OfferName, doc.MaxRelations, plural(doc.MaxRelations),
+		)
+	}
+	if doc.MaxConsumers > 0 && !models.Contains(args.SourceModelUUID) && models.Size() >= doc.MaxConsumers {
+		return nil, errors.Errorf(
+			"offer %q accepts at most %d consuming model%s",
+			doc.OfferName, doc.MaxConsumers, plural(doc.MaxConsumers),
+		)
+	}
+	return ops, nil
+}
+
+// offerConnectionApproved returns whether the user may consume an offer
+// that requires approval, and whether that is because the user is an
+// offer admin, model admin or controller superuser, who need no approval.
+func (st *State) offerConnectionApproved(offerUUID, username string) (approved, admin bool, _ error) {
+	user := names.NewUserTag(username)
+	isAdmin, err := st.isControllerOrModelAdmin(user)
+	if err != nil {
+		return false, false, errors.Trace(err)
+	}
+	if isAdmin {
+		return true, true, nil
+	}
+	access, err := st.GetOfferAccess(offerUUID, user)
+	if err != nil && !errors.IsNotFound(err) {
+		return false, false, errors.Trace(err)
+	}
+	if access == permission.AdminAccess {
+		return true, true, nil
+	}
+	req, err := st.OfferConnectionRequest(offerUUID, username)
+	if errors.IsNotFound(err) {
+		return false, false, nil
+	} else if err != nil {
+		return false, false, errors.Trace(err)
+	}
+	return req.Status() == OfferConnectionRequestApproved, false, nil
+}
+
 // OfferConnections returns the offer connections for an offer.
 func (st *State) OfferConnections(offerUUID string) (conns []*OfferConnection, err error) {
 	offerConnectionCollection, closer := st.db().GetCollection(offerConnectionsC)
@@ -251,3 +408,202 @@ func (r *RemoteConnectionStatus) TotalConnectionCount() int {
 func (r *RemoteConnectionStatus) ActiveConnectionCount() int {
 	return r.activeCount
 }
+
+// OfferConnectionRequestStatus describes the state of a request to
+// consume an offer which requires approval.
+type OfferConnectionRequestStatus string
+
+const (
+	// OfferConnectionRequestPending is the status of a request which
+	// has not yet been approved or rejected.
+	OfferConnectionRequestPending OfferConnectionRequestStatus = "pending"
+
+	// OfferConnectionRequestApproved is the status of a request which
+	// an offer admin has approved.
+	OfferConnectionRequestApproved OfferConnectionRequestStatus = "approved"
+
+	// OfferConnectionRequestRejected is the status of a request which
+	// an offer admin has rejected.
+	OfferConnectionRequestRejected OfferConnectionRequestStatus = "rejected"
+)
+
+// OfferConnectionRequest represents a user's request to consume an
+// offer which requires approval by an offer admin.
+type OfferConnectionRequest struct {
+	st  *State
+	doc offerConnectionRequestDoc
+}
+
+// offerConnectionRequestDoc represents the internal state of an offer
+// connection request in MongoDB.
+type offerConnectionRequestDoc struct {
+	DocID     string    `bson:"_id"`
+	OfferUUID string    `bson:"offer-uuid"`
+	UserName  string    `bson:"username"`
+	Status    string    `bson:"status"`
+	Requested time.Time `bson:"requested"`
+	Updated   time.Time `bson:"updated"`
+}
+
+func offerConnectionRequestKey(offerUUID, username string) string {
+	return fmt.Sprintf("%s#%s", offerUUID, username)
+}
+
+// OfferUUID returns the UUID of the requested offer.
+func (r *OfferConnectionRequest) OfferUUID() string {
+	return r.doc.OfferUUID
+}
+
+// UserName returns the name of the user who made the request.
+func (r *OfferConnectionRequest) UserName() string {
+	return r.doc.UserName
+}
+
+// Status returns the status of the request.
+func (r *OfferConnectionRequest) Status() OfferConnectionRequestStatus {
+	return OfferConnectionRequestStatus(r.doc.Status)
+}
+
+// Requested returns when the request was first made.
+func (r *OfferConnectionRequest) Requested() time.Time {
+	return r.doc.Requested
+}
+
+// Updated returns when the request was last approved or rejected,
+// or made if it is pending.
+func (r *OfferConnectionRequest) Updated() time.Time {
+	return r.doc.Updated
+}
+
+// RequestOfferConnection records a pending request by the user to consume
+// the offer, returning the existing request if the user has made one before.
+func (st *State) RequestOfferConnection(offerUUID, username string) (_ *OfferConnectionRequest, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot request connection to offer %q", offerUUID)
+
+	if !names.IsValidUser(username) {
+		return nil, errors.NotValidf("offer connection user %q", username)
+	}
+	offers := &applicationOffers{st: st}
+	if _, err := offers.ApplicationOfferForUUID(offerUUID); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	now := st.clock().Now().UTC().Round(time.Second)
+	doc := offerConnectionRequestDoc{
+		DocID:     offerConnectionRequestKey(offerUUID, username),
+		OfferUUID: offerUUID,
+		UserName:  username,
+		Status:    string(OfferConnectionRequestPending),
+		Requested: now,
+		Updated:   now,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := st.OfferConnectionRequest(offerUUID, username); err == nil {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		return []txn.Op{{
+			C:      offerConnectionRequestsC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st.OfferConnectionRequest(offerUUID, username)
+}
+
+// OfferConnectionRequest returns the user's request to consume the offer.
+func (st *State) OfferConnectionRequest(offerUUID, username string) (*OfferConnectionRequest, error) {
+	requests, closer := st.db().GetCollection(offerConnectionRequestsC)
+	defer closer()
+
+	var doc offerConnectionRequestDoc
+	err := requests.FindId(offerConnectionRequestKey(offerUUID, username)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("request by %q to consume offer %q", username, offerUUID)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get request by %q to consume offer %q", username, offerUUID)
+	}
+	return &OfferConnectionRequest{st: st, doc: doc}, nil
+}
+
+// OfferConnectionRequests returns all the requests to consume the offer.
+func (st *State) OfferConnectionRequests(offerUUID string) ([]*OfferConnectionRequest, error) {
+	requests, closer := st.db().GetCollection(offerConnectionRequestsC)
+	defer closer()
+
+	var docs []offerConnectionRequestDoc
+	err := requests.Find(bson.D{{"offer-uuid", offerUUID}}).Sort("requested", "username").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get requests to consume offer %q", offerUUID)
+	}
+	result := make([]*OfferConnectionRequest, len(docs))
+	for i, doc := range docs {
+		result[i] = &OfferConnectionRequest{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// ApproveOfferConnectionRequest approves the user's request to consume
+// the offer.
+func (st *State) ApproveOfferConnectionRequest(offerUUID, username string) error {
+	return st.setOfferConnectionRequestStatus(offerUUID, username, OfferConnectionRequestApproved)
+}
+
+// RejectOfferConnectionRequest rejects the user's request to consume the
+// offer. The user can no longer make new relations to the offer; any
+// existing relations are not affected.
+func (st *State) RejectOfferConnectionRequest(offerUUID, username string) error {
+	return st.setOfferConnectionRequestStatus(offerUUID, username, OfferConnectionRequestRejected)
+}
+
+func (st *State) setOfferConnectionRequestStatus(offerUUID, username string, status OfferConnectionRequestStatus) error {
+	req, err := st.OfferConnectionRequest(offerUUID, username)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      offerConnectionRequestsC,
+		Id:     req.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{
+			{"status", string(status)},
+			{"updated", st.clock().Now().UTC().Round(time.Second)},
+		}}},
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("request by %q to consume offer %q", username, offerUUID)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot update request by %q to consume offer %q", username, offerUUID)
+	}
+	return nil
+}
+
+// removeOfferConnectionRequestsOps returns the operations to remove all
+// the requests to consume the offer.
+func removeOfferConnectionRequestsOps(st *State, offerUUID string) ([]txn.Op, error) {
+	requests, closer := st.db().GetCollection(offerConnectionRequestsC)
+	defer closer()
+
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	err := requests.Find(bson.D{{"offer-uuid", offerUUID}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get requests to consume offer %q", offerUUID)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      offerConnectionRequestsC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
@@ -7,12 +7,16 @@ import (
 	"fmt"
 
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/errors"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type offerConnectionsSuite struct {
@@ -152,3 +156,195 @@ func (s *offerConnectionsSuite) TestOfferConnectionsForUser(c *gc.C) {
 	c.Assert(obtained[0].OfferUUID(), gc.Equals, oc.OfferUUID())
 	c.Assert(obtained[0].UserName(), gc.Equals, oc.UserName())
 }
+
+func (s *offerConnectionsSuite) addOffer(c *gc.C, args crossmodel.AddApplicationOfferArgs) (*crossmodel.ApplicationOffer, string) {
+	owner := s.Factory.MakeUser(c, nil)
+	args.OfferName = "hosted-mysql"
+	args.ApplicationName = "mysql"
+	args.Endpoints = map[string]string{"server": "server"}
+	args.Owner = owner.Name()
+	offer, err := state.NewApplicationOffers(s.State).AddOffer(args)
+	c.Assert(err, jc.ErrorIsNil)
+	return offer, owner.Name()
+}
+
+func (s *offerConnectionsSuite) addOfferConnection(offerUUID, username, modelUUID string, rel *state.Relation) error {
+	_, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: modelUUID,
+		RelationId:      rel.Id(),
+		RelationKey:     rel.Tag().Id(),
+		Username:        username,
+		OfferUUID:       offerUUID,
+	})
+	return err
+}
+
+func (s *offerConnectionsSuite) TestRequestOfferConnection(c *gc.C) {
+	offer, _ := s.addOffer(c, crossmodel.AddApplicationOfferArgs{RequireApproval: true})
+
+	req, err := s.State.RequestOfferConnection(offer.OfferUUID, "fred")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(req.OfferUUID(), gc.Equals, offer.OfferUUID)
+	c.Check(req.UserName(), gc.Equals, "fred")
+	c.Check(req.Status(), gc.Equals, state.OfferConnectionRequestPending)
+	c.Check(req.Requested().IsZero(), jc.IsFalse)
+
+	// Requesting again returns the existing request.
+	again, err := s.State.RequestOfferConnection(offer.OfferUUID, "fred")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(again.Requested(), gc.DeepEquals, req.Requested())
+
+	_, err = s.State.RequestOfferConnection(offer.OfferUUID, "mary")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RejectOfferConnectionRequest(offer.OfferUUID, "mary")
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.OfferConnectionRequests(offer.OfferUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+	c.Check(all[0].UserName(), gc.Equals, "fred")
+	c.Check(all[0].Status(), gc.Equals, state.OfferConnectionRequestPending)
+	c.Check(all[1].UserName(), gc.Equals, "mary")
+	c.Check(all[1].Status(), gc.Equals, state.OfferConnectionRequestRejected)
+}
+
+func (s *offerConnectionsSuite) TestRequestOfferConnectionUnknownOffer(c *gc.C) {
+	_, err := s.State.RequestOfferConnection("offer-uuid", "fred")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *offerConnectionsSuite) TestApproveOfferConnectionRequestNotFound(c *gc.C) {
+	offer, _ := s.addOffer(c, crossmodel.AddApplicationOfferArgs{RequireApproval: true})
+	err := s.State.ApproveOfferConnectionRequest(offer.OfferUUID, "fred")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *offerConnectionsSuite) TestAddOfferConnectionRequiresApproval(c *gc.C) {
+	offer, owner := s.addOffer(c, crossmodel.AddApplicationOfferArgs{RequireApproval: true})
+
+	err := s.addOfferConnection(offer.OfferUUID, "fred", testing.ModelTag.Id(), s.activeRel)
+	c.Assert(err, gc.ErrorMatches, `cannot add offer record for ".*": user "fred" is not approved to consume offer "hosted-mysql"`)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+
+	_, err = s.State.RequestOfferConnection(offer.OfferUUID, "fred")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.addOfferConnection(offer.OfferUUID, "fred", testing.ModelTag.Id(), s.activeRel)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+
+	err = s.State.ApproveOfferConnectionRequest(offer.OfferUUID, "fred")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.addOfferConnection(offer.OfferUUID, "fred", testing.ModelTag.Id(), s.activeRel)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Offer admins need no approval.
+	err = s.addOfferConnection(offer.OfferUUID, owner, testing.ModelTag.Id(), s.suspendedRel)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *offerConnectionsSuite) TestCheckOfferConnectionAdminsNeedNoApproval(c *gc.C) {
+	offer, _ := s.addOffer(c, crossmodel.AddApplicationOfferArgs{RequireApproval: true})
+	modelAdmin := s.Factory.MakeUser(c, &factory.UserParams{Access: permission.AdminAccess})
+	superuser := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	_, err := s.State.SetUserAccess(superuser.UserTag(), s.State.ControllerTag(), permission.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	other := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+
+	check := func(username string) error {
+		return s.State.CheckOfferConnection(state.AddOfferConnectionParams{
+			SourceModelUUID: testing.ModelTag.Id(),
+			Username:        username,
+			OfferUUID:       offer.OfferUUID,
+		})
+	}
+	c.Check(check(modelAdmin.Name()), jc.ErrorIsNil)
+	c.Check(check(superuser.Name()), jc.ErrorIsNil)
+	err = check(other.Name())
+	c.Check(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *offerConnectionsSuite) TestAddOfferConnectionMaxRelations(c *gc.C) {
+	offer, _ := s.addOffer(c, crossmodel.AddApplicationOfferArgs{MaxRelations: 1})
+
+	err := s.addOfferConnection(offer.OfferUUID, "fred", testing.ModelTag.Id(), s.activeRel)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.addOfferConnection(offer.OfferUUID, "fred", testing.ModelTag.Id(), s.suspendedRel)
+	c.Assert(err, gc.ErrorMatches, `cannot add offer record for ".*": offer "hosted-mysql" accepts at most 1 relation`)
+
+	// The existing connection is still accepted.
+	err = s.State.CheckOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationKey:     s.activeRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       offer.OfferUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *offerConnectionsSuite) TestAddOfferConnectionMaxConsumers(c *gc.C) {
+	offer, _ := s.addOffer(c, crossmodel.AddApplicationOfferArgs{MaxConsumers: 1})
+
+	err := s.addOfferConnection(offer.OfferUUID, "fred", testing.ModelTag.Id(), s.activeRel)
+	c.Assert(err, jc.ErrorIsNil)
+
+	otherModel := utils.MustNewUUID().String()
+	err = s.State.CheckOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: otherModel,
+		Username:        "mary",
+		OfferUUID:       offer.OfferUUID,
+	})
+	c.Assert(err, gc.ErrorMatches, `offer "hosted-mysql" accepts at most 1 consuming model`)
+
+	// More relations from a connected model are accepted.
+	err = s.addOfferConnection(offer.OfferUUID, "fred", testing.ModelTag.Id(), s.suspendedRel)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *offerConnectionsSuite) TestAddOfferConnectionMaxRelationsConcurrent(c *gc.C) {
+	offer, _ := s.addOffer(c, crossmodel.AddApplicationOfferArgs{MaxRelations: 1})
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.addOfferConnection(offer.OfferUUID, "fred", testing.ModelTag.Id(), s.activeRel)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err := s.addOfferConnection(offer.OfferUUID, "fred", testing.ModelTag.Id(), s.suspendedRel)
+	c.Assert(err, gc.ErrorMatches, `cannot add offer record for ".*": offer "hosted-mysql" accepts at most 1 relation`)
+}
+
+func (s *offerConnectionsSuite) TestAddOfferConnectionRejectedConcurrently(c *gc.C) {
+	offer, _ := s.addOffer(c, crossmodel.AddApplicationOfferArgs{RequireApproval: true})
+	_, err := s.State.RequestOfferConnection(offer.OfferUUID, "fred")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ApproveOfferConnectionRequest(offer.OfferUUID, "fred")
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.RejectOfferConnectionRequest(offer.OfferUUID, "fred")
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err = s.addOfferConnection(offer.OfferUUID, "fred", testing.ModelTag.Id(), s.activeRel)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *offerConnectionsSuite) TestRemoveOfferConnectionAllowsAnother(c *gc.C) {
+	offer, _ := s.addOffer(c, crossmodel.AddApplicationOfferArgs{MaxRelations: 1})
+
+	err := s.addOfferConnection(offer.OfferUUID, "fred", testing.ModelTag.Id(), s.activeRel)
+	c.Assert(err, jc.ErrorIsNil)
+	state.RemoveOfferConnectionsForRelation(c, s.activeRel)
+	err = s.addOfferConnection(offer.OfferUUID, "fred", testing.ModelTag.Id(), s.suspendedRel)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *offerConnectionsSuite) TestRemoveOfferRemovesRequests(c *gc.C) {
+	offer, _ := s.addOffer(c, crossmodel.AddApplicationOfferArgs{RequireApproval: true})
+	_, err := s.State.RequestOfferConnection(offer.OfferUUID, "fred")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.NewApplicationOffers(s.State).Remove(offer.OfferName, false)
+	c.Assert(err, jc.ErrorIsNil)
+	all, err := s.State.OfferConnectionRequests(offer.OfferUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+}
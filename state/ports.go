@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
@@ -37,6 +38,11 @@ type PortRange struct {
 	FromPort int
 	ToPort   int
 	Protocol string
+
+	// Endpoint is the name of the unit's endpoint the ports were
+	// opened for. It is empty if they were opened for all of the
+	// unit's endpoints.
+	Endpoint string `bson:"endpoint,omitempty"`
 }
 
 // NewPortRange create a new port range and validate it.
@@ -121,8 +127,9 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 
 	// An exact port range match (including the associated unit name) is not
 	// considered a conflict due to the fact that many charms issue commands
-	// to open the same port multiple times.
-	if prA == prB {
+	// to open the same port multiple times. The same unit may also open
+	// the same range for several of its endpoints.
+	if prA.sameRange(prB) {
 		return nil
 	}
 	if prA.Protocol != prB.Protocol {
@@ -134,13 +141,24 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 	return nil
 }
 
+// sameRange reports whether the two port ranges were opened by the
+// same unit for the same ports, regardless of their endpoints.
+func (prA PortRange) sameRange(prB PortRange) bool {
+	prA.Endpoint, prB.Endpoint = "", ""
+	return prA == prB
+}
+
 // Strings returns the port range as a string.
 func (p PortRange) String() string {
+	owner := fmt.Sprintf("%q", p.UnitName)
+	if p.Endpoint != "" {
+		owner = fmt.Sprintf("%q endpoint %q", p.UnitName, p.Endpoint)
+	}
 	proto := strings.ToLower(p.Protocol)
 	if proto == "icmp" {
-		return fmt.Sprintf("%s (%q)", proto, p.UnitName)
+		return fmt.Sprintf("%s (%s)", proto, owner)
 	}
-	return fmt.Sprintf("%d-%d/%s (%q)", p.FromPort, p.ToPort, proto, p.UnitName)
+	return fmt.Sprintf("%d-%d/%s (%s)", p.FromPort, p.ToPort, proto, owner)
 }
 
 // portsDoc represents the state of ports opened on machines for networks
@@ -295,7 +313,10 @@ func (p *Ports) ClosePorts(portRange PortRange) (err error) {
 
 		found := false
 		for _, existingPortsDef := range ports.doc.Ports {
-			if existingPortsDef == portRange {
+			// Closing ports for all endpoints also closes them for
+			// each endpoint they were opened for.
+			if existingPortsDef == portRange ||
+				portRange.Endpoint == "" && existingPortsDef.sameRange(portRange) {
 				found = true
 				continue
 			}
@@ -364,6 +385,34 @@ func (p *Ports) AllPortRanges() map[network.PortRange]string {
 	return result
 }
 
+// PortRangeEndpoints returns a map with the network.PortRange of each
+// range opened only for specific endpoints as keys, and the names of
+// those endpoints as values. Ranges opened for all of the endpoints of
+// their unit are not included.
+func (p *Ports) PortRangeEndpoints() map[network.PortRange][]string {
+	allEndpoints := make(map[network.PortRange]bool)
+	endpoints := make(map[network.PortRange][]string)
+	for _, portRange := range p.doc.Ports {
+		rawRange := network.PortRange{
+			FromPort: portRange.FromPort,
+			ToPort:   portRange.ToPort,
+			Protocol: portRange.Protocol,
+		}
+		if portRange.Endpoint == "" {
+			allEndpoints[rawRange] = true
+			continue
+		}
+		endpoints[rawRange] = append(endpoints[rawRange], portRange.Endpoint)
+	}
+	for rawRange := range allEndpoints {
+		delete(endpoints, rawRange)
+	}
+	for _, names := range endpoints {
+		sort.Strings(names)
+	}
+	return endpoints
+}
+
 // Remove removes the ports document from state.
 func (p *Ports) Remove() error {
 	ports := &Ports{st: p.st, doc: p.doc}
@@ -508,17 +557,10 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 	}
 	var ops []txn.Op
 	for _, ports := range allPorts {
-		allRanges := ports.AllPortRanges()
 		var keepPorts []PortRange
-		for portRange, unitName := range allRanges {
-			if unitName != unit.Name() {
-				unitRange := PortRange{
-					UnitName: unitName,
-					FromPort: portRange.FromPort,
-					ToPort:   portRange.ToPort,
-					Protocol: portRange.Protocol,
-				}
-				keepPorts = append(keepPorts, unitRange)
+		for _, portRange := range ports.doc.Ports {
+			if portRange.UnitName != unit.Name() {
+				keepPorts = append(keepPorts, portRange)
 			}
 		}
 		if len(keepPorts) > 0 {
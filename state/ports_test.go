@@ -314,6 +314,47 @@ func (s *PortsDocSuite) TestAllPortRanges(c *gc.C) {
 	c.Assert(ranges[network.PortRange{100, 200, "TCP"}], gc.Equals, s.unit1.Name())
 }
 
+func (s *PortsDocSuite) TestOpenPortsForEndpoint(c *gc.C) {
+	err := s.unit1.OpenPortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit1.OpenPortsForEndpoint("db", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit1.OpenPortsForEndpoint("", "tcp", 443, 443)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit2.OpenPortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 80-80/tcp \("wordpress/1" endpoint "url"\) for unit "wordpress/1": .*conflict`)
+	err = s.unit1.OpenPortsForEndpoint("missing", "tcp", 80, 80)
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" has no "missing" relation`)
+
+	ports, err := s.machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.AllPortRanges(), jc.DeepEquals, map[network.PortRange]string{
+		{80, 80, "tcp"}:   s.unit1.Name(),
+		{443, 443, "tcp"}: s.unit1.Name(),
+	})
+	c.Assert(ports.PortRangeEndpoints(), jc.DeepEquals, map[network.PortRange][]string{
+		{80, 80, "tcp"}: {"db", "url"},
+	})
+
+	err = s.unit1.ClosePortsForEndpoint("db", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err = s.machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.PortRangeEndpoints(), jc.DeepEquals, map[network.PortRange][]string{
+		{80, 80, "tcp"}: {"url"},
+	})
+
+	// Closing the ports for all endpoints closes them for each endpoint.
+	err = s.unit1.ClosePorts("tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err = s.machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.AllPortRanges(), jc.DeepEquals, map[network.PortRange]string{
+		{443, 443, "tcp"}: s.unit1.Name(),
+	})
+	c.Assert(ports.PortRangeEndpoints(), gc.HasLen, 0)
+}
+
 func (s *PortsDocSuite) TestICMP(c *gc.C) {
 	portRange := state.PortRange{
 		FromPort: -1,
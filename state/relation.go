@@ -495,7 +495,10 @@ func (r *Relation) removeOps(ignoreApplication string, departingUnitName string,
 	re := r.st.RemoteEntities()
 	tokenOps := re.removeRemoteEntityOps(r.Tag())
 	ops = append(ops, tokenOps...)
-	offerOps := removeOfferConnectionsForRelationOps(r.Id())
+	offerOps, err := removeOfferConnectionsForRelationOps(r.st, r.Id())
+	if err != nil {
+		op.AddError(err)
+	}
 	ops = append(ops, offerOps...)
 	// This cleanup does not need to be forced.
 	cleanupOp := newCleanupOp(cleanupRelationSettings, fmt.Sprintf("r#%d#", r.Id()))
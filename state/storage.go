@@ -218,8 +218,9 @@ type storageInstanceDoc struct {
 // storageInstanceConstraints contains a subset of StorageConstraints,
 // for a single storage instance.
 type storageInstanceConstraints struct {
-	Pool string `bson:"pool"`
-	Size uint64 `bson:"size"`
+	Pool       string `bson:"pool"`
+	Size       uint64 `bson:"size"`
+	SnapshotId string `bson:"snapshotid,omitempty"`
 }
 
 type storageAttachment struct {
@@ -835,8 +836,9 @@ func createStorageOps(
 				Owner:       owner,
 				StorageName: t.storageName,
 				Constraints: storageInstanceConstraints{
-					Pool: cons.Pool,
-					Size: cons.Size,
+					Pool:       cons.Pool,
+					Size:       cons.Size,
+					SnapshotId: cons.SnapshotId,
 				},
 			}
 			var hostStorageOps []txn.Op
@@ -1671,6 +1673,12 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count uint64 `bson:"count"`
+
+	// SnapshotId, if non-empty, is the provider ID of a volume
+	// snapshot from which the storage instances are to be restored.
+	// It is only used when adding storage to a unit, and is never
+	// recorded as part of an application's storage constraints.
+	SnapshotId string `bson:"snapshotid,omitempty"`
 }
 
 func createStorageConstraintsOp(key string, cons map[string]StorageConstraints) txn.Op {
@@ -2085,6 +2093,12 @@ func (sb *storageBackend) addStorageForUnitOps(
 	}
 	ops := u.assertCharmOps(ch)
 
+	if cons.SnapshotId != "" && charmStorageMeta.Type != charm.StorageBlock {
+		return nil, nil, errors.NotSupportedf(
+			"restoring %s storage %q from a snapshot", charmStorageMeta.Type, storageName,
+		)
+	}
+
 	if cons.Pool == "" || cons.Size == 0 {
 		// Either pool or size, or both, were not specified. Take the
 		// values from the unit's recorded storage constraints.
@@ -123,6 +123,38 @@ func (s *storageAddSuite) TestAddStorageToUnitInheritPoolAndSize(c *gc.C) {
 	})
 }
 
+func (s *storageAddSuite) TestAddStorageToUnitFromSnapshot(c *gc.C) {
+	u := s.setupMultipleStoragesForAdd(c)
+	s.assignUnit(c, u)
+
+	cons := makeStorageCons("loop-pool", 4096, 1)
+	cons.SnapshotId = "snap-0"
+	_, err := s.storageBackend.AddStorageForUnit(s.unitTag, "multi1to10", cons)
+	c.Assert(err, jc.ErrorIsNil)
+
+	allVolumeParams := allMachineVolumeParams(c, s.storageBackend, s.machineTag)
+	c.Assert(allVolumeParams, jc.SameContents, []state.VolumeParams{
+		{Pool: "persistent-block", Size: 1024},
+		{Pool: "persistent-block", Size: 1024},
+		{Pool: "persistent-block", Size: 1024},
+		{Pool: "loop", Size: 2048},
+		{Pool: "loop", Size: 2048},
+		{Pool: "loop-pool", Size: 4096, SnapshotId: "snap-0"},
+	})
+}
+
+func (s *storageAddSuite) TestAddStorageFilesystemFromSnapshot(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "filesystem", "loop-pool")
+
+	cons := makeStorageCons("loop-pool", 1024, 1)
+	cons.SnapshotId = "snap-0"
+	_, err := s.storageBackend.AddStorageForUnit(u.UnitTag(), "data", cons)
+	c.Assert(err, gc.ErrorMatches, `adding "data" storage to storage-filesystem/0: `+
+		`restoring filesystem storage "data" from a snapshot not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	s.assertStorageCount(c, 1) // no change
+}
+
 func (s *storageAddSuite) TestAddStorageToUnitNotAssigned(c *gc.C) {
 	u := s.setupMultipleStoragesForAdd(c)
 	// don't assign unit
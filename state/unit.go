@@ -147,14 +147,77 @@ func (u *Unit) ConfigSettings() (charm.Settings, error) {
 		return nil, fmt.Errorf("unit charm not set")
 	}
 
-	// TODO (manadart 2019-02-21) Factor the current generation into this call.
-	s, err := charmSettingsWithDefaults(u.st, u.doc.CharmURL, u.doc.Application, model.GenerationMaster)
+	branchName, err := u.branchName()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s, err := charmSettingsWithDefaults(u.st, u.doc.CharmURL, u.doc.Application, branchName)
 	if err != nil {
 		return nil, errors.Annotatef(err, "charm config for unit %q", u.Name())
 	}
 	return s, nil
 }
 
+// branchName returns the name of the in-flight branch that the unit is
+// tracking, or the master generation if it is not tracking a branch.
+func (u *Unit) branchName() (string, error) {
+	branch, err := u.st.unitBranch(u.doc.Name)
+	if errors.IsNotFound(err) {
+		return model.GenerationMaster, nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return branch.BranchName(), nil
+}
+
+// ApplicationCharmURL returns the charm URL that the unit should be running.
+// This is the charm URL staged for the application under the branch that the
+// unit is tracking, or the application's charm URL if there is none.
+// Units yet to be handed the application's charm by a charm rollout are
+// given the charm that the rollout is upgrading from.
+// The returned bool indicates whether the upgrade should be forced.
+func (u *Unit) ApplicationCharmURL() (*charm.URL, bool, error) {
+	app, err := u.Application()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	branchName, err := u.branchName()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if rollout := app.doc.CharmRollout; branchName == model.GenerationMaster && rollout.holdsBack(u.doc.Name) {
+		curl, err := charm.ParseURL(rollout.FromCharmURL)
+		return curl, false, errors.Trace(err)
+	}
+	appURL, force := app.CharmURL()
+	curl, err := app.BranchCharmURL(branchName)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if curl.String() != appURL.String() {
+		force = false
+	}
+	return curl, force, nil
+}
+
+// ApplicationCharmModifiedVersion returns the charm modified version of the
+// application, as seen by the unit. Units yet to be handed the application's
+// charm by a charm rollout see the version from before the rollout started.
+func (u *Unit) ApplicationCharmModifiedVersion() (int, error) {
+	app, err := u.Application()
+	if err != nil {
+		return -1, errors.Trace(err)
+	}
+	branchName, err := u.branchName()
+	if err != nil {
+		return -1, errors.Trace(err)
+	}
+	if rollout := app.doc.CharmRollout; branchName == model.GenerationMaster && rollout.holdsBack(u.doc.Name) {
+		return rollout.FromCharmModifiedVersion, nil
+	}
+	return app.CharmModifiedVersion(), nil
+}
+
 // ApplicationName returns the application name.
 func (u *Unit) ApplicationName() string {
 	return u.doc.Application
@@ -1444,21 +1507,30 @@ func (u *Unit) OpenPortsOnSubnet(subnetID, protocol string, fromPort, toPort int
 	}
 	defer errors.DeferredAnnotatef(&err, "cannot open ports %v for unit %q on subnet %q", ports, u, subnetID)
 
+	machinePorts, err := u.machinePorts(subnetID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return machinePorts.OpenPorts(ports)
+}
+
+// machinePorts returns the ports document for the given subnet, which
+// can be empty, on the unit's assigned machine.
+func (u *Unit) machinePorts(subnetID string) (*Ports, error) {
 	machineID, err := u.AssignedMachineId()
 	if err != nil {
-		return errors.Annotatef(err, "unit %q has no assigned machine", u)
+		return nil, errors.Annotatef(err, "unit %q has no assigned machine", u)
 	}
 
 	if err := u.checkSubnetAliveWhenSet(subnetID); err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	machinePorts, err := getOrCreatePorts(u.st, machineID, subnetID)
 	if err != nil {
-		return errors.Annotate(err, "cannot get or create ports")
+		return nil, errors.Annotate(err, "cannot get or create ports")
 	}
-
-	return machinePorts.OpenPorts(ports)
+	return machinePorts, nil
 }
 
 func (u *Unit) checkSubnetAliveWhenSet(subnetID string) error {
@@ -1487,23 +1559,70 @@ func (u *Unit) ClosePortsOnSubnet(subnetID, protocol string, fromPort, toPort in
 	}
 	defer errors.DeferredAnnotatef(&err, "cannot close ports %v for unit %q on subnet %q", ports, u, subnetID)
 
-	machineID, err := u.AssignedMachineId()
+	machinePorts, err := u.machinePorts(subnetID)
 	if err != nil {
-		return errors.Annotatef(err, "unit %q has no assigned machine", u)
+		return errors.Trace(err)
 	}
+	return machinePorts.ClosePorts(ports)
+}
 
-	if err := u.checkSubnetAliveWhenSet(subnetID); err != nil {
+// OpenPortsForEndpoint opens the given port range and protocol for the
+// named endpoint of the unit, so that the ports are only accessible from
+// wherever that endpoint is exposed to. An empty endpoint opens the ports
+// for all of the unit's endpoints, as OpenPorts does.
+func (u *Unit) OpenPortsForEndpoint(endpoint, protocol string, fromPort, toPort int) (err error) {
+	ports, err := u.EndpointPortRange(endpoint, protocol, fromPort, toPort)
+	if err != nil {
 		return errors.Trace(err)
 	}
+	defer errors.DeferredAnnotatef(&err, "cannot open ports %v for unit %q", ports, u)
 
-	machinePorts, err := getOrCreatePorts(u.st, machineID, subnetID)
+	machinePorts, err := u.machinePorts("")
 	if err != nil {
-		return errors.Annotate(err, "cannot get or create ports")
+		return errors.Trace(err)
 	}
+	return machinePorts.OpenPorts(ports)
+}
+
+// ClosePortsForEndpoint closes the given port range and protocol for the
+// named endpoint of the unit. An empty endpoint closes the ports for all
+// of the unit's endpoints, as ClosePorts does.
+func (u *Unit) ClosePortsForEndpoint(endpoint, protocol string, fromPort, toPort int) (err error) {
+	ports, err := u.EndpointPortRange(endpoint, protocol, fromPort, toPort)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer errors.DeferredAnnotatef(&err, "cannot close ports %v for unit %q", ports, u)
 
+	machinePorts, err := u.machinePorts("")
+	if err != nil {
+		return errors.Trace(err)
+	}
 	return machinePorts.ClosePorts(ports)
 }
 
+// EndpointPortRange returns the port range opened by the unit for the
+// named endpoint, which must be one of the endpoints of its charm. An
+// empty endpoint refers to all of the unit's endpoints.
+func (u *Unit) EndpointPortRange(endpoint, protocol string, fromPort, toPort int) (PortRange, error) {
+	ports, err := NewPortRange(u.Name(), fromPort, toPort, protocol)
+	if err != nil {
+		return PortRange{}, errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
+	}
+	if endpoint == "" {
+		return ports, nil
+	}
+	app, err := u.Application()
+	if err != nil {
+		return PortRange{}, errors.Trace(err)
+	}
+	if _, err := app.Endpoint(endpoint); err != nil {
+		return PortRange{}, errors.Trace(err)
+	}
+	ports.Endpoint = endpoint
+	return ports, nil
+}
+
 // OpenPorts opens the given port range and protocol for the unit, if it does
 // not conflict with another already opened range on the unit's assigned
 // machine.
@@ -2224,6 +2343,20 @@ func (u *Unit) Constraints() (*constraints.Value, error) {
 	} else if err != nil {
 		return nil, err
 	}
+
+	// If the unit is tracking a branch with constraints set for its
+	// application, they take precedence over those set at unit creation.
+	branch, err := u.st.unitBranch(u.doc.Name)
+	if errors.IsNotFound(err) {
+		return &cons, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if branchCons, ok := branch.Constraints()[u.doc.Application]; ok {
+		if cons, err = u.st.ResolveConstraints(branchCons); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	return &cons, nil
 }
 
@@ -2560,9 +2693,10 @@ func storageParamsForStorageInstance(
 			volumeAttachments[volume.VolumeTag()] = volumeAttachmentParams
 		} else if errors.IsNotFound(err) {
 			volumeParams := VolumeParams{
-				storage: storage.StorageTag(),
-				Pool:    storage.doc.Constraints.Pool,
-				Size:    storage.doc.Constraints.Size,
+				storage:    storage.StorageTag(),
+				Pool:       storage.doc.Constraints.Pool,
+				Size:       storage.doc.Constraints.Size,
+				SnapshotId: storage.doc.Constraints.SnapshotId,
 			}
 			volumes = append(volumes, HostVolumeParams{
 				volumeParams, volumeAttachmentParams,
@@ -2927,24 +3061,12 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
-	if len(name) == 0 {
-		return nil, errors.New("no action name given")
-	}
-
-	// If the action is predefined inside juju, get spec from map
-	spec, ok := actions.PredefinedActionsSpec[name]
-	if !ok {
-		specs, err := u.ActionSpecs()
-		if err != nil {
-			return nil, err
-		}
-		spec, ok = specs[name]
-		if !ok {
-			return nil, errors.Errorf("action %q not defined on unit %q", name, u.Name())
-		}
+	spec, err := u.actionSpec(name)
+	if err != nil {
+		return nil, err
 	}
 	// Reject bad payloads before attempting to insert defaults.
-	err := spec.ValidateParams(payload)
+	err = spec.ValidateParams(payload)
 	if err != nil {
 		return nil, err
 	}
@@ -2960,6 +3082,28 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	return m.EnqueueAction(u.Tag(), name, payloadWithDefaults)
 }
 
+// actionSpec returns the spec of the named action, which is either
+// predefined by juju or defined by the unit's charm.
+func (u *Unit) actionSpec(name string) (charm.ActionSpec, error) {
+	if len(name) == 0 {
+		return charm.ActionSpec{}, errors.New("no action name given")
+	}
+
+	// If the action is predefined inside juju, get spec from map
+	spec, ok := actions.PredefinedActionsSpec[name]
+	if !ok {
+		specs, err := u.ActionSpecs()
+		if err != nil {
+			return charm.ActionSpec{}, err
+		}
+		spec, ok = specs[name]
+		if !ok {
+			return charm.ActionSpec{}, errors.Errorf("action %q not defined on unit %q", name, u.Name())
+		}
+	}
+	return spec, nil
+}
+
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
 func (u *Unit) ActionSpecs() (ActionSpecsByName, error) {
 	none := ActionSpecsByName{}
@@ -0,0 +1,110 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	mgoutils "github.com/juju/juju/mongo/utils"
+)
+
+// MaxUnitStateSize is the maximum combined size, in bytes, of the
+// keys and values a unit's charm may store in the unit's state.
+const MaxUnitStateSize = 64 * 1024
+
+// unitStateDoc records the private key/value state stored by a
+// unit's charm with the state-set hook tool. Keys are escaped so
+// they may be stored as mongo document keys.
+type unitStateDoc struct {
+	DocID     string            `bson:"_id"`
+	ModelUUID string            `bson:"model-uuid"`
+	State     map[string]string `bson:"state"`
+}
+
+// State returns the key/value state stored by the unit's charm.
+func (u *Unit) State() (map[string]string, error) {
+	coll, closer := u.st.db().GetCollection(unitStatesC)
+	defer closer()
+
+	var doc unitStateDoc
+	err := coll.FindId(u.doc.DocID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get state for unit %q", u)
+	}
+	state := make(map[string]string, len(doc.State))
+	for k, v := range doc.State {
+		state[mgoutils.UnescapeKey(k)] = v
+	}
+	return state, nil
+}
+
+// SetState replaces the key/value state stored for the unit. The
+// combined size of the keys and values may not exceed MaxUnitStateSize.
+func (u *Unit) SetState(state map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set state for unit %q", u)
+	return errors.Trace(u.commitHookChanges(HookChanges{State: &state}))
+}
+
+// setStateOps returns the operations needed to replace the key/value
+// state stored for the unit, or nil if the unit has no stored state
+// and the new state is empty.
+func (u *Unit) setStateOps(state map[string]string) ([]txn.Op, error) {
+	var size int
+	escaped := make(map[string]string, len(state))
+	for k, v := range state {
+		if k == "" {
+			return nil, errors.NotValidf("empty key")
+		}
+		size += len(k) + len(v)
+		escaped[mgoutils.EscapeKey(k)] = v
+	}
+	if size > MaxUnitStateSize {
+		return nil, errors.Errorf("state size of %d bytes exceeds maximum of %d bytes", size, MaxUnitStateSize)
+	}
+
+	coll, closer := u.st.db().GetCollection(unitStatesC)
+	defer closer()
+	n, err := coll.FindId(u.doc.DocID).Count()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch {
+	case n == 0 && len(escaped) == 0:
+		return nil, nil
+	case n == 0:
+		return []txn.Op{{
+			C:      unitStatesC,
+			Id:     u.doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &unitStateDoc{
+				DocID:     u.doc.DocID,
+				ModelUUID: u.st.ModelUUID(),
+				State:     escaped,
+			},
+		}}, nil
+	case len(escaped) == 0:
+		return []txn.Op{removeUnitStateOp(u.st, u.doc.DocID)}, nil
+	}
+	return []txn.Op{{
+		C:      unitStatesC,
+		Id:     u.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"state", escaped}}}},
+	}}, nil
+}
+
+// removeUnitStateOp returns the operation needed to remove the state
+// document for the unit with the given document ID, if it exists.
+func removeUnitStateOp(mb modelBackend, unitDocID string) txn.Op {
+	return txn.Op{
+		C:      unitStatesC,
+		Id:     mb.docID(unitDocID),
+		Remove: true,
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+func (s *UnitSuite) TestStateEmpty(c *gc.C) {
+	st, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, gc.HasLen, 0)
+}
+
+func (s *UnitSuite) TestSetState(c *gc.C) {
+	err := s.unit.SetState(map[string]string{
+		"foo":         "bar",
+		"dotted.key":  "value",
+		"$dollar-key": "value",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	st, err := unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, jc.DeepEquals, map[string]string{
+		"foo":         "bar",
+		"dotted.key":  "value",
+		"$dollar-key": "value",
+	})
+
+	// Setting the state replaces it entirely.
+	err = s.unit.SetState(map[string]string{"baz": "qux"})
+	c.Assert(err, jc.ErrorIsNil)
+	st, err = unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, jc.DeepEquals, map[string]string{"baz": "qux"})
+
+	// Setting empty state removes it.
+	err = s.unit.SetState(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	st, err = unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, gc.HasLen, 0)
+
+	// Which is idempotent.
+	err = s.unit.SetState(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UnitSuite) TestSetStateTooLarge(c *gc.C) {
+	err := s.unit.SetState(map[string]string{
+		"key": strings.Repeat("x", state.MaxUnitStateSize),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set state for unit "wordpress/0": state size of 65539 bytes exceeds maximum of 65536 bytes`)
+}
+
+func (s *UnitSuite) TestSetStateEmptyKey(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"": "value"})
+	c.Assert(err, gc.ErrorMatches, `cannot set state for unit "wordpress/0": empty key not valid`)
+}
+
+func (s *UnitSuite) TestSetStateDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetState(map[string]string{"foo": "bar"})
+	c.Assert(err, gc.ErrorMatches, `cannot set state for unit "wordpress/0": unit is dead`)
+}
+
+func (s *UnitSuite) TestRemoveUnitRemovesState(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err := state.UnitStateCount(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
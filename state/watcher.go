@@ -5,6 +5,7 @@ package state
 
 import (
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -1649,9 +1650,13 @@ func (st *State) WatchControllerInfo() NotifyWatcher {
 	return newEntityWatcher(st, controllersC, modelGlobalKey)
 }
 
-// WatchControllerConfig returns a NotifyWatcher for controller settings.
+// WatchControllerConfig returns a NotifyWatcher for controller settings,
+// including those kept secret.
 func (st *State) WatchControllerConfig() NotifyWatcher {
-	return newEntityWatcher(st, controllersC, controllerSettingsGlobalKey)
+	return newDocWatcher(st, []docKey{
+		{controllersC, controllerSettingsGlobalKey},
+		{controllersC, controllerSecretsGlobalKey},
+	})
 }
 
 // Watch returns a watcher for observing changes to a machine.
@@ -1741,6 +1746,18 @@ func (sb *storageBackend) WatchStorageAttachment(s names.StorageTag, u names.Uni
 	return newEntityWatcher(sb.mb, storageAttachmentsC, sb.mb.docID(id))
 }
 
+// WatchVolume returns a watcher for observing changes to a volume,
+// such as its size changing after it has been resized.
+func (sb *storageBackend) WatchVolume(v names.VolumeTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, volumesC, sb.mb.docID(v.Id()))
+}
+
+// WatchFilesystem returns a watcher for observing changes to a
+// filesystem, such as its size changing after it has been resized.
+func (sb *storageBackend) WatchFilesystem(f names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, filesystemsC, sb.mb.docID(f.Id()))
+}
+
 // WatchVolumeAttachment returns a watcher for observing changes
 // to a volume attachment.
 func (sb *storageBackend) WatchVolumeAttachment(host names.Tag, v names.VolumeTag) NotifyWatcher {
@@ -2942,6 +2959,123 @@ func (m *Model) WatchActionResultsFilteredBy(receivers ...ActionReceiver) String
 	return newActionStatusWatcher(m.st, receivers, []ActionStatus{ActionCompleted, ActionCancelled, ActionFailed}...)
 }
 
+// WatchActionLogs starts and returns a StringsWatcher that notifies
+// when progress messages are logged by the action with the given id.
+// Each string is a JSON encoded ActionMessage; the first event holds
+// all the messages logged so far.
+func (m *Model) WatchActionLogs(actionId string) StringsWatcher {
+	return newActionLogsWatcher(m.st, actionId)
+}
+
+// actionLogsWatcher reports new progress messages logged by an action.
+type actionLogsWatcher struct {
+	commonWatcher
+	out      chan []string
+	actionId string
+}
+
+var _ StringsWatcher = (*actionLogsWatcher)(nil)
+
+func newActionLogsWatcher(backend modelBackend, actionId string) StringsWatcher {
+	w := &actionLogsWatcher{
+		commonWatcher: newCommonWatcher(backend),
+		out:           make(chan []string),
+		actionId:      actionId,
+	}
+	w.tomb.Go(func() error {
+		defer close(w.out)
+		return w.loop()
+	})
+	return w
+}
+
+// Changes returns the channel that sends the JSON encoded progress
+// messages logged by the action.
+func (w *actionLogsWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+// messages returns the JSON encoded messages logged by the action
+// after the first seen messages, and the number of messages logged
+// so far. Only the most recent messages are kept, so messages that
+// were discarded before they could be reported are skipped.
+func (w *actionLogsWatcher) messages(seen int) ([]string, int, error) {
+	coll, closer := w.db.GetCollection(actionsC)
+	defer closer()
+
+	var doc struct {
+		Logs         []ActionMessage `bson:"messages"`
+		MessageCount int             `bson:"message-count"`
+	}
+	err := coll.FindId(w.actionId).Select(bson.D{{"messages", 1}, {"message-count", 1}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, seen, errors.NotFoundf("action %q", w.actionId)
+	} else if err != nil {
+		return nil, seen, errors.Trace(err)
+	}
+	count := doc.MessageCount
+	if count < len(doc.Logs) {
+		// The action was started before messages were counted.
+		count = len(doc.Logs)
+	}
+	if seen >= count {
+		return nil, seen, nil
+	}
+	skip := seen - (count - len(doc.Logs))
+	if skip < 0 {
+		skip = 0
+	}
+	result := make([]string, 0, len(doc.Logs)-skip)
+	for _, m := range doc.Logs[skip:] {
+		data, err := json.Marshal(m)
+		if err != nil {
+			return nil, seen, errors.Trace(err)
+		}
+		result = append(result, string(data))
+	}
+	return result, count, nil
+}
+
+func (w *actionLogsWatcher) loop() error {
+	in := make(chan watcher.Change)
+	docID := w.backend.docID(w.actionId)
+	filter := func(id interface{}) bool {
+		return id == docID
+	}
+	w.watcher.WatchCollectionWithFilter(actionsC, in, filter)
+	defer w.watcher.UnwatchCollection(actionsC, in)
+
+	changes, seen, err := w.messages(0)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case _, ok := <-in:
+			if !ok {
+				return tomb.ErrDying
+			}
+			logs, count, err := w.messages(seen)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			seen = count
+			if len(logs) > 0 {
+				changes = append(changes, logs...)
+				out = w.out
+			}
+		case out <- changes:
+			changes = nil
+			out = nil
+		}
+	}
+}
+
 // openedPortsWatcher notifies of changes in the openedPorts
 // collection
 type openedPortsWatcher struct {
@@ -216,6 +216,47 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// VolumeSnapshotter provides an interface for taking point-in-time
+// snapshots of volumes, and for restoring those snapshots to new
+// volumes. A VolumeSource may optionally implement VolumeSnapshotter.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots takes snapshots of the volumes with the
+	// provider volume IDs specified in the parameters.
+	CreateVolumeSnapshots(ctx context.ProviderCallContext, params []VolumeSnapshotParams) ([]CreateVolumeSnapshotsResult, error)
+
+	// ListVolumeSnapshots lists the snapshots of the volumes with the
+	// specified provider volume IDs.
+	ListVolumeSnapshots(ctx context.ProviderCallContext, volIds []string) ([]ListVolumeSnapshotsResult, error)
+
+	// DeleteVolumeSnapshots deletes the snapshots with the specified
+	// provider snapshot IDs.
+	DeleteVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error)
+
+	// RestoreVolumeSnapshots creates new volumes with the specified
+	// parameters, populated from the snapshot identified by each
+	// VolumeParams' SnapshotId field.
+	RestoreVolumeSnapshots(ctx context.ProviderCallContext, params []VolumeParams) ([]CreateVolumesResult, error)
+}
+
+// VolumeResizer provides an interface for growing volumes in place,
+// while they remain attached. A VolumeSource may optionally implement
+// VolumeResizer.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the provider volume IDs
+	// specified in the parameters. Shrinking volumes is not supported.
+	ResizeVolumes(ctx context.ProviderCallContext, params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
+// FilesystemResizer provides an interface for growing filesystems in
+// place, while they remain attached. A FilesystemSource may optionally
+// implement FilesystemResizer.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the filesystems with the provider
+	// filesystem IDs specified in the parameters. Shrinking filesystems
+	// is not supported.
+	ResizeFilesystems(ctx context.ProviderCallContext, params []FilesystemResizeParams) ([]ResizeFilesystemsResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -250,6 +291,40 @@ type VolumeParams struct {
 	// once the instance is created there are still unprovisioned volumes,
 	// the dynamic storage provisioner will take care of creating them.
 	Attachment *VolumeAttachmentParams
+
+	// SnapshotId, if non-empty, is the provider ID of the snapshot
+	// from which the volume should be restored. Volumes with a
+	// snapshot ID are created by VolumeSnapshotter.RestoreVolumeSnapshots
+	// rather than VolumeSource.CreateVolumes.
+	SnapshotId string
+}
+
+// VolumeSnapshotParams is a set of parameters for taking a snapshot
+// of a volume.
+type VolumeSnapshotParams struct {
+	// Volume is the tag assigned by Juju to the volume that should
+	// be snapshotted.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume that
+	// should be snapshotted.
+	VolumeId string
+
+	// ResourceTags is a set of tags to set on the created snapshot, if
+	// the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
+// VolumeResizeParams is a set of parameters for resizing a volume.
+type VolumeResizeParams struct {
+	// Tag is the tag assigned by Juju to the volume.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Size is the new minimum size of the volume in MiB.
+	Size uint64
 }
 
 // VolumeAttachmentParams is a set of parameters for volume attachment or
@@ -321,6 +396,18 @@ type FilesystemParams struct {
 	Attachment *FilesystemAttachmentParams
 }
 
+// FilesystemResizeParams is a set of parameters for resizing a filesystem.
+type FilesystemResizeParams struct {
+	// Tag is the tag assigned by Juju to the filesystem.
+	Tag names.FilesystemTag
+
+	// FilesystemId is the unique provider-supplied ID for the filesystem.
+	FilesystemId string
+
+	// Size is the new minimum size of the filesystem in MiB.
+	Size uint64
+}
+
 // FilesystemAttachmentParams is a set of parameters for filesystem attachment
 // or detachment.
 type FilesystemAttachmentParams struct {
@@ -355,6 +442,31 @@ type DescribeVolumesResult struct {
 	Error      error
 }
 
+// CreateVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateVolumeSnapshots call for one volume.
+// Snapshot should only be used if Error is nil.
+type CreateVolumeSnapshotsResult struct {
+	Snapshot *VolumeSnapshot
+	Error    error
+}
+
+// ListVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.ListVolumeSnapshots call for one volume.
+// Snapshots should only be used if Error is nil.
+type ListVolumeSnapshotsResult struct {
+	Snapshots []VolumeSnapshot
+	Error     error
+}
+
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. Size should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	// Size is the size of the volume in MiB after resizing, which
+	// may be larger than requested.
+	Size  uint64
+	Error error
+}
+
 // AttachVolumesResult contains the result of a VolumeSource.AttachVolumes call
 // for one volume. VolumeAttachment should only be used if Error is nil.
 type AttachVolumesResult struct {
@@ -375,3 +487,13 @@ type AttachFilesystemsResult struct {
 	FilesystemAttachment *FilesystemAttachment
 	Error                error
 }
+
+// ResizeFilesystemsResult contains the result of a
+// FilesystemResizer.ResizeFilesystems call for one filesystem.
+// Size should only be used if Error is nil.
+type ResizeFilesystemsResult struct {
+	// Size is the size of the filesystem in MiB after resizing,
+	// which may be larger than requested.
+	Size  uint64
+	Error error
+}
@@ -23,8 +23,11 @@ type FilesystemSource struct {
 	ValidateFilesystemParamsFunc func(storage.FilesystemParams) error
 	AttachFilesystemsFunc        func(context.ProviderCallContext, []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error)
 	DetachFilesystemsFunc        func(context.ProviderCallContext, []storage.FilesystemAttachmentParams) ([]error, error)
+	ResizeFilesystemsFunc        func(context.ProviderCallContext, []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error)
 }
 
+var _ storage.FilesystemResizer = (*FilesystemSource)(nil)
+
 // CreateFilesystems is defined on storage.FilesystemSource.
 func (s *FilesystemSource) CreateFilesystems(ctx context.ProviderCallContext, params []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
 	s.MethodCall(s, "CreateFilesystems", ctx, params)
@@ -78,3 +81,12 @@ func (s *FilesystemSource) DetachFilesystems(ctx context.ProviderCallContext, pa
 	}
 	return nil, errors.NotImplementedf("DetachFilesystems")
 }
+
+// ResizeFilesystems is defined on storage.FilesystemResizer.
+func (s *FilesystemSource) ResizeFilesystems(ctx context.ProviderCallContext, params []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	s.MethodCall(s, "ResizeFilesystems", ctx, params)
+	if s.ResizeFilesystemsFunc != nil {
+		return s.ResizeFilesystemsFunc(ctx, params)
+	}
+	return nil, errors.NotImplementedf("ResizeFilesystems")
+}
@@ -25,8 +25,18 @@ type VolumeSource struct {
 	ValidateVolumeParamsFunc func(storage.VolumeParams) error
 	AttachVolumesFunc        func(context.ProviderCallContext, []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
 	DetachVolumesFunc        func(context.ProviderCallContext, []storage.VolumeAttachmentParams) ([]error, error)
+
+	CreateVolumeSnapshotsFunc  func(context.ProviderCallContext, []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
+	ListVolumeSnapshotsFunc    func(context.ProviderCallContext, []string) ([]storage.ListVolumeSnapshotsResult, error)
+	DeleteVolumeSnapshotsFunc  func(context.ProviderCallContext, []string) ([]error, error)
+	RestoreVolumeSnapshotsFunc func(context.ProviderCallContext, []storage.VolumeParams) ([]storage.CreateVolumesResult, error)
+
+	ResizeVolumesFunc func(context.ProviderCallContext, []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
 }
 
+var _ storage.VolumeSnapshotter = (*VolumeSource)(nil)
+var _ storage.VolumeResizer = (*VolumeSource)(nil)
+
 // CreateVolumes is defined on storage.VolumeSource.
 func (s *VolumeSource) CreateVolumes(ctx context.ProviderCallContext, params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
 	s.MethodCall(s, "CreateVolumes", ctx, params)
@@ -98,3 +108,48 @@ func (s *VolumeSource) DetachVolumes(ctx context.ProviderCallContext, params []s
 	}
 	return nil, errors.NotImplementedf("DetachVolumes")
 }
+
+// CreateVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) CreateVolumeSnapshots(ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	s.MethodCall(s, "CreateVolumeSnapshots", ctx, params)
+	if s.CreateVolumeSnapshotsFunc != nil {
+		return s.CreateVolumeSnapshotsFunc(ctx, params)
+	}
+	return nil, errors.NotImplementedf("CreateVolumeSnapshots")
+}
+
+// ListVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) ListVolumeSnapshots(ctx context.ProviderCallContext, volIds []string) ([]storage.ListVolumeSnapshotsResult, error) {
+	s.MethodCall(s, "ListVolumeSnapshots", ctx, volIds)
+	if s.ListVolumeSnapshotsFunc != nil {
+		return s.ListVolumeSnapshotsFunc(ctx, volIds)
+	}
+	return make([]storage.ListVolumeSnapshotsResult, len(volIds)), nil
+}
+
+// DeleteVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) DeleteVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	s.MethodCall(s, "DeleteVolumeSnapshots", ctx, snapshotIds)
+	if s.DeleteVolumeSnapshotsFunc != nil {
+		return s.DeleteVolumeSnapshotsFunc(ctx, snapshotIds)
+	}
+	return nil, errors.NotImplementedf("DeleteVolumeSnapshots")
+}
+
+// RestoreVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) RestoreVolumeSnapshots(ctx context.ProviderCallContext, params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	s.MethodCall(s, "RestoreVolumeSnapshots", ctx, params)
+	if s.RestoreVolumeSnapshotsFunc != nil {
+		return s.RestoreVolumeSnapshotsFunc(ctx, params)
+	}
+	return nil, errors.NotImplementedf("RestoreVolumeSnapshots")
+}
+
+// ResizeVolumes is defined on storage.VolumeResizer.
+func (s *VolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	s.MethodCall(s, "ResizeVolumes", ctx, params)
+	if s.ResizeVolumesFunc != nil {
+		return s.ResizeVolumesFunc(ctx, params)
+	}
+	return nil, errors.NotImplementedf("ResizeVolumes")
+}
@@ -35,6 +35,12 @@ func LoopProvider(
 	return &loopProvider{run}
 }
 
+func LVMProvider(
+	run func(string, ...string) (string, error),
+) storage.Provider {
+	return &lvmProvider{run}
+}
+
 func NewMockManagedFilesystemSource(
 	etcDir string,
 	run func(string, ...string) (string, error),
@@ -0,0 +1,383 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// LVMProviderType is the storage provider type for LVM
+	// logical volumes created on the machine.
+	LVMProviderType = storage.ProviderType("lvm")
+
+	// LVMVolumeGroup is the name of the pool attribute holding the
+	// volume group in which logical volumes are created.
+	LVMVolumeGroup = "volume-group"
+
+	// LVMThinPool is the name of the pool attribute holding the thin
+	// pool, in the volume group, from which thin-provisioned logical
+	// volumes are allocated. If unset, volumes are fully allocated.
+	LVMThinPool = "thin-pool"
+
+	// LVMStripes is the name of the pool attribute holding the number
+	// of physical volumes to stripe each logical volume across.
+	LVMStripes = "stripes"
+
+	// LVMStripeSize is the name of the pool attribute holding the
+	// stripe size, in KiB.
+	LVMStripeSize = "stripe-size"
+
+	// lvmVolumeTag is the LVM tag added to each logical volume
+	// created by Juju, so they can be listed.
+	lvmVolumeTag = "juju"
+)
+
+var lvmConfigFields = schema.Fields{
+	LVMVolumeGroup: schema.String(),
+	LVMThinPool:    schema.String(),
+	LVMStripes:     schema.ForceInt(),
+	LVMStripeSize:  schema.ForceInt(),
+}
+
+var lvmConfigChecker = schema.FieldMap(
+	lvmConfigFields,
+	schema.Defaults{
+		LVMVolumeGroup: "",
+		LVMThinPool:    "",
+		LVMStripes:     schema.Omit,
+		LVMStripeSize:  schema.Omit,
+	},
+)
+
+type lvmConfig struct {
+	volumeGroup string
+	thinPool    string
+	stripes     int
+	stripeSize  int
+}
+
+func newLVMConfig(attrs map[string]interface{}) (*lvmConfig, error) {
+	out, err := lvmConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating LVM storage config")
+	}
+	coerced := out.(map[string]interface{})
+	stripes, _ := coerced[LVMStripes].(int)
+	stripeSize, _ := coerced[LVMStripeSize].(int)
+	cfg := &lvmConfig{
+		volumeGroup: coerced[LVMVolumeGroup].(string),
+		thinPool:    coerced[LVMThinPool].(string),
+		stripes:     stripes,
+		stripeSize:  stripeSize,
+	}
+	if cfg.volumeGroup == "" {
+		return nil, errors.Errorf("%s not specified", LVMVolumeGroup)
+	}
+	if _, ok := coerced[LVMStripes]; ok && cfg.stripes < 1 {
+		return nil, errors.NotValidf("%s %d", LVMStripes, cfg.stripes)
+	}
+	if _, ok := coerced[LVMStripeSize]; ok {
+		if cfg.stripeSize < 1 {
+			return nil, errors.NotValidf("%s %d", LVMStripeSize, cfg.stripeSize)
+		}
+		if cfg.stripes < 2 {
+			return nil, errors.Errorf("%s specified, but volumes are not striped", LVMStripeSize)
+		}
+	}
+	if cfg.thinPool != "" && cfg.stripes > 0 {
+		// Thin volumes are striped according to their thin pool.
+		return nil, errors.Errorf("%s cannot be specified with %s", LVMStripes, LVMThinPool)
+	}
+	return cfg, nil
+}
+
+// lvmProvider creates volume sources which use LVM logical
+// volumes carved out of a volume group on the machine.
+type lvmProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var _ storage.Provider = (*lvmProvider)(nil)
+
+// ValidateConfig is defined on the Provider interface.
+func (*lvmProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := newLVMConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *lvmProvider) VolumeSource(sourceConfig *storage.Config) (storage.VolumeSource, error) {
+	cfg, err := newLVMConfig(sourceConfig.Attrs())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &lvmVolumeSource{p.run, cfg}, nil
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *lvmProvider) FilesystemSource(providerConfig *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is defined on the Provider interface.
+func (*lvmProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is defined on the Provider interface.
+func (*lvmProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*lvmProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the Provider interface.
+func (*lvmProvider) Releasable() bool {
+	return false
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*lvmProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// lvmVolumeSource creates and destroys logical volumes in the
+// configured volume group. Volume IDs have the form "vg/lv".
+type lvmVolumeSource struct {
+	run runCommandFunc
+	cfg *lvmConfig
+}
+
+var _ storage.VolumeSource = (*lvmVolumeSource)(nil)
+
+// CreateVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := s.createVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating volume")
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) createVolume(params storage.VolumeParams) (*storage.Volume, error) {
+	lvName := params.Tag.String()
+	size := fmt.Sprintf("%dm", params.Size)
+	args := []string{"--yes", "--name", lvName, "--addtag", lvmVolumeTag}
+	if s.cfg.thinPool != "" {
+		args = append(args,
+			"--virtualsize", size,
+			"--thinpool", path.Join(s.cfg.volumeGroup, s.cfg.thinPool),
+		)
+	} else {
+		args = append(args, "--size", size)
+		if s.cfg.stripes > 0 {
+			args = append(args, "--stripes", strconv.Itoa(s.cfg.stripes))
+		}
+		if s.cfg.stripeSize > 0 {
+			args = append(args, "--stripesize", fmt.Sprintf("%dk", s.cfg.stripeSize))
+		}
+		args = append(args, s.cfg.volumeGroup)
+	}
+	if _, err := s.run("lvcreate", args...); err != nil {
+		return nil, errors.Annotatef(err, "creating logical volume %q", lvName)
+	}
+	return &storage.Volume{
+		params.Tag,
+		storage.VolumeInfo{
+			VolumeId: path.Join(s.cfg.volumeGroup, lvName),
+			Size:     params.Size,
+		},
+	}, nil
+}
+
+// ListVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ListVolumes(ctx context.ProviderCallContext) ([]string, error) {
+	stdout, err := s.run(
+		"lvs", "--noheadings", "--separator", "/",
+		"--options", "vg_name,lv_name",
+		"--select", "lv_tags="+lvmVolumeTag,
+		s.cfg.volumeGroup,
+	)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing logical volumes")
+	}
+	var volumeIds []string
+	for _, line := range strings.Split(stdout, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		volumeIds = append(volumeIds, line)
+	}
+	return volumeIds, nil
+}
+
+// DescribeVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DescribeVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]storage.DescribeVolumesResult, error) {
+	results := make([]storage.DescribeVolumesResult, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		info, err := s.describeVolume(volumeId)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "describing %q", volumeId)
+			continue
+		}
+		results[i].VolumeInfo = info
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) describeVolume(volumeId string) (*storage.VolumeInfo, error) {
+	if _, _, err := parseLVMVolumeId(volumeId); err != nil {
+		return nil, errors.Trace(err)
+	}
+	stdout, err := s.run(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m",
+		"--options", "lv_size", volumeId,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	size, err := strconv.ParseFloat(strings.TrimSpace(stdout), 64)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing size of logical volume %q", volumeId)
+	}
+	return &storage.VolumeInfo{
+		VolumeId: volumeId,
+		Size:     uint64(size),
+	}, nil
+}
+
+// DestroyVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DestroyVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]error, error) {
+	results := make([]error, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		if err := s.destroyVolume(volumeId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", volumeId)
+		}
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) destroyVolume(volumeId string) error {
+	if _, _, err := parseLVMVolumeId(volumeId); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := s.run("lvremove", "--force", volumeId)
+	if err != nil && !isLogicalVolumeNotFound(err) {
+		return errors.Annotate(err, "removing logical volume")
+	}
+	return nil
+}
+
+// ReleaseVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]error, error) {
+	return make([]error, len(volumeIds)), nil
+}
+
+// ValidateVolumeParams is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	// ValidateVolumeParams may be called on a machine other than the
+	// machine where the logical volume will be created, so we cannot
+	// check the free space in the volume group until CreateVolumes.
+	return nil
+}
+
+// AttachVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) AttachVolumes(ctx context.ProviderCallContext, args []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	vg, lv, err := parseLVMVolumeId(arg.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The logical volume is local to the machine, so attaching
+	// is just a matter of activating it with the right access.
+	permission := "rw"
+	if arg.ReadOnly {
+		permission = "r"
+	}
+	if _, err := s.run("lvchange", "--activate", "y", "--permission", permission, arg.VolumeId); err != nil {
+		return nil, errors.Annotate(err, "activating logical volume")
+	}
+	return &storage.VolumeAttachment{
+		arg.Volume,
+		arg.Machine,
+		storage.VolumeAttachmentInfo{
+			DeviceLink: path.Join("/dev", vg, lv),
+			ReadOnly:   arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DetachVolumes(ctx context.ProviderCallContext, args []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := s.detachVolume(arg.VolumeId); err != nil {
+			results[i] = errors.Annotatef(err, "detaching volume %s", arg.Volume.Id())
+		}
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) detachVolume(volumeId string) error {
+	if _, _, err := parseLVMVolumeId(volumeId); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := s.run("lvchange", "--activate", "n", volumeId)
+	if err != nil && !isLogicalVolumeNotFound(err) {
+		return errors.Annotate(err, "deactivating logical volume")
+	}
+	return nil
+}
+
+// parseLVMVolumeId splits a volume ID into its volume
+// group and logical volume names.
+func parseLVMVolumeId(volumeId string) (vg, lv string, _ error) {
+	parts := strings.Split(volumeId, "/")
+	if len(parts) != 2 || parts[0] == "" {
+		return "", "", errors.Errorf("invalid LVM volume ID %q", volumeId)
+	}
+	if _, err := names.ParseVolumeTag(parts[1]); err != nil {
+		return "", "", errors.Errorf("invalid LVM volume ID %q", volumeId)
+	}
+	return parts[0], parts[1], nil
+}
+
+// isLogicalVolumeNotFound reports whether err is the result of
+// running an LVM command against a non-existent logical volume.
+func isLogicalVolumeNotFound(err error) bool {
+	return strings.Contains(err.Error(), "Failed to find logical volume")
+}
@@ -0,0 +1,293 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&lvmSuite{})
+
+type lvmSuite struct {
+	testing.BaseSuite
+	commands *mockRunCommand
+
+	callCtx context.ProviderCallContext
+}
+
+func (s *lvmSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.callCtx = context.NewCloudCallContext()
+}
+
+func (s *lvmSuite) TearDownTest(c *gc.C) {
+	s.commands.assertDrained()
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *lvmSuite) lvmProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.LVMProvider(s.commands.run)
+}
+
+func (s *lvmSuite) lvmVolumeSource(c *gc.C, attrs map[string]interface{}) storage.VolumeSource {
+	p := s.lvmProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LVMProviderType, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	source, err := p.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return source
+}
+
+func (s *lvmSuite) TestValidateConfig(c *gc.C) {
+	p := s.lvmProvider(c)
+	for i, t := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{},
+		err:   "volume-group not specified",
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg0"},
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg0", "thin-pool": "pool0"},
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg0", "stripes": "2", "stripe-size": "64"},
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg0", "stripes": "0"},
+		err:   "stripes 0 not valid",
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg0", "stripe-size": "64"},
+		err:   "stripe-size specified, but volumes are not striped",
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg0", "thin-pool": "pool0", "stripes": "2"},
+		err:   "stripes cannot be specified with thin-pool",
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg0", "stripes": "many"},
+		err:   `validating LVM storage config: stripes: .*`,
+	}} {
+		c.Logf("test %d", i)
+		cfg, err := storage.NewConfig("name", provider.LVMProviderType, t.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
+func (s *lvmSuite) TestSupports(c *gc.C) {
+	p := s.lvmProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+}
+
+func (s *lvmSuite) TestScope(c *gc.C) {
+	p := s.lvmProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *lvmSuite) TestVolumeSourceNoVolumeGroup(c *gc.C) {
+	p := s.lvmProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(cfg)
+	c.Assert(err, gc.ErrorMatches, "volume-group not specified")
+}
+
+func (s *lvmSuite) TestCreateVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	s.commands.expect("lvcreate", "--yes", "--name", "volume-0", "--addtag", "juju", "--size", "1024m", "vg0")
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("0"),
+		storage.VolumeInfo{
+			VolumeId: "vg0/volume-0",
+			Size:     1024,
+		},
+	})
+}
+
+func (s *lvmSuite) TestCreateVolumesStriped(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{
+		"volume-group": "vg0",
+		"stripes":      3,
+		"stripe-size":  64,
+	})
+	s.commands.expect(
+		"lvcreate", "--yes", "--name", "volume-0-1", "--addtag", "juju",
+		"--size", "1024m", "--stripes", "3", "--stripesize", "64k", "vg0",
+	)
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0/1"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.VolumeId, gc.Equals, "vg0/volume-0-1")
+}
+
+func (s *lvmSuite) TestCreateVolumesThin(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{
+		"volume-group": "vg0",
+		"thin-pool":    "pool0",
+	})
+	s.commands.expect(
+		"lvcreate", "--yes", "--name", "volume-0", "--addtag", "juju",
+		"--virtualsize", "1024m", "--thinpool", "vg0/pool0",
+	)
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.VolumeId, gc.Equals, "vg0/volume-0")
+}
+
+func (s *lvmSuite) TestCreateVolumesError(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	cmd := s.commands.expect("lvcreate", "--yes", "--name", "volume-0", "--addtag", "juju", "--size", "1024m", "vg0")
+	cmd.respond("", errors.New(`Volume group "vg0" has insufficient free space`))
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating volume: creating logical volume "volume-0": Volume group "vg0" has insufficient free space`)
+	c.Assert(results[0].Volume, gc.IsNil)
+}
+
+func (s *lvmSuite) TestListVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	cmd := s.commands.expect(
+		"lvs", "--noheadings", "--separator", "/",
+		"--options", "vg_name,lv_name", "--select", "lv_tags=juju", "vg0",
+	)
+	cmd.respond("  vg0/volume-0\n  vg0/volume-1-2\n", nil)
+
+	volumeIds, err := source.ListVolumes(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeIds, jc.DeepEquals, []string{"vg0/volume-0", "vg0/volume-1-2"})
+}
+
+func (s *lvmSuite) TestDescribeVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	cmd := s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m",
+		"--options", "lv_size", "vg0/volume-0",
+	)
+	cmd.respond("  1024.00\n", nil)
+
+	results, err := source.DescribeVolumes(s.callCtx, []string{"vg0/volume-0", "invalid"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId: "vg0/volume-0",
+		Size:     1024,
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `describing "invalid": invalid LVM volume ID "invalid"`)
+}
+
+func (s *lvmSuite) TestDestroyVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	s.commands.expect("lvremove", "--force", "vg0/volume-0")
+	cmd := s.commands.expect("lvremove", "--force", "vg0/volume-1")
+	cmd.respond("", errors.New(`Failed to find logical volume "vg0/volume-1"`))
+	cmd = s.commands.expect("lvremove", "--force", "vg0/volume-2")
+	cmd.respond("", errors.New(`Logical volume vg0/volume-2 contains a filesystem in use.`))
+
+	errs, err := source.DestroyVolumes(s.callCtx, []string{
+		"vg0/volume-0", "vg0/volume-1", "vg0/volume-2", "volume-3",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 4)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `destroying "vg0/volume-2": removing logical volume: Logical volume vg0/volume-2 contains a filesystem in use.`)
+	c.Assert(errs[3], gc.ErrorMatches, `destroying "volume-3": invalid LVM volume ID "volume-3"`)
+}
+
+func (s *lvmSuite) TestAttachVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	s.commands.expect("lvchange", "--activate", "y", "--permission", "rw", "vg0/volume-0")
+	s.commands.expect("lvchange", "--activate", "y", "--permission", "r", "vg0/volume-1")
+
+	results, err := source.AttachVolumes(s.callCtx, []storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vg0/volume-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-id",
+		},
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "vg0/volume-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-id",
+			ReadOnly:   true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("0"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/vg0/volume-0",
+			},
+		},
+	}, {
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("1"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/vg0/volume-1",
+				ReadOnly:   true,
+			},
+		},
+	}})
+}
+
+func (s *lvmSuite) TestDetachVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	s.commands.expect("lvchange", "--activate", "n", "vg0/volume-0")
+
+	results, err := source.DetachVolumes(s.callCtx, []storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vg0/volume-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-id",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0], jc.ErrorIsNil)
+}
@@ -3,7 +3,11 @@
 
 package storage
 
-import "gopkg.in/juju/names.v2"
+import (
+	"time"
+
+	"gopkg.in/juju/names.v2"
+)
 
 type DeviceType string
 
@@ -43,6 +47,27 @@ type VolumeInfo struct {
 	Persistent bool
 }
 
+// VolumeSnapshot describes a point-in-time snapshot of a volume.
+type VolumeSnapshot struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// VolumeId is the provider-supplied ID of the volume that the
+	// snapshot was taken of.
+	VolumeId string
+
+	// Size is the size of the snapshotted volume, in MiB. A volume
+	// restored from the snapshot must be at least this size.
+	Size uint64
+
+	// Created is the time at which the snapshot was taken.
+	Created time.Time
+
+	// Status is the provider-specific status of the snapshot,
+	// such as "pending" or "completed".
+	Status string
+}
+
 // VolumeAttachment identifies and describes machine-specific volume
 // attachment information, including how the volume is exposed on the
 // machine.
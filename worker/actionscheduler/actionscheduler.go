@@ -0,0 +1,102 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/watcher"
+)
+
+// period is the longest time to wait before running due action
+// schedules. Schedules are run when they are next due, or when they
+// change, but also periodically so that a failure to enqueue actions
+// is retried.
+const period = time.Minute
+
+var logger = loggo.GetLogger("juju.worker.actionscheduler")
+
+// Facade exposes the controller functionality used by the worker.
+type Facade interface {
+	RunDueActionSchedules() (time.Time, error)
+	WatchActionSchedules() (watcher.NotifyWatcher, error)
+}
+
+// Scheduler is a worker that enqueues scheduled actions when they
+// are due.
+type Scheduler struct {
+	catacomb catacomb.Catacomb
+	facade   Facade
+	watcher  watcher.NotifyWatcher
+	clock    clock.Clock
+}
+
+// NewScheduler returns a worker that enqueues the actions of the
+// model's action schedules as they become due.
+func NewScheduler(facade Facade, clock clock.Clock) (worker.Worker, error) {
+	watcher, err := facade.WatchActionSchedules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &Scheduler{
+		facade:  facade,
+		watcher: watcher,
+		clock:   clock,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &s.catacomb,
+		Work: s.loop,
+		Init: []worker.Worker{watcher},
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s, nil
+}
+
+func (s *Scheduler) loop() error {
+	timer := s.clock.NewTimer(period)
+	defer timer.Stop()
+	for {
+		select {
+		case <-s.catacomb.Dying():
+			return s.catacomb.ErrDying()
+		case _, ok := <-s.watcher.Changes():
+			if !ok {
+				return errors.New("change channel closed")
+			}
+		case <-timer.Chan():
+		}
+		wait := period
+		next, err := s.facade.RunDueActionSchedules()
+		if err != nil {
+			// Failing to enqueue actions isn't fatal; we
+			// retry when the timer next fires.
+			logger.Errorf("cannot run action schedules: %v", err)
+		} else if !next.IsZero() {
+			if d := next.Sub(s.clock.Now()); d < wait {
+				wait = d
+			}
+			if wait < 0 {
+				wait = 0
+			}
+		}
+		timer.Reset(wait)
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (s *Scheduler) Kill() {
+	s.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (s *Scheduler) Wait() error {
+	return s.catacomb.Wait()
+}
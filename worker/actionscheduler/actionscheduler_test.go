@@ -0,0 +1,139 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+)
+
+type SchedulerSuite struct {
+	coretesting.BaseSuite
+	facade *mockFacade
+	clock  *testclock.Clock
+}
+
+var _ = gc.Suite(&SchedulerSuite{})
+
+func (s *SchedulerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2019, 4, 1, 10, 0, 0, 0, time.UTC))
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	s.facade = &mockFacade{
+		calls:   make(chan string, 10),
+		changes: changes,
+	}
+}
+
+func (s *SchedulerSuite) assertReceived(c *gc.C, expect string) {
+	select {
+	case call := <-s.facade.calls:
+		c.Assert(call, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %s", expect)
+	}
+}
+
+func (s *SchedulerSuite) assertEmpty(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Fatalf("unexpected %s", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *SchedulerSuite) TestRunsOnChange(c *gc.C) {
+	w, err := actionscheduler.NewScheduler(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertReceived(c, "WatchActionSchedules")
+	s.assertReceived(c, "RunDueActionSchedules")
+	s.assertEmpty(c)
+
+	s.facade.changes <- struct{}{}
+	s.assertReceived(c, "RunDueActionSchedules")
+	s.assertEmpty(c)
+}
+
+func (s *SchedulerSuite) TestRunsWhenNextDue(c *gc.C) {
+	s.facade.next = s.clock.Now().Add(10 * time.Second)
+	w, err := actionscheduler.NewScheduler(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertReceived(c, "WatchActionSchedules")
+	s.assertReceived(c, "RunDueActionSchedules")
+
+	s.clock.WaitAdvance(9*time.Second, coretesting.LongWait, 1)
+	s.assertEmpty(c)
+	s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	s.assertReceived(c, "RunDueActionSchedules")
+}
+
+func (s *SchedulerSuite) TestRunsPeriodically(c *gc.C) {
+	s.facade.next = s.clock.Now().Add(time.Hour)
+	w, err := actionscheduler.NewScheduler(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertReceived(c, "WatchActionSchedules")
+	s.assertReceived(c, "RunDueActionSchedules")
+
+	s.clock.WaitAdvance(59*time.Second, coretesting.LongWait, 1)
+	s.assertEmpty(c)
+	s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	s.assertReceived(c, "RunDueActionSchedules")
+}
+
+func (s *SchedulerSuite) TestRunErrorNotFatal(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := actionscheduler.NewScheduler(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertReceived(c, "WatchActionSchedules")
+	s.assertReceived(c, "RunDueActionSchedules")
+	workertest.CheckAlive(c, w)
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains, "ERROR juju.worker.actionscheduler cannot run action schedules: boom")
+}
+
+func (s *SchedulerSuite) TestWatchError(c *gc.C) {
+	s.facade.watchErr = errors.New("boom")
+	_, err := actionscheduler.NewScheduler(s.facade, s.clock)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockFacade struct {
+	calls    chan string
+	changes  chan struct{}
+	next     time.Time
+	err      error
+	watchErr error
+}
+
+func (f *mockFacade) RunDueActionSchedules() (time.Time, error) {
+	f.calls <- "RunDueActionSchedules"
+	return f.next, f.err
+}
+
+func (f *mockFacade) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	f.calls <- "WatchActionSchedules"
+	if f.watchErr != nil {
+		return nil, f.watchErr
+	}
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
@@ -5,15 +5,20 @@ package auditconfigupdater
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
 
 	jujuagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/common"
 	workerstate "github.com/juju/juju/worker/state"
 )
 
+var logger = loggo.GetLogger("juju.worker.auditconfigupdater")
+
 // ManifoldConfig holds the information needed to run an
 // auditconfigupdater in a dependency.Engine.
 type ManifoldConfig struct {
@@ -78,7 +83,7 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 	st := statePool.SystemState()
 
 	logFactory := func(cfg auditlog.Config) auditlog.AuditLog {
-		return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+		return newAuditLog(cfg, logDir, st)
 	}
 	auditConfig, err := initialConfig(st)
 	if err != nil {
@@ -126,6 +131,29 @@ func initialConfig(source ConfigSource) (auditlog.Config, error) {
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Backends:       cfg.AuditLogBackends(),
 	}
 	return result, nil
 }
+
+// newAuditLog returns an audit log that writes to each of the
+// backends named in the config.
+func newAuditLog(cfg auditlog.Config, logDir string, st *state.State) auditlog.AuditLog {
+	var logs []auditlog.AuditLog
+	for _, backend := range cfg.Backends.SortedValues() {
+		switch backend {
+		case controller.AuditLogBackendFile:
+			logs = append(logs, auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups))
+		case controller.AuditLogBackendLogForward:
+			logs = append(logs, auditlog.NewForwardingLog(loggo.GetLogger("juju.audit")))
+		case controller.AuditLogBackendDatabase:
+			logs = append(logs, st.AuditLogStore())
+		default:
+			logger.Warningf("ignoring unknown audit log backend %q", backend)
+		}
+	}
+	if len(logs) == 1 {
+		return logs[0]
+	}
+	return auditlog.NewTeeLog(logs...)
+}
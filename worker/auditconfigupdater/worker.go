@@ -6,6 +6,7 @@ package auditconfigupdater
 import (
 	"sync"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/catacomb"
@@ -30,9 +31,10 @@ type AuditLogFactory func(auditlog.Config) auditlog.AuditLog
 // New returns a worker that will keep an up-to-date audit log config.
 func New(source ConfigSource, initial auditlog.Config, logFactory AuditLogFactory) (worker.Worker, error) {
 	u := &updater{
-		source:     source,
-		current:    initial,
-		logFactory: logFactory,
+		source:         source,
+		current:        initial,
+		targetBackends: initial.Backends,
+		logFactory:     logFactory,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -50,6 +52,10 @@ type updater struct {
 	source     ConfigSource
 	current    auditlog.Config
 	logFactory AuditLogFactory
+
+	// targetBackends records the backends the current target was
+	// created for, so we know when it needs to be replaced.
+	targetBackends set.Strings
 }
 
 // Kill is part of the worker.Worker interface.
@@ -95,9 +101,20 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Backends:       cfg.AuditLogBackends(),
 	}
 	if result.Enabled && u.current.Target == nil {
 		result.Target = u.logFactory(result)
+		u.targetBackends = result.Backends
+	} else if result.Enabled && !sameBackends(u.targetBackends, result.Backends) {
+		// The backends have changed, so the old target needs to be
+		// replaced. Connections that started before the change may
+		// still write through the old one after it's closed.
+		result.Target = u.logFactory(result)
+		u.targetBackends = result.Backends
+		if err := u.current.Target.Close(); err != nil {
+			logger.Warningf("closing previous audit log: %v", err)
+		}
 	} else {
 		// Keep the existing target to avoid file handle leaks from
 		// disabling and enabling auditing - we'll still stop logging
@@ -107,6 +124,10 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 	return result, nil
 }
 
+func sameBackends(a, b set.Strings) bool {
+	return a.Difference(b).IsEmpty() && b.Difference(a).IsEmpty()
+}
+
 func (u *updater) update(newConfig auditlog.Config) {
 	u.mu.Lock()
 	defer u.mu.Unlock()
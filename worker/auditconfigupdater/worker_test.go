@@ -80,8 +80,9 @@ func waitForConfig(c *gc.C, w worker.Worker, predicate func(auditlog.Config) boo
 func (s *updaterSuite) TestKeepsLogFileWhenAuditingDisabled(c *gc.C) {
 	configChanged := make(chan struct{}, 1)
 	initial := auditlog.Config{
-		Enabled: true,
-		Target:  &apitesting.FakeAuditLog{},
+		Enabled:  true,
+		Target:   &apitesting.FakeAuditLog{},
+		Backends: set.NewStrings("file"),
 	}
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
@@ -108,8 +109,9 @@ func (s *updaterSuite) TestKeepsLogFileWhenAuditingDisabled(c *gc.C) {
 func (s *updaterSuite) TestKeepsLogFileWhenEnabled(c *gc.C) {
 	configChanged := make(chan struct{}, 1)
 	initial := auditlog.Config{
-		Enabled: false,
-		Target:  &apitesting.FakeAuditLog{},
+		Enabled:  false,
+		Target:   &apitesting.FakeAuditLog{},
+		Backends: set.NewStrings("file"),
 	}
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
@@ -139,6 +141,7 @@ func (s *updaterSuite) TestChangingExcludeMethod(c *gc.C) {
 		Enabled:        true,
 		ExcludeMethods: set.NewStrings("Pink.Floyd"),
 		Target:         &apitesting.FakeAuditLog{},
+		Backends:       set.NewStrings("file"),
 	}
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
@@ -170,6 +173,7 @@ func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 		Enabled:        true,
 		CaptureAPIArgs: false,
 		Target:         &apitesting.FakeAuditLog{},
+		Backends:       set.NewStrings("file"),
 	}
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
@@ -188,6 +192,43 @@ func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 	})
 }
 
+func (s *updaterSuite) TestChangingBackends(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	oldTarget := &apitesting.FakeAuditLog{}
+	initial := auditlog.Config{
+		Enabled:  true,
+		Target:   oldTarget,
+		Backends: set.NewStrings("file"),
+	}
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	newTarget := &apitesting.FakeAuditLog{}
+	var calls []auditlog.Config
+	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+		calls = append(calls, cfg)
+		return newTarget
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-backends"] = []interface{}{"file", "database"}
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.Target == auditlog.AuditLog(newTarget)
+	})
+	c.Assert(newConfig.Backends, gc.DeepEquals, set.NewStrings("file", "database"))
+	c.Assert(calls, gc.HasLen, 1)
+	oldTarget.CheckCallNames(c, "Close")
+}
+
 func makeControllerConfig(auditEnabled bool, captureArgs bool, methods ...interface{}) controller.Config {
 	result := map[string]interface{}{
 		"other-setting":             "something",
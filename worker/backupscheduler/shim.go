@@ -0,0 +1,181 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"io"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// stateShim provides the backups.DB methods that *state.State
+// and *state.Model don't agree on.
+type stateShim struct {
+	*state.State
+	*state.Model
+}
+
+// backendShim adds the controller's secret config, which is needed
+// to access the backup target, to its controller config.
+type backendShim struct {
+	*state.State
+}
+
+// NewBackend returns a Backend that reads the controller config,
+// including its secrets, from the given state.
+func NewBackend(st *state.State) Backend {
+	return backendShim{st}
+}
+
+// ControllerConfig is part of the Backend interface.
+func (b backendShim) ControllerConfig() (controller.Config, error) {
+	cfg, err := b.State.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	secrets, err := b.State.ControllerSecretConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for k, v := range secrets {
+		cfg[k] = v
+	}
+	return cfg, nil
+}
+
+type backupsShim struct {
+	st          *state.State
+	agentConfig agent.Config
+}
+
+// NewBackups returns a Backups that creates backups of the controller
+// running the given agent, in the same way as the backups facade.
+func NewBackups(st *state.State, agentConfig agent.Config) Backups {
+	return &backupsShim{
+		st:          st,
+		agentConfig: agentConfig,
+	}
+}
+
+func (b *backupsShim) open() (backups.Backups, stateShim, io.Closer, error) {
+	model, err := b.st.Model()
+	if err != nil {
+		return nil, stateShim{}, nil, errors.Trace(err)
+	}
+	db := stateShim{b.st, model}
+	stor := backups.NewStorage(db)
+	return backups.NewBackups(stor), db, stor, nil
+}
+
+// Create is part of the Backups interface.
+func (b *backupsShim) Create(notes string) (*backups.Metadata, error) {
+	backupsMethods, db, closer, err := b.open()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer closer.Close()
+
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return nil, errors.Annotatef(err, "HA not ready")
+	}
+
+	mgoInfo, ok := b.agentConfig.MongoInfo()
+	if !ok {
+		return nil, errors.New("no mongo info found in agent config")
+	}
+	v, err := b.st.MongoVersion()
+	if err != nil {
+		return nil, errors.Annotatef(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dbInfo, err := backups.NewDBInfo(mgoInfo, session, mongoVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	machineID := b.agentConfig.Tag().Id()
+	machine, err := b.st.Machine(machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(db, machineID, machine.Series())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Notes = notes
+
+	modelConfig, err := db.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	paths := backups.Paths{
+		BackupDir: modelConfig.BackupDir(),
+		DataDir:   b.agentConfig.DataDir(),
+		LogsDir:   b.agentConfig.LogDir(),
+	}
+	if _, err := backupsMethods.Create(meta, &paths, dbInfo, true, true); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// List is part of the Backups interface.
+func (b *backupsShim) List() ([]*backups.Metadata, error) {
+	backupsMethods, _, closer, err := b.open()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer closer.Close()
+	return backupsMethods.List()
+}
+
+// Get is part of the Backups interface.
+func (b *backupsShim) Get(id string) (*backups.Metadata, io.ReadCloser, error) {
+	backupsMethods, _, closer, err := b.open()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	meta, archive, err := backupsMethods.Get(id)
+	if err != nil {
+		closer.Close()
+		return nil, nil, errors.Trace(err)
+	}
+	return meta, &archiveCloser{ReadCloser: archive, storage: closer}, nil
+}
+
+// Remove is part of the Backups interface.
+func (b *backupsShim) Remove(id string) error {
+	backupsMethods, _, closer, err := b.open()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer.Close()
+	return backupsMethods.Remove(id)
+}
+
+// archiveCloser closes the backups storage along with the archive
+// read from it.
+type archiveCloser struct {
+	io.ReadCloser
+	storage io.Closer
+}
+
+func (a *archiveCloser) Close() error {
+	err := a.ReadCloser.Close()
+	a.storage.Close()
+	return errors.Trace(err)
+}
@@ -0,0 +1,344 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupscheduler provides a worker that takes backups of the
+// controller on a schedule, copies them to remote storage and removes
+// old backups according to a retention policy.
+package backupscheduler
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/backups/remote"
+)
+
+// AutomaticBackupNotes is recorded as the notes of every backup taken
+// by the worker. Only backups with these notes are considered for
+// removal by the retention policy.
+const AutomaticBackupNotes = "automatic backup"
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// Backend exposes the controller configuration used by the worker.
+type Backend interface {
+	ControllerConfig() (controller.Config, error)
+	WatchControllerConfig() state.NotifyWatcher
+}
+
+// Backups exposes the backup operations used by the worker.
+type Backups interface {
+	// Create takes a backup of the controller, stores it on the
+	// controller and returns its metadata.
+	Create(notes string) (*backups.Metadata, error)
+
+	// List returns the metadata of all the backups stored on the
+	// controller.
+	List() ([]*backups.Metadata, error)
+
+	// Get returns the metadata and archive of the backup with the
+	// given id.
+	Get(id string) (*backups.Metadata, io.ReadCloser, error)
+
+	// Remove deletes the backup with the given id.
+	Remove(id string) error
+}
+
+// Config holds the dependencies and configuration for a backup
+// scheduler worker.
+type Config struct {
+	Backend    Backend
+	Backups    Backups
+	Clock      clock.Clock
+	NewStorage func(controller.Config) (remote.Storage, error)
+}
+
+// Validate returns an error if the config cannot be expected to drive
+// a functional worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.NewStorage == nil {
+		return errors.NotValidf("nil NewStorage")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that takes backups of the controller at
+// the interval given in the controller configuration. This worker
+// must not be run in more than one agent concurrently.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &backupWorker{
+		config: config,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type backupWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	controllerConfig controller.Config
+	storage          remote.Storage
+
+	// lastAttempt records when a backup was last attempted, so that
+	// a failed backup is not retried until the next interval.
+	lastAttempt time.Time
+}
+
+func (w *backupWorker) loop() error {
+	configWatcher := w.config.Backend.WatchControllerConfig()
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var timer clock.Timer
+	var due <-chan time.Time
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("controller configuration watcher closed")
+			}
+			if err := w.loadConfig(); err != nil {
+				return errors.Trace(err)
+			}
+
+		case <-due:
+			w.backup()
+		}
+
+		if timer != nil {
+			timer.Stop()
+			timer, due = nil, nil
+		}
+		if wait, ok := w.nextBackup(); ok {
+			timer = w.config.Clock.NewTimer(wait)
+			due = timer.Chan()
+		}
+	}
+}
+
+func (w *backupWorker) loadConfig() error {
+	cfg, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "cannot load controller configuration")
+	}
+	w.controllerConfig = cfg
+	w.storage = nil
+	if cfg.BackupTarget() != "" {
+		storage, err := w.config.NewStorage(cfg)
+		if err != nil {
+			// A bad target shouldn't prevent backups being
+			// taken and kept on the controller.
+			logger.Errorf("cannot use backup target %q: %v", cfg.BackupTarget(), err)
+		} else {
+			w.storage = storage
+		}
+	}
+	logger.Infof(
+		"backup config: interval %v, retention count %d, retention age %v, target %q",
+		cfg.BackupInterval(), cfg.BackupRetentionCount(), cfg.BackupRetentionAge(), cfg.BackupTarget(),
+	)
+	return nil
+}
+
+// nextBackup returns how long to wait until the next backup is due.
+// It returns false if automatic backups are disabled.
+func (w *backupWorker) nextBackup() (time.Duration, bool) {
+	interval := w.controllerConfig.BackupInterval()
+	if interval <= 0 {
+		return 0, false
+	}
+	last := w.lastAttempt
+	automatic, err := w.automaticBackups()
+	if err != nil {
+		logger.Errorf("cannot list backups: %v", err)
+	} else if len(automatic) > 0 && automatic[0].Started.After(last) {
+		last = automatic[0].Started
+	}
+	wait := last.Add(interval).Sub(w.config.Clock.Now())
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+// backup takes a backup, copies it to remote storage if configured,
+// and then removes old backups. Failures are logged rather than
+// returned, so that the worker tries again at the next interval.
+func (w *backupWorker) backup() {
+	w.lastAttempt = w.config.Clock.Now()
+	logger.Infof("creating automatic backup")
+	meta, err := w.config.Backups.Create(AutomaticBackupNotes)
+	if err != nil {
+		logger.Errorf("cannot create backup: %v", err)
+		return
+	}
+	logger.Infof("created backup %q", meta.ID())
+
+	if w.storage != nil {
+		if err := w.upload(meta); err != nil {
+			logger.Errorf("cannot copy backup %q to %q: %v", meta.ID(), w.controllerConfig.BackupTarget(), err)
+		}
+	}
+	if err := w.prune(); err != nil {
+		logger.Errorf("cannot remove old backups: %v", err)
+	}
+	if w.storage != nil {
+		if err := w.pruneRemote(); err != nil {
+			logger.Errorf("cannot remove old backups from %q: %v", w.controllerConfig.BackupTarget(), err)
+		}
+	}
+}
+
+func (w *backupWorker) upload(meta *backups.Metadata) error {
+	_, archive, err := w.config.Backups.Get(meta.ID())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+	name := meta.Started.UTC().Format(backups.FilenameTemplate)
+	return errors.Trace(w.storage.Put(name, archive, meta.Size()))
+}
+
+// automaticBackups returns the metadata of the backups taken by the
+// worker, newest first.
+func (w *backupWorker) automaticBackups() ([]*backups.Metadata, error) {
+	all, err := w.config.Backups.List()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var automatic []*backups.Metadata
+	for _, meta := range all {
+		if meta.Notes == AutomaticBackupNotes {
+			automatic = append(automatic, meta)
+		}
+	}
+	sort.Slice(automatic, func(i, j int) bool {
+		return automatic[i].Started.After(automatic[j].Started)
+	})
+	return automatic, nil
+}
+
+func (w *backupWorker) prune() error {
+	automatic, err := w.automaticBackups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	times := make([]time.Time, len(automatic))
+	for i, meta := range automatic {
+		times[i] = meta.Started
+	}
+	for _, i := range w.expired(times) {
+		id := automatic[i].ID()
+		logger.Infof("removing backup %q", id)
+		if err := w.config.Backups.Remove(id); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing backup %q", id)
+		}
+	}
+	return nil
+}
+
+func (w *backupWorker) pruneRemote() error {
+	archives, err := w.storage.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Only archives named as the worker names them are considered,
+	// so anything else kept alongside them is left alone.
+	var names []string
+	var times []time.Time
+	for _, archive := range archives {
+		if !strings.HasPrefix(archive.Name, backups.FilenamePrefix) {
+			continue
+		}
+		t, err := time.Parse(backups.FilenameTemplate, archive.Name)
+		if err != nil {
+			continue
+		}
+		names = append(names, archive.Name)
+		times = append(times, t)
+	}
+	sort.Sort(byTimeDesc{names, times})
+	for _, i := range w.expired(times) {
+		logger.Infof("removing backup archive %q from %q", names[i], w.controllerConfig.BackupTarget())
+		if err := w.storage.Remove(names[i]); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing backup archive %q", names[i])
+		}
+	}
+	return nil
+}
+
+// expired returns the indices of the backups, taken at the given
+// times in newest first order, that should be removed according to
+// the retention policy. The newest backup is never removed.
+func (w *backupWorker) expired(times []time.Time) []int {
+	count := w.controllerConfig.BackupRetentionCount()
+	age := w.controllerConfig.BackupRetentionAge()
+	now := w.config.Clock.Now()
+	var expired []int
+	for i := 1; i < len(times); i++ {
+		if (count > 0 && i >= count) || (age > 0 && now.Sub(times[i]) > age) {
+			expired = append(expired, i)
+		}
+	}
+	return expired
+}
+
+type byTimeDesc struct {
+	names []string
+	times []time.Time
+}
+
+func (b byTimeDesc) Len() int           { return len(b.names) }
+func (b byTimeDesc) Less(i, j int) bool { return b.times[i].After(b.times[j]) }
+func (b byTimeDesc) Swap(i, j int) {
+	b.names[i], b.names[j] = b.names[j], b.names[i]
+	b.times[i], b.times[j] = b.times[j], b.times[i]
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *backupWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *backupWorker) Wait() error {
+	return w.catacomb.Wait()
+}
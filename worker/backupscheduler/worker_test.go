@@ -0,0 +1,364 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/backups/remote"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	clock   *testclock.Clock
+	backend *fakeBackend
+	backups *fakeBackups
+	storage *fakeStorage
+	config  backupscheduler.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC))
+	s.backend = &fakeBackend{
+		config: controller.Config{
+			controller.BackupInterval: "1h",
+		},
+		changes: make(chan struct{}, 1),
+	}
+	s.backups = &fakeBackups{
+		clock:   s.clock,
+		created: make(chan string, 10),
+		removed: make(chan string, 10),
+	}
+	s.storage = &fakeStorage{
+		archives: make(map[string]string),
+		removed:  make(chan string, 10),
+	}
+	s.config = backupscheduler.Config{
+		Backend: s.backend,
+		Backups: s.backups,
+		Clock:   s.clock,
+		NewStorage: func(controller.Config) (remote.Storage, error) {
+			return s.storage, nil
+		},
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.config.Backups = nil
+	_, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, gc.ErrorMatches, "nil Backups not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestDisabled(c *gc.C) {
+	s.backend.config = controller.Config{}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case id := <-s.backups.created:
+		c.Fatalf("unexpected backup %q", id)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestBackupWithoutPrevious(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCreated(c, "id-0")
+	c.Assert(s.backups.metas, gc.HasLen, 1)
+	c.Check(s.backups.metas[0].Notes, gc.Equals, backupscheduler.AutomaticBackupNotes)
+	c.Check(s.backups.metas[0].Started, gc.Equals, s.clock.Now())
+
+	// The next backup is taken after the interval.
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCreated(c, "id-1")
+}
+
+func (s *WorkerSuite) TestBackupScheduledFromPrevious(c *gc.C) {
+	s.backups.add("manual", "", -30*time.Minute)
+	s.backups.add("previous", backupscheduler.AutomaticBackupNotes, -40*time.Minute)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	err := s.clock.WaitAdvance(19*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case id := <-s.backups.created:
+		c.Fatalf("unexpected backup %q", id)
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	s.clock.Advance(time.Minute)
+	s.waitCreated(c, "id-0")
+}
+
+func (s *WorkerSuite) TestFailedBackupRetriedAfterInterval(c *gc.C) {
+	s.backups.createErr = errors.New("boom")
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCreated(c, "")
+	s.backups.mu.Lock()
+	s.backups.createErr = nil
+	s.backups.mu.Unlock()
+
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCreated(c, "id-0")
+}
+
+func (s *WorkerSuite) TestRetentionCount(c *gc.C) {
+	s.backend.config[controller.BackupRetentionCount] = 2
+	s.backups.add("manual", "", -5*time.Hour)
+	s.backups.add("old", backupscheduler.AutomaticBackupNotes, -3*time.Hour)
+	s.backups.add("recent", backupscheduler.AutomaticBackupNotes, -2*time.Hour)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCreated(c, "id-0")
+	s.waitRemoved(c, s.backups.removed, "old")
+	c.Check(s.backups.ids(), jc.SameContents, []string{"manual", "recent", "id-0"})
+}
+
+func (s *WorkerSuite) TestRetentionAge(c *gc.C) {
+	s.backend.config[controller.BackupRetentionAge] = "90m"
+	s.backups.add("manual", "", -5*time.Hour)
+	s.backups.add("old", backupscheduler.AutomaticBackupNotes, -2*time.Hour)
+	s.backups.add("older", backupscheduler.AutomaticBackupNotes, -3*time.Hour)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCreated(c, "id-0")
+	s.waitRemoved(c, s.backups.removed, "older", "old")
+	c.Check(s.backups.ids(), jc.SameContents, []string{"manual", "id-0"})
+}
+
+func (s *WorkerSuite) TestRemoteStorage(c *gc.C) {
+	s.backend.config[controller.BackupTarget] = "file:///var/backups/juju"
+	s.backend.config[controller.BackupRetentionCount] = 2
+	s.backups.add("old", backupscheduler.AutomaticBackupNotes, -3*time.Hour)
+	s.backups.add("recent", backupscheduler.AutomaticBackupNotes, -2*time.Hour)
+	s.storage.archives["juju-backup-20190301-090000.tar.gz"] = "old"
+	s.storage.archives["juju-backup-20190301-100000.tar.gz"] = "recent"
+	s.storage.archives["README"] = "not a backup"
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCreated(c, "id-0")
+	s.waitRemoved(c, s.storage.removed, "juju-backup-20190301-090000.tar.gz")
+	c.Check(s.storage.names(), jc.DeepEquals, []string{
+		"README",
+		"juju-backup-20190301-100000.tar.gz",
+		"juju-backup-20190301-120000.tar.gz",
+	})
+	s.storage.mu.Lock()
+	defer s.storage.mu.Unlock()
+	c.Check(s.storage.archives["juju-backup-20190301-120000.tar.gz"], gc.Equals, "archive id-0")
+}
+
+func (s *WorkerSuite) TestRemoteStorageError(c *gc.C) {
+	s.backend.config[controller.BackupTarget] = "file:///var/backups/juju"
+	s.config.NewStorage = func(controller.Config) (remote.Storage, error) {
+		return nil, errors.New("no way")
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// Backups are still taken and kept on the controller.
+	s.waitCreated(c, "id-0")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.changes <- struct{}{}
+	return w
+}
+
+func (s *WorkerSuite) waitCreated(c *gc.C, expect string) {
+	select {
+	case id := <-s.backups.created:
+		c.Assert(id, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for backup")
+	}
+}
+
+func (s *WorkerSuite) waitRemoved(c *gc.C, removed <-chan string, expect ...string) {
+	var got []string
+	for range expect {
+		select {
+		case name := <-removed:
+			got = append(got, name)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for removal; got %v", got)
+		}
+	}
+	c.Assert(got, jc.SameContents, expect)
+}
+
+type fakeBackend struct {
+	config  controller.Config
+	changes chan struct{}
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	return b.config, nil
+}
+
+func (b *fakeBackend) WatchControllerConfig() state.NotifyWatcher {
+	return statetesting.NewMockNotifyWatcher(b.changes)
+}
+
+type fakeBackups struct {
+	mu        sync.Mutex
+	clock     *testclock.Clock
+	metas     []*backups.Metadata
+	createErr error
+	next      int
+	created   chan string
+	removed   chan string
+}
+
+func (b *fakeBackups) add(id, notes string, age time.Duration) {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Notes = notes
+	meta.Started = b.clock.Now().Add(age)
+	meta.MarkComplete(int64(len("archive "+id)), "checksum")
+	b.metas = append(b.metas, meta)
+}
+
+func (b *fakeBackups) ids() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var ids []string
+	for _, meta := range b.metas {
+		ids = append(ids, meta.ID())
+	}
+	return ids
+}
+
+func (b *fakeBackups) Create(notes string) (*backups.Metadata, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.createErr != nil {
+		b.created <- ""
+		return nil, b.createErr
+	}
+	id := fmt.Sprintf("id-%d", b.next)
+	b.next++
+	b.add(id, notes, 0)
+	b.created <- id
+	return b.metas[len(b.metas)-1], nil
+}
+
+func (b *fakeBackups) List() ([]*backups.Metadata, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*backups.Metadata(nil), b.metas...), nil
+}
+
+func (b *fakeBackups) Get(id string) (*backups.Metadata, io.ReadCloser, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, meta := range b.metas {
+		if meta.ID() == id {
+			return meta, ioutil.NopCloser(strings.NewReader("archive " + id)), nil
+		}
+	}
+	return nil, nil, errors.NotFoundf("backup %q", id)
+}
+
+func (b *fakeBackups) Remove(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, meta := range b.metas {
+		if meta.ID() == id {
+			b.metas = append(b.metas[:i], b.metas[i+1:]...)
+			b.removed <- id
+			return nil
+		}
+	}
+	return errors.NotFoundf("backup %q", id)
+}
+
+type fakeStorage struct {
+	mu       sync.Mutex
+	archives map[string]string
+	removed  chan string
+}
+
+func (s *fakeStorage) names() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var names []string
+	for name := range s.archives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *fakeStorage) Put(name string, r io.Reader, size int64) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) != size {
+		return errors.Errorf("expected %d bytes, got %d", size, len(data))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.archives[name] = string(data)
+	return nil
+}
+
+func (s *fakeStorage) List() ([]remote.Archive, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var archives []remote.Archive
+	for name, data := range s.archives {
+		archives = append(archives, remote.Archive{Name: name, Size: int64(len(data))})
+	}
+	return archives, nil
+}
+
+func (s *fakeStorage) Remove(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.archives[name]; !ok {
+		return errors.NotFoundf("backup archive %q", name)
+	}
+	delete(s.archives, name)
+	s.removed <- name
+	return nil
+}
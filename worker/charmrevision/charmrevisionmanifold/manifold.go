@@ -27,10 +27,13 @@ type ManifoldConfig struct {
 	// The remaining dependencies will be used with the resources to configure
 	// and create the worker. The period must be greater than 0; the NewFacade
 	// and NewWorker fields must not be nil. charmrevision.NewWorker, and
-	// NewAPIFacade, are suitable implementations for most clients.
-	Period    time.Duration
-	NewFacade func(base.APICaller) (Facade, error)
-	NewWorker func(charmrevision.Config) (worker.Worker, error)
+	// NewAPIFacade, are suitable implementations for most clients. The
+	// refresh period may be zero, in which case charm refresh policies
+	// are only applied after charm revision updates.
+	Period        time.Duration
+	RefreshPeriod time.Duration
+	NewFacade     func(base.APICaller) (Facade, error)
+	NewWorker     func(charmrevision.Config) (worker.Worker, error)
 }
 
 // Manifold returns a dependency.Manifold that runs a charm revision worker
@@ -59,6 +62,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				RevisionUpdater: facade,
 				Clock:           clock,
 				Period:          config.Period,
+				RefreshPeriod:   config.RefreshPeriod,
 			})
 			if err != nil {
 				return nil, errors.Annotatef(err, "cannot create worker")
@@ -131,6 +131,7 @@ func (s *ManifoldSuite) TestSuccess(c *gc.C) {
 		APICallerName: "api-caller",
 		ClockName:     "clock",
 		Period:        10 * time.Minute,
+		RefreshPeriod: time.Minute,
 		NewFacade: func(apiCaller base.APICaller) (charmrevisionmanifold.Facade, error) {
 			stub.AddCall("NewFacade", apiCaller)
 			return fakeFacade, nil
@@ -152,6 +153,7 @@ func (s *ManifoldSuite) TestSuccess(c *gc.C) {
 	}, {
 		"NewWorker", []interface{}{charmrevision.Config{
 			Period:          10 * time.Minute,
+			RefreshPeriod:   time.Minute,
 			RevisionUpdater: fakeFacade,
 			Clock:           fakeClock,
 		}},
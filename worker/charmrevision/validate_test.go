@@ -56,6 +56,11 @@ func (s *ValidateSuite) TestBadPeriods(c *gc.C) {
 	}
 }
 
+func (s *ValidateSuite) TestNegativeRefreshPeriod(c *gc.C) {
+	s.config.RefreshPeriod = -time.Minute
+	s.checkNotValid(c, "negative RefreshPeriod not valid")
+}
+
 func (s *ValidateSuite) checkNotValid(c *gc.C, match string) {
 	check := func(err error) {
 		c.Check(err, jc.Satisfies, errors.IsNotValid)
@@ -26,6 +26,11 @@ type RevisionUpdater interface {
 	// to change/mature, please migrate responsibilities down to the worker
 	// and grow this interface to match.
 	UpdateLatestRevisions() error
+
+	// ApplyCharmRefreshPolicies causes the charms of applications whose
+	// charm refresh policy's maintenance window is open to be upgraded
+	// to the latest revisions stored by UpdateLatestRevisions.
+	ApplyCharmRefreshPolicies() error
 }
 
 // Config defines the operation of a charm revision updater worker.
@@ -39,6 +44,11 @@ type Config struct {
 
 	// Period is the time between charm revision updates.
 	Period time.Duration
+
+	// RefreshPeriod is the time between applications of charm refresh
+	// policies. Policies are always applied after each charm revision
+	// update; if RefreshPeriod is zero, they are applied only then.
+	RefreshPeriod time.Duration
 }
 
 // Validate returns an error if the configuration cannot be expected
@@ -53,12 +63,16 @@ func (config Config) Validate() error {
 	if config.Period <= 0 {
 		return errors.NotValidf("non-positive Period")
 	}
+	if config.RefreshPeriod < 0 {
+		return errors.NotValidf("negative RefreshPeriod")
+	}
 	return nil
 }
 
 // NewWorker returns a worker that calls UpdateLatestRevisions on the
 // configured RevisionUpdater, once when started and subsequently every
-// Period.
+// Period. ApplyCharmRefreshPolicies is called after each update, and
+// every RefreshPeriod if that is set.
 func NewWorker(config Config) (worker.Worker, error) {
 	if err := config.Validate(); err != nil {
 		return nil, errors.Trace(err)
@@ -76,18 +90,27 @@ type revisionUpdateWorker struct {
 }
 
 func (ruw *revisionUpdateWorker) loop() error {
-	var delay time.Duration
+	updater := ruw.config.RevisionUpdater
+	update := ruw.config.Clock.After(0)
+	var refresh <-chan time.Time
 	for {
 		select {
 		case <-ruw.tomb.Dying():
 			return tomb.ErrDying
-		case <-ruw.config.Clock.After(delay):
-			err := ruw.config.RevisionUpdater.UpdateLatestRevisions()
-			if err != nil {
+		case <-update:
+			if err := updater.UpdateLatestRevisions(); err != nil {
 				return errors.Trace(err)
 			}
+			update = ruw.config.Clock.After(ruw.config.Period)
+		case <-refresh:
+			refresh = nil
+		}
+		if err := updater.ApplyCharmRefreshPolicies(); err != nil {
+			return errors.Trace(err)
+		}
+		if refresh == nil && ruw.config.RefreshPeriod > 0 {
+			refresh = ruw.config.Clock.After(ruw.config.RefreshPeriod)
 		}
-		delay = ruw.config.Period
 	}
 }
 
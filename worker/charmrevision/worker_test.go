@@ -26,33 +26,58 @@ var _ = gc.Suite(&WorkerSuite{})
 func (s *WorkerSuite) TestUpdatesImmediately(c *gc.C) {
 	fix := newFixture(time.Minute)
 	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitCall(c)
 		fix.waitCall(c)
 		fix.waitNoCall(c)
 	})
-	fix.revisionUpdater.stub.CheckCallNames(c, "UpdateLatestRevisions")
+	fix.revisionUpdater.stub.CheckCallNames(c, "UpdateLatestRevisions", "ApplyCharmRefreshPolicies")
 }
 
 func (s *WorkerSuite) TestNoMoreUpdatesUntilPeriod(c *gc.C) {
 	fix := newFixture(time.Minute)
 	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitCall(c)
 		fix.waitCall(c)
 		fix.clock.Advance(time.Minute - time.Nanosecond)
 		fix.waitNoCall(c)
 	})
-	fix.revisionUpdater.stub.CheckCallNames(c, "UpdateLatestRevisions")
+	fix.revisionUpdater.stub.CheckCallNames(c, "UpdateLatestRevisions", "ApplyCharmRefreshPolicies")
 }
 
 func (s *WorkerSuite) TestUpdatesAfterPeriod(c *gc.C) {
 	fix := newFixture(time.Minute)
 	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitCall(c)
 		fix.waitCall(c)
 		if err := fix.clock.WaitAdvance(time.Minute, testing.LongWait, 1); err != nil {
 			c.Fatal(err)
 		}
 		fix.waitCall(c)
+		fix.waitCall(c)
+		fix.waitNoCall(c)
+	})
+	fix.revisionUpdater.stub.CheckCallNames(c,
+		"UpdateLatestRevisions", "ApplyCharmRefreshPolicies",
+		"UpdateLatestRevisions", "ApplyCharmRefreshPolicies",
+	)
+}
+
+func (s *WorkerSuite) TestRefreshesAfterRefreshPeriod(c *gc.C) {
+	fix := newFixture(time.Hour)
+	fix.refreshPeriod = time.Minute
+	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitCall(c)
+		fix.waitCall(c)
+		if err := fix.clock.WaitAdvance(time.Minute, testing.LongWait, 2); err != nil {
+			c.Fatal(err)
+		}
+		fix.waitCall(c)
 		fix.waitNoCall(c)
 	})
-	fix.revisionUpdater.stub.CheckCallNames(c, "UpdateLatestRevisions", "UpdateLatestRevisions")
+	fix.revisionUpdater.stub.CheckCallNames(c,
+		"UpdateLatestRevisions", "ApplyCharmRefreshPolicies",
+		"ApplyCharmRefreshPolicies",
+	)
 }
 
 func (s *WorkerSuite) TestImmediateUpdateError(c *gc.C) {
@@ -71,10 +96,11 @@ func (s *WorkerSuite) TestImmediateUpdateError(c *gc.C) {
 func (s *WorkerSuite) TestDelayedUpdateError(c *gc.C) {
 	fix := newFixture(time.Minute)
 	fix.revisionUpdater.stub.SetErrors(
-		nil,
+		nil, nil,
 		errors.New("no more updates for you"),
 	)
 	fix.dirtyTest(c, func(w worker.Worker) {
+		fix.waitCall(c)
 		fix.waitCall(c)
 		if err := fix.clock.WaitAdvance(time.Minute, testing.LongWait, 1); err != nil {
 			c.Fatal(err)
@@ -83,7 +109,25 @@ func (s *WorkerSuite) TestDelayedUpdateError(c *gc.C) {
 		c.Check(w.Wait(), gc.ErrorMatches, "no more updates for you")
 		fix.waitNoCall(c)
 	})
-	fix.revisionUpdater.stub.CheckCallNames(c, "UpdateLatestRevisions", "UpdateLatestRevisions")
+	fix.revisionUpdater.stub.CheckCallNames(c,
+		"UpdateLatestRevisions", "ApplyCharmRefreshPolicies",
+		"UpdateLatestRevisions",
+	)
+}
+
+func (s *WorkerSuite) TestRefreshError(c *gc.C) {
+	fix := newFixture(time.Minute)
+	fix.revisionUpdater.stub.SetErrors(
+		nil,
+		errors.New("no refreshes for you"),
+	)
+	fix.dirtyTest(c, func(w worker.Worker) {
+		fix.waitCall(c)
+		fix.waitCall(c)
+		c.Check(w.Wait(), gc.ErrorMatches, "no refreshes for you")
+		fix.waitNoCall(c)
+	})
+	fix.revisionUpdater.stub.CheckCallNames(c, "UpdateLatestRevisions", "ApplyCharmRefreshPolicies")
 }
 
 // workerFixture isolates a charmrevision worker for testing.
@@ -91,6 +135,7 @@ type workerFixture struct {
 	revisionUpdater mockRevisionUpdater
 	clock           *testclock.Clock
 	period          time.Duration
+	refreshPeriod   time.Duration
 }
 
 func newFixture(period time.Duration) workerFixture {
@@ -116,6 +161,7 @@ func (fix workerFixture) runTest(c *gc.C, test testFunc, checkWaitErr bool) {
 		RevisionUpdater: fix.revisionUpdater,
 		Clock:           fix.clock,
 		Period:          fix.period,
+		RefreshPeriod:   fix.refreshPeriod,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	defer func() {
@@ -143,7 +189,8 @@ func (fix workerFixture) waitNoCall(c *gc.C) {
 	}
 }
 
-// mockRevisionUpdater records (and notifies of) calls made to UpdateLatestRevisions.
+// mockRevisionUpdater records (and notifies of) calls made to UpdateLatestRevisions
+// and ApplyCharmRefreshPolicies.
 type mockRevisionUpdater struct {
 	stub  *testing.Stub
 	calls chan struct{}
@@ -161,3 +208,9 @@ func (mock mockRevisionUpdater) UpdateLatestRevisions() error {
 	mock.calls <- struct{}{}
 	return mock.stub.NextErr()
 }
+
+func (mock mockRevisionUpdater) ApplyCharmRefreshPolicies() error {
+	mock.stub.AddCall("ApplyCharmRefreshPolicies")
+	mock.calls <- struct{}{}
+	return mock.stub.NextErr()
+}
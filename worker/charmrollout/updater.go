@@ -0,0 +1,77 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmrollout provides a worker that advances the batched
+// charm upgrades in progress in a model.
+package charmrollout
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+)
+
+// period is the time between checks of the units released by charm
+// rollouts. A rollout advances to its next batch no sooner than this
+// after the units of the previous batch become healthy.
+const period = 10 * time.Second
+
+var logger = loggo.GetLogger("juju.worker.charmrollout")
+
+// Facade exposes the controller functionality used by the worker.
+type Facade interface {
+	UpdateCharmRollouts() error
+}
+
+// Updater is a worker that periodically advances the model's charm
+// rollouts.
+type Updater struct {
+	catacomb catacomb.Catacomb
+	facade   Facade
+	clock    clock.Clock
+}
+
+// NewUpdater returns a worker that advances the model's charm rollouts
+// as the units they have released become healthy.
+func NewUpdater(facade Facade, clock clock.Clock) (worker.Worker, error) {
+	u := &Updater{
+		facade: facade,
+		clock:  clock,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &u.catacomb,
+		Work: u.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return u, nil
+}
+
+func (u *Updater) loop() error {
+	for {
+		select {
+		case <-u.catacomb.Dying():
+			return u.catacomb.ErrDying()
+		case <-u.clock.After(period):
+		}
+		if err := u.facade.UpdateCharmRollouts(); err != nil {
+			// Failing to update rollouts isn't fatal; we
+			// retry next period.
+			logger.Errorf("cannot update charm rollouts: %v", err)
+		}
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (u *Updater) Kill() {
+	u.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (u *Updater) Wait() error {
+	return u.catacomb.Wait()
+}
@@ -0,0 +1,84 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollout_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/charmrollout"
+)
+
+type UpdaterSuite struct {
+	coretesting.BaseSuite
+	facade *mockFacade
+	clock  *testclock.Clock
+}
+
+var _ = gc.Suite(&UpdaterSuite{})
+
+func (s *UpdaterSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2019, 4, 1, 10, 0, 0, 0, time.UTC))
+	s.facade = &mockFacade{calls: make(chan struct{}, 10)}
+}
+
+func (s *UpdaterSuite) assertCalled(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for UpdateCharmRollouts")
+	}
+}
+
+func (s *UpdaterSuite) assertNotCalled(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+		c.Fatalf("unexpected UpdateCharmRollouts")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *UpdaterSuite) TestUpdatesPeriodically(c *gc.C) {
+	w, err := charmrollout.NewUpdater(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.clock.WaitAdvance(9*time.Second, coretesting.LongWait, 1)
+	s.assertNotCalled(c)
+	s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	s.assertCalled(c)
+
+	s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	s.assertCalled(c)
+}
+
+func (s *UpdaterSuite) TestUpdateErrorNotFatal(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := charmrollout.NewUpdater(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	s.assertCalled(c)
+	workertest.CheckAlive(c, w)
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains, "ERROR juju.worker.charmrollout cannot update charm rollouts: boom")
+}
+
+type mockFacade struct {
+	calls chan struct{}
+	err   error
+}
+
+func (f *mockFacade) UpdateCharmRollouts() error {
+	f.calls <- struct{}{}
+	return f.err
+}
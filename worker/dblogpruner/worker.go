@@ -4,6 +4,7 @@
 package dblogpruner
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
@@ -93,6 +94,7 @@ type report struct {
 	nextPrune       time.Time
 	maxLogAge       time.Duration
 	maxCollectionMB int
+	maxAuditLogMB   int
 	message         string
 	pruning         bool
 }
@@ -117,12 +119,19 @@ func (w *pruneWorker) loop() error {
 			}
 			newMaxAge := controllerConfig.MaxLogsAge()
 			newMaxCollectionMB := controllerConfig.MaxLogSizeMB()
-			if newMaxAge != w.current.maxLogAge || newMaxCollectionMB != w.current.maxCollectionMB {
+			newMaxAuditLogMB := controllerConfig.AuditLogMaxSizeMB()
+			if newMaxAge != w.current.maxLogAge ||
+				newMaxCollectionMB != w.current.maxCollectionMB ||
+				newMaxAuditLogMB != w.current.maxAuditLogMB {
 				w.mu.Lock()
 				w.current.maxLogAge = newMaxAge
 				w.current.maxCollectionMB = newMaxCollectionMB
+				w.current.maxAuditLogMB = newMaxAuditLogMB
 				w.mu.Unlock()
-				logger.Infof("log pruning config: max age: %v, max collection size %dM", newMaxAge, newMaxCollectionMB)
+				logger.Infof(
+					"log pruning config: max age: %v, max collection size %dM, max audit log size %dM",
+					newMaxAge, newMaxCollectionMB, newMaxAuditLogMB,
+				)
 			}
 			if prune == nil {
 				// We defer starting the timer until the
@@ -149,6 +158,16 @@ func (w *pruneWorker) loop() error {
 			if err != nil {
 				return errors.Trace(err)
 			}
+			// The audit log recorded in the database is kept for
+			// as long as the logs, and capped at the size of the
+			// audit log file.
+			auditRemoved, err := w.config.State.AuditLogStore().Prune(minLogTime, w.current.maxAuditLogMB)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if auditRemoved > 0 {
+				message = fmt.Sprintf("%s, pruned %d audit log entries", message, auditRemoved)
+			}
 			w.mu.Lock()
 			w.current.pruning = false
 			w.current.message = message
@@ -4,6 +4,7 @@
 package dblogpruner_test
 
 import (
+	"fmt"
 	stdtesting "testing"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 
+	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
@@ -143,6 +145,32 @@ func (s *suite) TestPrunesOldLogs(c *gc.C) {
 	c.Fatal("pruning didn't happen as expected")
 }
 
+func (s *suite) TestPrunesOldAuditLog(c *gc.C) {
+	s.setupState(c, "24h", "1000P")
+	store := s.state.AuditLogStore()
+	now := time.Now()
+	for i, when := range []time.Time{now.Add(-25 * time.Hour), now} {
+		err := store.AddConversation(auditlog.Conversation{
+			Who:            "fred",
+			When:           when.Format(time.RFC3339),
+			ModelName:      "fred/default",
+			ConversationID: fmt.Sprintf("000%d", i),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.startWorker(c)
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		results, err := store.Query(auditlog.Filter{})
+		c.Assert(err, jc.ErrorIsNil)
+		if len(results) == 1 {
+			c.Assert(results[0].Conversation.ConversationID, gc.Equals, "0001")
+			return
+		}
+	}
+	c.Fatal("audit log pruning didn't happen as expected")
+}
+
 type storageEngine struct {
 	Name string `bson:"name"`
 }
@@ -250,5 +250,8 @@ EOF`)
 	}, {
 		DeviceName: "loop0",
 		Size:       243,
+	}, {
+		DeviceName: "whatever",
+		Size:       243,
 	}})
 }
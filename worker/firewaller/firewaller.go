@@ -5,6 +5,7 @@ package firewaller
 
 import (
 	"io"
+	"reflect"
 	"strings"
 	"time"
 
@@ -38,6 +39,7 @@ import (
 type FirewallerAPI interface {
 	WatchModelMachines() (watcher.StringsWatcher, error)
 	WatchOpenedPorts() (watcher.StringsWatcher, error)
+	WatchSubnets() (watcher.StringsWatcher, error)
 	Machine(tag names.MachineTag) (*firewaller.Machine, error)
 	Unit(tag names.UnitTag) (*firewaller.Unit, error)
 	Relation(tag names.RelationTag) (*firewaller.Relation, error)
@@ -119,7 +121,10 @@ func (cfg Config) Validate() error {
 	return nil
 }
 
-type portRanges map[corenetwork.PortRange]bool
+// portRanges holds the port ranges opened by a unit, mapped to the
+// names of the endpoints each was opened for. No endpoints means the
+// range was opened for all of them.
+type portRanges map[corenetwork.PortRange][]string
 
 // Firewaller watches the state for port ranges opened or closed on
 // machines and reflects those changes onto the backing environment.
@@ -133,6 +138,7 @@ type Firewaller struct {
 
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
+	subnetsWatcher       watcher.StringsWatcher
 	machineds            map[names.MachineTag]*machineData
 	unitsChange          chan *unitsChange
 	unitds               map[names.UnitTag]*unitData
@@ -228,6 +234,17 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	// Subnet changes may change the CIDRs of the spaces that
+	// applications are exposed to.
+	fw.subnetsWatcher, err = fw.firewallerApi.WatchSubnets()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not watching subnets: %v", err)
+	} else if err != nil {
+		return errors.Annotatef(err, "failed to start subnets watcher")
+	} else if err := fw.catacomb.Add(fw.subnetsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
 	fw.remoteRelationsWatcher, err = fw.remoteRelationsApi.WatchRemoteRelations()
 	if err != nil {
 		return errors.Trace(err)
@@ -246,6 +263,10 @@ func (fw *Firewaller) loop() error {
 	}
 	var reconciled bool
 	portsChange := fw.portsWatcher.Changes()
+	var subnetsChange watcher.StringsChannel
+	if fw.subnetsWatcher != nil {
+		subnetsChange = fw.subnetsWatcher.Changes()
+	}
 	for {
 		select {
 		case <-fw.catacomb.Dying():
@@ -284,6 +305,13 @@ func (fw *Firewaller) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case _, ok := <-subnetsChange:
+			if !ok {
+				return errors.New("subnets watcher closed")
+			}
+			for _, applicationd := range fw.applicationids {
+				applicationd.subnetsChanged()
+			}
 		case change, ok := <-fw.remoteRelationsWatcher.Changes():
 			if !ok {
 				return errors.New("remote relations watcher closed")
@@ -306,6 +334,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.applicationd.exposed = change.exposed
+			change.applicationd.exposedEndpoints = change.exposedEndpoints
 			unitds := []*unitData{}
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -470,22 +499,24 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startApplication creates a new data value for tracking details of the
 // application and starts watching the application for exposure changes.
 func (fw *Firewaller) startApplication(app *firewaller.Application) error {
-	exposed, err := app.IsExposed()
+	exposed, exposedEndpoints, err := app.ExposeInfo()
 	if err != nil {
 		return err
 	}
 	applicationd := &applicationData{
-		fw:          fw,
-		application: app,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:               fw,
+		application:      app,
+		exposed:          exposed,
+		exposedEndpoints: exposedEndpoints,
+		unitds:           make(map[names.UnitTag]*unitData),
+		subnetsChange:    make(chan struct{}, 1),
 	}
 	fw.applicationids[app.Tag()] = applicationd
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, exposedEndpoints)
 		},
 	})
 	if err != nil {
@@ -664,7 +695,8 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 	}
 
 	newPortRanges := make(map[names.UnitTag]portRanges)
-	for portRange, unitTag := range ports {
+	for portRange, opened := range ports {
+		unitTag := opened.Unit
 		unitd, ok := machined.unitds[unitTag]
 		if !ok {
 			// It is common to receive port change notification before
@@ -678,7 +710,7 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 			ranges = make(portRanges)
 			newPortRanges[unitd.tag] = ranges
 		}
-		ranges[portRange] = true
+		ranges[portRange] = opened.Endpoints
 	}
 
 	if !unitPortsEqual(machined.definedPorts, newPortRanges) {
@@ -713,7 +745,7 @@ func portRangesEqual(a, b portRanges) bool {
 		if !exists {
 			return false
 		}
-		if valueA != valueB {
+		if !reflect.DeepEqual(valueA, valueB) {
 			return false
 		}
 	}
@@ -761,25 +793,29 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 			}
 
 			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
-			if unitd.applicationd.exposed {
-				cidrs.Add("0.0.0.0/0")
-			} else {
+			if !unitd.applicationd.exposed {
 				// Not exposed, so add any ingress rules required by remote relations.
 				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
 					return nil, errors.Trace(err)
 				}
 				logger.Debugf("CIDRS for %v: %v", unitTag, cidrs.Values())
 			}
-			if cidrs.Size() > 0 {
-				for portRange := range portRanges {
-					sourceCidrs := cidrs.SortedValues()
-					rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
-					if err != nil {
-						return nil, errors.Trace(err)
-					}
-					want = append(want, rule)
+			for portRange, endpoints := range portRanges {
+				if unitd.applicationd.exposed {
+					// If the unit is exposed, allow access to the range
+					// from wherever the endpoints it was opened for were
+					// exposed to.
+					cidrs = unitd.applicationd.exposedCIDRs(endpoints)
+				}
+				if cidrs.Size() == 0 {
+					continue
+				}
+				sourceCidrs := cidrs.SortedValues()
+				rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
+				if err != nil {
+					return nil, errors.Trace(err)
 				}
+				want = append(want, rule)
 			}
 		}
 	}
@@ -1086,23 +1122,83 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed expose settings for one specific
+// application.
 type exposedChange struct {
-	applicationd *applicationData
-	exposed      bool
+	applicationd     *applicationData
+	exposed          bool
+	exposedEndpoints map[string]params.ExposedEndpoint
 }
 
 // applicationData holds application details and watches exposure changes.
 type applicationData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	catacomb         catacomb.Catacomb
+	fw               *Firewaller
+	application      *firewaller.Application
+	exposed          bool
+	exposedEndpoints map[string]params.ExposedEndpoint
+	unitds           map[names.UnitTag]*unitData
+	subnetsChange    chan struct{}
+}
+
+// subnetsChanged notifies the application's watch loop that the model's
+// subnets have changed, so the spaces it is exposed to must be resolved
+// again.
+func (ad *applicationData) subnetsChanged() {
+	select {
+	case ad.subnetsChange <- struct{}{}:
+	default:
+		// A notification is already pending.
+	}
+}
+
+// exposedCIDRs returns the CIDRs the exposed application's ports opened
+// for the given endpoints may be accessed from: those the endpoints, and
+// all endpoints, are exposed to. Ports opened for all endpoints may be
+// accessed from wherever any endpoint is exposed to.
+func (ad *applicationData) exposedCIDRs(endpoints []string) set.Strings {
+	cidrs := set.NewStrings()
+	if len(ad.exposedEndpoints) == 0 {
+		// No expose settings, allow access from everywhere.
+		cidrs.Add("0.0.0.0/0")
+		return cidrs
+	}
+	addCIDRs := func(exposed params.ExposedEndpoint) {
+		for _, cidr := range exposed.ExposeToCIDRs {
+			cidrs.Add(cidr)
+		}
+	}
+	if len(endpoints) == 0 {
+		for _, exposed := range ad.exposedEndpoints {
+			addCIDRs(exposed)
+		}
+		return cidrs
+	}
+	if exposed, ok := ad.exposedEndpoints[""]; ok {
+		addCIDRs(exposed)
+	}
+	for _, endpoint := range endpoints {
+		if exposed, ok := ad.exposedEndpoints[endpoint]; ok {
+			addCIDRs(exposed)
+		}
+	}
+	return cidrs
+}
+
+// exposedToSpaces reports whether any of the endpoints are exposed to
+// spaces, whose CIDRs change with the model's subnets.
+func exposedToSpaces(exposedEndpoints map[string]params.ExposedEndpoint) bool {
+	for _, exposed := range exposedEndpoints {
+		if len(exposed.ExposeToSpaces) > 0 {
+			return true
+		}
+	}
+	return false
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// watchLoop watches the application's expose settings, and the subnets
+// of the spaces it is exposed to, for changes.
+func (ad *applicationData) watchLoop(exposed bool, exposedEndpoints map[string]params.ExposedEndpoint) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1121,26 +1217,32 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 			if !ok {
 				return errors.New("application watcher closed")
 			}
-			change, err := ad.application.IsExposed()
-			if err != nil {
-				if errors.IsNotFound(err) {
-					logger.Debugf("application(%q).IsExposed() returned NotFound: %v", ad.application.Name(), err)
-					return nil
-				}
-				return errors.Trace(err)
-			}
-			if change == exposed {
-				logger.Tracef("application(%q).IsExposed() == %v (unchanged)", ad.application.Name(), exposed)
+		case <-ad.subnetsChange:
+			if !exposedToSpaces(exposedEndpoints) {
 				continue
 			}
-			logger.Tracef("application(%q).IsExposed() changed %v => %v", ad.application.Name(), exposed, change)
-
-			exposed = change
-			select {
-			case <-ad.catacomb.Dying():
-				return ad.catacomb.ErrDying()
-			case ad.fw.exposedChange <- &exposedChange{ad, change}:
+		}
+		change, changeEndpoints, err := ad.application.ExposeInfo()
+		if err != nil {
+			if errors.IsNotFound(err) {
+				logger.Debugf("application(%q).ExposeInfo() returned NotFound: %v", ad.application.Name(), err)
+				return nil
 			}
+			return errors.Trace(err)
+		}
+		if change == exposed && reflect.DeepEqual(changeEndpoints, exposedEndpoints) {
+			logger.Tracef("application(%q).ExposeInfo() == %v, %v (unchanged)", ad.application.Name(), exposed, exposedEndpoints)
+			continue
+		}
+		logger.Tracef("application(%q).ExposeInfo() changed %v, %v => %v, %v",
+			ad.application.Name(), exposed, exposedEndpoints, change, changeEndpoints)
+
+		exposed = change
+		exposedEndpoints = changeEndpoints
+		select {
+		case <-ad.catacomb.Dying():
+			return ad.catacomb.ErrDying()
+		case ad.fw.exposedChange <- &exposedChange{ad, change, changeEndpoints}:
 		}
 	}
 }
@@ -558,6 +558,124 @@ func (s *InstanceModeSuite) TestSetClearExposedApplication(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestExposeSettingsApplication(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err := u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Exposing to specific CIDRs only opens the ports to those CIDRs.
+	err = app.SetExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8", "192.168.0.0/16"),
+	})
+
+	// Changing the settings of an already exposed application
+	// updates the rules.
+	err = app.SetExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+	})
+
+	// A plain expose opens the ports to everywhere again.
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+}
+
+func (s *InstanceModeSuite) TestExposeToSpaceSubnetsChange(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("dmz", "", []string{"10.0.0.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.SetExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToSpaces: []string{"dmz"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24"),
+	})
+
+	// Adding a subnet to the space opens the ports to it too.
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.1.0.0/24", SpaceName: "dmz"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "10.1.0.0/24"),
+	})
+}
+
+func (s *InstanceModeSuite) TestExposeSettingsEndpointPorts(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err := u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPortsForEndpoint("url", "tcp", 443, 443)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPortsForEndpoint("db", "tcp", 8080, 8080)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Ports opened for an endpoint are only accessible from wherever
+	// that endpoint, or all endpoints, are exposed to. Ports opened for
+	// all endpoints are accessible from wherever any endpoint is.
+	err = app.SetExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		"db":  {ExposeToCIDRs: []string{"192.168.0.0/16"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8", "192.168.0.0/16"),
+		network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "192.168.0.0/16"),
+	})
+
+	err = app.SetExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		"":    {ExposeToCIDRs: []string{"172.16.0.0/12"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8", "172.16.0.0/12"),
+		network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/8", "172.16.0.0/12"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "172.16.0.0/12"),
+	})
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
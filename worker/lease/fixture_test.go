@@ -84,6 +84,9 @@ type Fixture struct {
 
 	// autoexpire is whether the store should autoexpire.
 	autoexpire bool
+
+	// registerer is used to register the manager's metrics, if set.
+	registerer prometheus.Registerer
 }
 
 // RunTest sets up a Manager and a Clock and passes them into the supplied
@@ -91,6 +94,10 @@ type Fixture struct {
 func (fix *Fixture) RunTest(c *gc.C, test func(*lease.Manager, *testclock.Clock)) {
 	clock := testclock.NewClock(defaultClockStart)
 	store := NewStore(fix.autoexpire, fix.leases, fix.expectCalls)
+	var registerer prometheus.Registerer = noopRegisterer{}
+	if fix.registerer != nil {
+		registerer = fix.registerer
+	}
 	manager, err := lease.NewManager(lease.ManagerConfig{
 		Clock: clock,
 		Store: store,
@@ -99,7 +106,7 @@ func (fix *Fixture) RunTest(c *gc.C, test func(*lease.Manager, *testclock.Clock)
 		},
 		MaxSleep:             defaultMaxSleep,
 		Logger:               loggo.GetLogger("lease_test"),
-		PrometheusRegisterer: noopRegisterer{},
+		PrometheusRegisterer: registerer,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	var wg sync.WaitGroup
@@ -82,6 +82,7 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 		pins:       make(chan pin),
 		unpins:     make(chan pin),
 		logContext: logContext,
+		metrics:    newMetricsCollector(),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &manager.catacomb,
@@ -137,6 +138,9 @@ type Manager struct {
 	// wg is used to ensure that all child goroutines are finished
 	// before we stop.
 	wg sync.WaitGroup
+
+	// metrics records the outcome of the claims handled.
+	metrics *metricsCollector
 }
 
 // Kill is part of the worker.Worker interface.
@@ -151,11 +155,15 @@ func (manager *Manager) Wait() error {
 
 // loop runs until the manager is stopped.
 func (manager *Manager) loop() error {
-	if collector, ok := manager.config.Store.(prometheus.Collector); ok && manager.config.PrometheusRegisterer != nil {
-		// The store implements the collector interface, but the lease.Store
-		// does not expose those.
-		_ = manager.config.PrometheusRegisterer.Register(collector)
-		defer manager.config.PrometheusRegisterer.Unregister(collector)
+	if registerer := manager.config.PrometheusRegisterer; registerer != nil {
+		if collector, ok := manager.config.Store.(prometheus.Collector); ok {
+			// The store implements the collector interface, but the
+			// lease.Store does not expose those.
+			_ = registerer.Register(collector)
+			defer registerer.Unregister(collector)
+		}
+		_ = registerer.Register(manager.metrics)
+		defer registerer.Unregister(manager.metrics)
 	}
 
 	defer manager.wg.Wait()
@@ -302,11 +310,14 @@ func (manager *Manager) handleClaim(claim claim) (bool, error) {
 	request := lease.Request{Holder: claim.holderName, Duration: claim.duration}
 	err := lease.ErrInvalid
 	action := "unknown"
+	operation := "claim"
+	var start time.Time
 	select {
 	case <-manager.catacomb.Dying():
 		return false, manager.catacomb.ErrDying()
 	default:
 		info, found := manager.lookupLease(claim.leaseKey)
+		start = manager.config.Clock.Now()
 		switch {
 		case !found:
 			manager.config.Logger.Tracef("[%s] %s asked for lease %s, no lease found, claiming for %s",
@@ -317,19 +328,22 @@ func (manager *Manager) handleClaim(claim claim) (bool, error) {
 			manager.config.Logger.Tracef("[%s] %s extending lease %s for %s",
 				manager.logContext, claim.holderName, claim.leaseKey.Lease, claim.duration)
 			action = "extending"
+			operation = "extend"
 			err = store.ExtendLease(claim.leaseKey, request, manager.catacomb.Dying())
 		default:
 			// Note: (jam) 2017-10-31) We don't check here if the lease has
 			// expired for the current holder. Should we?
-			remaining := info.Expiry.Sub(manager.config.Clock.Now())
+			remaining := info.Expiry.Sub(start)
 			manager.config.Logger.Tracef("[%s] %s asked for lease %s, held by %s for another %s, rejecting",
 				manager.logContext, claim.holderName, claim.leaseKey.Lease, info.Holder, remaining)
+			manager.recordClaim(claim.leaseKey, operation, "denied", 0)
 			return false, nil
 		}
 	}
 	if lease.IsAborted(err) {
 		return false, manager.catacomb.ErrDying()
 	}
+	manager.recordClaim(claim.leaseKey, operation, claimResult(err), manager.config.Clock.Now().Sub(start))
 	if err != nil {
 		return false, errors.Trace(err)
 	}
@@ -338,6 +352,53 @@ func (manager *Manager) handleClaim(claim claim) (bool, error) {
 	return true, nil
 }
 
+// recordClaim updates the claim metrics with the outcome of a claim
+// or extension of the given lease. The elapsed time is only recorded
+// if the store was asked to make the change.
+func (manager *Manager) recordClaim(key lease.Key, operation, result string, elapsed time.Duration) {
+	manager.metrics.claims.With(prometheus.Labels{
+		"namespace": key.Namespace,
+		"operation": operation,
+		"result":    result,
+	}).Inc()
+	if result == "denied" {
+		return
+	}
+	manager.metrics.claimTimes.With(prometheus.Labels{
+		"namespace": key.Namespace,
+		"operation": operation,
+	}).Observe(elapsed.Seconds())
+	if operation == "claim" && result == "success" {
+		manager.metrics.holderChanges.With(prometheus.Labels{
+			"namespace": key.Namespace,
+			"model":     key.ModelUUID,
+		}).Inc()
+		// Leadership flaps are tracked per application; other
+		// namespaces could have too many leases to label by name.
+		if key.Namespace == lease.ApplicationLeadershipNamespace {
+			manager.metrics.leadershipChanges.With(prometheus.Labels{
+				"model":       key.ModelUUID,
+				"application": key.Lease,
+			}).Inc()
+		}
+	}
+}
+
+// claimResult returns the metrics label for the result of asking
+// the store to claim or extend a lease.
+func claimResult(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case lease.IsInvalid(err):
+		return "invalid"
+	case lease.IsTimeout(err):
+		return "timeout"
+	default:
+		return "error"
+	}
+}
+
 // handleCheck processes and responds to the supplied check. It will only return
 // unrecoverable errors; mere untruth of the assertion just indicates a bad
 // request, and is communicated back to the check's originator.
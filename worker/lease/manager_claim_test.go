@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
 
 	corelease "github.com/juju/juju/core/lease"
@@ -315,6 +316,112 @@ func (s *ClaimSuite) TestOtherHolder_Failure(c *gc.C) {
 	})
 }
 
+func (s *ClaimSuite) TestClaimMetrics(c *gc.C) {
+	registry := prometheus.NewPedanticRegistry()
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "ClaimLease",
+			args: []interface{}{
+				key("redis"),
+				corelease.Request{"redis/0", time.Minute},
+			},
+			callback: func(leases map[corelease.Key]corelease.Info) {
+				leases[key("redis")] = corelease.Info{
+					Holder: "redis/0",
+					Expiry: offset(time.Minute),
+				}
+			},
+		}, {
+			method: "ExtendLease",
+			args: []interface{}{
+				key("redis"),
+				corelease.Request{"redis/0", time.Minute},
+			},
+		}},
+		registerer: registry,
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		claimer := getClaimer(c, manager)
+		err := claimer.Claim("redis", "redis/0", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+		err = claimer.Claim("redis", "redis/0", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+		err = claimer.Claim("redis", "redis/1", time.Minute)
+		c.Check(err, gc.Equals, corelease.ErrClaimDenied)
+
+		families, err := registry.Gather()
+		c.Assert(err, jc.ErrorIsNil)
+		counters := make(map[string]float64)
+		for _, family := range families {
+			for _, metric := range family.GetMetric() {
+				if metric.Counter == nil {
+					continue
+				}
+				name := family.GetName()
+				for _, label := range metric.GetLabel() {
+					name += "/" + label.GetValue()
+				}
+				counters[name] = metric.Counter.GetValue()
+			}
+		}
+		c.Check(counters, jc.DeepEquals, map[string]float64{
+			"juju_lease_manager_claims_total/namespace/claim/success":     1,
+			"juju_lease_manager_claims_total/namespace/extend/success":    1,
+			"juju_lease_manager_claims_total/namespace/claim/denied":      1,
+			"juju_lease_manager_holder_changes_total/modelUUID/namespace": 1,
+		})
+	})
+}
+
+func (s *ClaimSuite) TestClaimMetricsLeadershipChanges(c *gc.C) {
+	registry := prometheus.NewPedanticRegistry()
+	leadershipKey := key(corelease.ApplicationLeadershipNamespace, "modelUUID", "redis")
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "ClaimLease",
+			args: []interface{}{
+				leadershipKey,
+				corelease.Request{"redis/0", time.Minute},
+			},
+			callback: func(leases map[corelease.Key]corelease.Info) {
+				leases[leadershipKey] = corelease.Info{
+					Holder: "redis/0",
+					Expiry: offset(time.Minute),
+				}
+			},
+		}},
+		registerer: registry,
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		claimer, err := manager.Claimer(corelease.ApplicationLeadershipNamespace, "modelUUID")
+		c.Assert(err, jc.ErrorIsNil)
+		err = claimer.Claim("redis", "redis/0", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+
+		families, err := registry.Gather()
+		c.Assert(err, jc.ErrorIsNil)
+		var found bool
+		for _, family := range families {
+			if family.GetName() != "juju_lease_manager_leadership_changes_total" {
+				continue
+			}
+			c.Assert(family.GetMetric(), gc.HasLen, 1)
+			metric := family.GetMetric()[0]
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			c.Check(labels, jc.DeepEquals, map[string]string{
+				"model":       "modelUUID",
+				"application": "redis",
+			})
+			c.Check(metric.Counter.GetValue(), gc.Equals, float64(1))
+			found = true
+		}
+		c.Check(found, jc.IsTrue)
+	})
+}
+
 func getClaimer(c *gc.C, manager *lease.Manager) corelease.Claimer {
 	claimer, err := manager.Claimer("namespace", "modelUUID")
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,82 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace = "juju_lease_manager"
+)
+
+// metricsCollector is a prometheus.Collector that collects metrics
+// about the lease claims handled by the manager.
+type metricsCollector struct {
+	claims            *prometheus.CounterVec
+	claimTimes        *prometheus.SummaryVec
+	holderChanges     *prometheus.CounterVec
+	leadershipChanges *prometheus.CounterVec
+}
+
+func newMetricsCollector() *metricsCollector {
+	return &metricsCollector{
+		claims: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "claims_total",
+			Help:      "Number of lease claims handled by the lease manager",
+		}, []string{
+			"namespace", // the lease namespace, e.g. application-leadership
+			"operation", // claim or extend
+			"result",    // success, denied, invalid, timeout or error
+		}),
+		claimTimes: prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Namespace: metricsNamespace,
+			Name:      "claim_seconds",
+			Help:      "Time taken by the lease store to claim or extend a lease",
+			Objectives: map[float64]float64{
+				0.5:  0.05,
+				0.9:  0.01,
+				0.99: 0.001,
+			},
+		}, []string{
+			"namespace", // the lease namespace, e.g. application-leadership
+			"operation", // claim or extend
+		}),
+		holderChanges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "holder_changes_total",
+			Help:      "Number of times a lease was claimed afresh, e.g. when application leadership changes",
+		}, []string{
+			// The lease name is not a label, as there would be a
+			// series for every application in every model.
+			"namespace", // the lease namespace, e.g. application-leadership
+			"model",     // the UUID of the model the lease belongs to
+		}),
+		leadershipChanges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "leadership_changes_total",
+			Help:      "Number of times an application's leadership was claimed afresh",
+		}, []string{
+			"model",       // the UUID of the model the application belongs to
+			"application", // the application name
+		}),
+	}
+}
+
+// Describe is part of prometheus.Collector.
+func (c *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.claims.Describe(ch)
+	c.claimTimes.Describe(ch)
+	c.holderChanges.Describe(ch)
+	c.leadershipChanges.Describe(ch)
+}
+
+// Collect is part of prometheus.Collector.
+func (c *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.claims.Collect(ch)
+	c.claimTimes.Collect(ch)
+	c.holderChanges.Collect(ch)
+	c.leadershipChanges.Collect(ch)
+}
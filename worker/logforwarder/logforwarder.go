@@ -67,6 +67,10 @@ type OpenLogForwarderArgs struct {
 	// Name is the name given to the log sink.
 	Name string
 
+	// SinkType is the kind of log sink, used to select the matching
+	// log forwarding configuration.
+	SinkType string
+
 	// OpenSink is the function that opens the underlying log sink that
 	// will be wrapped.
 	OpenSink LogSinkFn
@@ -76,7 +80,7 @@ type OpenLogForwarderArgs struct {
 	OpenLogStream LogStreamFn
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
@@ -91,13 +95,20 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	}
 
 	// Get the new config and set up log forwarding if enabled.
-	cfg, ok, err := lf.args.LogForwardConfig.LogForwardConfig()
+	cfgs, err := lf.args.LogForwardConfig.LogForwardConfig()
 	if err != nil {
 		closeExisting()
 		return nil, errors.Trace(err)
 	}
-	if !ok || !cfg.Enabled {
-		logger.Infof("config change - log forwarding not enabled")
+	var cfg logfwd.SinkConfig
+	for _, candidate := range cfgs {
+		if candidate.SinkType() == lf.args.SinkType {
+			cfg = candidate
+			break
+		}
+	}
+	if cfg == nil || !cfg.IsEnabled() {
+		logger.Infof("config change - %s log forwarding not enabled", lf.args.SinkType)
 		return nil, closeExisting()
 	}
 	// If the config is not valid, we don't want to exit with an error
@@ -145,7 +156,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		logger.Infof("log forward enabled, starting to stream logs to %s sink", lf.args.SinkType)
 	}
 	lf.enabled = enabled
 	return enabled, nil
@@ -230,7 +241,7 @@ func (lf *LogForwarder) loop() error {
 			return lf.catacomb.ErrDying()
 		case _, ok := <-configWatcher.Changes():
 			if !ok {
-				return errors.New("syslog configuration watcher closed")
+				return errors.New("log forward configuration watcher closed")
 			}
 			if sender, err = lf.processNewConfig(sender); err != nil {
 				return errors.Trace(err)
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/logfwd/syslog"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/version"
@@ -85,8 +86,10 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 		Caller:           &mockCaller{},
 		LogForwardConfig: configAPI,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		Name:             "juju-log-forward",
+		SinkType:         syslog.SinkType,
+		OpenSink: func(cfg logfwd.SinkConfig) (*logforwarder.LogSink, error) {
+			sender.host = cfg.(*syslog.RawConfig).Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -165,6 +168,24 @@ func (s *LogForwarderSuite) TestNotEnabled(c *gc.C) {
 	s.sender.stub.CheckCallNames(c)
 }
 
+func (s *LogForwarderSuite) TestOtherSinkTypeOnly(c *gc.C) {
+	api := &mockLogForwardConfig{
+		enabled:  true,
+		host:     "10.0.0.1",
+		sinkType: httpjson.SinkType,
+	}
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+
+	time.Sleep(coretesting.ShortWait)
+	workertest.CleanKill(c, lf)
+
+	// Only the http sink is configured, so the syslog forwarder
+	// should stay idle.
+	s.stream.stub.CheckCallNames(c)
+	s.sender.stub.CheckCallNames(c)
+}
+
 func (s *LogForwarderSuite) TestStreamError(c *gc.C) {
 	failure := errors.New("<failure>")
 	s.stream.stub.SetErrors(nil, failure)
@@ -207,9 +228,10 @@ func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
 }
 
 type mockLogForwardConfig struct {
-	enabled bool
-	host    string
-	changes chan struct{}
+	enabled  bool
+	host     string
+	sinkType string
+	changes  chan struct{}
 }
 
 type mockWatcher struct {
@@ -248,14 +270,20 @@ func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.Notify
 	}, nil
 }
 
-func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
-	return &syslog.RawConfig{
+func (c *mockLogForwardConfig) LogForwardConfig() ([]logfwd.SinkConfig, error) {
+	if c.sinkType == httpjson.SinkType {
+		return []logfwd.SinkConfig{&httpjson.RawConfig{
+			Enabled: c.enabled,
+			URL:     "http://" + c.host,
+		}}, nil
+	}
+	return []logfwd.SinkConfig{&syslog.RawConfig{
 		Enabled:    c.enabled,
 		Host:       c.host,
 		CACert:     coretesting.CACert,
 		ClientCert: coretesting.ServerCert,
 		ClientKey:  coretesting.ServerKey,
-	}, true, nil
+	}}, nil
 }
 
 type stubStream struct {
@@ -5,7 +5,7 @@ package logforwarder
 
 import (
 	"github.com/juju/juju/core/watcher"
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd"
 )
 
 // LogForwardConfig provides access to the log forwarding config for a model.
@@ -14,20 +14,26 @@ type LogForwardConfig interface {
 	// log forward configuration to change.
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
-	// LogForwardConfig returns the current log forward configuration.
-	LogForwardConfig() (*syslog.RawConfig, bool, error)
+	// LogForwardConfig returns the current configuration of each log
+	// forwarding target that has been configured.
+	LogForwardConfig() ([]logfwd.SinkConfig, error)
 }
 
+// LogSinkSpec describes a log sink that log records may be forwarded to.
 type LogSinkSpec struct {
 	// Name is the name of the log sink.
 	Name string
 
+	// Type is the kind of sink, used to select the matching
+	// configuration (e.g. "syslog").
+	Type string
+
 	// OpenFn is a function that opens a log sink.
 	OpenFn LogSinkFn
 }
 
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg logfwd.SinkConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
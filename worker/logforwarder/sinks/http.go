@@ -0,0 +1,31 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenHTTP returns a sink that POSTs log messages to be forwarded
+// to an HTTP endpoint as newline-delimited JSON.
+func OpenHTTP(sinkCfg logfwd.SinkConfig) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*httpjson.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected http config, got %T", sinkCfg)
+	}
+	if !cfg.Enabled {
+		return nil, errors.New("http log forwarding not enabled")
+	}
+	client, err := httpjson.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
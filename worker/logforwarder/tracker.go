@@ -10,13 +10,12 @@ import (
 	"github.com/juju/juju/api/base"
 	logfwdapi "github.com/juju/juju/api/logfwd"
 	"github.com/juju/juju/logfwd"
-	"github.com/juju/juju/logfwd/syslog"
 )
 
 // TrackingSinkArgs holds the args to OpenTrackingSender.
 type TrackingSinkArgs struct {
 	// Config is the logging config that will be used.
-	Config *syslog.RawConfig
+	Config logfwd.SinkConfig
 
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
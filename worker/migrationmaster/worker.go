@@ -404,7 +404,7 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 	}
 	defer conn.Close()
 	targetClient := migrationtarget.NewClient(conn)
-	err = targetClient.Import(serialized.Bytes)
+	err = targetClient.Import(serialized.Bytes, serialized.Extras)
 	if err != nil {
 		return errors.Annotate(err, "failed to import model into target controller")
 	}
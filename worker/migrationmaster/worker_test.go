@@ -52,6 +52,7 @@ var _ = gc.Suite(&Suite{})
 
 var (
 	fakeModelBytes      = []byte("model")
+	fakeModelExtras     = []byte("extras")
 	targetControllerTag = names.NewControllerTag("controller-uuid")
 	modelUUID           = "model-uuid"
 	modelTag            = names.NewModelTag(modelUUID)
@@ -88,7 +89,7 @@ var (
 	importCall = jujutesting.StubCall{
 		"MigrationTarget.Import",
 		[]interface{}{
-			params.SerializedModel{Bytes: fakeModelBytes},
+			params.SerializedModel{Bytes: fakeModelBytes, Extras: fakeModelExtras},
 		},
 	}
 	activateCall = jujutesting.StubCall{
@@ -1190,6 +1191,7 @@ func (f *stubMasterFacade) Export() (coremigration.SerializedModel, error) {
 			version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
 		},
 		Resources: f.exportedResources,
+		Extras:    fakeModelExtras,
 	}, nil
 }
 
@@ -0,0 +1,13 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoteintrospection provides a worker that answers
+// introspection requests relayed to an agent through the controller.
+//
+// Model admins use the `juju introspect` command to query the same
+// endpoints that the introspection worker serves on the agent's local
+// abstract domain socket. Each query is recorded by the controller; this
+// worker watches for queries addressed to its agent, performs them
+// against the local socket, and records the response for the
+// controller to return to the client.
+package remoteintrospection
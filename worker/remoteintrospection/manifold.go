@@ -0,0 +1,79 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoteintrospection
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+)
+
+// ManifoldConfig describes the dependencies of the remote
+// introspection worker.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+
+	// SocketName returns the name of the abstract domain socket
+	// on which the agent with the given tag serves introspection
+	// requests.
+	SocketName func(names.Tag) string
+
+	Clock     clock.Clock
+	NewFacade func(base.APICaller) Facade
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate returns an error if the configuration is not complete.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.SocketName == nil {
+		return errors.NotValidf("nil SocketName")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// start is used by engine.AgentAPIManifold to create a StartFunc.
+func (config ManifoldConfig) start(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tag := a.CurrentConfig().Tag()
+	return config.NewWorker(Config{
+		Facade: config.NewFacade(apiCaller),
+		Agent:  tag,
+		Clock:  config.Clock,
+		Query:  SocketQuery(config.SocketName(tag)),
+	})
+}
+
+// Manifold returns a dependency.Manifold that runs the remote
+// introspection worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	typedConfig := engine.AgentAPIManifoldConfig{
+		AgentName:     config.AgentName,
+		APICallerName: config.APICallerName,
+	}
+	return engine.AgentAPIManifold(typedConfig, config.start)
+}
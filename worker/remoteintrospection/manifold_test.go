@@ -0,0 +1,100 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoteintrospection_test
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	dt "gopkg.in/juju/worker.v1/dependency/testing"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/remoteintrospection"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) manifoldConfig(newWorker func(remoteintrospection.Config) (worker.Worker, error)) remoteintrospection.ManifoldConfig {
+	return remoteintrospection.ManifoldConfig{
+		AgentName:     "agent",
+		APICallerName: "api-caller",
+		SocketName: func(tag names.Tag) string {
+			return "jujud-" + tag.String()
+		},
+		Clock: clock.WallClock,
+		NewFacade: func(base.APICaller) remoteintrospection.Facade {
+			return &mockFacade{}
+		},
+		NewWorker: newWorker,
+	}
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := remoteintrospection.Manifold(s.manifoldConfig(nil))
+	c.Assert(manifold.Inputs, jc.SameContents, []string{"agent", "api-caller"})
+}
+
+func (s *ManifoldSuite) TestStart(c *gc.C) {
+	var config remoteintrospection.Config
+	manifold := remoteintrospection.Manifold(s.manifoldConfig(
+		func(c remoteintrospection.Config) (worker.Worker, error) {
+			config = c
+			return workertest.NewErrorWorker(nil), nil
+		},
+	))
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      &fakeAgent{tag: names.NewUnitTag("mysql/0")},
+		"api-caller": &fakeCaller{},
+	})
+	w, err := manifold.Start(context)
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CleanKill(c, w)
+
+	c.Check(config.Agent, gc.Equals, names.NewUnitTag("mysql/0"))
+	c.Check(config.Facade, gc.NotNil)
+	c.Check(config.Clock, gc.Equals, clock.WallClock)
+	c.Check(config.Query, gc.NotNil)
+}
+
+func (s *ManifoldSuite) TestStartInvalidConfig(c *gc.C) {
+	config := s.manifoldConfig(nil)
+	manifold := remoteintrospection.Manifold(config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      &fakeAgent{tag: names.NewUnitTag("mysql/0")},
+		"api-caller": &fakeCaller{},
+	})
+	_, err := manifold.Start(context)
+	c.Assert(err, gc.ErrorMatches, "nil NewWorker not valid")
+}
+
+type fakeAgent struct {
+	agent.Agent
+	tag names.Tag
+}
+
+func (a *fakeAgent) CurrentConfig() agent.Config {
+	return &fakeConfig{tag: a.tag}
+}
+
+type fakeConfig struct {
+	agent.Config
+	tag names.Tag
+}
+
+func (c *fakeConfig) Tag() names.Tag {
+	return c.tag
+}
+
+type fakeCaller struct {
+	base.APICaller
+}
@@ -0,0 +1,15 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoteintrospection
+
+import (
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/remoteintrospection"
+)
+
+// NewFacade creates a Facade from a base.APICaller.
+// It's a sensible value for ManifoldConfig.NewFacade.
+func NewFacade(apiCaller base.APICaller) Facade {
+	return remoteintrospection.NewClient(apiCaller)
+}
@@ -0,0 +1,90 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoteintrospection
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+)
+
+// maxResponseSize limits the size of a relayed introspection response,
+// so that it can be stored in the controller database.
+const maxResponseSize = 4 * 1024 * 1024
+
+// allowedEndpoints holds the names of the introspection endpoints that
+// may be queried through the controller. The others, such as the
+// profiling endpoints, may take too long to respond or return data
+// that cannot be relayed.
+var allowedEndpoints = set.NewStrings(
+	"depengine",
+	"pubsub",
+	"presence",
+	"machinelock",
+	"metrics",
+)
+
+// SocketQuery returns a function that queries the introspection
+// endpoint served on the abstract domain socket with the given name.
+// Only the endpoints in allowedEndpoints may be queried.
+func SocketQuery(socketName string) func(ctx context.Context, path string) (string, error) {
+	client := &http.Client{
+		Transport: &http.Transport{
+			Dial: func(proto, addr string) (net.Conn, error) {
+				return net.Dial("unix", "@"+socketName)
+			},
+		},
+	}
+	return func(ctx context.Context, path string) (string, error) {
+		path, err := endpointPath(path)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		req, err := http.NewRequest("GET", "http://unix.socket"+path, nil)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		resp, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+		if err != nil {
+			return "", errors.Annotate(err, "reading response")
+		}
+		if resp.StatusCode != http.StatusOK {
+			return "", errors.Errorf(
+				"response returned %d (%s): %s",
+				resp.StatusCode,
+				http.StatusText(resp.StatusCode),
+				strings.TrimSpace(string(body)),
+			)
+		}
+		return string(body), nil
+	}
+}
+
+// endpointPath returns the path to request from the introspection
+// endpoint for the given path, which must name an allowed endpoint.
+func endpointPath(path string) (string, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", errors.NotValidf("introspection path %q", path)
+	}
+	name := strings.Trim(u.Path, "/")
+	if !allowedEndpoints.Contains(name) {
+		return "", errors.NotValidf("introspection path %q", path)
+	}
+	endpoint := &url.URL{Path: "/" + name, RawQuery: u.RawQuery}
+	return endpoint.String(), nil
+}
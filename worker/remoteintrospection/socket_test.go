@@ -0,0 +1,56 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoteintrospection_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/remoteintrospection"
+)
+
+type SocketSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SocketSuite{})
+
+func (s *SocketSuite) TestSocketQueryRejectsPaths(c *gc.C) {
+	query := remoteintrospection.SocketQuery("remoteintrospection-test-missing")
+	for _, path := range []string{
+		"",
+		"/",
+		"/debug/pprof/profile",
+		"/statepool",
+		"/depengine/../debug/pprof/heap",
+		"/metrics/extra",
+	} {
+		c.Logf("path %q", path)
+		_, err := query(context.Background(), path)
+		c.Check(err, gc.ErrorMatches, `introspection path ".*" not valid`)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *SocketSuite) TestSocketQueryAllowsPaths(c *gc.C) {
+	// Nothing is listening on the socket, so allowed paths
+	// fail to connect rather than being rejected.
+	query := remoteintrospection.SocketQuery("remoteintrospection-test-missing")
+	for _, path := range []string{
+		"/depengine",
+		"pubsub",
+		"/presence/",
+		"/machinelock/",
+		"/metrics/",
+	} {
+		c.Logf("path %q", path)
+		_, err := query(context.Background(), path)
+		c.Check(err, gc.NotNil)
+		c.Check(err, gc.Not(jc.Satisfies), errors.IsNotValid)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoteintrospection
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+var logger = loggo.GetLogger("juju.worker.remoteintrospection")
+
+// queryTimeout is how long to wait for the agent's introspection
+// endpoint to respond. It is shorter than the controller waits for
+// an answer, so that the requester is told why the query failed.
+const queryTimeout = 10 * time.Second
+
+// Facade defines the capabilities required by the worker from the API.
+type Facade interface {
+	WatchRequests(agent names.Tag) (watcher.StringsWatcher, error)
+	Request(id string) (params.IntrospectionRequest, error)
+	Complete(id, response, errMsg string) error
+}
+
+// Config defines the worker's dependencies.
+type Config struct {
+	Facade Facade
+	Agent  names.Tag
+	Clock  clock.Clock
+
+	// Query returns the response of the agent's introspection
+	// endpoint for the given path, giving up when the context
+	// is done.
+	Query func(ctx context.Context, path string) (string, error)
+}
+
+// Validate returns an error if the configuration is not complete.
+func (c Config) Validate() error {
+	if c.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if c.Agent == nil {
+		return errors.NotValidf("nil Agent")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if c.Query == nil {
+		return errors.NotValidf("nil Query")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that answers introspection requests made
+// of the agent through the controller, by querying the agent's own
+// introspection endpoint.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher.NewStringsWorker(watcher.StringsConfig{
+		Handler: &handler{config},
+	})
+}
+
+// handler implements watcher.StringsHandler.
+type handler struct {
+	config Config
+}
+
+// SetUp is part of the watcher.StringsHandler interface.
+func (h *handler) SetUp() (watcher.StringsWatcher, error) {
+	return h.config.Facade.WatchRequests(h.config.Agent)
+}
+
+// Handle is part of the watcher.StringsHandler interface.
+func (h *handler) Handle(abort <-chan struct{}, ids []string) error {
+	for _, id := range ids {
+		req, err := h.config.Facade.Request(id)
+		if params.IsCodeNotFound(err) {
+			// The requester has already given up.
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "getting introspection request %q", id)
+		}
+		if req.Completed {
+			continue
+		}
+
+		logger.Debugf("answering introspection request %q for %q", id, req.Path)
+		var errMsg string
+		response, err := h.query(abort, req.Path)
+		if err != nil {
+			errMsg = err.Error()
+		}
+		err = h.config.Facade.Complete(id, response, errMsg)
+		if params.IsCodeNotFound(err) {
+			logger.Debugf("introspection request %q removed before completion", id)
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "completing introspection request %q", id)
+		}
+	}
+	return nil
+}
+
+// query queries the agent's introspection endpoint for the given
+// path, giving up after queryTimeout or when the worker is stopped.
+func (h *handler) query(abort <-chan struct{}, path string) (string, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timedOut := make(chan struct{})
+	go func() {
+		select {
+		case <-h.config.Clock.After(queryTimeout):
+			close(timedOut)
+		case <-abort:
+		case <-ctx.Done():
+			return
+		}
+		cancel()
+	}()
+
+	response, err := h.config.Query(ctx, path)
+	if err == nil {
+		return response, nil
+	}
+	select {
+	case <-timedOut:
+		return "", errors.Timeoutf("querying %q", path)
+	default:
+		return "", errors.Trace(err)
+	}
+}
+
+// TearDown is part of the watcher.StringsHandler interface.
+func (h *handler) TearDown() error {
+	return nil
+}
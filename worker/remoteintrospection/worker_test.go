@@ -0,0 +1,189 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoteintrospection_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/remoteintrospection"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	stub    testing.Stub
+	facade  *mockFacade
+	changes chan []string
+	clock   *testclock.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = testing.Stub{}
+	s.changes = make(chan []string, 1)
+	s.clock = testclock.NewClock(time.Time{})
+	s.facade = &mockFacade{
+		stub:    &s.stub,
+		changes: s.changes,
+		requests: map[string]params.IntrospectionRequest{
+			"0#introspect#1": {Id: "0#introspect#1", Path: "/depengine"},
+			"0#introspect#2": {Id: "0#introspect#2", Path: "/pubsub", Completed: true},
+			"0#introspect#3": {Id: "0#introspect#3", Path: "/bogus"},
+		},
+		completed: make(chan struct{}, 10),
+	}
+}
+
+func (s *WorkerSuite) config() remoteintrospection.Config {
+	return remoteintrospection.Config{
+		Facade: s.facade,
+		Agent:  names.NewMachineTag("0"),
+		Clock:  s.clock,
+		Query: func(_ context.Context, path string) (string, error) {
+			s.stub.AddCall("Query", path)
+			if path == "/bogus" {
+				return "", errors.New("response returned 404 (Not Found)")
+			}
+			return "report for " + path, nil
+		},
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	_, err := remoteintrospection.NewWorker(config)
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.Agent = nil
+	_, err = remoteintrospection.NewWorker(config)
+	c.Check(err, gc.ErrorMatches, "nil Agent not valid")
+
+	config = s.config()
+	config.Clock = nil
+	_, err = remoteintrospection.NewWorker(config)
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config()
+	config.Query = nil
+	_, err = remoteintrospection.NewWorker(config)
+	c.Check(err, gc.ErrorMatches, "nil Query not valid")
+}
+
+func (s *WorkerSuite) TestAnswersRequests(c *gc.C) {
+	w, err := remoteintrospection.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- []string{"0#introspect#1", "0#introspect#2", "0#introspect#3", "0#introspect#4"}
+	s.waitForCompletions(c, 2)
+	workertest.CleanKill(c, w)
+
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"WatchRequests", []interface{}{names.NewMachineTag("0")}},
+		{"Request", []interface{}{"0#introspect#1"}},
+		{"Query", []interface{}{"/depengine"}},
+		{"Complete", []interface{}{"0#introspect#1", "report for /depengine", ""}},
+		{"Request", []interface{}{"0#introspect#2"}},
+		{"Request", []interface{}{"0#introspect#3"}},
+		{"Query", []interface{}{"/bogus"}},
+		{"Complete", []interface{}{"0#introspect#3", "", "response returned 404 (Not Found)"}},
+		{"Request", []interface{}{"0#introspect#4"}},
+	})
+}
+
+func (s *WorkerSuite) TestQueryTimeout(c *gc.C) {
+	config := s.config()
+	config.Query = func(ctx context.Context, path string) (string, error) {
+		s.stub.AddCall("Query", path)
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+	w, err := remoteintrospection.NewWorker(config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- []string{"0#introspect#1"}
+	err = s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForCompletions(c, 1)
+	workertest.CleanKill(c, w)
+
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"WatchRequests", []interface{}{names.NewMachineTag("0")}},
+		{"Request", []interface{}{"0#introspect#1"}},
+		{"Query", []interface{}{"/depengine"}},
+		{"Complete", []interface{}{"0#introspect#1", "", `querying "/depengine" timed out`}},
+	})
+}
+
+func (s *WorkerSuite) TestRequestError(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("boom"))
+	w, err := remoteintrospection.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.changes <- []string{"0#introspect#1"}
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, `getting introspection request "0#introspect#1": boom`)
+}
+
+func (s *WorkerSuite) waitForCompletions(c *gc.C, n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case <-s.facade.completed:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for request %d to be completed", i)
+		}
+	}
+}
+
+type mockFacade struct {
+	stub      *testing.Stub
+	changes   chan []string
+	requests  map[string]params.IntrospectionRequest
+	completed chan struct{}
+}
+
+func (f *mockFacade) WatchRequests(agent names.Tag) (watcher.StringsWatcher, error) {
+	f.stub.AddCall("WatchRequests", agent)
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return watchertest.NewMockStringsWatcher(f.changes), nil
+}
+
+func (f *mockFacade) Request(id string) (params.IntrospectionRequest, error) {
+	f.stub.AddCall("Request", id)
+	if err := f.stub.NextErr(); err != nil {
+		return params.IntrospectionRequest{}, err
+	}
+	req, ok := f.requests[id]
+	if !ok {
+		return params.IntrospectionRequest{}, &params.Error{Code: params.CodeNotFound}
+	}
+	return req, nil
+}
+
+func (f *mockFacade) Complete(id, response, errMsg string) error {
+	f.stub.AddCall("Complete", id, response, errMsg)
+	f.completed <- struct{}{}
+	return f.stub.NextErr()
+}
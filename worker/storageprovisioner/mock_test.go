@@ -24,6 +24,7 @@ import (
 
 const needsInstanceVolumeId = "23"
 const noAttachmentVolumeId = "66"
+const restoredVolumeId = "77"
 
 var (
 	releasingVolumeId     = "2"
@@ -232,6 +233,9 @@ func (v *mockVolumeAccessor) VolumeParams(volumes []names.VolumeTag) ([]params.V
 				"very": "fancy",
 			},
 		}
+		if tag.Id() == restoredVolumeId {
+			volumeParams.SnapshotId = "snap-" + restoredVolumeId
+		}
 		if tag.Id() != noAttachmentVolumeId {
 			volumeParams.Attachment = &params.VolumeAttachmentParams{
 				VolumeTag:  tag.String(),
@@ -556,10 +560,12 @@ type dummyProvider struct {
 	releaseFilesystemsFunc       func([]string) ([]error, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
+	restoreVolumeSnapshotsFunc   func([]storage.VolumeParams) ([]storage.CreateVolumesResult, error)
 }
 
 type dummyVolumeSource struct {
 	storage.VolumeSource
+	storage.VolumeSnapshotter
 	provider          *dummyProvider
 	createVolumesArgs [][]storage.VolumeParams
 }
@@ -621,6 +627,14 @@ func (s *dummyVolumeSource) CreateVolumes(ctx context.ProviderCallContext, param
 	return results, nil
 }
 
+// RestoreVolumeSnapshots restores volumes from snapshots.
+func (s *dummyVolumeSource) RestoreVolumeSnapshots(ctx context.ProviderCallContext, params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	if s.provider != nil && s.provider.restoreVolumeSnapshotsFunc != nil {
+		return s.provider.restoreVolumeSnapshotsFunc(params)
+	}
+	return nil, errors.NotImplementedf("RestoreVolumeSnapshots")
+}
+
 // DestroyVolumes destroys volumes.
 func (s *dummyVolumeSource) DestroyVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]error, error) {
 	if s.provider.destroyVolumesFunc != nil {
@@ -211,6 +211,54 @@ func (s *storageProvisionerSuite) TestCreateVolumeCreatesAttachment(c *gc.C) {
 	assertNoEvent(c, attachVolumesCalled, "AttachVolumes called")
 }
 
+func (s *storageProvisionerSuite) TestCreateVolumeFromSnapshot(c *gc.C) {
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+			VolumeTag: "volume-77",
+			Info: params.VolumeInfo{
+				VolumeId: "id-77",
+				Size:     1024,
+			},
+		}})
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	s.provider.createVolumesFunc = func(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+		return nil, errors.New("should not be called")
+	}
+	var restoreArgs []storage.VolumeParams
+	s.provider.restoreVolumeSnapshotsFunc = func(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+		restoreArgs = args
+		return []storage.CreateVolumesResult{{
+			Volume: &storage.Volume{
+				Tag: args[0].Tag,
+				VolumeInfo: storage.VolumeInfo{
+					VolumeId: "id-77",
+					Size:     1024,
+				},
+			},
+		}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag: "machine-1", AttachmentTag: "volume-77",
+	}}
+	volumeAccessor.volumesWatcher.changes <- []string{"77"}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(restoreArgs, gc.HasLen, 1)
+	c.Assert(restoreArgs[0].Tag, gc.Equals, names.NewVolumeTag("77"))
+	c.Assert(restoreArgs[0].SnapshotId, gc.Equals, "snap-77")
+}
+
 func (s *storageProvisionerSuite) TestCreateVolumeRetry(c *gc.C) {
 	volumeInfoSet := make(chan interface{})
 	volumeAccessor := newMockVolumeAccessor()
@@ -51,7 +51,8 @@ func createVolumes(ctx *context, ops map[names.VolumeTag]*createVolumeOp) error
 		if len(volumeParams) == 0 {
 			continue
 		}
-		results, err := volumeSource.CreateVolumes(ctx.config.CloudCallContext, volumeParams)
+		var results []storage.CreateVolumesResult
+		volumeParams, results, err = createOrRestoreVolumes(ctx, volumeSource, volumeParams)
 		if err != nil {
 			return errors.Annotatef(err, "creating volumes from source %q", sourceName)
 		}
@@ -122,6 +123,45 @@ func createVolumes(ctx *context, ops map[names.VolumeTag]*createVolumeOp) error
 	return nil
 }
 
+// createOrRestoreVolumes creates volumes with the specified parameters
+// using the given volume source. Volumes with a snapshot ID are restored
+// from that snapshot, which requires the volume source to implement
+// storage.VolumeSnapshotter. The parameters are returned reordered to
+// correspond with the results.
+func createOrRestoreVolumes(
+	ctx *context,
+	volumeSource storage.VolumeSource,
+	volumeParams []storage.VolumeParams,
+) ([]storage.VolumeParams, []storage.CreateVolumesResult, error) {
+	var createParams, restoreParams []storage.VolumeParams
+	for _, p := range volumeParams {
+		if p.SnapshotId != "" {
+			restoreParams = append(restoreParams, p)
+		} else {
+			createParams = append(createParams, p)
+		}
+	}
+	var results []storage.CreateVolumesResult
+	if len(createParams) > 0 {
+		createResults, err := volumeSource.CreateVolumes(ctx.config.CloudCallContext, createParams)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		results = append(results, createResults...)
+	}
+	if len(restoreParams) > 0 {
+		// validateVolumeParams ensures that the source
+		// is a snapshotter if there are any restore params.
+		snapshotter := volumeSource.(storage.VolumeSnapshotter)
+		restoreResults, err := snapshotter.RestoreVolumeSnapshots(ctx.config.CloudCallContext, restoreParams)
+		if err != nil {
+			return nil, nil, errors.Annotate(err, "restoring volume snapshots")
+		}
+		results = append(results, restoreResults...)
+	}
+	return append(createParams, restoreParams...), results, nil
+}
+
 // attachVolumes creates volume attachments with the specified parameters.
 func attachVolumes(ctx *context, ops map[params.MachineStorageId]*attachVolumeOp) error {
 	volumeAttachmentParams := make([]storage.VolumeAttachmentParams, 0, len(ops))
@@ -460,8 +500,14 @@ func validateVolumeParams(
 ) ([]storage.VolumeParams, []error) {
 	valid := make([]storage.VolumeParams, 0, len(volumeParams))
 	results := make([]error, len(volumeParams))
+	_, canRestore := volumeSource.(storage.VolumeSnapshotter)
 	for i, params := range volumeParams {
-		err := volumeSource.ValidateVolumeParams(params)
+		var err error
+		if params.SnapshotId != "" && !canRestore {
+			err = errors.NotSupportedf("restoring volumes from snapshots with storage provider %q", params.Provider)
+		} else {
+			err = volumeSource.ValidateVolumeParams(params)
+		}
 		if err == nil {
 			valid = append(valid, params)
 		}
@@ -96,4 +96,5 @@ type StorageSnapshot struct {
 	Life     params.Life
 	Attached bool
 	Location string
+	Size     uint64
 }
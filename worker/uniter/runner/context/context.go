@@ -7,6 +7,7 @@ package context
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
@@ -187,7 +188,7 @@ type HookContext struct {
 	// machinePorts contains cached information about all opened port
 	// ranges on the unit's assigned machine, mapped to the unit that
 	// opened each range and the relevant relation.
-	machinePorts map[network.PortRange]params.RelationUnit
+	machinePorts map[network.PortRange]params.MachinePortRange
 
 	// assignedMachineTag contains the tag of the unit's assigned
 	// machine.
@@ -220,6 +221,12 @@ type HookContext struct {
 	// hook run, so the actual add will happen in a flush.
 	storageAddConstraints map[string][]params.StorageConstraints
 
+	// charmState holds the key/value state stored by the unit's charm.
+	// It is read lazily, and written back on successful hook run if
+	// charmStateDirty is true.
+	charmState      map[string]string
+	charmStateDirty bool
+
 	// clock is used for any time operations.
 	clock Clock
 
@@ -460,17 +467,17 @@ func (ctx *HookContext) AddUnitStorage(cons map[string]params.StorageConstraints
 	return nil
 }
 
-func (ctx *HookContext) OpenPorts(protocol string, fromPort, toPort int) error {
+func (ctx *HookContext) OpenPorts(endpoint, protocol string, fromPort, toPort int) error {
 	return tryOpenPorts(
-		protocol, fromPort, toPort,
+		endpoint, protocol, fromPort, toPort,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
 }
 
-func (ctx *HookContext) ClosePorts(protocol string, fromPort, toPort int) error {
+func (ctx *HookContext) ClosePorts(endpoint, protocol string, fromPort, toPort int) error {
 	return tryClosePorts(
-		protocol, fromPort, toPort,
+		endpoint, protocol, fromPort, toPort,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
@@ -478,8 +485,8 @@ func (ctx *HookContext) ClosePorts(protocol string, fromPort, toPort int) error
 
 func (ctx *HookContext) OpenedPorts() []network.PortRange {
 	var unitRanges []network.PortRange
-	for portRange, relUnit := range ctx.machinePorts {
-		if relUnit.Unit == ctx.unit.Tag().String() {
+	for portRange, machinePort := range ctx.machinePorts {
+		if machinePort.UnitTag == ctx.unit.Tag().String() {
 			unitRanges = append(unitRanges, portRange)
 		}
 	}
@@ -535,6 +542,60 @@ func (ctx *HookContext) SetPodSpec(specYaml string) error {
 	return errors.Trace(err)
 }
 
+// CharmState returns a copy of the key/value state stored by the unit's
+// charm, including any changes made during the current hook.
+func (ctx *HookContext) CharmState() (map[string]string, error) {
+	if err := ctx.ensureCharmState(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string, len(ctx.charmState))
+	for k, v := range ctx.charmState {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// SetCharmStateValue sets the value of a key in the unit's charm state.
+func (ctx *HookContext) SetCharmStateValue(key, value string) error {
+	if err := ctx.ensureCharmState(); err != nil {
+		return errors.Trace(err)
+	}
+	if old, ok := ctx.charmState[key]; ok && old == value {
+		return nil
+	}
+	ctx.charmState[key] = value
+	ctx.charmStateDirty = true
+	return nil
+}
+
+// DeleteCharmStateValue removes a key from the unit's charm state.
+func (ctx *HookContext) DeleteCharmStateValue(key string) error {
+	if err := ctx.ensureCharmState(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := ctx.charmState[key]; !ok {
+		return nil
+	}
+	delete(ctx.charmState, key)
+	ctx.charmStateDirty = true
+	return nil
+}
+
+func (ctx *HookContext) ensureCharmState() error {
+	if ctx.charmState != nil {
+		return nil
+	}
+	state, err := ctx.unit.State()
+	if err != nil {
+		return errors.Annotate(err, "cannot read charm state")
+	}
+	if state == nil {
+		state = make(map[string]string)
+	}
+	ctx.charmState = state
+	return nil
+}
+
 // CloudSpec return the cloud specification for the running unit's model
 func (ctx *HookContext) CloudSpec() (*params.CloudSpec, error) {
 	var err error
@@ -570,6 +631,16 @@ func (ctx *HookContext) SetActionMessage(message string) error {
 	return nil
 }
 
+// LogActionMessage records a progress message for the running action.
+// The message is sent to the controller immediately, so that it can be
+// watched while the action is still running.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.state.LogActionMessage(ctx.actionData.Tag, message)
+}
+
 // SetActionFailed sets the fail state of the action.
 func (ctx *HookContext) SetActionFailed() error {
 	if ctx.actionData == nil {
@@ -749,71 +820,137 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		defer ctx.handleReboot(&err)
 	}
 
+	if writeChanges {
+		if err := ctx.writeChanges(process); err != nil {
+			ctxErr = err
+		}
+	}
+
+	return ctxErr
+}
+
+// writeChanges commits the changes made by the hook, so that either
+// all or none of them are made. If the controller is too old to commit
+// them together, they are written separately instead.
+func (ctx *HookContext) writeChanges(process string) error {
+	var changes params.CommitHookChangesArg
+	for _, rctx := range ctx.relations {
+		if settings := rctx.pendingSettings(); settings != nil {
+			changes.RelationSettings = append(changes.RelationSettings, *settings)
+		}
+	}
+	for rangeKey, rangeInfo := range ctx.pendingPorts {
+		ports := params.EntityPortRange{
+			Tag:      ctx.unit.Tag().String(),
+			Protocol: rangeKey.Ports.Protocol,
+			FromPort: rangeKey.Ports.FromPort,
+			ToPort:   rangeKey.Ports.ToPort,
+			Endpoint: rangeKey.Endpoint,
+		}
+		if rangeInfo.ShouldOpen {
+			changes.OpenPorts = append(changes.OpenPorts, ports)
+		} else {
+			changes.ClosePorts = append(changes.ClosePorts, ports)
+		}
+	}
+	for storage, cons := range ctx.storageAddConstraints {
+		for _, one := range cons {
+			changes.AddStorage = append(changes.AddStorage, params.StorageAddParams{
+				UnitTag:     ctx.unit.Tag().String(),
+				StorageName: storage,
+				Constraints: one,
+			})
+		}
+	}
+	if ctx.charmStateDirty {
+		changes.State = &ctx.charmState
+	}
+
+	if reflect.DeepEqual(changes, params.CommitHookChangesArg{}) {
+		return nil
+	}
+
+	err := ctx.unit.CommitHookChanges(changes)
+	if errors.IsNotImplemented(err) {
+		return ctx.writeChangesSeparately(process)
+	}
+	if err != nil {
+		err = errors.Annotatef(err, "cannot commit changes from %q", process)
+		logger.Errorf("%v", err)
+	}
+	return err
+}
+
+// writeChangesSeparately writes the changes made by the hook with one
+// API call for each kind of change, returning the first error.
+func (ctx *HookContext) writeChangesSeparately(process string) error {
+	var firstErr error
 	for id, rctx := range ctx.relations {
-		if writeChanges {
-			if e := rctx.WriteSettings(); e != nil {
-				e = errors.Errorf(
-					"could not write settings from %q to relation %d: %v",
-					process, id, e,
-				)
-				logger.Errorf("%v", e)
-				if ctxErr == nil {
-					ctxErr = e
-				}
+		if e := rctx.WriteSettings(); e != nil {
+			e = errors.Errorf(
+				"could not write settings from %q to relation %d: %v",
+				process, id, e,
+			)
+			logger.Errorf("%v", e)
+			if firstErr == nil {
+				firstErr = e
 			}
 		}
 	}
 
 	for rangeKey, rangeInfo := range ctx.pendingPorts {
-		if writeChanges {
-			var e error
-			var op string
-			if rangeInfo.ShouldOpen {
-				e = ctx.unit.OpenPorts(
-					rangeKey.Ports.Protocol,
-					rangeKey.Ports.FromPort,
-					rangeKey.Ports.ToPort,
-				)
-				op = "open"
-			} else {
-				e = ctx.unit.ClosePorts(
-					rangeKey.Ports.Protocol,
-					rangeKey.Ports.FromPort,
-					rangeKey.Ports.ToPort,
-				)
-				op = "close"
-			}
-			if e != nil {
-				e = errors.Annotatef(e, "cannot %s %v", op, rangeKey.Ports)
-				logger.Errorf("%v", e)
-				if ctxErr == nil {
-					ctxErr = e
-				}
+		var e error
+		var op string
+		if rangeInfo.ShouldOpen {
+			e = ctx.unit.OpenPorts(
+				rangeKey.Endpoint,
+				rangeKey.Ports.Protocol,
+				rangeKey.Ports.FromPort,
+				rangeKey.Ports.ToPort,
+			)
+			op = "open"
+		} else {
+			e = ctx.unit.ClosePorts(
+				rangeKey.Endpoint,
+				rangeKey.Ports.Protocol,
+				rangeKey.Ports.FromPort,
+				rangeKey.Ports.ToPort,
+			)
+			op = "close"
+		}
+		if e != nil {
+			e = errors.Annotatef(e, "cannot %s %v", op, rangeKey.Ports)
+			logger.Errorf("%v", e)
+			if firstErr == nil {
+				firstErr = e
 			}
 		}
 	}
 
 	// add storage to unit dynamically
-	if len(ctx.storageAddConstraints) > 0 && writeChanges {
+	if len(ctx.storageAddConstraints) > 0 {
 		err := ctx.unit.AddStorage(ctx.storageAddConstraints)
 		if err != nil {
 			err = errors.Annotatef(err, "cannot add storage")
 			logger.Errorf("%v", err)
-			if ctxErr == nil {
-				ctxErr = err
+			if firstErr == nil {
+				firstErr = err
 			}
 		}
 	}
 
-	// TODO (tasdomas) 2014 09 03: context finalization needs to modified to apply all
-	//                             changes in one api call to minimize the risk
-	//                             of partial failures.
-
-	if !writeChanges {
-		return ctxErr
+	// write any changes to the charm's state
+	if ctx.charmStateDirty {
+		err := ctx.unit.SetState(ctx.charmState)
+		if err != nil {
+			err = errors.Annotatef(err, "cannot write charm state")
+			logger.Errorf("%v", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
 	}
-
-	return ctxErr
+	return firstErr
 }
 
 // finalizeAction passes back the final status of an Action hook to state.
@@ -122,31 +122,31 @@ func (s *FlushContextSuite) TestRunHookOpensAndClosesPendingPorts(c *gc.C) {
 	ctx := s.context(c)
 
 	// Try opening some ports via the context.
-	err = ctx.OpenPorts("tcp", 100, 200)
+	err = ctx.OpenPorts("", "tcp", 100, 200)
 	c.Assert(err, jc.ErrorIsNil) // duplicates are ignored
-	err = ctx.OpenPorts("udp", 200, 300)
+	err = ctx.OpenPorts("", "udp", 200, 300)
 	c.Assert(err, gc.ErrorMatches, `cannot open 200-300/udp \(unit "u/0"\): conflicts with existing 200-300/udp \(unit "u/1"\)`)
-	err = ctx.OpenPorts("udp", 100, 200)
+	err = ctx.OpenPorts("", "udp", 100, 200)
 	c.Assert(err, gc.ErrorMatches, `cannot open 100-200/udp \(unit "u/0"\): conflicts with existing 200-300/udp \(unit "u/1"\)`)
-	err = ctx.OpenPorts("udp", 10, 20)
+	err = ctx.OpenPorts("", "udp", 10, 20)
 	c.Assert(err, jc.ErrorIsNil)
-	err = ctx.OpenPorts("tcp", 50, 100)
+	err = ctx.OpenPorts("", "tcp", 50, 100)
 	c.Assert(err, gc.ErrorMatches, `cannot open 50-100/tcp \(unit "u/0"\): conflicts with existing 100-200/tcp \(unit "u/0"\)`)
-	err = ctx.OpenPorts("tcp", 50, 80)
+	err = ctx.OpenPorts("", "tcp", 50, 80)
 	c.Assert(err, jc.ErrorIsNil)
-	err = ctx.OpenPorts("tcp", 40, 90)
+	err = ctx.OpenPorts("", "tcp", 40, 90)
 	c.Assert(err, gc.ErrorMatches, `cannot open 40-90/tcp \(unit "u/0"\): conflicts with 50-80/tcp requested earlier`)
 
 	// Now try closing some ports as well.
-	err = ctx.ClosePorts("udp", 8080, 8088)
+	err = ctx.ClosePorts("", "udp", 8080, 8088)
 	c.Assert(err, jc.ErrorIsNil) // not existing -> ignored
-	err = ctx.ClosePorts("tcp", 100, 200)
+	err = ctx.ClosePorts("", "tcp", 100, 200)
 	c.Assert(err, jc.ErrorIsNil)
-	err = ctx.ClosePorts("tcp", 100, 200)
+	err = ctx.ClosePorts("", "tcp", 100, 200)
 	c.Assert(err, jc.ErrorIsNil) // duplicates are ignored
-	err = ctx.ClosePorts("udp", 200, 300)
+	err = ctx.ClosePorts("", "udp", 200, 300)
 	c.Assert(err, gc.ErrorMatches, `cannot close 200-300/udp \(opened by "u/1"\) from "u/0"`)
-	err = ctx.ClosePorts("tcp", 50, 80)
+	err = ctx.ClosePorts("", "tcp", 50, 80)
 	c.Assert(err, jc.ErrorIsNil) // still pending -> no longer pending
 
 	// Ensure the ports are not actually changed on the unit yet.
@@ -212,6 +212,72 @@ func (s *FlushContextSuite) TestRunHookAddUnitStorageOnSuccess(c *gc.C) {
 	c.Assert(all, gc.HasLen, 0)
 }
 
+func (s *FlushContextSuite) TestRunHookCharmStateOnFailure(c *gc.C) {
+	ctx := s.context(c)
+	err := ctx.SetCharmStateValue("foo", "bar")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Flush the context with an error.
+	err = ctx.Flush("some badge", errors.New("blam pow"))
+	c.Assert(err, gc.ErrorMatches, "blam pow")
+
+	// Check that the state has not been written.
+	charmState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+}
+
+func (s *FlushContextSuite) TestRunHookCharmStateOnSuccess(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"foo": "bar", "baz": "qux"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := s.context(c)
+	err = ctx.SetCharmStateValue("foo", "quux")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeleteCharmStateValue("baz")
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err := ctx.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "quux"})
+
+	// Flush the context with a success.
+	err = ctx.Flush("success", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Check that the state has been written.
+	charmState, err = s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "quux"})
+}
+
+func (s *FlushContextSuite) TestRunHookNoChangesMadeOnPartialFailure(c *gc.C) {
+	ctx := s.context(c)
+	err := ctx.SetCharmStateValue("foo", "bar")
+	c.Assert(err, jc.ErrorIsNil)
+	relCtx, err := ctx.Relation(0)
+	c.Assert(err, jc.ErrorIsNil)
+	node, err := relCtx.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	node.Set("baz", "3")
+	size := uint64(1)
+	ctx.AddUnitStorage(
+		map[string]params.StorageConstraints{
+			"allecto": {Size: &size},
+		})
+
+	// Flush the context with a success; adding the storage fails.
+	err = ctx.Flush("success", nil)
+	c.Assert(errors.Cause(err), gc.ErrorMatches, `.*storage "allecto" not found.*`)
+
+	// Check that none of the other changes have been written.
+	charmState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+	settings, err := s.relunits[0].ReadSettings("u/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"relation-name": "db0"})
+}
+
 func (s *HookContextSuite) context(c *gc.C) *context.HookContext {
 	uuid, err := utils.NewUUID()
 	c.Assert(err, jc.ErrorIsNil)
@@ -21,11 +21,13 @@ type PortRangeInfo struct {
 	RelationTag names.RelationTag
 }
 
-// PortRange contains a port range and a relation id. Used as key to
+// PortRange contains a port range, a relation id and the endpoint the
+// range applies to, which is empty for all endpoints. Used as key to
 // pendingRelations and is only exported for testing.
 type PortRange struct {
 	Ports      network.PortRange
 	RelationId int
+	Endpoint   string
 }
 
 func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange, error) {
@@ -41,11 +43,26 @@ func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange
 	return newRange, nil
 }
 
+// openedForEndpoint reports whether the machine port range was opened
+// for the given endpoint, or for all endpoints if it is empty.
+func openedForEndpoint(machinePort params.MachinePortRange, endpoint string) bool {
+	if len(machinePort.Endpoints) == 0 {
+		// The range is open for all endpoints.
+		return true
+	}
+	for _, opened := range machinePort.Endpoints {
+		if endpoint != "" && opened == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
 func tryOpenPorts(
-	protocol string,
+	endpoint, protocol string,
 	fromPort, toPort int,
 	unitTag names.UnitTag,
-	machinePorts map[network.PortRange]params.RelationUnit,
+	machinePorts map[network.PortRange]params.MachinePortRange,
 	pendingPorts map[PortRange]PortRangeInfo,
 ) error {
 	// TODO(dimitern) Once port ranges are linked to relations in
@@ -61,6 +78,7 @@ func tryOpenPorts(
 	rangeKey := PortRange{
 		Ports:      newRange,
 		RelationId: relationId,
+		Endpoint:   endpoint,
 	}
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
@@ -76,8 +94,8 @@ func tryOpenPorts(
 
 	// Ensure there are no conflicts with existing ports on the
 	// machine.
-	for portRange, relUnit := range machinePorts {
-		relUnitTag, err := names.ParseUnitTag(relUnit.Unit)
+	for portRange, machinePort := range machinePorts {
+		relUnitTag, err := names.ParseUnitTag(machinePort.UnitTag)
 		if err != nil {
 			return errors.Annotatef(
 				err,
@@ -88,8 +106,12 @@ func tryOpenPorts(
 		if newRange.ConflictsWith(portRange) {
 			if portRange == newRange && relUnitTag == unitTag {
 				// The same unit trying to open the same range is just
-				// ignored.
-				return nil
+				// ignored, unless the range isn't yet open for the
+				// endpoint.
+				if openedForEndpoint(machinePort, endpoint) {
+					return nil
+				}
+				continue
 			}
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with existing %v (unit %q)",
@@ -99,6 +121,10 @@ func tryOpenPorts(
 	}
 	// Ensure other pending port ranges do not conflict with this one.
 	for rangeKey, rangeInfo := range pendingPorts {
+		if rangeKey.Ports == newRange {
+			// The same range may be opened for several endpoints.
+			continue
+		}
 		if newRange.ConflictsWith(rangeKey.Ports) && rangeInfo.ShouldOpen {
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with %v requested earlier",
@@ -114,10 +140,10 @@ func tryOpenPorts(
 }
 
 func tryClosePorts(
-	protocol string,
+	endpoint, protocol string,
 	fromPort, toPort int,
 	unitTag names.UnitTag,
-	machinePorts map[network.PortRange]params.RelationUnit,
+	machinePorts map[network.PortRange]params.MachinePortRange,
 	pendingPorts map[PortRange]PortRangeInfo,
 ) error {
 	// TODO(dimitern) Once port ranges are linked to relations in
@@ -133,6 +159,7 @@ func tryClosePorts(
 	rangeKey := PortRange{
 		Ports:      newRange,
 		RelationId: relationId,
+		Endpoint:   endpoint,
 	}
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
@@ -147,12 +174,12 @@ func tryClosePorts(
 
 	// Ensure the range we're trying to close is opened on the
 	// machine.
-	relUnit, found := machinePorts[newRange]
+	machinePort, found := machinePorts[newRange]
 	if !found {
 		// Trying to close a range which is not open is ignored.
 		return nil
-	} else if relUnit.Unit != unitTag.String() {
-		relUnitTag, err := names.ParseUnitTag(relUnit.Unit)
+	} else if machinePort.UnitTag != unitTag.String() {
+		relUnitTag, err := names.ParseUnitTag(machinePort.UnitTag)
 		if err != nil {
 			return errors.Annotatef(
 				err,
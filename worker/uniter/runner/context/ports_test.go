@@ -94,9 +94,9 @@ func (s *PortsSuite) TestValidatePortRange(c *gc.C) {
 }
 
 func makeMachinePorts(
-	unitName, proto string, fromPort, toPort int,
-) map[network.PortRange]params.RelationUnit {
-	result := make(map[network.PortRange]params.RelationUnit)
+	unitName, proto string, fromPort, toPort int, endpoints ...string,
+) map[network.PortRange]params.MachinePortRange {
+	result := make(map[network.PortRange]params.MachinePortRange)
 	portRange := network.PortRange{
 		FromPort: fromPort,
 		ToPort:   toPort,
@@ -108,14 +108,22 @@ func makeMachinePorts(
 	} else {
 		unitTag = unitName
 	}
-	result[portRange] = params.RelationUnit{
-		Unit: unitTag,
+	result[portRange] = params.MachinePortRange{
+		UnitTag:   unitTag,
+		PortRange: params.FromNetworkPortRange(portRange),
+		Endpoints: endpoints,
 	}
 	return result
 }
 
 func makePendingPorts(
 	proto string, fromPort, toPort int, shouldOpen bool,
+) map[context.PortRange]context.PortRangeInfo {
+	return makeEndpointPendingPorts("", proto, fromPort, toPort, shouldOpen)
+}
+
+func makeEndpointPendingPorts(
+	endpoint, proto string, fromPort, toPort int, shouldOpen bool,
 ) map[context.PortRange]context.PortRangeInfo {
 	result := make(map[context.PortRange]context.PortRangeInfo)
 	portRange := network.PortRange{
@@ -126,6 +134,7 @@ func makePendingPorts(
 	key := context.PortRange{
 		Ports:      portRange,
 		RelationId: -1,
+		Endpoint:   endpoint,
 	}
 	result[key] = context.PortRangeInfo{
 		ShouldOpen: shouldOpen,
@@ -135,9 +144,10 @@ func makePendingPorts(
 
 type portsTest struct {
 	about         string
+	endpoint      string
 	proto         string
 	ports         []int
-	machinePorts  map[network.PortRange]params.RelationUnit
+	machinePorts  map[network.PortRange]params.MachinePortRange
 	pendingPorts  map[context.PortRange]context.PortRangeInfo
 	expectErr     string
 	expectPending map[context.PortRange]context.PortRangeInfo
@@ -196,12 +206,35 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 		about:        "try opening a range conflicting with another pending range",
 		pendingPorts: makePendingPorts("tcp", 5, 25, true),
 		expectErr:    `cannot open 10-20/tcp \(unit "u/0"\): conflicts with 5-25/tcp requested earlier`,
+	}, {
+		about:         "open an existing range for an endpoint (ignored)",
+		endpoint:      "website",
+		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
+		expectPending: map[context.PortRange]context.PortRangeInfo{},
+	}, {
+		about:         "open an existing range for another endpoint",
+		endpoint:      "admin",
+		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20, "website"),
+		expectPending: makeEndpointPendingPorts("admin", "tcp", 10, 20, true),
+	}, {
+		about:         "open an existing endpoint range for all endpoints",
+		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20, "website"),
+		expectPending: makePendingPorts("tcp", 10, 20, true),
+	}, {
+		about:        "open a range pending to be opened for another endpoint",
+		endpoint:     "admin",
+		pendingPorts: makeEndpointPendingPorts("website", "tcp", 10, 20, true),
+		expectPending: map[context.PortRange]context.PortRangeInfo{
+			{Ports: network.PortRange{FromPort: 10, ToPort: 20, Protocol: "tcp"}, RelationId: -1, Endpoint: "website"}: {ShouldOpen: true},
+			{Ports: network.PortRange{FromPort: 10, ToPort: 20, Protocol: "tcp"}, RelationId: -1, Endpoint: "admin"}:   {ShouldOpen: true},
+		},
 	}}
 	for i, test := range tests {
 		c.Logf("test %d: %s", i, test.about)
 
 		test = test.withDefaults("tcp", 10, 20)
 		err := context.TryOpenPorts(
+			test.endpoint,
 			test.proto,
 			test.ports[0],
 			test.ports[1],
@@ -250,12 +283,18 @@ func (s *PortsSuite) TestTryClosePorts(c *gc.C) {
 		about:        "try closing a range of another unit",
 		machinePorts: makeMachinePorts("u/1", "tcp", 10, 20),
 		expectErr:    `cannot close 10-20/tcp \(opened by "u/1"\) from "u/0"`,
+	}, {
+		about:         "close an existing range for an endpoint",
+		endpoint:      "website",
+		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20, "website"),
+		expectPending: makeEndpointPendingPorts("website", "tcp", 10, 20, false),
 	}}
 	for i, test := range tests {
 		c.Logf("test %d: %s", i, test.about)
 
 		test = test.withDefaults("tcp", 10, 20)
 		err := context.TryClosePorts(
+			test.endpoint,
 			test.proto,
 			test.ports[0],
 			test.ports[1],
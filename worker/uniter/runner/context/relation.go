@@ -80,6 +80,16 @@ func (ctx *ContextRelation) WriteSettings() (err error) {
 	return
 }
 
+// pendingSettings returns the changes made to the unit's relation
+// settings, or nil if they have not been accessed.
+func (ctx *ContextRelation) pendingSettings() *params.RelationUnitSettings {
+	if ctx.settings == nil {
+		return nil
+	}
+	settings := ctx.settings.RelationUnitSettings()
+	return &settings
+}
+
 // Suspended returns true if the relation is suspended.
 func (ctx *ContextRelation) Suspended() bool {
 	return ctx.ru.Relation().Suspended()
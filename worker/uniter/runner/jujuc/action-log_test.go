@@ -0,0 +1,105 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type ActionLogSuite struct {
+	ContextSuite
+}
+
+type actionLogContext struct {
+	jujuc.Context
+	logged []string
+}
+
+func (ctx *actionLogContext) LogActionMessage(message string) error {
+	ctx.logged = append(ctx.logged, message)
+	return nil
+}
+
+type nonActionLogContext struct {
+	jujuc.Context
+}
+
+func (ctx *nonActionLogContext) LogActionMessage(message string) error {
+	return fmt.Errorf("not running an action")
+}
+
+var _ = gc.Suite(&ActionLogSuite{})
+
+func (s *ActionLogSuite) TestActionLog(c *gc.C) {
+	var actionLogTests = []struct {
+		summary string
+		command []string
+		logged  []string
+		errMsg  string
+		code    int
+	}{{
+		summary: "no message is an error",
+		command: []string{},
+		errMsg:  "ERROR no message specified\n",
+		code:    2,
+	}, {
+		summary: "a single argument is logged",
+		command: []string{"copying files"},
+		logged:  []string{"copying files"},
+	}, {
+		summary: "multiple arguments are joined",
+		command: []string{"copied", "10", "of", "20", "files"},
+		logged:  []string{"copied 10 of 20 files"},
+	}}
+
+	for i, t := range actionLogTests {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx := &actionLogContext{}
+		com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.command)
+		c.Check(code, gc.Equals, t.code)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.errMsg)
+		c.Check(hctx.logged, jc.DeepEquals, t.logged)
+	}
+}
+
+func (s *ActionLogSuite) TestNonActionLogFails(c *gc.C) {
+	hctx := &nonActionLogContext{}
+	com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{"oops"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR not running an action\n")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+}
+
+func (s *ActionLogSuite) TestHelp(c *gc.C) {
+	hctx, _ := s.NewHookContext()
+	com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{"--help"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, `Usage: action-log <message>
+
+Summary:
+record a progress message for the current action
+
+Details:
+action-log records a progress message for the running action. Messages are
+timestamped and sent to the controller immediately, so they can be followed
+with "juju show-action-output --watch" while the action is still running.
+`)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+}
@@ -85,6 +85,9 @@ type actionHookContext interface {
 
 	// SetActionFailed sets a failure state for the Action.
 	SetActionFailed() error
+
+	// LogActionMessage records a progress message for the Action.
+	LogActionMessage(string) error
 }
 
 // ContextUnit is the part of a hook context related to the unit.
@@ -103,6 +106,17 @@ type ContextUnit interface {
 
 	// CloudSpec returns the unit's cloud specification
 	CloudSpec() (*params.CloudSpec, error)
+
+	// CharmState returns the key/value state stored by the unit's charm.
+	CharmState() (map[string]string, error)
+
+	// SetCharmStateValue sets the value of a key in the unit's charm
+	// state. The change is written when the hook is committed.
+	SetCharmStateValue(key, value string) error
+
+	// DeleteCharmStateValue removes a key from the unit's charm state.
+	// The change is written when the hook is committed.
+	DeleteCharmStateValue(key string) error
 }
 
 // ContextStatus is the part of a hook context related to the unit's status.
@@ -145,14 +159,16 @@ type ContextNetworking interface {
 	// error if it is not available.
 	PrivateAddress() (string, error)
 
-	// OpenPorts marks the supplied port range for opening when the
+	// OpenPorts marks the supplied port range for opening for the
+	// given endpoint, or for all endpoints if it is empty, when the
 	// executing unit's application is exposed.
-	OpenPorts(protocol string, fromPort, toPort int) error
+	OpenPorts(endpoint, protocol string, fromPort, toPort int) error
 
-	// ClosePorts ensures the supplied port range is closed even when
+	// ClosePorts ensures the supplied port range is closed for the
+	// given endpoint, or for all endpoints if it is empty, even when
 	// the executing unit's application is exposed (unless it is opened
 	// separately by a co- located unit).
-	ClosePorts(protocol string, fromPort, toPort int) error
+	ClosePorts(endpoint, protocol string, fromPort, toPort int) error
 
 	// OpenedPorts returns all port ranges currently opened by this
 	// unit on its assigned machine. The result is sorted first by
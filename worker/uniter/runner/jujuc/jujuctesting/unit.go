@@ -18,6 +18,7 @@ type Unit struct {
 	GoalState      application.GoalState
 	ContainerSpec  string
 	CloudSpec      params.CloudSpec
+	CharmState     map[string]string
 }
 
 // ContextUnit is a test double for jujuc.ContextUnit.
@@ -70,3 +71,39 @@ func (c *ContextUnit) CloudSpec() (*params.CloudSpec, error) {
 	c.info.CloudSpec = params.CloudSpec{}
 	return &c.info.CloudSpec, nil
 }
+
+// CharmState implements jujuc.ContextUnit.
+func (c *ContextUnit) CharmState() (map[string]string, error) {
+	c.stub.AddCall("CharmState")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	state := make(map[string]string, len(c.info.CharmState))
+	for k, v := range c.info.CharmState {
+		state[k] = v
+	}
+	return state, nil
+}
+
+// SetCharmStateValue implements jujuc.ContextUnit.
+func (c *ContextUnit) SetCharmStateValue(key, value string) error {
+	c.stub.AddCall("SetCharmStateValue", key, value)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.info.CharmState == nil {
+		c.info.CharmState = make(map[string]string)
+	}
+	c.info.CharmState[key] = value
+	return nil
+}
+
+// DeleteCharmStateValue implements jujuc.ContextUnit.
+func (c *ContextUnit) DeleteCharmStateValue(key string) error {
+	c.stub.AddCall("DeleteCharmStateValue", key)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	delete(c.info.CharmState, key)
+	return nil
+}
@@ -130,6 +130,8 @@ type portCommand struct {
 	Protocol   string
 	FromPort   int
 	ToPort     int
+	Endpoints  []string
+	endpoints  string
 	formatFlag string // deprecated
 }
 
@@ -139,6 +141,7 @@ func (c *portCommand) Info() *cmd.Info {
 
 func (c *portCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
+	f.StringVar(&c.endpoints, "endpoints", "", "a comma-delimited list of application endpoints to target with this operation")
 }
 
 func (c *portCommand) Init(args []string) error {
@@ -154,6 +157,19 @@ func (c *portCommand) Init(args []string) error {
 	c.FromPort = portRange.fromPort
 	c.ToPort = portRange.toPort
 	c.Protocol = portRange.protocol
+
+	// An empty endpoint targets all of the unit's endpoints.
+	c.Endpoints = []string{""}
+	if c.endpoints != "" {
+		c.Endpoints = nil
+		for _, endpoint := range strings.Split(c.endpoints, ",") {
+			endpoint = strings.TrimSpace(endpoint)
+			if endpoint == "" {
+				return errors.Errorf("invalid endpoints %q", c.endpoints)
+			}
+			c.Endpoints = append(c.Endpoints, endpoint)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
@@ -168,14 +184,24 @@ var openPortInfo = &cmd.Info{
 	Name:    "open-port",
 	Args:    portFormat,
 	Purpose: "register a port or range to open",
-	Doc:     "The port range will only be open while the application is exposed.",
+	Doc: `
+The port range will only be open while the application is exposed.
+
+By default, the port range is opened for all of the application's
+endpoints. With --endpoints, it is only opened for the named endpoints,
+and is only accessible from wherever those endpoints are exposed to.`[1:],
 }
 
 func NewOpenPortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
 		info: openPortInfo,
 		action: func(c *portCommand) error {
-			return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
+			for _, endpoint := range c.Endpoints {
+				if err := ctx.OpenPorts(endpoint, c.Protocol, c.FromPort, c.ToPort); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			return nil
 		},
 	}, nil
 }
@@ -184,13 +210,21 @@ var closePortInfo = &cmd.Info{
 	Name:    "close-port",
 	Args:    portFormat,
 	Purpose: "ensure a port or range is always closed",
+	Doc: `
+By default, the port range is closed for all of the application's
+endpoints. With --endpoints, it is only closed for the named endpoints.`[1:],
 }
 
 func NewClosePortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
 		info: closePortInfo,
 		action: func(c *portCommand) error {
-			return ctx.ClosePorts(c.Protocol, c.FromPort, c.ToPort)
+			for _, endpoint := range c.Endpoints {
+				if err := ctx.ClosePorts(endpoint, c.Protocol, c.FromPort, c.ToPort); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			return nil
 		},
 	}, nil
 }
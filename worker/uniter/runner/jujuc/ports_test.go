@@ -136,6 +136,10 @@ register a port or range to open
 
 Details:
 The port range will only be open while the application is exposed.
+
+By default, the port range is opened for all of the application's
+endpoints. With --endpoints, it is only opened for the named endpoints,
+and is only accessible from wherever those endpoints are exposed to.
 `[1:])
 
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
@@ -145,9 +149,36 @@ Usage: close-port <port>[/<protocol>] or <from>-<to>[/<protocol>] or icmp
 
 Summary:
 ensure a port or range is always closed
+
+Details:
+By default, the port range is closed for all of the application's
+endpoints. With --endpoints, it is only closed for the named endpoints.
 `[1:])
 }
 
+func (s *PortsSuite) TestOpenCloseEndpoints(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	for _, name := range []string{"open-port", "close-port"} {
+		com, err := jujuc.NewCommand(hctx, cmdString(name))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{"--endpoints", "website, admin", "80"})
+		c.Assert(code, gc.Equals, 0)
+	}
+	s.Stub.CheckCallNames(c, "OpenPorts", "OpenPorts", "ClosePorts", "ClosePorts")
+	s.Stub.CheckCall(c, 0, "OpenPorts", "website", "tcp", 80, 80)
+	s.Stub.CheckCall(c, 1, "OpenPorts", "admin", "tcp", 80, 80)
+	s.Stub.CheckCall(c, 3, "ClosePorts", "admin", "tcp", 80, 80)
+}
+
+func (s *PortsSuite) TestBadEndpoints(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("open-port"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(jujuc.NewJujucCommandWrappedForTest(com), []string{"--endpoints", "website,", "80"})
+	c.Assert(err, gc.ErrorMatches, `invalid endpoints "website,"`)
+}
+
 // Since the deprecation warning gets output during Run, we really need
 // some valid commands to run
 var portsFormatDeprectaionTests = []struct {
@@ -45,6 +45,17 @@ func (c *RestrictedContext) CloudSpec() (*params.CloudSpec, error) {
 	return nil, ErrRestrictedContext
 }
 
+// CharmState implements hooks.Context.
+func (*RestrictedContext) CharmState() (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// SetCharmStateValue implements hooks.Context.
+func (*RestrictedContext) SetCharmStateValue(string, string) error { return ErrRestrictedContext }
+
+// DeleteCharmStateValue implements hooks.Context.
+func (*RestrictedContext) DeleteCharmStateValue(string) error { return ErrRestrictedContext }
+
 // SetUnitStatus implements hooks.Context.
 func (*RestrictedContext) SetUnitStatus(StatusInfo) error { return ErrRestrictedContext }
 
@@ -73,12 +84,12 @@ func (*RestrictedContext) PublicAddress() (string, error) { return "", ErrRestri
 func (*RestrictedContext) PrivateAddress() (string, error) { return "", ErrRestrictedContext }
 
 // OpenPorts implements hooks.Context.
-func (*RestrictedContext) OpenPorts(protocol string, fromPort, toPort int) error {
+func (*RestrictedContext) OpenPorts(endpoint, protocol string, fromPort, toPort int) error {
 	return ErrRestrictedContext
 }
 
 // ClosePorts implements hooks.Context.
-func (*RestrictedContext) ClosePorts(protocol string, fromPort, toPort int) error {
+func (*RestrictedContext) ClosePorts(endpoint, protocol string, fromPort, toPort int) error {
 	return ErrRestrictedContext
 }
 
@@ -159,6 +170,9 @@ func (*RestrictedContext) SetActionMessage(string) error { return ErrRestrictedC
 // SetActionFailed implements hooks.Context.
 func (*RestrictedContext) SetActionFailed() error { return ErrRestrictedContext }
 
+// LogActionMessage implements hooks.Context.
+func (*RestrictedContext) LogActionMessage(string) error { return ErrRestrictedContext }
+
 // Component implements jujc.Context.
 func (*RestrictedContext) Component(string) (ContextComponent, error) {
 	return nil, ErrRestrictedContext
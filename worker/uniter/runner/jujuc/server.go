@@ -54,6 +54,7 @@ var baseCommands = map[string]creator{
 	"action-get" + cmdSuffix:              NewActionGetCommand,
 	"action-set" + cmdSuffix:              NewActionSetCommand,
 	"action-fail" + cmdSuffix:             NewActionFailCommand,
+	"action-log" + cmdSuffix:              NewActionLogCommand,
 	"relation-ids" + cmdSuffix:            NewRelationIdsCommand,
 	"relation-list" + cmdSuffix:           NewRelationListCommand,
 	"relation-set" + cmdSuffix:            NewRelationSetCommand,
@@ -67,6 +68,9 @@ var baseCommands = map[string]creator{
 	"pod-spec-set" + cmdSuffix:            NewPodSpecSetCommand,
 	"goal-state" + cmdSuffix:              NewGoalStateCommand,
 	"credential-get" + cmdSuffix:          NewCredentialGetCommand,
+	"state-get" + cmdSuffix:               NewStateGetCommand,
+	"state-set" + cmdSuffix:               NewStateSetCommand,
+	"state-delete" + cmdSuffix:            NewStateDeleteCommand,
 }
 
 var storageCommands = map[string]creator{
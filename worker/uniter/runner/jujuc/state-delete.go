@@ -0,0 +1,53 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+// stateDeleteCommand implements the state-delete command.
+type stateDeleteCommand struct {
+	cmd.CommandBase
+	ctx Context
+	key string
+}
+
+// NewStateDeleteCommand returns a new stateDeleteCommand with the given context.
+func NewStateDeleteCommand(ctx Context) (cmd.Command, error) {
+	return &stateDeleteCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *stateDeleteCommand) Info() *cmd.Info {
+	doc := `
+state-delete removes a key from the unit's charm state, which is stored by the
+controller. The change is written when the hook completes successfully.
+Deleting a key which does not exist is not an error.
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "state-delete",
+		Args:    "<key>",
+		Purpose: "delete unit charm state",
+		Doc:     doc,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *stateDeleteCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no key specified")
+	}
+	c.key = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *stateDeleteCommand) Run(_ *cmd.Context) error {
+	err := c.ctx.DeleteCharmStateValue(c.key)
+	return errors.Annotatef(err, "cannot delete charm state")
+}
@@ -0,0 +1,60 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type stateDeleteSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&stateDeleteSuite{})
+
+func (s *stateDeleteSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CharmState = map[string]string{"key": "value", "other": "thing"}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("state-delete"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, jujuc.NewJujucCommandWrappedForTest(com)
+}
+
+func (s *stateDeleteSuite) TestInitEmpty(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	err := com.Init(nil)
+	c.Assert(err, gc.ErrorMatches, "no key specified")
+}
+
+func (s *stateDeleteSuite) TestInitTooManyArgs(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	err := com.Init([]string{"key", "other"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["other"\]`)
+}
+
+func (s *stateDeleteSuite) TestDelete(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"key"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.CharmState, jc.DeepEquals, map[string]string{"other": "thing"})
+}
+
+func (s *stateDeleteSuite) TestDeleteError(c *gc.C) {
+	hctx, com := s.createCommand(c, errors.New("zap"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"key"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot delete charm state: zap\n")
+	c.Check(hctx.info.CharmState, jc.DeepEquals, map[string]string{"key": "value", "other": "thing"})
+}
@@ -0,0 +1,72 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type stateGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&stateGetSuite{})
+
+func (s *stateGetSuite) createCommand(c *gc.C, err error) cmd.Command {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CharmState = map[string]string{
+		"key":   "value",
+		"other": "thing",
+	}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("state-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return jujuc.NewJujucCommandWrappedForTest(com)
+}
+
+func (s *stateGetSuite) TestInitError(c *gc.C) {
+	err := s.createCommand(c, nil).Init([]string{"x=x"})
+	c.Assert(err, gc.ErrorMatches, `invalid key "x=x"`)
+}
+
+func (s *stateGetSuite) TestInitTooManyArgs(c *gc.C) {
+	err := s.createCommand(c, nil).Init([]string{"x", "y"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["y"\]`)
+}
+
+func (s *stateGetSuite) TestGetKey(c *gc.C) {
+	s.testOutput(c, []string{"key"}, "value\n")
+}
+
+func (s *stateGetSuite) TestGetMissingKey(c *gc.C) {
+	s.testOutput(c, []string{"unknown"}, "")
+}
+
+func (s *stateGetSuite) TestGetAll(c *gc.C) {
+	s.testOutput(c, []string{"--format", "yaml"}, "key: value\nother: thing\n")
+	s.testOutput(c, []string{"--format", "yaml", "-"}, "key: value\nother: thing\n")
+}
+
+func (s *stateGetSuite) TestGetError(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(s.createCommand(c, errors.New("zap")), ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot read charm state: zap\n")
+}
+
+func (s *stateGetSuite) testOutput(c *gc.C, args []string, expect string) {
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(s.createCommand(c, nil), ctx, args)
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, expect)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+}
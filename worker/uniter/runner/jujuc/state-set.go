@@ -0,0 +1,59 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+// stateSetCommand implements the state-set command.
+type stateSetCommand struct {
+	cmd.CommandBase
+	ctx    Context
+	values map[string]string
+}
+
+// NewStateSetCommand returns a new stateSetCommand with the given context.
+func NewStateSetCommand(ctx Context) (cmd.Command, error) {
+	return &stateSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *stateSetCommand) Info() *cmd.Info {
+	doc := `
+state-set sets the supplied key/value pairs in the unit's charm state, which
+is stored by the controller. The changes are written when the hook completes
+successfully, and are discarded if the hook fails. The combined size of all
+keys and values in the unit's charm state is limited to 64KiB.
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "state-set",
+		Args:    "<key>=<value> [...]",
+		Purpose: "set unit charm state",
+		Doc:     doc,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *stateSetCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no key/value pairs specified")
+	}
+	c.values, err = keyvalues.Parse(args, true)
+	return
+}
+
+// Run is part of the cmd.Command interface.
+func (c *stateSetCommand) Run(_ *cmd.Context) error {
+	for key, value := range c.values {
+		if err := c.ctx.SetCharmStateValue(key, value); err != nil {
+			return errors.Annotatef(err, "cannot set charm state")
+		}
+	}
+	return nil
+}
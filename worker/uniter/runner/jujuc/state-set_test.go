@@ -0,0 +1,63 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type stateSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&stateSetSuite{})
+
+func (s *stateSetSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CharmState = map[string]string{"key": "value"}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("state-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, jujuc.NewJujucCommandWrappedForTest(com)
+}
+
+func (s *stateSetSuite) TestInitEmpty(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	err := com.Init(nil)
+	c.Assert(err, gc.ErrorMatches, "no key/value pairs specified")
+}
+
+func (s *stateSetSuite) TestInitError(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	err := com.Init([]string{"nonsense"})
+	c.Assert(err, gc.ErrorMatches, `expected "key=value", got "nonsense"`)
+}
+
+func (s *stateSetSuite) TestSetValues(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"key=new", "foo=bar=baz"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.CharmState, jc.DeepEquals, map[string]string{
+		"key": "new",
+		"foo": "bar=baz",
+	})
+}
+
+func (s *stateSetSuite) TestSetError(c *gc.C) {
+	hctx, com := s.createCommand(c, errors.New("zap"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"key=new"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot set charm state: zap\n")
+	c.Check(hctx.info.CharmState, jc.DeepEquals, map[string]string{"key": "value"})
+}
@@ -4,11 +4,15 @@
 package runner
 
 import (
+	"bytes"
 	"encoding/base64"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 	"unicode/utf8"
 
@@ -136,7 +140,14 @@ func (runner *runner) runJujuRunAction() (err error) {
 		logger.Debugf("unable to read juju-run action timeout, will continue running action without one")
 	}
 
-	results, err := runner.runCommandsWithTimeout(command, time.Duration(timeout), clock.WallClock)
+	// Streaming the output relies on running the commands with bash,
+	// so on Windows the output is only reported when they complete.
+	var results *utilexec.ExecResponse
+	if stream, _ := params["stream-output"].(bool); stream && jujuos.HostOS() != jujuos.Windows {
+		results, err = runner.runCommandsStreamingOutput(command, time.Duration(timeout), clock.WallClock)
+	} else {
+		results, err = runner.runCommandsWithTimeout(command, time.Duration(timeout), clock.WallClock)
+	}
 
 	if err != nil {
 		return runner.context.Flush("juju-run", err)
@@ -149,6 +160,196 @@ func (runner *runner) runJujuRunAction() (err error) {
 	return runner.context.Flush("juju-run", nil)
 }
 
+// runCommandsStreamingOutput runs the commands like runCommandsWithTimeout,
+// additionally logging each line of their output as an action message as
+// soon as it is written, so that clients may follow the output of a
+// juju-run action while it runs.
+func (runner *runner) runCommandsStreamingOutput(commands string, timeout time.Duration, clock clock.Clock) (*utilexec.ExecResponse, error) {
+	srv, err := runner.startJujucServer()
+	if err != nil {
+		return nil, err
+	}
+	defer srv.Close()
+
+	env, err := runner.context.HookVars(runner.paths)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stdout := newActionOutputWriter(runner.context, actions.JujuRunStdoutPrefix)
+	stderr := newActionOutputWriter(runner.context, actions.JujuRunStderrPrefix)
+	ps := exec.Command("/bin/bash", "-s")
+	ps.Env = env
+	ps.Dir = runner.paths.GetCharmDir()
+	ps.Stdin = strings.NewReader(commands)
+	ps.Stdout = stdout
+	ps.Stderr = stderr
+	// Run the commands in their own process group, so that any
+	// processes they start are killed along with them on timeout.
+	setProcessGroup(ps)
+	if err := ps.Start(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	runner.context.SetProcess(hookProcess{ps.Process})
+
+	done := make(chan struct{})
+	killed := make(chan struct{})
+	if timeout != 0 {
+		go func() {
+			select {
+			case <-clock.After(timeout):
+				if err := killProcessGroup(ps.Process); err != nil {
+					logger.Warningf("cannot kill juju-run process: %v", err)
+				}
+				close(killed)
+			case <-done:
+			}
+		}()
+	}
+	flushed := make(chan struct{})
+	go func() {
+		defer close(flushed)
+		for {
+			select {
+			case <-clock.After(actionOutputFlushInterval):
+				stdout.flush()
+				stderr.flush()
+			case <-done:
+				return
+			}
+		}
+	}()
+	err = ps.Wait()
+	close(done)
+	<-flushed
+	stdout.close()
+	stderr.close()
+	select {
+	case <-killed:
+		return nil, utilexec.ErrCancelled
+	default:
+	}
+
+	var code int
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			code = status.ExitStatus()
+		}
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &utilexec.ExecResponse{
+		Code:   code,
+		Stdout: stdout.output.Bytes(),
+		Stderr: stderr.output.Bytes(),
+	}, nil
+}
+
+const (
+	// actionOutputFlushInterval is the longest time for which lines of
+	// juju-run output are buffered before being logged.
+	actionOutputFlushInterval = time.Second
+
+	// actionOutputBatchSize is the size of the buffered juju-run output
+	// at which it is logged without waiting for the flush interval.
+	actionOutputBatchSize = 4 * 1024
+)
+
+// maxActionOutputLogged is the amount of each juju-run output stream
+// logged as action messages. Any output beyond it is only reported when
+// the action completes.
+var maxActionOutputLogged = 64 * 1024
+
+// actionOutputWriter records the output of a juju-run action, logging
+// complete lines in batches as action messages with the given prefix.
+// Each message holds one or more lines separated by newlines.
+type actionOutputWriter struct {
+	context Context
+	prefix  string
+
+	mu      sync.Mutex
+	output  bytes.Buffer
+	line    []byte
+	pending []string
+	size    int
+	logged  int
+	full    bool
+}
+
+func newActionOutputWriter(context Context, prefix string) *actionOutputWriter {
+	return &actionOutputWriter{context: context, prefix: prefix}
+}
+
+// Write is part of the io.Writer interface.
+func (w *actionOutputWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.output.Write(p)
+	if w.full {
+		return len(p), nil
+	}
+	w.line = append(w.line, p...)
+	for {
+		i := bytes.IndexByte(w.line, '\n')
+		if i < 0 {
+			break
+		}
+		w.add(string(w.line[:i]))
+		w.line = w.line[i+1:]
+	}
+	if w.size >= actionOutputBatchSize {
+		w.log()
+	}
+	return len(p), nil
+}
+
+// flush logs any buffered lines of output.
+func (w *actionOutputWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.log()
+}
+
+// close logs any buffered lines of output, including an incomplete
+// final line.
+func (w *actionOutputWriter) close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.line) > 0 && !w.full {
+		w.add(string(w.line))
+		w.line = nil
+	}
+	w.log()
+}
+
+// add buffers a line of output to be logged, unless doing so would take
+// the output logged past maxActionOutputLogged.
+func (w *actionOutputWriter) add(line string) {
+	line = strings.TrimSuffix(line, "\r")
+	if w.logged+w.size+len(line)+1 > maxActionOutputLogged {
+		logger.Debugf("juju-run output exceeds %d bytes, not logging any more", maxActionOutputLogged)
+		w.full = true
+		w.line = nil
+		return
+	}
+	w.pending = append(w.pending, line)
+	w.size += len(line) + 1
+}
+
+func (w *actionOutputWriter) log() {
+	if len(w.pending) == 0 {
+		return
+	}
+	// Failing to stream the output isn't fatal; it is
+	// reported in full when the action completes.
+	message := w.prefix + strings.Join(w.pending, "\n")
+	if err := w.context.LogActionMessage(message); err != nil {
+		logger.Warningf("cannot log juju-run output: %v", err)
+	}
+	w.logged += w.size
+	w.pending = nil
+	w.size = 0
+}
+
 func encodeBytes(input []byte) (value string, encoding string) {
 	if utf8.Valid(input) {
 		value = string(input)
@@ -8,7 +8,9 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -156,6 +158,9 @@ type MockContext struct {
 	flushBadge      string
 	flushFailure    error
 	flushResult     error
+
+	mu             sync.Mutex
+	actionMessages []string
 }
 
 func (ctx *MockContext) UnitName() string {
@@ -198,6 +203,13 @@ func (ctx *MockContext) UpdateActionResults(keys []string, value string) error {
 	return nil
 }
 
+func (ctx *MockContext) LogActionMessage(message string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.actionMessages = append(ctx.actionMessages, message)
+	return nil
+}
+
 type RunMockContextSuite struct {
 	envtesting.IsolationSuite
 	paths runnertesting.RealPaths
@@ -318,6 +330,98 @@ func (s *RunMockContextSuite) TestRunActionSuccessful(c *gc.C) {
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, "")
 }
 
+func (s *RunMockContextSuite) TestRunActionStreamsOutput(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("juju-run output is not streamed on windows")
+	}
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+		actionParams: map[string]interface{}{
+			"command":       "echo one; echo two >&2; printf three",
+			"timeout":       0,
+			"stream-output": true,
+		},
+		actionResults: map[string]interface{}{},
+	}
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "juju-run")
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(ctx.actionResults["Code"], gc.Equals, "0")
+	c.Assert(ctx.actionResults["Stdout"], gc.Equals, "one\nthree")
+	c.Assert(ctx.actionResults["Stderr"], gc.Equals, "two\n")
+	sort.Strings(ctx.actionMessages)
+	c.Assert(ctx.actionMessages, jc.DeepEquals, []string{
+		"stderr: two",
+		"stdout: one\nthree",
+	})
+}
+
+func (s *RunMockContextSuite) TestRunActionStreamsLimitedOutput(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("juju-run output is not streamed on windows")
+	}
+	s.PatchValue(runner.MaxActionOutputLogged, 8)
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+		actionParams: map[string]interface{}{
+			"command":       "echo one; echo two; echo three",
+			"timeout":       0,
+			"stream-output": true,
+		},
+		actionResults: map[string]interface{}{},
+	}
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(ctx.actionResults["Stdout"], gc.Equals, "one\ntwo\nthree\n")
+	c.Assert(ctx.actionMessages, jc.DeepEquals, []string{"stdout: one\ntwo"})
+}
+
+func (s *RunMockContextSuite) TestRunActionStreamsOutputExitCode(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("juju-run output is not streamed on windows")
+	}
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+		actionParams: map[string]interface{}{
+			"command":       "echo failing; exit 3",
+			"timeout":       0,
+			"stream-output": true,
+		},
+		actionResults: map[string]interface{}{},
+	}
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(ctx.actionResults["Code"], gc.Equals, "3")
+	c.Assert(ctx.actionMessages, jc.DeepEquals, []string{"stdout: failing"})
+}
+
+func (s *RunMockContextSuite) TestRunActionStreamsOutputCancelled(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("juju-run output is not streamed on windows")
+	}
+	timeout := 1 * time.Nanosecond
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+		actionParams: map[string]interface{}{
+			// The sleep is a child of the shell, and holds its
+			// output open unless it is killed too.
+			"command":       "sleep 10; echo done",
+			"timeout":       float64(timeout.Nanoseconds()),
+			"stream-output": true,
+		},
+		actionResults: map[string]interface{}{},
+	}
+	start := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Since(start) < 10*time.Second, jc.IsTrue)
+	c.Assert(ctx.flushFailure, gc.Equals, exec.ErrCancelled)
+	c.Assert(ctx.actionResults["Code"], gc.Equals, nil)
+}
+
 func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
 	timeout := 1 * time.Nanosecond
 	ctx := &MockContext{
@@ -136,6 +136,7 @@ func (a *Attachments) init() error {
 				tag:      storageTag,
 				kind:     storage.StorageKind(attachment.Kind),
 				location: attachment.Location,
+				size:     attachment.Size,
 			},
 		}
 	}
@@ -209,7 +210,14 @@ func (a *Attachments) CommitHook(hi hook.Info) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if err := storageState.CommitHook(hi); err != nil {
+	// Record the size of the storage as it was reported
+	// to the hook, so we know when it grows again.
+	size := storageState.size
+	attachment := a.storageAttachments[names.NewStorageTag(hi.StorageId)]
+	if ctx, ok := attachment.ContextStorageAttachment.(*contextStorage); ok && ctx.size > 0 {
+		size = ctx.size
+	}
+	if err := storageState.commitHook(hi, size); err != nil {
 		return err
 	}
 	storageTag := names.NewStorageTag(hi.StorageId)
@@ -234,7 +242,7 @@ func (a *Attachments) removeStorageAttachment(tag names.StorageTag) error {
 }
 
 func (a *Attachments) storageStateForHook(hi hook.Info) (*stateFile, error) {
-	if !hi.Kind.IsStorage() {
+	if !hook.IsStorage(hi.Kind) {
 		return nil, errors.Errorf("not a storage hook: %#v", hi)
 	}
 	storageAttachment, ok := a.storageAttachments[names.NewStorageTag(hi.StorageId)]
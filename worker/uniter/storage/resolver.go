@@ -144,8 +144,23 @@ func (s *storageResolver) nextHookOp(
 		storageAttachment, ok := s.storage.storageAttachments[tag]
 		if ok && storageAttachment.attached {
 			// Once the storage is attached, we only care about
-			// lifecycle state changes.
-			return nil, resolver.ErrNoOperation
+			// lifecycle state changes and growth of the storage.
+			if snap.Size == 0 || snap.Size == storageAttachment.size {
+				return nil, resolver.ErrNoOperation
+			}
+			if storageAttachment.size == 0 || snap.Size < storageAttachment.size {
+				// The size was not recorded when the storage was
+				// attached, or the storage has not grown; record
+				// the current size without running a hook.
+				if err := storageAttachment.SetSize(snap.Size); err != nil {
+					return nil, errors.Trace(err)
+				}
+				return nil, resolver.ErrNoOperation
+			}
+			// The storage has grown since we last reported its
+			// size to the charm, so run the "storage-resized" hook.
+			hookInfo.Kind = hook.StorageResized
+			break
 		}
 		// The storage-attached hook has not been committed, so add the
 		// storage to the pending set.
@@ -183,6 +198,7 @@ func (s *storageResolver) nextHookOp(
 			tag:      tag,
 			kind:     storage.StorageKind(snap.Kind),
 			location: snap.Location,
+			size:     snap.Size,
 		},
 	}
 
@@ -25,6 +25,10 @@ type state struct {
 	// attached records the uniter's knowledge of the
 	// storage attachment state.
 	attached bool
+
+	// size records the size of the storage, in MiB, as
+	// last reported to the charm by a committed hook.
+	size uint64
 }
 
 // ValidateHook returns an error if the supplied hook.Info does not represent
@@ -41,7 +45,7 @@ func (s *state) ValidateHook(hi hook.Info) (err error) {
 		if s.attached {
 			return errors.New("storage already attached")
 		}
-	case hooks.StorageDetaching:
+	case hooks.StorageDetaching, hook.StorageResized:
 		if !s.attached {
 			return errors.New("storage not attached")
 		}
@@ -85,6 +89,7 @@ func readStateFile(dirPath string, tag names.StorageTag) (d *stateFile, err erro
 		return nil, errors.Errorf("invalid storage state file %q: missing 'attached'", d.path)
 	}
 	d.state.attached = *info.Attached
+	d.state.size = info.Size
 	return d, nil
 }
 
@@ -130,18 +135,37 @@ func readAllStateFiles(dirPath string) (files map[names.StorageTag]*stateFile, e
 // It must be called after the respective hook was executed successfully.
 // CommitHook doesn't validate hi but guarantees that successive writes
 // of the same hi are idempotent.
-func (d *stateFile) CommitHook(hi hook.Info) (err error) {
+func (d *stateFile) CommitHook(hi hook.Info) error {
+	return d.commitHook(hi, d.state.size)
+}
+
+// commitHook is like CommitHook, but also records the size of the
+// storage as reported to the charm by the hook.
+func (d *stateFile) commitHook(hi hook.Info, size uint64) (err error) {
 	defer errors.DeferredAnnotatef(&err, "failed to write %q hook info for %q on state directory", hi.Kind, hi.StorageId)
 	if hi.Kind == hooks.StorageDetaching {
 		return d.Remove()
 	}
+	return d.write(size)
+}
+
+// SetSize records the size of the storage without running a hook.
+// This is used to establish a baseline for storage attached by an
+// older agent, which did not record the storage size.
+func (d *stateFile) SetSize(size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "failed to write size for %q on state directory", d.storage.Id())
+	return d.write(size)
+}
+
+func (d *stateFile) write(size uint64) error {
 	attached := true
-	di := diskInfo{&attached}
+	di := diskInfo{Attached: &attached, Size: size}
 	if err := utils.WriteYaml(d.path, &di); err != nil {
 		return err
 	}
 	// If write was successful, update own state.
 	d.state.attached = true
+	d.state.size = size
 	return nil
 }
 
@@ -152,10 +176,12 @@ func (d *stateFile) Remove() error {
 	}
 	// If atomic delete succeeded, update own state.
 	d.state.attached = false
+	d.state.size = 0
 	return nil
 }
 
 // diskInfo defines the storage attachment data serialization.
 type diskInfo struct {
-	Attached *bool `yaml:"attached,omitempty"`
+	Attached *bool  `yaml:"attached,omitempty"`
+	Size     uint64 `yaml:"size,omitempty"`
 }
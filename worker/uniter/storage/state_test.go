@@ -112,6 +112,25 @@ func (s *stateSuite) TestReadStateFileFileNotExist(c *gc.C) {
 	c.Assert(string(data), gc.Equals, "attached: true\n")
 }
 
+func (s *stateSuite) TestReadStateFileSize(c *gc.C) {
+	dir := c.MkDir()
+	writeFile(c, filepath.Join(dir, "data-0"), "attached: true\nsize: 1024\n")
+	state, err := storage.ReadStateFile(dir, names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage.StateAttached(state), jc.IsTrue)
+	c.Assert(storage.StateSize(state), gc.Equals, uint64(1024))
+
+	// Committing a hook preserves the recorded size.
+	err = state.CommitHook(hook.Info{
+		Kind:      hook.StorageResized,
+		StorageId: "data/0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadFile(filepath.Join(dir, "data-0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "attached: true\nsize: 1024\n")
+}
+
 func (s *stateSuite) TestReadStateFileDirNotExist(c *gc.C) {
 	dir := filepath.Join(c.MkDir(), "doesnotexist")
 	state, err := storage.ReadStateFile(dir, names.NewStorageTag("data/0"))
@@ -195,6 +214,8 @@ func (s *stateSuite) TestValidateHook(c *gc.C) {
 
 	assertValidates(false, hooks.StorageAttached)
 	assertValidates(true, hooks.StorageDetaching)
+	assertValidates(true, hook.StorageResized)
 	assertValidateFails(false, hooks.StorageDetaching, `inappropriate "storage-detaching" hook for storage "data/0": storage not attached`)
 	assertValidateFails(true, hooks.StorageAttached, `inappropriate "storage-attached" hook for storage "data/0": storage already attached`)
+	assertValidateFails(false, hook.StorageResized, `inappropriate "storage-resized" hook for storage "data/0": storage not attached`)
 }